@@ -0,0 +1,32 @@
+package null
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// JSONAppender is implemented by the types whose MarshalJSON has an
+// allocation-free counterpart. AppendJSON appends the JSON encoding that
+// MarshalJSON returns to b, null included, and returns the extended
+// buffer, as encoding.TextAppender does for MarshalText. Encoders that
+// reuse a buffer can check for it to avoid an allocation per value.
+type JSONAppender interface {
+	AppendJSON(b []byte) ([]byte, error)
+}
+
+// appendJSONString appends s to b as a quoted JSON string, byte for byte as
+// json.Marshal encodes it. Strings of printable ASCII that encoding/json
+// does not escape, the common case, are appended as they are; others go
+// through json.Marshal, so HTML characters, control characters and invalid
+// UTF-8 are escaped exactly as it escapes them.
+func appendJSONString[S ~string | ~[]byte](b []byte, s S) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			data, _ := json.Marshal(string(s))
+			return append(b, data...)
+		}
+	}
+	b = append(b, '"')
+	b = append(b, s...)
+	return append(b, '"')
+}
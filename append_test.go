@@ -0,0 +1,189 @@
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// appendSamples returns the values of binaryStates and some whose
+// encodings need escaping or take other paths.
+func appendSamples() []interface{} {
+	var samples []interface{}
+	for _, states := range binaryStates() {
+		samples = append(samples, states[:]...)
+	}
+	u, _ := url.Parse("https://example.com/a?b=<c>&d=é")
+	return append(samples,
+		StringFrom(""), StringFrom(`<a href="x">&amp;</a>`), StringFrom("tab\t nul\x00 us\x1f del\x7f"),
+		StringFrom("line para "), StringFrom("bad \xff\xfe utf-8"), StringFrom(`back\slash "quote"`),
+		StringFrom("日本語 🎉"), RawBytesFrom([]byte("<é>")), RawBytesFrom([]byte("\xff")),
+		ByteFrom(0), ByteFrom('"'), ByteFrom('<'), ByteFrom('\n'), ByteFrom(0x7f), ByteFrom(0xff),
+		RuneFrom('<'), RuneFrom(' '), RuneFrom(0), RuneFrom(utf8.MaxRune), RuneFrom(-1), RuneFrom(0xd800),
+		BytesFrom([]byte{}), BytesFrom([]byte{0, 1, 2, 0xff}), HexBytesFrom([]byte{}), JSONFrom([]byte(" \n")),
+		JSONFrom([]byte(`[1, 2]`)), BigIntFrom(big.NewInt(-1234567890123)), BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 200)),
+		IP{Valid: true}, IPFrom(netip.MustParseAddr("fe80::1%eth0")), IPFrom(netip.MustParseAddr("::ffff:1.2.3.4")),
+		CIDR{Valid: true}, CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")),
+		DecimalFrom("-0.000123"), DurationFrom(0), DurationFrom(-1500*time.Microsecond), DurationFrom(math.MaxInt64),
+		DurationFrom(1234567891*time.Microsecond), TimeOfDayFrom(0), TimeOfDayFrom(23*time.Hour+59*time.Minute+500*time.Millisecond),
+		TimeOfDayFrom(time.Nanosecond), TimeOfDayFrom(10*time.Hour+100*time.Nanosecond), TimeOfDay{TimeOfDay: -time.Second - 10, Valid: true},
+		TimeFrom(time.Date(1, 2, 3, 4, 5, 6, 7, time.FixedZone("", 3600))), DateOf(2024, 2, 29),
+		EnumFrom[testColor](""), EnumFrom[testColor]("<b>"), URLFrom(u), URLFrom(&url.URL{}),
+		Float64From(math.NaN()), Float64From(math.Inf(-1)), Float64From(1e-7), Float32From(1e21), Float64From(-0.0),
+		MoneyFrom(Amount{Minor: 5, Currency: "JPY"}), MoneyFrom(Amount{Minor: 1, Currency: "XXX"}),
+		SecretFrom(testSecret), NewSecret("", false),
+	)
+}
+
+// checkAppend checks that append, called on a buffer holding a prefix,
+// appends what marshal returns, and that both fail alike.
+func checkAppend(t *testing.T, v interface{}, method string, marshal func() ([]byte, error), appendTo func([]byte) ([]byte, error)) {
+	t.Helper()
+	want, wantErr := marshal()
+	got, err := appendTo([]byte("x="))
+	if (err != nil) != (wantErr != nil) || err != nil && err.Error() != wantErr.Error() {
+		t.Errorf("%#v.%s() error = %v, want %v", v, method, err, wantErr)
+		return
+	}
+	if err == nil && string(got) != "x="+string(want) {
+		t.Errorf("%#v.%s() = %q, want %q", v, method, got, "x="+string(want))
+	}
+}
+
+func TestAppendMatchesMarshal(t *testing.T) {
+	knobs := []struct {
+		name string
+		set  func() func()
+	}{
+		{"defaults", func() func() { return func() {} }},
+		{"StringJSONEmptyNull", func() func() {
+			StringJSONEmptyNull = true
+			return func() { StringJSONEmptyNull = false }
+		}},
+		{"ByteJSONNumber", func() func() {
+			ByteJSONNumber = true
+			return func() { ByteJSONNumber = false }
+		}},
+		{"Int64JSONString", func() func() {
+			Int64JSONString = true
+			return func() { Int64JSONString = false }
+		}},
+		{"DecimalJSONString", func() func() {
+			old := DecimalJSONString
+			DecimalJSONString = !old
+			return func() { DecimalJSONString = old }
+		}},
+		{"TimeValueUTC", func() func() {
+			TimeValueUTC, TimeTruncate, TimeFormat = true, true, time.DateTime
+			return func() { TimeValueUTC, TimeTruncate, TimeFormat = false, false, time.RFC3339Nano }
+		}},
+		{"SecretJSONNull", func() func() {
+			SecretJSONAs = SecretJSONNull
+			return func() { SecretJSONAs = SecretJSONPlaceholder }
+		}},
+		{"SecretPlaceholder", func() func() {
+			SecretPlaceholder = "<redacted>"
+			return func() { SecretPlaceholder = "***" }
+		}},
+	}
+	for _, knob := range knobs {
+		reset := knob.set()
+		for _, v := range appendSamples() {
+			if m, ok := v.(encoding.TextMarshaler); ok {
+				a, ok := v.(encoding.TextAppender)
+				if !ok {
+					t.Errorf("%T has MarshalText but no AppendText", v)
+					continue
+				}
+				checkAppend(t, v, "AppendText", m.MarshalText, a.AppendText)
+			}
+			if a, ok := v.(JSONAppender); ok {
+				checkAppend(t, v, "AppendJSON", v.(json.Marshaler).MarshalJSON, a.AppendJSON)
+			} else if _, ok := v.(encoding.TextAppender); ok && !strings.HasPrefix(fmt.Sprintf("%T", v), "null.Validated[") {
+				t.Errorf("%T has AppendText but no AppendJSON", v)
+			}
+		}
+		reset()
+	}
+}
+
+func TestAppendJSONString(t *testing.T) {
+	for _, s := range []string{
+		"", "plain ascii", "<>&", `"\`, "\x00\x01\b\f\n\r\t\x1f", "\x7f", "é", "  ", "\xff", "a\xc3", "🎉\xed\xa0\x80",
+	} {
+		want, err := json.Marshal(s)
+		maybePanic(err)
+		if got := appendJSONString([]byte("x"), s); string(got) != "x"+string(want) {
+			t.Errorf("appendJSONString(%q) = %s, want x%s", s, got, want)
+		}
+		if got := appendJSONString(nil, []byte(s)); string(got) != string(want) {
+			t.Errorf("appendJSONString([]byte(%q)) = %s, want %s", s, got, want)
+		}
+	}
+}
+
+// appendBenchValues are the values of the append benchmarks.
+var appendBenchValues = []interface{}{
+	StringFrom("a metric label"), Int64From(-1234567), Float64From(0.25), BoolFrom(true),
+	TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 123456000, time.UTC)), DateOf(2012, 12, 21),
+	DurationFrom(1500 * time.Millisecond), TimeOfDayFrom(13*time.Hour + 500*time.Millisecond),
+	UUIDFrom([16]byte{0xde, 0xad, 0xbe, 0xef}), IPFrom(netip.MustParseAddr("192.168.1.1")),
+	DecimalFrom("12.50"), BigIntFrom(big.NewInt(1e15)), EnumFrom[testColor]("red"), HexBytesFrom([]byte{1, 2, 3, 4}),
+	MoneyFrom(Amount{Minor: 1234, Currency: "EUR"}),
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	for _, v := range appendBenchValues {
+		m, a := v.(encoding.TextMarshaler), v.(encoding.TextAppender)
+		b.Run(fmt.Sprintf("%T/MarshalText", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.MarshalText(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%T/AppendText", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var err error
+				if buf, err = a.AppendText(buf[:0]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAppendJSON(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	for _, v := range appendBenchValues {
+		m, a := v.(json.Marshaler), v.(JSONAppender)
+		b.Run(fmt.Sprintf("%T/MarshalJSON", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.MarshalJSON(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%T/AppendJSON", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var err error
+				if buf, err = a.AppendJSON(buf[:0]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
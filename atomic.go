@@ -0,0 +1,120 @@
+package null
+
+import (
+	"database/sql/driver"
+	"sync"
+)
+
+// AtomicInt64 holds an Int64 that is safe for concurrent use, such as an
+// optional quota read on every request and updated from many goroutines.
+// The zero value is unset and ready to use. An AtomicInt64 must not be
+// copied after first use, so its methods, the JSON and SQL ones included,
+// have pointer receivers.
+type AtomicInt64 struct {
+	mu sync.Mutex
+	v  Int64
+}
+
+// Load returns the current value.
+func (a *AtomicInt64) Load() Int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.v
+}
+
+// Store sets the value to v.
+func (a *AtomicInt64) Store(v Int64) {
+	a.mu.Lock()
+	a.v = v
+	a.mu.Unlock()
+}
+
+// Swap sets the value to v and returns the previous one.
+func (a *AtomicInt64) Swap(v Int64) Int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	old := a.v
+	a.v = v
+	return old
+}
+
+// SetValid sets the value to n.
+func (a *AtomicInt64) SetValid(n int64) {
+	a.Store(Int64From(n))
+}
+
+// SetNull sets the value to an explicit null.
+func (a *AtomicInt64) SetNull() {
+	a.Store(NewInt64(0, false))
+}
+
+// Add adds delta to a valid value and returns the result, wrapping around
+// on overflow as sync/atomic does. A null or unset value is left as it is,
+// and Add returns it with an error matching ErrNullValue, so a counter
+// only counts once it has been given a starting value.
+func (a *AtomicInt64) Add(delta int64) (Int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.v.Valid {
+		return a.v, nullValuef("null: cannot add %d to a null null.AtomicInt64", delta)
+	}
+	a.v.Int64 += delta
+	return a.v, nil
+}
+
+// CompareAndSwap sets the value to new if it is old, compared with
+// ExactEqual, and reports whether it did.
+func (a *AtomicInt64) CompareAndSwap(old, new Int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.v.ExactEqual(old) {
+		return false
+	}
+	a.v = new
+	return true
+}
+
+// MarshalJSON implements json.Marshaler, marshaling the current value.
+func (a *AtomicInt64) MarshalJSON() ([]byte, error) {
+	return a.Load().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. On error the value is left
+// as it was.
+func (a *AtomicInt64) UnmarshalJSON(data []byte) error {
+	var v Int64
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	a.Store(v)
+	return nil
+}
+
+// IsZero reports whether the value was never set, for the omitzero JSON
+// option.
+func (a *AtomicInt64) IsZero() bool {
+	return a.Load().IsZero()
+}
+
+// Scan implements the Scanner interface. On error the value is left as it
+// was.
+func (a *AtomicInt64) Scan(value interface{}) error {
+	var v Int64
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	a.Store(v)
+	return nil
+}
+
+// Value implements the driver Valuer interface, returning the current
+// value.
+func (a *AtomicInt64) Value() (driver.Value, error) {
+	return a.Load().Value()
+}
+
+// String implements the Stringer interface, returning what %v prints for
+// the current value.
+func (a *AtomicInt64) String() string {
+	return a.Load().String()
+}
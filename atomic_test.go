@@ -0,0 +1,153 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestAtomicInt64(t *testing.T) {
+	var a AtomicInt64
+	if v := a.Load(); v.Set || v.Valid {
+		t.Errorf("zero AtomicInt64 = %#v, want unset", v)
+	}
+	if v, err := a.Add(1); !errors.Is(err, ErrNullValue) || v.Set {
+		t.Errorf("Add on unset = %#v, %v, want an ErrNullValue error", v, err)
+	}
+	a.SetNull()
+	if v, err := a.Add(1); !errors.Is(err, ErrNullValue) || !v.Set || v.Valid {
+		t.Errorf("Add on null = %#v, %v, want an ErrNullValue error", v, err)
+	}
+
+	a.SetValid(40)
+	if v, err := a.Add(2); err != nil || !v.ExactEqual(Int64From(42)) {
+		t.Errorf("Add = %#v, %v, want 42", v, err)
+	}
+	if a.CompareAndSwap(Int64From(41), NewInt64(0, false)) || a.Load().Int64 != 42 {
+		t.Error("CompareAndSwap with a stale value should fail")
+	}
+	if !a.CompareAndSwap(Int64From(42), NewInt64(0, false)) || !a.Load().IsNull() {
+		t.Error("CompareAndSwap should set a null")
+	}
+	if a.CompareAndSwap(Int64{}, Int64From(1)) {
+		t.Error("CompareAndSwap should tell unset from null")
+	}
+	if old := a.Swap(Int64From(7)); !old.IsNull() || a.Load().Int64 != 7 {
+		t.Errorf("Swap returned %#v", old)
+	}
+	a.Store(Int64{})
+	if !a.IsZero() {
+		t.Error("Store of an unset value should unset it")
+	}
+}
+
+func TestAtomicInt64Marshal(t *testing.T) {
+	type quota struct {
+		Limit *AtomicInt64 `json:"limit,omitzero"`
+		Used  AtomicInt64  `json:"used"`
+	}
+	q := quota{Limit: new(AtomicInt64)}
+	q.Used.SetValid(3)
+	data, err := json.Marshal(&q)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"used":3}`, "AtomicInt64 in a struct")
+
+	err = json.Unmarshal([]byte(`{"limit":5,"used":null}`), &q)
+	maybePanic(err)
+	if q.Limit.Load().Int64 != 5 || !q.Used.Load().IsNull() {
+		t.Errorf("unmarshal = %v, %v", q.Limit, &q.Used)
+	}
+	if err := q.Limit.UnmarshalJSON([]byte(`"x"`)); err == nil || q.Limit.Load().Int64 != 5 {
+		t.Errorf("failed UnmarshalJSON = %v, %v, want the old value kept", q.Limit, err)
+	}
+
+	var a AtomicInt64
+	maybePanic(a.Scan(int64(9)))
+	if v, err := a.Value(); err != nil || v != int64(9) || a.String() != "9" {
+		t.Errorf("Value = %v, %v, String = %s", v, err, a.String())
+	}
+	if err := a.Scan("x"); err == nil || a.Load().Int64 != 9 {
+		t.Errorf("failed Scan = %v, %v, want the old value kept", &a, err)
+	}
+	maybePanic(a.Scan(nil))
+	if v, _ := a.Value(); v != nil || !a.Load().IsNull() {
+		t.Errorf("Scan(nil) = %v", &a)
+	}
+}
+
+func TestAtomicInt64Concurrent(t *testing.T) {
+	const workers, rounds = 8, 1000
+	var a AtomicInt64
+	a.SetValid(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < rounds; j++ {
+				_, err := a.Add(1)
+				maybePanic(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < rounds; j++ {
+				for {
+					old := a.Load()
+					if a.CompareAndSwap(old, Int64From(old.Int64+2)) {
+						break
+					}
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			last := int64(-1)
+			for j := 0; j < rounds; j++ {
+				v := a.Load()
+				if !v.Valid || v.Int64 < last {
+					t.Errorf("Load = %#v after %d", v, last)
+					return
+				}
+				last = v.Int64
+				if _, err := json.Marshal(&a); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if v := a.Load(); v.Int64 != 3*workers*rounds {
+		t.Errorf("after concurrent updates = %d, want %d", v.Int64, 3*workers*rounds)
+	}
+
+	// Writers that null and restore the value race with adders, which must
+	// either add or report the null, never lose a restore.
+	var nulls sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		nulls.Add(2)
+		go func() {
+			defer nulls.Done()
+			for j := 0; j < rounds; j++ {
+				a.SetNull()
+				a.SetValid(1)
+			}
+		}()
+		go func() {
+			defer nulls.Done()
+			for j := 0; j < rounds; j++ {
+				if v, err := a.Add(1); err == nil && v.Int64 < 2 || err != nil && v.Valid {
+					t.Errorf("Add = %#v, %v", v, err)
+					return
+				}
+			}
+		}()
+	}
+	nulls.Wait()
+	if v := a.Load(); !v.Valid {
+		t.Errorf("final value = %#v, want valid", v)
+	}
+}
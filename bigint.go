@@ -0,0 +1,568 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+)
+
+// BigInt is a nullable *big.Int, such as a NUMERIC(78,0) value.
+// The stored value is always a private copy: constructors and SetValid
+// copy their argument, and Ptr returns a copy.
+type BigInt struct {
+	BigInt *big.Int
+	Valid  bool
+	Set    bool
+}
+
+// NewBigInt creates a new BigInt holding a copy of b.
+func NewBigInt(b *big.Int, valid bool) BigInt {
+	return BigInt{
+		BigInt: copyBigInt(b),
+		Valid:  valid && b != nil,
+		Set:    true,
+	}
+}
+
+// BigIntFrom creates a new BigInt that will be null if b is nil.
+func BigIntFrom(b *big.Int) BigInt {
+	return NewBigInt(b, true)
+}
+
+// BigIntFromNonZero creates a new BigInt that will be null if b is nil or 0.
+func BigIntFromNonZero(b *big.Int) BigInt {
+	return NewBigInt(b, b != nil && b.Sign() != 0)
+}
+
+// BigIntFromString parses s as a base 10 integer into a new BigInt.
+// An empty string produces a null BigInt.
+func BigIntFromString(s string) (BigInt, error) {
+	var b BigInt
+	err := b.UnmarshalText([]byte(s))
+	return b, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (b BigInt) IsValid() bool {
+	return b.Set && b.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (b BigInt) IsSet() bool {
+	return b.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (b BigInt) IsNull() bool {
+	return b.Set && !b.Valid
+}
+
+// Equal reports whether b and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (b BigInt) Equal(other BigInt) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	if b.BigInt == nil || other.BigInt == nil {
+		return b.BigInt == other.BigInt
+	}
+	return b.BigInt.Cmp(other.BigInt) == 0
+}
+
+// ExactEqual is like Equal, but also requires b and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (b BigInt) ExactEqual(other BigInt) bool {
+	return b.Set == other.Set && b.Equal(other)
+}
+
+// Clone returns a copy of b with its own big.Int, so that arithmetic on
+// either does not change the other.
+func (b BigInt) Clone() BigInt {
+	b.BigInt = copyBigInt(b.BigInt)
+	return b
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts both JSON numbers and strings holding an integer.
+func (b *BigInt) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.BigInt", b, data)
+	b.Set = true
+	data, err = unquoteNumber(data, "null.BigInt")
+	if err != nil {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+	if bytes.Equal(data, NullBytes) {
+		b.BigInt, b.Valid = nil, false
+		return nil
+	}
+	return b.UnmarshalText(data)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null BigInt if the input is a blank string.
+func (b *BigInt) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.BigInt", text)
+	b.Set = true
+	if len(text) == 0 {
+		b.BigInt, b.Valid = nil, false
+		return nil
+	}
+	res, ok := new(big.Int).SetString(string(text), 10)
+	if !ok {
+		b.BigInt, b.Valid = nil, false
+		return fmt.Errorf("null: cannot parse %q into null.BigInt", text)
+	}
+	b.BigInt, b.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The value is encoded as a JSON string so that JavaScript clients do not
+// lose precision. It will encode null if this BigInt is null.
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return NullBytes, nil
+	}
+	return b.AppendJSON(nil)
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to buf.
+func (b BigInt) AppendJSON(buf []byte) ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '"')
+	buf = b.BigInt.Append(buf, 10)
+	return append(buf, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this BigInt is null.
+func (b BigInt) MarshalText() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return []byte{}, nil
+	}
+	return b.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to buf. Nothing is appended if b is null.
+func (b BigInt) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return buf, nil
+	}
+	return b.BigInt.Append(buf, 10), nil
+}
+
+// SetValid changes this BigInt's value to a copy of v and also sets it to
+// be non-null.
+func (b *BigInt) SetValid(v *big.Int) {
+	b.BigInt = copyBigInt(v)
+	b.Valid = v != nil
+	b.Set = true
+}
+
+// SetNull sets this BigInt to an explicit null, zeroing its value.
+func (b *BigInt) SetNull() {
+	*b = BigInt{Set: true}
+}
+
+// Unset resets this BigInt to the zero value, as if it was never set.
+func (b *BigInt) Unset() {
+	*b = BigInt{}
+}
+
+// SetPtr changes this BigInt to a copy of p, or to an explicit null if p
+// is nil, like SetValid.
+func (b *BigInt) SetPtr(p *big.Int) {
+	*b = BigIntFrom(p)
+}
+
+// Ptr returns a copy of this BigInt's value, or a nil pointer if this BigInt is null.
+func (b BigInt) Ptr() *big.Int {
+	if !b.Valid {
+		return nil
+	}
+	return copyBigInt(b.BigInt)
+}
+
+// ValueOrZero returns a copy of the inner value if valid, otherwise nil.
+func (b BigInt) ValueOrZero() *big.Int {
+	if !b.Valid {
+		return nil
+	}
+	return copyBigInt(b.BigInt)
+}
+
+// Or returns a copy of the inner value if valid, otherwise def.
+func (b BigInt) Or(def *big.Int) *big.Int {
+	if !b.Valid {
+		return def
+	}
+	return copyBigInt(b.BigInt)
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (b BigInt) Val() *big.Int {
+	return b.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (b BigInt) Get() (*big.Int, bool) {
+	return b.ValueOrZero(), b.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if b is
+// null.
+func (b BigInt) MustGet() *big.Int {
+	if !b.Valid {
+		panic(&NullError{Type: "null.BigInt"})
+	}
+	return copyBigInt(b.BigInt)
+}
+
+// IsZero returns true if this BigInt was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (b BigInt) IsZero() bool {
+	return !b.Set && !b.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this BigInt is null, and the text form otherwise.
+func (b BigInt) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	data, err := b.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// BigInt if the input is null or ~.
+func (b *BigInt) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	b.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+	return b.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this BigInt is null.
+func (b BigInt) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null BigInt.
+func (b *BigInt) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, b.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this BigInt is null.
+func (b BigInt) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null BigInt.
+func (b *BigInt) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string of decimal digits, like Value, or BSON null if
+// this BigInt is null.
+func (b BigInt) MarshalBSONValue() (byte, []byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return bsonNull, nil, nil
+	}
+	return bsonStringValue(b.BigInt.String())
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText, a BSON
+// integer or an integral decimal128.
+func (b *BigInt) UnmarshalBSONValue(typ byte, data []byte) error {
+	b.Set = true
+	if bsonIsNull(typ) {
+		b.BigInt, b.Valid = nil, false
+		return nil
+	}
+	switch typ {
+	case bsonString, bsonSymbol:
+		return unmarshalBSONText(typ, data, "null.BigInt", b.UnmarshalText)
+	case bsonDecimal128:
+		s, err := bsonReadDecimal(data)
+		if err != nil {
+			b.BigInt, b.Valid = nil, false
+			return err
+		}
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			b.BigInt, b.Valid = nil, false
+			return fmt.Errorf("null: cannot decode BSON decimal128 %s into null.BigInt", s)
+		}
+		b.BigInt, b.Valid = v, true
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.BigInt", math.MinInt64, math.MaxInt64)
+	if err != nil {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+	b.BigInt, b.Valid = big.NewInt(v), true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string of decimal digits, like Value, or nil if
+// this BigInt is null.
+func (b BigInt) MarshalMsgpack() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return msgpackNull, nil
+	}
+	return appendMsgpackString(nil, b.BigInt.String()), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText, or any
+// msgpack integer.
+func (b *BigInt) UnmarshalMsgpack(data []byte) error {
+	b.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return b.UnmarshalText([]byte(x))
+	case []byte:
+		return b.UnmarshalText(x)
+	case int64:
+		b.BigInt, b.Valid = big.NewInt(x), true
+		return nil
+	case uint64:
+		b.BigInt, b.Valid = new(big.Int).SetUint64(x), true
+		return nil
+	}
+	b.BigInt, b.Valid = nil, false
+	return msgpackTypeError(v, "null.BigInt")
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR integer, or a bignum of tag 2 or 3 beyond 64 bits, or
+// null if this BigInt is null.
+func (b BigInt) MarshalCBOR() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return cborNull, nil
+	}
+	return appendCBORBigInt(nil, b.BigInt), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer or bignum, or a text string in the same form
+// as UnmarshalText.
+func (b *BigInt) UnmarshalCBOR(data []byte) error {
+	b.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		b.BigInt, b.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return b.UnmarshalText([]byte(x))
+	case []byte:
+		return b.UnmarshalText(x)
+	case int64:
+		b.BigInt, b.Valid = big.NewInt(x), true
+		return nil
+	case uint64:
+		b.BigInt, b.Valid = new(big.Int).SetUint64(x), true
+		return nil
+	case *big.Int:
+		b.BigInt, b.Valid = x, true
+		return nil
+	}
+	b.BigInt, b.Valid = nil, false
+	return cborTypeError(v, "null.BigInt")
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (b BigInt) MarshalGQL(w io.Writer) {
+	marshalGQL(w, b.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null BigInt.
+func (b *BigInt) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, b.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this BigInt is
+// null.
+func (b BigInt) MarshalCSV() (string, error) {
+	return marshalCSV(b.Valid, b.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null BigInt.
+func (b *BigInt) UnmarshalCSV(cell string) error {
+	return b.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this BigInt is null.
+func (b BigInt) MarshalTOML() ([]byte, error) {
+	return marshalTOML(b.Valid, b.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null BigInt.
+func (b *BigInt) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, b.UnmarshalText, b.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative number, or an explicit null if shouldBeNull is true.
+func (b *BigInt) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*b = NewBigInt(nil, false)
+		return
+	}
+	b.SetValid(big.NewInt(randomN(nextInt, math.MaxInt64)))
+}
+
+// Generate implements testing/quick's Generator.
+func (b BigInt) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewBigInt(nil, false), func() BigInt {
+		return BigIntFrom(new(big.Int).Lsh(big.NewInt(generateInt64(rand)), uint(rand.Intn(64))))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (b BigInt) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Set, b.Valid, func() ([]byte, error) {
+		if b.BigInt == nil {
+			return nil, nil
+		}
+		return b.BigInt.GobEncode()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *BigInt) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.BigInt")
+	if err != nil {
+		return err
+	}
+	var v *big.Int
+	if valid {
+		v = new(big.Int)
+		if err = v.GobDecode(p); err != nil {
+			return err
+		}
+	}
+	b.BigInt, b.Valid, b.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (b *BigInt) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.BigInt", value)
+	switch x := value.(type) {
+	case nil:
+		b.BigInt, b.Valid, b.Set = nil, false, true
+		return nil
+	case int64:
+		b.BigInt, b.Valid, b.Set = big.NewInt(x), true, true
+	case string:
+		err = b.UnmarshalText([]byte(x))
+	case []byte:
+		err = b.UnmarshalText(x)
+	default:
+		b.BigInt, b.Valid, b.Set = nil, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.BigInt: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The value is stored as its decimal string.
+func (b BigInt) Value() (driver.Value, error) {
+	if !b.Valid || b.BigInt == nil {
+		return nil, nil
+	}
+	return b.BigInt.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (b BigInt) String() string {
+	return fmt.Sprint(b)
+}
+
+// GoString implements the GoStringer interface, formatting b as the Go
+// expression that builds it.
+func (b BigInt) GoString() string {
+	return goString("BigInt", "", "BigInt", goBigInt(b.BigInt), b.BigInt == nil, b.Valid, b.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// b with the verb if it is valid.
+func (b BigInt) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, b, b.Valid && b.BigInt != nil, b.Set, b.BigInt)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the decimal digits, or to nil if this
+// BigInt is null.
+func (b BigInt) LogValue() slog.Value {
+	if !b.Valid || b.BigInt == nil {
+		return logNull
+	}
+	return slog.StringValue(b.BigInt.String())
+}
+
+// copyBigInt returns a copy of b, or nil if b is nil.
+func copyBigInt(b *big.Int) *big.Int {
+	if b == nil {
+		return nil
+	}
+	return new(big.Int).Set(b)
+}
@@ -0,0 +1,198 @@
+package null
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+var (
+	bigIntString = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	bigIntJSON   = []byte(`"` + bigIntString + `"`)
+)
+
+func bigIntValue() *big.Int {
+	v, _ := new(big.Int).SetString(bigIntString, 10)
+	return v
+}
+
+func TestBigIntFrom(t *testing.T) {
+	b := BigIntFrom(bigIntValue())
+	assertBigInt(t, b, "BigIntFrom()")
+
+	zero := BigIntFrom(new(big.Int))
+	if !zero.Valid {
+		t.Error("BigIntFrom(0)", "is invalid, but should be valid")
+	}
+
+	null := BigIntFrom(nil)
+	assertNullBigInt(t, null, "BigIntFrom(nil)")
+}
+
+func TestBigIntFromString(t *testing.T) {
+	b, err := BigIntFromString(bigIntString)
+	maybePanic(err)
+	assertBigInt(t, b, "BigIntFromString()")
+
+	null, err := BigIntFromString("")
+	maybePanic(err)
+	assertNullBigInt(t, null, "BigIntFromString(\"\")")
+
+	for _, in := range []string{"abc", "1.5", "0x10", "1e3"} {
+		if _, err := BigIntFromString(in); err == nil {
+			t.Errorf("BigIntFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestBigIntCopy(t *testing.T) {
+	v := bigIntValue()
+	b := BigIntFrom(v)
+	v.SetInt64(1)
+	assertBigInt(t, b, "BigIntFrom() after mutating argument")
+
+	var set BigInt
+	v = bigIntValue()
+	set.SetValid(v)
+	v.SetInt64(1)
+	assertBigInt(t, set, "SetValid() after mutating argument")
+
+	b.Ptr().SetInt64(1)
+	assertBigInt(t, b, "BigInt after mutating Ptr()")
+
+	clone := b.Clone()
+	b.BigInt.SetInt64(1)
+	assertBigInt(t, clone, "Clone() after mutating original")
+}
+
+func TestUnmarshalBigInt(t *testing.T) {
+	var b BigInt
+	err := json.Unmarshal(bigIntJSON, &b)
+	maybePanic(err)
+	assertBigInt(t, b, "bigint string json")
+
+	var num BigInt
+	err = json.Unmarshal([]byte(bigIntString), &num)
+	maybePanic(err)
+	assertBigInt(t, num, "bigint number json")
+
+	var null BigInt
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullBigInt(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty BigInt
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullBigInt(t, empty, "empty string json")
+
+	var float BigInt
+	err = json.Unmarshal(float64JSON, &float)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullBigInt(t, float, "float json")
+
+	var badType BigInt
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullBigInt(t, badType, "wrong type json")
+}
+
+func TestMarshalBigInt(t *testing.T) {
+	b := BigIntFrom(bigIntValue())
+	data, err := json.Marshal(b)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(bigIntJSON), "non-empty json marshal")
+
+	data, err = b.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, bigIntString, "non-empty text marshal")
+
+	null := NewBigInt(nil, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestBigIntScanValue(t *testing.T) {
+	for _, v := range []interface{}{bigIntString, []byte(bigIntString)} {
+		var b BigInt
+		err := b.Scan(v)
+		maybePanic(err)
+		assertBigInt(t, b, "scanned bigint")
+
+		value, err := b.Value()
+		maybePanic(err)
+		if value != bigIntString {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var i BigInt
+	err := i.Scan(int64(-42))
+	maybePanic(err)
+	if !i.Valid || i.BigInt.Int64() != -42 {
+		t.Errorf("bad scanned int64: %v", i)
+	}
+
+	var null BigInt
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullBigInt(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"abc", float64(1.5)} {
+		var wrong BigInt
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullBigInt(t, wrong, "scanned garbage")
+	}
+}
+
+func TestBigIntPointer(t *testing.T) {
+	b := BigIntFrom(bigIntValue())
+	ptr := b.Ptr()
+	if ptr.Cmp(bigIntValue()) != 0 {
+		t.Errorf("bad %s bigint: %v ≠ %s\n", "pointer", ptr, bigIntString)
+	}
+
+	null := NewBigInt(nil, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s bigint: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestBigIntSetValid(t *testing.T) {
+	change := NewBigInt(nil, false)
+	assertNullBigInt(t, change, "SetValid()")
+	change.SetValid(bigIntValue())
+	assertBigInt(t, change, "SetValid()")
+}
+
+func assertBigInt(t *testing.T, b BigInt, from string) {
+	if b.BigInt == nil || b.BigInt.String() != bigIntString {
+		t.Errorf("bad %s bigint: %v ≠ %s\n", from, b.BigInt, bigIntString)
+	}
+	if !b.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullBigInt(t *testing.T, b BigInt, from string) {
+	if b.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,166 @@
+package null
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// binaryVersion is the first byte of the MarshalBinary format. It is
+// followed by a flags byte and, for valid values only, the payload.
+const binaryVersion = 1
+
+// Flags of the MarshalBinary format.
+const (
+	binarySet   = 1 << 0
+	binaryValid = 1 << 1
+)
+
+// marshalBinary encodes the header of the MarshalBinary format, followed
+// by the payload if valid is true.
+func marshalBinary(set, valid bool, payload func() ([]byte, error)) ([]byte, error) {
+	var flags byte
+	if set {
+		flags |= binarySet
+	}
+	if valid {
+		flags |= binaryValid
+	}
+	data := []byte{binaryVersion, flags}
+	if !valid {
+		return data, nil
+	}
+	p, err := payload()
+	if err != nil {
+		return nil, err
+	}
+	return append(data, p...), nil
+}
+
+// unmarshalBinary decodes the header of the MarshalBinary format and
+// returns the payload that follows it.
+func unmarshalBinary(data []byte, typ string) (set, valid bool, payload []byte, err error) {
+	if len(data) < 2 {
+		return false, false, nil, fmt.Errorf("null: cannot unmarshal binary into %s: data too short", typ)
+	}
+	if data[0] != binaryVersion {
+		return false, false, nil, fmt.Errorf("null: cannot unmarshal binary into %s: unsupported version %d", typ, data[0])
+	}
+	flags := data[1]
+	if flags&^(binarySet|binaryValid) != 0 {
+		return false, false, nil, fmt.Errorf("null: cannot unmarshal binary into %s: unknown flags %#x", typ, flags)
+	}
+	set, valid, payload = flags&binarySet != 0, flags&binaryValid != 0, data[2:]
+	if !valid && len(payload) != 0 {
+		return false, false, nil, fmt.Errorf("null: cannot unmarshal binary into %s: payload after null", typ)
+	}
+	return set, valid, payload, nil
+}
+
+// appendVarint appends the varint encoding of v to b.
+func appendVarint(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+// appendUvarint appends the uvarint encoding of v to b.
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// binaryVarint decodes a payload holding exactly one varint in [min, max].
+func binaryVarint(p []byte, typ string, min, max int64) (int64, error) {
+	v, n := binary.Varint(p)
+	if n <= 0 || n != len(p) {
+		return 0, fmt.Errorf("null: cannot unmarshal binary into %s: bad varint", typ)
+	}
+	if v < min || v > max {
+		return 0, overflowf("null: cannot unmarshal binary into %s: %d out of range", typ, v)
+	}
+	return v, nil
+}
+
+// binaryUvarint decodes a payload holding exactly one uvarint up to max.
+func binaryUvarint(p []byte, typ string, max uint64) (uint64, error) {
+	v, n := binary.Uvarint(p)
+	if n <= 0 || n != len(p) {
+		return 0, fmt.Errorf("null: cannot unmarshal binary into %s: bad uvarint", typ)
+	}
+	if v > max {
+		return 0, overflowf("null: cannot unmarshal binary into %s: %d out of range", typ, v)
+	}
+	return v, nil
+}
+
+// binaryFixed checks that a payload is exactly size bytes long.
+func binaryFixed(p []byte, typ string, size int) error {
+	if len(p) != size {
+		return fmt.Errorf("null: cannot unmarshal binary into %s: want %d bytes, got %d", typ, size, len(p))
+	}
+	return nil
+}
+
+// appendBinaryStrings appends a count followed by length-prefixed strings.
+func appendBinaryStrings(b []byte, s []string) []byte {
+	b = appendUvarint(b, uint64(len(s)))
+	for _, e := range s {
+		b = appendUvarint(b, uint64(len(e)))
+		b = append(b, e...)
+	}
+	return b
+}
+
+// binaryStrings decodes what appendBinaryStrings encoded.
+func binaryStrings(p []byte, typ string) ([]string, error) {
+	bad := fmt.Errorf("null: cannot unmarshal binary into %s: bad string list", typ)
+	count, n := binary.Uvarint(p)
+	if n <= 0 || count > uint64(len(p)) {
+		return nil, bad
+	}
+	p = p[n:]
+	res := make([]string, 0, count)
+	for i := uint64(0); i < count; i++ {
+		size, n := binary.Uvarint(p)
+		if n <= 0 || size > uint64(len(p)-n) {
+			return nil, bad
+		}
+		res = append(res, string(p[n:n+int(size)]))
+		p = p[n+int(size):]
+	}
+	if len(p) != 0 {
+		return nil, bad
+	}
+	return res, nil
+}
+
+// appendBinaryInt64s appends a count followed by varints.
+func appendBinaryInt64s(b []byte, s []int64) []byte {
+	b = appendUvarint(b, uint64(len(s)))
+	for _, e := range s {
+		b = appendVarint(b, e)
+	}
+	return b
+}
+
+// binaryInt64s decodes what appendBinaryInt64s encoded.
+func binaryInt64s(p []byte, typ string) ([]int64, error) {
+	bad := fmt.Errorf("null: cannot unmarshal binary into %s: bad integer list", typ)
+	count, n := binary.Uvarint(p)
+	if n <= 0 || count > uint64(len(p)) {
+		return nil, bad
+	}
+	p = p[n:]
+	res := make([]int64, 0, count)
+	for i := uint64(0); i < count; i++ {
+		v, n := binary.Varint(p)
+		if n <= 0 {
+			return nil, bad
+		}
+		res = append(res, v)
+		p = p[n:]
+	}
+	if len(p) != 0 {
+		return nil, bad
+	}
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package null
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// binaryStates returns every nullable type in the unset, set-null and
+// set-valid states.
+func binaryStates() [][3]interface{} {
+	u, _ := url.Parse("https://example.com/a?b=c")
+	return [][3]interface{}{
+		{Bool{}, NewBool(false, false), BoolFrom(true)},
+		{Byte{}, NewByte(0, false), ByteFrom('x')},
+		{Rune{}, NewRune(0, false), RuneFrom('€')},
+		{Bytes{}, NewBytes(nil, false), BytesFrom([]byte("hello"))},
+		{HexBytes{}, NewHexBytes(nil, false), HexBytesFrom([]byte{0xde, 0xad, 0xbe, 0xef})},
+		{RawBytes{}, NewRawBytes(nil, false), RawBytesFrom([]byte("hello"))},
+		{JSON{}, NewJSON(nil, false), JSONFrom([]byte(`{"a":1}`))},
+		{String{}, NewString("", false), StringFrom("hello")},
+		{Int{}, NewInt(0, false), IntFrom(-12345)},
+		{Int8{}, NewInt8(0, false), Int8From(-128)},
+		{Int16{}, NewInt16(0, false), Int16From(-32768)},
+		{Int32{}, NewInt32(0, false), Int32From(-2147483648)},
+		{Int64{}, NewInt64(0, false), Int64From(-9223372036854775808)},
+		{Uint{}, NewUint(0, false), UintFrom(12345)},
+		{Uint8{}, NewUint8(0, false), Uint8From(255)},
+		{Uint16{}, NewUint16(0, false), Uint16From(65535)},
+		{Uint32{}, NewUint32(0, false), Uint32From(4294967295)},
+		{Uint64{}, NewUint64(0, false), Uint64From(18446744073709551615)},
+		{Float32{}, NewFloat32(0, false), Float32From(1.5)},
+		{Float64{}, NewFloat64(0, false), Float64From(-2.25)},
+		{Time{}, NewTime(time.Time{}, false), TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 21, time.UTC))},
+		{Date{}, NewDate(time.Time{}, false), DateOf(2012, 12, 21)},
+		{TimeOfDay{}, NewTimeOfDay(0, false), TimeOfDayOf(15, 4, 5, 6)},
+		{Duration{}, NewDuration(0, false), DurationFrom(-90 * time.Minute)},
+		{Decimal{}, NewDecimal("", false), DecimalFrom("1.10")},
+		{Money{}, NewMoney(Amount{}, false), MoneyFrom(Amount{Minor: -1234, Currency: "EUR"})},
+		{UUID{}, NewUUID([16]byte{}, false), UUIDFrom(uuidValue)},
+		{URL{}, NewURL(nil, false), URLFrom(u)},
+		{IP{}, NewIP(netip.Addr{}, false), IPFrom(netip.MustParseAddr("fe80::1%eth0"))},
+		{CIDR{}, NewCIDR(netip.Prefix{}, false), CIDRFrom(netip.MustParsePrefix("10.0.0.0/8"))},
+		{BigInt{}, NewBigInt(nil, false), BigIntFrom(big.NewInt(-42))},
+		{Map{}, NewMap(nil, false), MapFrom(map[string]interface{}{"a": 1.0, "b": []interface{}{"c"}})},
+		{StringMap{}, NewStringMap(nil, false), StringMapFrom(map[string]string{"a": "1", "b": ""})},
+		{StringSlice{}, NewStringSlice(nil, false), StringSliceFrom([]string{"a", "", "c,d"})},
+		{Int64Slice{}, NewInt64Slice(nil, false), Int64SliceFrom([]int64{1, -2, 3})},
+		{Enum[testStatus]{}, NewEnum(testStatus(""), false), EnumFrom(statusActive)},
+		{Val[testPoint]{}, NewVal(testPoint{}, false), ValFrom(testPoint{1, 2})},
+		{Validated[int64, testPercent]{}, NewValidated[int64, testPercent](0, false), ValidatedFrom[int64, testPercent](42)},
+		{Slice[testPoint]{}, NewSlice[testPoint](nil, false), SliceFrom([]testPoint{{1, 2}, {-3, 4}})},
+		{MapOf[string, int64]{}, NewMapOf[string, int64](nil, false), MapOfFrom(map[string]int64{"a": 1, "b": -9007199254740993})},
+		{JSONOf[testPoint]{}, NewJSONOf(testPoint{}, false), JSONOfFrom(testPoint{1, -2})},
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, states := range binaryStates() {
+		for _, v := range states {
+			data, err := v.(encoding.BinaryMarshaler).MarshalBinary()
+			maybePanic(err)
+
+			ptr := reflect.New(reflect.TypeOf(v))
+			err = ptr.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+			maybePanic(err)
+			if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, v) {
+				t.Errorf("binary round trip of %T: %#v ≠ %#v", v, got, v)
+			}
+		}
+	}
+}
+
+func TestBinaryGob(t *testing.T) {
+	for _, states := range binaryStates() {
+		for _, v := range states {
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).EncodeValue(reflect.ValueOf(v))
+			maybePanic(err)
+
+			ptr := reflect.New(reflect.TypeOf(v))
+			err = gob.NewDecoder(&buf).DecodeValue(ptr)
+			maybePanic(err)
+			if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, v) {
+				t.Errorf("gob round trip of %T: %#v ≠ %#v", v, got, v)
+			}
+		}
+	}
+}
+
+func TestBinaryGobStruct(t *testing.T) {
+	type cached struct {
+		Name  String
+		Count Int64
+		Seen  Time
+	}
+	in := cached{Name: StringFrom("a"), Count: NewInt64(0, false)}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(in)
+	maybePanic(err)
+	var out cached
+	err = gob.NewDecoder(&buf).Decode(&out)
+	maybePanic(err)
+	if out != in {
+		t.Errorf("bad gob struct round trip: %#v ≠ %#v", out, in)
+	}
+	if !out.Count.Set || out.Count.Valid || out.Seen.Set {
+		t.Errorf("Set flags did not survive: %#v", out)
+	}
+}
+
+func TestBinaryFormat(t *testing.T) {
+	tests := []struct {
+		in   encoding.BinaryMarshaler
+		want []byte
+	}{
+		{Int64{}, []byte{binaryVersion, 0}},
+		{NewInt64(0, false), []byte{binaryVersion, binarySet}},
+		{Int64From(-1), []byte{binaryVersion, binarySet | binaryValid, 1}},
+		{StringFrom("hi"), []byte{binaryVersion, binarySet | binaryValid, 'h', 'i'}},
+	}
+	for _, test := range tests {
+		data, err := test.in.MarshalBinary()
+		maybePanic(err)
+		if !bytes.Equal(data, test.want) {
+			t.Errorf("%v.MarshalBinary() = %v, want %v", test.in, data, test.want)
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		in   encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{&Int64{}, nil},
+		{&Int64{}, []byte{binaryVersion}},
+		{&Int64{}, []byte{99, binarySet}},
+		{&Int64{}, []byte{binaryVersion, 0x80}},
+		{&Int64{}, []byte{binaryVersion, binarySet, 1}},
+		{&Int64{}, []byte{binaryVersion, binarySet | binaryValid}},
+		{&Int8{}, []byte{binaryVersion, binarySet | binaryValid, 0x80, 0x02}},
+		{&Uint8{}, []byte{binaryVersion, binarySet | binaryValid, 0x80, 0x02}},
+		{&Bool{}, []byte{binaryVersion, binarySet | binaryValid, 2}},
+		{&Float64{}, []byte{binaryVersion, binarySet | binaryValid, 1, 2}},
+		{&UUID{}, []byte{binaryVersion, binarySet | binaryValid, 1, 2}},
+		{&StringSlice{}, []byte{binaryVersion, binarySet | binaryValid, 5, 1}},
+		{&TimeOfDay{}, []byte{binaryVersion, binarySet | binaryValid, 1}},
+	}
+	for _, test := range tests {
+		if err := test.in.UnmarshalBinary(test.data); err == nil {
+			t.Errorf("%T.UnmarshalBinary(%v) should fail", test.in, test.data)
+		}
+	}
+}
@@ -2,14 +2,49 @@ package null
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/sisoftrg/null/convert"
 )
 
+// BoolJSONLenient makes Bool.UnmarshalJSON also accept the JSON numbers 0
+// and 1 and the JSON strings "true" and "false", as some APIs send them.
+// Other numbers and strings are still an error, and MarshalJSON writes a
+// JSON boolean either way. UnmarshalGQL and the other decoders that go
+// through UnmarshalJSON follow it.
+var BoolJSONLenient = false
+
+// BoolValueMode is the driver value Bool.Value returns; see BoolValueAs.
+type BoolValueMode int
+
+const (
+	// BoolValueBool returns a bool, the default.
+	BoolValueBool BoolValueMode = iota
+	// BoolValueInt returns an int64 of 1 or 0, for TINYINT and NUMBER(1)
+	// columns.
+	BoolValueInt
+	// BoolValueYN returns the string "Y" or "N", for CHAR(1) columns.
+	BoolValueYN
+	// BoolValueTF returns the string "t" or "f", Postgres's text form.
+	BoolValueTF
+)
+
+// BoolValueAs sets what Bool.Value returns for a valid Bool, for schemas
+// that store booleans as numbers or letters and drivers that do not
+// convert a bool for them. A null Bool is nil in every mode. Scan accepts
+// all of these forms whatever the mode.
+var BoolValueAs = BoolValueBool
+
 // Bool is a nullable bool.
 type Bool struct {
 	Bool  bool
@@ -39,6 +74,34 @@ func BoolFromPtr(b *bool) Bool {
 	return NewBool(*b, true)
 }
 
+// BoolFromNonZero creates a new Bool that will be null if b is false.
+func BoolFromNonZero(b bool) Bool {
+	return NewBool(b, b)
+}
+
+// BoolFromString parses s with the rules of UnmarshalText into a new Bool.
+// An empty string produces a null Bool.
+func BoolFromString(s string) (Bool, error) {
+	var b Bool
+	err := b.UnmarshalText([]byte(s))
+	return b, err
+}
+
+// MustBoolFromString is like BoolFromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustBoolFromString(s string) Bool {
+	b, err := BoolFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// BoolFromNullBool creates a new Bool from a sql.NullBool. It is always Set.
+func BoolFromNullBool(n sql.NullBool) Bool {
+	return NewBool(n.Bool, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (b Bool) IsValid() bool {
@@ -50,8 +113,83 @@ func (b Bool) IsSet() bool {
 	return b.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (b Bool) IsNull() bool {
+	return b.Set && !b.Valid
+}
+
+// Equal reports whether b and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (b Bool) Equal(other Bool) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	return b.Bool == other.Bool
+}
+
+// ExactEqual is like Equal, but also requires b and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (b Bool) ExactEqual(other Bool) bool {
+	return b.Set == other.Set && b.Equal(other)
+}
+
+// And returns b AND other with SQL's three-valued logic: false if either
+// is false, true if both are true, and null otherwise, so null AND false is
+// false while null AND true is null. A null result is set if either input
+// is; an unset value counts as null.
+func (b Bool) And(other Bool) Bool {
+	switch {
+	case (b.Valid && !b.Bool) || (other.Valid && !other.Bool):
+		return BoolFrom(false)
+	case b.Valid && other.Valid:
+		return BoolFrom(true)
+	}
+	return Bool{Set: b.Set || other.Set}
+}
+
+// Not returns NOT b with SQL's three-valued logic: the negation of a valid
+// value, and b itself, set or not, if it is null.
+func (b Bool) Not() Bool {
+	if !b.Valid {
+		return Bool{Set: b.Set}
+	}
+	return BoolFrom(!b.Bool)
+}
+
+// BoolAnd folds bs with And. It returns true for no arguments, like an
+// empty conjunction.
+func BoolAnd(bs ...Bool) Bool {
+	out := Bool{Bool: true, Valid: true, Set: len(bs) == 0}
+	for _, b := range bs {
+		out = out.And(b)
+	}
+	return out
+}
+
+// BoolOr returns the OR of bs with SQL's three-valued logic: true if any is
+// true, false if all are false, and null otherwise, so null OR true is true
+// while null OR false is null. A null result is set if any input is. It
+// returns false for no arguments, like an empty disjunction. Bool has no Or
+// method for this, as Or returns the value or a default.
+func BoolOr(bs ...Bool) Bool {
+	out := Bool{Valid: true, Set: len(bs) == 0}
+	for _, b := range bs {
+		switch {
+		case b.Valid && b.Bool:
+			return BoolFrom(true)
+		case out.Valid && b.Valid:
+			out = BoolFrom(false)
+		default:
+			out = Bool{Set: out.Set || b.Set}
+		}
+	}
+	return out
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (b *Bool) UnmarshalJSON(data []byte) error {
+func (b *Bool) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Bool", b, data)
 	b.Set = true
 
 	if bytes.Equal(data, NullBytes) {
@@ -60,6 +198,14 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	if BoolJSONLenient {
+		if v, ok := lenientJSONBool(data); ok {
+			b.Bool = v
+			b.Valid = true
+			return nil
+		}
+	}
+
 	if err := json.Unmarshal(data, &b.Bool); err != nil {
 		return err
 	}
@@ -68,37 +214,64 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// lenientJSONBool reads the numbers and strings that BoolJSONLenient allows.
+func lenientJSONBool(data []byte) (v, ok bool) {
+	switch string(data) {
+	case "1", `"true"`:
+		return true, true
+	case "0", `"false"`:
+		return false, true
+	}
+	return false, false
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (b *Bool) UnmarshalText(text []byte) error {
+func (b *Bool) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Bool", text)
 	b.Set = true
 	if len(text) == 0 {
 		b.Valid = false
 		return nil
 	}
 
-	str := string(text)
-	switch str {
-	case "true":
-		b.Bool = true
-	case "false":
-		b.Bool = false
-	default:
+	v, err := parseBool(string(text))
+	if err != nil {
 		b.Valid = false
-		return errors.New("invalid input:" + str)
+		return err
 	}
+	b.Bool = v
 	b.Valid = true
 	return nil
 }
 
+// parseBool accepts the common textual spellings of a boolean,
+// case-insensitively: 1/0, t/f, true/false, y/n, yes/no and on/off. These
+// cover what MySQL and Postgres drivers return as text.
+func parseBool(str string) (bool, error) {
+	switch strings.ToLower(str) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("null: cannot parse %q as a boolean", str)
+}
+
 // MarshalJSON implements json.Marshaler.
 func (b Bool) MarshalJSON() ([]byte, error) {
 	if !b.Valid {
 		return NullBytes, nil
 	}
-	if !b.Bool {
-		return []byte("false"), nil
+	return b.AppendJSON(make([]byte, 0, 5))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to buf.
+func (b Bool) AppendJSON(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return append(buf, "null"...), nil
 	}
-	return []byte("true"), nil
+	return strconv.AppendBool(buf, b.Bool), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -106,10 +279,16 @@ func (b Bool) MarshalText() ([]byte, error) {
 	if !b.Valid {
 		return []byte{}, nil
 	}
-	if !b.Bool {
-		return []byte("false"), nil
+	return b.AppendText(make([]byte, 0, 5))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to buf. Nothing is appended if b is null.
+func (b Bool) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return buf, nil
 	}
-	return []byte("true"), nil
+	return strconv.AppendBool(buf, b.Bool), nil
 }
 
 // SetValid changes this Bool's value and also sets it to be non-null.
@@ -119,6 +298,22 @@ func (b *Bool) SetValid(v bool) {
 	b.Set = true
 }
 
+// SetNull sets this Bool to an explicit null, zeroing its value.
+func (b *Bool) SetNull() {
+	*b = Bool{Set: true}
+}
+
+// Unset resets this Bool to the zero value, as if it was never set.
+func (b *Bool) Unset() {
+	*b = Bool{}
+}
+
+// SetPtr changes this Bool to the value p points to, or to an explicit
+// null if p is nil.
+func (b *Bool) SetPtr(p *bool) {
+	*b = BoolFromPtr(p)
+}
+
 // Ptr returns a pointer to this Bool's value, or a nil pointer if this Bool is null.
 func (b Bool) Ptr() *bool {
 	if !b.Valid {
@@ -127,33 +322,393 @@ func (b Bool) Ptr() *bool {
 	return &b.Bool
 }
 
-// IsZero returns true for invalid Bools, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (b Bool) ValueOrZero() bool {
+	if !b.Valid {
+		return false
+	}
+	return b.Bool
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (b Bool) Or(def bool) bool {
+	if !b.Valid {
+		return def
+	}
+	return b.Bool
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (b Bool) Val() bool {
+	return b.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (b Bool) Get() (bool, bool) {
+	return b.ValueOrZero(), b.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if b is
+// null.
+func (b Bool) MustGet() bool {
+	if !b.Valid {
+		panic(&NullError{Type: "null.Bool"})
+	}
+	return b.Bool
+}
+
+// IsZero returns true if this Bool was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (b Bool) IsZero() bool {
-	return !b.Valid
+	return !b.Set && !b.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Bool is null.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bool, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Bool if the input is null or ~.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	b.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+
+	var v bool
+	if err := unmarshal(&v); err != nil {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	b.Bool, b.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Bool is null.
+func (b Bool) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Bool.
+func (b *Bool) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, b.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Bool is null.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Bool.
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON boolean, or BSON null if this Bool is null.
+func (b Bool) MarshalBSONValue() (byte, []byte, error) {
+	if !b.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonBoolValue(b.Bool)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON boolean.
+func (b *Bool) UnmarshalBSONValue(typ byte, data []byte) error {
+	b.Set = true
+	if bsonIsNull(typ) {
+		b.Bool, b.Valid = false, false
+		return nil
+	}
+	if typ != bsonBoolean {
+		b.Bool, b.Valid = false, false
+		return bsonTypeError(typ, "null.Bool")
+	}
+	v, err := bsonFixed(typ, data, 1)
+	b.Bool, b.Valid = err == nil && v[0] != 0, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack bool, or nil if this Bool is null.
+func (b Bool) MarshalMsgpack() ([]byte, error) {
+	if !b.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackBool(nil, b.Bool), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack bool.
+func (b *Bool) UnmarshalMsgpack(data []byte) error {
+	b.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	res, ok := v.(bool)
+	if !ok {
+		b.Bool, b.Valid = false, false
+		return msgpackTypeError(v, "null.Bool")
+	}
+	b.Bool, b.Valid = res, true
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR bool, or null if this Bool is null.
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	if !b.Valid {
+		return cborNull, nil
+	}
+	return appendCBORBool(nil, b.Bool), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR bool.
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	b.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	res, ok := v.(bool)
+	if !ok {
+		b.Bool, b.Valid = false, false
+		return cborTypeError(v, "null.Bool")
+	}
+	b.Bool, b.Valid = res, true
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (b Bool) MarshalGQL(w io.Writer) {
+	marshalGQL(w, b.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Bool.
+func (b *Bool) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, b.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Bool is
+// null.
+func (b Bool) MarshalCSV() (string, error) {
+	return marshalCSV(b.Valid, b.MarshalText)
 }
 
-// Scan implements the Scanner interface.
-func (b *Bool) Scan(value interface{}) error {
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Bool.
+func (b *Bool) UnmarshalCSV(cell string) error {
+	return b.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Bool, while an absent one is never passed, leaving
+// the field unset.
+func (b *Bool) UnmarshalParam(param string) error {
+	return b.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Bool is null.
+func (b Bool) MarshalTOML() ([]byte, error) {
+	return marshalTOML(b.Valid, b.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Bool.
+func (b *Bool) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, b.UnmarshalText, b.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes a BOOL, or a typed NULL if this Bool is null.
+func (b Bool) EncodeSpanner() (interface{}, error) {
+	if !b.Valid {
+		return (*bool)(nil), nil
+	}
+	return b.Bool, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts a BOOL. NULL decodes to a null Bool.
+func (b *Bool) DecodeSpanner(input interface{}) error {
+	b.Set = true
+	v, null := spannerValue(input)
+	x, ok := v.(bool)
+	if null || !ok {
+		b.Bool, b.Valid = false, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.Bool")
+	}
+	b.Bool, b.Valid = x, true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random boolean, or an explicit null if shouldBeNull is true.
+func (b *Bool) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*b = NewBool(false, false)
+		return
+	}
+	b.SetValid(nextInt()%2 == 0)
+}
+
+// Generate implements testing/quick's Generator.
+func (b Bool) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewBool(false, false), func() Bool {
+		return BoolFrom(rand.Intn(2) == 0)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (b Bool) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Set, b.Valid, func() ([]byte, error) {
+		if b.Bool {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Bool")
+	if err != nil {
+		return err
+	}
+	var v bool
+	if valid {
+		if err = binaryFixed(p, "null.Bool", 1); err != nil {
+			return err
+		}
+		if p[0] > 1 {
+			return fmt.Errorf("null: cannot unmarshal binary into null.Bool: bad value %d", p[0])
+		}
+		v = p[0] == 1
+	}
+	b.Bool, b.Valid, b.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface. It accepts a bool, an int64 of 0
+// or 1, and text such as "t", "Y", "yes" or "1" as a string or []byte,
+// which it parses like UnmarshalText, so it reads every form of
+// BoolValueAs.
+func (b *Bool) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Bool", value)
 	if value == nil {
-		b.Bool, b.Valid, b.Set = false, false, false
+		b.Bool, b.Valid, b.Set = false, false, true
 		return nil
 	}
-	b.Valid, b.Set = true, true
-	return convert.ConvertAssign(&b.Bool, value)
+	b.Set = true
+
+	switch x := value.(type) {
+	case bool:
+		b.Bool = x
+	case int64:
+		// MySQL stores booleans as TINYINT(1).
+		if x != 0 && x != 1 {
+			err = fmt.Errorf("null: cannot scan %d into null.Bool, want 0 or 1", x)
+		}
+		b.Bool = x == 1
+	case string:
+		b.Bool, err = parseBool(x)
+	case []byte:
+		b.Bool, err = parseBool(string(x))
+	default:
+		err = convert.ConvertAssign(&b.Bool, value)
+	}
+	b.Valid = err == nil
+	return err
 }
 
-// Value implements the driver Valuer interface.
+// Value implements the driver Valuer interface. It returns a bool, or the
+// form BoolValueAs selects.
 func (b Bool) Value() (driver.Value, error) {
 	if !b.Valid {
 		return nil, nil
 	}
+	switch BoolValueAs {
+	case BoolValueInt:
+		if b.Bool {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case BoolValueYN:
+		if b.Bool {
+			return "Y", nil
+		}
+		return "N", nil
+	case BoolValueTF:
+		if b.Bool {
+			return "t", nil
+		}
+		return "f", nil
+	}
 	return b.Bool, nil
 }
 
-// String implements the Stringer interface
+// ToNullBool converts b to a sql.NullBool, dropping Set.
+func (b Bool) ToNullBool() sql.NullBool {
+	return sql.NullBool{Bool: b.Bool, Valid: b.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (b Bool) String() string {
-	if b.Valid {
-		return fmt.Sprintf("Bool(%t)", b.Bool)
+	return fmt.Sprint(b)
+}
+
+// GoString implements the GoStringer interface, formatting b as the Go
+// expression that builds it.
+func (b Bool) GoString() string {
+	return goString("Bool", "", "Bool", strconv.FormatBool(b.Bool), !b.Bool, b.Valid, b.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// b with the verb if it is valid.
+func (b Bool) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, b, b.Valid, b.Set, b.Bool)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Bool value, or to nil if this Bool is null.
+func (b Bool) LogValue() slog.Value {
+	if !b.Valid {
+		return logNull
 	}
-	return "Bool(invalid)"
+	return slog.BoolValue(b.Bool)
 }
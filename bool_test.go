@@ -1,7 +1,13 @@
 package null
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -29,6 +35,28 @@ func TestBoolFromPtr(t *testing.T) {
 	assertNullBool(t, null, "BoolFromPtr(nil)")
 }
 
+func TestBoolFromString(t *testing.T) {
+	b, err := BoolFromString("yes")
+	maybePanic(err)
+	assertBool(t, b, "BoolFromString(\"yes\")")
+
+	b, err = BoolFromString("0")
+	maybePanic(err)
+	assertFalseBool(t, b, "BoolFromString(\"0\")")
+
+	null, err := BoolFromString("")
+	maybePanic(err)
+	assertNullBool(t, null, "BoolFromString(\"\")")
+	if !null.Set {
+		t.Error("BoolFromString(\"\") should be set")
+	}
+
+	if _, err := BoolFromString("maybe"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("BoolFromString(\"maybe\") error = %v, want ErrInvalidInput", err)
+	}
+	assertBool(t, MustBoolFromString("true"), "MustBoolFromString()")
+}
+
 func TestUnmarshalBool(t *testing.T) {
 	var null Bool
 	err := json.Unmarshal(nullJSON, &null)
@@ -47,11 +75,46 @@ func TestUnmarshalBool(t *testing.T) {
 
 	var invalid Bool
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 }
 
+func TestUnmarshalBoolLenient(t *testing.T) {
+	for _, in := range []string{"1", "0", `"true"`, `"false"`} {
+		var b Bool
+		if err := json.Unmarshal([]byte(in), &b); err == nil {
+			t.Errorf("strict UnmarshalJSON(%s) should fail", in)
+		}
+	}
+
+	BoolJSONLenient = true
+	defer func() { BoolJSONLenient = false }()
+
+	for in, want := range map[string]bool{"1": true, "0": false, `"true"`: true, `"false"`: false, "true": true} {
+		var b Bool
+		err := json.Unmarshal([]byte(in), &b)
+		maybePanic(err)
+		if !b.Valid || !b.Set || b.Bool != want {
+			t.Errorf("lenient UnmarshalJSON(%s) = %#v, want %v", in, b, want)
+		}
+		data, err := json.Marshal(b)
+		maybePanic(err)
+		if string(data) != fmt.Sprint(want) {
+			t.Errorf("lenient UnmarshalJSON(%s) marshals to %s, want %v", in, data, want)
+		}
+	}
+
+	for _, in := range []string{"2", "-1", "1.0", `"1"`, `"yes"`, `"TRUE"`, `""`} {
+		var b Bool
+		if err := json.Unmarshal([]byte(in), &b); err == nil {
+			t.Errorf("lenient UnmarshalJSON(%s) should fail", in)
+		}
+		assertNullBool(t, b, "lenient "+in)
+	}
+}
+
 func TestTextUnmarshalBool(t *testing.T) {
 	var b Bool
 	err := b.UnmarshalText([]byte("true"))
@@ -74,6 +137,33 @@ func TestTextUnmarshalBool(t *testing.T) {
 		panic("err should not be nil")
 	}
 	assertNullBool(t, invalid, "invalid json")
+	if !strings.Contains(err.Error(), ":D") {
+		t.Errorf("error should name the input: %v", err)
+	}
+}
+
+func TestTextUnmarshalBoolSpellings(t *testing.T) {
+	for _, in := range []string{"1", "t", "T", "true", "TRUE", "yes", "Yes", "on", "ON"} {
+		var b Bool
+		err := b.UnmarshalText([]byte(in))
+		maybePanic(err)
+		assertBool(t, b, "UnmarshalText() "+in)
+
+		data, err := b.MarshalText()
+		maybePanic(err)
+		assertJSONEquals(t, data, "true", "MarshalText() after "+in)
+	}
+
+	for _, in := range []string{"0", "f", "F", "false", "False", "no", "NO", "off", "Off"} {
+		var b Bool
+		err := b.UnmarshalText([]byte(in))
+		maybePanic(err)
+		assertFalseBool(t, b, "UnmarshalText() "+in)
+
+		data, err := b.MarshalText()
+		maybePanic(err)
+		assertJSONEquals(t, data, "false", "MarshalText() after "+in)
+	}
 }
 
 func TestMarshalBool(t *testing.T) {
@@ -133,7 +223,12 @@ func TestBoolIsZero(t *testing.T) {
 	}
 
 	null := NewBool(false, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Bool
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -156,10 +251,117 @@ func TestBoolScan(t *testing.T) {
 	maybePanic(err)
 	assertBool(t, b, "scanned bool")
 
+	for _, v := range []interface{}{"yes", []byte("1"), "ON", "t"} {
+		var text Bool
+		err = text.Scan(v)
+		maybePanic(err)
+		assertBool(t, text, fmt.Sprintf("scanned %q", v))
+	}
+
+	for _, v := range []interface{}{"no", []byte("0"), "OFF", "f"} {
+		var text Bool
+		err = text.Scan(v)
+		maybePanic(err)
+		assertFalseBool(t, text, fmt.Sprintf("scanned %q", v))
+	}
+
+	var wrong Bool
+	err = wrong.Scan("maybe")
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullBool(t, wrong, "scanned maybe")
+
 	var null Bool
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullBool(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestBoolScanDriverValues(t *testing.T) {
+	tests := []struct {
+		driver string
+		in     interface{}
+		want   bool
+	}{
+		{"bool", true, true},
+		{"bool", false, false},
+		{"MySQL TINYINT(1)", int64(1), true},
+		{"MySQL TINYINT(1)", int64(0), false},
+		{"MySQL text protocol", []byte("1"), true},
+		{"MySQL text protocol", []byte("0"), false},
+		{"Postgres simple protocol", "t", true},
+		{"Postgres simple protocol", "f", false},
+		{"Postgres simple protocol", []byte("f"), false},
+		{"SQLite text", "TRUE", true},
+		{"SQLite text", "n", false},
+		{"Oracle CHAR(1)", "Y", true},
+		{"Oracle CHAR(1)", []byte("N"), false},
+	}
+	for _, test := range tests {
+		var b Bool
+		err := b.Scan(test.in)
+		maybePanic(err)
+		if b.Bool != test.want || !b.Valid || !b.Set {
+			t.Errorf("%s: Scan(%#v) = %#v, want %v", test.driver, test.in, b, test.want)
+		}
+	}
+
+	for _, in := range []interface{}{int64(2), int64(-1), "maybe", []byte("2"), 1.0} {
+		b := BoolFrom(true)
+		err := b.Scan(in)
+		if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), inputString(in)) {
+			t.Errorf("Scan(%#v) = %v, want an error naming the input", in, err)
+		}
+		if b.Valid || !b.Set {
+			t.Errorf("Scan(%#v) = %#v, want a set null", in, b)
+		}
+	}
+}
+
+func TestBoolValueAs(t *testing.T) {
+	defer func(mode BoolValueMode) { BoolValueAs = mode }(BoolValueAs)
+	tests := []struct {
+		mode        BoolValueMode
+		true, false driver.Value
+	}{
+		{BoolValueBool, true, false},
+		{BoolValueInt, int64(1), int64(0)},
+		{BoolValueYN, "Y", "N"},
+		{BoolValueTF, "t", "f"},
+	}
+	for _, test := range tests {
+		BoolValueAs = test.mode
+		db := &rowsDB{cols: []string{"b"}}
+		conn := sql.OpenDB(db)
+		for _, b := range []Bool{BoolFrom(true), BoolFrom(false), NewBool(true, false)} {
+			_, err := conn.Exec("INSERT", b)
+			maybePanic(err)
+		}
+		if want := [][]driver.Value{{test.true}, {test.false}, {nil}}; !reflect.DeepEqual(db.data, want) {
+			t.Errorf("mode %d: stored %#v, want %#v", test.mode, db.data, want)
+		}
+
+		// Whatever the mode, every form scans back.
+		db.data = append(db.data, []driver.Value{tests[(int(test.mode)+1)%len(tests)].true})
+		rows, err := conn.Query("SELECT")
+		maybePanic(err)
+		var got []Bool
+		for rows.Next() {
+			var b Bool
+			maybePanic(rows.Scan(&b))
+			got = append(got, b)
+		}
+		maybePanic(rows.Err())
+		conn.Close()
+		want := []Bool{BoolFrom(true), BoolFrom(false), NewBool(false, false), BoolFrom(true)}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("mode %d: scanned %v, want %v", test.mode, got, want)
+		}
+	}
 }
 
 func assertBool(t *testing.T, b Bool, from string) {
@@ -185,3 +387,57 @@ func assertNullBool(t *testing.T, b Bool, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestBoolLogic(t *testing.T) {
+	u, n, tr, f := Bool{}, NewBool(false, false), BoolFrom(true), BoolFrom(false)
+	name := map[Bool]string{u: "unset", n: "null", tr: "true", f: "false"}
+	for _, test := range []struct {
+		a, b, and, or Bool
+	}{
+		{tr, tr, tr, tr},
+		{tr, f, f, tr},
+		{tr, n, n, tr},
+		{tr, u, n, tr},
+		{f, tr, f, tr},
+		{f, f, f, f},
+		{f, n, f, n},
+		{f, u, f, n},
+		{n, tr, n, tr},
+		{n, f, f, n},
+		{n, n, n, n},
+		{n, u, n, n},
+		{u, tr, n, tr},
+		{u, f, f, n},
+		{u, n, n, n},
+		{u, u, u, u},
+	} {
+		if got := test.a.And(test.b); got != test.and {
+			t.Errorf("%s AND %s = %s, want %s", name[test.a], name[test.b], name[got], name[test.and])
+		}
+		if got := BoolAnd(test.a, test.b); got != test.and {
+			t.Errorf("BoolAnd(%s, %s) = %s, want %s", name[test.a], name[test.b], name[got], name[test.and])
+		}
+		if got := BoolOr(test.a, test.b); got != test.or {
+			t.Errorf("BoolOr(%s, %s) = %s, want %s", name[test.a], name[test.b], name[got], name[test.or])
+		}
+	}
+
+	for in, want := range map[Bool]Bool{tr: f, f: tr, n: n, u: u} {
+		if got := in.Not(); got != want {
+			t.Errorf("NOT %s = %s, want %s", name[in], name[got], name[want])
+		}
+	}
+
+	if got := BoolAnd(); got != tr {
+		t.Errorf("BoolAnd() = %#v, want true", got)
+	}
+	if got := BoolOr(); got != f {
+		t.Errorf("BoolOr() = %#v, want false", got)
+	}
+	if got := BoolAnd(tr, n, f); got != f {
+		t.Errorf("BoolAnd(true, null, false) = %s, want false", name[got])
+	}
+	if got := BoolOr(f, n, tr); got != tr {
+		t.Errorf("BoolOr(false, null, true) = %s, want true", name[got])
+	}
+}
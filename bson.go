@@ -0,0 +1,702 @@
+package null
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The BSON methods implement the bson.ValueMarshaler and
+// bson.ValueUnmarshaler interfaces of go.mongodb.org/mongo-driver/v2,
+// whose signatures use plain bytes for the BSON type, so the package needs
+// no MongoDB dependency. Valid values encode as the matching BSON scalar and
+// null values as BSON null.
+//
+// The driver calls UnmarshalBSONValue for BSON null, so an explicit null
+// sets Set, while a missing field leaves it false.
+
+// BSON element types, as defined by the BSON specification.
+const (
+	bsonDouble     byte = 0x01
+	bsonString     byte = 0x02
+	bsonDocument   byte = 0x03
+	bsonArray      byte = 0x04
+	bsonBinary     byte = 0x05
+	bsonUndefined  byte = 0x06
+	bsonObjectID   byte = 0x07
+	bsonBoolean    byte = 0x08
+	bsonDateTime   byte = 0x09
+	bsonNull       byte = 0x0A
+	bsonRegex      byte = 0x0B
+	bsonDBPointer  byte = 0x0C
+	bsonJavaScript byte = 0x0D
+	bsonSymbol     byte = 0x0E
+	bsonCodeScope  byte = 0x0F
+	bsonInt32      byte = 0x10
+	bsonTimestamp  byte = 0x11
+	bsonInt64      byte = 0x12
+	bsonDecimal128 byte = 0x13
+	bsonMaxKey     byte = 0x7F
+	bsonMinKey     byte = 0xFF
+)
+
+// BSON binary subtypes.
+const (
+	bsonBinaryGeneric byte = 0x00
+	bsonBinaryOld     byte = 0x02
+	bsonBinaryUUIDOld byte = 0x03
+	bsonBinaryUUID    byte = 0x04
+)
+
+var bsonTypeNames = map[byte]string{
+	bsonDouble:     "double",
+	bsonString:     "string",
+	bsonDocument:   "embedded document",
+	bsonArray:      "array",
+	bsonBinary:     "binary",
+	bsonUndefined:  "undefined",
+	bsonObjectID:   "objectID",
+	bsonBoolean:    "boolean",
+	bsonDateTime:   "UTC datetime",
+	bsonNull:       "null",
+	bsonRegex:      "regex",
+	bsonDBPointer:  "dbPointer",
+	bsonJavaScript: "javascript",
+	bsonSymbol:     "symbol",
+	bsonCodeScope:  "code with scope",
+	bsonInt32:      "32-bit integer",
+	bsonTimestamp:  "timestamp",
+	bsonInt64:      "64-bit integer",
+	bsonDecimal128: "128-bit decimal",
+	bsonMaxKey:     "max key",
+	bsonMinKey:     "min key",
+}
+
+// bsonValueMarshaler and bsonValueUnmarshaler mirror the mongo-driver
+// interfaces, for Val to delegate to T.
+type bsonValueMarshaler interface {
+	MarshalBSONValue() (byte, []byte, error)
+}
+
+type bsonValueUnmarshaler interface {
+	UnmarshalBSONValue(typ byte, data []byte) error
+}
+
+func bsonTypeName(typ byte) string {
+	if name, ok := bsonTypeNames[typ]; ok {
+		return name
+	}
+	return fmt.Sprintf("type 0x%02x", typ)
+}
+
+// bsonIsNull reports whether typ is BSON null or the deprecated undefined.
+func bsonIsNull(typ byte) bool {
+	return typ == bsonNull || typ == bsonUndefined
+}
+
+func bsonTypeError(typ byte, to string) error {
+	return fmt.Errorf("null: cannot decode BSON %s into %s", bsonTypeName(typ), to)
+}
+
+func bsonInvalid(typ byte) error {
+	return fmt.Errorf("null: invalid BSON %s value", bsonTypeName(typ))
+}
+
+// bsonFixed returns data if it is exactly n bytes long.
+func bsonFixed(typ byte, data []byte, n int) ([]byte, error) {
+	if len(data) != n {
+		return nil, bsonInvalid(typ)
+	}
+	return data, nil
+}
+
+func bsonInt32Value(v int32) (byte, []byte, error) {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, uint32(v))
+	return bsonInt32, data, nil
+}
+
+func bsonInt64Value(v int64) (byte, []byte, error) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(v))
+	return bsonInt64, data, nil
+}
+
+func bsonDoubleValue(v float64) (byte, []byte, error) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, math.Float64bits(v))
+	return bsonDouble, data, nil
+}
+
+func bsonBoolValue(v bool) (byte, []byte, error) {
+	if v {
+		return bsonBoolean, []byte{1}, nil
+	}
+	return bsonBoolean, []byte{0}, nil
+}
+
+func bsonStringValue(s string) (byte, []byte, error) {
+	if !utf8.ValidString(s) {
+		return 0, nil, fmt.Errorf("null: cannot encode invalid UTF-8 %q as a BSON string", s)
+	}
+	return bsonString, appendBSONString(nil, s), nil
+}
+
+// bsonTextValue encodes the output of a MarshalText method as a BSON string.
+func bsonTextValue(text func() ([]byte, error)) (byte, []byte, error) {
+	data, err := text()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonStringValue(string(data))
+}
+
+func bsonDateTimeValue(t time.Time) (byte, []byte, error) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(t.UnixMilli()))
+	return bsonDateTime, data, nil
+}
+
+func bsonBinaryValue(subtype byte, b []byte) (byte, []byte, error) {
+	data := make([]byte, 5, 5+len(b))
+	binary.LittleEndian.PutUint32(data, uint32(len(b)))
+	data[4] = subtype
+	return bsonBinary, append(data, b...), nil
+}
+
+func appendBSONString(dst []byte, s string) []byte {
+	n := make([]byte, 4)
+	binary.LittleEndian.PutUint32(n, uint32(len(s)+1))
+	dst = append(dst, n...)
+	dst = append(dst, s...)
+	return append(dst, 0)
+}
+
+// appendBSONElement appends a document element with the given key.
+func appendBSONElement(dst []byte, typ byte, key string, data []byte) []byte {
+	dst = append(dst, typ)
+	dst = append(dst, key...)
+	dst = append(dst, 0)
+	return append(dst, data...)
+}
+
+// bsonDocumentValue wraps encoded elements in a document length and
+// terminator.
+func bsonDocumentValue(elems []byte) []byte {
+	data := make([]byte, 4, len(elems)+5)
+	binary.LittleEndian.PutUint32(data, uint32(len(elems)+5))
+	data = append(data, elems...)
+	return append(data, 0)
+}
+
+// bsonArrayValue encodes values as a BSON array, using encode for each
+// element.
+func bsonArrayValue(n int, encode func(i int) (byte, []byte, error)) (byte, []byte, error) {
+	var elems []byte
+	for i := 0; i < n; i++ {
+		typ, data, err := encode(i)
+		if err != nil {
+			return 0, nil, err
+		}
+		elems = appendBSONElement(elems, typ, strconv.Itoa(i), data)
+	}
+	return bsonArray, bsonDocumentValue(elems), nil
+}
+
+// bsonReadString decodes a BSON string, symbol or javascript value.
+func bsonReadString(typ byte, data []byte) (string, error) {
+	if len(data) < 5 {
+		return "", bsonInvalid(typ)
+	}
+	n := int(int32(binary.LittleEndian.Uint32(data)))
+	if n < 1 || n != len(data)-4 || data[len(data)-1] != 0 {
+		return "", bsonInvalid(typ)
+	}
+	return string(data[4 : len(data)-1]), nil
+}
+
+// bsonText decodes a BSON string for types that parse text.
+func bsonText(typ byte, data []byte, to string) (string, error) {
+	if typ != bsonString && typ != bsonSymbol {
+		return "", bsonTypeError(typ, to)
+	}
+	return bsonReadString(typ, data)
+}
+
+// unmarshalBSONText decodes a BSON string and passes it to fromText. BSON
+// null passes empty text, and so does any other BSON type before its error
+// is returned, leaving the value set but null.
+func unmarshalBSONText(typ byte, data []byte, to string, fromText func([]byte) error) error {
+	if bsonIsNull(typ) {
+		return fromText(nil)
+	}
+	s, err := bsonText(typ, data, to)
+	if err != nil {
+		fromText(nil)
+		return err
+	}
+	return fromText([]byte(s))
+}
+
+// bsonInt decodes an integral BSON number within [min, max].
+func bsonInt(typ byte, data []byte, to string, min, max int64) (int64, error) {
+	var v int64
+	switch typ {
+	case bsonInt32:
+		b, err := bsonFixed(typ, data, 4)
+		if err != nil {
+			return 0, err
+		}
+		v = int64(int32(binary.LittleEndian.Uint32(b)))
+	case bsonInt64:
+		b, err := bsonFixed(typ, data, 8)
+		if err != nil {
+			return 0, err
+		}
+		v = int64(binary.LittleEndian.Uint64(b))
+	case bsonDouble:
+		f, err := bsonFloat(typ, data, to)
+		if err != nil {
+			return 0, err
+		}
+		if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 {
+			return 0, fmt.Errorf("null: cannot decode BSON double %v into %s", f, to)
+		}
+		v = int64(f)
+	default:
+		return 0, bsonTypeError(typ, to)
+	}
+	if v < min || v > max {
+		return 0, overflowf("null: BSON value %d overflows %s", v, to)
+	}
+	return v, nil
+}
+
+// bsonUint decodes an integral, non-negative BSON number up to max.
+func bsonUint(typ byte, data []byte, to string, max uint64) (uint64, error) {
+	v, err := bsonInt(typ, data, to, 0, math.MaxInt64)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(v) > max {
+		return 0, overflowf("null: BSON value %d overflows %s", v, to)
+	}
+	return uint64(v), nil
+}
+
+// bsonUintValue encodes v as a BSON int64, which is the widest integer
+// BSON has.
+func bsonUintValue(v uint64, from string) (byte, []byte, error) {
+	if v > math.MaxInt64 {
+		return 0, nil, fmt.Errorf("null: cannot encode %s value %d as a BSON 64-bit integer", from, v)
+	}
+	return bsonInt64Value(int64(v))
+}
+
+// bsonFloat decodes a BSON double or integer.
+func bsonFloat(typ byte, data []byte, to string) (float64, error) {
+	switch typ {
+	case bsonDouble:
+		b, err := bsonFixed(typ, data, 8)
+		if err != nil {
+			return 0, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case bsonInt32, bsonInt64:
+		v, err := bsonInt(typ, data, to, math.MinInt64, math.MaxInt64)
+		return float64(v), err
+	}
+	return 0, bsonTypeError(typ, to)
+}
+
+// bsonTime decodes a BSON datetime, a BSON timestamp or an RFC 3339 string
+// as a UTC time.
+func bsonTime(typ byte, data []byte, to string) (time.Time, error) {
+	switch typ {
+	case bsonDateTime:
+		b, err := bsonFixed(typ, data, 8)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(int64(binary.LittleEndian.Uint64(b))).UTC(), nil
+	case bsonTimestamp:
+		b, err := bsonFixed(typ, data, 8)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(int64(binary.LittleEndian.Uint32(b[4:])), 0).UTC(), nil
+	case bsonString, bsonSymbol:
+		s, err := bsonReadString(typ, data)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	return time.Time{}, bsonTypeError(typ, to)
+}
+
+// bsonReadBinary decodes BSON binary data and its subtype.
+func bsonReadBinary(typ byte, data []byte, to string) (byte, []byte, error) {
+	if typ != bsonBinary {
+		return 0, nil, bsonTypeError(typ, to)
+	}
+	if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-5 {
+		return 0, nil, bsonInvalid(typ)
+	}
+	subtype, b := data[4], data[5:]
+	if subtype == bsonBinaryOld {
+		if len(b) < 4 || int(binary.LittleEndian.Uint32(b)) != len(b)-4 {
+			return 0, nil, bsonInvalid(typ)
+		}
+		b = b[4:]
+	}
+	return subtype, append([]byte(nil), b...), nil
+}
+
+var (
+	bsonDecimalMaxCoef = new(big.Int).Exp(big.NewInt(10), big.NewInt(34), nil)
+	bsonDecimalLow     = new(big.Int).SetUint64(math.MaxUint64)
+)
+
+const (
+	bsonDecimalBias   = 6176
+	bsonDecimalMinExp = -6176
+	bsonDecimalMaxExp = 6111
+)
+
+// bsonDecimalValue encodes canonical decimal text as a BSON decimal128.
+func bsonDecimalValue(s string) (byte, []byte, error) {
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	exp := 0
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		exp = -(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return 0, nil, fmt.Errorf("null: cannot encode %q as a BSON decimal128", s)
+	}
+	ten := big.NewInt(10)
+	for coef.Cmp(bsonDecimalMaxCoef) >= 0 && exp < bsonDecimalMaxExp {
+		q, r := new(big.Int).QuoRem(coef, ten, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		coef, exp = q, exp+1
+	}
+	if coef.Cmp(bsonDecimalMaxCoef) >= 0 || exp < bsonDecimalMinExp || exp > bsonDecimalMaxExp {
+		return 0, nil, fmt.Errorf("null: %q is out of range for a BSON decimal128", s)
+	}
+
+	low := new(big.Int).And(coef, bsonDecimalLow).Uint64()
+	high := new(big.Int).Rsh(coef, 64).Uint64()
+	high |= uint64(exp+bsonDecimalBias) << 49
+	if neg {
+		high |= 1 << 63
+	}
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data, low)
+	binary.LittleEndian.PutUint64(data[8:], high)
+	return bsonDecimal128, data, nil
+}
+
+// bsonReadDecimal decodes a BSON decimal128 into canonical decimal text.
+// NaN and infinities have no Decimal form and are rejected.
+func bsonReadDecimal(data []byte) (string, error) {
+	b, err := bsonFixed(bsonDecimal128, data, 16)
+	if err != nil {
+		return "", err
+	}
+	low := binary.LittleEndian.Uint64(b)
+	high := binary.LittleEndian.Uint64(b[8:])
+	neg := high>>63 == 1
+
+	var exp int
+	coef := new(big.Int)
+	if (high>>61)&3 == 3 {
+		if (high>>59)&3 == 3 {
+			return "", fmt.Errorf("null: cannot decode a BSON decimal128 NaN or infinity")
+		}
+		// The coefficient cannot fit in 113 bits, so it is non-canonical
+		// and counts as zero.
+		exp = int((high>>47)&0x3FFF) - bsonDecimalBias
+	} else {
+		exp = int((high>>49)&0x3FFF) - bsonDecimalBias
+		coef.SetUint64(high & (1<<49 - 1))
+		coef.Lsh(coef, 64)
+		coef.Or(coef, new(big.Int).SetUint64(low))
+		if coef.Cmp(bsonDecimalMaxCoef) >= 0 {
+			coef.SetInt64(0)
+		}
+	}
+
+	s := coef.String() + "e" + strconv.Itoa(exp)
+	if neg {
+		s = "-" + s
+	}
+	return parseDecimal(s)
+}
+
+// bsonFromJSON encodes JSON text as a BSON value, keeping object keys in
+// order. Integers become int32 or int64 when they fit, and other numbers
+// become doubles.
+func bsonFromJSON(data []byte) (byte, []byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	typ, res, err := bsonFromJSONTokens(dec)
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return 0, nil, fmt.Errorf("null: cannot encode JSON %q as BSON: trailing data", data)
+	}
+	return typ, res, nil
+}
+
+func bsonFromJSONTokens(dec *json.Decoder) (byte, []byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, nil, err
+	}
+	switch x := tok.(type) {
+	case nil:
+		return bsonNull, nil, nil
+	case bool:
+		return bsonBoolValue(x)
+	case string:
+		return bsonStringValue(x)
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			if i >= math.MinInt32 && i <= math.MaxInt32 {
+				return bsonInt32Value(int32(i))
+			}
+			return bsonInt64Value(i)
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return 0, nil, err
+		}
+		return bsonDoubleValue(f)
+	case json.Delim:
+		var elems []byte
+		for i := 0; dec.More(); i++ {
+			key := strconv.Itoa(i)
+			if x == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return 0, nil, err
+				}
+				key = k.(string)
+				if strings.IndexByte(key, 0) >= 0 {
+					return 0, nil, fmt.Errorf("null: cannot encode JSON key %q as BSON", key)
+				}
+			}
+			typ, data, err := bsonFromJSONTokens(dec)
+			if err != nil {
+				return 0, nil, err
+			}
+			elems = appendBSONElement(elems, typ, key, data)
+		}
+		if _, err := dec.Token(); err != nil {
+			return 0, nil, err
+		}
+		if x == '{' {
+			return bsonDocument, bsonDocumentValue(elems), nil
+		}
+		return bsonArray, bsonDocumentValue(elems), nil
+	}
+	return 0, nil, fmt.Errorf("null: unexpected JSON token %v", tok)
+}
+
+// bsonToJSON converts a BSON value to JSON text. Binary data becomes a
+// base64 string, object IDs hex strings and datetimes RFC 3339 strings.
+// BSON types without a JSON counterpart, such as regular expressions, are
+// rejected.
+func bsonToJSON(typ byte, data []byte) ([]byte, error) {
+	switch typ {
+	case bsonNull, bsonUndefined:
+		return NullBytes, nil
+	case bsonBoolean:
+		b, err := bsonFixed(typ, data, 1)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(b[0] != 0)
+	case bsonString, bsonSymbol:
+		s, err := bsonReadString(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(s)
+	case bsonInt32, bsonInt64:
+		v, err := bsonInt(typ, data, "JSON", math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.FormatInt(v, 10)), nil
+	case bsonDouble:
+		f, err := bsonFloat(typ, data, "JSON")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(f)
+	case bsonDecimal128:
+		s, err := bsonReadDecimal(data)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	case bsonDateTime, bsonTimestamp:
+		t, err := bsonTime(typ, data, "JSON")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t)
+	case bsonBinary:
+		_, b, err := bsonReadBinary(typ, data, "JSON")
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(base64.StdEncoding.EncodeToString(b))
+	case bsonObjectID:
+		b, err := bsonFixed(typ, data, 12)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(hex.EncodeToString(b))
+	case bsonDocument, bsonArray:
+		elems, err := bsonElements(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if typ == bsonDocument {
+			buf.WriteByte('{')
+		} else {
+			buf.WriteByte('[')
+		}
+		for i, e := range elems {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if typ == bsonDocument {
+				key, _ := json.Marshal(e.key)
+				buf.Write(key)
+				buf.WriteByte(':')
+			}
+			v, err := bsonToJSON(e.typ, e.data)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(v)
+		}
+		if typ == bsonDocument {
+			buf.WriteByte('}')
+		} else {
+			buf.WriteByte(']')
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, bsonTypeError(typ, "JSON")
+}
+
+// bsonElement is one element of a BSON document or array.
+type bsonElement struct {
+	typ  byte
+	key  string
+	data []byte
+}
+
+// bsonElements splits a BSON document or array into its elements.
+func bsonElements(typ byte, data []byte) ([]bsonElement, error) {
+	if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data) || data[len(data)-1] != 0 {
+		return nil, bsonInvalid(typ)
+	}
+	var res []bsonElement
+	rest := data[4 : len(data)-1]
+	for len(rest) > 0 {
+		et := rest[0]
+		end := bytes.IndexByte(rest[1:], 0)
+		if end < 0 {
+			return nil, bsonInvalid(typ)
+		}
+		key := string(rest[1 : 1+end])
+		rest = rest[2+end:]
+		n, err := bsonValueLen(et, rest)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, bsonElement{typ: et, key: key, data: rest[:n]})
+		rest = rest[n:]
+	}
+	return res, nil
+}
+
+// bsonValueLen returns the length of the value of type typ at the start of
+// data.
+func bsonValueLen(typ byte, data []byte) (int, error) {
+	prefixed := func(extra int) (int, error) {
+		if len(data) < 4 {
+			return 0, bsonInvalid(typ)
+		}
+		n := int(int32(binary.LittleEndian.Uint32(data))) + extra
+		if n < 4 || n > len(data) {
+			return 0, bsonInvalid(typ)
+		}
+		return n, nil
+	}
+	fixed := func(n int) (int, error) {
+		if n > len(data) {
+			return 0, bsonInvalid(typ)
+		}
+		return n, nil
+	}
+	switch typ {
+	case bsonNull, bsonUndefined, bsonMinKey, bsonMaxKey:
+		return 0, nil
+	case bsonBoolean:
+		return fixed(1)
+	case bsonInt32:
+		return fixed(4)
+	case bsonDouble, bsonDateTime, bsonTimestamp, bsonInt64:
+		return fixed(8)
+	case bsonObjectID:
+		return fixed(12)
+	case bsonDecimal128:
+		return fixed(16)
+	case bsonString, bsonSymbol, bsonJavaScript:
+		return prefixed(4)
+	case bsonBinary:
+		return prefixed(5)
+	case bsonDocument, bsonArray, bsonCodeScope:
+		return prefixed(0)
+	case bsonDBPointer:
+		n, err := prefixed(4)
+		if err != nil {
+			return 0, err
+		}
+		return fixed(n + 12)
+	case bsonRegex:
+		first := bytes.IndexByte(data, 0)
+		if first < 0 {
+			return 0, bsonInvalid(typ)
+		}
+		second := bytes.IndexByte(data[first+1:], 0)
+		if second < 0 {
+			return 0, bsonInvalid(typ)
+		}
+		return first + second + 2, nil
+	}
+	return 0, fmt.Errorf("null: unknown BSON %s", bsonTypeName(typ))
+}
@@ -0,0 +1,235 @@
+package null
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type bsonMarshaler interface {
+	MarshalBSONValue() (byte, []byte, error)
+}
+
+func bsonHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	maybePanic(err)
+	return b
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	u, _ := url.Parse("https://example.com/a?b=c")
+	tests := []struct {
+		valid interface{}
+		typ   byte
+	}{
+		{BoolFrom(true), bsonBoolean},
+		{ByteFrom('x'), bsonString},
+		{RuneFrom('€'), bsonString},
+		{BytesFrom([]byte("hello")), bsonBinary},
+		{RawBytesFrom([]byte("hello")), bsonString},
+		{JSONFrom([]byte(`{"b":1,"a":[true,null,1.5,"s"]}`)), bsonDocument},
+		{StringFrom("hello"), bsonString},
+		{StringFrom(""), bsonString},
+		{IntFrom(-1), bsonInt64},
+		{Int8From(-8), bsonInt32},
+		{Int16From(-16), bsonInt32},
+		{Int32From(-32), bsonInt32},
+		{Int64From(-1 << 40), bsonInt64},
+		{UintFrom(1), bsonInt64},
+		{Uint8From(255), bsonInt32},
+		{Uint16From(65535), bsonInt32},
+		{Uint32From(4294967295), bsonInt64},
+		{Uint64From(math.MaxInt64), bsonInt64},
+		{Float32From(1.5), bsonDouble},
+		{Float64From(-2.5), bsonDouble},
+		{TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 21e6, time.UTC)), bsonDateTime},
+		{DateOf(2012, 12, 21), bsonDateTime},
+		{TimeOfDayOf(15, 4, 5, 0), bsonString},
+		{DurationFrom(90 * time.Minute), bsonInt64},
+		{DecimalFrom("-1.10"), bsonDecimal128},
+		{UUIDFrom(uuidValue), bsonBinary},
+		{URLFrom(u), bsonString},
+		{IPFrom(netip.MustParseAddr("fe80::1%eth0")), bsonString},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), bsonString},
+		{BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 100)), bsonString},
+		{MapFrom(map[string]interface{}{"a": 1.0, "b": []interface{}{"c"}}), bsonDocument},
+		{StringSliceFrom([]string{"a", "", "c"}), bsonArray},
+		{Int64SliceFrom([]int64{1, -2, 3}), bsonArray},
+		{EnumFrom(statusActive), bsonString},
+		{ValFrom(testPoint{1, 2}), bsonDocument},
+		{ValFrom(time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)), bsonDateTime},
+	}
+	for _, test := range tests {
+		typ, data, err := test.valid.(bsonMarshaler).MarshalBSONValue()
+		maybePanic(err)
+		if typ != test.typ {
+			t.Errorf("%T.MarshalBSONValue() type = %s, want %s", test.valid, bsonTypeName(typ), bsonTypeName(test.typ))
+		}
+
+		ptr := reflect.New(reflect.TypeOf(test.valid))
+		err = ptr.Interface().(bsonValueUnmarshaler).UnmarshalBSONValue(typ, data)
+		maybePanic(err)
+		if back := ptr.Elem().Interface(); !reflect.DeepEqual(back, test.valid) {
+			t.Errorf("%T BSON round trip = %#v, want %#v", test.valid, back, test.valid)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(test.valid)).Interface()
+		typ, data, err = zero.(bsonMarshaler).MarshalBSONValue()
+		maybePanic(err)
+		if typ != bsonNull || data != nil {
+			t.Errorf("null %T.MarshalBSONValue() = %s %x, want null", test.valid, bsonTypeName(typ), data)
+		}
+
+		ptr = reflect.New(reflect.TypeOf(test.valid))
+		err = ptr.Interface().(bsonValueUnmarshaler).UnmarshalBSONValue(bsonNull, nil)
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalBSONValue(null) = %#v, want set null", test.valid, back.Interface())
+		}
+	}
+}
+
+func TestBSONEncoding(t *testing.T) {
+	tests := []struct {
+		in   bsonMarshaler
+		typ  byte
+		data string
+	}{
+		{Int32From(1), bsonInt32, "01000000"},
+		{Int64From(-1), bsonInt64, "ffffffffffffffff"},
+		{StringFrom("hi"), bsonString, "03000000686900"},
+		{BoolFrom(false), bsonBoolean, "00"},
+		{TimeFrom(time.Unix(1, 5e8)), bsonDateTime, "dc05000000000000"},
+		{BytesFrom([]byte{0xab}), bsonBinary, "0100000000ab"},
+		{DecimalFrom("1.10"), bsonDecimal128, "6e000000000000000000000000003c30"},
+		{DecimalFrom("-1.10"), bsonDecimal128, "6e000000000000000000000000003cb0"},
+		{DecimalFrom("12345678901234567890123456789012.34"), bsonDecimal128, "f2af967ed05c82de3297ff6fde3c3c30"},
+		{JSONFrom([]byte(`{"b":1,"a":[2]}`)), bsonDocument, "1b000000106200010000000461000c000000103000020000000000"},
+		{StringSliceFrom(nil), bsonArray, "0500000000"},
+		{MapFrom(nil), bsonDocument, "0500000000"},
+	}
+	for _, test := range tests {
+		typ, data, err := test.in.MarshalBSONValue()
+		maybePanic(err)
+		if typ != test.typ || !bytes.Equal(data, bsonHex(test.data)) {
+			t.Errorf("%T.MarshalBSONValue() = %s %x, want %s %s", test.in, bsonTypeName(typ), data, bsonTypeName(test.typ), test.data)
+		}
+	}
+}
+
+func TestBSONRelatedTypes(t *testing.T) {
+	int32Data := func(v int32) []byte {
+		_, data, _ := bsonInt32Value(v)
+		return data
+	}
+	doubleData := func(v float64) []byte {
+		_, data, _ := bsonDoubleValue(v)
+		return data
+	}
+	_, strData, _ := bsonStringValue("2012-12-21")
+	timestamp := make([]byte, 8)
+	binary.LittleEndian.PutUint32(timestamp[4:], 1356124881)
+
+	tests := []struct {
+		out  bsonValueUnmarshaler
+		typ  byte
+		data []byte
+		want interface{}
+	}{
+		{&Int64{}, bsonInt32, int32Data(5), Int64From(5)},
+		{&Int64{}, bsonDouble, doubleData(-3), Int64From(-3)},
+		{&Uint8{}, bsonInt32, int32Data(200), Uint8From(200)},
+		{&Float64{}, bsonInt32, int32Data(7), Float64From(7)},
+		{&Time{}, bsonTimestamp, timestamp, TimeFrom(time.Unix(1356124881, 0).UTC())},
+		{&Date{}, bsonString, strData, DateOf(2012, 12, 21)},
+		{&Date{}, bsonDateTime, bsonHex("0064c2ba3b010000"), DateOf(2012, 12, 21)},
+		{&Decimal{}, bsonDecimal128, bsonHex("01000000000000000000000000005430"), DecimalFrom("10000000000")},
+		{&Decimal{}, bsonDecimal128, bsonHex("01000000000000000000000000003430"), DecimalFrom("0.000001")},
+		{&Decimal{}, bsonInt32, int32Data(-4), DecimalFrom("-4")},
+		{&Decimal{}, bsonDouble, doubleData(0.25), DecimalFrom("0.25")},
+		{&BigInt{}, bsonInt32, int32Data(12), BigIntFrom(big.NewInt(12))},
+		{&Duration{}, bsonInt32, int32Data(1000), DurationFrom(time.Microsecond)},
+		{&Bytes{}, bsonBinary, bsonHex("050000000201000000ab"), BytesFrom([]byte{0xab})},
+		{&UUID{}, bsonBinary, append(bsonHex("1000000003"), uuidValue[:]...), UUIDFrom(uuidValue)},
+		{&String{}, bsonSymbol, bsonHex("03000000686900"), StringFrom("hi")},
+		{&Val[int]{}, bsonInt64, bsonHex("0900000000000000"), ValFrom(9)},
+		{&String{}, bsonUndefined, nil, String{Set: true}},
+	}
+	for _, test := range tests {
+		err := test.out.UnmarshalBSONValue(test.typ, test.data)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalBSONValue(%s %x) = %#v, want %#v", test.out, bsonTypeName(test.typ), test.data, got, test.want)
+		}
+	}
+}
+
+func TestBSONToJSON(t *testing.T) {
+	oid := bsonHex("5f1d7a3e9b1e8c2a4d6f0b12")
+	var elems []byte
+	elems = appendBSONElement(elems, bsonObjectID, "id", oid)
+	elems = appendBSONElement(elems, bsonDateTime, "at", bsonHex("0064c2ba3b010000"))
+	elems = appendBSONElement(elems, bsonBinary, "bin", bsonHex("0100000000ab"))
+	elems = appendBSONElement(elems, bsonDecimal128, "dec", bsonHex("6e000000000000000000000000003c30"))
+	elems = appendBSONElement(elems, bsonNull, "nil", nil)
+
+	var j JSON
+	err := j.UnmarshalBSONValue(bsonDocument, bsonDocumentValue(elems))
+	maybePanic(err)
+	want := `{"id":"5f1d7a3e9b1e8c2a4d6f0b12","at":"2012-12-21T00:00:00Z","bin":"qw==","dec":1.10,"nil":null}`
+	if string(j.JSON) != want {
+		t.Errorf("JSON.UnmarshalBSONValue() = %s, want %s", j.JSON, want)
+	}
+}
+
+func TestBSONErrors(t *testing.T) {
+	_, str, _ := bsonStringValue("abc")
+	_, big32, _ := bsonInt32Value(500)
+	_, neg, _ := bsonInt32Value(-1)
+	_, frac, _ := bsonDoubleValue(1.5)
+	tests := []struct {
+		out  bsonValueUnmarshaler
+		typ  byte
+		data []byte
+	}{
+		{&Int64{}, bsonString, str},
+		{&Int8{}, bsonInt32, big32},
+		{&Int64{}, bsonDouble, frac},
+		{&Uint64{}, bsonInt32, neg},
+		{&Bool{}, bsonInt32, neg},
+		{&Int32{}, bsonInt32, []byte{1, 2}},
+		{&String{}, bsonString, []byte{1, 0, 0, 0}},
+		{&UUID{}, bsonBinary, bsonHex("0100000004ab")},
+		{&Decimal{}, bsonDecimal128, bsonHex("0000000000000000000000000000007c")},
+		{&Enum[testStatus]{}, bsonString, str},
+		{&Map{}, bsonArray, bsonHex("0500000000")},
+		{&StringSlice{}, bsonDocument, bsonHex("0500000000")},
+		{&JSON{}, bsonRegex, bsonHex("610000")},
+		{&Val[int]{}, bsonString, str},
+	}
+	for _, test := range tests {
+		if err := test.out.UnmarshalBSONValue(test.typ, test.data); err == nil {
+			t.Errorf("%T.UnmarshalBSONValue(%s %x) should fail", test.out, bsonTypeName(test.typ), test.data)
+		}
+		if reflect.ValueOf(test.out).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalBSONValue", test.out)
+		}
+	}
+
+	for _, in := range []bsonMarshaler{
+		Uint64From(math.MaxUint64),
+		DecimalFrom("1234567890123456789012345678901234.5"),
+		RawBytesFrom([]byte{0xff}),
+	} {
+		if _, _, err := in.MarshalBSONValue(); err == nil {
+			t.Errorf("%T.MarshalBSONValue() should fail for %v", in, in)
+		}
+	}
+}
@@ -2,12 +2,35 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+// ByteJSONNumber makes Byte marshal to a JSON number, such as 7, rather
+// than a one-character JSON string. UnmarshalJSON accepts both forms.
+var ByteJSONNumber = false
+
+// ByteValueNumber makes Byte store as a number, for integer columns such as
+// TINYINT UNSIGNED in MySQL or SMALLINT in Postgres. Value then returns an
+// int64 instead of []byte{b}, which suits CHAR(1) and BINARY(1) columns and
+// which MySQL would otherwise store in a TINYINT as the character code or
+// reject. Scan accepts an int64 either way, and with ByteValueNumber reads
+// text such as "65", as the MySQL text protocol returns it, as a number.
+var ByteValueNumber = false
+
 // Byte is an nullable int.
 type Byte struct {
 	Byte  byte
@@ -37,6 +60,16 @@ func ByteFromPtr(b *byte) Byte {
 	return NewByte(*b, true)
 }
 
+// ByteFromNonZero creates a new Byte that will be null if b is 0.
+func ByteFromNonZero(b byte) Byte {
+	return NewByte(b, b != 0)
+}
+
+// ByteFromNullByte creates a new Byte from a sql.NullByte. It is always Set.
+func ByteFromNullByte(n sql.NullByte) Byte {
+	return NewByte(n.Byte, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (b Byte) IsValid() bool {
@@ -48,8 +81,42 @@ func (b Byte) IsSet() bool {
 	return b.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (b Byte) IsNull() bool {
+	return b.Set && !b.Valid
+}
+
+// Equal reports whether b and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (b Byte) Equal(other Byte) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	return b.Byte == other.Byte
+}
+
+// ExactEqual is like Equal, but also requires b and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (b Byte) ExactEqual(other Byte) bool {
+	return b.Set == other.Set && b.Equal(other)
+}
+
+// Compare returns -1 if b is less than other, 0 if they are equal and +1
+// if b is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (b Byte) Compare(other Byte) int {
+	if !b.Valid || !other.Valid {
+		return compareNull(b.Valid, other.Valid)
+	}
+	return cmp.Compare(b.Byte, other.Byte)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (b *Byte) UnmarshalJSON(data []byte) error {
+// It accepts a one-character string, as MarshalJSON writes it, or a whole
+// JSON number from 0 to 255.
+func (b *Byte) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Byte", b, data)
 	b.Set = true
 
 	if len(data) == 0 || bytes.Equal(data, NullBytes) {
@@ -58,13 +125,36 @@ func (b *Byte) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	if data[0] != '"' {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		b.Byte, err = parseByte(n.String())
+		b.Valid = err == nil
+		return err
+	}
+
 	var x string
 	if err := json.Unmarshal(data, &x); err != nil {
 		return err
 	}
 
+	if len(x) == 0 {
+		b.Valid = false
+		b.Byte = 0
+		return nil
+	}
+
 	if len(x) > 1 {
-		return errors.New("json: cannot convert to byte, text len is greater than one")
+		// A byte above 0x7f arrives as the character of the same code
+		// point, as MarshalJSON writes it.
+		r, size := utf8.DecodeRuneInString(x)
+		if size != len(x) || r > math.MaxUint8 {
+			return fmt.Errorf("json: cannot convert %q to byte, text len is greater than one", x)
+		}
+		b.Byte, b.Valid = byte(r), true
+		return nil
 	}
 
 	b.Byte = x[0]
@@ -73,7 +163,10 @@ func (b *Byte) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (b *Byte) UnmarshalText(text []byte) error {
+// A single character is the byte itself, so "7" is 0x37, as MarshalText
+// writes it. Longer text must be a decimal number from 0 to 255.
+func (b *Byte) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Byte", text)
 	b.Set = true
 	if len(text) == 0 {
 		b.Valid = false
@@ -81,7 +174,9 @@ func (b *Byte) UnmarshalText(text []byte) error {
 	}
 
 	if len(text) > 1 {
-		return errors.New("text: cannot convert to byte, text len is greater than one")
+		b.Byte, err = parseByte(string(text))
+		b.Valid = err == nil
+		return err
 	}
 
 	b.Valid = true
@@ -90,19 +185,67 @@ func (b *Byte) UnmarshalText(text []byte) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// It encodes a one-character string, escaped as needed; a byte above 0x7f
+// is the character of that code point, such as "\u00ff" for 0xff. With
+// ByteJSONNumber it encodes the value as a number instead.
 func (b Byte) MarshalJSON() ([]byte, error) {
 	if !b.Valid {
 		return NullBytes, nil
 	}
-	return []byte{'"', b.Byte, '"'}, nil
+	return b.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to buf.
+func (b Byte) AppendJSON(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return append(buf, "null"...), nil
+	}
+	if ByteJSONNumber {
+		return strconv.AppendUint(buf, uint64(b.Byte), 10), nil
+	}
+	if b.Byte >= utf8.RuneSelf {
+		// A lone byte above 0x7f is not UTF-8, so it is written as the
+		// character of the same code point instead.
+		return fmt.Appendf(buf, `"\u%04x"`, b.Byte), nil
+	}
+	c := [1]byte{b.Byte}
+	return appendJSONString(buf, c[:]), nil
+}
+
+// parseByte parses the decimal number s as a byte value.
+func parseByte(s string) (byte, error) {
+	x, err := parseUint(s, 8, "null.Byte")
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, overflowf("null: %s does not fit in null.Byte", s)
+	case err != nil && errors.Is(err, strconv.ErrSyntax) && strings.ContainsAny(s, ".eE"):
+		return 0, fmt.Errorf("null: cannot store %s in null.Byte, it is not a whole number", s)
+	case err != nil && errors.Is(err, strconv.ErrSyntax):
+		return 0, fmt.Errorf("null: cannot parse %q as a byte", s)
+	}
+	return byte(x), err
 }
 
 // MarshalText implements encoding.TextMarshaler.
+// It returns the byte itself, and empty text if this Byte is null, so that
+// UnmarshalText restores every value exactly, including 0x00 and spaces.
+// Formats built on it may not: CSV readers can trim spaces, and XML cannot
+// hold most control characters.
 func (b Byte) MarshalText() ([]byte, error) {
 	if !b.Valid {
 		return []byte{}, nil
 	}
-	return []byte{b.Byte}, nil
+	return b.AppendText(make([]byte, 0, 1))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to buf. Nothing is appended if b is null.
+func (b Byte) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return buf, nil
+	}
+	return append(buf, b.Byte), nil
 }
 
 // SetValid changes this Byte's value and also sets it to be non-null.
@@ -112,6 +255,22 @@ func (b *Byte) SetValid(n byte) {
 	b.Set = true
 }
 
+// SetNull sets this Byte to an explicit null, zeroing its value.
+func (b *Byte) SetNull() {
+	*b = Byte{Set: true}
+}
+
+// Unset resets this Byte to the zero value, as if it was never set.
+func (b *Byte) Unset() {
+	*b = Byte{}
+}
+
+// SetPtr changes this Byte to the value p points to, or to an explicit
+// null if p is nil.
+func (b *Byte) SetPtr(p *byte) {
+	*b = ByteFromPtr(p)
+}
+
 // Ptr returns a pointer to this Byte's value, or a nil pointer if this Byte is null.
 func (b Byte) Ptr() *byte {
 	if !b.Valid {
@@ -120,40 +279,325 @@ func (b Byte) Ptr() *byte {
 	return &b.Byte
 }
 
-// IsZero returns true for invalid Bytes, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (b Byte) ValueOrZero() byte {
+	if !b.Valid {
+		return 0
+	}
+	return b.Byte
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (b Byte) Or(def byte) byte {
+	if !b.Valid {
+		return def
+	}
+	return b.Byte
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (b Byte) Val() byte {
+	return b.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (b Byte) Get() (byte, bool) {
+	return b.ValueOrZero(), b.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if b is
+// null.
+func (b Byte) MustGet() byte {
+	if !b.Valid {
+		panic(&NullError{Type: "null.Byte"})
+	}
+	return b.Byte
+}
+
+// IsZero returns true if this Byte was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (b Byte) IsZero() bool {
-	return !b.Valid
+	return !b.Set && !b.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Byte is null, and the text form otherwise.
+func (b Byte) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	data, err := b.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Byte if the input is null or ~.
+func (b *Byte) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	b.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		b.Byte, b.Valid = 0, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		b.Byte, b.Valid = 0, false
+		return err
+	}
+	return b.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Byte is null.
+func (b Byte) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Byte.
+func (b *Byte) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, b.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Byte is null.
+func (b Byte) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Byte.
+func (b *Byte) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this Byte is null.
+func (b Byte) MarshalBSONValue() (byte, []byte, error) {
+	if !b.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(b.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (b *Byte) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.Byte", b.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this Byte is null.
+func (b Byte) MarshalMsgpack() ([]byte, error) {
+	if !b.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, b.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (b *Byte) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.Byte", b.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this Byte is null.
+func (b Byte) MarshalCBOR() ([]byte, error) {
+	if !b.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, b.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (b *Byte) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.Byte", b.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (b Byte) MarshalGQL(w io.Writer) {
+	marshalGQL(w, b.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Byte.
+func (b *Byte) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, b.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Byte is
+// null.
+func (b Byte) MarshalCSV() (string, error) {
+	return marshalCSV(b.Valid, b.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Byte.
+func (b *Byte) UnmarshalCSV(cell string) error {
+	return b.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Byte is null.
+func (b Byte) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(b.Valid, b.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Byte.
+func (b *Byte) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, b.UnmarshalText, b.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random letter or digit, or an explicit null if shouldBeNull is true.
+func (b *Byte) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*b = NewByte(0, false)
+		return
+	}
+	b.SetValid(randomString(nextInt, 1)[0])
+}
+
+// Generate implements testing/quick's Generator.
+func (b Byte) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewByte(0, false), func() Byte {
+		return ByteFrom(byte(rand.Intn(256)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (b Byte) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Set, b.Valid, func() ([]byte, error) {
+		return []byte{b.Byte}, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Byte) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Byte")
+	if err != nil {
+		return err
+	}
+	var v byte
+	if valid {
+		if err = binaryFixed(p, "null.Byte", 1); err != nil {
+			return err
+		}
+		v = p[0]
+	}
+	b.Byte, b.Valid, b.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (b *Byte) Scan(value interface{}) error {
+func (b *Byte) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Byte", value)
 	if value == nil {
-		b.Byte, b.Valid, b.Set = 0, false, false
+		b.Byte, b.Valid, b.Set = 0, false, true
 		return nil
 	}
 
-	val := value.(string)
+	var val []byte
+	switch x := value.(type) {
+	case string:
+		val = []byte(x)
+	case []byte:
+		val = x
+	case int64:
+		if x < 0 || x > math.MaxUint8 {
+			return overflowf("null: %d does not fit in null.Byte", x)
+		}
+		b.Byte, b.Valid, b.Set = byte(x), true, true
+		return nil
+	default:
+		return fmt.Errorf("null: cannot scan type %T into null.Byte: %v", value, value)
+	}
+
 	if len(val) == 0 {
-		b.Byte, b.Valid, b.Set = 0, false, false
+		b.Byte, b.Valid, b.Set = 0, false, true
+		return nil
+	}
+	if ByteValueNumber {
+		v, err := parseByte(string(val))
+		if err != nil {
+			return err
+		}
+		b.Byte, b.Valid, b.Set = v, true, true
 		return nil
 	}
+	if len(val) > 1 {
+		return fmt.Errorf("null: cannot scan %q into null.Byte: text len is greater than one", val)
+	}
 
 	b.Byte, b.Valid, b.Set = val[0], true, true
 	return nil
 }
 
 // Value implements the driver Valuer interface.
+// It returns []byte{b}, or an int64 with ByteValueNumber.
 func (b Byte) Value() (driver.Value, error) {
 	if !b.Valid {
 		return nil, nil
 	}
+	if ByteValueNumber {
+		return int64(b.Byte), nil
+	}
 	return []byte{b.Byte}, nil
 }
 
-// String implements the Stringer interface
+// ToNullByte converts b to a sql.NullByte, dropping Set.
+func (b Byte) ToNullByte() sql.NullByte {
+	return sql.NullByte{Byte: b.Byte, Valid: b.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (b Byte) String() string {
-	if b.Valid {
-		return fmt.Sprintf("Byte(%02x)", b.Byte)
+	return fmt.Sprint(b)
+}
+
+// GoString implements the GoStringer interface, formatting b as the Go
+// expression that builds it.
+func (b Byte) GoString() string {
+	return goString("Byte", "", "Byte", strconv.QuoteRune(rune(b.Byte)), b.Byte == 0, b.Valid, b.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// b with the verb if it is valid.
+func (b Byte) Format(state fmt.State, verb rune) {
+	payload := interface{}(b.Byte)
+	if verb == 'v' || verb == 's' {
+		payload = string(rune(b.Byte))
+	}
+	formatValue(state, verb, b, b.Valid, b.Set, payload)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the character, or to nil if this Byte is
+// null.
+func (b Byte) LogValue() slog.Value {
+	if !b.Valid {
+		return logNull
 	}
-	return "Byte(invalid)"
+	return slog.StringValue(string(rune(b.Byte)))
 }
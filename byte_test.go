@@ -1,7 +1,12 @@
 package null
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -43,12 +48,52 @@ func TestUnmarshalByte(t *testing.T) {
 
 	var invalid Byte
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullByte(t, invalid, "invalid json")
 }
 
+func TestUnmarshalByteInStruct(t *testing.T) {
+	type byteInStruct struct {
+		B Byte `json:"b"`
+	}
+
+	tests := []struct {
+		in    string
+		valid bool
+		set   bool
+		err   bool
+	}{
+		{in: `{"b":""}`, valid: false, set: true},
+		{in: `{"b":null}`, valid: false, set: true},
+		{in: `{}`, valid: false, set: false},
+		{in: `{"b":"b"}`, valid: true, set: true},
+		{in: `{"b":"bb"}`, err: true},
+	}
+
+	for _, test := range tests {
+		var v byteInStruct
+		err := json.Unmarshal([]byte(test.in), &v)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected error", test.in)
+			} else if !strings.Contains(err.Error(), `"bb"`) {
+				t.Errorf("%s: error should name the input: %v", test.in, err)
+			}
+			continue
+		}
+		maybePanic(err)
+		if v.B.Valid != test.valid || v.B.Set != test.set {
+			t.Errorf("%s: got valid=%t set=%t, want valid=%t set=%t", test.in, v.B.Valid, v.B.Set, test.valid, test.set)
+		}
+		if test.valid {
+			assertByte(t, v.B, test.in)
+		}
+	}
+}
+
 func TestUnmarshalNonByteegerNumber(t *testing.T) {
 	var i Byte
 	err := json.Unmarshal(float64JSON, &i)
@@ -57,6 +102,131 @@ func TestUnmarshalNonByteegerNumber(t *testing.T) {
 	}
 }
 
+func TestUnmarshalByteNumber(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want byte
+		err  error
+	}{
+		{in: `0`, want: 0},
+		{in: `255`, want: 255},
+		{in: `7`, want: 7},
+		{in: `256`, err: ErrOverflow},
+		{in: `-1`, err: ErrOverflow},
+		{in: `7.5`, err: ErrInvalidInput},
+		{in: `"a"`, want: 'a'},
+		{in: `"ab"`, err: ErrInvalidInput},
+		{in: `true`, err: ErrInvalidInput},
+	} {
+		var b Byte
+		err := json.Unmarshal([]byte(test.in), &b)
+		if test.err != nil {
+			if te := new(json.UnmarshalTypeError); !errors.Is(err, test.err) && (typeErrorsMatchKinds || !errors.As(err, &te)) {
+				t.Errorf("UnmarshalJSON(%s) error = %v, want %v", test.in, err, test.err)
+			}
+			if b.Valid {
+				t.Errorf("UnmarshalJSON(%s) should be null after an error", test.in)
+			}
+			continue
+		}
+		maybePanic(err)
+		if !b.Valid || b.Byte != test.want {
+			t.Errorf("UnmarshalJSON(%s) = %#v, want %d", test.in, b, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		in   string
+		want byte
+		err  error
+	}{
+		{in: "7", want: '7'},
+		{in: "07", want: 7},
+		{in: "255", want: 255},
+		{in: "256", err: ErrOverflow},
+		{in: "-1", err: ErrOverflow},
+		{in: "ab", err: ErrInvalidInput},
+	} {
+		var b Byte
+		err := b.UnmarshalText([]byte(test.in))
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("UnmarshalText(%q) error = %v, want %v", test.in, err, test.err)
+			}
+			continue
+		}
+		maybePanic(err)
+		if !b.Valid || b.Byte != test.want {
+			t.Errorf("UnmarshalText(%q) = %#v, want %d", test.in, b, test.want)
+		}
+	}
+}
+
+func TestByteJSONNumber(t *testing.T) {
+	ByteJSONNumber = true
+	defer func() { ByteJSONNumber = false }()
+
+	data, err := json.Marshal(ByteFrom(7))
+	maybePanic(err)
+	assertJSONEquals(t, data, "7", "ByteJSONNumber marshal")
+
+	var b Byte
+	err = json.Unmarshal(data, &b)
+	maybePanic(err)
+	if b != ByteFrom(7) {
+		t.Errorf("ByteJSONNumber round trip = %#v", b)
+	}
+
+	data, err = json.Marshal(NewByte(0, false))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "ByteJSONNumber null marshal")
+}
+
+func TestByteValueNumber(t *testing.T) {
+	v, err := ByteFrom('A').Value()
+	maybePanic(err)
+	if !reflect.DeepEqual(v, driver.Value([]byte{'A'})) {
+		t.Errorf("Value() = %#v, want []byte{'A'}", v)
+	}
+
+	ByteValueNumber = true
+	defer func() { ByteValueNumber = false }()
+
+	v, err = ByteFrom(200).Value()
+	maybePanic(err)
+	if v != driver.Value(int64(200)) {
+		t.Errorf("ByteValueNumber Value() = %#v, want int64(200)", v)
+	}
+	v, err = NewByte(0, false).Value()
+	maybePanic(err)
+	if v != nil {
+		t.Errorf("ByteValueNumber null Value() = %#v, want nil", v)
+	}
+
+	for _, test := range []struct {
+		in   interface{}
+		want Byte
+	}{
+		{int64(200), ByteFrom(200)},
+		{[]byte("65"), ByteFrom(65)},
+		{"7", ByteFrom(7)},
+		{[]byte{}, NewByte(0, false)},
+	} {
+		var b Byte
+		err := b.Scan(test.in)
+		maybePanic(err)
+		if b != test.want {
+			t.Errorf("ByteValueNumber Scan(%#v) = %#v, want %#v", test.in, b, test.want)
+		}
+	}
+	for _, in := range []interface{}{"256", []byte("A"), int64(-1)} {
+		var b Byte
+		if err := b.Scan(in); err == nil {
+			t.Errorf("ByteValueNumber Scan(%#v) should fail, got %#v", in, b)
+		}
+	}
+}
+
 func TestTextUnmarshalByte(t *testing.T) {
 	var i Byte
 	err := i.UnmarshalText([]byte("b"))
@@ -116,7 +286,12 @@ func TestByteIsZero(t *testing.T) {
 	}
 
 	null := NewByte(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Byte
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -139,10 +314,53 @@ func TestByteScan(t *testing.T) {
 	maybePanic(err)
 	assertByte(t, i, "scanned int")
 
+	var bs Byte
+	err = bs.Scan([]byte("b"))
+	maybePanic(err)
+	assertByte(t, bs, "scanned []byte")
+
+	var num Byte
+	err = num.Scan(int64('b'))
+	maybePanic(err)
+	assertByte(t, num, "scanned int64")
+
 	var null Byte
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullByte(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty Byte
+	err = empty.Scan("")
+	maybePanic(err)
+	assertNullByte(t, empty, "scanned empty string")
+
+	var emptyBytes Byte
+	err = emptyBytes.Scan([]byte{})
+	maybePanic(err)
+	assertNullByte(t, emptyBytes, "scanned empty []byte")
+
+	var long Byte
+	if err = long.Scan("bb"); err == nil {
+		t.Error("expected error scanning multi-char string")
+	}
+	assertNullByte(t, long, "scanned long string")
+
+	for _, v := range []int64{-1, 256} {
+		var overflow Byte
+		if err = overflow.Scan(v); err == nil {
+			t.Errorf("expected error scanning %d", v)
+		}
+		assertNullByte(t, overflow, "scanned out of range int64")
+	}
+
+	var wrong Byte
+	if err = wrong.Scan(1.5); err == nil {
+		t.Error("expected error scanning float64")
+	}
+	assertNullByte(t, wrong, "scanned wrong type")
 }
 
 func assertByte(t *testing.T, i Byte, from string) {
@@ -159,3 +377,101 @@ func assertNullByte(t *testing.T, i Byte, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestByteRoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint8; i++ {
+		in := ByteFrom(byte(i))
+		data, err := json.Marshal(in)
+		maybePanic(err)
+		if !json.Valid(data) {
+			t.Errorf("json.Marshal(%#x) = %q, which is not valid JSON", i, data)
+			continue
+		}
+		var fromJSON Byte
+		err = json.Unmarshal(data, &fromJSON)
+		maybePanic(err)
+		if !fromJSON.ExactEqual(in) {
+			t.Errorf("JSON round trip of %#x via %s = %#v", i, data, fromJSON)
+		}
+
+		text, err := in.MarshalText()
+		maybePanic(err)
+		var fromText Byte
+		err = fromText.UnmarshalText(text)
+		maybePanic(err)
+		if !fromText.ExactEqual(in) {
+			t.Errorf("text round trip of %#x via %q = %#v", i, text, fromText)
+		}
+	}
+
+	for _, test := range []struct {
+		in   Byte
+		json string
+	}{
+		{ByteFrom('"'), `"\""`},
+		{ByteFrom('\\'), `"\\"`},
+		{ByteFrom('\n'), `"\n"`},
+		{ByteFrom(0x1f), `"\u001f"`},
+		{ByteFrom(0), `"\u0000"`},
+		{ByteFrom(0x7f), "\"\x7f\""},
+		{ByteFrom(0x80), `"\u0080"`},
+		{ByteFrom(0xff), `"\u00ff"`},
+		{ByteFrom('a'), `"a"`},
+	} {
+		data, err := test.in.MarshalJSON()
+		maybePanic(err)
+		if string(data) != test.json {
+			t.Errorf("%#v.MarshalJSON() = %s, want %s", test.in, data, test.json)
+		}
+	}
+
+	// Other escapes of the same characters decode too.
+	for in, want := range map[string]byte{
+		`"\u0022"`: '"',
+		`"\/"`:     '/',
+		`"\u005c"`: '\\',
+		`"\t"`:     '\t',
+		`"\u00E9"`: 0xe9,
+		`"é"`:      0xe9,
+	} {
+		var b Byte
+		maybePanic(json.Unmarshal([]byte(in), &b))
+		if !b.ExactEqual(ByteFrom(want)) {
+			t.Errorf("Unmarshal(%s) = %#v, want %#x", in, b, want)
+		}
+	}
+
+	null := NewByte(0, false)
+	text, err := null.MarshalText()
+	maybePanic(err)
+	var fromText Byte
+	err = fromText.UnmarshalText(text)
+	maybePanic(err)
+	if !fromText.ExactEqual(null) {
+		t.Errorf("text round trip of null via %q = %#v", text, fromText)
+	}
+
+	var wide Byte
+	if err := json.Unmarshal([]byte(`"\u0100"`), &wide); err == nil {
+		t.Errorf("U+0100 should not unmarshal into a Byte, got %#v", wide)
+	}
+}
+
+func TestByteString(t *testing.T) {
+	for _, test := range []struct {
+		b    Byte
+		want string
+	}{
+		{ByteFrom('a'), "a"},
+		{ByteFrom(' '), " "},
+		{ByteFrom('\''), "'"},
+		{ByteFrom(7), "\a"},
+		{ByteFrom(0xff), "ÿ"},
+		{NewByte('a', false), "<null>"},
+		{Byte{}, "<unset>"},
+	} {
+		if got := test.b.String(); got != test.want {
+			t.Errorf("%#v.String() = %s, want %s", test.b, got, test.want)
+		}
+	}
+}
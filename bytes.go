@@ -2,9 +2,16 @@ package null
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
 
 	"github.com/sisoftrg/null/convert"
 )
@@ -19,7 +26,8 @@ type Bytes struct {
 	Set   bool
 }
 
-// NewBytes creates a new Bytes
+// NewBytes creates a new Bytes. It shares b with the caller instead of
+// copying it; see BytesFromCopy and Clone.
 func NewBytes(b []byte, valid bool) Bytes {
 	return Bytes{
 		Bytes: b,
@@ -28,9 +36,16 @@ func NewBytes(b []byte, valid bool) Bytes {
 	}
 }
 
-// BytesFrom creates a new Bytes that will be invalid if nil.
+// BytesFrom creates a new valid Bytes. A nil b is a valid empty value like
+// []byte{}, which marshals to the JSON string "" rather than null; use
+// BytesNull or BytesFromPtr for a null.
 func BytesFrom(b []byte) Bytes {
-	return NewBytes(b, b != nil)
+	return NewBytes(b, true)
+}
+
+// BytesNull creates a new Bytes that is an explicit null.
+func BytesNull() Bytes {
+	return NewBytes(nil, false)
 }
 
 // BytesFromPtr creates a new Bytes that will be invalid if nil.
@@ -42,6 +57,32 @@ func BytesFromPtr(b *[]byte) Bytes {
 	return n
 }
 
+// BytesFromNonEmpty creates a new Bytes that will be null if b is empty.
+func BytesFromNonEmpty(b []byte) Bytes {
+	return NewBytes(b, len(b) > 0)
+}
+
+// BytesFromCopy creates a new Bytes holding a copy of b, so that later
+// changes to b do not show through. Like BytesFrom, it is valid even if b
+// is nil.
+func BytesFromCopy(b []byte) Bytes {
+	return BytesFrom(bytes.Clone(b))
+}
+
+// BytesFromString creates a new valid Bytes holding a copy of s.
+func BytesFromString(s string) Bytes {
+	return NewBytes([]byte(s), true)
+}
+
+// BytesFromStringPtr is like BytesFromString, but gives a null Bytes if s
+// is nil.
+func BytesFromStringPtr(s *string) Bytes {
+	if s == nil {
+		return NewBytes(nil, false)
+	}
+	return BytesFromString(*s)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (b Bytes) IsValid() bool {
@@ -53,8 +94,59 @@ func (b Bytes) IsSet() bool {
 	return b.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (b Bytes) IsNull() bool {
+	return b.Set && !b.Valid
+}
+
+// Equal reports whether b and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (b Bytes) Equal(other Bytes) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	return bytes.Equal(b.Bytes, other.Bytes)
+}
+
+// EqualConstantTime is like Equal, but compares valid values with
+// subtle.ConstantTimeCompare, so the time taken does not depend on where
+// they differ. Use it rather than Equal for secrets such as token hashes.
+// Values of different lengths are unequal at once, which reveals only that
+// the lengths differ. Whether either side is null is not hidden.
+func (b Bytes) EqualConstantTime(other Bytes) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	return subtle.ConstantTimeCompare(b.Bytes, other.Bytes) == 1
+}
+
+// ExactEqual is like Equal, but also requires b and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (b Bytes) ExactEqual(other Bytes) bool {
+	return b.Set == other.Set && b.Equal(other)
+}
+
+// Clone returns a copy of b with its own copy of the bytes, so that
+// changes to either do not show in the other.
+func (b Bytes) Clone() Bytes {
+	b.Bytes = bytes.Clone(b.Bytes)
+	return b
+}
+
+// ToString converts b to a String holding a copy of the bytes, keeping
+// Valid and Set. The bytes are not checked: invalid UTF-8 is passed
+// through as it is.
+func (b Bytes) ToString() String {
+	if !b.Valid {
+		return String{Set: b.Set}
+	}
+	return String{String: string(b.Bytes), Valid: true, Set: b.Set}
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (b *Bytes) UnmarshalJSON(data []byte) error {
+func (b *Bytes) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Bytes", b, data)
 	b.Set = true
 
 	if bytes.Equal(data, NullBytes) {
@@ -67,6 +159,10 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &bv); err != nil {
 		return err
 	}
+	if err := checkLen("null.Bytes", MaxBytesLen, len(bv)); err != nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
 
 	b.Bytes = bv
 	b.Valid = true
@@ -74,8 +170,13 @@ func (b *Bytes) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (b *Bytes) UnmarshalText(text []byte) error {
+func (b *Bytes) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Bytes", text)
 	b.Set = true
+	if err := checkLen("null.Bytes", MaxBytesLen, len(text)); err != nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
 	if len(text) == 0 {
 		b.Bytes = nil
 		b.Valid = false
@@ -89,10 +190,21 @@ func (b *Bytes) UnmarshalText(text []byte) error {
 
 // MarshalJSON implements json.Marshaler.
 func (b Bytes) MarshalJSON() ([]byte, error) {
-	if len(b.Bytes) == 0 {
+	if !b.Valid {
 		return NullBytes, nil
 	}
-	return json.Marshal(b.Bytes)
+	return b.AppendJSON(make([]byte, 0, base64.StdEncoding.EncodedLen(len(b.Bytes))+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to buf.
+func (b Bytes) AppendJSON(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '"')
+	buf = base64.StdEncoding.AppendEncode(buf, b.Bytes)
+	return append(buf, '"'), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -103,6 +215,16 @@ func (b Bytes) MarshalText() ([]byte, error) {
 	return b.Bytes, nil
 }
 
+// AppendText implements encoding.TextAppender, appending the bytes that
+// MarshalText returns, without copying, to buf. Nothing is appended if b
+// is null.
+func (b Bytes) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return buf, nil
+	}
+	return append(buf, b.Bytes...), nil
+}
+
 // SetValid changes this Bytes's value and also sets it to be non-null.
 func (b *Bytes) SetValid(n []byte) {
 	b.Bytes = n
@@ -110,6 +232,22 @@ func (b *Bytes) SetValid(n []byte) {
 	b.Set = true
 }
 
+// SetNull sets this Bytes to an explicit null, zeroing its value.
+func (b *Bytes) SetNull() {
+	*b = Bytes{Set: true}
+}
+
+// Unset resets this Bytes to the zero value, as if it was never set.
+func (b *Bytes) Unset() {
+	*b = Bytes{}
+}
+
+// SetPtr changes this Bytes to the value p points to, or to an explicit
+// null if p is nil.
+func (b *Bytes) SetPtr(p *[]byte) {
+	*b = BytesFromPtr(p)
+}
+
 // Ptr returns a pointer to this Bytes's value, or a nil pointer if this Bytes is null.
 func (b Bytes) Ptr() *[]byte {
 	if !b.Valid {
@@ -118,33 +256,446 @@ func (b Bytes) Ptr() *[]byte {
 	return &b.Bytes
 }
 
-// IsZero returns true for null or zero Bytes's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (b Bytes) ValueOrZero() []byte {
+	if !b.Valid {
+		return nil
+	}
+	return b.Bytes
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (b Bytes) Or(def []byte) []byte {
+	if !b.Valid {
+		return def
+	}
+	return b.Bytes
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (b Bytes) Val() []byte {
+	return b.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (b Bytes) Get() ([]byte, bool) {
+	return b.ValueOrZero(), b.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if b is
+// null.
+func (b Bytes) MustGet() []byte {
+	if !b.Valid {
+		panic(&NullError{Type: "null.Bytes"})
+	}
+	return b.Bytes
+}
+
+// IsZero returns true if this Bytes was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (b Bytes) IsZero() bool {
-	return !b.Valid
+	return !b.Set && !b.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// Like MarshalJSON, it encodes the bytes as a base64 string, or null if
+// there are none.
+func (b Bytes) MarshalYAML() (interface{}, error) {
+	if len(b.Bytes) == 0 {
+		return nil, nil
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It decodes a base64 string. It will unmarshal to a null Bytes if the
+// input is null or ~.
+func (b *Bytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	b.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+	bv, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+	b.Bytes, b.Valid = bv, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// Like MarshalJSON, it encodes the bytes as base64, or an empty element if
+// there are none.
+func (b Bytes) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, len(b.Bytes) != 0, b.base64Text)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It decodes base64 content. An empty element unmarshals to a null Bytes.
+func (b *Bytes) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, b.setBase64Text)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute holds the bytes as base64, and is omitted if there are none.
+func (b Bytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, len(b.Bytes) != 0, b.base64Text)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Bytes.
+func (b *Bytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.setBase64Text([]byte(attr.Value))
+}
+
+// base64Text returns the bytes in standard base64.
+func (b Bytes) base64Text() ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(b.Bytes)), nil
+}
+
+// setBase64Text decodes standard base64 text. Empty text is null.
+func (b *Bytes) setBase64Text(text []byte) error {
+	b.Set = true
+	if len(text) == 0 {
+		b.Bytes, b.Valid = nil, false
+		return nil
+	}
+	bv, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+	b.Bytes, b.Valid = bv, true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// Like MarshalJSON, it encodes generic BSON binary, or BSON null if there
+// are no bytes.
+func (b Bytes) MarshalBSONValue() (byte, []byte, error) {
+	if len(b.Bytes) == 0 {
+		return bsonNull, nil, nil
+	}
+	return bsonBinaryValue(bsonBinaryGeneric, b.Bytes)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts BSON binary of any subtype.
+func (b *Bytes) UnmarshalBSONValue(typ byte, data []byte) error {
+	b.Set = true
+	if bsonIsNull(typ) {
+		b.Bytes, b.Valid = nil, false
+		return nil
+	}
+	_, v, err := bsonReadBinary(typ, data, "null.Bytes")
+	b.Bytes, b.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// Like MarshalJSON, it encodes msgpack binary, or nil if there are no
+// bytes.
+func (b Bytes) MarshalMsgpack() ([]byte, error) {
+	if len(b.Bytes) == 0 {
+		return msgpackNull, nil
+	}
+	return appendMsgpackBin(nil, b.Bytes), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts msgpack binary or a string.
+func (b *Bytes) UnmarshalMsgpack(data []byte) error {
+	b.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case []byte:
+		b.Bytes, b.Valid = x, true
+	case string:
+		b.Bytes, b.Valid = []byte(x), true
+	default:
+		b.Bytes, b.Valid = nil, false
+		return msgpackTypeError(v, "null.Bytes")
+	}
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// Like MarshalJSON, it encodes a CBOR byte string, or null if there are no
+// bytes.
+func (b Bytes) MarshalCBOR() ([]byte, error) {
+	if len(b.Bytes) == 0 {
+		return cborNull, nil
+	}
+	return appendCBORBytes(nil, b.Bytes), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR byte string or a string.
+func (b *Bytes) UnmarshalCBOR(data []byte) error {
+	b.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		b.Bytes, b.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case []byte:
+		b.Bytes, b.Valid = x, true
+	case string:
+		b.Bytes, b.Valid = []byte(x), true
+	default:
+		b.Bytes, b.Valid = nil, false
+		return cborTypeError(v, "null.Bytes")
+	}
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (b Bytes) MarshalGQL(w io.Writer) {
+	marshalGQL(w, b.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Bytes.
+func (b *Bytes) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, b.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the bytes as standard base64, or an empty cell if there are no
+// bytes.
+func (b Bytes) MarshalCSV() (string, error) {
+	return marshalCSV(len(b.Bytes) != 0, b.base64Text)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts standard base64. An empty cell unmarshals to a null Bytes.
+func (b *Bytes) UnmarshalCSV(cell string) error {
+	return b.setBase64Text([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Bytes is null.
+func (b Bytes) MarshalTOML() ([]byte, error) {
+	return marshalTOML(b.Valid, b.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Bytes.
+func (b *Bytes) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, b.UnmarshalText, b.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes BYTES, or a typed NULL if this Bytes is null.
+func (b Bytes) EncodeSpanner() (interface{}, error) {
+	if !b.Valid {
+		return []byte(nil), nil
+	}
+	if b.Bytes == nil {
+		// Spanner encodes a nil slice as NULL.
+		return []byte{}, nil
+	}
+	return b.Bytes, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts BYTES, which Spanner sends as standard base64. NULL decodes
+// to a null Bytes.
+func (b *Bytes) DecodeSpanner(input interface{}) error {
+	b.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		b.Bytes, b.Valid = nil, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.Bytes")
+	}
+	bv, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		b.Bytes, b.Valid = nil, false
+		return fmt.Errorf("null: cannot decode Spanner BYTES into null.Bytes: %w", err)
+	}
+	b.Bytes, b.Valid = bv, true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random byte, or an explicit null if shouldBeNull is true.
+func (b *Bytes) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*b = NewBytes(nil, false)
+		return
+	}
+	b.SetValid(randomBytes(nextInt, 1))
+}
+
+// Generate implements testing/quick's Generator.
+func (b Bytes) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewBytes(nil, false), func() Bytes {
+		return BytesFrom(generateBytes(rand, size))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (b Bytes) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Set, b.Valid, func() ([]byte, error) {
+		return b.Bytes, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bytes) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Bytes")
+	if err != nil {
+		return err
+	}
+	var v []byte
+	if valid {
+		v = append([]byte{}, p...)
+	}
+	b.Bytes, b.Valid, b.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (b *Bytes) Scan(value interface{}) error {
+// It copies []byte and string values, except that those starting with
+// `\x` are decoded as hex, which is the text form of a Postgres bytea
+// column when it was stringified on the way, as in COPY output or logical
+// replication. Scan cannot tell the legacy escape format from raw bytes, so
+// it keeps such values as they are; decode them with DecodeBytea first.
+func (b *Bytes) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Bytes", value)
 	if value == nil {
-		b.Bytes, b.Valid, b.Set = nil, false, false
+		b.Bytes, b.Valid, b.Set = nil, false, true
 		return nil
 	}
 	b.Valid, b.Set = true, true
-	return convert.ConvertAssign(&b.Bytes, value)
+	switch x := value.(type) {
+	case []byte:
+		if bytes.HasPrefix(x, []byte(`\x`)) {
+			b.Bytes, err = decodeHex(x[2:], "null.Bytes")
+		} else {
+			// The driver may reuse x once Scan returns.
+			b.Bytes = bytes.Clone(x)
+		}
+	case string:
+		if len(x) >= 2 && x[:2] == `\x` {
+			b.Bytes, err = decodeHex([]byte(x[2:]), "null.Bytes")
+		} else {
+			b.Bytes = []byte(x)
+		}
+	default:
+		err = convert.ConvertAssign(&b.Bytes, value)
+	}
+	if err == nil {
+		err = checkLen("null.Bytes", MaxBytesLen, len(b.Bytes))
+	}
+	if err != nil {
+		b.Bytes, b.Valid = nil, false
+	}
+	return err
+}
+
+// DecodeBytea decodes the text form of a Postgres bytea value: the hex
+// format, `\x` followed by hex digits, or the legacy escape format of
+// bytea_output = 'escape', where `\\` is a backslash, a backslash followed
+// by three octal digits is the byte they make, and any other byte is
+// itself. A backslash starting anything else is an error.
+func DecodeBytea(text []byte) ([]byte, error) {
+	if bytes.HasPrefix(text, []byte(`\x`)) {
+		return decodeHex(text[2:], "bytea")
+	}
+	v := make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c != '\\' {
+			v = append(v, c)
+			continue
+		}
+		switch {
+		case i+1 < len(text) && text[i+1] == '\\':
+			v = append(v, '\\')
+			i++
+		case i+3 < len(text) && isOctal(text[i+1]) && text[i+1] <= '3' && isOctal(text[i+2]) && isOctal(text[i+3]):
+			v = append(v, (text[i+1]-'0')<<6|(text[i+2]-'0')<<3|(text[i+3]-'0'))
+			i += 3
+		default:
+			return nil, fmt.Errorf("null: invalid bytea escape at byte %d of %q", i, text)
+		}
+	}
+	if err := checkLen("bytea", MaxBytesLen, len(v)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
 }
 
 // Value implements the driver Valuer interface.
+// A valid nil payload, as BytesFrom(nil) makes, is written as []byte{},
+// since drivers such as lib/pq, pgx and go-sql-driver/mysql send a nil
+// []byte as NULL.
 func (b Bytes) Value() (driver.Value, error) {
 	if !b.Valid {
 		return nil, nil
 	}
+	if b.Bytes == nil {
+		return []byte{}, nil
+	}
 	return b.Bytes, nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (b Bytes) String() string {
-	if b.Valid {
-		return fmt.Sprintf("Bytes(%02x)", b.Bytes)
+	return fmt.Sprint(b)
+}
+
+// GoString implements the GoStringer interface, formatting b as the Go
+// expression that builds it.
+func (b Bytes) GoString() string {
+	return goString("Bytes", "", "Bytes", goBytes(b.Bytes), b.Bytes == nil, b.Valid, b.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// b with the verb if it is valid.
+func (b Bytes) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, b, b.Valid, b.Set, b.Bytes)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the base64 bytes, cut off after
+// MaxLogValueLen bytes, or to nil if this Bytes is null.
+func (b Bytes) LogValue() slog.Value {
+	if !b.Valid {
+		return logNull
 	}
-	return "Bytes(invalid)"
+	return logBytes(b.Bytes, false, base64.StdEncoding.EncodeToString)
 }
@@ -3,6 +3,8 @@ package null
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -17,14 +19,45 @@ func TestBytesFrom(t *testing.T) {
 	assertBytes(t, i, "BytesFrom()")
 
 	zero := BytesFrom(nil)
-	if zero.Valid {
-		t.Error("BytesFrom(nil)", "is valid, but should be invalid")
+	if !zero.Valid {
+		t.Error("BytesFrom(nil)", "is invalid, but should be valid")
 	}
 
 	zero = BytesFrom([]byte{})
 	if !zero.Valid {
 		t.Error("BytesFrom([]byte{})", "is invalid, but should be valid")
 	}
+
+	assertNullBytes(t, BytesNull(), "BytesNull()")
+	if !BytesNull().Set {
+		t.Error("BytesNull()", "is unset, but should be set")
+	}
+}
+
+func TestBytesEmptyJSON(t *testing.T) {
+	for _, test := range []struct {
+		b    Bytes
+		json string
+	}{
+		{BytesFrom(nil), `""`},
+		{BytesFrom([]byte{}), `""`},
+		{BytesFrom(hello), `"aGVsbG8="`},
+		{BytesNull(), `null`},
+		{Bytes{}, `null`},
+	} {
+		data, err := json.Marshal(test.b)
+		maybePanic(err)
+		assertJSONEquals(t, data, test.json, fmt.Sprintf("%#v", test.b))
+
+		var back Bytes
+		maybePanic(json.Unmarshal(data, &back))
+		if back.Valid != test.b.Valid || !back.Set || !bytes.Equal(back.Bytes, test.b.Bytes) {
+			t.Errorf("%s decodes to %#v, want the same state as %#v", data, back, test.b)
+		}
+		if back.Valid && back.Bytes == nil {
+			t.Errorf("%s decodes to a nil payload, want a non-nil one", data)
+		}
+	}
 }
 
 func TestBytesFromPtr(t *testing.T) {
@@ -124,7 +157,12 @@ func TestBytesIsZero(t *testing.T) {
 	}
 
 	null := NewBytes(nil, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Bytes
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -151,6 +189,104 @@ func TestBytesScan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullBytes(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestBytesValue(t *testing.T) {
+	for _, test := range []struct {
+		b    Bytes
+		want []byte
+	}{
+		{BytesFrom(nil), []byte{}},
+		{BytesFrom([]byte{}), []byte{}},
+		{BytesFrom(hello), hello},
+		{BytesNull(), nil},
+		{Bytes{}, nil},
+	} {
+		v, err := test.b.Value()
+		maybePanic(err)
+		got, _ := v.([]byte)
+		if (v == nil) != (test.want == nil) || (got == nil) != (test.want == nil) || !bytes.Equal(got, test.want) {
+			t.Errorf("%#v.Value() = %#v, want %#v", test.b, v, test.want)
+		}
+	}
+}
+
+func TestBytesScanCopies(t *testing.T) {
+	buf := []byte("hello")
+	var b Bytes
+	err := b.Scan(buf)
+	maybePanic(err)
+	// A driver reuses its buffer for the next row.
+	copy(buf, "jello")
+	assertBytes(t, b, "Scan() of a reused buffer")
+
+	var empty Bytes
+	err = empty.Scan([]byte{})
+	maybePanic(err)
+	if empty.Bytes == nil || len(empty.Bytes) != 0 || !empty.Valid {
+		t.Errorf("Scan([]byte{}) = %#v, want a valid empty slice", empty)
+	}
+}
+
+func TestBytesScanBytea(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want []byte
+	}{
+		{[]byte{0x00, 0x5c, 0xff}, []byte{0x00, 0x5c, 0xff}},
+		{`\x68656c6c6f`, hello},
+		{[]byte(`\x68656C6C6F`), hello},
+		{`\x`, []byte{}},
+		{[]byte(`\x`), []byte{}},
+		{`\150ello`, []byte(`\150ello`)},
+	}
+	for _, test := range tests {
+		var b Bytes
+		err := b.Scan(test.in)
+		maybePanic(err)
+		if !b.Valid || !b.Set || b.Bytes == nil || !bytes.Equal(b.Bytes, test.want) {
+			t.Errorf("Scan(%q) = %#v, want %q", test.in, b, test.want)
+		}
+	}
+
+	for _, in := range []interface{}{`\x6`, `\xzz`, []byte(`\x68656c6c6g`)} {
+		var b Bytes
+		if err := b.Scan(in); err == nil || !strings.Contains(err.Error(), "null.Bytes") {
+			t.Errorf("Scan(%q) should fail naming null.Bytes, got %v", in, err)
+		}
+		assertNullBytes(t, b, "scanned malformed hex")
+	}
+}
+
+func TestDecodeBytea(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{`\x68656c6c6f`, hello},
+		{`\x`, []byte{}},
+		{`hello`, hello},
+		{`\150\145llo`, hello},
+		{`a\\b`, []byte(`a\b`)},
+		{`\000\377`, []byte{0, 0xff}},
+		{``, []byte{}},
+	}
+	for _, test := range tests {
+		got, err := DecodeBytea([]byte(test.in))
+		maybePanic(err)
+		if got == nil || !bytes.Equal(got, test.want) {
+			t.Errorf("DecodeBytea(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+
+	for _, in := range []string{`\x123`, `a\b`, `\`, `\12`, `\400`, `\19a`} {
+		if _, err := DecodeBytea([]byte(in)); err == nil {
+			t.Errorf("DecodeBytea(%q) should fail", in)
+		}
+	}
 }
 
 func assertBytes(t *testing.T, i Bytes, from string) {
@@ -167,3 +303,77 @@ func assertNullBytes(t *testing.T, i Bytes, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestBytesClone(t *testing.T) {
+	buf := []byte("hello")
+	shared, copied := BytesFrom(buf), BytesFromCopy(buf)
+	clone := shared.Clone()
+	copy(buf, "jello")
+	if string(shared.Bytes) != "jello" {
+		t.Errorf("BytesFrom() should share its argument, got %q", shared.Bytes)
+	}
+	assertBytes(t, copied, "BytesFromCopy() after mutating argument")
+	assertBytes(t, clone, "Clone() after mutating original")
+
+	if c := NewBytes(nil, false).Clone(); c.Bytes != nil || c.Valid || !c.Set {
+		t.Errorf("Clone() of null = %#v", c)
+	}
+	if c := BytesFromCopy([]byte{}).Clone(); c.Bytes == nil || !c.Valid {
+		t.Errorf("Clone() of empty = %#v, want a valid empty slice", c)
+	}
+	if c := BytesFromCopy(nil); !c.Valid {
+		t.Errorf("BytesFromCopy(nil) = %#v, want valid", c)
+	}
+}
+
+func TestBytesStringConversion(t *testing.T) {
+	s := "hello"
+	b := BytesFromString(s)
+	if !b.ExactEqual(BytesFrom([]byte("hello"))) {
+		t.Errorf("BytesFromString() = %#v", b)
+	}
+	if !BytesFromStringPtr(&s).ExactEqual(b) {
+		t.Errorf("BytesFromStringPtr() = %#v", BytesFromStringPtr(&s))
+	}
+	if got := BytesFromStringPtr(nil); !got.ExactEqual(NewBytes(nil, false)) {
+		t.Errorf("BytesFromStringPtr(nil) = %#v", got)
+	}
+	if got := BytesFromString(""); !got.Valid || len(got.Bytes) != 0 {
+		t.Errorf(`BytesFromString("") = %#v, want valid and empty`, got)
+	}
+
+	str := b.ToString()
+	if !str.ExactEqual(StringFrom("hello")) {
+		t.Errorf("ToString() = %#v", str)
+	}
+	b.Bytes[0] = 'j'
+	if str.String != "hello" {
+		t.Error("ToString() shares its payload with the Bytes")
+	}
+	if got := BytesFrom([]byte{0xff, 'a'}).ToString(); got.String != "\xffa" {
+		t.Errorf("ToString() of invalid UTF-8 = %q, want it passed through", got.String)
+	}
+
+	for _, test := range []struct {
+		b Bytes
+		s String
+	}{
+		{NewBytes(nil, false), NewString("", false)},
+		{Bytes{}, String{}},
+		{BytesFrom([]byte("x")), StringFrom("x")},
+	} {
+		if got := test.b.ToString(); !got.ExactEqual(test.s) {
+			t.Errorf("%#v.ToString() = %#v, want %#v", test.b, got, test.s)
+		}
+		if got := test.s.ToBytes(); !got.ExactEqual(test.b) {
+			t.Errorf("%#v.ToBytes() = %#v, want %#v", test.s, got, test.b)
+		}
+	}
+
+	in := StringFrom("abc")
+	out := in.ToBytes()
+	out.Bytes[0] = 'x'
+	if in.String != "abc" {
+		t.Error("ToBytes() shares its payload with the String")
+	}
+}
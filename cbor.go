@@ -0,0 +1,555 @@
+package null
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// The CBOR methods implement the cbor.Marshaler and cbor.Unmarshaler
+// interfaces of github.com/fxamacker/cbor, which exchange encoded bytes, so
+// the package needs no CBOR dependency. Valid values encode as the plain
+// CBOR scalar and null values as CBOR null. Time uses the RFC 3339 string of
+// tag 0, Date the full-date string of tag 1004 from RFC 8943, and BigInt a
+// bignum of tag 2 or 3 when it does not fit in 64 bits.
+//
+// Decoding accepts any integer width, half-precision floats,
+// indefinite-length strings, arrays and maps, and epoch times of tag 1. A
+// CBOR null or undefined unmarshals to a null value with Set true.
+
+// cborMarshaler and cborUnmarshaler mirror the cbor package's interfaces,
+// for Val to delegate to T.
+type cborMarshaler interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+type cborUnmarshaler interface {
+	UnmarshalCBOR(data []byte) error
+}
+
+// cborNull is the CBOR encoding of null.
+var cborNull = []byte{0xf6}
+
+// The CBOR major types.
+const (
+	cborMajorUint byte = iota
+	cborMajorNegInt
+	cborMajorBytes
+	cborMajorText
+	cborMajorArray
+	cborMajorMap
+	cborMajorTag
+	cborMajorSimple
+)
+
+// The CBOR tags the package encodes or decodes.
+const (
+	cborTagDateTime  = 0
+	cborTagEpoch     = 1
+	cborTagPosBignum = 2
+	cborTagNegBignum = 3
+	cborTagDays      = 100
+	cborTagFullDate  = 1004
+	cborTagSelfDesc  = 55799
+)
+
+// cborBreak ends an indefinite-length item.
+const cborBreak = 0xff
+
+// appendCBORHead appends the head of an item of the major type with the
+// argument n, in the shortest form.
+func appendCBORHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(dst, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, major|27), n)
+}
+
+func appendCBORInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		return appendCBORHead(dst, cborMajorNegInt, uint64(^v))
+	}
+	return appendCBORHead(dst, cborMajorUint, uint64(v))
+}
+
+func appendCBORUint(dst []byte, v uint64) []byte {
+	return appendCBORHead(dst, cborMajorUint, v)
+}
+
+func appendCBORFloat32(dst []byte, v float32) []byte {
+	return binary.BigEndian.AppendUint32(append(dst, 0xfa), math.Float32bits(v))
+}
+
+func appendCBORFloat64(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(append(dst, 0xfb), math.Float64bits(v))
+}
+
+func appendCBORBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 0xf5)
+	}
+	return append(dst, 0xf4)
+}
+
+func appendCBORString(dst []byte, s string) []byte {
+	return append(appendCBORHead(dst, cborMajorText, uint64(len(s))), s...)
+}
+
+func appendCBORBytes(dst []byte, b []byte) []byte {
+	return append(appendCBORHead(dst, cborMajorBytes, uint64(len(b))), b...)
+}
+
+// appendCBORText appends the output of a MarshalText method as a CBOR
+// text string.
+func appendCBORText(dst []byte, text func() ([]byte, error)) ([]byte, error) {
+	data, err := text()
+	if err != nil {
+		return nil, err
+	}
+	return appendCBORString(dst, string(data)), nil
+}
+
+// appendCBORTime appends t as an RFC 3339 string of tag 0, which keeps its
+// nanoseconds and offset.
+func appendCBORTime(dst []byte, t time.Time) []byte {
+	return appendCBORString(appendCBORHead(dst, cborMajorTag, cborTagDateTime), t.Format(time.RFC3339Nano))
+}
+
+// appendCBORDate appends the date of t as a full-date string of tag 1004.
+func appendCBORDate(dst []byte, t time.Time) []byte {
+	return appendCBORString(appendCBORHead(dst, cborMajorTag, cborTagFullDate), t.Format(dateLayout))
+}
+
+// appendCBORBigInt appends b as a CBOR integer, or as a bignum if it does
+// not fit in one.
+func appendCBORBigInt(dst []byte, b *big.Int) []byte {
+	switch {
+	case b.IsInt64():
+		return appendCBORInt(dst, b.Int64())
+	case b.IsUint64():
+		return appendCBORUint(dst, b.Uint64())
+	case b.Sign() > 0:
+		return appendCBORBytes(appendCBORHead(dst, cborMajorTag, cborTagPosBignum), b.Bytes())
+	}
+	// A negative bignum holds -1 - b, which is ^b.
+	return appendCBORBytes(appendCBORHead(dst, cborMajorTag, cborTagNegBignum), new(big.Int).Not(b).Bytes())
+}
+
+// cborFromJSON encodes JSON text as CBOR, keeping object keys in order.
+// Integers become CBOR integers and other numbers float64.
+func cborFromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	res, err := appendCBORJSON(nil, dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("null: cannot encode JSON %q as CBOR: trailing data", data)
+	}
+	return res, nil
+}
+
+func appendCBORJSON(dst []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch x := tok.(type) {
+	case nil:
+		return append(dst, cborNull...), nil
+	case bool:
+		return appendCBORBool(dst, x), nil
+	case string:
+		return appendCBORString(dst, x), nil
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return appendCBORInt(dst, i), nil
+		}
+		if u, err := strconv.ParseUint(x.String(), 10, 64); err == nil {
+			return appendCBORUint(dst, u), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendCBORFloat64(dst, f), nil
+	case json.Delim:
+		// The element count isn't known until the end, so encode the
+		// elements first and add the head after.
+		var elems []byte
+		n := 0
+		for ; dec.More(); n++ {
+			if x == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				elems = appendCBORString(elems, key.(string))
+			}
+			if elems, err = appendCBORJSON(elems, dec); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		major := cborMajorArray
+		if x == '{' {
+			major = cborMajorMap
+		}
+		return append(appendCBORHead(dst, major, uint64(n)), elems...), nil
+	}
+	return nil, fmt.Errorf("null: unexpected JSON token %v", tok)
+}
+
+// cborTag is a decoded CBOR tag the package does not interpret.
+type cborTag struct {
+	num     uint64
+	content interface{}
+}
+
+// decodeCBOR decodes a single CBOR value into the Go values listed in
+// decoded.go. Null and undefined decode as nil, negative integers below
+// math.MinInt64 and bignums that do not fit in 64 bits as *big.Int, and
+// the time and date tags as UTC times, except that a tag 0 string keeps its
+// offset. Other tags decode as cborTag.
+func decodeCBOR(data []byte) (interface{}, error) {
+	v, rest, err := readCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("null: %d bytes of trailing CBOR data", len(rest))
+	}
+	return v, nil
+}
+
+var errCBORShort = errors.New("null: unexpected end of CBOR data")
+
+func readCBOR(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errCBORShort
+	}
+	c, data := data[0], data[1:]
+	major, info := c>>5, c&0x1f
+	if major == cborMajorSimple {
+		return readCBORSimple(info, data)
+	}
+	if info == 31 {
+		return readCBORIndefinite(major, data)
+	}
+	n, data, err := readCBORArg(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborMajorUint:
+		if n > math.MaxInt64 {
+			return n, data, nil
+		}
+		return int64(n), data, nil
+	case cborMajorNegInt:
+		if n > math.MaxInt64 {
+			return new(big.Int).Not(new(big.Int).SetUint64(n)), data, nil
+		}
+		return ^int64(n), data, nil
+	case cborMajorBytes, cborMajorText:
+		if n > uint64(len(data)) {
+			return nil, nil, errCBORShort
+		}
+		b := data[:n]
+		if major == cborMajorText {
+			return string(b), data[n:], nil
+		}
+		return append([]byte{}, b...), data[n:], nil
+	case cborMajorArray:
+		if n > uint64(len(data)) {
+			return nil, nil, errCBORShort
+		}
+		res := make([]interface{}, n)
+		for i := range res {
+			if res[i], data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, data, nil
+	case cborMajorMap:
+		if n > uint64(len(data))/2 {
+			return nil, nil, errCBORShort
+		}
+		res := make(decodedMap, n)
+		for i := range res {
+			if res[i].key, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			if res[i].value, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, data, nil
+	}
+	content, data, err := readCBOR(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	v, err := cborTagValue(n, content)
+	return v, data, err
+}
+
+// readCBORArg reads the argument of a head with the additional info.
+func readCBORArg(info byte, data []byte) (uint64, []byte, error) {
+	if info < 24 {
+		return uint64(info), data, nil
+	}
+	if info > 27 {
+		return 0, nil, fmt.Errorf("null: invalid CBOR additional info %d", info)
+	}
+	n := 1 << (info - 24)
+	if n > len(data) {
+		return 0, nil, errCBORShort
+	}
+	var u uint64
+	for _, x := range data[:n] {
+		u = u<<8 | uint64(x)
+	}
+	return u, data[n:], nil
+}
+
+func readCBORSimple(info byte, data []byte) (interface{}, []byte, error) {
+	switch info {
+	case 20, 21:
+		return info == 21, data, nil
+	case 22, 23:
+		return nil, data, nil
+	case 25, 26, 27:
+		n := 2 << (info - 25)
+		if n > len(data) {
+			return nil, nil, errCBORShort
+		}
+		b, data := data[:n], data[n:]
+		switch n {
+		case 2:
+			return cborHalfFloat(binary.BigEndian.Uint16(b)), data, nil
+		case 4:
+			return math.Float32frombits(binary.BigEndian.Uint32(b)), data, nil
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), data, nil
+	case 31:
+		return nil, nil, errors.New("null: unexpected CBOR break")
+	}
+	return nil, nil, fmt.Errorf("null: unsupported CBOR simple value %d", info)
+}
+
+// readCBORIndefinite reads the chunks of an indefinite-length string or the
+// elements of an indefinite-length array or map, up to the break.
+func readCBORIndefinite(major byte, data []byte) (interface{}, []byte, error) {
+	atBreak := func() (bool, error) {
+		if len(data) == 0 {
+			return false, errCBORShort
+		}
+		if data[0] == cborBreak {
+			data = data[1:]
+			return true, nil
+		}
+		return false, nil
+	}
+	switch major {
+	case cborMajorBytes, cborMajorText:
+		res := []byte{}
+		for {
+			end, err := atBreak()
+			if err != nil {
+				return nil, nil, err
+			}
+			if end {
+				break
+			}
+			if data[0]>>5 != major || data[0]&0x1f == 31 {
+				return nil, nil, errors.New("null: invalid chunk in indefinite-length CBOR string")
+			}
+			var chunk interface{}
+			if chunk, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			switch x := chunk.(type) {
+			case string:
+				res = append(res, x...)
+			case []byte:
+				res = append(res, x...)
+			}
+		}
+		if major == cborMajorText {
+			return string(res), data, nil
+		}
+		return res, data, nil
+	case cborMajorArray:
+		res := []interface{}{}
+		for {
+			end, err := atBreak()
+			if err != nil {
+				return nil, nil, err
+			}
+			if end {
+				return res, data, nil
+			}
+			var e interface{}
+			if e, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			res = append(res, e)
+		}
+	case cborMajorMap:
+		res := decodedMap{}
+		for {
+			end, err := atBreak()
+			if err != nil {
+				return nil, nil, err
+			}
+			if end {
+				return res, data, nil
+			}
+			var p decodedPair
+			if p.key, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			if p.value, data, err = readCBOR(data); err != nil {
+				return nil, nil, err
+			}
+			res = append(res, p)
+		}
+	}
+	return nil, nil, fmt.Errorf("null: invalid indefinite-length CBOR major type %d", major)
+}
+
+// cborHalfFloat converts the bits of an IEEE 754 half-precision float.
+func cborHalfFloat(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp, frac := uint32(h>>10)&0x1f, uint32(h&0x3ff)
+	switch exp {
+	case 0:
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	case 0x1f:
+		return math.Float32frombits(sign | 0xff<<23 | frac<<13)
+	}
+	return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+}
+
+// cborTagValue interprets the content of a tag.
+func cborTagValue(num uint64, content interface{}) (interface{}, error) {
+	switch num {
+	case cborTagDateTime, cborTagFullDate:
+		s, ok := content.(string)
+		if !ok {
+			return nil, fmt.Errorf("null: CBOR tag %d holds a %s, not a string", num, decodedTypeName(content))
+		}
+		if num == cborTagFullDate {
+			return time.Parse(dateLayout, s)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case cborTagEpoch, cborTagDays:
+		var sec float64
+		switch x := content.(type) {
+		case int64:
+			if num == cborTagDays {
+				return time.Unix(x*86400, 0).UTC(), nil
+			}
+			return time.Unix(x, 0).UTC(), nil
+		case float32:
+			sec = float64(x)
+		case float64:
+			sec = x
+		default:
+			return nil, fmt.Errorf("null: CBOR tag %d holds a %s, not a number", num, decodedTypeName(content))
+		}
+		if num == cborTagDays || math.IsNaN(sec) || math.Abs(sec) >= 1<<62 {
+			return nil, fmt.Errorf("null: invalid CBOR tag %d value %v", num, sec)
+		}
+		whole, frac := math.Modf(sec)
+		return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+	case cborTagPosBignum, cborTagNegBignum:
+		b, ok := content.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("null: CBOR tag %d holds a %s, not binary", num, decodedTypeName(content))
+		}
+		n := new(big.Int).SetBytes(b)
+		if num == cborTagNegBignum {
+			n.Not(n)
+		}
+		switch {
+		case n.IsInt64():
+			return n.Int64(), nil
+		case n.IsUint64():
+			return n.Uint64(), nil
+		}
+		return n, nil
+	case cborTagSelfDesc:
+		return content, nil
+	}
+	return cborTag{num: num, content: content}, nil
+}
+
+func cborTypeError(v interface{}, to string) error {
+	return decodedTypeError("CBOR", v, to)
+}
+
+func cborInt(v interface{}, to string, min, max int64) (int64, error) {
+	return decodedInt("CBOR", v, to, min, max)
+}
+
+func cborUint(v interface{}, to string, max uint64) (uint64, error) {
+	return decodedUint("CBOR", v, to, max)
+}
+
+func cborFloat(v interface{}, to string) (float64, error) {
+	return decodedFloat("CBOR", v, to)
+}
+
+func cborText(v interface{}, to string) (string, error) {
+	return decodedText("CBOR", v, to)
+}
+
+func cborTime(v interface{}, to string) (time.Time, error) {
+	return decodedTime("CBOR", v, to)
+}
+
+func cborToJSON(v interface{}) ([]byte, error) {
+	return decodedToJSON("CBOR", v)
+}
+
+// unmarshalCBORText decodes a CBOR text string and passes it to fromText.
+// Null passes empty text, and so does any other CBOR type before its error
+// is returned, leaving the value set but null.
+func unmarshalCBORText(data []byte, to string, fromText func([]byte) error) error {
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		fromText(nil)
+		return err
+	}
+	s, err := cborText(v, to)
+	if err != nil {
+		fromText(nil)
+		return err
+	}
+	return fromText([]byte(s))
+}
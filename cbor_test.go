@@ -0,0 +1,169 @@
+package null
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cborCodec interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := states[2]
+		data, err := valid.(cborCodec).MarshalCBOR()
+		maybePanic(err)
+		ptr := reflect.New(reflect.TypeOf(valid))
+		err = ptr.Interface().(cborUnmarshaler).UnmarshalCBOR(data)
+		maybePanic(err)
+		if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, valid) {
+			t.Errorf("CBOR round trip of %T: %#v ≠ %#v", valid, got, valid)
+		}
+
+		for _, null := range states[:2] {
+			data, err := null.(cborCodec).MarshalCBOR()
+			maybePanic(err)
+			if !bytes.Equal(data, cborNull) {
+				t.Errorf("null %T.MarshalCBOR() = %x, want f6", null, data)
+			}
+		}
+		for _, null := range []string{"f6", "f7"} {
+			data, _ := hex.DecodeString(null)
+			ptr = reflect.New(reflect.TypeOf(valid))
+			err = ptr.Interface().(cborUnmarshaler).UnmarshalCBOR(data)
+			maybePanic(err)
+			if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+				t.Errorf("%T.UnmarshalCBOR(%s) = %#v, want set null", valid, null, back.Interface())
+			}
+		}
+	}
+}
+
+func TestCBOREncoding(t *testing.T) {
+	twoTo64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	tests := []struct {
+		in   cborCodec
+		want string
+	}{
+		{Int64From(1), "01"},
+		{Int64From(-1), "20"},
+		{Int64From(24), "1818"},
+		{Int64From(-1000), "3903e7"},
+		{Int64From(math.MinInt64), "3b7fffffffffffffff"},
+		{Uint64From(math.MaxUint64), "1bffffffffffffffff"},
+		{Float32From(1.5), "fa3fc00000"},
+		{Float64From(1.5), "fb3ff8000000000000"},
+		{BoolFrom(true), "f5"},
+		{StringFrom("hi"), "626869"},
+		{BytesFrom([]byte{0xab}), "41ab"},
+		{TimeFrom(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)), "c074323031332d30332d32315432303a30343a30305a"},
+		{DateOf(2012, 12, 21), "d903ec6a323031322d31322d3231"},
+		{JSONFrom([]byte(`{"b":1,"a":[true,null]}`)), "a2616201616182f5f6"},
+		{StringSliceFrom(nil), "80"},
+		{Int64SliceFrom([]int64{1, -1}), "820120"},
+		{DecimalFrom("1.10"), "64312e3130"},
+		{BigIntFrom(big.NewInt(-42)), "3829"},
+		{BigIntFrom(twoTo64), "c249010000000000000000"},
+		{BigIntFrom(new(big.Int).Neg(new(big.Int).Add(twoTo64, big.NewInt(1)))), "c349010000000000000000"},
+	}
+	for _, test := range tests {
+		data, err := test.in.MarshalCBOR()
+		maybePanic(err)
+		if hex.EncodeToString(data) != test.want {
+			t.Errorf("%T.MarshalCBOR() = %x, want %s", test.in, data, test.want)
+		}
+	}
+}
+
+func TestCBORRelatedTypes(t *testing.T) {
+	tests := []struct {
+		out  cborUnmarshaler
+		data string
+		want interface{}
+	}{
+		{&Int64{}, "1a000f4240", Int64From(1000000)},
+		{&Int16{}, "1b0000000000000005", Int16From(5)},
+		{&Uint8{}, "1800", Uint8From(0)},
+		{&Int64{}, "f94000", Int64From(2)},
+		{&Int64{}, "d9d9f701", Int64From(1)},
+		{&Float64{}, "f93e00", Float64From(1.5)},
+		{&Float64{}, "f97c00", Float64From(math.Inf(1))},
+		{&Float64{}, "f90001", Float64From(5.960464477539063e-08)},
+		{&Float32{}, "fb3ff8000000000000", Float32From(1.5)},
+		{&Time{}, "c11a514b67b0", TimeFrom(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC))},
+		{&Time{}, "c1fb41d452d9ec200000", TimeFrom(time.Date(2013, 3, 21, 20, 4, 0, 5e8, time.UTC))},
+		{&Time{}, "74323031332d30332d32315432303a30343a30305a", TimeFrom(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC))},
+		{&Date{}, "d864193d4f", DateOf(2012, 12, 21)},
+		{&Date{}, "c074323031332d30332d32315432303a30343a30305a", DateOf(2013, 3, 21)},
+		{&Date{}, "6a323031322d31322d3231", DateOf(2012, 12, 21)},
+		{&Duration{}, "1903e8", DurationFrom(time.Microsecond)},
+		{&Decimal{}, "f93400", DecimalFrom("0.25")},
+		{&Decimal{}, "23", DecimalFrom("-4")},
+		{&BigInt{}, "c249010000000000000000", BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{&BigInt{}, "3bffffffffffffffff", BigIntFrom(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 64)))},
+		{&BigInt{}, "1bffffffffffffffff", BigIntFrom(new(big.Int).SetUint64(math.MaxUint64))},
+		{&String{}, "7f657374726561646d696e67ff", StringFrom("streaming")},
+		{&String{}, "426869", StringFrom("hi")},
+		{&Bytes{}, "5f42010243030405ff", BytesFrom([]byte{1, 2, 3, 4, 5})},
+		{&Bytes{}, "626869", BytesFrom([]byte("hi"))},
+		{&Int64Slice{}, "9f0102ff", Int64SliceFrom([]int64{1, 2})},
+		{&Map{}, "bf6346756ef563416d7421ff", MapFrom(map[string]interface{}{"Fun": true, "Amt": -2.0})},
+		{&Val[int]{}, "09", ValFrom(9)},
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test.data)
+		maybePanic(err)
+		err = test.out.UnmarshalCBOR(data)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalCBOR(%s) = %#v, want %#v", test.out, test.data, got, test.want)
+		}
+	}
+}
+
+func TestCBORErrors(t *testing.T) {
+	tests := []struct {
+		out  cborUnmarshaler
+		data string
+	}{
+		{&Int64{}, "63616263"},
+		{&Int8{}, "190100"},
+		{&Int64{}, "fb3ff8000000000000"},
+		{&Int64{}, "1bffffffffffffffff"},
+		{&Int64{}, "3bffffffffffffffff"},
+		{&Uint64{}, "20"},
+		{&Bool{}, "01"},
+		{&Int32{}, "1a"},
+		{&Int32{}, "0101"},
+		{&String{}, "1c"},
+		{&String{}, "ff"},
+		{&String{}, "7f01ff"},
+		{&String{}, "7f6161"},
+		{&UUID{}, "63616263"},
+		{&Enum[testStatus]{}, "63616263"},
+		{&Map{}, "80"},
+		{&Map{}, "a10101"},
+		{&StringSlice{}, "a0"},
+		{&Time{}, "c001"},
+		{&Date{}, "c06161"},
+		{&JSON{}, "f8ff"},
+		{&JSON{}, "d82401"},
+		{&Val[int]{}, "63616263"},
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test.data)
+		maybePanic(err)
+		if err := test.out.UnmarshalCBOR(data); err == nil {
+			t.Errorf("%T.UnmarshalCBOR(%s) should fail", test.out, test.data)
+		}
+		if reflect.ValueOf(test.out).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalCBOR", test.out)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/netip"
+	"reflect"
+)
+
+// CIDR is a nullable netip.Prefix, such as a Postgres cidr value.
+// Prefixes are kept as parsed; host bits are not masked off.
+type CIDR struct {
+	CIDR  netip.Prefix
+	Valid bool
+	Set   bool
+}
+
+// NewCIDR creates a new CIDR
+func NewCIDR(p netip.Prefix, valid bool) CIDR {
+	return CIDR{
+		CIDR:  p,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// CIDRFrom creates a new CIDR that will be null if p is the zero netip.Prefix.
+func CIDRFrom(p netip.Prefix) CIDR {
+	return NewCIDR(p, p.IsValid())
+}
+
+// CIDRFromPtr creates a new CIDR that will be null if p is nil.
+func CIDRFromPtr(p *netip.Prefix) CIDR {
+	if p == nil {
+		return NewCIDR(netip.Prefix{}, false)
+	}
+	return CIDRFrom(*p)
+}
+
+// CIDRFromNonZero creates a new CIDR that will be null if p is the zero Prefix.
+func CIDRFromNonZero(p netip.Prefix) CIDR {
+	return NewCIDR(p, p.IsValid())
+}
+
+// CIDRFromString parses s into a new CIDR. An empty string produces
+// a null CIDR.
+func CIDRFromString(s string) (CIDR, error) {
+	var c CIDR
+	err := c.UnmarshalText([]byte(s))
+	return c, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (c CIDR) IsValid() bool {
+	return c.Set && c.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (c CIDR) IsSet() bool {
+	return c.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (c CIDR) IsNull() bool {
+	return c.Set && !c.Valid
+}
+
+// Equal reports whether c and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (c CIDR) Equal(other CIDR) bool {
+	if !c.Valid || !other.Valid {
+		return c.Valid == other.Valid
+	}
+	return c.CIDR == other.CIDR
+}
+
+// ExactEqual is like Equal, but also requires c and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (c CIDR) ExactEqual(other CIDR) bool {
+	return c.Set == other.Set && c.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (c *CIDR) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.CIDR", c, data)
+	c.Set = true
+	if bytes.Equal(data, NullBytes) {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return err
+	}
+	return c.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null CIDR if the input is a blank string.
+func (c *CIDR) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.CIDR", text)
+	c.Set = true
+	if len(text) == 0 {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return nil
+	}
+	res, err := netip.ParsePrefix(string(text))
+	if err != nil {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return fmt.Errorf("null: cannot parse %q into null.CIDR: %v", text, err)
+	}
+	c.CIDR, c.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this CIDR is null.
+func (c CIDR) MarshalJSON() ([]byte, error) {
+	if !c.Valid {
+		return NullBytes, nil
+	}
+	return c.AppendJSON(make([]byte, 0, 45))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (c CIDR) AppendJSON(b []byte) ([]byte, error) {
+	if !c.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = appendPrefix(b, c.CIDR)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this CIDR is null.
+func (c CIDR) MarshalText() ([]byte, error) {
+	if !c.Valid {
+		return []byte{}, nil
+	}
+	return c.AppendText(make([]byte, 0, 43))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if c is null.
+func (c CIDR) AppendText(b []byte) ([]byte, error) {
+	if !c.Valid {
+		return b, nil
+	}
+	return appendPrefix(b, c.CIDR), nil
+}
+
+// appendPrefix appends p.String() to b. Unlike String, p.AppendTo writes
+// nothing for the zero Prefix, so that one keeps its "invalid Prefix".
+func appendPrefix(b []byte, p netip.Prefix) []byte {
+	if !p.IsValid() {
+		return append(b, p.String()...)
+	}
+	return p.AppendTo(b)
+}
+
+// SetValid changes this CIDR's value and also sets it to be non-null.
+func (c *CIDR) SetValid(v netip.Prefix) {
+	c.CIDR = v
+	c.Valid = true
+	c.Set = true
+}
+
+// SetNull sets this CIDR to an explicit null, zeroing its value.
+func (c *CIDR) SetNull() {
+	*c = CIDR{Set: true}
+}
+
+// Unset resets this CIDR to the zero value, as if it was never set.
+func (c *CIDR) Unset() {
+	*c = CIDR{}
+}
+
+// SetPtr changes this CIDR to the value p points to, or to an explicit
+// null if p is nil.
+func (c *CIDR) SetPtr(p *netip.Prefix) {
+	*c = CIDRFromPtr(p)
+}
+
+// Ptr returns a pointer to this CIDR's value, or a nil pointer if this CIDR is null.
+func (c CIDR) Ptr() *netip.Prefix {
+	if !c.Valid {
+		return nil
+	}
+	return &c.CIDR
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (c CIDR) ValueOrZero() netip.Prefix {
+	if !c.Valid {
+		return netip.Prefix{}
+	}
+	return c.CIDR
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (c CIDR) Or(def netip.Prefix) netip.Prefix {
+	if !c.Valid {
+		return def
+	}
+	return c.CIDR
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (c CIDR) Val() netip.Prefix {
+	return c.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (c CIDR) Get() (netip.Prefix, bool) {
+	return c.ValueOrZero(), c.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if c is
+// null.
+func (c CIDR) MustGet() netip.Prefix {
+	if !c.Valid {
+		panic(&NullError{Type: "null.CIDR"})
+	}
+	return c.CIDR
+}
+
+// IsZero returns true if this CIDR was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (c CIDR) IsZero() bool {
+	return !c.Set && !c.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this CIDR is null, and the text form otherwise.
+func (c CIDR) MarshalYAML() (interface{}, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+	data, err := c.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// CIDR if the input is null or ~.
+func (c *CIDR) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	c.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		c.CIDR, c.Valid = netip.Prefix{}, false
+		return err
+	}
+	return c.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this CIDR is null.
+func (c CIDR) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, c.Valid, c.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null CIDR.
+func (c *CIDR) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, c.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this CIDR is null.
+func (c CIDR) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, c.Valid, c.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null CIDR.
+func (c *CIDR) UnmarshalXMLAttr(attr xml.Attr) error {
+	return c.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this CIDR is null.
+func (c CIDR) MarshalBSONValue() (byte, []byte, error) {
+	if !c.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(c.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (c *CIDR) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.CIDR", c.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this CIDR is null.
+func (c CIDR) MarshalMsgpack() ([]byte, error) {
+	if !c.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, c.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (c *CIDR) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.CIDR", c.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this CIDR is null.
+func (c CIDR) MarshalCBOR() ([]byte, error) {
+	if !c.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, c.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (c *CIDR) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.CIDR", c.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (c CIDR) MarshalGQL(w io.Writer) {
+	marshalGQL(w, c.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null CIDR.
+func (c *CIDR) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, c.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this CIDR is
+// null.
+func (c CIDR) MarshalCSV() (string, error) {
+	return marshalCSV(c.Valid, c.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null CIDR.
+func (c *CIDR) UnmarshalCSV(cell string) error {
+	return c.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this CIDR is null.
+func (c CIDR) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(c.Valid, c.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null CIDR.
+func (c *CIDR) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, c.UnmarshalText, c.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random /24 network in 10.0.0.0/8, or an explicit null if shouldBeNull is true.
+func (c *CIDR) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*c = NewCIDR(netip.Prefix{}, false)
+		return
+	}
+	n := randomN(nextInt, 1<<16)
+	c.SetValid(netip.PrefixFrom(netip.AddrFrom4([4]byte{10, byte(n >> 8), byte(n), 0}), 24))
+}
+
+// Generate implements testing/quick's Generator.
+func (c CIDR) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewCIDR(netip.Prefix{}, false), func() CIDR {
+		var a [16]byte
+		rand.Read(a[:])
+		addr := netip.AddrFrom16(a)
+		if rand.Intn(2) == 0 {
+			addr = netip.AddrFrom4([4]byte(a[:4]))
+		}
+		p, _ := addr.Prefix(rand.Intn(addr.BitLen() + 1))
+		return CIDRFrom(p)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (c CIDR) MarshalBinary() ([]byte, error) {
+	return marshalBinary(c.Set, c.Valid, func() ([]byte, error) {
+		return c.CIDR.MarshalBinary()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *CIDR) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.CIDR")
+	if err != nil {
+		return err
+	}
+	var v netip.Prefix
+	if valid {
+		if err = v.UnmarshalBinary(p); err != nil {
+			return err
+		}
+	}
+	c.CIDR, c.Valid, c.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (c *CIDR) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.CIDR", value)
+	switch x := value.(type) {
+	case nil:
+		c.CIDR, c.Valid, c.Set = netip.Prefix{}, false, true
+		return nil
+	case string:
+		err = c.UnmarshalText([]byte(x))
+	case []byte:
+		err = c.UnmarshalText(x)
+	default:
+		c.CIDR, c.Valid, c.Set = netip.Prefix{}, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.CIDR: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (c CIDR) Value() (driver.Value, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+	return c.CIDR.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (c CIDR) String() string {
+	return fmt.Sprint(c)
+}
+
+// GoString implements the GoStringer interface, formatting c as the Go
+// expression that builds it.
+func (c CIDR) GoString() string {
+	return goString("CIDR", "", "CIDR", goPrefix(c.CIDR), c.CIDR == netip.Prefix{}, c.Valid, c.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// c with the verb if it is valid.
+func (c CIDR) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, c, c.Valid, c.Set, c.CIDR)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value, or to nil if this CIDR is null.
+func (c CIDR) LogValue() slog.Value {
+	if !c.Valid {
+		return logNull
+	}
+	return slog.StringValue(c.CIDR.String())
+}
@@ -0,0 +1,160 @@
+package null
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+var (
+	cidrValue = netip.MustParsePrefix("10.0.0.0/8")
+	cidrJSON  = []byte(`"10.0.0.0/8"`)
+)
+
+func TestCIDRFrom(t *testing.T) {
+	c := CIDRFrom(cidrValue)
+	assertCIDR(t, c, "CIDRFrom()")
+
+	null := CIDRFrom(netip.Prefix{})
+	assertNullCIDR(t, null, "CIDRFrom(zero)")
+}
+
+func TestCIDRFromPtr(t *testing.T) {
+	v := cidrValue
+	c := CIDRFromPtr(&v)
+	assertCIDR(t, c, "CIDRFromPtr()")
+
+	null := CIDRFromPtr(nil)
+	assertNullCIDR(t, null, "CIDRFromPtr(nil)")
+}
+
+func TestCIDRFromString(t *testing.T) {
+	for _, in := range []string{"10.0.0.0/8", "2001:db8::/32", "::ffff:10.0.0.0/104"} {
+		c, err := CIDRFromString(in)
+		maybePanic(err)
+		data, err := c.MarshalText()
+		maybePanic(err)
+		if !c.Valid || string(data) != in {
+			t.Errorf("CIDRFromString(%q) = %s", in, data)
+		}
+	}
+
+	null, err := CIDRFromString("")
+	maybePanic(err)
+	assertNullCIDR(t, null, "CIDRFromString(\"\")")
+
+	for _, in := range []string{"abc", "10.0.0.0", "10.0.0.0/33"} {
+		if _, err := CIDRFromString(in); err == nil {
+			t.Errorf("CIDRFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestUnmarshalCIDR(t *testing.T) {
+	var c CIDR
+	err := json.Unmarshal(cidrJSON, &c)
+	maybePanic(err)
+	assertCIDR(t, c, "cidr json")
+
+	var null CIDR
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullCIDR(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty CIDR
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullCIDR(t, empty, "empty string json")
+
+	var badType CIDR
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullCIDR(t, badType, "wrong type json")
+}
+
+func TestMarshalCIDR(t *testing.T) {
+	c := CIDRFrom(cidrValue)
+	data, err := json.Marshal(c)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(cidrJSON), "non-empty json marshal")
+
+	null := NewCIDR(netip.Prefix{}, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestCIDRScanValue(t *testing.T) {
+	for _, v := range []interface{}{"10.0.0.0/8", []byte("10.0.0.0/8")} {
+		var c CIDR
+		err := c.Scan(v)
+		maybePanic(err)
+		assertCIDR(t, c, "scanned cidr")
+
+		value, err := c.Value()
+		maybePanic(err)
+		if value != "10.0.0.0/8" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var null CIDR
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullCIDR(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"abc", int64(1)} {
+		var wrong CIDR
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullCIDR(t, wrong, "scanned garbage")
+	}
+}
+
+func TestCIDRPointer(t *testing.T) {
+	c := CIDRFrom(cidrValue)
+	ptr := c.Ptr()
+	if *ptr != cidrValue {
+		t.Errorf("bad %s cidr: %#v ≠ %v\n", "pointer", ptr, cidrValue)
+	}
+
+	null := NewCIDR(netip.Prefix{}, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s cidr: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestCIDRSetValid(t *testing.T) {
+	change := NewCIDR(netip.Prefix{}, false)
+	assertNullCIDR(t, change, "SetValid()")
+	change.SetValid(cidrValue)
+	assertCIDR(t, change, "SetValid()")
+}
+
+func assertCIDR(t *testing.T, c CIDR, from string) {
+	if c.CIDR != cidrValue {
+		t.Errorf("bad %s cidr: %v ≠ %v\n", from, c.CIDR, cidrValue)
+	}
+	if !c.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullCIDR(t *testing.T, c CIDR, from string) {
+	if c.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
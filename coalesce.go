@@ -0,0 +1,37 @@
+package null
+
+// Coalesce returns the first of vals that is valid, like SQL's COALESCE,
+// so a user setting, an organization default and a system default can be
+// tried in turn:
+//
+//	name := null.Coalesce(user.Name, org.Name, null.StringFrom("anonymous"))
+//
+// The values need not be set. If none is valid, Coalesce returns the first
+// one that is set, an explicit null, or the zero value, which is unset, if
+// none is set either.
+func Coalesce[T Value](vals ...T) T {
+	var first T
+	found := false
+	for _, v := range vals {
+		if v.IsValid() {
+			return v
+		}
+		if !found && v.IsSet() {
+			first, found = v, true
+		}
+	}
+	return first
+}
+
+// FirstSet returns the first of vals that is set, null or not, or the zero
+// value, which is unset, if none is. It fills the fields a PATCH body left
+// out with defaults while keeping the explicit nulls it did send.
+func FirstSet[T Value](vals ...T) T {
+	for _, v := range vals {
+		if v.IsSet() {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
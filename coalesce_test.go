@@ -0,0 +1,35 @@
+package null
+
+import "testing"
+
+func TestCoalesce(t *testing.T) {
+	unset, null := String{}, NewString("", false)
+	a, b := StringFrom("a"), StringFrom("b")
+	tests := []struct {
+		in            []String
+		coalesce, set String
+	}{
+		{nil, unset, unset},
+		{[]String{unset}, unset, unset},
+		{[]String{unset, null}, null, null},
+		{[]String{unset, a, b}, a, a},
+		{[]String{null, unset, b}, b, null},
+		{[]String{StringFrom(""), a}, StringFrom(""), StringFrom("")},
+		{[]String{{String: "stale", Set: true}, a}, a, String{String: "stale", Set: true}},
+	}
+	for _, test := range tests {
+		if got := Coalesce(test.in...); got != test.coalesce {
+			t.Errorf("Coalesce(%#v) = %#v, want %#v", test.in, got, test.coalesce)
+		}
+		if got := FirstSet(test.in...); got != test.set {
+			t.Errorf("FirstSet(%#v) = %#v, want %#v", test.in, got, test.set)
+		}
+	}
+
+	if got := Coalesce(Int64{}, NewInt64(0, false), Int64From(7)); got != Int64From(7) {
+		t.Errorf("Coalesce of Int64 = %#v", got)
+	}
+	if got := Coalesce(Enum[testStatus]{}, EnumFrom(statusBlocked)); got != EnumFrom(statusBlocked) {
+		t.Errorf("Coalesce of Enum = %#v", got)
+	}
+}
@@ -0,0 +1,149 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SetColumns returns the names of the fields of patch, a struct or a
+// pointer to one, that hold a Value with IsSet true, in field order. This
+// is the column list of a dynamic UPDATE:
+//
+//	cols, err := null.SetColumns(patch, "db")
+//
+// Each field is named by the part of its tag before any comma, or by its
+// Go name if it has no such tag or tag is empty; a tag of "-" skips the
+// field. The fields of exported embedded structs, and of embedded pointers
+// that are not nil, are listed like fields of patch itself. Other fields,
+// and nil pointers to a Value, are not listed. A nil pointer yields no
+// columns. The reflection work is cached per type and tag.
+func SetColumns(patch interface{}, tag string) ([]string, error) {
+	return columns(patch, tag, false)
+}
+
+// ValidColumns is like SetColumns, but lists the fields that hold a Value
+// with IsValid true, which are the columns of an INSERT that skips nulls.
+func ValidColumns(patch interface{}, tag string) ([]string, error) {
+	return columns(patch, tag, true)
+}
+
+func columns(patch interface{}, tag string, valid bool) ([]string, error) {
+	v := reflect.ValueOf(patch)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("null: cannot list the columns of %T, it is not a struct", patch)
+	}
+	var names []string
+	for _, c := range columnsFor(v.Type(), tag) {
+		f, ok := fieldByIndex(v, c.index)
+		if !ok {
+			continue
+		}
+		if c.valid != nil {
+			// As IsValid, a Valid flag only counts once Set.
+			if set := f.FieldByIndex(c.set).Bool(); set && (!valid || f.FieldByIndex(c.valid).Bool()) {
+				names = append(names, c.name)
+			}
+			continue
+		}
+		if f.Kind() == reflect.Ptr && f.IsNil() {
+			continue
+		}
+		x := f.Interface().(Value)
+		if (valid && x.IsValid()) || (!valid && x.IsSet()) {
+			names = append(names, c.name)
+		}
+	}
+	return names, nil
+}
+
+// column is a field listed by SetColumns. The payload and the Valid and Set
+// flags of this package's types are read directly, which spares boxing the
+// field; their indexes are nil for other implementations of Value.
+type column struct {
+	name       string
+	index      []int
+	payload    []int
+	valid, set []int
+}
+
+var columnCache sync.Map // map[columnKey][]column
+
+type columnKey struct {
+	t   reflect.Type
+	tag string
+}
+
+func columnsFor(t reflect.Type, tag string) []column {
+	key := columnKey{t, tag}
+	if c, ok := columnCache.Load(key); ok {
+		return c.([]column)
+	}
+	c, _ := columnCache.LoadOrStore(key, buildColumns(t, tag, nil, map[reflect.Type]bool{}))
+	return c.([]column)
+}
+
+// buildColumns lists the columns of t found at prefix. Embedded structs
+// already in seen are skipped, so embedded pointer cycles end.
+func buildColumns(t reflect.Type, tag string, prefix []int, seen map[reflect.Type]bool) []column {
+	seen[t] = true
+	defer delete(seen, t)
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			n, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+			if n == "-" {
+				continue
+			}
+			if n != "" {
+				name = n
+			}
+		}
+		index := append(append([]int(nil), prefix...), i)
+		if f.Type.Implements(valueType) {
+			c := column{name: name, index: index}
+			if payload, valid, set, ok := nullFields(f.Type); ok {
+				c.payload, c.valid, c.set = payload, valid, set
+			}
+			cols = append(cols, c)
+			continue
+		}
+		if f.Anonymous && isNestedStruct(f.Type) {
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if !seen[et] {
+				cols = append(cols, buildColumns(et, tag, index, seen)...)
+			}
+		}
+	}
+	return cols
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but reports false
+// instead of panicking at a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,112 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ColumnsBase struct {
+	ID      Int64 `db:"id"`
+	Created Time  `db:"created_at"`
+	Next    *ColumnsBase
+	Skipped String `db:"-"`
+}
+
+type columnsFlag bool
+
+func (f columnsFlag) IsValid() bool { return bool(f) }
+func (f columnsFlag) IsSet() bool   { return true }
+
+type columnsPatch struct {
+	*ColumnsBase
+	Name     String           `db:"name,omitempty"`
+	Email    String           `json:"email"`
+	Age      *Int             `db:"age"`
+	Score    *Int             `db:"score"`
+	Status   Enum[testStatus] `db:"status"`
+	Point    Val[testPoint]   `db:"point"`
+	Flag     columnsFlag      `db:"flag"`
+	Address  struct{ City String }
+	Comment  string `db:"comment"`
+	internal String
+}
+
+func TestColumns(t *testing.T) {
+	age := IntFrom(3)
+	patch := columnsPatch{
+		Name:     StringFrom("Alice"),
+		Email:    NewString("", false),
+		Age:      &age,
+		Status:   EnumFrom(statusActive),
+		Point:    NewVal(testPoint{}, false),
+		Address:  struct{ City String }{StringFrom("Paris")},
+		internal: StringFrom("x"),
+	}
+	embedded := patch
+	embedded.ColumnsBase = &ColumnsBase{ID: Int64From(1), Created: NewTime(Time{}.Time, false), Skipped: StringFrom("x")}
+	tests := []struct {
+		patch interface{}
+		tag   string
+		valid bool
+		want  []string
+	}{
+		{patch, "db", false, []string{"name", "Email", "age", "status", "point", "flag"}},
+		{&patch, "db", true, []string{"name", "age", "status"}},
+		{patch, "json", false, []string{"Name", "email", "Age", "Status", "Point", "Flag"}},
+		{patch, "", true, []string{"Name", "Age", "Status"}},
+		{embedded, "db", false, []string{"id", "created_at", "name", "Email", "age", "status", "point", "flag"}},
+		{embedded, "db", true, []string{"id", "name", "age", "status"}},
+		{(*columnsPatch)(nil), "db", false, nil},
+		{struct{ A, B String }{}, "db", false, nil},
+	}
+	for _, test := range tests {
+		f := SetColumns
+		if test.valid {
+			f = ValidColumns
+		}
+		got, err := f(test.patch, test.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("columns(%T, %q, valid %v) = %q, want %q", test.patch, test.tag, test.valid, got, test.want)
+		}
+	}
+
+	for _, in := range []interface{}{nil, 42, []String{}} {
+		if _, err := SetColumns(in, "db"); err == nil || !strings.Contains(err.Error(), "not a struct") {
+			t.Errorf("SetColumns(%#v) = %v, want an error", in, err)
+		}
+	}
+}
+
+// A literal with Valid but not Set is unset, as IsValid and IsSet report, so
+// SetColumns, ValidColumns and Values all leave it out.
+func TestColumnsValidUnset(t *testing.T) {
+	age := Int{Int: 3, Valid: true}
+	patch := columnsPatch{
+		Name:  String{String: "Alice", Valid: true},
+		Email: StringFrom("a@example.com"),
+		Age:   &age,
+	}
+	if got, err := SetColumns(patch, "db"); err != nil || !reflect.DeepEqual(got, []string{"Email", "flag"}) {
+		t.Errorf("SetColumns() = %q, %v", got, err)
+	}
+	if got, err := ValidColumns(patch, "db"); err != nil || !reflect.DeepEqual(got, []string{"Email"}) {
+		t.Errorf("ValidColumns() = %q, %v", got, err)
+	}
+	if got, err := Values(patch, "db"); err != nil || !reflect.DeepEqual(got, map[string]interface{}{"Email": "a@example.com", "flag": nil}) {
+		t.Errorf("Values() = %#v, %v", got, err)
+	}
+}
+
+func BenchmarkSetColumns(b *testing.B) {
+	patch := columnsPatch{Name: StringFrom("Alice"), Status: EnumFrom(statusActive)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := SetColumns(&patch, "db"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package null
+
+// Ordered is implemented by the types with a Compare method: Byte, Rune,
+// String, the integer and float types, Time, Date, TimeOfDay and Duration.
+type Ordered[T any] interface {
+	Compare(other T) int
+}
+
+// compareNull orders a null before a valid value, for Compare methods
+// where aValid and bValid are not both true.
+func compareNull(aValid, bValid bool) int {
+	switch {
+	case aValid == bValid:
+		return 0
+	case aValid:
+		return +1
+	}
+	return -1
+}
+
+// NullsFirst compares a and b by their Compare method, which orders nulls
+// before valid values and treats two nulls as equal. It can be passed to
+// slices.SortFunc as it is, or called from a comparison of structs:
+//
+//	slices.SortFunc(users, func(a, b User) int {
+//		return null.NullsFirst(a.Name, b.Name)
+//	})
+func NullsFirst[T Ordered[T]](a, b T) int {
+	return a.Compare(b)
+}
+
+// NullsLast is like NullsFirst, but orders nulls after valid values. Two
+// nulls are still equal, so a stable sort keeps them in their order.
+func NullsLast[T Ordered[T]](a, b T) int {
+	// Only a null compares equal to the zero value, which is null.
+	var zero T
+	aNull, bNull := a.Compare(zero) == 0, b.Compare(zero) == 0
+	if aNull != bNull {
+		if aNull {
+			return +1
+		}
+		return -1
+	}
+	return a.Compare(b)
+}
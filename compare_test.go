@@ -0,0 +1,92 @@
+package null
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		a, b interface{}
+		want int
+	}{
+		{StringFrom("a"), StringFrom("b"), -1},
+		{StringFrom("b"), StringFrom("a"), +1},
+		{StringFrom(""), StringFrom(""), 0},
+		{String{}, NewString("", false), 0},
+		{NewString("stale", false), String{}, 0},
+		{String{}, StringFrom(""), -1},
+		{StringFrom(""), NewString("", false), +1},
+		{String{String: "a", Valid: true}, StringFrom("a"), 0},
+		{Int8From(-1), Int8From(1), -1},
+		{Int64From(math.MinInt64), NewInt64(0, false), +1},
+		{Uint64From(math.MaxUint64), Uint64From(0), +1},
+		{ByteFrom('a'), ByteFrom('a'), 0},
+		{RuneFrom('é'), RuneFrom('z'), +1},
+		{Float64From(math.NaN()), Float64From(math.Inf(-1)), -1},
+		{Float64From(math.NaN()), Float64From(math.NaN()), 0},
+		{Float64From(math.NaN()), Float64{}, +1},
+		{Float32From(-0.0), Float32From(0), 0},
+		{TimeFrom(when), TimeFrom(when.In(time.FixedZone("X", 3600))), 0},
+		{TimeFrom(when), TimeFrom(when.Add(1)), -1},
+		{DateOf(2012, 12, 21), DateOf(2012, 12, 20), +1},
+		{DurationFrom(time.Second), DurationFrom(time.Minute), -1},
+		{TimeOfDayOf(12, 0, 0, 0), TimeOfDay{}, +1},
+	}
+	for _, test := range tests {
+		got := reflect.ValueOf(test.a).MethodByName("Compare").Call([]reflect.Value{reflect.ValueOf(test.b)})[0].Int()
+		if int(got) != test.want {
+			t.Errorf("%#v.Compare(%#v) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestNullsFirstAndLast(t *testing.T) {
+	in := []Int64{Int64From(3), {}, Int64From(-1), NewInt64(0, false), Int64From(2)}
+
+	first := slices.Clone(in)
+	slices.SortStableFunc(first, NullsFirst[Int64])
+	want := []Int64{{}, NewInt64(0, false), Int64From(-1), Int64From(2), Int64From(3)}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("NullsFirst sorted to %v, want %v", first, want)
+	}
+
+	last := slices.Clone(in)
+	slices.SortStableFunc(last, NullsLast[Int64])
+	want = []Int64{Int64From(-1), Int64From(2), Int64From(3), {}, NewInt64(0, false)}
+	if !reflect.DeepEqual(last, want) {
+		t.Errorf("NullsLast sorted to %v, want %v", last, want)
+	}
+
+	type user struct {
+		Name  String
+		Score Float64
+	}
+	users := []user{
+		{StringFrom("carol"), Float64From(1)},
+		{String{}, Float64From(math.NaN())},
+		{StringFrom("alice"), Float64{}},
+		{StringFrom("bob"), Float64From(2)},
+		{NewString("", false), Float64From(3)},
+	}
+	slices.SortStableFunc(users, func(a, b user) int { return NullsLast(a.Name, b.Name) })
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Name.String)
+	}
+	if want := []string{"alice", "bob", "carol", "", ""}; !reflect.DeepEqual(names, want) {
+		t.Errorf("users sorted by name = %q, want %q", names, want)
+	}
+	slices.SortStableFunc(users, func(a, b user) int { return NullsFirst(a.Score, b.Score) })
+	var scores []string
+	for _, u := range users {
+		scores = append(scores, u.Score.String())
+	}
+	if want := []string{"<unset>", "NaN", "1", "2", "3"}; !reflect.DeepEqual(scores, want) {
+		t.Errorf("users sorted by score = %q, want %q", scores, want)
+	}
+}
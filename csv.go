@@ -0,0 +1,32 @@
+package null
+
+// The CSV methods implement gocsv's TypeMarshaller and TypeUnmarshaller
+// interfaces, so the package needs no CSV dependency. A valid value is
+// written with the same text as MarshalText, except Time, which uses
+// CSVTimeFormat, and a null value as an empty cell.
+//
+// An empty cell unmarshals to null with Set true. A valid empty String or
+// RawBytes is written as an empty cell as well, so it reads back as null.
+
+// marshalCSV returns the text of a valid value, or an empty cell if valid is
+// false.
+func marshalCSV(valid bool, text func() ([]byte, error)) (string, error) {
+	if !valid {
+		return "", nil
+	}
+	data, err := text()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// csvMarshaler and csvUnmarshaler match gocsv's TypeMarshaller and
+// TypeUnmarshaller, which Val uses for T when T implements them.
+type csvMarshaler interface {
+	MarshalCSV() (string, error)
+}
+
+type csvUnmarshaler interface {
+	UnmarshalCSV(string) error
+}
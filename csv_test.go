@@ -0,0 +1,159 @@
+package null
+
+import (
+	"encoding/csv"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvCell interface {
+	MarshalCSV() (string, error)
+}
+
+func TestCSV(t *testing.T) {
+	u, _ := url.Parse("https://example.com/a?b=c")
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		valid csvCell
+		cell  string
+	}{
+		{BoolFrom(false), "false"},
+		{ByteFrom('x'), "x"},
+		{RuneFrom('€'), "€"},
+		{BytesFrom([]byte("hello")), "aGVsbG8="},
+		{RawBytesFrom([]byte("hello")), "hello"},
+		{JSONFrom([]byte(`{"a":1}`)), `{"a":1}`},
+		{StringFrom("a, \"b\"\nc"), "a, \"b\"\nc"},
+		{IntFrom(-1), "-1"},
+		{Int8From(-8), "-8"},
+		{Int16From(-16), "-16"},
+		{Int32From(-32), "-32"},
+		{Int64From(-64), "-64"},
+		{UintFrom(1), "1"},
+		{Uint8From(8), "8"},
+		{Uint16From(16), "16"},
+		{Uint32From(32), "32"},
+		{Uint64From(18446744073709551615), "18446744073709551615"},
+		{Float32From(1.5), "1.5"},
+		{Float64From(-2.5), "-2.5"},
+		{TimeFrom(when), "2012-12-21T21:21:21Z"},
+		{DateOf(2012, 12, 21), "2012-12-21"},
+		{TimeOfDayOf(15, 4, 5, 0), "15:04:05"},
+		{DurationFrom(90 * time.Minute), "1h30m0s"},
+		{DecimalFrom("1.10"), "1.10"},
+		{UUIDFrom(uuidValue), uuidString},
+		{URLFrom(u), "https://example.com/a?b=c"},
+		{IPFrom(netip.MustParseAddr("fe80::1%eth0")), "fe80::1%eth0"},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8"},
+		{BigIntFrom(big.NewInt(-42)), "-42"},
+		{MapFrom(map[string]interface{}{"a": 1.0}), `{"a":1}`},
+		{StringSliceFrom([]string{"a", "b"}), `["a","b"]`},
+		{Int64SliceFrom([]int64{1, 2}), "[1,2]"},
+		{EnumFrom(statusActive), "active"},
+		{ValFrom(42), "42"},
+		{ValFrom(testUserID(7)), "7"},
+	}
+	for _, test := range tests {
+		typ := reflect.TypeOf(test.valid)
+
+		cell, err := test.valid.MarshalCSV()
+		maybePanic(err)
+		if cell != test.cell {
+			t.Errorf("%T.MarshalCSV() = %q, want %q", test.valid, cell, test.cell)
+		}
+
+		// Quoted cells come back from encoding/csv unquoted.
+		var sb strings.Builder
+		w := csv.NewWriter(&sb)
+		maybePanic(w.Write([]string{cell, ""}))
+		w.Flush()
+		record, err := csv.NewReader(strings.NewReader(sb.String())).Read()
+		maybePanic(err)
+
+		ptr := reflect.New(typ)
+		err = ptr.Interface().(csvUnmarshaler).UnmarshalCSV(record[0])
+		maybePanic(err)
+		if back := ptr.Elem().Interface(); !reflect.DeepEqual(back, test.valid) {
+			t.Errorf("%T.UnmarshalCSV(%q) = %#v, want %#v", test.valid, record[0], back, test.valid)
+		}
+
+		ptr = reflect.New(typ)
+		err = ptr.Interface().(csvUnmarshaler).UnmarshalCSV(record[1])
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalCSV(\"\") = %#v, want a set null", test.valid, back.Interface())
+		}
+
+		for _, null := range []interface{}{reflect.Zero(typ).Interface(), ptr.Elem().Interface()} {
+			cell, err := null.(csvCell).MarshalCSV()
+			maybePanic(err)
+			if cell != "" {
+				t.Errorf("null %T.MarshalCSV() = %q, want an empty cell", null, cell)
+			}
+		}
+	}
+}
+
+func TestCSVTimeFormat(t *testing.T) {
+	defer func(layout string) { CSVTimeFormat = layout }(CSVTimeFormat)
+	CSVTimeFormat = "01/02/2006 15:04"
+
+	var tm Time
+	err := tm.UnmarshalCSV("12/21/2012 21:21")
+	maybePanic(err)
+	when := time.Date(2012, 12, 21, 21, 21, 0, 0, time.UTC)
+	if !reflect.DeepEqual(tm, TimeFrom(when)) {
+		t.Errorf("UnmarshalCSV() = %#v, want %#v", tm, TimeFrom(when))
+	}
+	cell, err := tm.MarshalCSV()
+	maybePanic(err)
+	if cell != "12/21/2012 21:21" {
+		t.Errorf("MarshalCSV() = %q, want %q", cell, "12/21/2012 21:21")
+	}
+
+	// The text and JSON forms keep their own layouts.
+	text, err := tm.MarshalText()
+	maybePanic(err)
+	if string(text) != "2012-12-21T21:21:00Z" {
+		t.Errorf("MarshalText() = %s, want RFC 3339", text)
+	}
+
+	if err := tm.UnmarshalCSV("2012-12-21T21:21:21Z"); err == nil {
+		t.Error("UnmarshalCSV() should fail for a cell in another layout")
+	}
+	if tm.Valid || !tm.Set {
+		t.Errorf("UnmarshalCSV() failure left %#v, want a set null", tm)
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	for _, test := range []struct {
+		out  csvUnmarshaler
+		cell string
+	}{
+		{&Bool{}, "maybe"},
+		{&Int8{}, "300"},
+		{&Int64{}, "1.5"},
+		{&Uint64{}, "-1"},
+		{&Float64{}, "abc"},
+		{&Bytes{}, "not base64!"},
+		{&Date{}, "21.12.2012"},
+		{&UUID{}, "abc"},
+		{&Enum[testStatus]{}, "deleted"},
+		{&Map{}, "[1]"},
+		{&StringSlice{}, "a,b"},
+		{&Val[int]{}, "abc"},
+	} {
+		if err := test.out.UnmarshalCSV(test.cell); err == nil {
+			t.Errorf("%T.UnmarshalCSV(%q) should fail", test.out, test.cell)
+		}
+		if reflect.ValueOf(test.out).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalCSV", test.out)
+		}
+	}
+}
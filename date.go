@@ -0,0 +1,593 @@
+package null
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// dateLayout is the format Date uses for JSON, text and strings.
+const dateLayout = "2006-01-02"
+
+// Date is a nullable calendar date without a time of day, such as a SQL
+// DATE. The date is kept as a time.Time at midnight UTC, whatever the
+// location of the time it was made from, so it never shifts a day when
+// rendered in another timezone.
+//
+// Text with a time component, such as "2006-01-02T15:04:05Z", is rejected
+// rather than truncated.
+type Date struct {
+	Date  time.Time
+	Valid bool
+	Set   bool
+}
+
+// NewDate creates a new Date from the year, month and day of t in t's
+// own location.
+func NewDate(t time.Time, valid bool) Date {
+	return Date{
+		Date:  truncateDate(t),
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// DateFrom creates a new Date that will always be valid.
+func DateFrom(t time.Time) Date {
+	return NewDate(t, true)
+}
+
+// DateFromPtr creates a new Date that will be null if t is nil.
+func DateFromPtr(t *time.Time) Date {
+	if t == nil {
+		return NewDate(time.Time{}, false)
+	}
+	return NewDate(*t, true)
+}
+
+// DateFromNonZero creates a new Date that will be null if t is the zero time.
+func DateFromNonZero(t time.Time) Date {
+	return NewDate(t, !t.IsZero())
+}
+
+// DateFromNullTime creates a new Date from the date of a sql.NullTime. It is
+// always Set.
+func DateFromNullTime(n sql.NullTime) Date {
+	return NewDate(n.Time, n.Valid)
+}
+
+// DateOf creates a new valid Date for the given year, month and day.
+func DateOf(year int, month time.Month, day int) Date {
+	return NewDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true)
+}
+
+// DateFromString parses s in "2006-01-02" format into a new Date.
+// An empty string produces a null Date.
+func DateFromString(s string) (Date, error) {
+	var d Date
+	err := d.UnmarshalText([]byte(s))
+	return d, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (d Date) IsValid() bool {
+	return d.Set && d.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (d Date) IsSet() bool {
+	return d.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (d Date) IsNull() bool {
+	return d.Set && !d.Valid
+}
+
+// Equal reports whether d and other are the same date, or both null.
+// Set is ignored; see ExactEqual.
+func (d Date) Equal(other Date) bool {
+	if !d.Valid || !other.Valid {
+		return d.Valid == other.Valid
+	}
+	return d.Date.Equal(other.Date)
+}
+
+// ExactEqual is like Equal, but also requires d and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (d Date) ExactEqual(other Date) bool {
+	return d.Set == other.Set && d.Equal(other)
+}
+
+// Compare returns -1 if d is less than other, 0 if they are equal and +1
+// if d is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+//
+// Times are compared as instants, whatever their locations.
+func (d Date) Compare(other Date) int {
+	if !d.Valid || !other.Valid {
+		return compareNull(d.Valid, other.Valid)
+	}
+	return d.Date.Compare(other.Date)
+}
+
+// Before reports whether d is before other. It is false if either is null.
+func (d Date) Before(other Date) bool {
+	return d.Valid && other.Valid && d.Date.Before(other.Date)
+}
+
+// After reports whether d is after other. It is false if either is null.
+func (d Date) After(other Date) bool {
+	return d.Valid && other.Valid && d.Date.After(other.Date)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (d *Date) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Date", d, data)
+	d.Set = true
+	if bytes.Equal(data, NullBytes) {
+		d.Date, d.Valid = time.Time{}, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null Date if the input is a blank string.
+func (d *Date) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Date", text)
+	d.Set = true
+	if len(text) == 0 {
+		d.Date, d.Valid = time.Time{}, false
+		return nil
+	}
+	res, err := time.Parse(dateLayout, string(text))
+	if err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return fmt.Errorf("null: cannot parse %q into null.Date: want YYYY-MM-DD", text)
+	}
+	d.Date, d.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Date is null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return d.AppendJSON(make([]byte, 0, len(dateLayout)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (d Date) AppendJSON(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = d.Date.AppendFormat(b, dateLayout)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this Date is null.
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.AppendText(make([]byte, 0, len(dateLayout)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if d is null.
+func (d Date) AppendText(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return b, nil
+	}
+	return d.Date.AppendFormat(b, dateLayout), nil
+}
+
+// SetValid changes this Date's value and also sets it to be non-null.
+func (d *Date) SetValid(v time.Time) {
+	d.Date = truncateDate(v)
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull sets this Date to an explicit null, zeroing its value.
+func (d *Date) SetNull() {
+	*d = Date{Set: true}
+}
+
+// Unset resets this Date to the zero value, as if it was never set.
+func (d *Date) Unset() {
+	*d = Date{}
+}
+
+// SetPtr changes this Date to the value p points to, or to an explicit
+// null if p is nil.
+func (d *Date) SetPtr(p *time.Time) {
+	*d = DateFromPtr(p)
+}
+
+// Ptr returns a pointer to this Date's value, or a nil pointer if this Date is null.
+func (d Date) Ptr() *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Date
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (d Date) ValueOrZero() time.Time {
+	if !d.Valid {
+		return time.Time{}
+	}
+	return d.Date
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (d Date) Or(def time.Time) time.Time {
+	if !d.Valid {
+		return def
+	}
+	return d.Date
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (d Date) Val() time.Time {
+	return d.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (d Date) Get() (time.Time, bool) {
+	return d.ValueOrZero(), d.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if d is
+// null.
+func (d Date) MustGet() time.Time {
+	if !d.Valid {
+		panic(&NullError{Type: "null.Date"})
+	}
+	return d.Date
+}
+
+// IsZero returns true if this Date was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (d Date) IsZero() bool {
+	return !d.Set && !d.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Date is null, and the text form otherwise.
+func (d Date) MarshalYAML() (interface{}, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	data, err := d.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Date if the input is null or ~.
+func (d *Date) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	d.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Date is null.
+func (d Date) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Date.
+func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, d.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Date is null.
+func (d Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Date.
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON UTC datetime at midnight, or BSON null if this Date
+// is null.
+func (d Date) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonDateTimeValue(d.Date)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON datetime or timestamp, dropping the time of day, or a
+// string in the same form as UnmarshalText.
+func (d *Date) UnmarshalBSONValue(typ byte, data []byte) error {
+	d.Set = true
+	if bsonIsNull(typ) {
+		d.Date, d.Valid = time.Time{}, false
+		return nil
+	}
+	if typ == bsonString || typ == bsonSymbol {
+		return unmarshalBSONText(typ, data, "null.Date", d.UnmarshalText)
+	}
+	v, err := bsonTime(typ, data, "null.Date")
+	if err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	d.Date, d.Valid = truncateDate(v), true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack timestamp at midnight UTC, or nil if this Date is
+// null.
+func (d Date) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackTime(nil, d.Date), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack timestamp, dropping the time of day, or a string in
+// the same form as UnmarshalText.
+func (d *Date) UnmarshalMsgpack(data []byte) error {
+	d.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	if s, ok := v.(string); ok {
+		return d.UnmarshalText([]byte(s))
+	}
+	res, err := msgpackTime(v, "null.Date")
+	if err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	d.Date, d.Valid = truncateDate(res), true
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a full-date string of tag 1004, such as 2012-12-21, or null if
+// this Date is null.
+func (d Date) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cborNull, nil
+	}
+	return appendCBORDate(nil, d.Date), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a full-date of tag 1004 or a day count of tag 100, a time of
+// tag 0 or 1, dropping the time of day, or a string in the same form as
+// UnmarshalText.
+func (d *Date) UnmarshalCBOR(data []byte) error {
+	d.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	if s, ok := v.(string); ok {
+		return d.UnmarshalText([]byte(s))
+	}
+	res, err := cborTime(v, "null.Date")
+	if err != nil {
+		d.Date, d.Valid = time.Time{}, false
+		return err
+	}
+	d.Date, d.Valid = truncateDate(res), true
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (d Date) MarshalGQL(w io.Writer) {
+	marshalGQL(w, d.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Date.
+func (d *Date) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, d.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Date is
+// null.
+func (d Date) MarshalCSV() (string, error) {
+	return marshalCSV(d.Valid, d.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Date.
+func (d *Date) UnmarshalCSV(cell string) error {
+	return d.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML local date, such as 2012-12-21, or an empty string if
+// this Date is null.
+func (d Date) MarshalTOML() ([]byte, error) {
+	if !d.Valid {
+		return tomlEmpty, nil
+	}
+	return []byte(d.Date.Format(dateLayout)), nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML date or date-time, dropping the time of day, or a string
+// in the same form as UnmarshalText. An empty string unmarshals to a null
+// Date.
+func (d *Date) UnmarshalTOML(v interface{}) error {
+	if x, ok := v.(time.Time); ok {
+		d.Date, d.Valid, d.Set = truncateDate(x), true, true
+		return nil
+	}
+	return unmarshalTOML(v, d.UnmarshalText, d.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random day between 1972 and 2031, or an explicit null if shouldBeNull is true.
+func (d *Date) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*d = NewDate(time.Time{}, false)
+		return
+	}
+	d.SetValid(randomDate(nextInt))
+}
+
+// Generate implements testing/quick's Generator.
+func (d Date) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewDate(time.Time{}, false), func() Date {
+		t := generateTime(rand)
+		return DateOf(t.Year(), t.Month(), t.Day())
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (d Date) MarshalBinary() ([]byte, error) {
+	return marshalBinary(d.Set, d.Valid, func() ([]byte, error) {
+		return d.Date.MarshalBinary()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Date) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Date")
+	if err != nil {
+		return err
+	}
+	var v time.Time
+	if valid {
+		if err = v.UnmarshalBinary(p); err != nil {
+			return err
+		}
+		v = truncateDate(v)
+	}
+	d.Date, d.Valid, d.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// A time.Time keeps only its date in its own location; text must be in
+// "2006-01-02" format.
+func (d *Date) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Date", value)
+	switch x := value.(type) {
+	case nil:
+		d.Date, d.Valid, d.Set = time.Time{}, false, true
+		return nil
+	case time.Time:
+		d.SetValid(x)
+	case string:
+		err = d.UnmarshalText([]byte(x))
+	case []byte:
+		err = d.UnmarshalText(x)
+	default:
+		d.Date, d.Valid, d.Set = time.Time{}, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.Date: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The date is stored as a time.Time at midnight UTC.
+func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Date, nil
+}
+
+// ToNullTime converts d to a sql.NullTime, dropping Set.
+func (d Date) ToNullTime() sql.NullTime {
+	return sql.NullTime{Time: d.Date, Valid: d.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (d Date) String() string {
+	return fmt.Sprint(d)
+}
+
+// GoString implements the GoStringer interface, formatting d as the Go
+// expression that builds it.
+func (d Date) GoString() string {
+	return goString("Date", "", "Date", d.Date.GoString(), d.Date == (time.Time{}), d.Valid, d.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// d with the verb if it is valid.
+func (d Date) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, d, d.Valid, d.Set, d.Date.Format(dateLayout))
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value such as 2012-12-21, or to nil if this Date
+// is null.
+func (d Date) LogValue() slog.Value {
+	if !d.Valid {
+		return logNull
+	}
+	return slog.StringValue(d.Date.Format(dateLayout))
+}
+
+// truncateDate returns midnight UTC of t's date in t's own location.
+func truncateDate(t time.Time) time.Time {
+	y, m, day := t.Date()
+	return time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,203 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	dateValue = time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)
+	dateJSON  = []byte(`"2012-12-21"`)
+)
+
+func TestDateFrom(t *testing.T) {
+	d := DateFrom(dateValue)
+	assertDate(t, d, "DateFrom()")
+
+	west := time.FixedZone("UTC-8", -8*60*60)
+	late := DateFrom(time.Date(2012, 12, 21, 23, 30, 0, 0, west))
+	assertDate(t, late, "DateFrom(late evening west of UTC)")
+
+	east := time.FixedZone("UTC+10", 10*60*60)
+	early := DateFrom(time.Date(2012, 12, 21, 0, 30, 0, 0, east))
+	assertDate(t, early, "DateFrom(early morning east of UTC)")
+
+	assertDate(t, DateOf(2012, time.December, 21), "DateOf()")
+}
+
+func TestDateFromPtr(t *testing.T) {
+	v := dateValue
+	d := DateFromPtr(&v)
+	assertDate(t, d, "DateFromPtr()")
+
+	null := DateFromPtr(nil)
+	assertNullDate(t, null, "DateFromPtr(nil)")
+}
+
+func TestDateFromString(t *testing.T) {
+	d, err := DateFromString("2012-12-21")
+	maybePanic(err)
+	assertDate(t, d, "DateFromString()")
+
+	null, err := DateFromString("")
+	maybePanic(err)
+	assertNullDate(t, null, "DateFromString(\"\")")
+
+	for _, in := range []string{"2012-12-21T00:00:00Z", "2012-12-21 10:00:00", "2012-13-01", "2012-02-30", "21.12.2012"} {
+		if _, err := DateFromString(in); err == nil {
+			t.Errorf("DateFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestDateCompare(t *testing.T) {
+	d := DateOf(2012, time.December, 21)
+	next := DateOf(2012, time.December, 22)
+	null := NewDate(time.Time{}, false)
+
+	if !d.Equal(DateFrom(dateValue.Add(5 * time.Hour))) {
+		t.Error("dates on the same day should be equal")
+	}
+	if d.Equal(next) || d.Equal(null) || null.Equal(d) {
+		t.Error("different dates should not be equal")
+	}
+	if !null.Equal(NewDate(time.Time{}, false)) {
+		t.Error("null dates should be equal")
+	}
+	if !d.Before(next) || next.Before(d) || d.Before(d) {
+		t.Error("bad Before")
+	}
+	if !next.After(d) || d.After(next) || d.After(d) {
+		t.Error("bad After")
+	}
+	if d.Before(null) || null.Before(d) || d.After(null) || null.After(d) {
+		t.Error("comparisons with null should be false")
+	}
+}
+
+func TestUnmarshalDate(t *testing.T) {
+	var d Date
+	err := json.Unmarshal(dateJSON, &d)
+	maybePanic(err)
+	assertDate(t, d, "date json")
+
+	var null Date
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDate(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty Date
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullDate(t, empty, "empty string json")
+
+	var withTime Date
+	err = json.Unmarshal([]byte(`"2012-12-21T00:00:00Z"`), &withTime)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDate(t, withTime, "datetime json")
+
+	var badType Date
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDate(t, badType, "wrong type json")
+}
+
+func TestMarshalDate(t *testing.T) {
+	d := DateFrom(dateValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(dateJSON), "non-empty json marshal")
+
+	data, err = d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "2012-12-21", "non-empty text marshal")
+
+	null := NewDate(time.Time{}, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestDateScanValue(t *testing.T) {
+	west := time.FixedZone("UTC-8", -8*60*60)
+	for _, v := range []interface{}{
+		dateValue,
+		time.Date(2012, 12, 21, 0, 0, 0, 0, west),
+		"2012-12-21",
+		[]byte("2012-12-21"),
+	} {
+		var d Date
+		err := d.Scan(v)
+		maybePanic(err)
+		assertDate(t, d, "scanned date")
+
+		value, err := d.Value()
+		maybePanic(err)
+		if value != dateValue {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var null Date
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullDate(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"2012-12-21 00:00:00", "abc", int64(1)} {
+		var wrong Date
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullDate(t, wrong, "scanned garbage")
+	}
+}
+
+func TestDatePointer(t *testing.T) {
+	d := DateFrom(dateValue)
+	ptr := d.Ptr()
+	if *ptr != dateValue {
+		t.Errorf("bad %s date: %#v ≠ %v\n", "pointer", ptr, dateValue)
+	}
+
+	null := NewDate(time.Time{}, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s date: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDateSetValid(t *testing.T) {
+	change := NewDate(time.Time{}, false)
+	assertNullDate(t, change, "SetValid()")
+	change.SetValid(dateValue.Add(12 * time.Hour))
+	assertDate(t, change, "SetValid()")
+}
+
+func assertDate(t *testing.T, d Date, from string) {
+	if d.Date != dateValue {
+		t.Errorf("bad %s date: %v ≠ %v\n", from, d.Date, dateValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDate(t *testing.T, d Date, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
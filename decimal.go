@@ -0,0 +1,674 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecimalJSONString makes Decimal marshal to a JSON string rather than a
+// JSON number, for clients that would otherwise parse it into a float.
+var DecimalJSONString = false
+
+// Decimal is a nullable exact decimal number, such as a SQL NUMERIC value.
+// It is kept in canonical decimal text so that the scale is preserved
+// exactly: "1.10" stays "1.10".
+type Decimal struct {
+	Decimal string
+	Valid   bool
+	Set     bool
+}
+
+// NewDecimal creates a new Decimal
+func NewDecimal(d string, valid bool) Decimal {
+	return Decimal{
+		Decimal: d,
+		Valid:   valid,
+		Set:     true,
+	}
+}
+
+// DecimalFrom creates a new Decimal that will always be valid.
+// d is stored as is and should already be canonical decimal text;
+// use DecimalFromString to parse untrusted input.
+func DecimalFrom(d string) Decimal {
+	return NewDecimal(d, true)
+}
+
+// DecimalFromPtr creates a new Decimal that will be null if d is nil.
+func DecimalFromPtr(d *string) Decimal {
+	if d == nil {
+		return NewDecimal("", false)
+	}
+	return NewDecimal(*d, true)
+}
+
+// DecimalFromNonEmpty creates a new Decimal that will be null if d is empty.
+func DecimalFromNonEmpty(d string) Decimal {
+	return NewDecimal(d, d != "")
+}
+
+// DecimalFromString parses s into a new Decimal. An empty string produces
+// a null Decimal.
+func DecimalFromString(s string) (Decimal, error) {
+	var d Decimal
+	err := d.UnmarshalText([]byte(s))
+	return d, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (d Decimal) IsValid() bool {
+	return d.Set && d.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (d Decimal) IsSet() bool {
+	return d.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (d Decimal) IsNull() bool {
+	return d.Set && !d.Valid
+}
+
+// Equal reports whether d and other are both null, or both valid with
+// the same value. The text is compared, so "1.10" and "1.1" differ. Set is
+// ignored; see ExactEqual.
+func (d Decimal) Equal(other Decimal) bool {
+	if !d.Valid || !other.Valid {
+		return d.Valid == other.Valid
+	}
+	return d.Decimal == other.Decimal
+}
+
+// ExactEqual is like Equal, but also requires d and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (d Decimal) ExactEqual(other Decimal) bool {
+	return d.Set == other.Set && d.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts both JSON numbers and strings holding a number.
+func (d *Decimal) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Decimal", d, data)
+	d.Set = true
+	data, err = unquoteNumber(data, "null.Decimal")
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(data, NullBytes) {
+		d.Decimal, d.Valid = "", false
+		return nil
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+
+	res, err := parseDecimal(num.String())
+	if err != nil {
+		return err
+	}
+	d.Decimal, d.Valid = res, true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Decimal", text)
+	d.Set = true
+	if len(text) == 0 {
+		d.Decimal, d.Valid = "", false
+		return nil
+	}
+	res, err := parseDecimal(string(text))
+	d.Valid = err == nil
+	if d.Valid {
+		d.Decimal = res
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return d.AppendJSON(make([]byte, 0, len(d.Decimal)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b. A valid Decimal that does not hold decimal text, such as
+// DecimalFrom(""), is an error matching ErrInvalidInput, as it would not be
+// a JSON number.
+func (d Decimal) AppendJSON(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return append(b, "null"...), nil
+	}
+	if !isDecimalText(d.Decimal) {
+		return b, &kindError{fmt.Errorf("null: cannot marshal %q as a null.Decimal", d.Decimal), ErrInvalidInput}
+	}
+	if DecimalJSONString {
+		b = append(b, '"')
+		b = append(b, d.Decimal...)
+		return append(b, '"'), nil
+	}
+	return append(b, d.Decimal...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.AppendText(make([]byte, 0, len(d.Decimal)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if d is null.
+func (d Decimal) AppendText(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return b, nil
+	}
+	return append(b, d.Decimal...), nil
+}
+
+// SetValid changes this Decimal's value and also sets it to be non-null.
+func (d *Decimal) SetValid(v string) {
+	d.Decimal = v
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull sets this Decimal to an explicit null, zeroing its value.
+func (d *Decimal) SetNull() {
+	*d = Decimal{Set: true}
+}
+
+// Unset resets this Decimal to the zero value, as if it was never set.
+func (d *Decimal) Unset() {
+	*d = Decimal{}
+}
+
+// SetPtr changes this Decimal to the value p points to, or to an explicit
+// null if p is nil.
+func (d *Decimal) SetPtr(p *string) {
+	*d = DecimalFromPtr(p)
+}
+
+// Ptr returns a pointer to this Decimal's value, or a nil pointer if this Decimal is null.
+func (d Decimal) Ptr() *string {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (d Decimal) ValueOrZero() string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Decimal
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (d Decimal) Or(def string) string {
+	if !d.Valid {
+		return def
+	}
+	return d.Decimal
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (d Decimal) Val() string {
+	return d.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (d Decimal) Get() (string, bool) {
+	return d.ValueOrZero(), d.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if d is
+// null.
+func (d Decimal) MustGet() string {
+	if !d.Valid {
+		panic(&NullError{Type: "null.Decimal"})
+	}
+	return d.Decimal
+}
+
+// IsZero returns true if this Decimal was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (d Decimal) IsZero() bool {
+	return !d.Set && !d.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Decimal is null, and the text form otherwise.
+func (d Decimal) MarshalYAML() (interface{}, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	data, err := d.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Decimal if the input is null or ~.
+func (d *Decimal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	d.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Decimal is null.
+func (d Decimal) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Decimal.
+func (d *Decimal) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, d.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Decimal is null.
+func (d Decimal) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Decimal.
+func (d *Decimal) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON decimal128, or BSON null if this Decimal is null. A
+// Decimal with more than 34 significant digits cannot be encoded.
+func (d Decimal) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonDecimalValue(d.Decimal)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON decimal128, double or integer, or a string in the same
+// form as UnmarshalText. Decimal128 NaN and infinities are rejected.
+func (d *Decimal) UnmarshalBSONValue(typ byte, data []byte) error {
+	d.Set = true
+	if bsonIsNull(typ) {
+		d.Decimal, d.Valid = "", false
+		return nil
+	}
+	var v string
+	var err error
+	switch typ {
+	case bsonString, bsonSymbol:
+		return unmarshalBSONText(typ, data, "null.Decimal", d.UnmarshalText)
+	case bsonDecimal128:
+		v, err = bsonReadDecimal(data)
+	case bsonDouble:
+		var f float64
+		if f, err = bsonFloat(typ, data, "null.Decimal"); err == nil {
+			v, err = parseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	default:
+		var i int64
+		if i, err = bsonInt(typ, data, "null.Decimal", math.MinInt64, math.MaxInt64); err == nil {
+			v = strconv.FormatInt(i, 10)
+		}
+	}
+	d.Decimal, d.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string of the decimal text, which keeps every digit,
+// or nil if this Decimal is null.
+func (d Decimal) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackString(nil, d.Decimal), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText, or any
+// msgpack number.
+func (d *Decimal) UnmarshalMsgpack(data []byte) error {
+	d.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return d.UnmarshalText([]byte(x))
+	case []byte:
+		return d.UnmarshalText(x)
+	case int64:
+		d.Decimal, d.Valid = strconv.FormatInt(x, 10), true
+		return nil
+	case uint64:
+		d.Decimal, d.Valid = strconv.FormatUint(x, 10), true
+		return nil
+	}
+	f, err := msgpackFloat(v, "null.Decimal")
+	if err != nil {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+	res, err := parseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+	d.Decimal, d.Valid = res, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string of the decimal text, which keeps every
+// digit, or null if this Decimal is null.
+func (d Decimal) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cborNull, nil
+	}
+	return appendCBORString(nil, d.Decimal), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText, or any
+// CBOR number.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	d.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return d.UnmarshalText([]byte(x))
+	case []byte:
+		return d.UnmarshalText(x)
+	case int64:
+		d.Decimal, d.Valid = strconv.FormatInt(x, 10), true
+		return nil
+	case uint64:
+		d.Decimal, d.Valid = strconv.FormatUint(x, 10), true
+		return nil
+	}
+	f, err := cborFloat(v, "null.Decimal")
+	if err != nil {
+		d.Decimal, d.Valid = "", false
+		return err
+	}
+	res, err := parseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+	d.Decimal, d.Valid = res, err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (d Decimal) MarshalGQL(w io.Writer) {
+	marshalGQL(w, d.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Decimal.
+func (d *Decimal) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, d.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Decimal is
+// null.
+func (d Decimal) MarshalCSV() (string, error) {
+	return marshalCSV(d.Valid, d.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Decimal.
+func (d *Decimal) UnmarshalCSV(cell string) error {
+	return d.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Decimal is null.
+func (d Decimal) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(d.Valid, d.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Decimal.
+func (d *Decimal) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, d.UnmarshalText, d.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random amount with two decimals, or an explicit null if shouldBeNull is true.
+func (d *Decimal) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*d = NewDecimal("", false)
+		return
+	}
+	d.SetValid(fmt.Sprintf("%d.%02d", randomN(nextInt, 100000), randomN(nextInt, 100)))
+}
+
+// Generate implements testing/quick's Generator.
+func (d Decimal) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewDecimal("", false), func() Decimal {
+		n := strconv.FormatInt(generateInt64(rand), 10)
+		if scale := rand.Intn(7); scale > 0 {
+			frac := make([]byte, scale)
+			for i := range frac {
+				frac[i] = '0' + byte(rand.Intn(10))
+			}
+			n += "." + string(frac)
+		}
+		return DecimalFrom(n)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return marshalBinary(d.Set, d.Valid, func() ([]byte, error) {
+		return []byte(d.Decimal), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Decimal")
+	if err != nil {
+		return err
+	}
+	var v string
+	if valid {
+		v = string(p)
+	}
+	d.Decimal, d.Valid, d.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It accepts string, []byte, int64 and float64 driver values.
+func (d *Decimal) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Decimal", value)
+	var res string
+	switch x := value.(type) {
+	case nil:
+		d.Decimal, d.Valid, d.Set = "", false, true
+		return nil
+	case string:
+		res, err = parseDecimal(x)
+	case []byte:
+		res, err = parseDecimal(string(x))
+	case int64:
+		res = strconv.FormatInt(x, 10)
+	case float64:
+		res, err = parseDecimal(strconv.FormatFloat(x, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Decimal: %v", value, value)
+	}
+	d.Set = true
+	d.Valid = err == nil
+	if d.Valid {
+		d.Decimal = res
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (d Decimal) String() string {
+	return fmt.Sprint(d)
+}
+
+// GoString implements the GoStringer interface, formatting d as the Go
+// expression that builds it.
+func (d Decimal) GoString() string {
+	return goString("Decimal", "", "Decimal", strconv.Quote(d.Decimal), d.Decimal == "", d.Valid, d.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// d with the verb if it is valid.
+func (d Decimal) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, d, d.Valid, d.Set, d.Decimal)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the decimal text, or to nil if this
+// Decimal is null.
+func (d Decimal) LogValue() slog.Value {
+	if !d.Valid {
+		return logNull
+	}
+	return slog.StringValue(d.Decimal)
+}
+
+// isDecimalText reports whether s is decimal text, an optional '-' and
+// digits without superfluous leading zeros, with an optional fraction, as
+// parseDecimal returns it. Such text is also a JSON number.
+func isDecimalText(s string) bool {
+	intPart, fracPart, point := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if intPart == "" || len(intPart) > 1 && intPart[0] == '0' || point && fracPart == "" {
+		return false
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseDecimal validates s as a decimal number and returns it in canonical
+// form: no leading '+' or superfluous leading zeros, no exponent, and the
+// scale of the input preserved.
+func parseDecimal(s string) (string, error) {
+	bad := fmt.Errorf("null: cannot parse %q as a decimal", s)
+
+	str := s
+	neg := false
+	if len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil || e > 1000 || e < -1000 {
+			return "", bad
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	if len(intPart) == 0 && len(fracPart) == 0 {
+		return "", bad
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return "", bad
+			}
+		}
+	}
+
+	// Shift the decimal point by the exponent.
+	digits := intPart + fracPart
+	point := len(intPart) + exp
+	if point < 0 {
+		digits = strings.Repeat("0", -point) + digits
+		point = 0
+	} else if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	intPart, fracPart = strings.TrimLeft(digits[:point], "0"), digits[point:]
+	if len(intPart) == 0 {
+		intPart = "0"
+	}
+
+	res := intPart
+	if len(fracPart) > 0 {
+		res += "." + fracPart
+	}
+	if neg && strings.Trim(digits, "0") != "" {
+		res = "-" + res
+	}
+	return res, nil
+}
@@ -0,0 +1,235 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var (
+	decimalJSON = []byte(`1.10`)
+)
+
+func TestDecimalFrom(t *testing.T) {
+	d := DecimalFrom("1.10")
+	assertDecimal(t, d, "DecimalFrom()")
+
+	zero := DecimalFrom("0")
+	if !zero.Valid {
+		t.Error("DecimalFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestDecimalFromPtr(t *testing.T) {
+	s := "1.10"
+	d := DecimalFromPtr(&s)
+	assertDecimal(t, d, "DecimalFromPtr()")
+
+	null := DecimalFromPtr(nil)
+	assertNullDecimal(t, null, "DecimalFromPtr(nil)")
+}
+
+func TestDecimalFromString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1.10", "1.10"},
+		{"+1.10", "1.10"},
+		{"-1.10", "-1.10"},
+		{"001.10", "1.10"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"0.00", "0.00"},
+		{"-0.00", "0.00"},
+		{"1.5e3", "1500"},
+		{"1.50E-2", "0.0150"},
+		{"123456789012345678901234567890.000000001", "123456789012345678901234567890.000000001"},
+	}
+	for _, test := range tests {
+		d, err := DecimalFromString(test.in)
+		maybePanic(err)
+		if !d.Valid || d.Decimal != test.want {
+			t.Errorf("DecimalFromString(%q) = %v, want %s", test.in, d, test.want)
+		}
+	}
+
+	null, err := DecimalFromString("")
+	maybePanic(err)
+	assertNullDecimal(t, null, "DecimalFromString(\"\")")
+
+	for _, in := range []string{"abc", "1.2.3", "-", ".", "1e", "1,5", "0x10", "1e99999"} {
+		if _, err := DecimalFromString(in); err == nil {
+			t.Errorf("DecimalFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestUnmarshalDecimal(t *testing.T) {
+	var d Decimal
+	err := json.Unmarshal(decimalJSON, &d)
+	maybePanic(err)
+	assertDecimal(t, d, "decimal json")
+
+	var str Decimal
+	err = json.Unmarshal([]byte(`"1.10"`), &str)
+	maybePanic(err)
+	assertDecimal(t, str, "decimal string json")
+
+	var null Decimal
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDecimal(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty Decimal
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullDecimal(t, empty, "empty string json")
+
+	var badType Decimal
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDecimal(t, badType, "wrong type json")
+}
+
+func TestMarshalDecimal(t *testing.T) {
+	d := DecimalFrom("1.10")
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, "1.10", "non-empty json marshal")
+
+	data, err = d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "1.10", "non-empty text marshal")
+
+	null := NewDecimal("", false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMarshalDecimalInvalid(t *testing.T) {
+	defer func(v bool) { DecimalJSONString = v }(DecimalJSONString)
+	for _, s := range []string{"", "abc", "1e5", "01", "1.", ".5", "+1", "--1", "1.2.3"} {
+		for _, str := range []bool{false, true} {
+			DecimalJSONString = str
+			d := DecimalFrom(s)
+			if got, err := d.AppendJSON([]byte("x=")); !errors.Is(err, ErrInvalidInput) || string(got) != "x=" {
+				t.Errorf("AppendJSON(%q) with DecimalJSONString %v = %q, %v, want ErrInvalidInput", s, str, got, err)
+			}
+			if _, err := json.Marshal(d); err == nil {
+				t.Errorf("json.Marshal(%#v) succeeded", d)
+			}
+		}
+	}
+	for _, s := range []string{"0", "-0.00", "10", "0.001", "-123.450"} {
+		if _, err := DecimalFrom(s).AppendJSON(nil); err != nil {
+			t.Errorf("AppendJSON(%q) = %v", s, err)
+		}
+	}
+}
+
+func TestDecimalJSONString(t *testing.T) {
+	defer func(v bool) { DecimalJSONString = v }(DecimalJSONString)
+	DecimalJSONString = true
+
+	data, err := json.Marshal(DecimalFrom("1.10"))
+	maybePanic(err)
+	assertJSONEquals(t, data, `"1.10"`, "string json marshal")
+
+	var d Decimal
+	err = json.Unmarshal(data, &d)
+	maybePanic(err)
+	assertDecimal(t, d, "string json round trip")
+
+	data, err = json.Marshal(NewDecimal("", false))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestDecimalScanValue(t *testing.T) {
+	for _, v := range []interface{}{"1.10", []byte("1.10")} {
+		var d Decimal
+		err := d.Scan(v)
+		maybePanic(err)
+		assertDecimal(t, d, "scanned decimal")
+
+		value, err := d.Value()
+		maybePanic(err)
+		if value != "1.10" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var i Decimal
+	err := i.Scan(int64(-42))
+	maybePanic(err)
+	if !i.Valid || i.Decimal != "-42" {
+		t.Errorf("bad scanned int64: %v", i)
+	}
+
+	var f Decimal
+	err = f.Scan(float64(1.25))
+	maybePanic(err)
+	if !f.Valid || f.Decimal != "1.25" {
+		t.Errorf("bad scanned float64: %v", f)
+	}
+
+	var null Decimal
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDecimal(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong Decimal
+	if err = wrong.Scan("abc"); err == nil {
+		t.Error("expected error")
+	}
+	assertNullDecimal(t, wrong, "scanned garbage")
+}
+
+func TestDecimalPointer(t *testing.T) {
+	d := DecimalFrom("1.10")
+	ptr := d.Ptr()
+	if *ptr != "1.10" {
+		t.Errorf("bad %s decimal: %#v ≠ %s\n", "pointer", ptr, "1.10")
+	}
+
+	null := NewDecimal("", false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s decimal: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDecimalSetValid(t *testing.T) {
+	change := NewDecimal("", false)
+	assertNullDecimal(t, change, "SetValid()")
+	change.SetValid("1.10")
+	assertDecimal(t, change, "SetValid()")
+}
+
+func assertDecimal(t *testing.T, d Decimal, from string) {
+	if d.Decimal != "1.10" {
+		t.Errorf("bad %s decimal: %s ≠ %s\n", from, d.Decimal, "1.10")
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDecimal(t *testing.T, d Decimal, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,194 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// The msgpack and CBOR methods decode their input into plain Go values and
+// convert those to the payload, so both formats accept the same related
+// types. Integers decode as int64, or uint64 above math.MaxInt64, and CBOR
+// bignums as *big.Int; floats as float32 or float64; strings as string and
+// binary as []byte; arrays as []interface{} and maps as decodedMap; and
+// timestamps as time.Time. The conversions take the format name for their
+// error messages.
+
+// decodedMap is a decoded map, with its keys in input order.
+type decodedMap []decodedPair
+
+type decodedPair struct {
+	key   interface{}
+	value interface{}
+}
+
+func decodedTypeName(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "bool"
+	case int64, uint64:
+		return "integer"
+	case *big.Int:
+		return "bignum"
+	case float32:
+		return "float32"
+	case float64:
+		return "float64"
+	case string:
+		return "string"
+	case []byte:
+		return "binary"
+	case []interface{}:
+		return "array"
+	case decodedMap:
+		return "map"
+	case time.Time:
+		return "timestamp"
+	case msgpackExt:
+		return fmt.Sprintf("extension %d", x.typ)
+	case cborTag:
+		return fmt.Sprintf("tag %d", x.num)
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func decodedTypeError(format string, v interface{}, to string) error {
+	return fmt.Errorf("null: cannot decode %s %s into %s", format, decodedTypeName(v), to)
+}
+
+// decodedInt converts a decoded integer, or a float with no fractional
+// part, within [min, max].
+func decodedInt(format string, v interface{}, to string, min, max int64) (int64, error) {
+	var n int64
+	switch x := v.(type) {
+	case int64:
+		n = x
+	case uint64, *big.Int:
+		return 0, overflowf("null: %s value %v overflows %s", format, x, to)
+	case float32, float64:
+		f, _ := decodedFloat(format, v, to)
+		if f != math.Trunc(f) || f < -(1<<63) || f >= 1<<63 {
+			return 0, fmt.Errorf("null: cannot decode %s float %v into %s", format, f, to)
+		}
+		n = int64(f)
+	default:
+		return 0, decodedTypeError(format, v, to)
+	}
+	if n < min || n > max {
+		return 0, overflowf("null: %s value %d overflows %s", format, n, to)
+	}
+	return n, nil
+}
+
+// decodedUint converts a decoded non-negative integer, or a float with no
+// fractional part, up to max.
+func decodedUint(format string, v interface{}, to string, max uint64) (uint64, error) {
+	var n uint64
+	switch x := v.(type) {
+	case uint64:
+		n = x
+	default:
+		i, err := decodedInt(format, v, to, 0, math.MaxInt64)
+		if err != nil {
+			return 0, err
+		}
+		n = uint64(i)
+	}
+	if n > max {
+		return 0, overflowf("null: %s value %d overflows %s", format, n, to)
+	}
+	return n, nil
+}
+
+// decodedFloat converts a decoded float or integer.
+func decodedFloat(format string, v interface{}, to string) (float64, error) {
+	switch x := v.(type) {
+	case float32:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	case int64:
+		return float64(x), nil
+	case uint64:
+		return float64(x), nil
+	}
+	return 0, decodedTypeError(format, v, to)
+}
+
+// decodedText converts a decoded string, or binary holding text.
+func decodedText(format string, v interface{}, to string) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case []byte:
+		return string(x), nil
+	}
+	return "", decodedTypeError(format, v, to)
+}
+
+// decodedTime converts a decoded timestamp or RFC 3339 string.
+func decodedTime(format string, v interface{}, to string) (time.Time, error) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, x)
+	}
+	return time.Time{}, decodedTypeError(format, v, to)
+}
+
+// decodedToJSON converts a decoded value to JSON text. Map keys must be
+// strings; binary becomes a base64 string and timestamps RFC 3339 strings.
+func decodedToJSON(format string, v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case nil, bool, int64, uint64, *big.Int, string, []byte, time.Time:
+		return json.Marshal(x)
+	case float32:
+		return json.Marshal(float64(x))
+	case float64:
+		return json.Marshal(x)
+	case []interface{}:
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			data, err := decodedToJSON(format, e)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	case decodedMap:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, p := range x {
+			key, ok := p.key.(string)
+			if !ok {
+				return nil, fmt.Errorf("null: cannot convert %s map key of type %s to a JSON object key", format, decodedTypeName(p.key))
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			buf.Write(k)
+			buf.WriteByte(':')
+			data, err := decodedToJSON(format, p.value)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(data)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+	return nil, decodedTypeError(format, v, "JSON")
+}
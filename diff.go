@@ -0,0 +1,113 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Diff returns a patch of the same struct type as old and new holding only
+// what changed, for audit logs and minimal UPDATEs of a modified entity:
+//
+//	patch, err := null.Diff(loaded, edited)
+//	cols, err := null.SetColumns(patch, "db")
+//
+// A Value field is copied from new if it differs from old by its ExactEqual
+// method, so a field that became null is a set null in the patch, and Time
+// compares instants and Bytes contents. Fields that are equal, or unset in
+// new, are left unset. Nested structs and pointers to structs are compared
+// field by field, and a pointer in the patch is allocated only if something
+// below it changed; a nil pointer in old compares as a zero struct. Pointers
+// to the null types are compared by what they point to, a nil pointer being
+// unset. Other fields, such as plain strings, are left at their zero value,
+// as a patch cannot tell them apart from a change. T must be a struct or a
+// pointer to one.
+//
+// Applying the patch to old with ApplyPatch gives new, but for those other
+// fields.
+func Diff[T any](old, new T) (T, error) {
+	var patch T
+	pv := reflect.ValueOf(&patch).Elem()
+	t := pv.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return patch, fmt.Errorf("null: cannot diff %s, it is not a struct", pv.Type())
+	}
+	diffField(pv, reflect.ValueOf(&old).Elem(), reflect.ValueOf(&new).Elem())
+	return patch, nil
+}
+
+// diffField sets patch to what changed from old to new, all three of the
+// same type, and reports whether anything did.
+func diffField(patch, old, new reflect.Value) bool {
+	t := patch.Type()
+	switch {
+	case t.Implements(valueType) && t.Kind() == reflect.Ptr && t.Elem().Implements(valueType):
+		if new.IsNil() {
+			return false
+		}
+		o := reflect.Zero(t.Elem())
+		if !old.IsNil() {
+			o = old.Elem()
+		}
+		if !valueChanged(o, new.Elem()) {
+			return false
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(new.Elem())
+		patch.Set(p)
+		return true
+	case t.Implements(valueType):
+		if t.Kind() == reflect.Ptr && new.IsNil() || !valueChanged(old, new) {
+			return false
+		}
+		patch.Set(new)
+		return true
+	case t.Kind() == reflect.Struct && isNestedStruct(t):
+		changed := false
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" && diffField(patch.Field(i), old.Field(i), new.Field(i)) {
+				changed = true
+			}
+		}
+		return changed
+	case isNestedStruct(t):
+		if new.IsNil() {
+			return false
+		}
+		o := reflect.Zero(t.Elem())
+		if !old.IsNil() {
+			o = old.Elem()
+		}
+		p := reflect.New(t.Elem())
+		if !diffField(p.Elem(), o, new.Elem()) {
+			return false
+		}
+		patch.Set(p)
+		return true
+	}
+	return false
+}
+
+// valueChanged reports whether new, a Value, is set and differs from old,
+// by the ExactEqual or Equal method of their type, or by reflect.DeepEqual
+// for other implementations of Value.
+func valueChanged(old, new reflect.Value) bool {
+	if t := new.Type(); t.Kind() == reflect.Ptr && (old.IsNil() || new.IsNil()) {
+		return !new.IsNil() && new.Interface().(Value).IsSet()
+	}
+	nv := new.Interface().(Value)
+	if !nv.IsSet() {
+		return false
+	}
+	if old.Interface().(Value).IsSet() != nv.IsSet() {
+		return true
+	}
+	for _, name := range []string{"ExactEqual", "Equal"} {
+		if m := new.MethodByName(name); m.IsValid() && m.Type().NumIn() == 1 && m.Type().In(0) == new.Type() && m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Bool {
+			return !m.Call([]reflect.Value{old})[0].Bool()
+		}
+	}
+	return !reflect.DeepEqual(old.Interface(), new.Interface())
+}
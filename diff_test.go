@@ -0,0 +1,112 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type diffAddress struct {
+	City String
+	Zip  String
+}
+
+type diffUser struct {
+	Name     String
+	Email    String
+	Nick     String
+	Bio      String
+	Age      *Int
+	Born     Time
+	Avatar   Bytes
+	Flag     columnsFlag
+	Home     diffAddress
+	Work     *diffAddress
+	Note     string
+	internal String
+}
+
+func TestDiff(t *testing.T) {
+	age := IntFrom(30)
+	born := time.Date(1990, 5, 17, 12, 0, 0, 0, time.UTC)
+	old := diffUser{
+		Name:   StringFrom("alice"),
+		Email:  StringFrom("alice@example.com"),
+		Nick:   NewString("", false),
+		Bio:    NewString("", false),
+		Age:    &age,
+		Born:   TimeFrom(born),
+		Avatar: BytesFrom([]byte{1, 2}),
+		Home:   diffAddress{City: StringFrom("Paris"), Zip: StringFrom("75001")},
+		Note:   "old",
+	}
+	newAge := IntFrom(31)
+	edited := old
+	edited.Name = StringFrom("bob")                            // changed
+	edited.Email = NewString("", false)                        // to null
+	edited.Nick = StringFrom("al")                             // from null
+	edited.Age = &newAge                                       // through a pointer
+	edited.Born = TimeFrom(born.In(time.FixedZone("X", 3600))) // same instant
+	edited.Avatar = BytesFrom([]byte{1, 2})                    // same bytes
+	edited.Flag = columnsFlag(true)
+	edited.Home.Zip = StringFrom("75002")
+	edited.Work = &diffAddress{City: StringFrom("Lyon")}
+	edited.Note = "new"
+
+	patch, err := Diff(old, edited)
+	maybePanic(err)
+	want := diffUser{
+		Name:  StringFrom("bob"),
+		Email: NewString("", false),
+		Nick:  StringFrom("al"),
+		Age:   &newAge,
+		Flag:  columnsFlag(true),
+		Home:  diffAddress{Zip: StringFrom("75002")},
+		Work:  &diffAddress{City: StringFrom("Lyon")},
+	}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("Diff = %#v, want %#v", patch, want)
+	}
+	if patch.Bio.Set {
+		t.Error("a field staying null should be unset")
+	}
+	if patch.Age == edited.Age {
+		t.Error("the patch shares a pointer with new")
+	}
+
+	applied := old
+	maybePanic(ApplyPatch(&applied, patch))
+	applied.Note, applied.Flag = edited.Note, edited.Flag
+	if !reflect.DeepEqual(applied.Name, edited.Name) || !applied.Email.ExactEqual(edited.Email) || *applied.Age != newAge || applied.Home != edited.Home {
+		t.Errorf("ApplyPatch(old, Diff) = %#v, want %#v", applied, edited)
+	}
+
+	if patch, err := Diff(old, old); err != nil || !reflect.DeepEqual(patch, diffUser{}) {
+		t.Errorf("Diff of equal structs = %#v, %v, want an empty patch", patch, err)
+	}
+	// Fields unset in new, including nil pointers, are not changes.
+	if patch, err := Diff(old, diffUser{}); err != nil || !reflect.DeepEqual(patch, diffUser{}) {
+		t.Errorf("Diff to an unset struct = %#v, %v, want an empty patch", patch, err)
+	}
+	// Unset and null differ.
+	patch, err = Diff(diffUser{}, diffUser{Name: NewString("", false)})
+	if err != nil || !patch.Name.Set || patch.Name.Valid {
+		t.Errorf("Diff from unset to null = %#v, %v", patch.Name, err)
+	}
+}
+
+func TestDiffPointer(t *testing.T) {
+	old := &diffAddress{City: StringFrom("Paris")}
+	patch, err := Diff(old, &diffAddress{City: StringFrom("Paris"), Zip: StringFrom("75001")})
+	maybePanic(err)
+	if patch == nil || patch.City.Set || !patch.Zip.ExactEqual(StringFrom("75001")) {
+		t.Errorf("Diff of pointers = %#v", patch)
+	}
+	if patch, err := Diff(old, old); err != nil || patch != nil {
+		t.Errorf("Diff of equal pointers = %#v, %v, want nil", patch, err)
+	}
+	if _, err := Diff(1, 2); err == nil || !strings.Contains(err.Error(), "not a struct") {
+		t.Errorf("Diff(1, 2) = %v, want an error", err)
+	}
+}
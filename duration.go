@@ -0,0 +1,636 @@
+package null
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is a nullable time.Duration.
+type Duration struct {
+	Duration time.Duration
+	Valid    bool
+	Set      bool
+}
+
+// NewDuration creates a new Duration
+func NewDuration(d time.Duration, valid bool) Duration {
+	return Duration{
+		Duration: d,
+		Valid:    valid,
+		Set:      true,
+	}
+}
+
+// DurationFrom creates a new Duration that will always be valid.
+func DurationFrom(d time.Duration) Duration {
+	return NewDuration(d, true)
+}
+
+// DurationFromPtr creates a new Duration that will be null if d is nil.
+func DurationFromPtr(d *time.Duration) Duration {
+	if d == nil {
+		return NewDuration(0, false)
+	}
+	return NewDuration(*d, true)
+}
+
+// DurationFromNonZero creates a new Duration that will be null if d is 0.
+func DurationFromNonZero(d time.Duration) Duration {
+	return NewDuration(d, d != 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (d Duration) IsValid() bool {
+	return d.Set && d.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (d Duration) IsSet() bool {
+	return d.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (d Duration) IsNull() bool {
+	return d.Set && !d.Valid
+}
+
+// Equal reports whether d and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (d Duration) Equal(other Duration) bool {
+	if !d.Valid || !other.Valid {
+		return d.Valid == other.Valid
+	}
+	return d.Duration == other.Duration
+}
+
+// ExactEqual is like Equal, but also requires d and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (d Duration) ExactEqual(other Duration) bool {
+	return d.Set == other.Set && d.Equal(other)
+}
+
+// Compare returns -1 if d is less than other, 0 if they are equal and +1
+// if d is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (d Duration) Compare(other Duration) int {
+	if !d.Valid || !other.Valid {
+		return compareNull(d.Valid, other.Valid)
+	}
+	return cmp.Compare(d.Duration, other.Duration)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts strings in time.ParseDuration format, such as "1h30m", and
+// integer numbers of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Duration", d, data)
+	d.Set = true
+	if bytes.Equal(data, NullBytes) {
+		d.Duration, d.Valid = 0, false
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '"' {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		d.Duration, d.Valid = time.Duration(n), true
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Duration", text)
+	d.Set = true
+	if len(text) == 0 {
+		d.Duration, d.Valid = 0, false
+		return nil
+	}
+	res, err := time.ParseDuration(string(text))
+	d.Valid = err == nil
+	if d.Valid {
+		d.Duration = res
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return NullBytes, nil
+	}
+	return d.AppendJSON(make([]byte, 0, 26))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (d Duration) AppendJSON(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = append(b, d.Duration.String()...)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.AppendText(make([]byte, 0, 24))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if d is null.
+func (d Duration) AppendText(b []byte) ([]byte, error) {
+	if !d.Valid {
+		return b, nil
+	}
+	return append(b, d.Duration.String()...), nil
+}
+
+// SetValid changes this Duration's value and also sets it to be non-null.
+func (d *Duration) SetValid(v time.Duration) {
+	d.Duration = v
+	d.Valid = true
+	d.Set = true
+}
+
+// SetNull sets this Duration to an explicit null, zeroing its value.
+func (d *Duration) SetNull() {
+	*d = Duration{Set: true}
+}
+
+// Unset resets this Duration to the zero value, as if it was never set.
+func (d *Duration) Unset() {
+	*d = Duration{}
+}
+
+// SetPtr changes this Duration to the value p points to, or to an explicit
+// null if p is nil.
+func (d *Duration) SetPtr(p *time.Duration) {
+	*d = DurationFromPtr(p)
+}
+
+// Ptr returns a pointer to this Duration's value, or a nil pointer if this Duration is null.
+func (d Duration) Ptr() *time.Duration {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Duration
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (d Duration) ValueOrZero() time.Duration {
+	if !d.Valid {
+		return 0
+	}
+	return d.Duration
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (d Duration) Or(def time.Duration) time.Duration {
+	if !d.Valid {
+		return def
+	}
+	return d.Duration
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (d Duration) Val() time.Duration {
+	return d.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (d Duration) Get() (time.Duration, bool) {
+	return d.ValueOrZero(), d.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if d is
+// null.
+func (d Duration) MustGet() time.Duration {
+	if !d.Valid {
+		panic(&NullError{Type: "null.Duration"})
+	}
+	return d.Duration
+}
+
+// IsZero returns true if this Duration was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (d Duration) IsZero() bool {
+	return !d.Set && !d.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Duration is null, and the text form otherwise.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	data, err := d.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Duration if the input is null or ~.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	d.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		d.Duration, d.Valid = 0, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		d.Duration, d.Valid = 0, false
+		return err
+	}
+	return d.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Duration is null.
+func (d Duration) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Duration.
+func (d *Duration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, d.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Duration is null.
+func (d Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, d.Valid, d.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Duration.
+func (d *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 64-bit integer of nanoseconds, like Value, or BSON
+// null if this Duration is null.
+func (d Duration) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt64Value(int64(d.Duration))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON integer of nanoseconds or a string in the same form as
+// UnmarshalText.
+func (d *Duration) UnmarshalBSONValue(typ byte, data []byte) error {
+	d.Set = true
+	if bsonIsNull(typ) {
+		d.Duration, d.Valid = 0, false
+		return nil
+	}
+	if typ == bsonString || typ == bsonSymbol {
+		return unmarshalBSONText(typ, data, "null.Duration", d.UnmarshalText)
+	}
+	v, err := bsonInt(typ, data, "null.Duration", math.MinInt64, math.MaxInt64)
+	d.Duration, d.Valid = time.Duration(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack integer of nanoseconds, like Value, or nil if this
+// Duration is null.
+func (d Duration) MarshalMsgpack() ([]byte, error) {
+	if !d.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, int64(d.Duration)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack integer of nanoseconds or a string in the same form
+// as UnmarshalText.
+func (d *Duration) UnmarshalMsgpack(data []byte) error {
+	d.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		d.Duration, d.Valid = 0, false
+		return err
+	}
+	if s, ok := v.(string); ok {
+		return d.UnmarshalText([]byte(s))
+	}
+	n, err := msgpackInt(v, "null.Duration", math.MinInt64, math.MaxInt64)
+	d.Duration, d.Valid = time.Duration(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR integer of nanoseconds, like Value, or null if this
+// Duration is null.
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	if !d.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, int64(d.Duration)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR integer of nanoseconds or a string in the same form
+// as UnmarshalText.
+func (d *Duration) UnmarshalCBOR(data []byte) error {
+	d.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		d.Duration, d.Valid = 0, false
+		return err
+	}
+	if s, ok := v.(string); ok {
+		return d.UnmarshalText([]byte(s))
+	}
+	n, err := cborInt(v, "null.Duration", math.MinInt64, math.MaxInt64)
+	d.Duration, d.Valid = time.Duration(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (d Duration) MarshalGQL(w io.Writer) {
+	marshalGQL(w, d.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Duration.
+func (d *Duration) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, d.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Duration is
+// null.
+func (d Duration) MarshalCSV() (string, error) {
+	return marshalCSV(d.Valid, d.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Duration.
+func (d *Duration) UnmarshalCSV(cell string) error {
+	return d.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Duration is null.
+func (d Duration) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(d.Valid, d.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Duration.
+func (d *Duration) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, d.UnmarshalText, d.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random whole number of seconds up to a day, or an explicit null if shouldBeNull is true.
+func (d *Duration) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*d = NewDuration(0, false)
+		return
+	}
+	d.SetValid(time.Duration(1+randomN(nextInt, 24*60*60)) * time.Second)
+}
+
+// Generate implements testing/quick's Generator.
+func (d Duration) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewDuration(0, false), func() Duration {
+		return DurationFrom(time.Duration(generateInt64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	return marshalBinary(d.Set, d.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(d.Duration)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Duration")
+	if err != nil {
+		return err
+	}
+	var v time.Duration
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Duration", math.MinInt64, math.MaxInt64); err != nil {
+			return err
+		}
+		v = time.Duration(n)
+	}
+	d.Duration, d.Valid, d.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// int64 values are read as nanoseconds and float64 values as seconds.
+// Text is parsed in time.ParseDuration format or as a Postgres interval
+// without years or months, such as "1 day 02:03:04.5".
+func (d *Duration) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Duration", value)
+	var res time.Duration
+	switch x := value.(type) {
+	case nil:
+		d.Duration, d.Valid, d.Set = 0, false, true
+		return nil
+	case int64:
+		res = time.Duration(x)
+	case float64:
+		if math.IsNaN(x) || math.Abs(x*1e9) > math.MaxInt64 {
+			err = fmt.Errorf("null: cannot scan %v seconds into null.Duration: out of range", x)
+		}
+		res = time.Duration(math.Round(x * 1e9))
+	case string:
+		res, err = parseInterval(x)
+	case []byte:
+		res, err = parseInterval(string(x))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Duration: %v", value, value)
+	}
+	d.Set = true
+	d.Valid = err == nil
+	if d.Valid {
+		d.Duration = res
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The duration is stored as an int64 number of nanoseconds.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return int64(d.Duration), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (d Duration) String() string {
+	return fmt.Sprint(d)
+}
+
+// GoString implements the GoStringer interface, formatting d as the Go
+// expression that builds it.
+func (d Duration) GoString() string {
+	return goString("Duration", "", "Duration", goDuration(d.Duration), d.Duration == 0, d.Valid, d.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// d with the verb if it is valid.
+func (d Duration) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, d, d.Valid, d.Set, d.Duration)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Duration value, or to nil if this Duration is null.
+func (d Duration) LogValue() slog.Value {
+	if !d.Valid {
+		return logNull
+	}
+	return slog.DurationValue(d.Duration)
+}
+
+// intervalUnits maps the units of the Postgres interval output styles to
+// durations. Years and months are missing on purpose: they have no fixed
+// length.
+var intervalUnits = map[string]time.Duration{
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"sec":     time.Second,
+	"secs":    time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+}
+
+// parseInterval parses s as a time.ParseDuration string or a Postgres
+// interval such as "-1 days +02:03:04.5".
+func parseInterval(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	bad := fmt.Errorf("null: cannot parse %q as a duration", s)
+
+	fields := strings.Fields(strings.TrimPrefix(s, "@"))
+	ago := len(fields) > 1 && fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return 0, bad
+	}
+
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Contains(f, ":") {
+			d, err := parseClock(f)
+			if err != nil {
+				return 0, bad
+			}
+			total += d
+			continue
+		}
+
+		n, err := strconv.ParseFloat(f, 64)
+		if err != nil || i+1 >= len(fields) {
+			return 0, bad
+		}
+		i++
+		unit, ok := intervalUnits[fields[i]]
+		if !ok {
+			return 0, bad
+		}
+		total += time.Duration(math.Round(n * float64(unit)))
+	}
+	if ago {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseClock parses a signed [-+]HH:MM[:SS[.fraction]] time span.
+func parseClock(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("null: bad time span %q", s)
+	}
+	h, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || m > 59 {
+		return 0, fmt.Errorf("null: bad minutes in %q", s)
+	}
+	var sec float64
+	if len(parts) == 3 {
+		sec, err = strconv.ParseFloat(parts[2], 64)
+		if err != nil || sec < 0 || sec >= 60 {
+			return 0, fmt.Errorf("null: bad seconds in %q", s)
+		}
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(math.Round(sec*1e9))
+	return sign * d, nil
+}
@@ -0,0 +1,212 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	durationValue = 90 * time.Minute
+	durationJSON  = []byte(`"1h30m"`)
+)
+
+func TestDurationFrom(t *testing.T) {
+	d := DurationFrom(durationValue)
+	assertDuration(t, d, "DurationFrom()")
+
+	zero := DurationFrom(0)
+	if !zero.Valid {
+		t.Error("DurationFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestDurationFromPtr(t *testing.T) {
+	v := durationValue
+	d := DurationFromPtr(&v)
+	assertDuration(t, d, "DurationFromPtr()")
+
+	null := DurationFromPtr(nil)
+	assertNullDuration(t, null, "DurationFromPtr(nil)")
+}
+
+func TestUnmarshalDuration(t *testing.T) {
+	var d Duration
+	err := json.Unmarshal(durationJSON, &d)
+	maybePanic(err)
+	assertDuration(t, d, "duration json")
+
+	var nanos Duration
+	err = json.Unmarshal([]byte(`5400000000000`), &nanos)
+	maybePanic(err)
+	assertDuration(t, nanos, "nanoseconds json")
+
+	var neg Duration
+	err = json.Unmarshal([]byte(`"-1.5s"`), &neg)
+	maybePanic(err)
+	if !neg.Valid || neg.Duration != -1500*time.Millisecond {
+		t.Errorf("bad negative duration: %v", neg)
+	}
+
+	var zero Duration
+	err = json.Unmarshal([]byte(`"0s"`), &zero)
+	maybePanic(err)
+	if !zero.Valid || zero.Duration != 0 {
+		t.Errorf("bad zero duration: %v", zero)
+	}
+
+	var null Duration
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullDuration(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty Duration
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullDuration(t, empty, "empty string json")
+
+	var bad Duration
+	err = json.Unmarshal([]byte(`"1 fortnight"`), &bad)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDuration(t, bad, "bad string json")
+
+	var badType Duration
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullDuration(t, badType, "wrong type json")
+}
+
+func TestMarshalDuration(t *testing.T) {
+	d := DurationFrom(durationValue)
+	data, err := json.Marshal(d)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"1h30m0s"`, "non-empty json marshal")
+
+	data, err = d.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "1h30m0s", "non-empty text marshal")
+
+	null := NewDuration(0, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestDurationScanValue(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want time.Duration
+	}{
+		{int64(5400000000000), durationValue},
+		{float64(5400), durationValue},
+		{float64(-0.25), -250 * time.Millisecond},
+		{"1h30m", durationValue},
+		{[]byte("1h30m"), durationValue},
+		{"01:30:00", durationValue},
+		{"-00:00:01.5", -1500 * time.Millisecond},
+		{"1 day 02:03:04", 26*time.Hour + 3*time.Minute + 4*time.Second},
+		{"-1 days +01:00:00", -23 * time.Hour},
+		{"2 days", 48 * time.Hour},
+		{"@ 1 hour 30 mins", durationValue},
+		{"@ 1 hour 30 mins ago", -durationValue},
+		{"00:00:00", 0},
+	}
+	for _, test := range tests {
+		var d Duration
+		err := d.Scan(test.in)
+		maybePanic(err)
+		if !d.Valid || d.Duration != test.want {
+			t.Errorf("Scan(%#v) = %v, want %v", test.in, d, test.want)
+		}
+	}
+
+	d := DurationFrom(durationValue)
+	value, err := d.Value()
+	maybePanic(err)
+	if value != int64(durationValue) {
+		t.Errorf("bad value: %#v", value)
+	}
+
+	var null Duration
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullDuration(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, in := range []interface{}{"abc", "1 year", "3 mons", "1:75:00", "5", true} {
+		var wrong Duration
+		if err := wrong.Scan(in); err == nil {
+			t.Errorf("Scan(%#v) should fail", in)
+		}
+		assertNullDuration(t, wrong, "scanned garbage")
+	}
+}
+
+func TestDurationPointer(t *testing.T) {
+	d := DurationFrom(durationValue)
+	ptr := d.Ptr()
+	if *ptr != durationValue {
+		t.Errorf("bad %s duration: %#v ≠ %v\n", "pointer", ptr, durationValue)
+	}
+
+	null := NewDuration(0, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s duration: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestDurationIsZero(t *testing.T) {
+	d := DurationFrom(0)
+	if d.IsZero() {
+		t.Errorf("IsZero() should be false")
+	}
+
+	null := NewDuration(0, false)
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Duration
+	if !unset.IsZero() {
+		t.Errorf("IsZero() should be true")
+	}
+}
+
+func TestDurationSetValid(t *testing.T) {
+	change := NewDuration(0, false)
+	assertNullDuration(t, change, "SetValid()")
+	change.SetValid(durationValue)
+	assertDuration(t, change, "SetValid()")
+}
+
+func assertDuration(t *testing.T, d Duration, from string) {
+	if d.Duration != durationValue {
+		t.Errorf("bad %s duration: %v ≠ %v\n", from, d.Duration, durationValue)
+	}
+	if !d.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullDuration(t *testing.T, d Duration, from string) {
+	if d.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
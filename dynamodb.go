@@ -0,0 +1,770 @@
+//go:build dynamodb
+
+package null
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// The DynamoDB methods implement attributevalue.Marshaler and
+// attributevalue.Unmarshaler from github.com/aws/aws-sdk-go-v2. They are
+// only built with the dynamodb build tag, so the package doesn't depend on
+// the AWS SDK otherwise.
+//
+// Valid values marshal to the natural member: S for text, N for numbers,
+// BOOL, and B for Bytes. JSON, Map and the slices marshal to M and L
+// documents with numbers kept exact. Null values marshal to NULL. The
+// attributevalue decoder doesn't call unmarshalers for missing attributes,
+// so those leave Set false, while NULL sets it.
+
+// dynamoNull is the attribute value null values marshal to.
+var dynamoNull types.AttributeValue = &types.AttributeValueMemberNULL{Value: true}
+
+// marshalDynamoS marshals the text form of a valid value to an S member.
+func marshalDynamoS(valid bool, text func() ([]byte, error)) (types.AttributeValue, error) {
+	if !valid {
+		return dynamoNull, nil
+	}
+	b, err := text()
+	if err != nil {
+		return nil, err
+	}
+	return &types.AttributeValueMemberS{Value: string(b)}, nil
+}
+
+// marshalDynamoN marshals the text form of a valid value to an N member.
+func marshalDynamoN(valid bool, text func() ([]byte, error)) (types.AttributeValue, error) {
+	if !valid {
+		return dynamoNull, nil
+	}
+	b, err := text()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.ContainsAny(b, "NI") {
+		return nil, fmt.Errorf("null: cannot marshal %s as a DynamoDB number", b)
+	}
+	return &types.AttributeValueMemberN{Value: string(b)}, nil
+}
+
+// marshalDynamoJSON converts the JSON form of a valid value to the matching
+// attribute value.
+func marshalDynamoJSON(valid bool, marshalJSON func() ([]byte, error)) (types.AttributeValue, error) {
+	if !valid {
+		return dynamoNull, nil
+	}
+	data, err := marshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	v, err := decodeJSONValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return dynamoFromJSON(v), nil
+}
+
+// dynamoIsNull reports whether av is missing or NULL.
+func dynamoIsNull(av types.AttributeValue) bool {
+	if av == nil {
+		return true
+	}
+	_, ok := av.(*types.AttributeValueMemberNULL)
+	return ok
+}
+
+// unmarshalDynamoText unmarshals NULL through unmarshalJSON, and S and N
+// members through fromS and fromN. A nil fromN rejects N members.
+func unmarshalDynamoText(av types.AttributeValue, name string, unmarshalJSON, fromS, fromN func([]byte) error) error {
+	if dynamoIsNull(av) {
+		return unmarshalJSON(NullBytes)
+	}
+	switch x := av.(type) {
+	case *types.AttributeValueMemberS:
+		return fromS([]byte(x.Value))
+	case *types.AttributeValueMemberN:
+		if fromN != nil {
+			return fromN([]byte(x.Value))
+		}
+	}
+	return dynamoError(av, name)
+}
+
+// unmarshalDynamoJSON converts av to JSON and passes it to unmarshalJSON.
+func unmarshalDynamoJSON(av types.AttributeValue, unmarshalJSON func([]byte) error) error {
+	if dynamoIsNull(av) {
+		return unmarshalJSON(NullBytes)
+	}
+	data, err := json.Marshal(dynamoToJSON(av))
+	if err != nil {
+		return err
+	}
+	return unmarshalJSON(data)
+}
+
+func dynamoError(av types.AttributeValue, name string) error {
+	return fmt.Errorf("null: cannot unmarshal DynamoDB %s into %s", dynamoTypeName(av), name)
+}
+
+// dynamoTypeName returns the DynamoDB type descriptor of av, such as "S".
+func dynamoTypeName(av types.AttributeValue) string {
+	switch av.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberM:
+		return "M"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	}
+	return fmt.Sprintf("%T", av)
+}
+
+// dynamoFromJSON converts a value decoded by decodeJSONValue to an
+// attribute value. Numbers become N members holding the literal text.
+func dynamoFromJSON(v interface{}) types.AttributeValue {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]types.AttributeValue, len(x))
+		for k, e := range x {
+			m[k] = dynamoFromJSON(e)
+		}
+		return &types.AttributeValueMemberM{Value: m}
+	case []interface{}:
+		l := make([]types.AttributeValue, len(x))
+		for i, e := range x {
+			l[i] = dynamoFromJSON(e)
+		}
+		return &types.AttributeValueMemberL{Value: l}
+	case string:
+		return &types.AttributeValueMemberS{Value: x}
+	case json.Number:
+		return &types.AttributeValueMemberN{Value: string(x)}
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: x}
+	}
+	return dynamoNull
+}
+
+// dynamoToJSON converts an attribute value to a value that encodes as the
+// equivalent JSON. Binary values become base64 strings, as in
+// encoding/json.
+func dynamoToJSON(av types.AttributeValue) interface{} {
+	switch x := av.(type) {
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(x.Value))
+		for k, e := range x.Value {
+			m[k] = dynamoToJSON(e)
+		}
+		return m
+	case *types.AttributeValueMemberL:
+		l := make([]interface{}, len(x.Value))
+		for i, e := range x.Value {
+			l[i] = dynamoToJSON(e)
+		}
+		return l
+	case *types.AttributeValueMemberS:
+		return x.Value
+	case *types.AttributeValueMemberN:
+		return json.Number(x.Value)
+	case *types.AttributeValueMemberBOOL:
+		return x.Value
+	case *types.AttributeValueMemberB:
+		return base64.StdEncoding.EncodeToString(x.Value)
+	case *types.AttributeValueMemberSS:
+		l := append([]string(nil), x.Value...)
+		sort.Strings(l)
+		return l
+	case *types.AttributeValueMemberNS:
+		l := make([]json.Number, len(x.Value))
+		for i, n := range x.Value {
+			l[i] = json.Number(n)
+		}
+		return l
+	case *types.AttributeValueMemberBS:
+		l := make([]string, len(x.Value))
+		for i, b := range x.Value {
+			l[i] = base64.StdEncoding.EncodeToString(b)
+		}
+		return l
+	}
+	return nil
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes a BOOL member, or NULL if this Bool is null.
+func (b Bool) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if !b.Valid {
+		return dynamoNull, nil
+	}
+	return &types.AttributeValueMemberBOOL{Value: b.Bool}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts BOOL, its S text form and NULL.
+func (b *Bool) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	if x, ok := av.(*types.AttributeValueMemberBOOL); ok {
+		b.Bool, b.Valid, b.Set = x.Value, true, true
+		return nil
+	}
+	return unmarshalDynamoText(av, "null.Bool", b.UnmarshalJSON, b.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member holding the character, or NULL if this Byte is
+// null.
+func (b Byte) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(b.Valid, b.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (b *Byte) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Byte", b.UnmarshalJSON, b.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member holding the character, or NULL if this Rune is
+// null.
+func (r Rune) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(r.Valid, r.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (r *Rune) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Rune", r.UnmarshalJSON, r.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes a B member, or NULL if this Bytes is null.
+func (b Bytes) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if !b.Valid {
+		return dynamoNull, nil
+	}
+	return &types.AttributeValueMemberB{Value: append([]byte{}, b.Bytes...)}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts B and NULL.
+func (b *Bytes) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	if x, ok := av.(*types.AttributeValueMemberB); ok {
+		b.Bytes, b.Valid, b.Set = append([]byte{}, x.Value...), true, true
+		return nil
+	}
+	if dynamoIsNull(av) {
+		return b.UnmarshalJSON(NullBytes)
+	}
+	return dynamoError(av, "null.Bytes")
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// Like Bytes, it encodes a B member, or NULL if this HexBytes is null.
+func (h HexBytes) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if !h.Valid {
+		return dynamoNull, nil
+	}
+	return &types.AttributeValueMemberB{Value: append([]byte{}, h.HexBytes...)}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts B, S holding hex digits, and NULL.
+func (h *HexBytes) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	if x, ok := av.(*types.AttributeValueMemberB); ok {
+		h.HexBytes, h.Valid, h.Set = append([]byte{}, x.Value...), true, true
+		return nil
+	}
+	return unmarshalDynamoText(av, "null.HexBytes", h.UnmarshalJSON, h.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member holding the bytes verbatim, or NULL if this
+// RawBytes is null.
+func (b RawBytes) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(b.Valid, b.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S, B and NULL.
+func (b *RawBytes) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	if x, ok := av.(*types.AttributeValueMemberB); ok {
+		b.RawBytes, b.Valid, b.Set = append([]byte{}, x.Value...), true, true
+		return nil
+	}
+	return unmarshalDynamoText(av, "null.RawBytes", b.UnmarshalJSON, b.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It converts the JSON value to M, L, S, N, BOOL or NULL, or encodes NULL
+// if this JSON is null.
+func (j JSON) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(j.Valid && len(j.JSON) > 0, j.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts any attribute value and stores its JSON equivalent.
+func (j *JSON) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, j.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member, or NULL if this String is null.
+func (s String) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(s.Valid, s.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (s *String) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.String", s.UnmarshalJSON, s.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Int is null.
+func (i Int) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(i.Valid, i.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (i *Int) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Int", i.UnmarshalJSON, i.UnmarshalText, i.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Int8 is null.
+func (i Int8) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(i.Valid, i.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (i *Int8) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Int8", i.UnmarshalJSON, i.UnmarshalText, i.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Int16 is null.
+func (i Int16) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(i.Valid, i.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (i *Int16) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Int16", i.UnmarshalJSON, i.UnmarshalText, i.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Int32 is null.
+func (i Int32) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(i.Valid, i.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (i *Int32) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Int32", i.UnmarshalJSON, i.UnmarshalText, i.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Int64 is null.
+func (i Int64) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(i.Valid, i.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (i *Int64) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Int64", i.UnmarshalJSON, i.UnmarshalText, i.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Uint is null.
+func (u Uint) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (u *Uint) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Uint", u.UnmarshalJSON, u.UnmarshalText, u.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Uint8 is null.
+func (u Uint8) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (u *Uint8) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Uint8", u.UnmarshalJSON, u.UnmarshalText, u.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Uint16 is null.
+func (u Uint16) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (u *Uint16) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Uint16", u.UnmarshalJSON, u.UnmarshalText, u.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Uint32 is null.
+func (u Uint32) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (u *Uint32) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Uint32", u.UnmarshalJSON, u.UnmarshalText, u.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Uint64 is null.
+func (u Uint64) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (u *Uint64) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Uint64", u.UnmarshalJSON, u.UnmarshalText, u.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Float32 is null. NaN and the
+// infinities are an error, since DynamoDB cannot store them.
+func (f Float32) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(f.Valid, f.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (f *Float32) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Float32", f.UnmarshalJSON, f.UnmarshalText, f.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Float64 is null. NaN and the
+// infinities are an error, since DynamoDB cannot store them.
+func (f Float64) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(f.Valid, f.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (f *Float64) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Float64", f.UnmarshalJSON, f.UnmarshalText, f.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member in RFC 3339 format, like attributevalue does for
+// time.Time, or NULL if this Time is null.
+func (t Time) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(t.Valid, t.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S, N holding Unix seconds, and NULL.
+func (t *Time) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Time", t.UnmarshalJSON, t.UnmarshalText, t.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member in "2006-01-02" format, or NULL if this Date is
+// null.
+func (d Date) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(d.Valid, d.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (d *Date) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Date", d.UnmarshalJSON, d.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member in "15:04:05" format, or NULL if this TimeOfDay
+// is null.
+func (t TimeOfDay) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(t.Valid, t.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (t *TimeOfDay) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.TimeOfDay", t.UnmarshalJSON, t.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member holding nanoseconds, or NULL if this Duration is
+// null.
+func (d Duration) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(d.Valid, func() ([]byte, error) {
+		return strconv.AppendInt(nil, int64(d.Duration), 10), nil
+	})
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N holding nanoseconds, S in time.Duration format, and NULL.
+func (d *Duration) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Duration", d.UnmarshalJSON, d.UnmarshalText, d.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this Decimal is null.
+func (d Decimal) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(d.Valid, d.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (d *Decimal) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Decimal", d.UnmarshalJSON, d.UnmarshalText, d.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an M member like the JSON object, or NULL if this Money is
+// null.
+func (m Money) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts M and NULL, decoded as UnmarshalJSON decodes JSON.
+func (m *Money) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, m.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member in canonical form, or NULL if this UUID is null.
+func (u UUID) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (u *UUID) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.UUID", u.UnmarshalJSON, u.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member, or NULL if this URL is null.
+func (u URL) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(u.Valid, u.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (u *URL) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.URL", u.UnmarshalJSON, u.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member, or NULL if this IP is null.
+func (ip IP) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(ip.Valid, ip.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (ip *IP) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.IP", ip.UnmarshalJSON, ip.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member, or NULL if this CIDR is null.
+func (c CIDR) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(c.Valid, c.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S and NULL.
+func (c *CIDR) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.CIDR", c.UnmarshalJSON, c.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an N member, or NULL if this BigInt is null. DynamoDB keeps
+// only 38 significant digits.
+func (b BigInt) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoN(b.Valid, b.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N, numeric S and NULL.
+func (b *BigInt) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.BigInt", b.UnmarshalJSON, b.UnmarshalText, b.UnmarshalText)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an M member, or NULL if this Map is null.
+func (m Map) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts M and NULL.
+func (m *Map) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, m.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an M member of S values, or NULL if this StringMap is null.
+func (m StringMap) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts M and NULL, decoded as UnmarshalJSON decodes JSON.
+func (m *StringMap) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, m.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an L member of S values, or NULL if this StringSlice is null.
+// It doesn't use SS, which cannot be empty or hold duplicates.
+func (s StringSlice) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts L, SS and NULL.
+func (s *StringSlice) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, s.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an L member of N values, or NULL if this Int64Slice is null.
+// It doesn't use NS, which cannot be empty or hold duplicates.
+func (s Int64Slice) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts L, NS and NULL.
+func (s *Int64Slice) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, s.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes an S member, or NULL if this Enum is null.
+func (e Enum[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoS(e.Valid, e.MarshalText)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts S holding a registered value, and NULL.
+func (e *Enum[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoText(av, "null.Enum", e.UnmarshalJSON, e.UnmarshalText, nil)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes the value with attributevalue.Marshal, or NULL if this Val is
+// null.
+func (v Val[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if !v.Valid {
+		return dynamoNull, nil
+	}
+	return attributevalue.Marshal(v.Val)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It decodes the value with attributevalue.Unmarshal, and accepts NULL.
+func (v *Val[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	var zero T
+	v.Val, v.Valid, v.Set = zero, false, true
+	if dynamoIsNull(av) {
+		return nil
+	}
+	if err := attributevalue.Unmarshal(av, &v.Val); err != nil {
+		v.Val = zero
+		return err
+	}
+	v.Valid = true
+	return nil
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes as Val.MarshalDynamoDBAttributeValue does.
+func (v Validated[T, V]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return v.val().MarshalDynamoDBAttributeValue()
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It decodes as Val.UnmarshalDynamoDBAttributeValue does, then validates
+// the value.
+func (v *Validated[T, V]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalDynamoDBAttributeValue(av)
+	})
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes the JSON of MarshalJSON as an L member, or NULL if this Slice
+// is null.
+func (s Slice[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts L and NULL, decoded as UnmarshalJSON decodes JSON.
+func (s *Slice[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, s.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It encodes the JSON of MarshalJSON as an M member, or NULL if this MapOf
+// is null.
+func (m MapOf[K, V]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts M and NULL, decoded as UnmarshalJSON decodes JSON.
+func (m *MapOf[K, V]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, m.UnmarshalJSON)
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+// It converts the JSON of MarshalJSON to M, L, S, N, BOOL or NULL, or
+// encodes NULL if this JSONOf is null.
+func (j JSONOf[T]) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return marshalDynamoJSON(j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts any attribute value, decoded as UnmarshalJSON decodes its
+// JSON equivalent.
+func (j *JSONOf[T]) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	return unmarshalDynamoJSON(av, j.UnmarshalJSON)
+}
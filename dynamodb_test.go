@@ -0,0 +1,188 @@
+//go:build dynamodb
+
+package null
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type dynamoMarshaler interface {
+	MarshalDynamoDBAttributeValue() (types.AttributeValue, error)
+}
+
+type dynamoUnmarshaler interface {
+	UnmarshalDynamoDBAttributeValue(types.AttributeValue) error
+}
+
+func TestDynamoDBRoundTrip(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := states[2]
+		av, err := valid.(dynamoMarshaler).MarshalDynamoDBAttributeValue()
+		maybePanic(err)
+		ptr := reflect.New(reflect.TypeOf(valid))
+		err = ptr.Interface().(dynamoUnmarshaler).UnmarshalDynamoDBAttributeValue(av)
+		maybePanic(err)
+		if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, valid) {
+			t.Errorf("DynamoDB round trip of %T: %#v ≠ %#v", valid, got, valid)
+		}
+
+		for _, null := range states[:2] {
+			av, err := null.(dynamoMarshaler).MarshalDynamoDBAttributeValue()
+			maybePanic(err)
+			if !reflect.DeepEqual(av, dynamoNull) {
+				t.Errorf("null %T.MarshalDynamoDBAttributeValue() = %#v, want NULL", null, av)
+			}
+		}
+		ptr = reflect.New(reflect.TypeOf(valid))
+		err = ptr.Interface().(dynamoUnmarshaler).UnmarshalDynamoDBAttributeValue(dynamoNull)
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalDynamoDBAttributeValue(NULL) = %#v, want set null", valid, back.Interface())
+		}
+	}
+}
+
+func TestDynamoDBEncoding(t *testing.T) {
+	u, _ := url.Parse("https://example.com/a")
+	tests := []struct {
+		in   dynamoMarshaler
+		want types.AttributeValue
+	}{
+		{BoolFrom(false), &types.AttributeValueMemberBOOL{Value: false}},
+		{StringFrom(""), &types.AttributeValueMemberS{Value: ""}},
+		{Int64From(math.MinInt64), &types.AttributeValueMemberN{Value: "-9223372036854775808"}},
+		{Uint64From(math.MaxUint64), &types.AttributeValueMemberN{Value: "18446744073709551615"}},
+		{Float64From(0.1), &types.AttributeValueMemberN{Value: "0.1"}},
+		{DecimalFrom("1.10"), &types.AttributeValueMemberN{Value: "1.10"}},
+		{BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 100)), &types.AttributeValueMemberN{Value: "1267650600228229401496703205376"}},
+		{BytesFrom([]byte{0xab}), &types.AttributeValueMemberB{Value: []byte{0xab}}},
+		{TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC)), &types.AttributeValueMemberS{Value: "2012-12-21T21:21:21.000000005Z"}},
+		{DateOf(2012, 12, 21), &types.AttributeValueMemberS{Value: "2012-12-21"}},
+		{DurationFrom(time.Second), &types.AttributeValueMemberN{Value: "1000000000"}},
+		{UUIDFrom(uuidValue), &types.AttributeValueMemberS{Value: uuidString}},
+		{URLFrom(u), &types.AttributeValueMemberS{Value: "https://example.com/a"}},
+		{IPFrom(netip.MustParseAddr("::1")), &types.AttributeValueMemberS{Value: "::1"}},
+		{EnumFrom(statusActive), &types.AttributeValueMemberS{Value: "active"}},
+		{JSONFrom([]byte(`{"a":[1.50,true,null,"s"]}`)), &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"a": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberN{Value: "1.50"},
+				&types.AttributeValueMemberBOOL{Value: true},
+				dynamoNull,
+				&types.AttributeValueMemberS{Value: "s"},
+			}},
+		}}},
+		{StringSliceFrom(nil), &types.AttributeValueMemberL{Value: []types.AttributeValue{}}},
+		{Int64SliceFrom([]int64{1, -2}), &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberN{Value: "1"},
+			&types.AttributeValueMemberN{Value: "-2"},
+		}}},
+		{ValFrom(testPoint{1, 2}), &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"X": &types.AttributeValueMemberN{Value: "1"},
+			"Y": &types.AttributeValueMemberN{Value: "2"},
+		}}},
+	}
+	for _, test := range tests {
+		got, err := test.in.MarshalDynamoDBAttributeValue()
+		maybePanic(err)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.MarshalDynamoDBAttributeValue() = %#v, want %#v", test.in, got, test.want)
+		}
+	}
+
+	for _, in := range []dynamoMarshaler{Float64From(math.NaN()), Float32From(float32(math.Inf(1)))} {
+		if _, err := in.MarshalDynamoDBAttributeValue(); err == nil {
+			t.Errorf("%T.MarshalDynamoDBAttributeValue() should fail for %v", in, in)
+		}
+	}
+}
+
+func TestDynamoDBRelatedTypes(t *testing.T) {
+	tests := []struct {
+		out  dynamoUnmarshaler
+		in   types.AttributeValue
+		want interface{}
+	}{
+		{&Int64{}, &types.AttributeValueMemberS{Value: "42"}, Int64From(42)},
+		{&Int64{}, nil, NewInt64(0, false)},
+		{&Bool{}, &types.AttributeValueMemberS{Value: "true"}, BoolFrom(true)},
+		{&Time{}, &types.AttributeValueMemberN{Value: "1356124881"}, TimeFrom(time.Unix(1356124881, 0).UTC())},
+		{&Duration{}, &types.AttributeValueMemberS{Value: "1h30m"}, DurationFrom(90 * time.Minute)},
+		{&RawBytes{}, &types.AttributeValueMemberB{Value: []byte("hi")}, RawBytesFrom([]byte("hi"))},
+		{&StringSlice{}, &types.AttributeValueMemberSS{Value: []string{"b", "a"}}, StringSliceFrom([]string{"a", "b"})},
+		{&Int64Slice{}, &types.AttributeValueMemberNS{Value: []string{"3", "4"}}, Int64SliceFrom([]int64{3, 4})},
+		{&JSON{}, &types.AttributeValueMemberN{Value: "1.50"}, JSONFrom([]byte(`1.50`))},
+		{&JSON{}, &types.AttributeValueMemberB{Value: []byte{0xab}}, JSONFrom([]byte(`"qw=="`))},
+		{&Val[int]{}, &types.AttributeValueMemberN{Value: "9"}, ValFrom(9)},
+	}
+	for _, test := range tests {
+		err := test.out.UnmarshalDynamoDBAttributeValue(test.in)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalDynamoDBAttributeValue(%#v) = %#v, want %#v", test.out, test.in, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		out dynamoUnmarshaler
+		in  types.AttributeValue
+	}{
+		{&Int8{}, &types.AttributeValueMemberN{Value: "300"}},
+		{&Int64{}, &types.AttributeValueMemberN{Value: "1.5"}},
+		{&Uint64{}, &types.AttributeValueMemberN{Value: "18446744073709551616"}},
+		{&String{}, &types.AttributeValueMemberN{Value: "1"}},
+		{&Bytes{}, &types.AttributeValueMemberS{Value: "hi"}},
+		{&Bool{}, &types.AttributeValueMemberN{Value: "1"}},
+		{&UUID{}, &types.AttributeValueMemberS{Value: "abc"}},
+		{&Enum[testStatus]{}, &types.AttributeValueMemberS{Value: "deleted"}},
+		{&Map{}, &types.AttributeValueMemberL{Value: nil}},
+		{&Val[int]{}, &types.AttributeValueMemberS{Value: "abc"}},
+	} {
+		if err := test.out.UnmarshalDynamoDBAttributeValue(test.in); err == nil {
+			t.Errorf("%T.UnmarshalDynamoDBAttributeValue(%#v) should fail", test.out, test.in)
+		}
+		if reflect.ValueOf(test.out).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalDynamoDBAttributeValue", test.out)
+		}
+	}
+}
+
+func TestDynamoDBStruct(t *testing.T) {
+	type item struct {
+		ID     Int64
+		Name   String
+		Email  String
+		Score  Float64
+		Active Bool
+	}
+	in := item{ID: Int64From(9007199254740993), Name: StringFrom("Alice"), Email: NewString("", false)}
+	av, err := attributevalue.MarshalMap(in)
+	maybePanic(err)
+	want := map[string]types.AttributeValue{
+		"ID":     &types.AttributeValueMemberN{Value: "9007199254740993"},
+		"Name":   &types.AttributeValueMemberS{Value: "Alice"},
+		"Email":  dynamoNull,
+		"Score":  dynamoNull,
+		"Active": dynamoNull,
+	}
+	if !reflect.DeepEqual(av, want) {
+		t.Errorf("MarshalMap() = %#v, want %#v", av, want)
+	}
+
+	delete(av, "Score")
+	var out item
+	err = attributevalue.UnmarshalMap(av, &out)
+	maybePanic(err)
+	wantOut := item{ID: in.ID, Name: in.Name, Email: in.Email, Active: NewBool(false, false)}
+	if !reflect.DeepEqual(out, wantOut) {
+		t.Errorf("UnmarshalMap() = %#v, want %#v", out, wantOut)
+	}
+}
@@ -0,0 +1,658 @@
+//go:build easyjson
+
+package null
+
+import (
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// The easyjson methods implement easyjson.Marshaler, easyjson.Unmarshaler
+// and easyjson.Optional from github.com/mailru/easyjson, so code generated
+// by easyjson calls them directly. They are only built with the easyjson
+// build tag, so the package doesn't depend on easyjson otherwise.
+//
+// The output is that of MarshalJSON byte for byte, and input is decoded by
+// UnmarshalJSON, so UnmarshalEasyJSON sets Set for null. Generated decoders
+// call it for neither absent fields nor null ones, though: they skip a null
+// themselves, leaving the field unset, so decode with encoding/json where
+// an explicit null must differ from an absent field. IsDefined is the
+// opposite of IsZero, so an omitempty field is left out only if it was
+// never set.
+
+// unmarshalEasyJSON passes the next value of l to unmarshalJSON.
+func unmarshalEasyJSON(l *jlexer.Lexer, unmarshalJSON func([]byte) error) {
+	if data := l.Raw(); l.Ok() {
+		l.AddError(unmarshalJSON(data))
+	}
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (b Bool) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(b.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (b *Bool) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, b.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (b Bool) IsDefined() bool {
+	return !b.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (b Byte) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(b.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (b *Byte) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, b.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (b Byte) IsDefined() bool {
+	return !b.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (r Rune) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(r.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (r *Rune) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, r.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (r Rune) IsDefined() bool {
+	return !r.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (b Bytes) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(b.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (b *Bytes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, b.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (b Bytes) IsDefined() bool {
+	return !b.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (b RawBytes) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(b.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (b *RawBytes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, b.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (b RawBytes) IsDefined() bool {
+	return !b.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (h HexBytes) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(h.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (h *HexBytes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, h.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (h HexBytes) IsDefined() bool {
+	return !h.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (j JSON) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(j.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (j *JSON) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, j.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (j JSON) IsDefined() bool {
+	return !j.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (s String) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(s.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (s *String) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, s.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (s String) IsDefined() bool {
+	return !s.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (i Int) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(i.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (i *Int) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, i.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (i Int) IsDefined() bool {
+	return !i.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (i Int8) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(i.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (i *Int8) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, i.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (i Int8) IsDefined() bool {
+	return !i.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (i Int16) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(i.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (i *Int16) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, i.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (i Int16) IsDefined() bool {
+	return !i.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (i Int32) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(i.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (i *Int32) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, i.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (i Int32) IsDefined() bool {
+	return !i.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (i Int64) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(i.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (i *Int64) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, i.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (i Int64) IsDefined() bool {
+	return !i.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u Uint) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *Uint) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u Uint) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u Uint8) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *Uint8) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u Uint8) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u Uint16) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *Uint16) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u Uint16) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u Uint32) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *Uint32) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u Uint32) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u Uint64) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *Uint64) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u Uint64) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (f Float32) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(f.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (f *Float32) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, f.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (f Float32) IsDefined() bool {
+	return !f.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (f Float64) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(f.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (f *Float64) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, f.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (f Float64) IsDefined() bool {
+	return !f.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (t Time) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(t.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (t *Time) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, t.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (t Time) IsDefined() bool {
+	return !t.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (d Date) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(d.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (d *Date) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, d.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (d Date) IsDefined() bool {
+	return !d.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (t TimeOfDay) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(t.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (t *TimeOfDay) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, t.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (t TimeOfDay) IsDefined() bool {
+	return !t.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (d Duration) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(d.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (d *Duration) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, d.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (d Duration) IsDefined() bool {
+	return !d.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (d Decimal) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(d.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (d *Decimal) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, d.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (d Decimal) IsDefined() bool {
+	return !d.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (m Money) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(m.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (m *Money) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, m.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (m Money) IsDefined() bool {
+	return !m.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (s Secret) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(s.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (s *Secret) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, s.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (s Secret) IsDefined() bool {
+	return !s.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u UUID) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *UUID) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u UUID) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (u URL) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(u.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (u *URL) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, u.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (u URL) IsDefined() bool {
+	return !u.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (ip IP) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(ip.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (ip *IP) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, ip.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (ip IP) IsDefined() bool {
+	return !ip.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (c CIDR) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(c.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (c *CIDR) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, c.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (c CIDR) IsDefined() bool {
+	return !c.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (b BigInt) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(b.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (b *BigInt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, b.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (b BigInt) IsDefined() bool {
+	return !b.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (m Map) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(m.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (m *Map) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, m.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (m Map) IsDefined() bool {
+	return !m.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (m StringMap) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(m.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (m *StringMap) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, m.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (m StringMap) IsDefined() bool {
+	return !m.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (s StringSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(s.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (s *StringSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, s.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (s StringSlice) IsDefined() bool {
+	return !s.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (s Int64Slice) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(s.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (s *Int64Slice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, s.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (s Int64Slice) IsDefined() bool {
+	return !s.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (e Enum[T]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(e.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (e *Enum[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, e.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (e Enum[T]) IsDefined() bool {
+	return !e.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (v Val[T]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(v.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (v *Val[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, v.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (v Val[T]) IsDefined() bool {
+	return !v.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (v Validated[T, V]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(v.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (v *Validated[T, V]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, v.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (v Validated[T, V]) IsDefined() bool {
+	return !v.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (s Slice[T]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(s.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (s *Slice[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, s.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (s Slice[T]) IsDefined() bool {
+	return !s.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (m MapOf[K, V]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(m.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (m *MapOf[K, V]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, m.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (m MapOf[K, V]) IsDefined() bool {
+	return !m.IsZero()
+}
+
+// MarshalEasyJSON implements easyjson.Marshaler.
+func (j JSONOf[T]) MarshalEasyJSON(w *jwriter.Writer) {
+	w.Raw(j.MarshalJSON())
+}
+
+// UnmarshalEasyJSON implements easyjson.Unmarshaler.
+func (j *JSONOf[T]) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	unmarshalEasyJSON(l, j.UnmarshalJSON)
+}
+
+// IsDefined implements easyjson.Optional as the opposite of IsZero.
+func (j JSONOf[T]) IsDefined() bool {
+	return !j.IsZero()
+}
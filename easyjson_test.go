@@ -0,0 +1,205 @@
+//go:build easyjson
+
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+func TestEasyJSONMatchesMarshalJSON(t *testing.T) {
+	for _, states := range binaryStates() {
+		for i, v := range states {
+			want, err := v.(json.Marshaler).MarshalJSON()
+			maybePanic(err)
+			var w jwriter.Writer
+			v.(easyjson.Marshaler).MarshalEasyJSON(&w)
+			got, err := w.BuildBytes()
+			maybePanic(err)
+			if string(got) != string(want) {
+				t.Errorf("%T state %d: MarshalEasyJSON = %s, MarshalJSON = %s", v, i, got, want)
+			}
+			if defined := v.(easyjson.Optional).IsDefined(); defined != (i > 0) {
+				t.Errorf("%T state %d: IsDefined = %v", v, i, defined)
+			}
+
+			if i == 0 {
+				continue
+			}
+			back := reflect.New(reflect.TypeOf(v))
+			l := jlexer.Lexer{Data: want}
+			back.Interface().(easyjson.Unmarshaler).UnmarshalEasyJSON(&l)
+			maybePanic(l.Error())
+			if !back.Elem().MethodByName("ExactEqual").Call([]reflect.Value{reflect.ValueOf(v)})[0].Bool() {
+				t.Errorf("%T state %d: UnmarshalEasyJSON(%s) = %#v", v, i, want, back.Elem())
+			}
+		}
+	}
+}
+
+func TestEasyJSONErrors(t *testing.T) {
+	var i Int64
+	l := jlexer.Lexer{Data: []byte(`"abc"`)}
+	i.UnmarshalEasyJSON(&l)
+	if l.Error() == nil || i.Valid {
+		t.Errorf("UnmarshalEasyJSON(\"abc\") = %#v, %v, want an error", i, l.Error())
+	}
+
+	l = jlexer.Lexer{Data: []byte(`{"a":`)}
+	var m Map
+	m.UnmarshalEasyJSON(&l)
+	if l.Error() == nil || m.Valid {
+		t.Errorf("UnmarshalEasyJSON of truncated input = %#v, %v, want an error", m, l.Error())
+	}
+
+	var w jwriter.Writer
+	MapOfFrom(map[testMapKey]int{{1, 2}: 3}).MarshalEasyJSON(&w)
+	if _, err := w.BuildBytes(); err == nil {
+		t.Error("MarshalEasyJSON should report the MarshalJSON error")
+	}
+}
+
+// easyOrder is a typical API payload, with the methods easyjson generates
+// for it written out.
+type easyOrder struct {
+	ID       Int64       `json:"id"`
+	Customer String      `json:"customer"`
+	Note     String      `json:"note,omitzero"`
+	Total    Float64     `json:"total"`
+	Paid     Bool        `json:"paid"`
+	Created  Time        `json:"created"`
+	Tags     StringSlice `json:"tags"`
+}
+
+func (o easyOrder) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"id":`)
+	o.ID.MarshalEasyJSON(w)
+	w.RawString(`,"customer":`)
+	o.Customer.MarshalEasyJSON(w)
+	if o.Note.IsDefined() {
+		w.RawString(`,"note":`)
+		o.Note.MarshalEasyJSON(w)
+	}
+	w.RawString(`,"total":`)
+	o.Total.MarshalEasyJSON(w)
+	w.RawString(`,"paid":`)
+	o.Paid.MarshalEasyJSON(w)
+	w.RawString(`,"created":`)
+	o.Created.MarshalEasyJSON(w)
+	w.RawString(`,"tags":`)
+	o.Tags.MarshalEasyJSON(w)
+	w.RawByte('}')
+}
+
+func (o *easyOrder) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	isTopLevel := l.IsStart()
+	if l.IsNull() {
+		if isTopLevel {
+			l.Consumed()
+		}
+		l.Skip()
+		return
+	}
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+		if l.IsNull() {
+			l.Skip()
+			l.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			o.ID.UnmarshalEasyJSON(l)
+		case "customer":
+			o.Customer.UnmarshalEasyJSON(l)
+		case "note":
+			o.Note.UnmarshalEasyJSON(l)
+		case "total":
+			o.Total.UnmarshalEasyJSON(l)
+		case "paid":
+			o.Paid.UnmarshalEasyJSON(l)
+		case "created":
+			o.Created.UnmarshalEasyJSON(l)
+		case "tags":
+			o.Tags.UnmarshalEasyJSON(l)
+		default:
+			l.SkipRecursive()
+		}
+		l.WantComma()
+	}
+	l.Delim('}')
+	if isTopLevel {
+		l.Consumed()
+	}
+}
+
+var testEasyOrder = easyOrder{
+	ID:       Int64From(1234567),
+	Customer: StringFrom("Zoë <zoe@example.com>"),
+	Total:    Float64From(1234.5),
+	Paid:     NewBool(false, false),
+	Created:  TimeFrom(time.Date(2024, 5, 17, 12, 30, 0, 123456789, time.UTC)),
+	Tags:     StringSliceFrom([]string{"priority", "gift"}),
+}
+
+func TestEasyJSONStruct(t *testing.T) {
+	want, err := json.Marshal(testEasyOrder)
+	maybePanic(err)
+	got, err := easyjson.Marshal(testEasyOrder)
+	maybePanic(err)
+	if string(got) != string(want) {
+		t.Errorf("easyjson.Marshal = %s, json.Marshal = %s", got, want)
+	}
+
+	var back easyOrder
+	err = easyjson.Unmarshal(want, &back)
+	maybePanic(err)
+	if !reflect.DeepEqual(back.Tags, testEasyOrder.Tags) || !back.Created.ExactEqual(testEasyOrder.Created) ||
+		!back.Customer.ExactEqual(testEasyOrder.Customer) || back.Note.Set {
+		t.Errorf("easyjson.Unmarshal(%s) = %#v", want, back)
+	}
+	// A generated decoder skips the null without calling UnmarshalEasyJSON.
+	if back.Paid.Set {
+		t.Errorf("null paid = %#v, want it unset", back.Paid)
+	}
+}
+
+func BenchmarkEasyJSONMarshal(b *testing.B) {
+	b.Run("encoding/json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := json.Marshal(testEasyOrder)
+			maybePanic(err)
+		}
+	})
+	b.Run("easyjson", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := easyjson.Marshal(testEasyOrder)
+			maybePanic(err)
+		}
+	})
+}
+
+func BenchmarkEasyJSONUnmarshal(b *testing.B) {
+	data, err := json.Marshal(testEasyOrder)
+	maybePanic(err)
+	b.Run("encoding/json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var o easyOrder
+			maybePanic(json.Unmarshal(data, &o))
+		}
+	})
+	b.Run("easyjson", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var o easyOrder
+			maybePanic(easyjson.Unmarshal(data, &o))
+		}
+	})
+}
@@ -0,0 +1,562 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// enumSpec holds the allowed values registered for an enum type.
+type enumSpec struct {
+	values []string
+	fold   bool
+}
+
+var (
+	enumMu    sync.RWMutex
+	enumSpecs = map[reflect.Type]*enumSpec{}
+)
+
+// RegisterEnum sets the values Enum[T] accepts when unmarshaling and
+// scanning. It replaces any values registered for T before and is
+// meant to be called from an init function.
+func RegisterEnum[T ~string](values ...T) {
+	registerEnum(values, false)
+}
+
+// RegisterEnumFold is like RegisterEnum, but input matches the allowed
+// values case-insensitively. Matched input is stored in the spelling
+// given here.
+func RegisterEnumFold[T ~string](values ...T) {
+	registerEnum(values, true)
+}
+
+func registerEnum[T ~string](values []T, fold bool) {
+	spec := &enumSpec{fold: fold}
+	for _, v := range values {
+		spec.values = append(spec.values, string(v))
+	}
+	enumMu.Lock()
+	enumSpecs[reflect.TypeOf(*new(T))] = spec
+	enumMu.Unlock()
+}
+
+// EnumValues returns the values registered for T.
+func EnumValues[T ~string]() []T {
+	enumMu.RLock()
+	spec := enumSpecs[reflect.TypeOf(*new(T))]
+	enumMu.RUnlock()
+	if spec == nil {
+		return nil
+	}
+	res := make([]T, len(spec.values))
+	for i, v := range spec.values {
+		res[i] = T(v)
+	}
+	return res
+}
+
+// Enum is a nullable string enum, such as a status column. It rejects
+// values that were not registered for T with RegisterEnum or
+// RegisterEnumFold, and otherwise behaves like String.
+type Enum[T ~string] struct {
+	Enum  T
+	Valid bool
+	Set   bool
+}
+
+// NewEnum creates a new Enum
+func NewEnum[T ~string](e T, valid bool) Enum[T] {
+	return Enum[T]{
+		Enum:  e,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// EnumFrom creates a new Enum that will always be valid.
+// e is not checked against the registered values;
+// use EnumFromString to parse untrusted input.
+func EnumFrom[T ~string](e T) Enum[T] {
+	return NewEnum(e, true)
+}
+
+// EnumFromPtr creates a new Enum that will be null if e is nil.
+func EnumFromPtr[T ~string](e *T) Enum[T] {
+	if e == nil {
+		return NewEnum(T(""), false)
+	}
+	return NewEnum(*e, true)
+}
+
+// EnumFromNonEmpty creates a new Enum that will be null if e is empty.
+func EnumFromNonEmpty[T ~string](e T) Enum[T] {
+	return NewEnum(e, e != "")
+}
+
+// EnumFromString checks s against the registered values of T and creates
+// a new Enum. An empty string produces a null Enum.
+func EnumFromString[T ~string](s string) (Enum[T], error) {
+	var e Enum[T]
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (e Enum[T]) IsValid() bool {
+	return e.Set && e.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (e Enum[T]) IsSet() bool {
+	return e.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (e Enum[T]) IsNull() bool {
+	return e.Set && !e.Valid
+}
+
+// Equal reports whether e and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (e Enum[T]) Equal(other Enum[T]) bool {
+	if !e.Valid || !other.Valid {
+		return e.Valid == other.Valid
+	}
+	return e.Enum == other.Enum
+}
+
+// ExactEqual is like Equal, but also requires e and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (e Enum[T]) ExactEqual(other Enum[T]) bool {
+	return e.Set == other.Set && e.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (e *Enum[T]) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Enum", e, data)
+	e.Set = true
+	if bytes.Equal(data, NullBytes) {
+		e.Enum, e.Valid = "", false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		e.Enum, e.Valid = "", false
+		return err
+	}
+	return e.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null Enum if the input is a blank string.
+func (e *Enum[T]) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Enum", text)
+	e.Set = true
+	if len(text) == 0 {
+		e.Enum, e.Valid = "", false
+		return nil
+	}
+	res, err := parseEnum[T](string(text))
+	e.Enum, e.Valid = res, err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Enum is null or empty, as the empty string
+// unmarshals to null.
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	if !e.Valid || e.Enum == "" {
+		return NullBytes, nil
+	}
+	return e.AppendJSON(make([]byte, 0, len(e.Enum)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (e Enum[T]) AppendJSON(b []byte) ([]byte, error) {
+	if !e.Valid || e.Enum == "" {
+		return append(b, "null"...), nil
+	}
+	return appendJSONString(b, e.Enum), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this Enum is null.
+func (e Enum[T]) MarshalText() ([]byte, error) {
+	if !e.Valid {
+		return []byte{}, nil
+	}
+	return e.AppendText(make([]byte, 0, len(e.Enum)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if e is null.
+func (e Enum[T]) AppendText(b []byte) ([]byte, error) {
+	if !e.Valid {
+		return b, nil
+	}
+	return append(b, e.Enum...), nil
+}
+
+// SetValid changes this Enum's value and also sets it to be non-null.
+func (e *Enum[T]) SetValid(v T) {
+	e.Enum = v
+	e.Valid = true
+	e.Set = true
+}
+
+// SetNull sets this Enum to an explicit null, zeroing its value.
+func (e *Enum[T]) SetNull() {
+	*e = Enum[T]{Set: true}
+}
+
+// Unset resets this Enum to the zero value, as if it was never set.
+func (e *Enum[T]) Unset() {
+	*e = Enum[T]{}
+}
+
+// SetPtr changes this Enum to the value p points to, or to an explicit
+// null if p is nil.
+func (e *Enum[T]) SetPtr(p *T) {
+	*e = EnumFromPtr(p)
+}
+
+// Ptr returns a pointer to this Enum's value, or a nil pointer if this Enum is null.
+func (e Enum[T]) Ptr() *T {
+	if !e.Valid {
+		return nil
+	}
+	return &e.Enum
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (e Enum[T]) ValueOrZero() T {
+	if !e.Valid {
+		return ""
+	}
+	return e.Enum
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (e Enum[T]) Or(def T) T {
+	if !e.Valid {
+		return def
+	}
+	return e.Enum
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (e Enum[T]) Val() T {
+	return e.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (e Enum[T]) Get() (T, bool) {
+	return e.ValueOrZero(), e.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if e is
+// null.
+func (e Enum[T]) MustGet() T {
+	if !e.Valid {
+		panic(&NullError{Type: "null.Enum" + goTypeArgs[T]()})
+	}
+	return e.Enum
+}
+
+// IsZero returns true if this Enum was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (e Enum[T]) IsZero() bool {
+	return !e.Set && !e.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Enum is null, and the text form otherwise.
+func (e Enum[T]) MarshalYAML() (interface{}, error) {
+	if !e.Valid {
+		return nil, nil
+	}
+	data, err := e.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Enum if the input is null or ~.
+func (e *Enum[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	e.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		e.Enum, e.Valid = "", false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		e.Enum, e.Valid = "", false
+		return err
+	}
+	return e.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Enum is null.
+func (e Enum[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, e.Valid, e.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Enum.
+func (e *Enum[T]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, e.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Enum is null.
+func (e Enum[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, e.Valid, e.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Enum.
+func (e *Enum[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return e.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this Enum is null.
+func (e Enum[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !e.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(e.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (e *Enum[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.Enum", e.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this Enum is null.
+func (e Enum[T]) MarshalMsgpack() ([]byte, error) {
+	if !e.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, e.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (e *Enum[T]) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.Enum", e.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this Enum is null.
+func (e Enum[T]) MarshalCBOR() ([]byte, error) {
+	if !e.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, e.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (e *Enum[T]) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.Enum", e.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (e Enum[T]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, e.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Enum.
+func (e *Enum[T]) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, e.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Enum is
+// null.
+func (e Enum[T]) MarshalCSV() (string, error) {
+	return marshalCSV(e.Valid, e.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Enum.
+func (e *Enum[T]) UnmarshalCSV(cell string) error {
+	return e.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Enum is null.
+func (e Enum[T]) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(e.Valid, e.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Enum.
+func (e *Enum[T]) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, e.UnmarshalText, e.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It picks one of the values registered for T, or of the values in an
+// "enum('a','b')" fieldType if there are none, or sets an explicit null if
+// shouldBeNull is true. It panics if there are no values to pick from.
+func (e *Enum[T]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*e = NewEnum(T(""), false)
+		return
+	}
+	values := EnumValues[T]()
+	if len(values) == 0 {
+		for _, v := range enumFieldValues(fieldType) {
+			values = append(values, T(v))
+		}
+	}
+	if len(values) == 0 {
+		panic("null: cannot randomize null.Enum" + goTypeArgs[T]() + " without registered values")
+	}
+	e.SetValid(values[randomN(nextInt, int64(len(values)))])
+}
+
+// Generate implements testing/quick's Generator.
+func (e Enum[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewEnum(T(""), false), func() Enum[T] {
+		values := EnumValues[T]()
+		if len(values) == 0 {
+			panic("null: cannot generate null.Enum" + goTypeArgs[T]() + " without registered values")
+		}
+		return EnumFrom(values[rand.Intn(len(values))])
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (e Enum[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(e.Set, e.Valid, func() ([]byte, error) {
+		return []byte(e.Enum), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// The value is not checked against the registered values, as it was
+// produced by MarshalBinary.
+func (e *Enum[T]) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Enum")
+	if err != nil {
+		return err
+	}
+	e.Enum, e.Valid, e.Set = T(p), valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// Unlike UnmarshalText, an empty string is checked against the registered
+// values rather than treated as null.
+func (e *Enum[T]) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Enum", value)
+	var res T
+	switch x := value.(type) {
+	case nil:
+		e.Enum, e.Valid, e.Set = "", false, true
+		return nil
+	case string:
+		res, err = parseEnum[T](x)
+	case []byte:
+		res, err = parseEnum[T](string(x))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Enum: %v", value, value)
+	}
+	e.Set = true
+	e.Valid = err == nil
+	e.Enum = res
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.Valid {
+		return nil, nil
+	}
+	return string(e.Enum), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (e Enum[T]) String() string {
+	return fmt.Sprint(e)
+}
+
+// GoString implements the GoStringer interface, formatting e as the Go
+// expression that builds it.
+func (e Enum[T]) GoString() string {
+	return goString("Enum", goTypeArgs[T](), "Enum", strconv.Quote(string(e.Enum)), e.Enum == "", e.Valid, e.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// e with the verb if it is valid.
+func (e Enum[T]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, e, e.Valid, e.Set, e.Enum)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value, or to nil if this Enum is null.
+func (e Enum[T]) LogValue() slog.Value {
+	if !e.Valid {
+		return logNull
+	}
+	return slog.StringValue(string(e.Enum))
+}
+
+// parseEnum returns s in the spelling registered for T, or an error
+// listing the allowed values.
+func parseEnum[T ~string](s string) (T, error) {
+	typ := reflect.TypeOf(*new(T))
+	enumMu.RLock()
+	spec := enumSpecs[typ]
+	enumMu.RUnlock()
+	if spec == nil {
+		return "", fmt.Errorf("null: no values registered for null.Enum[%v]", typ)
+	}
+
+	for _, v := range spec.values {
+		if v == s || (spec.fold && strings.EqualFold(v, s)) {
+			return T(v), nil
+		}
+	}
+	return "", fmt.Errorf("null: invalid value %q for null.Enum[%v], want one of: %s",
+		s, typ, strings.Join(spec.values, ", "))
+}
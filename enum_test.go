@@ -0,0 +1,212 @@
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testStatus string
+
+const (
+	statusActive  testStatus = "active"
+	statusBlocked testStatus = "blocked"
+)
+
+type testColor string
+
+type testUnregistered string
+
+func init() {
+	RegisterEnum(statusActive, statusBlocked)
+	RegisterEnumFold[testColor]("Red", "Green")
+}
+
+var (
+	enumJSON = []byte(`"active"`)
+)
+
+func TestEnumFrom(t *testing.T) {
+	e := EnumFrom(statusActive)
+	assertEnum(t, e, "EnumFrom()")
+
+	null := NewEnum(testStatus(""), false)
+	assertNullEnum(t, null, "NewEnum(false)")
+}
+
+func TestEnumFromPtr(t *testing.T) {
+	v := statusActive
+	e := EnumFromPtr(&v)
+	assertEnum(t, e, "EnumFromPtr()")
+
+	null := EnumFromPtr[testStatus](nil)
+	assertNullEnum(t, null, "EnumFromPtr(nil)")
+}
+
+func TestEnumFromString(t *testing.T) {
+	e, err := EnumFromString[testStatus]("active")
+	maybePanic(err)
+	assertEnum(t, e, "EnumFromString()")
+
+	null, err := EnumFromString[testStatus]("")
+	maybePanic(err)
+	assertNullEnum(t, null, "EnumFromString(\"\")")
+
+	_, err = EnumFromString[testStatus]("actve")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), `"actve"`) || !strings.Contains(err.Error(), "active, blocked") {
+		t.Errorf("error should list the input and the valid options: %v", err)
+	}
+
+	if _, err = EnumFromString[testStatus]("ACTIVE"); err == nil {
+		t.Error("matching should be case-sensitive by default")
+	}
+
+	if _, err = EnumFromString[testUnregistered]("x"); err == nil {
+		t.Error("unregistered enum types should reject every value")
+	}
+}
+
+func TestEnumFold(t *testing.T) {
+	e, err := EnumFromString[testColor]("gREEN")
+	maybePanic(err)
+	if !e.Valid || e.Enum != "Green" {
+		t.Errorf("bad folded enum: %v", e)
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	if got := EnumValues[testStatus](); !reflect.DeepEqual(got, []testStatus{statusActive, statusBlocked}) {
+		t.Errorf("bad values: %v", got)
+	}
+	if got := EnumValues[testUnregistered](); got != nil {
+		t.Errorf("bad values for unregistered type: %v", got)
+	}
+}
+
+func TestUnmarshalEnum(t *testing.T) {
+	var e Enum[testStatus]
+	err := json.Unmarshal(enumJSON, &e)
+	maybePanic(err)
+	assertEnum(t, e, "enum json")
+
+	var null Enum[testStatus]
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullEnum(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var bad Enum[testStatus]
+	err = json.Unmarshal([]byte(`"deleted"`), &bad)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullEnum(t, bad, "bad enum json")
+
+	var badType Enum[testStatus]
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullEnum(t, badType, "wrong type json")
+
+	var missing struct {
+		Status Enum[testStatus] `json:"status"`
+	}
+	err = json.Unmarshal([]byte(`{}`), &missing)
+	maybePanic(err)
+	if missing.Status.IsSet() {
+		t.Error("missing field should not be Set")
+	}
+}
+
+func TestMarshalEnum(t *testing.T) {
+	e := EnumFrom(statusActive)
+	data, err := json.Marshal(e)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(enumJSON), "non-empty json marshal")
+
+	data, err = e.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "active", "non-empty text marshal")
+
+	null := NewEnum(testStatus(""), false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestEnumScanValue(t *testing.T) {
+	for _, v := range []interface{}{"active", []byte("active")} {
+		var e Enum[testStatus]
+		err := e.Scan(v)
+		maybePanic(err)
+		assertEnum(t, e, "scanned enum")
+
+		value, err := e.Value()
+		maybePanic(err)
+		if value != "active" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var null Enum[testStatus]
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullEnum(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"deleted", "", int64(1)} {
+		var wrong Enum[testStatus]
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullEnum(t, wrong, "scanned garbage")
+	}
+}
+
+func TestEnumPointer(t *testing.T) {
+	e := EnumFrom(statusActive)
+	ptr := e.Ptr()
+	if *ptr != statusActive {
+		t.Errorf("bad %s enum: %#v ≠ %s\n", "pointer", ptr, statusActive)
+	}
+
+	null := NewEnum(testStatus(""), false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s enum: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestEnumSetValid(t *testing.T) {
+	change := NewEnum(testStatus(""), false)
+	assertNullEnum(t, change, "SetValid()")
+	change.SetValid(statusActive)
+	assertEnum(t, change, "SetValid()")
+}
+
+func assertEnum(t *testing.T, e Enum[testStatus], from string) {
+	if e.Enum != statusActive {
+		t.Errorf("bad %s enum: %s ≠ %s\n", from, e.Enum, statusActive)
+	}
+	if !e.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullEnum(t *testing.T, e Enum[testStatus], from string) {
+	if e.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
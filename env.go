@@ -0,0 +1,90 @@
+package null
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+)
+
+// Lookup sets v, a pointer to any type with a text form, from the value
+// that lookup returns for key, parsing it with UnmarshalText. It takes the
+// signature of os.LookupEnv, so other sources of settings, or a map in
+// tests, can stand in for the environment:
+//
+//	var port null.Int
+//	if err := null.Lookup(os.LookupEnv, "PORT", &port); err != nil {
+//		return err
+//	}
+//
+// If lookup finds no key, v is left as it was, so a default stored before
+// the call survives. A key that is present but empty sets v to null, as
+// empty text does. The error names key and wraps that of UnmarshalText, so
+// it matches ErrInvalidInput.
+func Lookup(lookup func(key string) (string, bool), key string, v encoding.TextUnmarshaler) error {
+	s, ok := lookup(key)
+	if !ok {
+		return nil
+	}
+	if err := v.UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("null: %s: %w", key, err)
+	}
+	return nil
+}
+
+// fromEnv looks key up in the environment into a new value with Lookup.
+func fromEnv[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](key string) (T, error) {
+	var v T
+	err := Lookup(os.LookupEnv, key, P(&v))
+	return v, err
+}
+
+// StringFromEnv creates a new String from the environment variable key. It
+// is unset if the variable is not in the environment, null if it is set to
+// the empty string, and valid otherwise. The value is taken as it is, so
+// unlike UnmarshalText it is not checked for UTF-8 or against MaxStringLen.
+func StringFromEnv(key string) String {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return String{}
+	}
+	return NewString(s, s != "")
+}
+
+// IntFromEnv parses the environment variable key with the rules of
+// UnmarshalText into a new Int. It is unset if the variable is not in the
+// environment and null if it is set to the empty string.
+func IntFromEnv(key string) (Int, error) {
+	return fromEnv[Int](key)
+}
+
+// Int64FromEnv parses the environment variable key with the rules of
+// UnmarshalText into a new Int64. It is unset if the variable is not in the
+// environment and null if it is set to the empty string.
+func Int64FromEnv(key string) (Int64, error) {
+	return fromEnv[Int64](key)
+}
+
+// BoolFromEnv parses the environment variable key with the rules of
+// UnmarshalText into a new Bool. It is unset if the variable is not in the
+// environment and null if it is set to the empty string.
+func BoolFromEnv(key string) (Bool, error) {
+	return fromEnv[Bool](key)
+}
+
+// Float64FromEnv parses the environment variable key with the rules of
+// UnmarshalText into a new Float64. It is unset if the variable is not in
+// the environment and null if it is set to the empty string.
+func Float64FromEnv(key string) (Float64, error) {
+	return fromEnv[Float64](key)
+}
+
+// DurationFromEnv parses the environment variable key with the rules of
+// UnmarshalText, such as "1m30s", into a new Duration. It is unset if the
+// variable is not in the environment and null if it is set to the empty
+// string.
+func DurationFromEnv(key string) (Duration, error) {
+	return fromEnv[Duration](key)
+}
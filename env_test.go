@@ -0,0 +1,83 @@
+package null
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("NULL_TEST_NAME", "alice")
+	t.Setenv("NULL_TEST_EMPTY", "")
+	t.Setenv("NULL_TEST_COUNT", "0")
+	t.Setenv("NULL_TEST_SIZE", "-5")
+	t.Setenv("NULL_TEST_VERBOSE", "true")
+	t.Setenv("NULL_TEST_RATIO", "0.5")
+	t.Setenv("NULL_TEST_TIMEOUT", "1m30s")
+	t.Setenv("NULL_TEST_BAD", "many")
+
+	if s := StringFromEnv("NULL_TEST_NAME"); s != StringFrom("alice") {
+		t.Errorf("StringFromEnv() = %#v", s)
+	}
+	if s := StringFromEnv("NULL_TEST_EMPTY"); !s.IsSet() || s.Valid {
+		t.Errorf("StringFromEnv() of an empty variable = %#v, want null", s)
+	}
+	if s := StringFromEnv("NULL_TEST_MISSING"); s.IsSet() {
+		t.Errorf("StringFromEnv() of a missing variable = %#v, want unset", s)
+	}
+
+	count, err := IntFromEnv("NULL_TEST_COUNT")
+	maybePanic(err)
+	size, err := Int64FromEnv("NULL_TEST_SIZE")
+	maybePanic(err)
+	verbose, err := BoolFromEnv("NULL_TEST_VERBOSE")
+	maybePanic(err)
+	ratio, err := Float64FromEnv("NULL_TEST_RATIO")
+	maybePanic(err)
+	timeout, err := DurationFromEnv("NULL_TEST_TIMEOUT")
+	maybePanic(err)
+	if count != IntFrom(0) || size != Int64From(-5) || verbose != BoolFrom(true) ||
+		ratio != Float64From(0.5) || timeout != DurationFrom(90*time.Second) {
+		t.Errorf("bad values: %#v %#v %#v %#v %#v", count, size, verbose, ratio, timeout)
+	}
+
+	missing, err := IntFromEnv("NULL_TEST_MISSING")
+	if err != nil || missing.IsSet() {
+		t.Errorf("IntFromEnv() of a missing variable = %#v, %v, want unset", missing, err)
+	}
+	empty, err := DurationFromEnv("NULL_TEST_EMPTY")
+	if err != nil || !empty.IsSet() || empty.Valid {
+		t.Errorf("DurationFromEnv() of an empty variable = %#v, %v, want null", empty, err)
+	}
+	_, err = IntFromEnv("NULL_TEST_BAD")
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "NULL_TEST_BAD") {
+		t.Errorf("IntFromEnv() of a bad value: %v", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	settings := map[string]string{"port": "8080", "host": ""}
+	lookup := func(key string) (string, bool) {
+		s, ok := settings[key]
+		return s, ok
+	}
+
+	port := IntFrom(80)
+	maybePanic(Lookup(lookup, "port", &port))
+	if port != IntFrom(8080) {
+		t.Errorf("Lookup() = %#v", port)
+	}
+
+	timeout := DurationFrom(time.Second)
+	maybePanic(Lookup(lookup, "timeout", &timeout))
+	if timeout != DurationFrom(time.Second) {
+		t.Errorf("Lookup() of a missing key = %#v, want the default kept", timeout)
+	}
+
+	host := StringFrom("localhost")
+	maybePanic(Lookup(lookup, "host", &host))
+	if !host.IsSet() || host.Valid {
+		t.Errorf("Lookup() of an empty value = %#v, want null", host)
+	}
+}
@@ -0,0 +1,97 @@
+package null
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	u1, _ := url.Parse("https://example.com/a?b=c")
+	u2, _ := url.Parse("https://example.com/a?b=c")
+	u3, _ := url.Parse("https://example.com/a?b=d")
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+
+	// Each row holds a valid value, a separately built value equal to it and
+	// a valid value that differs from both.
+	tests := [][3]interface{}{
+		{BoolFrom(true), BoolFrom(true), BoolFrom(false)},
+		{ByteFrom('x'), ByteFrom('x'), ByteFrom('y')},
+		{RuneFrom('€'), RuneFrom('€'), RuneFrom('$')},
+		{BytesFrom([]byte("hello")), BytesFrom([]byte("hello")), BytesFrom([]byte("hellO"))},
+		{NewBytes(nil, true), BytesFrom([]byte{}), BytesFrom([]byte{0})},
+		{RawBytesFrom([]byte("hello")), RawBytesFrom([]byte("hello")), RawBytesFrom([]byte{})},
+		{HexBytesFrom([]byte{0xde, 0xad}), HexBytesFrom([]byte{0xde, 0xad}), HexBytesFrom([]byte{0xde, 0xad, 0})},
+		{JSONFrom([]byte(`{"a":1,"b":2}`)), JSONFrom([]byte(`{"b":2,"a":1.0}`)), JSONFrom([]byte(`{"a":1}`))},
+		{StringFrom(""), StringFrom(""), StringFrom(" ")},
+		{IntFrom(0), IntFrom(0), IntFrom(1)},
+		{Int8From(-8), Int8From(-8), Int8From(8)},
+		{Int16From(-16), Int16From(-16), Int16From(16)},
+		{Int32From(-32), Int32From(-32), Int32From(32)},
+		{Int64From(-64), Int64From(-64), Int64From(64)},
+		{UintFrom(1), UintFrom(1), UintFrom(2)},
+		{Uint8From(8), Uint8From(8), Uint8From(9)},
+		{Uint16From(16), Uint16From(16), Uint16From(17)},
+		{Uint32From(32), Uint32From(32), Uint32From(33)},
+		{Uint64From(math.MaxUint64), Uint64From(math.MaxUint64), Uint64From(0)},
+		{Float32From(1.5), Float32From(1.5), Float32From(-1.5)},
+		{Float64From(0), Float64From(math.Copysign(0, -1)), Float64From(math.SmallestNonzeroFloat64)},
+		{TimeFrom(when), TimeFrom(when.In(time.FixedZone("X", 3600))), TimeFrom(when.Add(1))},
+		{DateOf(2012, 12, 21), DateFrom(when), DateOf(2012, 12, 22)},
+		{TimeOfDayOf(15, 4, 5, 0), TimeOfDayFrom(15*time.Hour + 4*time.Minute + 5*time.Second), TimeOfDayOf(15, 4, 5, 1)},
+		{DurationFrom(time.Minute), DurationFrom(60 * time.Second), DurationFrom(-time.Minute)},
+		{DecimalFrom("1.10"), DecimalFrom("1.10"), DecimalFrom("1.1")},
+		{UUIDFrom(uuidValue), UUIDFrom(uuidValue), UUIDFrom([16]byte{})},
+		{URLFrom(u1), URLFrom(u2), URLFrom(u3)},
+		{IPFrom(netip.MustParseAddr("10.0.0.1")), IPFrom(netip.MustParseAddr("10.0.0.1")), IPFrom(netip.MustParseAddr("::ffff:10.0.0.1"))},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), CIDRFrom(netip.MustParsePrefix("10.0.0.0/16"))},
+		{BigIntFrom(big.NewInt(42)), BigIntFrom(new(big.Int).SetInt64(42)), BigIntFrom(big.NewInt(-42))},
+		{MapFrom(map[string]interface{}{"a": []interface{}{"b"}}), MapFrom(map[string]interface{}{"a": []interface{}{"b"}}), MapFrom(map[string]interface{}{"a": "b"})},
+		{StringSliceFrom(nil), StringSliceFrom([]string{}), StringSliceFrom([]string{""})},
+		{Int64SliceFrom([]int64{1, 2}), Int64SliceFrom([]int64{1, 2}), Int64SliceFrom([]int64{2, 1})},
+		{EnumFrom(statusActive), EnumFrom(statusActive), EnumFrom(testStatus("inactive"))},
+		{ValFrom(testPoint{1, 2}), ValFrom(testPoint{1, 2}), ValFrom(testPoint{2, 1})},
+		{ValFrom(when), ValFrom(when.In(time.FixedZone("X", 3600))), ValFrom(when.Add(1))},
+	}
+
+	for _, test := range tests {
+		typ := reflect.TypeOf(test[0])
+		unset := reflect.Zero(typ)
+		setNull := reflect.New(typ).Elem()
+		setNull.FieldByName("Set").SetBool(true)
+		validUnset := reflect.New(typ).Elem()
+		validUnset.Set(reflect.ValueOf(test[0]))
+		validUnset.FieldByName("Set").SetBool(false)
+
+		values := []reflect.Value{unset, setNull, reflect.ValueOf(test[0]), reflect.ValueOf(test[1]), validUnset, reflect.ValueOf(test[2])}
+		groups := []int{0, 0, 1, 1, 1, 2}
+		for i, a := range values {
+			for j, b := range values {
+				equal := groups[i] == groups[j]
+				exact := equal && a.FieldByName("Set").Bool() == b.FieldByName("Set").Bool()
+				if got := a.MethodByName("Equal").Call([]reflect.Value{b})[0].Bool(); got != equal {
+					t.Errorf("%#v.Equal(%#v) = %t, want %t", a, b, got, equal)
+				}
+				if got := a.MethodByName("ExactEqual").Call([]reflect.Value{b})[0].Bool(); got != exact {
+					t.Errorf("%#v.ExactEqual(%#v) = %t, want %t", a, b, got, exact)
+				}
+				if m := a.MethodByName("EqualConstantTime"); m.IsValid() {
+					if got := m.Call([]reflect.Value{b})[0].Bool(); got != equal {
+						t.Errorf("%#v.EqualConstantTime(%#v) = %t, want %t", a, b, got, equal)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEqualNaN(t *testing.T) {
+	nan := Float64From(math.NaN())
+	if nan.Equal(nan) {
+		t.Error("NaN should not equal itself")
+	}
+}
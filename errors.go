@@ -0,0 +1,188 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// The kinds of errors returned by UnmarshalJSON, UnmarshalText and Scan,
+// for use with errors.Is. Each of those errors is a *ConvertError matching
+// exactly one of them. A JSON value of the wrong kind is reported by
+// UnmarshalJSON as a *json.UnmarshalTypeError, so that encoding/json can add
+// the field path. With Go 1.27 and the jsonv2 experiment, the default, it
+// also matches ErrInvalidInput and holds a *ConvertError; older toolchains
+// and GOEXPERIMENT=nojsonv2 builds return it bare.
+var (
+	// ErrInvalidInput is matched by input that cannot be decoded into the
+	// type at all, such as malformed text or a JSON value of the wrong kind.
+	ErrInvalidInput = errors.New("null: invalid input")
+	// ErrOverflow is matched by numbers out of the range of the type,
+	// including negative numbers given to the unsigned types. The numeric
+	// conversion methods, such as Int64.Int32, wrap it too.
+	ErrOverflow = errors.New("null: numeric overflow")
+	// ErrTooLong is matched by values over a size limit, such as
+	// MaxStringLen; use errors.As with a *TooLongError for the details.
+	ErrTooLong = errors.New("null: value too long")
+	// ErrNullValue is matched by a null where the type cannot hold one,
+	// such as a NULL element of an Int64Slice. A *NullError matches it too.
+	ErrNullValue = errors.New("null: unexpected null")
+)
+
+// ConvertError describes input that UnmarshalJSON, UnmarshalText or Scan
+// could not decode. Its message is that of Err, the underlying error, which
+// errors.As can also reach, such as a *json.SyntaxError.
+type ConvertError struct {
+	// Type is the name of the null type, such as "null.Int64".
+	Type string
+	// Input is the offending input, as text.
+	Input string
+	Err   error
+}
+
+func (e *ConvertError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConvertError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes e match ErrInvalidInput unless Err matches one of the other
+// kinds.
+func (e *ConvertError) Is(target error) bool {
+	return target == ErrInvalidInput &&
+		!errors.Is(e.Err, ErrOverflow) && !errors.Is(e.Err, ErrTooLong) && !errors.Is(e.Err, ErrNullValue)
+}
+
+// TooLongError reports a value over one of the size limits.
+type TooLongError struct {
+	// Type is the name of the null type, such as "null.String".
+	Type string
+	// Limit is the limit in bytes, and Len the length of the value.
+	Limit, Len int
+}
+
+func (e *TooLongError) Error() string {
+	return fmt.Sprintf("null: %s of %d bytes exceeds the limit of %d", e.Type, e.Len, e.Limit)
+}
+
+// Is makes a *TooLongError match ErrTooLong.
+func (e *TooLongError) Is(target error) bool {
+	return target == ErrTooLong
+}
+
+// UTF8Error reports invalid UTF-8 that StringUTF8 rejects. It matches
+// ErrInvalidInput.
+type UTF8Error struct {
+	// Type is the name of the null type, such as "null.String".
+	Type string
+	// Offset is the position of the first invalid byte in the input.
+	Offset int
+}
+
+func (e *UTF8Error) Error() string {
+	return fmt.Sprintf("null: %s input has invalid UTF-8 at byte %d", e.Type, e.Offset)
+}
+
+// kindError gives an error one of the kinds above, keeping its message.
+type kindError struct {
+	err, kind error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.err, e.kind}
+}
+
+// overflowf formats an error matching ErrOverflow.
+func overflowf(format string, args ...interface{}) error {
+	return &kindError{fmt.Errorf(format, args...), ErrOverflow}
+}
+
+// nullValuef formats an error matching ErrNullValue.
+func nullValuef(format string, args ...interface{}) error {
+	return &kindError{fmt.Errorf(format, args...), ErrNullValue}
+}
+
+// wrapConvertError is deferred by UnmarshalJSON, UnmarshalText and Scan to
+// turn the error they return into a *ConvertError for typ. Errors that
+// already are one, from a nested call, are left alone, and range errors from
+// strconv are made to match ErrOverflow.
+func wrapConvertError(err *error, typ string, input interface{}) {
+	if *err == nil {
+		return
+	}
+	var ce *ConvertError
+	if errors.As(*err, &ce) {
+		return
+	}
+	e := *err
+	if errors.Is(e, strconv.ErrRange) && !errors.Is(e, ErrOverflow) {
+		e = &kindError{e, ErrOverflow}
+	}
+	*err = &ConvertError{Type: typ, Input: inputString(input), Err: e}
+}
+
+// wrapJSONError is deferred by UnmarshalJSON instead of wrapConvertError.
+// A JSON value of a kind that v, a pointer to the null type, cannot hold,
+// such as an object or the string "abc" for an Int64, is reported as a
+// *json.UnmarshalTypeError naming the value and the type, which is the one
+// error type that encoding/json adds the struct field path to. Where
+// newTypeError can, it also matches ErrInvalidInput and holds a
+// *ConvertError. Values of the right kind that do not parse, such as an
+// unknown Enum value, are *ConvertErrors as wrapConvertError makes them. A
+// type error from inside a struct T of a Val or JSONOf keeps the field it
+// names, and an error from a null type nested in T is returned as it is.
+func wrapJSONError(err *error, typ string, v interface{}, data []byte) {
+	if *err == nil || errors.As(*err, new(*ConvertError)) {
+		return
+	}
+	wrapConvertError(err, typ, data)
+	var te *json.UnmarshalTypeError
+	if !errors.As(*err, &te) || !errors.Is(*err, ErrInvalidInput) {
+		return
+	}
+	ce := &ConvertError{Type: typ, Input: string(data), Err: ErrInvalidInput}
+	if te.Struct != "" || te.Field != "" {
+		*err = newTypeError(&json.UnmarshalTypeError{Value: te.Value, Type: te.Type, Offset: te.Offset, Struct: te.Struct, Field: te.Field}, ce)
+		return
+	}
+	*err = newTypeError(&json.UnmarshalTypeError{Value: describeJSON(data), Type: reflect.TypeOf(v).Elem()}, ce)
+}
+
+// describeJSON describes a JSON value for a *json.UnmarshalTypeError, as
+// encoding/json does, but with the literal of a string, number or bool so
+// that the message shows the offending token.
+func describeJSON(data []byte) string {
+	data = bytes.TrimSpace(data)
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string " + string(data)
+	case 't', 'f':
+		return "bool " + string(data)
+	case 'n':
+		return "null"
+	}
+	return "number " + string(data)
+}
+
+func inputString(input interface{}) string {
+	switch v := input.(type) {
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(input)
+}
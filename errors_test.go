@@ -0,0 +1,149 @@
+package null
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestConvertErrors(t *testing.T) {
+	defer func(n int) { MaxStringLen = n }(MaxStringLen)
+	MaxStringLen = 3
+
+	tests := []struct {
+		out   interface{}
+		call  string
+		input interface{}
+		typ   string
+		kind  error
+	}{
+		{&Int64{}, "json", `"abc"`, "null.Int64", ErrInvalidInput},
+		{&Int64{}, "json", `{}`, "null.Int64", ErrInvalidInput},
+		{&UUID{}, "json", `"abc"`, "null.UUID", ErrInvalidInput},
+		{&Byte{}, "json", `7.5`, "null.Byte", ErrInvalidInput},
+		{&Int64{}, "text", "99999999999999999999", "null.Int64", ErrOverflow},
+		{&Int64{}, "scan", "99999999999999999999", "null.Int64", ErrOverflow},
+		{&Int8{}, "json", `300`, "null.Int8", ErrOverflow},
+		{&Int8{}, "text", "300", "null.Int8", ErrOverflow},
+		{&Int8{}, "scan", int64(300), "null.Int8", ErrOverflow},
+		{&Uint32{}, "scan", "-1", "null.Uint32", ErrOverflow},
+		{&Float64{}, "text", "1e999", "null.Float64", ErrOverflow},
+		{&Bool{}, "text", "maybe", "null.Bool", ErrInvalidInput},
+		{&Bool{}, "scan", 2.5, "null.Bool", ErrInvalidInput},
+		{&String{}, "json", `42`, "null.String", ErrInvalidInput},
+		{&String{}, "json", `"abcd"`, "null.String", ErrTooLong},
+		{&String{}, "text", "abcd", "null.String", ErrTooLong},
+		{&Time{}, "json", `"yesterday"`, "null.Time", ErrInvalidInput},
+		{&UUID{}, "text", "abc", "null.UUID", ErrInvalidInput},
+		{&Int64Slice{}, "scan", "{1,NULL}", "null.Int64Slice", ErrNullValue},
+		{&Enum[testStatus]{}, "text", "deleted", "null.Enum", ErrInvalidInput},
+		{&Enum[testStatus]{}, "json", `"deleted"`, "null.Enum", ErrInvalidInput},
+		{&Val[int]{}, "json", `"a"`, "null.Val", ErrInvalidInput},
+	}
+	kinds := []error{ErrInvalidInput, ErrOverflow, ErrTooLong, ErrNullValue}
+	for _, test := range tests {
+		var err error
+		switch test.call {
+		case "json":
+			err = test.out.(json.Unmarshaler).UnmarshalJSON([]byte(test.input.(string)))
+		case "text":
+			err = test.out.(encoding.TextUnmarshaler).UnmarshalText([]byte(test.input.(string)))
+		case "scan":
+			err = test.out.(sql.Scanner).Scan(test.input)
+		}
+		// Without encoding/json/v2 a kind mismatch is a bare
+		// *json.UnmarshalTypeError, which TestJSONTypeErrors checks.
+		if te := new(json.UnmarshalTypeError); !typeErrorsMatchKinds && errors.As(err, &te) {
+			continue
+		}
+		var ce *ConvertError
+		if !errors.As(err, &ce) {
+			t.Errorf("%T %s %v = %v (%T), want a *ConvertError", test.out, test.call, test.input, err, err)
+			continue
+		}
+		if ce.Type != test.typ || ce.Input == "" {
+			t.Errorf("%T %s %v = %#v, want Type %s and the input", test.out, test.call, test.input, ce, test.typ)
+		}
+		for _, kind := range kinds {
+			if got := errors.Is(err, kind); got != (kind == test.kind) {
+				t.Errorf("%T %s %v: errors.Is(%v, %v) = %v", test.out, test.call, test.input, err, kind, got)
+			}
+		}
+		if ce.Error() != ce.Err.Error() {
+			t.Errorf("ConvertError message %q, want %q", ce.Error(), ce.Err.Error())
+		}
+	}
+
+	// The underlying errors stay reachable, and nested calls are not wrapped
+	// twice.
+	var syntaxErr *json.SyntaxError
+	if err := new(Int64).UnmarshalJSON([]byte(`{`)); !errors.As(err, &syntaxErr) {
+		t.Errorf("UnmarshalJSON error = %v (%T), want a *json.SyntaxError inside", err, err)
+	}
+	if err := new(Int64).Scan("x"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Scan error = %v, want strconv.ErrSyntax inside", err)
+	}
+	var ce *ConvertError
+	if err := new(Int8).UnmarshalJSON([]byte(`"300"`)); !errors.As(err, &ce) || errors.As(ce.Err, new(*ConvertError)) {
+		t.Errorf("UnmarshalJSON error = %#v, want a single *ConvertError", err)
+	}
+
+	var utf8Err *UTF8Error
+	if err := new(String).UnmarshalJSON([]byte(`"\xff"`)); err != nil && errors.As(err, &utf8Err) {
+		t.Errorf("UnmarshalJSON error = %v, want none with StringUTF8 unset", err)
+	}
+
+	var nullErr error = &NullError{Type: "null.Int"}
+	if !errors.Is(nullErr, ErrNullValue) {
+		t.Error("a *NullError should match ErrNullValue")
+	}
+}
+
+func TestJSONTypeErrors(t *testing.T) {
+	tests := []struct {
+		out   json.Unmarshaler
+		input string
+		value string
+	}{
+		{&Int64{}, `"abc"`, `string "abc"`},
+		{&Int64{}, `{}`, "object"},
+		{&Int{}, `[1]`, "array"},
+		{&Byte{}, `true`, "bool true"},
+		{&String{}, `42`, "number 42"},
+		{&Time{}, `false`, "bool false"},
+		{&Float64{}, `"x"`, `string "x"`},
+		{&Map{}, `[]`, "array"},
+		{&Val[int]{}, `"a"`, `string "a"`},
+		{&Enum[testStatus]{}, `1`, "number 1"},
+	}
+	for _, test := range tests {
+		err := test.out.UnmarshalJSON([]byte(test.input))
+		var te *json.UnmarshalTypeError
+		if !errors.As(err, &te) {
+			t.Errorf("%T %s = %v (%T), want a *json.UnmarshalTypeError", test.out, test.input, err, err)
+			continue
+		}
+		if te.Value != test.value || te.Type != reflect.TypeOf(test.out).Elem() {
+			t.Errorf("%T %s = %#v, want Value %q and Type %T", test.out, test.input, te, test.value, test.out)
+		}
+		if typeErrorsMatchKinds && (!errors.Is(err, ErrInvalidInput) || !errors.As(err, new(*ConvertError))) {
+			t.Errorf("%T %s = %v, want ErrInvalidInput and a *ConvertError", test.out, test.input, err)
+		}
+	}
+
+	// Through encoding/json the error stays a *json.UnmarshalTypeError, and
+	// with encoding/json/v2 keeps matching ErrInvalidInput.
+	// TestJSONTypeErrorField checks the path.
+	var user struct {
+		Age Int `json:"age"`
+	}
+	var te *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`{"age": "abc"}`), &user); !errors.As(err, &te) || te.Type != reflect.TypeOf(Int{}) ||
+		(typeErrorsMatchKinds && !errors.Is(err, ErrInvalidInput)) {
+		t.Errorf("Unmarshal error = %v (%T), want a *json.UnmarshalTypeError", err, err)
+	}
+}
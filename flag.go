@@ -0,0 +1,63 @@
+package null
+
+import (
+	"encoding"
+	"flag"
+)
+
+// Flag returns a flag.Value that sets v, a pointer to any type with a text
+// form, from a command-line argument with UnmarshalText, for use with
+// flag.Var:
+//
+//	var timeout null.Duration
+//	flag.Var(null.Flag(&timeout), "timeout", "request timeout")
+//
+// The types can't implement flag.Value themselves, because their Set field
+// takes the name of its Set method. A flag that is never passed leaves v
+// unset, so IsSet tells it apart from one passed with the zero value, and
+// an empty argument such as -timeout= sets v to null. The value's String
+// method returns "" while v is null, so unset flags print no default. For
+// a *Bool the value is a boolean flag, so -verbose alone means true.
+func Flag(v flagTarget) flag.Value {
+	if _, ok := v.(*Bool); ok {
+		return &boolFlag{flagValue{v}}
+	}
+	return &flagValue{v}
+}
+
+// flagTarget is satisfied by a pointer to any type with a text form.
+type flagTarget interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	IsValid() bool
+}
+
+type flagValue struct {
+	v flagTarget
+}
+
+// String implements flag.Value.
+func (f *flagValue) String() string {
+	if f == nil || f.v == nil || !f.v.IsValid() {
+		return ""
+	}
+	text, err := f.v.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// Set implements flag.Value.
+func (f *flagValue) Set(s string) error {
+	return f.v.UnmarshalText([]byte(s))
+}
+
+type boolFlag struct {
+	flagValue
+}
+
+// IsBoolFlag lets the flag package accept a bare -name for true.
+func (f *boolFlag) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,91 @@
+package null
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlag(t *testing.T) {
+	var (
+		name    String
+		count   Int
+		size    Int64
+		port    Uint
+		ratio   Float64
+		verbose Bool
+		timeout Duration
+		since   Time
+		unused  Int
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(Flag(&name), "name", "")
+	fs.Var(Flag(&count), "count", "")
+	fs.Var(Flag(&size), "size", "")
+	fs.Var(Flag(&port), "port", "")
+	fs.Var(Flag(&ratio), "ratio", "")
+	fs.Var(Flag(&verbose), "verbose", "")
+	fs.Var(Flag(&timeout), "timeout", "")
+	fs.Var(Flag(&since), "since", "")
+	fs.Var(Flag(&unused), "unused", "")
+
+	err := fs.Parse([]string{
+		"-name=", "-count", "0", "-size=-5", "-port=8080", "-ratio=0.5",
+		"-verbose", "-timeout=1m30s", "-since=2012-12-21T21:21:21Z",
+	})
+	maybePanic(err)
+
+	if !name.IsSet() || name.Valid {
+		t.Errorf("-name= should set a null String, got %#v", name)
+	}
+	if !count.IsValid() || count.Int != 0 {
+		t.Errorf("-count 0 = %#v", count)
+	}
+	if size.Int64 != -5 || port.Uint != 8080 || ratio.Float64 != 0.5 {
+		t.Errorf("bad numbers: %v %v %v", size, port, ratio)
+	}
+	if !verbose.IsValid() || !verbose.Bool {
+		t.Errorf("-verbose = %#v, want true", verbose)
+	}
+	if timeout.Duration != 90*time.Second {
+		t.Errorf("-timeout = %v", timeout)
+	}
+	if !since.Time.Equal(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)) {
+		t.Errorf("-since = %v", since)
+	}
+	if unused.IsSet() {
+		t.Error("a flag that isn't passed should leave the value unset")
+	}
+
+	if s := fs.Lookup("unused").Value.String(); s != "" {
+		t.Errorf("unset flag String() = %q, want empty", s)
+	}
+	if s := fs.Lookup("timeout").Value.String(); s != "1m30s" {
+		t.Errorf("timeout flag String() = %q, want 1m30s", s)
+	}
+
+	var usage strings.Builder
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	if strings.Contains(usage.String(), "default") {
+		t.Errorf("flags registered unset should print no default:\n%s", usage.String())
+	}
+}
+
+func TestFlagErrors(t *testing.T) {
+	var count Int
+	var verbose Bool
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(Flag(&count), "count", "")
+	fs.Var(Flag(&verbose), "verbose", "")
+	if err := fs.Parse([]string{"-count=abc"}); err == nil {
+		t.Error("-count=abc should fail")
+	}
+	if err := fs.Parse([]string{"-verbose=maybe"}); err == nil {
+		t.Error("-verbose=maybe should fail")
+	}
+}
@@ -2,12 +2,19 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Float32 is a nullable float32.
@@ -39,6 +46,29 @@ func Float32FromPtr(f *float32) Float32 {
 	return NewFloat32(*f, true)
 }
 
+// Float32FromNonZero creates a new Float32 that will be null if f is 0 or -0.
+func Float32FromNonZero(f float32) Float32 {
+	return NewFloat32(f, f != 0)
+}
+
+// Float32FromString parses s with the rules of UnmarshalText into a new Float32.
+// An empty string produces a null Float32.
+func Float32FromString(s string) (Float32, error) {
+	var f Float32
+	err := f.UnmarshalText([]byte(s))
+	return f, err
+}
+
+// MustFloat32FromString is like Float32FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustFloat32FromString(s string) Float32 {
+	f, err := Float32FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (f Float32) IsValid() bool {
@@ -50,17 +80,72 @@ func (f Float32) IsSet() bool {
 	return f.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (f Float32) IsNull() bool {
+	return f.Set && !f.Valid
+}
+
+// Equal reports whether f and other are both null, or both valid with
+// the same value. As with ==, NaN is not equal to itself. Set is ignored;
+// see ExactEqual.
+func (f Float32) Equal(other Float32) bool {
+	if !f.Valid || !other.Valid {
+		return f.Valid == other.Valid
+	}
+	return f.Float32 == other.Float32
+}
+
+// ExactEqual is like Equal, but also requires f and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (f Float32) ExactEqual(other Float32) bool {
+	return f.Set == other.Set && f.Equal(other)
+}
+
+// Compare returns -1 if f is less than other, 0 if they are equal and +1
+// if f is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+//
+// A NaN is greater than a null, less than any other number and equal
+// to another NaN, as with cmp.Compare.
+func (f Float32) Compare(other Float32) int {
+	if !f.Valid || !other.Valid {
+		return compareNull(f.Valid, other.Valid)
+	}
+	return cmp.Compare(f.Float32, other.Float32)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (f *Float32) UnmarshalJSON(data []byte) error {
+func (f *Float32) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Float32", f, data)
 	f.Set = true
+	if v, ok := nonFiniteFloat(data); ok {
+		f.Float32, f.Valid = float32(v), true
+		return nil
+	}
+
+	data, err = unquoteNumber(data, "null.Float32")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		f.Valid = false
 		f.Float32 = 0
 		return nil
 	}
 
-	var x float64
-	if err := json.Unmarshal(data, &x); err != nil {
+	// Parsing at 32 bits rounds once, like encoding/json does for a
+	// float32, and reports values beyond its range instead of storing ±Inf.
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	x, err := strconv.ParseFloat(string(n), 32)
+	if errors.Is(err, strconv.ErrRange) {
+		return overflowf("null: %s does not fit in null.Float32", n)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -70,13 +155,13 @@ func (f *Float32) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (f *Float32) UnmarshalText(text []byte) error {
+func (f *Float32) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Float32", text)
 	f.Set = true
 	if len(text) == 0 {
 		f.Valid = false
 		return nil
 	}
-	var err error
 	res, err := strconv.ParseFloat(string(text), 32)
 	f.Valid = err == nil
 	if f.Valid {
@@ -86,11 +171,21 @@ func (f *Float32) UnmarshalText(text []byte) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// NaN and ±Inf have no JSON representation and are marshaled as null.
 func (f Float32) MarshalJSON() ([]byte, error) {
-	if !f.Valid {
+	if !f.Valid || math.IsNaN(float64(f.Float32)) || math.IsInf(float64(f.Float32), 0) {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatFloat(float64(f.Float32), 'f', -1, 32)), nil
+	return f.AppendJSON(make([]byte, 0, 32))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (f Float32) AppendJSON(b []byte) ([]byte, error) {
+	if !f.Valid || math.IsNaN(float64(f.Float32)) || math.IsInf(float64(f.Float32), 0) {
+		return append(b, "null"...), nil
+	}
+	return appendJSONFloat(b, float64(f.Float32), 32), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -98,7 +193,16 @@ func (f Float32) MarshalText() ([]byte, error) {
 	if !f.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatFloat(float64(f.Float32), 'f', -1, 32)), nil
+	return f.AppendText(make([]byte, 0, 32))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if f is null.
+func (f Float32) AppendText(b []byte) ([]byte, error) {
+	if !f.Valid {
+		return b, nil
+	}
+	return strconv.AppendFloat(b, float64(f.Float32), 'f', -1, 32), nil
 }
 
 // SetValid changes this Float32's value and also sets it to be non-null.
@@ -108,6 +212,22 @@ func (f *Float32) SetValid(n float32) {
 	f.Set = true
 }
 
+// SetNull sets this Float32 to an explicit null, zeroing its value.
+func (f *Float32) SetNull() {
+	*f = Float32{Set: true}
+}
+
+// Unset resets this Float32 to the zero value, as if it was never set.
+func (f *Float32) Unset() {
+	*f = Float32{}
+}
+
+// SetPtr changes this Float32 to the value p points to, or to an explicit
+// null if p is nil.
+func (f *Float32) SetPtr(p *float32) {
+	*f = Float32FromPtr(p)
+}
+
 // Ptr returns a pointer to this Float32's value, or a nil pointer if this Float32 is null.
 func (f Float32) Ptr() *float32 {
 	if !f.Valid {
@@ -116,19 +236,282 @@ func (f Float32) Ptr() *float32 {
 	return &f.Float32
 }
 
-// IsZero returns true for invalid Float32s, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (f Float32) ValueOrZero() float32 {
+	if !f.Valid {
+		return 0
+	}
+	return f.Float32
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (f Float32) Or(def float32) float32 {
+	if !f.Valid {
+		return def
+	}
+	return f.Float32
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (f Float32) Val() float32 {
+	return f.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (f Float32) Get() (float32, bool) {
+	return f.ValueOrZero(), f.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if f is
+// null.
+func (f Float32) MustGet() float32 {
+	if !f.Valid {
+		panic(&NullError{Type: "null.Float32"})
+	}
+	return f.Float32
+}
+
+// IsZero returns true if this Float32 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (f Float32) IsZero() bool {
-	return !f.Valid
+	return !f.Set && !f.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Float32 is null.
+func (f Float32) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float32, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Float32 if the input is null or ~.
+func (f *Float32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	f.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+
+	var v float32
+	if err := unmarshal(&v); err != nil {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+	f.Float32, f.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Float32 is null.
+func (f Float32) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, f.Valid, f.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Float32.
+func (f *Float32) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, f.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Float32 is null.
+func (f Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, f.Valid, f.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Float32.
+func (f *Float32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON double, or BSON null if this Float32 is null.
+func (f Float32) MarshalBSONValue() (byte, []byte, error) {
+	if !f.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonDoubleValue(float64(f.Float32))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON double or integer.
+func (f *Float32) UnmarshalBSONValue(typ byte, data []byte) error {
+	f.Set = true
+	if bsonIsNull(typ) {
+		f.Float32, f.Valid = 0, false
+		return nil
+	}
+	v, err := bsonFloat(typ, data, "null.Float32")
+	f.Float32, f.Valid = float32(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack float32, or nil if this Float32 is null.
+func (f Float32) MarshalMsgpack() ([]byte, error) {
+	if !f.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackFloat32(nil, f.Float32), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack float or integer.
+func (f *Float32) UnmarshalMsgpack(data []byte) error {
+	f.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+	n, err := msgpackFloat(v, "null.Float32")
+	f.Float32, f.Valid = float32(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR float32, or null if this Float32 is null.
+func (f Float32) MarshalCBOR() ([]byte, error) {
+	if !f.Valid {
+		return cborNull, nil
+	}
+	return appendCBORFloat32(nil, f.Float32), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR float or integer.
+func (f *Float32) UnmarshalCBOR(data []byte) error {
+	f.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+	n, err := cborFloat(v, "null.Float32")
+	f.Float32, f.Valid = float32(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (f Float32) MarshalGQL(w io.Writer) {
+	marshalGQL(w, f.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Float32.
+func (f *Float32) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, f.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Float32 is
+// null.
+func (f Float32) MarshalCSV() (string, error) {
+	return marshalCSV(f.Valid, f.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Float32.
+func (f *Float32) UnmarshalCSV(cell string) error {
+	return f.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Float32, while an absent one is never passed, leaving
+// the field unset.
+func (f *Float32) UnmarshalParam(param string) error {
+	return f.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML float, including nan and inf, or an empty string if this
+// Float32 is null.
+func (f Float32) MarshalTOML() ([]byte, error) {
+	if !f.Valid {
+		return tomlEmpty, nil
+	}
+	return appendTOMLFloat(nil, float64(f.Float32), 32), nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML float, including nan and inf, an integer, or a string
+// in the same form as UnmarshalText. An empty string unmarshals to a null
+// Float32.
+func (f *Float32) UnmarshalTOML(v interface{}) error {
+	if x, ok := v.(float64); ok {
+		f.Float32, f.Valid, f.Set = float32(x), true, true
+		return nil
+	}
+	return unmarshalTOML(v, f.UnmarshalText, f.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random number with one decimal below 10, or an explicit null if shouldBeNull is true.
+func (f *Float32) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*f = NewFloat32(0, false)
+		return
+	}
+	f.SetValid(float32(randomFloat(nextInt)))
+}
+
+// Generate implements testing/quick's Generator.
+func (f Float32) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewFloat32(0, false), func() Float32 {
+		return Float32From(float32(generateFloat64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (f Float32) MarshalBinary() ([]byte, error) {
+	return marshalBinary(f.Set, f.Valid, func() ([]byte, error) {
+		p := make([]byte, 4)
+		binary.BigEndian.PutUint32(p, math.Float32bits(f.Float32))
+		return p, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *Float32) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Float32")
+	if err != nil {
+		return err
+	}
+	var v float32
+	if valid {
+		if err = binaryFixed(p, "null.Float32", 4); err != nil {
+			return err
+		}
+		v = math.Float32frombits(binary.BigEndian.Uint32(p))
+	}
+	f.Float32, f.Valid, f.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (f *Float32) Scan(value interface{}) error {
+func (f *Float32) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Float32", value)
 	if value == nil {
-		f.Float32, f.Valid, f.Set = 0, false, false
+		f.Float32, f.Valid, f.Set = 0, false, true
 		return nil
 	}
-	f.Valid, f.Set = true, true
-	return convert.ConvertAssign(&f.Float32, value)
+	n, err := scanFloat(value, "null.Float32", 32)
+	f.Float32, f.Valid, f.Set = float32(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -139,10 +522,29 @@ func (f Float32) Value() (driver.Value, error) {
 	return float64(f.Float32), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (f Float32) String() string {
-	if f.Valid {
-		return fmt.Sprintf("Float32(%f)", f.Float32)
+	return fmt.Sprint(f)
+}
+
+// GoString implements the GoStringer interface, formatting f as the Go
+// expression that builds it.
+func (f Float32) GoString() string {
+	return goString("Float32", "", "Float32", goFloat(float64(f.Float32), 32), f.Float32 == 0 && !math.Signbit(float64(f.Float32)), f.Valid, f.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// f with the verb if it is valid.
+func (f Float32) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, f, f.Valid, f.Set, f.Float32)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Float64 value, or to nil if this Float32 is null.
+func (f Float32) LogValue() slog.Value {
+	if !f.Valid {
+		return logNull
 	}
-	return "Float32(invalid)"
+	return logFloat32(f.Float32)
 }
@@ -2,6 +2,8 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -52,7 +54,8 @@ func TestUnmarshalFloat32(t *testing.T) {
 
 	var invalid Float32
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 }
@@ -95,6 +98,39 @@ func TestMarshalFloat32Text(t *testing.T) {
 	assertJSONEquals(t, data, "", "null text marshal")
 }
 
+func TestFloat32LikeEncodingJSON(t *testing.T) {
+	type plain struct{ F float32 }
+	type nullable struct{ F Float32 }
+	for _, f := range []float32{0.1, 1.0 / 3, 16777217, 3.4028235e38, 1e-7, 1e21, 1e20, 1.4e-45, -2.5} {
+		want, err := json.Marshal(plain{f})
+		maybePanic(err)
+		got, err := json.Marshal(nullable{Float32From(f)})
+		maybePanic(err)
+		if string(got) != string(want) {
+			t.Errorf("Float32 %v marshals to %s, encoding/json to %s", f, got, want)
+		}
+	}
+
+	for _, in := range []string{"0.1", "0.10000000149011612", "1.00000005960464477550", "16777217", "3.4028235e38", "1e-46", "-0"} {
+		var want plain
+		err := json.Unmarshal([]byte(`{"F":`+in+`}`), &want)
+		maybePanic(err)
+		var got nullable
+		err = json.Unmarshal([]byte(`{"F":`+in+`}`), &got)
+		maybePanic(err)
+		if math.Float32bits(got.F.Float32) != math.Float32bits(want.F) || !got.F.Valid {
+			t.Errorf("Float32 unmarshals %s to %v, encoding/json to %v", in, got.F.Float32, want.F)
+		}
+	}
+
+	for _, in := range []string{"3.5e38", "-1e39", `"1e300"`} {
+		var f Float32
+		if err := json.Unmarshal([]byte(in), &f); !errors.Is(err, ErrOverflow) || f.Valid {
+			t.Errorf("Float32 unmarshals %s to %v, %v, want an overflow error", in, f, err)
+		}
+	}
+}
+
 func TestFloat32Pointer(t *testing.T) {
 	f := Float32From(1.2345)
 	ptr := f.Ptr()
@@ -116,7 +152,12 @@ func TestFloat32IsZero(t *testing.T) {
 	}
 
 	null := NewFloat32(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Float32
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -143,6 +184,9 @@ func TestFloat32Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullFloat32(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertFloat32(t *testing.T, f Float32, from string) {
@@ -159,3 +203,33 @@ func assertNullFloat32(t *testing.T, f Float32, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestFloat32NonFinite(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		f := Float32From(float32(v))
+		data, err := json.Marshal(f)
+		maybePanic(err)
+		assertJSONEquals(t, data, "null", "non-finite json marshal")
+	}
+
+	tests := map[string]func(float64) bool{
+		`"NaN"`:       math.IsNaN,
+		`"Infinity"`:  func(v float64) bool { return math.IsInf(v, 1) },
+		`"-Infinity"`: func(v float64) bool { return math.IsInf(v, -1) },
+	}
+	for in, check := range tests {
+		var f Float32
+		err := json.Unmarshal([]byte(in), &f)
+		maybePanic(err)
+		if !f.Valid || !check(float64(f.Float32)) {
+			t.Errorf("bad %s unmarshal: %v", in, f)
+		}
+
+		var scanned Float32
+		err = scanned.Scan(in[1 : len(in)-1])
+		maybePanic(err)
+		if !scanned.Valid || !check(float64(scanned.Float32)) {
+			t.Errorf("bad %s scan: %v", in, scanned)
+		}
+	}
+}
@@ -2,12 +2,19 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Float64 is a nullable float64.
@@ -39,6 +46,34 @@ func Float64FromPtr(f *float64) Float64 {
 	return NewFloat64(*f, true)
 }
 
+// Float64FromNonZero creates a new Float64 that will be null if f is 0 or -0.
+func Float64FromNonZero(f float64) Float64 {
+	return NewFloat64(f, f != 0)
+}
+
+// Float64FromString parses s with the rules of UnmarshalText into a new Float64.
+// An empty string produces a null Float64.
+func Float64FromString(s string) (Float64, error) {
+	var f Float64
+	err := f.UnmarshalText([]byte(s))
+	return f, err
+}
+
+// MustFloat64FromString is like Float64FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustFloat64FromString(s string) Float64 {
+	f, err := Float64FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Float64FromNullFloat64 creates a new Float64 from a sql.NullFloat64. It is always Set.
+func Float64FromNullFloat64(n sql.NullFloat64) Float64 {
+	return NewFloat64(n.Float64, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (f Float64) IsValid() bool {
@@ -50,9 +85,55 @@ func (f Float64) IsSet() bool {
 	return f.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (f Float64) IsNull() bool {
+	return f.Set && !f.Valid
+}
+
+// Equal reports whether f and other are both null, or both valid with
+// the same value. As with ==, NaN is not equal to itself. Set is ignored;
+// see ExactEqual.
+func (f Float64) Equal(other Float64) bool {
+	if !f.Valid || !other.Valid {
+		return f.Valid == other.Valid
+	}
+	return f.Float64 == other.Float64
+}
+
+// ExactEqual is like Equal, but also requires f and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (f Float64) ExactEqual(other Float64) bool {
+	return f.Set == other.Set && f.Equal(other)
+}
+
+// Compare returns -1 if f is less than other, 0 if they are equal and +1
+// if f is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+//
+// A NaN is greater than a null, less than any other number and equal
+// to another NaN, as with cmp.Compare.
+func (f Float64) Compare(other Float64) int {
+	if !f.Valid || !other.Valid {
+		return compareNull(f.Valid, other.Valid)
+	}
+	return cmp.Compare(f.Float64, other.Float64)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (f *Float64) UnmarshalJSON(data []byte) error {
+func (f *Float64) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Float64", f, data)
 	f.Set = true
+	if v, ok := nonFiniteFloat(data); ok {
+		f.Float64, f.Valid = v, true
+		return nil
+	}
+
+	data, err = unquoteNumber(data, "null.Float64")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		f.Float64 = 0
 		f.Valid = false
@@ -68,24 +149,34 @@ func (f *Float64) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (f *Float64) UnmarshalText(text []byte) error {
+func (f *Float64) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Float64", text)
 	f.Set = true
 	if len(text) == 0 {
 		f.Valid = false
 		return nil
 	}
-	var err error
 	f.Float64, err = strconv.ParseFloat(string(text), 64)
 	f.Valid = err == nil
 	return err
 }
 
 // MarshalJSON implements json.Marshaler.
+// NaN and ±Inf have no JSON representation and are marshaled as null.
 func (f Float64) MarshalJSON() ([]byte, error) {
-	if !f.Valid {
+	if !f.Valid || math.IsNaN(f.Float64) || math.IsInf(f.Float64, 0) {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatFloat(f.Float64, 'f', -1, 64)), nil
+	return f.AppendJSON(make([]byte, 0, 32))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (f Float64) AppendJSON(b []byte) ([]byte, error) {
+	if !f.Valid || math.IsNaN(f.Float64) || math.IsInf(f.Float64, 0) {
+		return append(b, "null"...), nil
+	}
+	return appendJSONFloat(b, f.Float64, 64), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -93,7 +184,16 @@ func (f Float64) MarshalText() ([]byte, error) {
 	if !f.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatFloat(f.Float64, 'f', -1, 64)), nil
+	return f.AppendText(make([]byte, 0, 32))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if f is null.
+func (f Float64) AppendText(b []byte) ([]byte, error) {
+	if !f.Valid {
+		return b, nil
+	}
+	return strconv.AppendFloat(b, f.Float64, 'f', -1, 64), nil
 }
 
 // SetValid changes this Float64's value and also sets it to be non-null.
@@ -103,6 +203,22 @@ func (f *Float64) SetValid(n float64) {
 	f.Set = true
 }
 
+// SetNull sets this Float64 to an explicit null, zeroing its value.
+func (f *Float64) SetNull() {
+	*f = Float64{Set: true}
+}
+
+// Unset resets this Float64 to the zero value, as if it was never set.
+func (f *Float64) Unset() {
+	*f = Float64{}
+}
+
+// SetPtr changes this Float64 to the value p points to, or to an explicit
+// null if p is nil.
+func (f *Float64) SetPtr(p *float64) {
+	*f = Float64FromPtr(p)
+}
+
 // Ptr returns a pointer to this Float64's value, or a nil pointer if this Float64 is null.
 func (f Float64) Ptr() *float64 {
 	if !f.Valid {
@@ -111,19 +227,324 @@ func (f Float64) Ptr() *float64 {
 	return &f.Float64
 }
 
-// IsZero returns true for invalid Float64s, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (f Float64) ValueOrZero() float64 {
+	if !f.Valid {
+		return 0
+	}
+	return f.Float64
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (f Float64) Or(def float64) float64 {
+	if !f.Valid {
+		return def
+	}
+	return f.Float64
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (f Float64) Val() float64 {
+	return f.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (f Float64) Get() (float64, bool) {
+	return f.ValueOrZero(), f.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if f is
+// null.
+func (f Float64) MustGet() float64 {
+	if !f.Valid {
+		panic(&NullError{Type: "null.Float64"})
+	}
+	return f.Float64
+}
+
+// IsZero returns true if this Float64 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (f Float64) IsZero() bool {
-	return !f.Valid
+	return !f.Set && !f.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Float64 is null.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Float64 if the input is null or ~.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	f.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+
+	var v float64
+	if err := unmarshal(&v); err != nil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	f.Float64, f.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Float64 is null.
+func (f Float64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, f.Valid, f.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Float64.
+func (f *Float64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, f.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Float64 is null.
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, f.Valid, f.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Float64.
+func (f *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON double, or BSON null if this Float64 is null.
+func (f Float64) MarshalBSONValue() (byte, []byte, error) {
+	if !f.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonDoubleValue(f.Float64)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON double or integer.
+func (f *Float64) UnmarshalBSONValue(typ byte, data []byte) error {
+	f.Set = true
+	if bsonIsNull(typ) {
+		f.Float64, f.Valid = 0, false
+		return nil
+	}
+	v, err := bsonFloat(typ, data, "null.Float64")
+	f.Float64, f.Valid = float64(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack float64, or nil if this Float64 is null.
+func (f Float64) MarshalMsgpack() ([]byte, error) {
+	if !f.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackFloat64(nil, f.Float64), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack float or integer.
+func (f *Float64) UnmarshalMsgpack(data []byte) error {
+	f.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	n, err := msgpackFloat(v, "null.Float64")
+	f.Float64, f.Valid = n, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR float64, or null if this Float64 is null.
+func (f Float64) MarshalCBOR() ([]byte, error) {
+	if !f.Valid {
+		return cborNull, nil
+	}
+	return appendCBORFloat64(nil, f.Float64), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR float or integer.
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	f.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	n, err := cborFloat(v, "null.Float64")
+	f.Float64, f.Valid = n, err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (f Float64) MarshalGQL(w io.Writer) {
+	marshalGQL(w, f.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Float64.
+func (f *Float64) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, f.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Float64 is
+// null.
+func (f Float64) MarshalCSV() (string, error) {
+	return marshalCSV(f.Valid, f.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Float64.
+func (f *Float64) UnmarshalCSV(cell string) error {
+	return f.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Float64, while an absent one is never passed, leaving
+// the field unset.
+func (f *Float64) UnmarshalParam(param string) error {
+	return f.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML float, including nan and inf, or an empty string if this
+// Float64 is null.
+func (f Float64) MarshalTOML() ([]byte, error) {
+	if !f.Valid {
+		return tomlEmpty, nil
+	}
+	return appendTOMLFloat(nil, f.Float64, 64), nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML float, including nan and inf, an integer, or a string
+// in the same form as UnmarshalText. An empty string unmarshals to a null
+// Float64.
+func (f *Float64) UnmarshalTOML(v interface{}) error {
+	if x, ok := v.(float64); ok {
+		f.Float64, f.Valid, f.Set = x, true, true
+		return nil
+	}
+	return unmarshalTOML(v, f.UnmarshalText, f.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes a FLOAT64, including NaN and infinities, or a typed NULL if
+// this Float64 is null.
+func (f Float64) EncodeSpanner() (interface{}, error) {
+	if !f.Valid {
+		return (*float64)(nil), nil
+	}
+	return f.Float64, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts a FLOAT64, and the strings "NaN", "Infinity" and "-Infinity"
+// Spanner uses for the values JSON can't hold. NULL decodes to a null
+// Float64.
+func (f *Float64) DecodeSpanner(input interface{}) error {
+	f.Set = true
+	v, null := spannerValue(input)
+	if null {
+		f.Float64, f.Valid = 0, false
+		return nil
+	}
+	switch x := v.(type) {
+	case float64:
+		f.Float64, f.Valid = x, true
+		return nil
+	case string:
+		switch x {
+		case "NaN":
+			f.Float64, f.Valid = math.NaN(), true
+			return nil
+		case "Infinity":
+			f.Float64, f.Valid = math.Inf(1), true
+			return nil
+		case "-Infinity":
+			f.Float64, f.Valid = math.Inf(-1), true
+			return nil
+		}
+	}
+	f.Float64, f.Valid = 0, false
+	return spannerError(input, "null.Float64")
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random number with one decimal below 10, or an explicit null if shouldBeNull is true.
+func (f *Float64) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*f = NewFloat64(0, false)
+		return
+	}
+	f.SetValid(randomFloat(nextInt))
+}
+
+// Generate implements testing/quick's Generator.
+func (f Float64) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewFloat64(0, false), func() Float64 {
+		return Float64From(generateFloat64(rand))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (f Float64) MarshalBinary() ([]byte, error) {
+	return marshalBinary(f.Set, f.Valid, func() ([]byte, error) {
+		p := make([]byte, 8)
+		binary.BigEndian.PutUint64(p, math.Float64bits(f.Float64))
+		return p, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Float64")
+	if err != nil {
+		return err
+	}
+	var v float64
+	if valid {
+		if err = binaryFixed(p, "null.Float64", 8); err != nil {
+			return err
+		}
+		v = math.Float64frombits(binary.BigEndian.Uint64(p))
+	}
+	f.Float64, f.Valid, f.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (f *Float64) Scan(value interface{}) error {
+func (f *Float64) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Float64", value)
 	if value == nil {
-		f.Float64, f.Valid, f.Set = 0, false, false
+		f.Float64, f.Valid, f.Set = 0, false, true
 		return nil
 	}
-	f.Valid, f.Set = true, true
-	return convert.ConvertAssign(&f.Float64, value)
+	n, err := scanFloat(value, "null.Float64", 64)
+	f.Float64, f.Valid, f.Set = n, err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -134,10 +555,34 @@ func (f Float64) Value() (driver.Value, error) {
 	return f.Float64, nil
 }
 
-// String implements the Stringer interface
+// ToNullFloat64 converts f to a sql.NullFloat64, dropping Set.
+func (f Float64) ToNullFloat64() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: f.Float64, Valid: f.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (f Float64) String() string {
-	if f.Valid {
-		return fmt.Sprintf("Float64(%f)", f.Float64)
+	return fmt.Sprint(f)
+}
+
+// GoString implements the GoStringer interface, formatting f as the Go
+// expression that builds it.
+func (f Float64) GoString() string {
+	return goString("Float64", "", "Float64", goFloat(f.Float64, 64), f.Float64 == 0 && !math.Signbit(f.Float64), f.Valid, f.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// f with the verb if it is valid.
+func (f Float64) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, f, f.Valid, f.Set, f.Float64)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Float64 value, or to nil if this Float64 is null.
+func (f Float64) LogValue() slog.Value {
+	if !f.Valid {
+		return logNull
 	}
-	return "Float64(invalid)"
+	return slog.Float64Value(f.Float64)
 }
@@ -2,6 +2,8 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -52,7 +54,8 @@ func TestUnmarshalFloat64(t *testing.T) {
 
 	var invalid Float64
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 }
@@ -116,7 +119,12 @@ func TestFloat64IsZero(t *testing.T) {
 	}
 
 	null := NewFloat64(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Float64
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -143,6 +151,9 @@ func TestFloat64Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullFloat64(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertFloat64(t *testing.T, f Float64, from string) {
@@ -159,3 +170,33 @@ func assertNullFloat64(t *testing.T, f Float64, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestFloat64NonFinite(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		f := Float64From(float64(v))
+		data, err := json.Marshal(f)
+		maybePanic(err)
+		assertJSONEquals(t, data, "null", "non-finite json marshal")
+	}
+
+	tests := map[string]func(float64) bool{
+		`"NaN"`:       math.IsNaN,
+		`"Infinity"`:  func(v float64) bool { return math.IsInf(v, 1) },
+		`"-Infinity"`: func(v float64) bool { return math.IsInf(v, -1) },
+	}
+	for in, check := range tests {
+		var f Float64
+		err := json.Unmarshal([]byte(in), &f)
+		maybePanic(err)
+		if !f.Valid || !check(float64(f.Float64)) {
+			t.Errorf("bad %s unmarshal: %v", in, f)
+		}
+
+		var scanned Float64
+		err = scanned.Scan(in[1 : len(in)-1])
+		maybePanic(err)
+		if !scanned.Valid || !check(float64(scanned.Float64)) {
+			t.Errorf("bad %s scan: %v", in, scanned)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package null
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The Format methods make fmt print the payload of a valid value, so
+// fmt.Sprintf("%5.2f", f) formats a Float64 like a float64, and "<null>" for
+// a set null or "<unset>" for a value never set. The flags, width and
+// precision of the verb are passed on to the payload; a null honors only the
+// width and the '-' flag. %#v still prints the GoString form, and String
+// returns what %v prints, so Int64From(42).String() is "42".
+
+// formatValue implements fmt.Formatter for v, whose payload is formatted
+// with the verb if v is valid.
+func formatValue(f fmt.State, verb rune, v fmt.GoStringer, valid, set bool, payload interface{}) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, v.GoString())
+	case valid:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), payload)
+	case set:
+		formatNull(f, "<null>")
+	default:
+		formatNull(f, "<unset>")
+	}
+}
+
+// formatNull writes s padded to the width of f.
+func formatNull(f fmt.State, s string) {
+	if w, ok := f.Width(); ok && w > len(s) {
+		pad := strings.Repeat(" ", w-len(s))
+		if f.Flag('-') {
+			s += pad
+		} else {
+			s = pad + s
+		}
+	}
+	io.WriteString(f, s)
+}
+
+// textVerb reports whether verb prints a value as text, for the types whose
+// payload is formatted as a string by those verbs and as is by the others.
+func textVerb(verb rune) bool {
+	return verb == 'v' || verb == 's' || verb == 'q'
+}
@@ -0,0 +1,132 @@
+package null
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestFormatPayload(t *testing.T) {
+	for _, test := range []struct {
+		format  string
+		v       interface{}
+		payload interface{}
+	}{
+		{"%v", Int64From(-42), int64(-42)},
+		{"%d", Int64From(-42), int64(-42)},
+		{"%+05d", Int64From(42), int64(42)},
+		{"%-6d|", IntFrom(7), 7},
+		{"%x", Uint32From(0xbeef), uint32(0xbeef)},
+		{"%#o", Int8From(8), int8(8)},
+		{"%08.3f", Float64From(3.14159), 3.14159},
+		{"%.2e", Float32From(1234.5), float32(1234.5)},
+		{"%g", Float64From(1e21), 1e21},
+		{"%v", BoolFrom(true), true},
+		{"%t", BoolFrom(false), false},
+		{"%s", StringFrom("hi"), "hi"},
+		{"%q", StringFrom(`a "b"`), `a "b"`},
+		{"%10s|", StringFrom("hi"), "hi"},
+		{"%-10.3s|", StringFrom("abcdef"), "abcdef"},
+		{"%x", StringFrom("hi"), "hi"},
+		{"%v", DurationFrom(90 * time.Second), 90 * time.Second},
+		{"%d", DurationFrom(time.Second), time.Second},
+		{"%v", DecimalFrom("1.50"), "1.50"},
+		{"%s", BytesFrom([]byte("raw")), []byte("raw")},
+		{"%x", BytesFrom([]byte("raw")), []byte("raw")},
+		{"%v", StringSliceFrom([]string{"a", "b"}), []string{"a", "b"}},
+		{"%d", BigIntFrom(big.NewInt(-5)), big.NewInt(-5)},
+		{"%v", EnumFrom(statusActive), statusActive},
+		{"%5.1f", ValFrom(2.25), 2.25},
+		{"%c", RuneFrom('é'), 'é'},
+		{"%U", RuneFrom('é'), 'é'},
+		{"%q", RuneFrom('é'), 'é'},
+		{"%d", ByteFrom('A'), byte('A')},
+		{"%q", ByteFrom('A'), byte('A')},
+	} {
+		got, want := fmt.Sprintf(test.format, test.v), fmt.Sprintf(test.format, test.payload)
+		if got != want {
+			t.Errorf("Sprintf(%q, %T) = %q, want %q", test.format, test.v, got, want)
+		}
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	id := [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}
+	for _, test := range []struct {
+		format string
+		v      interface{}
+		want   string
+	}{
+		{"%v", RuneFrom('é'), "é"},
+		{"%s", ByteFrom('A'), "A"},
+		{"%v", JSONFrom([]byte(`{"a":1}`)), `{"a":1}`},
+		{"%q", JSONFrom([]byte(`"x"`)), `"\"x\""`},
+		{"%v", DateFrom(time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)), "2012-12-21"},
+		{"%v", TimeOfDayFrom(9*time.Hour + 30*time.Minute), "09:30:00"},
+		{"%s", UUIDFrom(id), "12345678-9abc-def0-1234-56789abcdef0"},
+		{"%x", UUIDFrom(id), "123456789abcdef0123456789abcdef0"},
+		{"%v", Int64From(1), "1"},
+	} {
+		if got := fmt.Sprintf(test.format, test.v); got != test.want {
+			t.Errorf("Sprintf(%q, %T) = %q, want %q", test.format, test.v, got, test.want)
+		}
+	}
+}
+
+func TestFormatNull(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		v      interface{}
+		want   string
+	}{
+		{"%v", NewInt64(0, false), "<null>"},
+		{"%d", NewInt64(0, false), "<null>"},
+		{"%v", Int64{}, "<unset>"},
+		{"%s", String{}, "<unset>"},
+		{"%.2f", NewFloat64(1.5, false), "<null>"},
+		{"%8v|", NewString("", false), "  <null>|"},
+		{"%-8v|", Bool{}, "<unset> |"},
+		{"%v", BigInt{Valid: true, Set: true}, "<null>"},
+		{"%v", NewURL(nil, false), "<null>"},
+		{"%q", Val[int]{}, "<unset>"},
+	} {
+		if got := fmt.Sprintf(test.format, test.v); got != test.want {
+			t.Errorf("Sprintf(%q, %#v) = %q, want %q", test.format, test.v, got, test.want)
+		}
+	}
+}
+
+func TestFormatGoString(t *testing.T) {
+	for _, v := range []fmt.GoStringer{Int64From(42), NewString("", false), Float64{}, EnumFrom(statusBlocked)} {
+		if got, want := fmt.Sprintf("%#v", v), v.GoString(); got != want {
+			t.Errorf("Sprintf(%%#v) = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	for _, states := range binaryStates() {
+		for _, v := range states {
+			s, ok := v.(fmt.Stringer)
+			if !ok {
+				// String's payload field takes the method's name.
+				continue
+			}
+			if got, want := s.String(), fmt.Sprint(v); got != want {
+				t.Errorf("%#v.String() = %q, want %q", v, got, want)
+			}
+		}
+	}
+	for v, want := range map[fmt.Stringer]string{
+		IntFrom(3):             "3",
+		Float64From(1.5):       "1.5",
+		NewInt(0, false):       "<null>",
+		Time{}:                 "<unset>",
+		EnumFrom(statusActive): "active",
+	} {
+		if got := v.String(); got != want {
+			t.Errorf("%#v.String() = %q, want %q", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package null
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The Generate methods implement the Generator interface of testing/quick,
+// so quick.Check can pass the null types to the functions it tests. About a
+// quarter of the values are unset, a quarter set nulls and the rest valid,
+// with payloads drawn from rand: printable text, times between the years 1
+// and 9999, and strings, slices and maps at most size long. The package
+// doesn't import testing/quick, which would register its flags in every
+// program.
+
+// generator matches testing/quick's Generator, which generateValue uses for
+// the types that implement it.
+type generator interface {
+	Generate(rand *rand.Rand, size int) reflect.Value
+}
+
+// generate returns unset, null or valid(), picked at random.
+func generate[T any](rand *rand.Rand, null T, valid func() T) reflect.Value {
+	switch rand.Intn(4) {
+	case 0:
+		var unset T
+		return reflect.ValueOf(unset)
+	case 1:
+		return reflect.ValueOf(null)
+	}
+	return reflect.ValueOf(valid())
+}
+
+// generateLen returns a length in [0, size].
+func generateLen(rand *rand.Rand, size int) int {
+	if size <= 0 {
+		return 0
+	}
+	return rand.Intn(size + 1)
+}
+
+// generateRune returns a printable rune, ASCII three times out of four.
+func generateRune(rand *rand.Rand) rune {
+	if rand.Intn(4) > 0 {
+		return rune(' ' + rand.Intn('~'-' '+1))
+	}
+	for {
+		r := rune(rand.Int31n(unicode.MaxRune + 1))
+		if utf8.ValidRune(r) && unicode.IsPrint(r) {
+			return r
+		}
+	}
+}
+
+// generateText returns up to size printable runes.
+func generateText(rand *rand.Rand, size int) string {
+	runes := make([]rune, generateLen(rand, size))
+	for i := range runes {
+		runes[i] = generateRune(rand)
+	}
+	return string(runes)
+}
+
+// generateWord returns 1 to 8 letters and digits.
+func generateWord(rand *rand.Rand) string {
+	b := make([]byte, 1+rand.Intn(8))
+	for i := range b {
+		b[i] = randomAlphabet[rand.Intn(len(randomAlphabet))]
+	}
+	return string(b)
+}
+
+// generateBytes returns 1 to size arbitrary bytes. A valid Bytes is never
+// empty, as empty text unmarshals to null.
+func generateBytes(rand *rand.Rand, size int) []byte {
+	b := make([]byte, 1+generateLen(rand, size-1))
+	rand.Read(b)
+	return b
+}
+
+// generateInt64 returns an integer of any size half of the time, and one
+// within 100 of zero otherwise, where boundary cases tend to be.
+func generateInt64(rand *rand.Rand) int64 {
+	if rand.Intn(2) == 0 {
+		return int64(rand.Uint64())
+	}
+	return rand.Int63n(201) - 100
+}
+
+// generateUint64 is like generateInt64 for unsigned integers.
+func generateUint64(rand *rand.Rand) uint64 {
+	if rand.Intn(2) == 0 {
+		return rand.Uint64()
+	}
+	return uint64(rand.Intn(101))
+}
+
+// generateFloat64 returns a finite number with a magnitude from 1e-9 to
+// 1e10, which a float32 holds too.
+func generateFloat64(rand *rand.Rand) float64 {
+	return rand.NormFloat64() * math.Pow(10, float64(rand.Intn(19)-9))
+}
+
+// generateTime returns a UTC time with nanoseconds between the years 1 and
+// 9999, the range that RFC 3339 and so JSON can represent.
+func generateTime(rand *rand.Rand) time.Time {
+	lo := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	hi := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	return time.Unix(lo+rand.Int63n(hi-lo), rand.Int63n(1e9)).UTC()
+}
+
+// generateJSON returns a JSON document other than null: a scalar, or an
+// array or object of up to size members nested to a depth of a few levels.
+func generateJSON(rand *rand.Rand, size int) []byte {
+	v := generateJSONValue(rand, size, 3)
+	for v == nil {
+		v = generateJSONValue(rand, size, 3)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func generateJSONValue(rand *rand.Rand, size, depth int) interface{} {
+	n := 4
+	if depth > 0 {
+		n = 6
+	}
+	switch rand.Intn(n) {
+	case 0:
+		return nil
+	case 1:
+		return rand.Intn(2) == 0
+	case 2:
+		return generateFloat64(rand)
+	case 3:
+		return generateText(rand, size)
+	case 4:
+		a := make([]interface{}, generateLen(rand, size/2))
+		for i := range a {
+			a[i] = generateJSONValue(rand, size/2, depth-1)
+		}
+		return a
+	}
+	m := make(map[string]interface{})
+	for i := generateLen(rand, size/2); i > 0; i-- {
+		m[generateWord(rand)] = generateJSONValue(rand, size/2, depth-1)
+	}
+	return m
+}
+
+// generateValue sets rv, which must be settable, to a random value if its
+// type implements generator, is a time.Time, or is made of basic kinds,
+// slices, arrays, maps, pointers and structs of them, and reports whether it
+// did. Unexported struct fields are left zero.
+func generateValue(rand *rand.Rand, size int, rv reflect.Value) bool {
+	if g, ok := rv.Interface().(generator); ok {
+		rv.Set(g.Generate(rand, size))
+		return true
+	}
+	if rv.Type() == reflect.TypeOf(time.Time{}) {
+		rv.Set(reflect.ValueOf(generateTime(rand)))
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(rand.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(generateInt64(rand))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		rv.SetUint(generateUint64(rand))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(generateFloat64(rand))
+	case reflect.String:
+		rv.SetString(generateText(rand, size))
+	case reflect.Slice:
+		n := generateLen(rand, size)
+		s := reflect.MakeSlice(rv.Type(), n, n)
+		for i := 0; i < s.Len(); i++ {
+			if !generateValue(rand, size, s.Index(i)) {
+				return false
+			}
+		}
+		rv.Set(s)
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !generateValue(rand, size, rv.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		m := reflect.MakeMap(rv.Type())
+		for i := generateLen(rand, size); i > 0; i-- {
+			k, v := reflect.New(rv.Type().Key()).Elem(), reflect.New(rv.Type().Elem()).Elem()
+			if !generateValue(rand, size, k) || !generateValue(rand, size, v) {
+				return false
+			}
+			m.SetMapIndex(k, v)
+		}
+		rv.Set(m)
+	case reflect.Pointer:
+		p := reflect.New(rv.Type().Elem())
+		if !generateValue(rand, size, p.Elem()) {
+			return false
+		}
+		rv.Set(p)
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if field := rv.Field(i); field.CanSet() && !generateValue(rand, size, field) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package null
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerateStates(t *testing.T) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		t.Run(typ.Name(), func(t *testing.T) {
+			rand := rand.New(rand.NewSource(1))
+			var unset, null, valid int
+			for i := 0; i < 400; i++ {
+				rv := states[0].(generator).Generate(rand, 10)
+				if rv.Type() != typ {
+					t.Fatalf("Generate returned a %s", rv.Type())
+				}
+				v := rv.Interface().(Value)
+				switch {
+				case v.IsValid():
+					valid++
+					checkGeneratedJSON(t, rv)
+				case v.IsSet():
+					null++
+				default:
+					unset++
+				}
+			}
+			if unset < 50 || null < 50 || valid < 150 {
+				t.Errorf("generated %d unset, %d null and %d valid values", unset, null, valid)
+			}
+		})
+	}
+}
+
+// checkGeneratedJSON checks that rv, a valid value, survives a JSON round
+// trip, so the generated payloads are in range for their type.
+func checkGeneratedJSON(t *testing.T, rv reflect.Value) {
+	t.Helper()
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		t.Fatalf("json.Marshal(%#v): %v", rv.Interface(), err)
+	}
+	back := reflect.New(rv.Type())
+	if err := json.Unmarshal(data, back.Interface()); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+	if !rv.MethodByName("ExactEqual").Call([]reflect.Value{back.Elem()})[0].Bool() {
+		t.Fatalf("%#v became %#v through %s", rv.Interface(), back.Elem().Interface(), data)
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	gen := func(seed int64) []String {
+		rand := rand.New(rand.NewSource(seed))
+		s := make([]String, 20)
+		for i := range s {
+			s[i] = String{}.Generate(rand, 8).Interface().(String)
+		}
+		return s
+	}
+	if a, b := gen(7), gen(7); !reflect.DeepEqual(a, b) {
+		t.Errorf("the same seed generated %q and %q", a, b)
+	}
+	for _, s := range gen(7) {
+		if len([]rune(s.String)) > 8 {
+			t.Errorf("generated %q, longer than the size", s.String)
+		}
+	}
+}
+
+func TestGenerateQuickCheck(t *testing.T) {
+	type row struct {
+		Name  String
+		Born  Time
+		Score Float64
+		Tags  Slice[string]
+	}
+	roundTrip := func(in row) bool {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return false
+		}
+		var out row
+		if err := json.Unmarshal(data, &out); err != nil {
+			return false
+		}
+		return in.Name.Equal(out.Name) && in.Born.Equal(out.Born) && in.Score.Equal(out.Score) && in.Tags.Equal(out.Tags)
+	}
+	err := quick.Check(roundTrip, &quick.Config{Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Generate of a Val[chan int] should panic")
+		}
+	}()
+	rand := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		Val[chan int]{}.Generate(rand, 1)
+	}
+}
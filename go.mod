@@ -1,3 +1,18 @@
 module github.com/sisoftrg/null
 
-go 1.14
+go 1.24
+
+require (
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.21.7
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.69.1
+	github.com/google/go-cmp v0.7.0
+	github.com/gorilla/schema v1.4.1
+	github.com/mailru/easyjson v0.9.2
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.43.0 // indirect
+	github.com/aws/smithy-go v1.28.1 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+)
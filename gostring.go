@@ -0,0 +1,230 @@
+package null
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// The GoString methods print values as the Go expressions that build them,
+// so %#v output can be pasted back into a test:
+//
+//	null.IntFrom(42)           // valid
+//	null.NewInt(0, false)      // set to null
+//	null.Int{}                 // never set
+//
+// Values whose Set and Valid fields don't match a constructor are printed
+// as struct literals.
+
+// goStringLimit is the number of bytes of a Bytes, RawBytes or JSON
+// payload that GoString prints before truncating it.
+const goStringLimit = 64
+
+// goString formats a value for GoString. name is the type name, targs its
+// type arguments such as "[int]", field the payload field name and payload
+// the Go expression for the payload, which is zero if zero is true.
+func goString(name, targs, field, payload string, zero, valid, set bool) string {
+	switch {
+	case set && valid:
+		return "null." + name + "From" + targs + "(" + payload + ")"
+	case set:
+		return "null.New" + name + targs + "(" + payload + ", false)"
+	}
+	var fields []string
+	if !zero {
+		fields = append(fields, field+": "+payload)
+	}
+	if valid {
+		fields = append(fields, "Valid: true")
+	}
+	return "null." + name + targs + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// goTypeArgs returns the type argument list for T, such as "[int64]".
+func goTypeArgs[T any]() string {
+	return "[" + reflect.TypeOf((*T)(nil)).Elem().String() + "]"
+}
+
+// goTypeArgs2 is goTypeArgs for a type with two type parameters.
+func goTypeArgs2[K, V any]() string {
+	return "[" + reflect.TypeOf((*K)(nil)).Elem().String() + ", " + reflect.TypeOf((*V)(nil)).Elem().String() + "]"
+}
+
+// goFloat formats f as a Go float expression.
+func goFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// goBytes formats b as a []byte conversion of a string literal, truncated
+// to goStringLimit bytes.
+func goBytes(b []byte) string {
+	if b == nil {
+		return "nil"
+	}
+	if len(b) <= goStringLimit {
+		return "[]byte(" + strconv.Quote(string(b)) + ")"
+	}
+	return "[]byte(" + strconv.Quote(string(b[:goStringLimit])) + goTruncated(len(b)) + ")"
+}
+
+// goJSON formats j like goBytes, but prefers a raw string literal so the
+// JSON stays readable.
+func goJSON(j []byte) string {
+	if j == nil {
+		return "nil"
+	}
+	s, rest := string(j), ""
+	if len(j) > goStringLimit {
+		n := goStringLimit
+		for n > 0 && !utf8.RuneStart(j[n]) {
+			n--
+		}
+		s, rest = string(j[:n]), goTruncated(len(j))
+	}
+	if strconv.CanBackquote(s) {
+		return "[]byte(`" + s + "`" + rest + ")"
+	}
+	return "[]byte(" + strconv.Quote(s) + rest + ")"
+}
+
+// goTruncated returns the comment that marks a truncated payload of n bytes.
+func goTruncated(n int) string {
+	return " /* " + strconv.Itoa(n) + " bytes in total */"
+}
+
+// goDuration formats d as a sum of time unit constants, such as
+// 1*time.Hour + 30*time.Minute.
+func goDuration(d time.Duration) string {
+	if d == 0 {
+		return "0"
+	}
+	if d == math.MinInt64 {
+		return "math.MinInt64"
+	}
+	neg := d < 0
+	if neg {
+		d = -d
+	}
+	var terms []string
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+	} {
+		if n := d / unit.d; n > 0 {
+			terms = append(terms, strconv.FormatInt(int64(n), 10)+"*"+unit.name)
+			d -= n * unit.d
+		}
+	}
+	switch {
+	case d == 0:
+	case d%time.Millisecond == 0:
+		terms = append(terms, strconv.FormatInt(int64(d/time.Millisecond), 10)+"*time.Millisecond")
+	case d%time.Microsecond == 0:
+		terms = append(terms, strconv.FormatInt(int64(d/time.Microsecond), 10)+"*time.Microsecond")
+	default:
+		terms = append(terms, strconv.FormatInt(int64(d), 10)+"*time.Nanosecond")
+	}
+	s := strings.Join(terms, " + ")
+	if neg {
+		if len(terms) > 1 {
+			return "-(" + s + ")"
+		}
+		return "-" + s
+	}
+	return s
+}
+
+// goUUID formats u as a [16]byte literal preceded by its canonical form.
+func goUUID(u [16]byte) string {
+	var sb strings.Builder
+	sb.WriteString("/* " + formatUUID(u) + " */ [16]byte{")
+	for i, b := range u {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("0x" + hex.EncodeToString([]byte{b}))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// goURL formats u as a url.URL literal listing its non-empty fields. A
+// password is shown as "xxxxx", as url.URL.Redacted does, so that debug
+// output does not leak credentials.
+func goURL(u *url.URL) string {
+	if u == nil {
+		return "nil"
+	}
+	var fields []string
+	str := func(name, v string) {
+		if v != "" {
+			fields = append(fields, name+": "+strconv.Quote(v))
+		}
+	}
+	str("Scheme", u.Scheme)
+	str("Opaque", u.Opaque)
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			fields = append(fields, "User: url.UserPassword("+strconv.Quote(u.User.Username())+", \"xxxxx\")")
+		} else {
+			fields = append(fields, "User: url.User("+strconv.Quote(u.User.Username())+")")
+		}
+	}
+	str("Host", u.Host)
+	str("Path", u.Path)
+	str("RawPath", u.RawPath)
+	if u.ForceQuery {
+		fields = append(fields, "ForceQuery: true")
+	}
+	str("RawQuery", u.RawQuery)
+	str("Fragment", u.Fragment)
+	str("RawFragment", u.RawFragment)
+	return "&url.URL{" + strings.Join(fields, ", ") + "}"
+}
+
+// goAddr formats a as a netip.MustParseAddr call.
+func goAddr(a netip.Addr) string {
+	if !a.IsValid() {
+		return "netip.Addr{}"
+	}
+	return "netip.MustParseAddr(" + strconv.Quote(a.String()) + ")"
+}
+
+// goPrefix formats p as a netip.MustParsePrefix call.
+func goPrefix(p netip.Prefix) string {
+	if !p.IsValid() {
+		return "netip.Prefix{}"
+	}
+	return "netip.MustParsePrefix(" + strconv.Quote(p.String()) + ")"
+}
+
+// goBigInt formats b as a big.NewInt call, or as a call to SetString for
+// values outside the int64 range.
+func goBigInt(b *big.Int) string {
+	switch {
+	case b == nil:
+		return "nil"
+	case b.IsInt64():
+		return "big.NewInt(" + b.String() + ")"
+	}
+	return "func() *big.Int { b, _ := new(big.Int).SetString(" + strconv.Quote(b.String()) + ", 10); return b }()"
+}
@@ -0,0 +1,93 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGoString(t *testing.T) {
+	u, _ := url.Parse("https://user:pw@example.com/a?b=c#d")
+	tests := []struct {
+		in   fmt.GoStringer
+		want string
+	}{
+		{IntFrom(42), "null.IntFrom(42)"},
+		{NewInt(0, false), "null.NewInt(0, false)"},
+		{Int{}, "null.Int{}"},
+		{Int{Int: 5, Valid: true}, "null.Int{Int: 5, Valid: true}"},
+		{Int{Int: 5}, "null.Int{Int: 5}"},
+		{Int8From(-8), "null.Int8From(-8)"},
+		{Uint64From(math.MaxUint64), "null.Uint64From(18446744073709551615)"},
+		{BoolFrom(true), "null.BoolFrom(true)"},
+		{ByteFrom('x'), "null.ByteFrom('x')"},
+		{RuneFrom('€'), "null.RuneFrom('€')"},
+		{StringFrom("a\"b"), `null.StringFrom("a\"b")`},
+		{NewString("", false), `null.NewString("", false)`},
+		{String{}, "null.String{}"},
+		{Float64From(1.5), "null.Float64From(1.5)"},
+		{Float64From(math.NaN()), "null.Float64From(math.NaN())"},
+		{Float32From(float32(math.Inf(-1))), "null.Float32From(math.Inf(-1))"},
+		{Float32From(0.1), "null.Float32From(0.1)"},
+		{BytesFrom([]byte("hi\xff")), `null.BytesFrom([]byte("hi\xff"))`},
+		{NewBytes(nil, false), "null.NewBytes(nil, false)"},
+		{RawBytesFrom([]byte{}), `null.RawBytesFrom([]byte(""))`},
+		{JSONFrom([]byte(`{"a":1}`)), "null.JSONFrom([]byte(`{\"a\":1}`))"},
+		{JSONFrom([]byte(`"a` + "`" + `"`)), "null.JSONFrom([]byte(\"\\\"a`\\\"\"))"},
+		{TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)), "null.TimeFrom(time.Date(2012, time.December, 21, 21, 21, 21, 0, time.UTC))"},
+		{NewTime(time.Time{}, false), "null.NewTime(time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), false)"},
+		{DateOf(2012, 12, 21), "null.DateFrom(time.Date(2012, time.December, 21, 0, 0, 0, 0, time.UTC))"},
+		{TimeOfDayOf(15, 4, 5, 0), "null.TimeOfDayFrom(15*time.Hour + 4*time.Minute + 5*time.Second)"},
+		{DurationFrom(-90 * time.Minute), "null.DurationFrom(-(1*time.Hour + 30*time.Minute))"},
+		{DurationFrom(1500 * time.Microsecond), "null.DurationFrom(1500*time.Microsecond)"},
+		{DurationFrom(-7), "null.DurationFrom(-7*time.Nanosecond)"},
+		{NewDuration(0, false), "null.NewDuration(0, false)"},
+		{DecimalFrom("1.10"), `null.DecimalFrom("1.10")`},
+		{UUIDFrom([16]byte{15: 1}), "null.UUIDFrom(/* 00000000-0000-0000-0000-000000000001 */ [16]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01})"},
+		{URLFrom(u), `null.URLFrom(&url.URL{Scheme: "https", User: url.UserPassword("user", "xxxxx"), Host: "example.com", Path: "/a", RawQuery: "b=c", Fragment: "d"})`},
+		{NewURL(nil, false), "null.NewURL(nil, false)"},
+		{IPFrom(netip.MustParseAddr("::1")), `null.IPFrom(netip.MustParseAddr("::1"))`},
+		{NewIP(netip.Addr{}, false), "null.NewIP(netip.Addr{}, false)"},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), `null.CIDRFrom(netip.MustParsePrefix("10.0.0.0/8"))`},
+		{BigIntFrom(big.NewInt(-3)), "null.BigIntFrom(big.NewInt(-3))"},
+		{BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 100)), `null.BigIntFrom(func() *big.Int { b, _ := new(big.Int).SetString("1267650600228229401496703205376", 10); return b }())`},
+		{MapFrom(map[string]interface{}{"a": 1.0}), `null.MapFrom(map[string]interface {}{"a":1})`},
+		{StringSliceFrom([]string{"a", "b"}), `null.StringSliceFrom([]string{"a", "b"})`},
+		{Int64SliceFrom([]int64{1, 2}), "null.Int64SliceFrom([]int64{1, 2})"},
+		{EnumFrom(statusActive), `null.EnumFrom[null.testStatus]("active")`},
+		{NewEnum[testStatus]("", false), `null.NewEnum[null.testStatus]("", false)`},
+		{Enum[testStatus]{}, "null.Enum[null.testStatus]{}"},
+		{ValFrom(testPoint{1, 2}), "null.ValFrom[null.testPoint](null.testPoint{X:1, Y:2})"},
+		{ValFrom[int64](7), "null.ValFrom[int64](7)"},
+		{Val[int]{Val: 3}, "null.Val[int]{Val: 3}"},
+	}
+	for _, test := range tests {
+		if got := test.in.GoString(); got != test.want {
+			t.Errorf("%T.GoString() = %s, want %s", test.in, got, test.want)
+		}
+	}
+
+	if got := fmt.Sprintf("%#v", struct{ A Int }{IntFrom(1)}); got != "struct { A null.Int }{A:null.IntFrom(1)}" {
+		t.Errorf("%%#v of a struct = %s", got)
+	}
+}
+
+func TestGoStringTruncates(t *testing.T) {
+	long := strings.Repeat("é", 40)
+	got := JSONFrom([]byte(`"` + long + `"`)).GoString()
+	want := "null.JSONFrom([]byte(`\"" + strings.Repeat("é", 31) + "` /* 82 bytes in total */))"
+	if got != want {
+		t.Errorf("long JSON.GoString() = %s, want %s", got, want)
+	}
+
+	got = BytesFrom(make([]byte, 100)).GoString()
+	want = `null.BytesFrom([]byte("` + strings.Repeat(`\x00`, 64) + `" /* 100 bytes in total */))`
+	if got != want {
+		t.Errorf("long Bytes.GoString() = %s, want %s", got, want)
+	}
+}
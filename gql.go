@@ -0,0 +1,33 @@
+package null
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// The GraphQL methods implement the graphql.Marshaler and
+// graphql.Unmarshaler interfaces of github.com/99designs/gqlgen, so every
+// type can be bound to a custom scalar without a gqlgen dependency. gqlgen
+// hands UnmarshalGQL values that are already decoded, such as string,
+// json.Number, int64, bool or nil, so they are turned back into JSON and
+// decoded with UnmarshalJSON. That way an Int64 scalar accepts both
+// json.Number and numeric strings, like the JSON it came from.
+
+// marshalGQL writes the output of a MarshalJSON method. The interface
+// cannot return an error, so a failure writes null.
+func marshalGQL(w io.Writer, marshalJSON func() ([]byte, error)) {
+	data, err := marshalJSON()
+	if err != nil {
+		data = NullBytes
+	}
+	w.Write(data)
+}
+
+// unmarshalGQL encodes v as JSON and passes it to unmarshalJSON.
+func unmarshalGQL(v interface{}, unmarshalJSON func([]byte) error) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return unmarshalJSON(data)
+}
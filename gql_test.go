@@ -0,0 +1,96 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type gqlMarshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+type gqlUnmarshaler interface {
+	UnmarshalGQL(v interface{}) error
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		out  gqlUnmarshaler
+		in   interface{}
+		want interface{}
+	}{
+		{&Int64{}, json.Number("9007199254740993"), Int64From(9007199254740993)},
+		{&Int64{}, "-42", Int64From(-42)},
+		{&Int64{}, int64(7), Int64From(7)},
+		{&Int{}, 3, IntFrom(3)},
+		{&Int32{}, json.Number("12"), Int32From(12)},
+		{&Uint64{}, "18446744073709551615", Uint64From(math.MaxUint64)},
+		{&Float64{}, 1.5, Float64From(1.5)},
+		{&Float64{}, json.Number("2"), Float64From(2)},
+		{&Bool{}, true, BoolFrom(true)},
+		{&String{}, "hello", StringFrom("hello")},
+		{&Time{}, "2012-12-21T21:21:21Z", TimeFrom(when)},
+		{&Date{}, "2012-12-21", DateOf(2012, 12, 21)},
+		{&Decimal{}, json.Number("1.10"), DecimalFrom("1.10")},
+		{&UUID{}, uuidString, UUIDFrom(uuidValue)},
+		{&Map{}, map[string]interface{}{"a": 1.0}, MapFrom(map[string]interface{}{"a": 1.0})},
+		{&StringSlice{}, []interface{}{"a", "b"}, StringSliceFrom([]string{"a", "b"})},
+		{&Enum[testStatus]{}, "active", EnumFrom(statusActive)},
+		{&Val[testPoint]{}, map[string]interface{}{"x": int64(1), "y": int64(2)}, ValFrom(testPoint{1, 2})},
+		{&Int64{}, nil, NewInt64(0, false)},
+		{&String{}, nil, NewString("", false)},
+	}
+	for _, test := range tests {
+		err := test.out.UnmarshalGQL(test.in)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalGQL(%#v) = %#v, want %#v", test.out, test.in, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		out gqlUnmarshaler
+		in  interface{}
+	}{
+		{&Int64{}, "abc"},
+		{&Int8{}, int64(300)},
+		{&Bool{}, "yes"},
+		{&UUID{}, "abc"},
+		{&Enum[testStatus]{}, "deleted"},
+	} {
+		if err := test.out.UnmarshalGQL(test.in); err == nil {
+			t.Errorf("%T.UnmarshalGQL(%#v) should fail", test.out, test.in)
+		}
+	}
+}
+
+func TestMarshalGQL(t *testing.T) {
+	tests := []struct {
+		in   gqlMarshaler
+		want string
+	}{
+		{Int64From(9007199254740993), "9007199254740993"},
+		{NewInt64(0, false), "null"},
+		{StringFrom("a\"b"), `"a\"b"`},
+		{String{}, "null"},
+		{BoolFrom(false), "false"},
+		{Float64From(1.5), "1.5"},
+		{DateOf(2012, 12, 21), `"2012-12-21"`},
+		{MapFrom(map[string]interface{}{"a": 1.0}), `{"a":1}`},
+		{ValFrom(testPoint{1, 2}), `{"x":1,"y":2}`},
+		{Float64From(math.NaN()), "null"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		test.in.MarshalGQL(&buf)
+		if buf.String() != test.want {
+			t.Errorf("%T.MarshalGQL() = %s, want %s", test.in, buf.String(), test.want)
+		}
+	}
+}
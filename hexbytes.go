@@ -0,0 +1,622 @@
+package null
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+
+	"github.com/sisoftrg/null/convert"
+)
+
+// HexBytes is a nullable []byte that is encoded as lowercase hex text in
+// JSON, YAML, XML, CSV and MarshalText, rather than base64 like Bytes. It
+// is meant for digests and other opaque binary values that operators read
+// as hex. Input may have a "0x" prefix. The database and binary encodings
+// are those of Bytes.
+type HexBytes struct {
+	HexBytes []byte
+	Valid    bool
+	Set      bool
+}
+
+// NewHexBytes creates a new HexBytes. It shares b with the caller instead
+// of copying it; see HexBytesFromCopy and Clone.
+func NewHexBytes(b []byte, valid bool) HexBytes {
+	return HexBytes{
+		HexBytes: b,
+		Valid:    valid,
+		Set:      true,
+	}
+}
+
+// HexBytesFrom creates a new HexBytes that will be invalid if nil.
+func HexBytesFrom(b []byte) HexBytes {
+	return NewHexBytes(b, b != nil)
+}
+
+// HexBytesFromPtr creates a new HexBytes that will be invalid if nil.
+func HexBytesFromPtr(b *[]byte) HexBytes {
+	if b == nil {
+		return NewHexBytes(nil, false)
+	}
+	return NewHexBytes(*b, true)
+}
+
+// HexBytesFromNonEmpty creates a new HexBytes that will be null if b is empty.
+func HexBytesFromNonEmpty(b []byte) HexBytes {
+	return NewHexBytes(b, len(b) > 0)
+}
+
+// HexBytesFromCopy creates a new HexBytes holding a copy of b, so that
+// later changes to b do not show through. It will be invalid if b is nil.
+func HexBytesFromCopy(b []byte) HexBytes {
+	return HexBytesFrom(bytes.Clone(b))
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (h HexBytes) IsValid() bool {
+	return h.Set && h.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (h HexBytes) IsSet() bool {
+	return h.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (h HexBytes) IsNull() bool {
+	return h.Set && !h.Valid
+}
+
+// Equal reports whether h and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (h HexBytes) Equal(other HexBytes) bool {
+	if !h.Valid || !other.Valid {
+		return h.Valid == other.Valid
+	}
+	return bytes.Equal(h.HexBytes, other.HexBytes)
+}
+
+// EqualConstantTime is like Equal, but takes the same time wherever the
+// bytes differ, as Bytes.EqualConstantTime does. Use it for digests of
+// secrets.
+func (h HexBytes) EqualConstantTime(other HexBytes) bool {
+	if !h.Valid || !other.Valid {
+		return h.Valid == other.Valid
+	}
+	return subtle.ConstantTimeCompare(h.HexBytes, other.HexBytes) == 1
+}
+
+// ExactEqual is like Equal, but also requires h and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (h HexBytes) ExactEqual(other HexBytes) bool {
+	return h.Set == other.Set && h.Equal(other)
+}
+
+// Clone returns a copy of h with its own copy of the bytes, so that
+// changes to either do not show in the other.
+func (h HexBytes) Clone() HexBytes {
+	h.HexBytes = bytes.Clone(h.HexBytes)
+	return h
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a string of hex digits, the way UnmarshalText accepts them.
+func (h *HexBytes) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.HexBytes", h, data)
+	h.Set = true
+
+	if bytes.Equal(data, NullBytes) {
+		h.Valid = false
+		h.HexBytes = nil
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+	if str == "" {
+		// Unlike empty text, "" is a valid empty value in JSON.
+		h.HexBytes, h.Valid = []byte{}, true
+		return nil
+	}
+	return h.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes hex digits of either case, optionally prefixed with "0x" or
+// "0X". Empty text unmarshals to a null HexBytes.
+func (h *HexBytes) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.HexBytes", text)
+	h.Set = true
+	if len(text) == 0 {
+		h.HexBytes, h.Valid = nil, false
+		return nil
+	}
+	if len(text) > 1 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+	}
+	v, err := decodeHex(text, "null.HexBytes")
+	if err != nil {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+	h.HexBytes, h.Valid = v, true
+	return nil
+}
+
+// decodeHex decodes hex digits for typ, checking MaxBytesLen.
+func decodeHex(text []byte, typ string) ([]byte, error) {
+	if len(text)%2 != 0 {
+		return nil, fmt.Errorf("null: cannot decode %s from %d hex digits, want an even number", typ, len(text))
+	}
+	if err := checkLen(typ, MaxBytesLen, len(text)/2); err != nil {
+		return nil, err
+	}
+	v := make([]byte, len(text)/2)
+	if _, err := hex.Decode(v, text); err != nil {
+		return nil, fmt.Errorf("null: cannot decode %s: %w", typ, err)
+	}
+	return v, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It encodes the bytes as a string of lowercase hex digits, so a valid
+// empty value is "", or null if h is null.
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	if !h.Valid {
+		return NullBytes, nil
+	}
+	return h.AppendJSON(make([]byte, 0, hex.EncodedLen(len(h.HexBytes))+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (h HexBytes) AppendJSON(b []byte) ([]byte, error) {
+	if !h.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = hex.AppendEncode(b, h.HexBytes)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the bytes as lowercase hex digits without a prefix.
+func (h HexBytes) MarshalText() ([]byte, error) {
+	if !h.Valid {
+		return nil, nil
+	}
+	return h.AppendText(make([]byte, 0, hex.EncodedLen(len(h.HexBytes))))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if h is null.
+func (h HexBytes) AppendText(b []byte) ([]byte, error) {
+	if !h.Valid {
+		return b, nil
+	}
+	return hex.AppendEncode(b, h.HexBytes), nil
+}
+
+func (h HexBytes) hexText() ([]byte, error) {
+	b := make([]byte, hex.EncodedLen(len(h.HexBytes)))
+	hex.Encode(b, h.HexBytes)
+	return b, nil
+}
+
+// SetValid changes this HexBytes's value and also sets it to be non-null.
+func (h *HexBytes) SetValid(n []byte) {
+	h.HexBytes = n
+	h.Valid = true
+	h.Set = true
+}
+
+// SetNull sets this HexBytes to an explicit null, zeroing its value.
+func (h *HexBytes) SetNull() {
+	*h = HexBytes{Set: true}
+}
+
+// Unset resets this HexBytes to the zero value, as if it was never set.
+func (h *HexBytes) Unset() {
+	*h = HexBytes{}
+}
+
+// SetPtr changes this HexBytes to the value p points to, or to an explicit
+// null if p is nil.
+func (h *HexBytes) SetPtr(p *[]byte) {
+	*h = HexBytesFromPtr(p)
+}
+
+// Ptr returns a pointer to this HexBytes's value, or a nil pointer if this HexBytes is null.
+func (h HexBytes) Ptr() *[]byte {
+	if !h.Valid {
+		return nil
+	}
+	return &h.HexBytes
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (h HexBytes) ValueOrZero() []byte {
+	if !h.Valid {
+		return nil
+	}
+	return h.HexBytes
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (h HexBytes) Or(def []byte) []byte {
+	if !h.Valid {
+		return def
+	}
+	return h.HexBytes
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (h HexBytes) Val() []byte {
+	return h.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (h HexBytes) Get() ([]byte, bool) {
+	return h.ValueOrZero(), h.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if h is
+// null.
+func (h HexBytes) MustGet() []byte {
+	if !h.Valid {
+		panic(&NullError{Type: "null.HexBytes"})
+	}
+	return h.HexBytes
+}
+
+// IsZero returns true if this HexBytes was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (h HexBytes) IsZero() bool {
+	return !h.Set && !h.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// Like MarshalJSON, it encodes the bytes as a hex string, or null if there
+// are none.
+func (h HexBytes) MarshalYAML() (interface{}, error) {
+	if len(h.HexBytes) == 0 {
+		return nil, nil
+	}
+	return hex.EncodeToString(h.HexBytes), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It decodes a hex string like UnmarshalText. It will unmarshal to a null
+// HexBytes if the input is null or ~.
+func (h *HexBytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	h.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+	return h.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// Like MarshalJSON, it encodes the bytes as hex, or an empty element if
+// there are none.
+func (h HexBytes) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, len(h.HexBytes) != 0, h.hexText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It decodes hex content. An empty element unmarshals to a null HexBytes.
+func (h *HexBytes) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, h.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute holds the bytes as hex, and is omitted if there are none.
+func (h HexBytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, len(h.HexBytes) != 0, h.hexText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null HexBytes.
+func (h *HexBytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return h.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// Like Bytes, it encodes generic BSON binary, or BSON null if there are
+// no bytes.
+func (h HexBytes) MarshalBSONValue() (byte, []byte, error) {
+	if len(h.HexBytes) == 0 {
+		return bsonNull, nil, nil
+	}
+	return bsonBinaryValue(bsonBinaryGeneric, h.HexBytes)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts BSON binary of any subtype.
+func (h *HexBytes) UnmarshalBSONValue(typ byte, data []byte) error {
+	h.Set = true
+	if bsonIsNull(typ) {
+		h.HexBytes, h.Valid = nil, false
+		return nil
+	}
+	_, v, err := bsonReadBinary(typ, data, "null.HexBytes")
+	h.HexBytes, h.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// Like Bytes, it encodes msgpack binary, or nil if there are no bytes.
+func (h HexBytes) MarshalMsgpack() ([]byte, error) {
+	if len(h.HexBytes) == 0 {
+		return msgpackNull, nil
+	}
+	return appendMsgpackBin(nil, h.HexBytes), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts msgpack binary, or a string of hex digits.
+func (h *HexBytes) UnmarshalMsgpack(data []byte) error {
+	h.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case []byte:
+		h.HexBytes, h.Valid = x, true
+	case string:
+		return h.UnmarshalText([]byte(x))
+	default:
+		h.HexBytes, h.Valid = nil, false
+		return msgpackTypeError(v, "null.HexBytes")
+	}
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// Like Bytes, it encodes a CBOR byte string, or null if there are no bytes.
+func (h HexBytes) MarshalCBOR() ([]byte, error) {
+	if len(h.HexBytes) == 0 {
+		return cborNull, nil
+	}
+	return appendCBORBytes(nil, h.HexBytes), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR byte string, or a string of hex digits.
+func (h *HexBytes) UnmarshalCBOR(data []byte) error {
+	h.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		h.HexBytes, h.Valid = nil, false
+		return err
+	}
+	switch x := v.(type) {
+	case []byte:
+		h.HexBytes, h.Valid = x, true
+	case string:
+		return h.UnmarshalText([]byte(x))
+	default:
+		h.HexBytes, h.Valid = nil, false
+		return cborTypeError(v, "null.HexBytes")
+	}
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (h HexBytes) MarshalGQL(w io.Writer) {
+	marshalGQL(w, h.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null HexBytes.
+func (h *HexBytes) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, h.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the bytes as lowercase hex, or an empty cell if there are no
+// bytes.
+func (h HexBytes) MarshalCSV() (string, error) {
+	return marshalCSV(len(h.HexBytes) != 0, h.hexText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts hex like UnmarshalText. An empty cell unmarshals to a null
+// HexBytes.
+func (h *HexBytes) UnmarshalCSV(cell string) error {
+	return h.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this HexBytes is null.
+func (h HexBytes) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(h.Valid, h.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null HexBytes.
+func (h *HexBytes) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, h.UnmarshalText, h.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes BYTES, or a typed NULL if this HexBytes is null.
+func (h HexBytes) EncodeSpanner() (interface{}, error) {
+	if !h.Valid {
+		return []byte(nil), nil
+	}
+	if h.HexBytes == nil {
+		// Spanner encodes a nil slice as NULL.
+		return []byte{}, nil
+	}
+	return h.HexBytes, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts BYTES, which Spanner sends as standard base64. NULL decodes
+// to a null HexBytes.
+func (h *HexBytes) DecodeSpanner(input interface{}) error {
+	h.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		h.HexBytes, h.Valid = nil, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.HexBytes")
+	}
+	bv, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		h.HexBytes, h.Valid = nil, false
+		return fmt.Errorf("null: cannot decode Spanner BYTES into null.HexBytes: %w", err)
+	}
+	h.HexBytes, h.Valid = bv, true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random byte, or an explicit null if shouldBeNull is true.
+func (h *HexBytes) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*h = NewHexBytes(nil, false)
+		return
+	}
+	h.SetValid(randomBytes(nextInt, 1))
+}
+
+// Generate implements testing/quick's Generator.
+func (h HexBytes) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewHexBytes(nil, false), func() HexBytes {
+		return HexBytesFrom(generateBytes(rand, size))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (h HexBytes) MarshalBinary() ([]byte, error) {
+	return marshalBinary(h.Set, h.Valid, func() ([]byte, error) {
+		return h.HexBytes, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *HexBytes) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.HexBytes")
+	if err != nil {
+		return err
+	}
+	var v []byte
+	if valid {
+		v = append([]byte{}, p...)
+	}
+	h.HexBytes, h.Valid, h.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It copies []byte and string values, except that those starting with
+// `\x` are decoded as hex, which is how Postgres drivers using the text
+// format deliver bytea columns.
+func (h *HexBytes) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.HexBytes", value)
+	if value == nil {
+		h.HexBytes, h.Valid, h.Set = nil, false, true
+		return nil
+	}
+	h.Valid, h.Set = true, true
+	switch x := value.(type) {
+	case []byte:
+		if bytes.HasPrefix(x, []byte(`\x`)) {
+			h.HexBytes, err = decodeHex(x[2:], "null.HexBytes")
+		} else {
+			// The driver may reuse x once Scan returns.
+			h.HexBytes = bytes.Clone(x)
+		}
+	case string:
+		if len(x) >= 2 && x[:2] == `\x` {
+			h.HexBytes, err = decodeHex([]byte(x[2:]), "null.HexBytes")
+		} else {
+			h.HexBytes = []byte(x)
+		}
+	default:
+		err = convert.ConvertAssign(&h.HexBytes, value)
+	}
+	if err == nil {
+		err = checkLen("null.HexBytes", MaxBytesLen, len(h.HexBytes))
+	}
+	if err != nil {
+		h.HexBytes, h.Valid = nil, false
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// It returns the bytes themselves, not hex.
+func (h HexBytes) Value() (driver.Value, error) {
+	if !h.Valid {
+		return nil, nil
+	}
+	return h.HexBytes, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (h HexBytes) String() string {
+	return fmt.Sprint(h)
+}
+
+// GoString implements the GoStringer interface, formatting h as the Go
+// expression that builds it.
+func (h HexBytes) GoString() string {
+	return goString("HexBytes", "", "HexBytes", goBytes(h.HexBytes), h.HexBytes == nil, h.Valid, h.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// h with the verb if it is valid.
+func (h HexBytes) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, h, h.Valid, h.Set, h.HexBytes)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the hex bytes, cut off after
+// MaxLogValueLen bytes, or to nil if this HexBytes is null.
+func (h HexBytes) LogValue() slog.Value {
+	if !h.Valid {
+		return logNull
+	}
+	return logBytes(h.HexBytes, false, hex.EncodeToString)
+}
@@ -0,0 +1,128 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var digest = []byte{0xde, 0xad, 0xbe, 0xef, 0x00, 0x0a}
+
+func TestHexBytesFrom(t *testing.T) {
+	assertHexBytes(t, HexBytesFrom(digest), "HexBytesFrom()")
+	assertNullHexBytes(t, HexBytesFrom(nil), "HexBytesFrom(nil)")
+	assertHexBytes(t, HexBytesFromPtr(&digest), "HexBytesFromPtr()")
+	assertNullHexBytes(t, HexBytesFromPtr(nil), "HexBytesFromPtr(nil)")
+
+	c := HexBytesFromCopy(digest)
+	c.HexBytes[0] = 0
+	if digest[0] != 0xde {
+		t.Error("HexBytesFromCopy() shares its payload with the caller")
+	}
+}
+
+func TestHexBytesRoundTrip(t *testing.T) {
+	h := HexBytesFrom(digest)
+	data, err := json.Marshal(h)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"deadbeef000a"`, "hex json marshal")
+	var back HexBytes
+	err = json.Unmarshal(data, &back)
+	maybePanic(err)
+	assertHexBytes(t, back, "hex json round trip")
+
+	text, err := h.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, text, "deadbeef000a", "hex text marshal")
+	back = HexBytes{}
+	err = back.UnmarshalText(text)
+	maybePanic(err)
+	assertHexBytes(t, back, "hex text round trip")
+
+	cell, err := h.MarshalCSV()
+	maybePanic(err)
+	back = HexBytes{}
+	err = back.UnmarshalCSV(cell)
+	maybePanic(err)
+	assertHexBytes(t, back, "hex csv round trip")
+
+	data, err = json.Marshal(NewHexBytes(nil, false))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+	err = json.Unmarshal(data, &back)
+	maybePanic(err)
+	assertNullHexBytes(t, back, "null json round trip")
+}
+
+func TestHexBytesUnmarshalText(t *testing.T) {
+	for _, in := range []string{"deadbeef000a", "DEADBEEF000A", "0xdeadbeef000a", "0XDeadBeef000a"} {
+		var h HexBytes
+		err := h.UnmarshalText([]byte(in))
+		maybePanic(err)
+		assertHexBytes(t, h, "UnmarshalText("+in+")")
+	}
+
+	var h HexBytes
+	err := h.UnmarshalText(nil)
+	maybePanic(err)
+	assertNullHexBytes(t, h, "UnmarshalText(empty)")
+
+	for _, in := range []string{"abc", "0xabc", "zz", "de ad"} {
+		var h HexBytes
+		err := h.UnmarshalText([]byte(in))
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("UnmarshalText(%q) error = %v, want ErrInvalidInput", in, err)
+		}
+		if h.Valid {
+			t.Errorf("UnmarshalText(%q) should be null after an error", in)
+		}
+	}
+
+	err = h.UnmarshalText([]byte("abc"))
+	if err == nil || !strings.Contains(err.Error(), "even") {
+		t.Errorf("odd-length error = %v, want it to mention an even number of digits", err)
+	}
+	if err := json.Unmarshal([]byte(`"0xabc"`), &h); err == nil {
+		t.Error("UnmarshalJSON() of odd-length hex should fail")
+	}
+}
+
+func TestHexBytesScan(t *testing.T) {
+	for _, in := range []interface{}{digest, `\xdeadbeef000a`, []byte(`\xDEADBEEF000A`)} {
+		var h HexBytes
+		err := h.Scan(in)
+		maybePanic(err)
+		assertHexBytes(t, h, "Scan()")
+	}
+
+	var h HexBytes
+	err := h.Scan(`\xabc`)
+	if !errors.Is(err, ErrInvalidInput) || h.Valid {
+		t.Errorf("Scan() of odd-length bytea = %#v, %v", h, err)
+	}
+	err = h.Scan(nil)
+	maybePanic(err)
+	assertNullHexBytes(t, h, "Scan(nil)")
+
+	v, err := HexBytesFrom(digest).Value()
+	maybePanic(err)
+	if !bytes.Equal(v.([]byte), digest) {
+		t.Errorf("Value() = %v, want the raw bytes", v)
+	}
+}
+
+func assertHexBytes(t *testing.T, h HexBytes, from string) {
+	t.Helper()
+	if !h.Valid || !bytes.Equal(h.HexBytes, digest) {
+		t.Errorf("bad %s HexBytes: %#v ≠ %x", from, h, digest)
+	}
+}
+
+func assertNullHexBytes(t *testing.T, h HexBytes, from string) {
+	t.Helper()
+	if h.Valid {
+		t.Errorf("%s HexBytes is valid, but should be invalid", from)
+	}
+}
@@ -2,12 +2,18 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Int is an nullable int.
@@ -39,6 +45,34 @@ func IntFromPtr(i *int) Int {
 	return NewInt(*i, true)
 }
 
+// IntFromNonZero creates a new Int that will be null if i is 0.
+func IntFromNonZero(i int) Int {
+	return NewInt(i, i != 0)
+}
+
+// IntFromString parses s with the rules of UnmarshalText into a new Int.
+// An empty string produces a null Int.
+func IntFromString(s string) (Int, error) {
+	var i Int
+	err := i.UnmarshalText([]byte(s))
+	return i, err
+}
+
+// MustIntFromString is like IntFromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustIntFromString(s string) Int {
+	i, err := IntFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// IntFromNullInt64 creates a new Int from a sql.NullInt64. It is always Set.
+func IntFromNullInt64(n sql.NullInt64) Int {
+	return NewInt(int(n.Int64), n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int) IsValid() bool {
@@ -50,9 +84,45 @@ func (i Int) IsSet() bool {
 	return i.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (i Int) IsNull() bool {
+	return i.Set && !i.Valid
+}
+
+// Equal reports whether i and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (i Int) Equal(other Int) bool {
+	if !i.Valid || !other.Valid {
+		return i.Valid == other.Valid
+	}
+	return i.Int == other.Int
+}
+
+// ExactEqual is like Equal, but also requires i and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (i Int) ExactEqual(other Int) bool {
+	return i.Set == other.Set && i.Equal(other)
+}
+
+// Compare returns -1 if i is less than other, 0 if they are equal and +1
+// if i is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (i Int) Compare(other Int) int {
+	if !i.Valid || !other.Valid {
+		return compareNull(i.Valid, other.Valid)
+	}
+	return cmp.Compare(i.Int, other.Int)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (i *Int) UnmarshalJSON(data []byte) error {
+func (i *Int) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int", i, data)
 	i.Set = true
+	data, err = unquoteNumber(data, "null.Int")
+	if err != nil {
+		return err
+	}
 
 	if bytes.Equal(data, NullBytes) {
 		i.Valid = false
@@ -71,13 +141,13 @@ func (i *Int) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (i *Int) UnmarshalText(text []byte) error {
+func (i *Int) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Int", text)
 	i.Set = true
 	if len(text) == 0 {
 		i.Valid = false
 		return nil
 	}
-	var err error
 	res, err := strconv.ParseInt(string(text), 10, 0)
 	i.Valid = err == nil
 	if i.Valid {
@@ -91,7 +161,16 @@ func (i Int) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int), 10)), nil
+	return i.AppendJSON(make([]byte, 0, 20))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (i Int) AppendJSON(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendInt(b, int64(i.Int), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -99,7 +178,16 @@ func (i Int) MarshalText() ([]byte, error) {
 	if !i.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int), 10)), nil
+	return i.AppendText(make([]byte, 0, 20))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if i is null.
+func (i Int) AppendText(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(i.Int), 10), nil
 }
 
 // SetValid changes this Int's value and also sets it to be non-null.
@@ -109,6 +197,22 @@ func (i *Int) SetValid(n int) {
 	i.Set = true
 }
 
+// SetNull sets this Int to an explicit null, zeroing its value.
+func (i *Int) SetNull() {
+	*i = Int{Set: true}
+}
+
+// Unset resets this Int to the zero value, as if it was never set.
+func (i *Int) Unset() {
+	*i = Int{}
+}
+
+// SetPtr changes this Int to the value p points to, or to an explicit
+// null if p is nil.
+func (i *Int) SetPtr(p *int) {
+	*i = IntFromPtr(p)
+}
+
 // Ptr returns a pointer to this Int's value, or a nil pointer if this Int is null.
 func (i Int) Ptr() *int {
 	if !i.Valid {
@@ -117,19 +221,279 @@ func (i Int) Ptr() *int {
 	return &i.Int
 }
 
-// IsZero returns true for invalid Ints, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (i Int) ValueOrZero() int {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (i Int) Or(def int) int {
+	if !i.Valid {
+		return def
+	}
+	return i.Int
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (i Int) Val() int {
+	return i.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (i Int) Get() (int, bool) {
+	return i.ValueOrZero(), i.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if i is
+// null.
+func (i Int) MustGet() int {
+	if !i.Valid {
+		panic(&NullError{Type: "null.Int"})
+	}
+	return i.Int
+}
+
+// IsZero returns true if this Int was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (i Int) IsZero() bool {
-	return !i.Valid
+	return !i.Set && !i.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int is null.
+func (i Int) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int if the input is null or ~.
+func (i *Int) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	i.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+
+	var v int
+	if err := unmarshal(&v); err != nil {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+	i.Int, i.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int is null.
+func (i Int) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Int.
+func (i *Int) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, i.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int is null.
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int.
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 64-bit integer, or BSON null if this Int is null.
+func (i Int) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt64Value(int64(i.Int))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON integer, or a double with no fractional part, that
+// fits in an int.
+func (i *Int) UnmarshalBSONValue(typ byte, data []byte) error {
+	i.Set = true
+	if bsonIsNull(typ) {
+		i.Int, i.Valid = 0, false
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.Int", math.MinInt, math.MaxInt)
+	i.Int, i.Valid = int(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Int is null.
+func (i Int) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, int64(i.Int)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack integer, or a float with no fractional part, that
+// fits in an int.
+func (i *Int) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+	n, err := msgpackInt(v, "null.Int", math.MinInt, math.MaxInt)
+	i.Int, i.Valid = int(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Int is null.
+func (i Int) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, int64(i.Int)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer, or a float with no fractional part, that
+// fits in an int.
+func (i *Int) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+	n, err := cborInt(v, "null.Int", math.MinInt, math.MaxInt)
+	i.Int, i.Valid = int(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (i Int) MarshalGQL(w io.Writer) {
+	marshalGQL(w, i.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int.
+func (i *Int) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, i.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Int is
+// null.
+func (i Int) MarshalCSV() (string, error) {
+	return marshalCSV(i.Valid, i.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Int.
+func (i *Int) UnmarshalCSV(cell string) error {
+	return i.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Int, while an absent one is never passed, leaving the
+// field unset.
+func (i *Int) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int is null.
+func (i Int) MarshalTOML() ([]byte, error) {
+	return marshalTOML(i.Valid, i.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Int.
+func (i *Int) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, i.UnmarshalText, i.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative 32-bit number, or an explicit null if shouldBeNull is true.
+func (i *Int) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = NewInt(0, false)
+		return
+	}
+	i.SetValid(int(randomN(nextInt, math.MaxInt32)))
+}
+
+// Generate implements testing/quick's Generator.
+func (i Int) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt(0, false), func() Int {
+		return IntFrom(int(generateInt64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (i Int) MarshalBinary() ([]byte, error) {
+	return marshalBinary(i.Set, i.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(i.Int)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int")
+	if err != nil {
+		return err
+	}
+	var v int
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Int", math.MinInt, math.MaxInt); err != nil {
+			return err
+		}
+		v = int(n)
+	}
+	i.Int, i.Valid, i.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (i *Int) Scan(value interface{}) error {
+func (i *Int) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int", value)
 	if value == nil {
-		i.Int, i.Valid, i.Set = 0, false, false
+		i.Int, i.Valid, i.Set = 0, false, true
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int, value)
+	n, err := scanInt(value, "null.Int", strconv.IntSize)
+	i.Int, i.Valid, i.Set = int(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -140,10 +504,34 @@ func (i Int) Value() (driver.Value, error) {
 	return int64(i.Int), nil
 }
 
-// String implements the Stringer interface
+// ToNullInt64 converts i to a sql.NullInt64, dropping Set.
+func (i Int) ToNullInt64() sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(i.Int), Valid: i.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Int) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Int(%d)", i.Int)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting i as the Go
+// expression that builds it.
+func (i Int) GoString() string {
+	return goString("Int", "", "Int", strconv.FormatInt(int64(i.Int), 10), i.Int == 0, i.Valid, i.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// i with the verb if it is valid.
+func (i Int) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, i, i.Valid, i.Set, i.Int)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Int64 value, or to nil if this Int is null.
+func (i Int) LogValue() slog.Value {
+	if !i.Valid {
+		return logNull
 	}
-	return "Int(invalid)"
+	return slog.Int64Value(int64(i.Int))
 }
@@ -2,13 +2,18 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Int16 is an nullable int16.
@@ -40,6 +45,34 @@ func Int16FromPtr(i *int16) Int16 {
 	return NewInt16(*i, true)
 }
 
+// Int16FromNonZero creates a new Int16 that will be null if i is 0.
+func Int16FromNonZero(i int16) Int16 {
+	return NewInt16(i, i != 0)
+}
+
+// Int16FromString parses s with the rules of UnmarshalText into a new Int16.
+// An empty string produces a null Int16.
+func Int16FromString(s string) (Int16, error) {
+	var i Int16
+	err := i.UnmarshalText([]byte(s))
+	return i, err
+}
+
+// MustInt16FromString is like Int16FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustInt16FromString(s string) Int16 {
+	i, err := Int16FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// Int16FromNullInt16 creates a new Int16 from a sql.NullInt16. It is always Set.
+func Int16FromNullInt16(n sql.NullInt16) Int16 {
+	return NewInt16(n.Int16, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int16) IsValid() bool {
@@ -51,9 +84,46 @@ func (i Int16) IsSet() bool {
 	return i.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (i Int16) IsNull() bool {
+	return i.Set && !i.Valid
+}
+
+// Equal reports whether i and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (i Int16) Equal(other Int16) bool {
+	if !i.Valid || !other.Valid {
+		return i.Valid == other.Valid
+	}
+	return i.Int16 == other.Int16
+}
+
+// ExactEqual is like Equal, but also requires i and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (i Int16) ExactEqual(other Int16) bool {
+	return i.Set == other.Set && i.Equal(other)
+}
+
+// Compare returns -1 if i is less than other, 0 if they are equal and +1
+// if i is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (i Int16) Compare(other Int16) int {
+	if !i.Valid || !other.Valid {
+		return compareNull(i.Valid, other.Valid)
+	}
+	return cmp.Compare(i.Int16, other.Int16)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (i *Int16) UnmarshalJSON(data []byte) error {
+func (i *Int16) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int16", i, data)
 	i.Set = true
+	data, err = unquoteNumber(data, "null.Int16")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		i.Int16, i.Valid = 0, false
 		return nil
@@ -64,8 +134,8 @@ func (i *Int16) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if x > math.MaxInt16 {
-		return fmt.Errorf("json: %d overflows max int16 value", x)
+	if x < math.MinInt16 || x > math.MaxInt16 {
+		return overflowf("null: %d does not fit in null.Int16", x)
 	}
 
 	i.Int16, i.Valid = int16(x), true
@@ -73,13 +143,13 @@ func (i *Int16) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (i *Int16) UnmarshalText(text []byte) error {
+func (i *Int16) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Int16", text)
 	i.Set = true
 	if len(text) == 0 {
 		i.Valid = false
 		return nil
 	}
-	var err error
 	res, err := strconv.ParseInt(string(text), 10, 16)
 	i.Valid = err == nil
 	if i.Valid {
@@ -93,7 +163,16 @@ func (i Int16) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int16), 10)), nil
+	return i.AppendJSON(make([]byte, 0, 6))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (i Int16) AppendJSON(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendInt(b, int64(i.Int16), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -101,7 +180,16 @@ func (i Int16) MarshalText() ([]byte, error) {
 	if !i.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int16), 10)), nil
+	return i.AppendText(make([]byte, 0, 6))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if i is null.
+func (i Int16) AppendText(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(i.Int16), 10), nil
 }
 
 // SetValid changes this Int16's value and also sets it to be non-null.
@@ -111,6 +199,22 @@ func (i *Int16) SetValid(n int16) {
 	i.Set = true
 }
 
+// SetNull sets this Int16 to an explicit null, zeroing its value.
+func (i *Int16) SetNull() {
+	*i = Int16{Set: true}
+}
+
+// Unset resets this Int16 to the zero value, as if it was never set.
+func (i *Int16) Unset() {
+	*i = Int16{}
+}
+
+// SetPtr changes this Int16 to the value p points to, or to an explicit
+// null if p is nil.
+func (i *Int16) SetPtr(p *int16) {
+	*i = Int16FromPtr(p)
+}
+
 // Ptr returns a pointer to this Int16's value, or a nil pointer if this Int16 is null.
 func (i Int16) Ptr() *int16 {
 	if !i.Valid {
@@ -119,19 +223,279 @@ func (i Int16) Ptr() *int16 {
 	return &i.Int16
 }
 
-// IsZero returns true for invalid Int16's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (i Int16) ValueOrZero() int16 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int16
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (i Int16) Or(def int16) int16 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int16
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (i Int16) Val() int16 {
+	return i.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (i Int16) Get() (int16, bool) {
+	return i.ValueOrZero(), i.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if i is
+// null.
+func (i Int16) MustGet() int16 {
+	if !i.Valid {
+		panic(&NullError{Type: "null.Int16"})
+	}
+	return i.Int16
+}
+
+// IsZero returns true if this Int16 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (i Int16) IsZero() bool {
-	return !i.Valid
+	return !i.Set && !i.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int16 is null.
+func (i Int16) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int16, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int16 if the input is null or ~.
+func (i *Int16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	i.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		i.Int16, i.Valid = 0, false
+		return err
+	}
+
+	var v int16
+	if err := unmarshal(&v); err != nil {
+		i.Int16, i.Valid = 0, false
+		return err
+	}
+	i.Int16, i.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int16 is null.
+func (i Int16) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Int16.
+func (i *Int16) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, i.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int16 is null.
+func (i Int16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int16.
+func (i *Int16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 32-bit integer, or BSON null if this Int16 is null.
+func (i Int16) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt32Value(int32(i.Int16))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON integer, or a double with no fractional part, that
+// fits in an int16.
+func (i *Int16) UnmarshalBSONValue(typ byte, data []byte) error {
+	i.Set = true
+	if bsonIsNull(typ) {
+		i.Int16, i.Valid = 0, false
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.Int16", math.MinInt16, math.MaxInt16)
+	i.Int16, i.Valid = int16(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Int16 is null.
+func (i Int16) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, int64(i.Int16)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack integer, or a float with no fractional part, that
+// fits in an int16.
+func (i *Int16) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		i.Int16, i.Valid = 0, false
+		return err
+	}
+	n, err := msgpackInt(v, "null.Int16", math.MinInt16, math.MaxInt16)
+	i.Int16, i.Valid = int16(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Int16 is null.
+func (i Int16) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, int64(i.Int16)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer, or a float with no fractional part, that
+// fits in an int16.
+func (i *Int16) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		i.Int16, i.Valid = 0, false
+		return err
+	}
+	n, err := cborInt(v, "null.Int16", math.MinInt16, math.MaxInt16)
+	i.Int16, i.Valid = int16(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (i Int16) MarshalGQL(w io.Writer) {
+	marshalGQL(w, i.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int16.
+func (i *Int16) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, i.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Int16 is
+// null.
+func (i Int16) MarshalCSV() (string, error) {
+	return marshalCSV(i.Valid, i.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Int16.
+func (i *Int16) UnmarshalCSV(cell string) error {
+	return i.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Int16, while an absent one is never passed, leaving
+// the field unset.
+func (i *Int16) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int16 is null.
+func (i Int16) MarshalTOML() ([]byte, error) {
+	return marshalTOML(i.Valid, i.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Int16.
+func (i *Int16) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, i.UnmarshalText, i.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative number, or an explicit null if shouldBeNull is true.
+func (i *Int16) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = NewInt16(0, false)
+		return
+	}
+	i.SetValid(int16(randomN(nextInt, math.MaxInt16)))
+}
+
+// Generate implements testing/quick's Generator.
+func (i Int16) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt16(0, false), func() Int16 {
+		return Int16From(int16(generateInt64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (i Int16) MarshalBinary() ([]byte, error) {
+	return marshalBinary(i.Set, i.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(i.Int16)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int16) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int16")
+	if err != nil {
+		return err
+	}
+	var v int16
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Int16", math.MinInt16, math.MaxInt16); err != nil {
+			return err
+		}
+		v = int16(n)
+	}
+	i.Int16, i.Valid, i.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (i *Int16) Scan(value interface{}) error {
+func (i *Int16) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int16", value)
 	if value == nil {
-		i.Int16, i.Valid, i.Set = 0, false, false
+		i.Int16, i.Valid, i.Set = 0, false, true
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int16, value)
+	n, err := scanInt(value, "null.Int16", 16)
+	i.Int16, i.Valid, i.Set = int16(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -142,10 +506,34 @@ func (i Int16) Value() (driver.Value, error) {
 	return int64(i.Int16), nil
 }
 
-// String implements the Stringer interface
+// ToNullInt16 converts i to a sql.NullInt16, dropping Set.
+func (i Int16) ToNullInt16() sql.NullInt16 {
+	return sql.NullInt16{Int16: i.Int16, Valid: i.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Int16) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Int16(%d)", i.Int16)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting i as the Go
+// expression that builds it.
+func (i Int16) GoString() string {
+	return goString("Int16", "", "Int16", strconv.FormatInt(int64(i.Int16), 10), i.Int16 == 0, i.Valid, i.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// i with the verb if it is valid.
+func (i Int16) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, i, i.Valid, i.Set, i.Int16)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Int64 value, or to nil if this Int16 is null.
+func (i Int16) LogValue() slog.Value {
+	if !i.Valid {
+		return logNull
 	}
-	return "Int16(invalid)"
+	return slog.Int64Value(int64(i.Int16))
 }
@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 	"testing"
@@ -54,7 +55,8 @@ func TestUnmarshalInt16(t *testing.T) {
 
 	var invalid Int16
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullInt16(t, invalid, "invalid json")
@@ -142,7 +144,12 @@ func TestInt16IsZero(t *testing.T) {
 	}
 
 	null := NewInt16(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Int16
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -169,6 +176,9 @@ func TestInt16Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullInt16(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertInt16(t *testing.T, i Int16, from string) {
@@ -2,13 +2,18 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Int32 is an nullable int32.
@@ -40,6 +45,34 @@ func Int32FromPtr(i *int32) Int32 {
 	return NewInt32(*i, true)
 }
 
+// Int32FromNonZero creates a new Int32 that will be null if i is 0.
+func Int32FromNonZero(i int32) Int32 {
+	return NewInt32(i, i != 0)
+}
+
+// Int32FromString parses s with the rules of UnmarshalText into a new Int32.
+// An empty string produces a null Int32.
+func Int32FromString(s string) (Int32, error) {
+	var i Int32
+	err := i.UnmarshalText([]byte(s))
+	return i, err
+}
+
+// MustInt32FromString is like Int32FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustInt32FromString(s string) Int32 {
+	i, err := Int32FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// Int32FromNullInt32 creates a new Int32 from a sql.NullInt32. It is always Set.
+func Int32FromNullInt32(n sql.NullInt32) Int32 {
+	return NewInt32(n.Int32, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int32) IsValid() bool {
@@ -51,9 +84,46 @@ func (i Int32) IsSet() bool {
 	return i.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (i Int32) IsNull() bool {
+	return i.Set && !i.Valid
+}
+
+// Equal reports whether i and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (i Int32) Equal(other Int32) bool {
+	if !i.Valid || !other.Valid {
+		return i.Valid == other.Valid
+	}
+	return i.Int32 == other.Int32
+}
+
+// ExactEqual is like Equal, but also requires i and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (i Int32) ExactEqual(other Int32) bool {
+	return i.Set == other.Set && i.Equal(other)
+}
+
+// Compare returns -1 if i is less than other, 0 if they are equal and +1
+// if i is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (i Int32) Compare(other Int32) int {
+	if !i.Valid || !other.Valid {
+		return compareNull(i.Valid, other.Valid)
+	}
+	return cmp.Compare(i.Int32, other.Int32)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (i *Int32) UnmarshalJSON(data []byte) error {
+func (i *Int32) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int32", i, data)
 	i.Set = true
+	data, err = unquoteNumber(data, "null.Int32")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		i.Valid = false
 		i.Int32 = 0
@@ -65,8 +135,8 @@ func (i *Int32) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if x > math.MaxInt32 {
-		return fmt.Errorf("json: %d overflows max int32 value", x)
+	if x < math.MinInt32 || x > math.MaxInt32 {
+		return overflowf("null: %d does not fit in null.Int32", x)
 	}
 
 	i.Int32 = int32(x)
@@ -75,13 +145,13 @@ func (i *Int32) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (i *Int32) UnmarshalText(text []byte) error {
+func (i *Int32) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Int32", text)
 	i.Set = true
 	if len(text) == 0 {
 		i.Valid = false
 		return nil
 	}
-	var err error
 	res, err := strconv.ParseInt(string(text), 10, 32)
 	i.Valid = err == nil
 	if i.Valid {
@@ -95,7 +165,16 @@ func (i Int32) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int32), 10)), nil
+	return i.AppendJSON(make([]byte, 0, 11))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (i Int32) AppendJSON(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendInt(b, int64(i.Int32), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -103,7 +182,16 @@ func (i Int32) MarshalText() ([]byte, error) {
 	if !i.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int32), 10)), nil
+	return i.AppendText(make([]byte, 0, 11))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if i is null.
+func (i Int32) AppendText(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(i.Int32), 10), nil
 }
 
 // SetValid changes this Int32's value and also sets it to be non-null.
@@ -113,6 +201,22 @@ func (i *Int32) SetValid(n int32) {
 	i.Set = true
 }
 
+// SetNull sets this Int32 to an explicit null, zeroing its value.
+func (i *Int32) SetNull() {
+	*i = Int32{Set: true}
+}
+
+// Unset resets this Int32 to the zero value, as if it was never set.
+func (i *Int32) Unset() {
+	*i = Int32{}
+}
+
+// SetPtr changes this Int32 to the value p points to, or to an explicit
+// null if p is nil.
+func (i *Int32) SetPtr(p *int32) {
+	*i = Int32FromPtr(p)
+}
+
 // Ptr returns a pointer to this Int32's value, or a nil pointer if this Int32 is null.
 func (i Int32) Ptr() *int32 {
 	if !i.Valid {
@@ -121,19 +225,284 @@ func (i Int32) Ptr() *int32 {
 	return &i.Int32
 }
 
-// IsZero returns true for invalid Int32's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (i Int32) ValueOrZero() int32 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int32
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (i Int32) Or(def int32) int32 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int32
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (i Int32) Val() int32 {
+	return i.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (i Int32) Get() (int32, bool) {
+	return i.ValueOrZero(), i.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if i is
+// null.
+func (i Int32) MustGet() int32 {
+	if !i.Valid {
+		panic(&NullError{Type: "null.Int32"})
+	}
+	return i.Int32
+}
+
+// IsZero returns true if this Int32 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (i Int32) IsZero() bool {
-	return !i.Valid
+	return !i.Set && !i.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int32 is null.
+func (i Int32) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int32, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int32 if the input is null or ~.
+func (i *Int32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	i.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		i.Int32, i.Valid = 0, false
+		return err
+	}
+
+	var v int32
+	if err := unmarshal(&v); err != nil {
+		i.Int32, i.Valid = 0, false
+		return err
+	}
+	i.Int32, i.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int32 is null.
+func (i Int32) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Int32.
+func (i *Int32) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, i.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int32 is null.
+func (i Int32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int32.
+func (i *Int32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 32-bit integer, or BSON null if this Int32 is null.
+func (i Int32) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt32Value(i.Int32)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON integer, or a double with no fractional part, that
+// fits in an int32.
+func (i *Int32) UnmarshalBSONValue(typ byte, data []byte) error {
+	i.Set = true
+	if bsonIsNull(typ) {
+		i.Int32, i.Valid = 0, false
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.Int32", math.MinInt32, math.MaxInt32)
+	i.Int32, i.Valid = int32(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Int32 is null.
+func (i Int32) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, int64(i.Int32)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack integer, or a float with no fractional part, that
+// fits in an int32.
+func (i *Int32) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		i.Int32, i.Valid = 0, false
+		return err
+	}
+	n, err := msgpackInt(v, "null.Int32", math.MinInt32, math.MaxInt32)
+	i.Int32, i.Valid = int32(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Int32 is null.
+func (i Int32) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, int64(i.Int32)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer, or a float with no fractional part, that
+// fits in an int32.
+func (i *Int32) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		i.Int32, i.Valid = 0, false
+		return err
+	}
+	n, err := cborInt(v, "null.Int32", math.MinInt32, math.MaxInt32)
+	i.Int32, i.Valid = int32(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (i Int32) MarshalGQL(w io.Writer) {
+	marshalGQL(w, i.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int32.
+func (i *Int32) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, i.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Int32 is
+// null.
+func (i Int32) MarshalCSV() (string, error) {
+	return marshalCSV(i.Valid, i.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Int32.
+func (i *Int32) UnmarshalCSV(cell string) error {
+	return i.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Int32, while an absent one is never passed, leaving
+// the field unset.
+func (i *Int32) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int32 is null.
+func (i Int32) MarshalTOML() ([]byte, error) {
+	return marshalTOML(i.Valid, i.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Int32.
+func (i *Int32) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, i.UnmarshalText, i.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative number, within the MySQL MEDIUMINT range
+// if fieldType is "mediumint", or an explicit null if shouldBeNull is true.
+func (i *Int32) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = NewInt32(0, false)
+		return
+	}
+	limit := int64(math.MaxInt32)
+	if fieldType == "mediumint" {
+		limit = 1<<23 - 1
+	}
+	i.SetValid(int32(randomN(nextInt, limit)))
+}
+
+// Generate implements testing/quick's Generator.
+func (i Int32) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt32(0, false), func() Int32 {
+		return Int32From(int32(generateInt64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (i Int32) MarshalBinary() ([]byte, error) {
+	return marshalBinary(i.Set, i.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(i.Int32)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int32) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int32")
+	if err != nil {
+		return err
+	}
+	var v int32
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Int32", math.MinInt32, math.MaxInt32); err != nil {
+			return err
+		}
+		v = int32(n)
+	}
+	i.Int32, i.Valid, i.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (i *Int32) Scan(value interface{}) error {
+func (i *Int32) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int32", value)
 	if value == nil {
-		i.Int32, i.Valid, i.Set = 0, false, false
+		i.Int32, i.Valid, i.Set = 0, false, true
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int32, value)
+	n, err := scanInt(value, "null.Int32", 32)
+	i.Int32, i.Valid, i.Set = int32(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -144,10 +513,34 @@ func (i Int32) Value() (driver.Value, error) {
 	return int64(i.Int32), nil
 }
 
-// String implements the Stringer interface
+// ToNullInt32 converts i to a sql.NullInt32, dropping Set.
+func (i Int32) ToNullInt32() sql.NullInt32 {
+	return sql.NullInt32{Int32: i.Int32, Valid: i.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Int32) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Int32(%d)", i.Int32)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting i as the Go
+// expression that builds it.
+func (i Int32) GoString() string {
+	return goString("Int32", "", "Int32", strconv.FormatInt(int64(i.Int32), 10), i.Int32 == 0, i.Valid, i.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// i with the verb if it is valid.
+func (i Int32) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, i, i.Valid, i.Set, i.Int32)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Int64 value, or to nil if this Int32 is null.
+func (i Int32) LogValue() slog.Value {
+	if !i.Valid {
+		return logNull
 	}
-	return "Int32(invalid)"
+	return slog.Int64Value(int64(i.Int32))
 }
@@ -2,14 +2,28 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
+// Int64JSONString makes Int64 and Uint64 marshal to a JSON string, such as
+// "9007199254740993", rather than a JSON number, as protobuf does for its
+// 64-bit integers. JavaScript parses numbers beyond 2^53 inexactly, which
+// mangles IDs. UnmarshalJSON accepts both forms either way. MarshalGQL
+// follows MarshalJSON, while the other encodings, Scan and Value are not
+// affected.
+var Int64JSONString = false
+
 // Int64 is an nullable int64.
 type Int64 struct {
 	Int64 int64
@@ -39,6 +53,34 @@ func Int64FromPtr(i *int64) Int64 {
 	return NewInt64(*i, true)
 }
 
+// Int64FromNonZero creates a new Int64 that will be null if i is 0.
+func Int64FromNonZero(i int64) Int64 {
+	return NewInt64(i, i != 0)
+}
+
+// Int64FromString parses s with the rules of UnmarshalText into a new Int64.
+// An empty string produces a null Int64.
+func Int64FromString(s string) (Int64, error) {
+	var i Int64
+	err := i.UnmarshalText([]byte(s))
+	return i, err
+}
+
+// MustInt64FromString is like Int64FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustInt64FromString(s string) Int64 {
+	i, err := Int64FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// Int64FromNullInt64 creates a new Int64 from a sql.NullInt64. It is always Set.
+func Int64FromNullInt64(n sql.NullInt64) Int64 {
+	return NewInt64(n.Int64, n.Valid)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int64) IsValid() bool {
@@ -50,15 +92,54 @@ func (i Int64) IsSet() bool {
 	return i.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (i Int64) IsNull() bool {
+	return i.Set && !i.Valid
+}
+
+// Equal reports whether i and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (i Int64) Equal(other Int64) bool {
+	if !i.Valid || !other.Valid {
+		return i.Valid == other.Valid
+	}
+	return i.Int64 == other.Int64
+}
+
+// ExactEqual is like Equal, but also requires i and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (i Int64) ExactEqual(other Int64) bool {
+	return i.Set == other.Set && i.Equal(other)
+}
+
+// Compare returns -1 if i is less than other, 0 if they are equal and +1
+// if i is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (i Int64) Compare(other Int64) int {
+	if !i.Valid || !other.Valid {
+		return compareNull(i.Valid, other.Valid)
+	}
+	return cmp.Compare(i.Int64, other.Int64)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (i *Int64) UnmarshalJSON(data []byte) error {
+func (i *Int64) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int64", i, data)
 	i.Set = true
+	data, err = unquoteNumber(data, "null.Int64")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		i.Valid = false
 		i.Int64 = 0
 		return nil
 	}
 
+	// Decoding straight into an int64 parses the literal with strconv, so
+	// values beyond 2^53 never pass through a float64.
 	if err := json.Unmarshal(data, &i.Int64); err != nil {
 		return err
 	}
@@ -68,24 +149,38 @@ func (i *Int64) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (i *Int64) UnmarshalText(text []byte) error {
+func (i *Int64) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Int64", text)
 	i.Set = true
 	if len(text) == 0 {
 		i.Valid = false
 		return nil
 	}
-	var err error
 	i.Int64, err = strconv.ParseInt(string(text), 10, 64)
 	i.Valid = err == nil
 	return err
 }
 
 // MarshalJSON implements json.Marshaler.
+// It encodes a JSON number, or a string with Int64JSONString.
 func (i Int64) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatInt(i.Int64, 10)), nil
+	return i.AppendJSON(make([]byte, 0, 22))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (i Int64) AppendJSON(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return append(b, "null"...), nil
+	}
+	if Int64JSONString {
+		b = append(b, '"')
+		return append(strconv.AppendInt(b, i.Int64, 10), '"'), nil
+	}
+	return strconv.AppendInt(b, i.Int64, 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -93,7 +188,16 @@ func (i Int64) MarshalText() ([]byte, error) {
 	if !i.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatInt(i.Int64, 10)), nil
+	return i.AppendText(make([]byte, 0, 20))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if i is null.
+func (i Int64) AppendText(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, i.Int64, 10), nil
 }
 
 // SetValid changes this Int64's value and also sets it to be non-null.
@@ -103,6 +207,22 @@ func (i *Int64) SetValid(n int64) {
 	i.Set = true
 }
 
+// SetNull sets this Int64 to an explicit null, zeroing its value.
+func (i *Int64) SetNull() {
+	*i = Int64{Set: true}
+}
+
+// Unset resets this Int64 to the zero value, as if it was never set.
+func (i *Int64) Unset() {
+	*i = Int64{}
+}
+
+// SetPtr changes this Int64 to the value p points to, or to an explicit
+// null if p is nil.
+func (i *Int64) SetPtr(p *int64) {
+	*i = Int64FromPtr(p)
+}
+
 // Ptr returns a pointer to this Int64's value, or a nil pointer if this Int64 is null.
 func (i Int64) Ptr() *int64 {
 	if !i.Valid {
@@ -111,19 +231,308 @@ func (i Int64) Ptr() *int64 {
 	return &i.Int64
 }
 
-// IsZero returns true for invalid Int64's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (i Int64) ValueOrZero() int64 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int64
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (i Int64) Or(def int64) int64 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int64
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (i Int64) Val() int64 {
+	return i.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (i Int64) Get() (int64, bool) {
+	return i.ValueOrZero(), i.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if i is
+// null.
+func (i Int64) MustGet() int64 {
+	if !i.Valid {
+		panic(&NullError{Type: "null.Int64"})
+	}
+	return i.Int64
+}
+
+// IsZero returns true if this Int64 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (i Int64) IsZero() bool {
-	return !i.Valid
+	return !i.Set && !i.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int64 is null.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int64 if the input is null or ~.
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	i.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		i.Int64, i.Valid = 0, false
+		return err
+	}
+
+	var v int64
+	if err := unmarshal(&v); err != nil {
+		i.Int64, i.Valid = 0, false
+		return err
+	}
+	i.Int64, i.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int64 is null.
+func (i Int64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Int64.
+func (i *Int64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, i.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int64 is null.
+func (i Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int64.
+func (i *Int64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 64-bit integer, or BSON null if this Int64 is null.
+func (i Int64) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt64Value(i.Int64)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON integer, or a double with no fractional part, that
+// fits in an int64.
+func (i *Int64) UnmarshalBSONValue(typ byte, data []byte) error {
+	i.Set = true
+	if bsonIsNull(typ) {
+		i.Int64, i.Valid = 0, false
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.Int64", math.MinInt64, math.MaxInt64)
+	i.Int64, i.Valid = int64(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Int64 is null.
+func (i Int64) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, i.Int64), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack integer, or a float with no fractional part, that
+// fits in an int64.
+func (i *Int64) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		i.Int64, i.Valid = 0, false
+		return err
+	}
+	n, err := msgpackInt(v, "null.Int64", math.MinInt64, math.MaxInt64)
+	i.Int64, i.Valid = int64(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Int64 is null.
+func (i Int64) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, i.Int64), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer, or a float with no fractional part, that
+// fits in an int64.
+func (i *Int64) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		i.Int64, i.Valid = 0, false
+		return err
+	}
+	n, err := cborInt(v, "null.Int64", math.MinInt64, math.MaxInt64)
+	i.Int64, i.Valid = int64(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (i Int64) MarshalGQL(w io.Writer) {
+	marshalGQL(w, i.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int64.
+func (i *Int64) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, i.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Int64 is
+// null.
+func (i Int64) MarshalCSV() (string, error) {
+	return marshalCSV(i.Valid, i.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Int64.
+func (i *Int64) UnmarshalCSV(cell string) error {
+	return i.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Int64, while an absent one is never passed, leaving
+// the field unset.
+func (i *Int64) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int64 is null.
+func (i Int64) MarshalTOML() ([]byte, error) {
+	return marshalTOML(i.Valid, i.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Int64.
+func (i *Int64) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, i.UnmarshalText, i.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes an INT64, or a typed NULL if this Int64 is null.
+func (i Int64) EncodeSpanner() (interface{}, error) {
+	if !i.Valid {
+		return (*int64)(nil), nil
+	}
+	return i.Int64, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts an INT64, which Spanner sends as a decimal string, so no
+// precision is lost. NULL decodes to a null Int64.
+func (i *Int64) DecodeSpanner(input interface{}) error {
+	i.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		i.Int64, i.Valid = 0, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.Int64")
+	}
+	n, err := strconv.ParseInt(str, 10, 64)
+	i.Int64, i.Valid = n, err == nil
+	if err != nil {
+		return fmt.Errorf("null: cannot decode Spanner INT64 %q into null.Int64: %w", str, err)
+	}
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative number, or an explicit null if shouldBeNull is true.
+func (i *Int64) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = NewInt64(0, false)
+		return
+	}
+	i.SetValid(randomN(nextInt, math.MaxInt64))
+}
+
+// Generate implements testing/quick's Generator.
+func (i Int64) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt64(0, false), func() Int64 {
+		return Int64From(generateInt64(rand))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (i Int64) MarshalBinary() ([]byte, error) {
+	return marshalBinary(i.Set, i.Valid, func() ([]byte, error) {
+		return appendVarint(nil, i.Int64), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int64) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int64")
+	if err != nil {
+		return err
+	}
+	var v int64
+	if valid {
+		if v, err = binaryVarint(p, "null.Int64", math.MinInt64, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+	i.Int64, i.Valid, i.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (i *Int64) Scan(value interface{}) error {
+func (i *Int64) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int64", value)
 	if value == nil {
-		i.Int64, i.Valid, i.Set = 0, false, false
+		i.Int64, i.Valid, i.Set = 0, false, true
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int64, value)
+	n, err := scanInt(value, "null.Int64", 64)
+	i.Int64, i.Valid, i.Set = n, err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -134,10 +543,34 @@ func (i Int64) Value() (driver.Value, error) {
 	return i.Int64, nil
 }
 
-// String implements the Stringer interface
+// ToNullInt64 converts i to a sql.NullInt64, dropping Set.
+func (i Int64) ToNullInt64() sql.NullInt64 {
+	return sql.NullInt64{Int64: i.Int64, Valid: i.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Int64) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Int64(%d)", i.Int64)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting i as the Go
+// expression that builds it.
+func (i Int64) GoString() string {
+	return goString("Int64", "", "Int64", strconv.FormatInt(i.Int64, 10), i.Int64 == 0, i.Valid, i.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// i with the verb if it is valid.
+func (i Int64) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, i, i.Valid, i.Set, i.Int64)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Int64 value, or to nil if this Int64 is null.
+func (i Int64) LogValue() slog.Value {
+	if !i.Valid {
+		return logNull
 	}
-	return "Int64(invalid)"
+	return slog.Int64Value(i.Int64)
 }
@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 	"testing"
@@ -54,7 +55,8 @@ func TestUnmarshalInt64(t *testing.T) {
 
 	var invalid Int64
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullInt64(t, invalid, "invalid json")
@@ -143,7 +145,12 @@ func TestInt64IsZero(t *testing.T) {
 	}
 
 	null := NewInt64(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Int64
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -170,6 +177,9 @@ func TestInt64Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullInt64(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertInt64(t *testing.T, i Int64, from string) {
@@ -186,3 +196,73 @@ func assertNullInt64(t *testing.T, i Int64, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestUnmarshalInt64Precision(t *testing.T) {
+	for _, v := range []int64{1<<53 + 1, -(1<<53 + 1), math.MaxInt64, math.MinInt64} {
+		var i Int64
+		err := json.Unmarshal([]byte(strconv.FormatInt(v, 10)), &i)
+		maybePanic(err)
+		if !i.Valid || i.Int64 != v {
+			t.Errorf("bad unmarshal of %d: %d", v, i.Int64)
+		}
+
+		data, err := json.Marshal(i)
+		maybePanic(err)
+		assertJSONEquals(t, data, strconv.FormatInt(v, 10), "marshal of unmarshaled value")
+	}
+
+	for _, in := range []string{"12345678901234567890", "-12345678901234567890"} {
+		var i Int64
+		if err := json.Unmarshal([]byte(in), &i); err == nil {
+			t.Errorf("expected overflow error for %s", in)
+		}
+		assertNullInt64(t, i, "overflowing json")
+	}
+}
+
+func TestInt64JSONString(t *testing.T) {
+	Int64JSONString = true
+	defer func() { Int64JSONString = false }()
+
+	for _, test := range []struct {
+		v    json.Marshaler
+		back json.Unmarshaler
+		want string
+	}{
+		{Int64From(math.MaxInt64), new(Int64), `"9223372036854775807"`},
+		{Int64From(math.MinInt64), new(Int64), `"-9223372036854775808"`},
+		{Uint64From(math.MaxUint64), new(Uint64), `"18446744073709551615"`},
+		{NewInt64(0, false), new(Int64), `null`},
+	} {
+		data, err := json.Marshal(test.v)
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("Int64JSONString marshal = %s, want %s", data, test.want)
+		}
+		err = json.Unmarshal(data, test.back)
+		maybePanic(err)
+		again, err := json.Marshal(test.back)
+		maybePanic(err)
+		if string(again) != test.want {
+			t.Errorf("Int64JSONString round trip = %s, want %s", again, test.want)
+		}
+	}
+
+	// Bare numbers are still accepted, and the text form is unchanged.
+	var i Int64
+	err := json.Unmarshal([]byte(`9223372036854775807`), &i)
+	maybePanic(err)
+	if i.Int64 != math.MaxInt64 {
+		t.Errorf("bare number = %d", i.Int64)
+	}
+	text, err := i.MarshalText()
+	maybePanic(err)
+	if string(text) != "9223372036854775807" {
+		t.Errorf("MarshalText() = %s", text)
+	}
+	v, err := i.Value()
+	maybePanic(err)
+	if v != int64(math.MaxInt64) {
+		t.Errorf("Value() = %#v", v)
+	}
+}
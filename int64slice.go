@@ -0,0 +1,542 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Int64Slice is a nullable []int64, such as a Postgres bigint[] column.
+// It scans and values the Postgres array literal format. NULL array
+// elements cannot be represented and are an error.
+type Int64Slice struct {
+	Int64Slice []int64
+	Valid      bool
+	Set        bool
+}
+
+// NewInt64Slice creates a new Int64Slice. It shares s with the caller
+// instead of copying it; see Clone.
+func NewInt64Slice(s []int64, valid bool) Int64Slice {
+	return Int64Slice{
+		Int64Slice: s,
+		Valid:      valid,
+		Set:        true,
+	}
+}
+
+// Int64SliceFrom creates a new Int64Slice that will always be valid.
+// A nil slice is valid and marshals to an empty array.
+func Int64SliceFrom(s []int64) Int64Slice {
+	return NewInt64Slice(s, true)
+}
+
+// Int64SliceFromPtr creates a new Int64Slice that will be null if s is nil.
+func Int64SliceFromPtr(s *[]int64) Int64Slice {
+	if s == nil {
+		return NewInt64Slice(nil, false)
+	}
+	return NewInt64Slice(*s, true)
+}
+
+// Int64SliceFromNonEmpty creates a new Int64Slice that will be null if s is empty.
+func Int64SliceFromNonEmpty(s []int64) Int64Slice {
+	return NewInt64Slice(s, len(s) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (s Int64Slice) IsValid() bool {
+	return s.Set && s.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (s Int64Slice) IsSet() bool {
+	return s.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (s Int64Slice) IsNull() bool {
+	return s.Set && !s.Valid
+}
+
+// Equal reports whether s and other are both null, or both valid with
+// the same elements. Set is ignored; see ExactEqual.
+func (s Int64Slice) Equal(other Int64Slice) bool {
+	if !s.Valid || !other.Valid {
+		return s.Valid == other.Valid
+	}
+	return slicesEqual(s.Int64Slice, other.Int64Slice)
+}
+
+// ExactEqual is like Equal, but also requires s and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (s Int64Slice) ExactEqual(other Int64Slice) bool {
+	return s.Set == other.Set && s.Equal(other)
+}
+
+// Clone returns a copy of s with its own copy of the slice, so that
+// changes to either do not show in the other.
+func (s Int64Slice) Clone() Int64Slice {
+	s.Int64Slice = slices.Clone(s.Int64Slice)
+	return s
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and arrays of numbers.
+func (s *Int64Slice) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int64Slice", s, data)
+	s.Set = true
+	if bytes.Equal(data, NullBytes) {
+		s.Int64Slice, s.Valid = nil, false
+		return nil
+	}
+
+	res := []int64{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		s.Int64Slice, s.Valid = nil, false
+		return fmt.Errorf("null: cannot unmarshal %s into null.Int64Slice: %v", data, err)
+	}
+	s.Int64Slice, s.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Int64Slice is null.
+func (s Int64Slice) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return NullBytes, nil
+	}
+	if s.Int64Slice == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.Int64Slice)
+}
+
+// SetValid changes this Int64Slice's value and also sets it to be non-null.
+func (s *Int64Slice) SetValid(v []int64) {
+	s.Int64Slice = v
+	s.Valid = true
+	s.Set = true
+}
+
+// SetNull sets this Int64Slice to an explicit null, zeroing its value.
+func (s *Int64Slice) SetNull() {
+	*s = Int64Slice{Set: true}
+}
+
+// Unset resets this Int64Slice to the zero value, as if it was never set.
+func (s *Int64Slice) Unset() {
+	*s = Int64Slice{}
+}
+
+// SetPtr changes this Int64Slice to the value p points to, or to an explicit
+// null if p is nil.
+func (s *Int64Slice) SetPtr(p *[]int64) {
+	*s = Int64SliceFromPtr(p)
+}
+
+// Ptr returns a pointer to this Int64Slice's value, or a nil pointer if this Int64Slice is null.
+func (s Int64Slice) Ptr() *[]int64 {
+	if !s.Valid {
+		return nil
+	}
+	return &s.Int64Slice
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (s Int64Slice) ValueOrZero() []int64 {
+	if !s.Valid {
+		return nil
+	}
+	return s.Int64Slice
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (s Int64Slice) Or(def []int64) []int64 {
+	if !s.Valid {
+		return def
+	}
+	return s.Int64Slice
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (s Int64Slice) Val() []int64 {
+	return s.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (s Int64Slice) Get() ([]int64, bool) {
+	return s.ValueOrZero(), s.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if s is
+// null.
+func (s Int64Slice) MustGet() []int64 {
+	if !s.Valid {
+		panic(&NullError{Type: "null.Int64Slice"})
+	}
+	return s.Int64Slice
+}
+
+// IsZero returns true if this Int64Slice was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (s Int64Slice) IsZero() bool {
+	return !s.Set && !s.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int64Slice is null.
+func (s Int64Slice) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	if s.Int64Slice == nil {
+		return []int64{}, nil
+	}
+	return s.Int64Slice, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int64Slice if the input is null or ~.
+func (s *Int64Slice) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	s.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+
+	res := []int64{}
+	if err := unmarshal(&res); err != nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	s.Int64Slice, s.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int64Slice is null.
+func (s Int64Slice) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null Int64Slice.
+func (s *Int64Slice) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(s.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int64Slice is null.
+func (s Int64Slice) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int64Slice.
+func (s *Int64Slice) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(s.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON array of 64-bit integers, or BSON null if this
+// Int64Slice is null.
+func (s Int64Slice) MarshalBSONValue() (byte, []byte, error) {
+	if !s.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonArrayValue(len(s.Int64Slice), func(i int) (byte, []byte, error) {
+		return bsonInt64Value(s.Int64Slice[i])
+	})
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON array, decoded as UnmarshalJSON decodes JSON.
+func (s *Int64Slice) UnmarshalBSONValue(typ byte, data []byte) error {
+	s.Set = true
+	if bsonIsNull(typ) {
+		s.Int64Slice, s.Valid = nil, false
+		return nil
+	}
+	if typ != bsonArray {
+		s.Int64Slice, s.Valid = nil, false
+		return bsonTypeError(typ, "null.Int64Slice")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack array of integers, or nil if this Int64Slice is
+// null.
+func (s Int64Slice) MarshalMsgpack() ([]byte, error) {
+	if !s.Valid {
+		return msgpackNull, nil
+	}
+	res := appendMsgpackHeader(nil, false, len(s.Int64Slice))
+	for _, e := range s.Int64Slice {
+		res = appendMsgpackInt(res, e)
+	}
+	return res, nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack array, decoded as UnmarshalJSON decodes JSON.
+func (s *Int64Slice) UnmarshalMsgpack(data []byte) error {
+	s.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.Int64Slice, s.Valid = nil, false
+		return msgpackTypeError(v, "null.Int64Slice")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR array of integers, or null if this Int64Slice is null.
+func (s Int64Slice) MarshalCBOR() ([]byte, error) {
+	if !s.Valid {
+		return cborNull, nil
+	}
+	res := appendCBORHead(nil, cborMajorArray, uint64(len(s.Int64Slice)))
+	for _, e := range s.Int64Slice {
+		res = appendCBORInt(res, e)
+	}
+	return res, nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR array, decoded as UnmarshalJSON decodes JSON.
+func (s *Int64Slice) UnmarshalCBOR(data []byte) error {
+	s.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.Int64Slice, s.Valid = nil, false
+		return cborTypeError(v, "null.Int64Slice")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		s.Int64Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (s Int64Slice) MarshalGQL(w io.Writer) {
+	marshalGQL(w, s.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int64Slice.
+func (s *Int64Slice) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, s.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this Int64Slice is
+// null.
+func (s Int64Slice) MarshalCSV() (string, error) {
+	return marshalCSV(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null Int64Slice.
+func (s *Int64Slice) UnmarshalCSV(cell string) error {
+	return jsonText(s.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int64Slice is null.
+func (s Int64Slice) MarshalTOML() ([]byte, error) {
+	return marshalTOML(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null Int64Slice.
+func (s *Int64Slice) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, s.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a list of one random number, or an explicit null if shouldBeNull is true.
+func (s *Int64Slice) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*s = NewInt64Slice(nil, false)
+		return
+	}
+	s.SetValid([]int64{randomN(nextInt, math.MaxInt64)})
+}
+
+// Generate implements testing/quick's Generator.
+func (s Int64Slice) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt64Slice(nil, false), func() Int64Slice {
+		s := make([]int64, generateLen(rand, size))
+		for i := range s {
+			s[i] = generateInt64(rand)
+		}
+		return Int64SliceFrom(s)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (s Int64Slice) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.Set, s.Valid, func() ([]byte, error) {
+		return appendBinaryInt64s(nil, s.Int64Slice), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Int64Slice) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int64Slice")
+	if err != nil {
+		return err
+	}
+	var v []int64
+	if valid {
+		if v, err = binaryInt64s(p, "null.Int64Slice"); err != nil {
+			return err
+		}
+	}
+	s.Int64Slice, s.Valid, s.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It parses a one-dimensional Postgres array literal such as {1,2,3}.
+func (s *Int64Slice) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int64Slice", value)
+	var res []int64
+	switch x := value.(type) {
+	case nil:
+		s.Int64Slice, s.Valid, s.Set = nil, false, true
+		return nil
+	case string:
+		res, err = parseInt64Array(x)
+	case []byte:
+		res, err = parseInt64Array(string(x))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Int64Slice: %v", value, value)
+	}
+	s.Set = true
+	s.Valid = err == nil
+	s.Int64Slice = res
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The slice is encoded as a Postgres array literal.
+func (s Int64Slice) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	b := []byte{'{'}
+	for i, e := range s.Int64Slice {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendInt(b, e, 10)
+	}
+	b = append(b, '}')
+	return string(b), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (s Int64Slice) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it.
+func (s Int64Slice) GoString() string {
+	return goString("Int64Slice", "", "Int64Slice", fmt.Sprintf("%#v", s.Int64Slice), s.Int64Slice == nil, s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// s with the verb if it is valid.
+func (s Int64Slice) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, s.Int64Slice)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the slice, or to nil if this Int64Slice is
+// null.
+func (s Int64Slice) LogValue() slog.Value {
+	if !s.Valid {
+		return logNull
+	}
+	return slog.AnyValue(s.Int64Slice)
+}
+
+// parseInt64Array parses a one-dimensional Postgres array literal of integers.
+func parseInt64Array(src string) ([]int64, error) {
+	s := src
+	if strings.HasPrefix(s, "[") {
+		// Skip explicit bounds, as in "[0:1]={1,2}".
+		if i := strings.Index(s, "="); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("null: cannot parse %q into null.Int64Slice: not an array literal", src)
+	}
+
+	res := []int64{}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return res, nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if strings.EqualFold(f, "NULL") {
+			return nil, nullValuef("null: cannot parse %q into null.Int64Slice: NULL elements are not supported", src)
+		}
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("null: cannot parse %q into null.Int64Slice: %v", src, err)
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
@@ -0,0 +1,177 @@
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	int64SliceValue = []int64{1, -2, 9223372036854775807}
+	int64SliceJSON  = []byte(`[1,-2,9223372036854775807]`)
+)
+
+func TestInt64SliceFrom(t *testing.T) {
+	s := Int64SliceFrom(int64SliceValue)
+	assertInt64Slice(t, s, "Int64SliceFrom()")
+
+	nilSlice := Int64SliceFrom(nil)
+	if !nilSlice.Valid {
+		t.Error("Int64SliceFrom(nil)", "is invalid, but should be valid")
+	}
+}
+
+func TestInt64SliceFromPtr(t *testing.T) {
+	v := int64SliceValue
+	s := Int64SliceFromPtr(&v)
+	assertInt64Slice(t, s, "Int64SliceFromPtr()")
+
+	null := Int64SliceFromPtr(nil)
+	assertNullInt64Slice(t, null, "Int64SliceFromPtr(nil)")
+}
+
+func TestUnmarshalInt64Slice(t *testing.T) {
+	var s Int64Slice
+	err := json.Unmarshal(int64SliceJSON, &s)
+	maybePanic(err)
+	assertInt64Slice(t, s, "int64 slice json")
+
+	var empty Int64Slice
+	err = json.Unmarshal([]byte(`[]`), &empty)
+	maybePanic(err)
+	if !empty.Valid || empty.Int64Slice == nil || len(empty.Int64Slice) != 0 {
+		t.Errorf("bad empty slice: %#v", empty)
+	}
+
+	var null Int64Slice
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullInt64Slice(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	for _, in := range []string{`["1","2"]`, `[1.5]`, `{}`, `"1"`} {
+		var bad Int64Slice
+		err = json.Unmarshal([]byte(in), &bad)
+		if err == nil || !strings.Contains(err.Error(), "null.Int64Slice") {
+			t.Errorf("Unmarshal(%s) should fail with a clear error, got %v", in, err)
+		}
+		assertNullInt64Slice(t, bad, "bad json")
+	}
+}
+
+func TestMarshalInt64Slice(t *testing.T) {
+	tests := []struct {
+		in   Int64Slice
+		want string
+	}{
+		{Int64SliceFrom(int64SliceValue), string(int64SliceJSON)},
+		{Int64SliceFrom(nil), "[]"},
+		{Int64SliceFrom([]int64{}), "[]"},
+		{NewInt64Slice(nil, false), "null"},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		assertJSONEquals(t, data, test.want, test.in.String())
+	}
+}
+
+func TestInt64SliceScanValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []int64
+	}{
+		{`{1,-2,9223372036854775807}`, int64SliceValue},
+		{`{}`, []int64{}},
+		{`{ 1 , 2 }`, []int64{1, 2}},
+		{`[1:2]={3,4}`, []int64{3, 4}},
+	}
+	for _, test := range tests {
+		for _, v := range []interface{}{test.in, []byte(test.in)} {
+			var s Int64Slice
+			err := s.Scan(v)
+			maybePanic(err)
+			if !s.Valid || !reflect.DeepEqual(s.Int64Slice, test.want) {
+				t.Errorf("Scan(%#v) = %#v, want %#v", v, s.Int64Slice, test.want)
+			}
+		}
+	}
+
+	s := Int64SliceFrom(int64SliceValue)
+	value, err := s.Value()
+	maybePanic(err)
+	if value != "{1,-2,9223372036854775807}" {
+		t.Errorf("bad value: %v", value)
+	}
+	empty := Int64SliceFrom(nil)
+	if value, _ := empty.Value(); value != "{}" {
+		t.Errorf("bad empty value: %v", value)
+	}
+
+	var null Int64Slice
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullInt64Slice(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"1,2", "{1,NULL}", "{9223372036854775808}", "{1,,2}", "{a}", "{{1},{2}}", int64(1)} {
+		var wrong Int64Slice
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullInt64Slice(t, wrong, "scanned garbage")
+	}
+}
+
+func TestInt64SlicePointer(t *testing.T) {
+	s := Int64SliceFrom(int64SliceValue)
+	ptr := s.Ptr()
+	if !reflect.DeepEqual(*ptr, int64SliceValue) {
+		t.Errorf("bad %s int64 slice: %#v ≠ %v\n", "pointer", ptr, int64SliceValue)
+	}
+
+	null := NewInt64Slice(nil, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s int64 slice: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestInt64SliceSetValid(t *testing.T) {
+	change := NewInt64Slice(nil, false)
+	assertNullInt64Slice(t, change, "SetValid()")
+	change.SetValid(int64SliceValue)
+	assertInt64Slice(t, change, "SetValid()")
+}
+
+func assertInt64Slice(t *testing.T, s Int64Slice, from string) {
+	if !reflect.DeepEqual(s.Int64Slice, int64SliceValue) {
+		t.Errorf("bad %s int64 slice: %#v ≠ %#v\n", from, s.Int64Slice, int64SliceValue)
+	}
+	if !s.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullInt64Slice(t *testing.T, s Int64Slice, from string) {
+	if s.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestInt64SliceClone(t *testing.T) {
+	s := Int64SliceFrom([]int64{1, 2})
+	clone := s.Clone()
+	s.Int64Slice[0] = 9
+	if !clone.Equal(Int64SliceFrom([]int64{1, 2})) || !clone.Set {
+		t.Errorf("Clone() after mutating original = %#v", clone)
+	}
+}
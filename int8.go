@@ -2,13 +2,17 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Int8 is an nullable int8.
@@ -40,6 +44,29 @@ func Int8FromPtr(i *int8) Int8 {
 	return NewInt8(*i, true)
 }
 
+// Int8FromNonZero creates a new Int8 that will be null if i is 0.
+func Int8FromNonZero(i int8) Int8 {
+	return NewInt8(i, i != 0)
+}
+
+// Int8FromString parses s with the rules of UnmarshalText into a new Int8.
+// An empty string produces a null Int8.
+func Int8FromString(s string) (Int8, error) {
+	var i Int8
+	err := i.UnmarshalText([]byte(s))
+	return i, err
+}
+
+// MustInt8FromString is like Int8FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustInt8FromString(s string) Int8 {
+	i, err := Int8FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int8) IsValid() bool {
@@ -51,9 +78,46 @@ func (i Int8) IsSet() bool {
 	return i.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (i Int8) IsNull() bool {
+	return i.Set && !i.Valid
+}
+
+// Equal reports whether i and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (i Int8) Equal(other Int8) bool {
+	if !i.Valid || !other.Valid {
+		return i.Valid == other.Valid
+	}
+	return i.Int8 == other.Int8
+}
+
+// ExactEqual is like Equal, but also requires i and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (i Int8) ExactEqual(other Int8) bool {
+	return i.Set == other.Set && i.Equal(other)
+}
+
+// Compare returns -1 if i is less than other, 0 if they are equal and +1
+// if i is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (i Int8) Compare(other Int8) int {
+	if !i.Valid || !other.Valid {
+		return compareNull(i.Valid, other.Valid)
+	}
+	return cmp.Compare(i.Int8, other.Int8)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (i *Int8) UnmarshalJSON(data []byte) error {
+func (i *Int8) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Int8", i, data)
 	i.Set = true
+	data, err = unquoteNumber(data, "null.Int8")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		i.Valid = false
 		i.Int8 = 0
@@ -65,8 +129,8 @@ func (i *Int8) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if x > math.MaxInt8 {
-		return fmt.Errorf("json: %d overflows max int8 value", x)
+	if x < math.MinInt8 || x > math.MaxInt8 {
+		return overflowf("null: %d does not fit in null.Int8", x)
 	}
 
 	i.Int8 = int8(x)
@@ -75,13 +139,13 @@ func (i *Int8) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (i *Int8) UnmarshalText(text []byte) error {
+func (i *Int8) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Int8", text)
 	i.Set = true
 	if len(text) == 0 {
 		i.Valid = false
 		return nil
 	}
-	var err error
 	res, err := strconv.ParseInt(string(text), 10, 8)
 	i.Valid = err == nil
 	if i.Valid {
@@ -95,7 +159,16 @@ func (i Int8) MarshalJSON() ([]byte, error) {
 	if !i.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int8), 10)), nil
+	return i.AppendJSON(make([]byte, 0, 4))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (i Int8) AppendJSON(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendInt(b, int64(i.Int8), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -103,7 +176,16 @@ func (i Int8) MarshalText() ([]byte, error) {
 	if !i.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatInt(int64(i.Int8), 10)), nil
+	return i.AppendText(make([]byte, 0, 4))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if i is null.
+func (i Int8) AppendText(b []byte) ([]byte, error) {
+	if !i.Valid {
+		return b, nil
+	}
+	return strconv.AppendInt(b, int64(i.Int8), 10), nil
 }
 
 // SetValid changes this Int8's value and also sets it to be non-null.
@@ -113,6 +195,22 @@ func (i *Int8) SetValid(n int8) {
 	i.Set = true
 }
 
+// SetNull sets this Int8 to an explicit null, zeroing its value.
+func (i *Int8) SetNull() {
+	*i = Int8{Set: true}
+}
+
+// Unset resets this Int8 to the zero value, as if it was never set.
+func (i *Int8) Unset() {
+	*i = Int8{}
+}
+
+// SetPtr changes this Int8 to the value p points to, or to an explicit
+// null if p is nil.
+func (i *Int8) SetPtr(p *int8) {
+	*i = Int8FromPtr(p)
+}
+
 // Ptr returns a pointer to this Int8's value, or a nil pointer if this Int8 is null.
 func (i Int8) Ptr() *int8 {
 	if !i.Valid {
@@ -121,19 +219,279 @@ func (i Int8) Ptr() *int8 {
 	return &i.Int8
 }
 
-// IsZero returns true for invalid Int8's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (i Int8) ValueOrZero() int8 {
+	if !i.Valid {
+		return 0
+	}
+	return i.Int8
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (i Int8) Or(def int8) int8 {
+	if !i.Valid {
+		return def
+	}
+	return i.Int8
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (i Int8) Val() int8 {
+	return i.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (i Int8) Get() (int8, bool) {
+	return i.ValueOrZero(), i.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if i is
+// null.
+func (i Int8) MustGet() int8 {
+	if !i.Valid {
+		panic(&NullError{Type: "null.Int8"})
+	}
+	return i.Int8
+}
+
+// IsZero returns true if this Int8 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (i Int8) IsZero() bool {
-	return !i.Valid
+	return !i.Set && !i.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Int8 is null.
+func (i Int8) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int8, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Int8 if the input is null or ~.
+func (i *Int8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	i.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		i.Int8, i.Valid = 0, false
+		return err
+	}
+
+	var v int8
+	if err := unmarshal(&v); err != nil {
+		i.Int8, i.Valid = 0, false
+		return err
+	}
+	i.Int8, i.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Int8 is null.
+func (i Int8) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Int8.
+func (i *Int8) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, i.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Int8 is null.
+func (i Int8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, i.Valid, i.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Int8.
+func (i *Int8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 32-bit integer, or BSON null if this Int8 is null.
+func (i Int8) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt32Value(int32(i.Int8))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON integer, or a double with no fractional part, that
+// fits in an int8.
+func (i *Int8) UnmarshalBSONValue(typ byte, data []byte) error {
+	i.Set = true
+	if bsonIsNull(typ) {
+		i.Int8, i.Valid = 0, false
+		return nil
+	}
+	v, err := bsonInt(typ, data, "null.Int8", math.MinInt8, math.MaxInt8)
+	i.Int8, i.Valid = int8(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Int8 is null.
+func (i Int8) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackInt(nil, int64(i.Int8)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack integer, or a float with no fractional part, that
+// fits in an int8.
+func (i *Int8) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		i.Int8, i.Valid = 0, false
+		return err
+	}
+	n, err := msgpackInt(v, "null.Int8", math.MinInt8, math.MaxInt8)
+	i.Int8, i.Valid = int8(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Int8 is null.
+func (i Int8) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return cborNull, nil
+	}
+	return appendCBORInt(nil, int64(i.Int8)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR integer, or a float with no fractional part, that
+// fits in an int8.
+func (i *Int8) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		i.Int8, i.Valid = 0, false
+		return err
+	}
+	n, err := cborInt(v, "null.Int8", math.MinInt8, math.MaxInt8)
+	i.Int8, i.Valid = int8(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (i Int8) MarshalGQL(w io.Writer) {
+	marshalGQL(w, i.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Int8.
+func (i *Int8) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, i.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Int8 is
+// null.
+func (i Int8) MarshalCSV() (string, error) {
+	return marshalCSV(i.Valid, i.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Int8.
+func (i *Int8) UnmarshalCSV(cell string) error {
+	return i.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Int8, while an absent one is never passed, leaving
+// the field unset.
+func (i *Int8) UnmarshalParam(param string) error {
+	return i.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Int8 is null.
+func (i Int8) MarshalTOML() ([]byte, error) {
+	return marshalTOML(i.Valid, i.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Int8.
+func (i *Int8) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, i.UnmarshalText, i.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random non-negative number, or an explicit null if shouldBeNull is true.
+func (i *Int8) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*i = NewInt8(0, false)
+		return
+	}
+	i.SetValid(int8(randomN(nextInt, math.MaxInt8)))
+}
+
+// Generate implements testing/quick's Generator.
+func (i Int8) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewInt8(0, false), func() Int8 {
+		return Int8From(int8(generateInt64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (i Int8) MarshalBinary() ([]byte, error) {
+	return marshalBinary(i.Set, i.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(i.Int8)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int8) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Int8")
+	if err != nil {
+		return err
+	}
+	var v int8
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Int8", math.MinInt8, math.MaxInt8); err != nil {
+			return err
+		}
+		v = int8(n)
+	}
+	i.Int8, i.Valid, i.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (i *Int8) Scan(value interface{}) error {
+func (i *Int8) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Int8", value)
 	if value == nil {
-		i.Int8, i.Valid, i.Set = 0, false, false
+		i.Int8, i.Valid, i.Set = 0, false, true
 		return nil
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int8, value)
+	n, err := scanInt(value, "null.Int8", 8)
+	i.Int8, i.Valid, i.Set = int8(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -144,10 +502,29 @@ func (i Int8) Value() (driver.Value, error) {
 	return int64(i.Int8), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Int8) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Int8(%d)", i.Int8)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting i as the Go
+// expression that builds it.
+func (i Int8) GoString() string {
+	return goString("Int8", "", "Int8", strconv.FormatInt(int64(i.Int8), 10), i.Int8 == 0, i.Valid, i.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// i with the verb if it is valid.
+func (i Int8) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, i, i.Valid, i.Set, i.Int8)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Int64 value, or to nil if this Int8 is null.
+func (i Int8) LogValue() slog.Value {
+	if !i.Valid {
+		return logNull
 	}
-	return "Int8(invalid)"
+	return slog.Int64Value(int64(i.Int8))
 }
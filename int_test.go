@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -52,7 +53,8 @@ func TestUnmarshalInt(t *testing.T) {
 
 	var invalid Int
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullInt(t, invalid, "invalid json")
@@ -125,7 +127,12 @@ func TestIntIsZero(t *testing.T) {
 	}
 
 	null := NewInt(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Int
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -152,6 +159,9 @@ func TestIntScan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullInt(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertInt(t *testing.T, i Int, from string) {
@@ -0,0 +1,7 @@
+// Package csvtest checks that the null types round trip through gocsv.
+//
+// gocsv calls the MarshalCSV and UnmarshalCSV methods of the null types, so
+// an empty cell reads as null instead of a parse error or the zero value.
+// This package lives in its own module so that the null package itself
+// needs no CSV dependency.
+package csvtest
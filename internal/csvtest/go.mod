@@ -0,0 +1,17 @@
+module github.com/sisoftrg/null/internal/csvtest
+
+go 1.24
+
+require (
+	github.com/gocarina/gocsv v0.0.0-20260926200228-b2c6eb8fefab
+	github.com/sisoftrg/null v0.0.0
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.21.7 // indirect
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.69.1 // indirect
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.43.0 // indirect
+	github.com/aws/smithy-go v1.28.1 // indirect
+)
+
+replace github.com/sisoftrg/null => ../..
@@ -0,0 +1,85 @@
+package csvtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/sisoftrg/null"
+)
+
+type row struct {
+	ID      null.Int64   `csv:"id"`
+	Name    null.String  `csv:"name"`
+	Score   null.Float64 `csv:"score"`
+	Active  null.Bool    `csv:"active"`
+	Joined  null.Time    `csv:"joined"`
+	Born    null.Date    `csv:"born"`
+	Tags    null.JSON    `csv:"tags"`
+	Balance null.Decimal `csv:"balance"`
+}
+
+const sheet = `id,name,score,active,joined,born,tags,balance
+1,"Doe, Jane",9.5,TRUE,2012-12-21 21:21:21,1990-01-02,"[""a"",""b""]",10.00
+2,,,,,,,
+`
+
+var rows = []*row{
+	{
+		ID:      null.Int64From(1),
+		Name:    null.StringFrom("Doe, Jane"),
+		Score:   null.Float64From(9.5),
+		Active:  null.BoolFrom(true),
+		Joined:  null.TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)),
+		Born:    null.DateOf(1990, 1, 2),
+		Tags:    null.JSONFrom([]byte(`["a","b"]`)),
+		Balance: null.DecimalFrom("10.00"),
+	},
+	{
+		ID:      null.Int64From(2),
+		Name:    null.NewString("", false),
+		Score:   null.NewFloat64(0, false),
+		Active:  null.NewBool(false, false),
+		Joined:  null.NewTime(time.Time{}, false),
+		Born:    null.NewDate(time.Time{}, false),
+		Tags:    null.NewJSON(nil, false),
+		Balance: null.NewDecimal("", false),
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	defer func(layout string) { null.CSVTimeFormat = layout }(null.CSVTimeFormat)
+	null.CSVTimeFormat = time.DateTime
+
+	var got []*row
+	if err := gocsv.UnmarshalString(sheet, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("UnmarshalString() = %#v, want %#v", got, rows)
+	}
+
+	out, err := gocsv.MarshalString(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Booleans are written in lower case; everything else is unchanged.
+	want := `id,name,score,active,joined,born,tags,balance
+1,"Doe, Jane",9.5,true,2012-12-21 21:21:21,1990-01-02,"[""a"",""b""]",10.00
+2,,,,,,,
+`
+	if out != want {
+		t.Errorf("MarshalString() = %s, want %s", out, want)
+	}
+}
+
+func TestMissingColumn(t *testing.T) {
+	var got []*row
+	if err := gocsv.UnmarshalString("id\n3\n", &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != null.Int64From(3) || got[0].Name.IsSet() {
+		t.Errorf("UnmarshalString() = %#v, want only ID set", got)
+	}
+}
@@ -0,0 +1,14 @@
+// Package pgxtest checks that the null types work with pgx v5's native
+// interface, without database/sql.
+//
+// pgx scans into any sql.Scanner and encodes any driver.Valuer, in both
+// the text and binary protocols, so the null types need no pgx-specific
+// code. This package lives in its own module so that the null package
+// itself stays free of dependencies.
+//
+// The tests in pgx_test.go run pgx's codecs without a server. The tests in
+// integration_test.go need the integration build tag and a Postgres server
+// named by PGX_TEST_DATABASE:
+//
+//	PGX_TEST_DATABASE=postgres://localhost/test go test -tags integration .
+package pgxtest
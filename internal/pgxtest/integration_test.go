@@ -0,0 +1,50 @@
+//go:build integration
+
+package pgxtest
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestIntegration(t *testing.T) {
+	url := os.Getenv("PGX_TEST_DATABASE")
+	if url == "" {
+		t.Skip("PGX_TEST_DATABASE is not set")
+	}
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(ctx)
+
+	for _, c := range cases {
+		// The extended protocol sends the parameter and reads the result
+		// in the binary format where pgx supports it.
+		ptr := reflect.New(reflect.TypeOf(c.valid))
+		err := conn.QueryRow(ctx, "select $1::"+c.name, c.valid).Scan(ptr.Interface())
+		if err != nil {
+			t.Errorf("%s: round trip of %#v: %v", c.name, c.valid, err)
+			continue
+		}
+		if got := ptr.Elem().Interface(); !equal(got, c.valid) {
+			t.Errorf("%s: round trip = %#v, want %#v", c.name, got, c.valid)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(c.valid)).Interface()
+		ptr = reflect.New(reflect.TypeOf(c.valid))
+		err = conn.QueryRow(ctx, "select $1::"+c.name, zero).Scan(ptr.Interface())
+		if err != nil {
+			t.Errorf("%s: round trip of NULL: %v", c.name, err)
+			continue
+		}
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%s: scanning NULL = %#v, want a set null", c.name, back.Interface())
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package pgxtest
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sisoftrg/null"
+)
+
+var when = time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+
+// cases pairs each null type with the Postgres type it is stored as, a
+// valid value and the same value as pgx's own Go type.
+var cases = []struct {
+	name   string
+	oid    uint32
+	valid  interface{}
+	native interface{}
+}{
+	{"int8", pgtype.Int8OID, null.Int64From(-42), int64(-42)},
+	{"int4", pgtype.Int4OID, null.Int32From(-42), int32(-42)},
+	{"int2", pgtype.Int2OID, null.Int16From(-42), int16(-42)},
+	{"float8", pgtype.Float8OID, null.Float64From(1.5), 1.5},
+	{"bool", pgtype.BoolOID, null.BoolFrom(true), true},
+	{"text", pgtype.TextOID, null.StringFrom("hello"), "hello"},
+	{"timestamptz", pgtype.TimestamptzOID, null.TimeFrom(when), when},
+	{"date", pgtype.DateOID, null.DateOf(2012, 12, 21), time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)},
+	{"bytea", pgtype.ByteaOID, null.BytesFrom([]byte{0, 1, 0xff}), []byte{0, 1, 0xff}},
+	{"jsonb", pgtype.JSONBOID, null.JSONFrom([]byte(`{"a":1}`)), map[string]interface{}{"a": 1.0}},
+	{"uuid", pgtype.UUIDOID, null.UUIDFrom([16]byte{0x6b, 0xa7, 15: 0xc8}), [16]byte{0x6b, 0xa7, 15: 0xc8}},
+	{"numeric", pgtype.NumericOID, null.DecimalFrom("1.10"), pgtype.Numeric{Int: big.NewInt(110), Exp: -2, Valid: true}},
+}
+
+func TestScan(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, c := range cases {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			src, err := m.Encode(c.oid, format, c.native, nil)
+			if err != nil {
+				t.Fatalf("%s: encoding %#v: %v", c.name, c.native, err)
+			}
+
+			ptr := reflect.New(reflect.TypeOf(c.valid))
+			if err := m.Scan(c.oid, format, src, ptr.Interface()); err != nil {
+				t.Errorf("%s: scanning format %d into %T: %v", c.name, format, c.valid, err)
+				continue
+			}
+			if got := ptr.Elem().Interface(); !equal(got, c.valid) {
+				t.Errorf("%s: scanning format %d = %#v, want %#v", c.name, format, got, c.valid)
+			}
+
+			ptr = reflect.New(reflect.TypeOf(c.valid))
+			if err := m.Scan(c.oid, format, nil, ptr.Interface()); err != nil {
+				t.Errorf("%s: scanning NULL into %T: %v", c.name, c.valid, err)
+				continue
+			}
+			if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+				t.Errorf("%s: scanning NULL = %#v, want a set null", c.name, back.Interface())
+			}
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, c := range cases {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			got, err := m.Encode(c.oid, format, c.valid, nil)
+			if err != nil {
+				t.Errorf("%s: encoding %#v: %v", c.name, c.valid, err)
+				continue
+			}
+			want, err := m.Encode(c.oid, format, c.native, nil)
+			if err != nil {
+				t.Fatalf("%s: encoding %#v: %v", c.name, c.native, err)
+			}
+			if c.oid == pgtype.JSONBOID {
+				// Object key order and spacing may differ.
+				var a, b interface{}
+				if m.Scan(c.oid, format, got, &a) != nil || m.Scan(c.oid, format, want, &b) != nil || !reflect.DeepEqual(a, b) {
+					t.Errorf("%s: encoding format %d = %q, want %q", c.name, format, got, want)
+				}
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: encoding format %d = %q, want %q", c.name, format, got, want)
+			}
+
+			zero := reflect.Zero(reflect.TypeOf(c.valid)).Interface()
+			if got, err := m.Encode(c.oid, format, zero, nil); err != nil || got != nil {
+				t.Errorf("%s: encoding null %T = %q, %v, want NULL", c.name, zero, got, err)
+			}
+		}
+	}
+}
+
+// equal compares scanned values with Equal, so times match in any location.
+func equal(a, b interface{}) bool {
+	method := reflect.ValueOf(a).MethodByName("Equal")
+	return method.Call([]reflect.Value{reflect.ValueOf(b)})[0].Bool()
+}
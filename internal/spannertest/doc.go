@@ -0,0 +1,9 @@
+// Package spannertest checks that the null types work with the Cloud
+// Spanner client's Encoder and Decoder interfaces.
+//
+// The tests build rows with spanner.NewRow, which encodes values the same
+// way mutations and query parameters do, and read them back with
+// Row.ToStruct and Row.Column, so they need no Spanner instance or
+// emulator. This package lives in its own module so that the null package
+// itself needs no Spanner dependency.
+package spannertest
@@ -0,0 +1,89 @@
+package spannertest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/sisoftrg/null"
+)
+
+type singer struct {
+	ID       null.Int64
+	Name     null.String
+	Rating   null.Float64
+	Active   null.Bool
+	Birthday null.Time
+	Photo    null.Bytes
+	Meta     null.JSON
+}
+
+var columns = []string{"ID", "Name", "Rating", "Active", "Birthday", "Photo", "Meta"}
+
+func TestRoundTrip(t *testing.T) {
+	valid := singer{
+		ID:       null.Int64From(9007199254740993),
+		Name:     null.StringFrom("Marc"),
+		Rating:   null.Float64From(4.5),
+		Active:   null.BoolFrom(true),
+		Birthday: null.TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC)),
+		Photo:    null.BytesFrom([]byte{0, 1, 0xff}),
+		Meta:     null.JSONFrom([]byte(`{"a":1}`)),
+	}
+	nulls := singer{
+		ID:       null.NewInt64(0, false),
+		Name:     null.NewString("", false),
+		Rating:   null.NewFloat64(0, false),
+		Active:   null.NewBool(false, false),
+		Birthday: null.NewTime(time.Time{}, false),
+		Photo:    null.NewBytes(nil, false),
+		Meta:     null.NewJSON(nil, false),
+	}
+	// Unset values are written as NULL, which reads back as set.
+	for _, test := range []struct{ in, want singer }{{valid, valid}, {nulls, nulls}, {singer{}, nulls}} {
+		in := test.in
+		v := reflect.ValueOf(in)
+		values := make([]interface{}, v.NumField())
+		for i := range values {
+			values[i] = v.Field(i).Interface()
+		}
+		row, err := spanner.NewRow(columns, values)
+		if err != nil {
+			t.Fatalf("NewRow(%#v): %v", in, err)
+		}
+
+		var out singer
+		if err := row.ToStruct(&out); err != nil {
+			t.Fatalf("ToStruct: %v", err)
+		}
+		if !reflect.DeepEqual(out, test.want) {
+			t.Errorf("ToStruct() = %#v, want %#v", out, test.want)
+		}
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	row, err := spanner.NewRow(
+		[]string{"i", "s", "ts"},
+		[]interface{}{int64(-42), spanner.NullString{}, spanner.NullTime{Time: time.Unix(0, 0).UTC(), Valid: true}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var i null.Int64
+	var s null.String
+	var ts null.Time
+	if err := row.Columns(&i, &s, &ts); err != nil {
+		t.Fatal(err)
+	}
+	if i != null.Int64From(-42) || s != null.NewString("", false) || !ts.Equal(null.TimeFrom(time.Unix(0, 0))) {
+		t.Errorf("Columns() = %#v, %#v, %#v", i, s, ts)
+	}
+
+	// A mismatched column surfaces the decoder's error.
+	var b null.Bool
+	if err := row.Column(0, &b); err == nil {
+		t.Error("decoding an INT64 into null.Bool should fail")
+	}
+}
@@ -0,0 +1,511 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// IP is a nullable netip.Addr, such as a Postgres inet host address.
+// Addresses are kept as parsed: an IPv4-mapped IPv6 address like
+// "::ffff:1.2.3.4" is preserved rather than converted to IPv4, and zones
+// on link-local addresses ("fe80::1%eth0") round-trip.
+type IP struct {
+	IP    netip.Addr
+	Valid bool
+	Set   bool
+}
+
+// NewIP creates a new IP
+func NewIP(ip netip.Addr, valid bool) IP {
+	return IP{
+		IP:    ip,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// IPFrom creates a new IP that will be null if ip is the zero netip.Addr.
+func IPFrom(ip netip.Addr) IP {
+	return NewIP(ip, ip.IsValid())
+}
+
+// IPFromPtr creates a new IP that will be null if ip is nil.
+func IPFromPtr(ip *netip.Addr) IP {
+	if ip == nil {
+		return NewIP(netip.Addr{}, false)
+	}
+	return IPFrom(*ip)
+}
+
+// IPFromNonZero creates a new IP that will be null if ip is the zero Addr.
+func IPFromNonZero(ip netip.Addr) IP {
+	return NewIP(ip, ip.IsValid())
+}
+
+// IPFromString parses s into a new IP. An empty string produces a null IP.
+func IPFromString(s string) (IP, error) {
+	var ip IP
+	err := ip.UnmarshalText([]byte(s))
+	return ip, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (ip IP) IsValid() bool {
+	return ip.Set && ip.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (ip IP) IsSet() bool {
+	return ip.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (ip IP) IsNull() bool {
+	return ip.Set && !ip.Valid
+}
+
+// Equal reports whether ip and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (ip IP) Equal(other IP) bool {
+	if !ip.Valid || !other.Valid {
+		return ip.Valid == other.Valid
+	}
+	return ip.IP == other.IP
+}
+
+// ExactEqual is like Equal, but also requires ip and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (ip IP) ExactEqual(other IP) bool {
+	return ip.Set == other.Set && ip.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (ip *IP) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.IP", ip, data)
+	ip.Set = true
+	if bytes.Equal(data, NullBytes) {
+		ip.IP, ip.Valid = netip.Addr{}, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		ip.IP, ip.Valid = netip.Addr{}, false
+		return err
+	}
+	return ip.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// A host prefix such as "10.0.0.1/32", as Postgres may return for inet,
+// is accepted; other prefix lengths are an error.
+func (ip *IP) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.IP", text)
+	ip.Set = true
+	if len(text) == 0 {
+		ip.IP, ip.Valid = netip.Addr{}, false
+		return nil
+	}
+	res, err := parseIP(string(text))
+	ip.IP, ip.Valid = res, err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this IP is null.
+func (ip IP) MarshalJSON() ([]byte, error) {
+	if !ip.Valid {
+		return NullBytes, nil
+	}
+	return ip.AppendJSON(make([]byte, 0, 41))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (ip IP) AppendJSON(b []byte) ([]byte, error) {
+	if !ip.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = appendAddr(b, ip.IP)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this IP is null.
+func (ip IP) MarshalText() ([]byte, error) {
+	if !ip.Valid {
+		return []byte{}, nil
+	}
+	return ip.AppendText(make([]byte, 0, 39))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if ip is null.
+func (ip IP) AppendText(b []byte) ([]byte, error) {
+	if !ip.Valid {
+		return b, nil
+	}
+	return appendAddr(b, ip.IP), nil
+}
+
+// appendAddr appends addr.String() to b. Unlike String, addr.AppendTo
+// writes nothing for the zero Addr, so that one keeps its "invalid IP".
+func appendAddr(b []byte, addr netip.Addr) []byte {
+	if !addr.IsValid() {
+		return append(b, addr.String()...)
+	}
+	return addr.AppendTo(b)
+}
+
+// SetValid changes this IP's value and also sets it to be non-null.
+func (ip *IP) SetValid(v netip.Addr) {
+	ip.IP = v
+	ip.Valid = true
+	ip.Set = true
+}
+
+// SetNull sets this IP to an explicit null, zeroing its value.
+func (ip *IP) SetNull() {
+	*ip = IP{Set: true}
+}
+
+// Unset resets this IP to the zero value, as if it was never set.
+func (ip *IP) Unset() {
+	*ip = IP{}
+}
+
+// SetPtr changes this IP to the value p points to, or to an explicit
+// null if p is nil.
+func (ip *IP) SetPtr(p *netip.Addr) {
+	*ip = IPFromPtr(p)
+}
+
+// Ptr returns a pointer to this IP's value, or a nil pointer if this IP is null.
+func (ip IP) Ptr() *netip.Addr {
+	if !ip.Valid {
+		return nil
+	}
+	return &ip.IP
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (ip IP) ValueOrZero() netip.Addr {
+	if !ip.Valid {
+		return netip.Addr{}
+	}
+	return ip.IP
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (ip IP) Or(def netip.Addr) netip.Addr {
+	if !ip.Valid {
+		return def
+	}
+	return ip.IP
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (ip IP) Val() netip.Addr {
+	return ip.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (ip IP) Get() (netip.Addr, bool) {
+	return ip.ValueOrZero(), ip.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if ip is
+// null.
+func (ip IP) MustGet() netip.Addr {
+	if !ip.Valid {
+		panic(&NullError{Type: "null.IP"})
+	}
+	return ip.IP
+}
+
+// IsZero returns true if this IP was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (ip IP) IsZero() bool {
+	return !ip.Set && !ip.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this IP is null, and the text form otherwise.
+func (ip IP) MarshalYAML() (interface{}, error) {
+	if !ip.Valid {
+		return nil, nil
+	}
+	data, err := ip.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// IP if the input is null or ~.
+func (ip *IP) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	ip.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		ip.IP, ip.Valid = netip.Addr{}, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		ip.IP, ip.Valid = netip.Addr{}, false
+		return err
+	}
+	return ip.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this IP is null.
+func (ip IP) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, ip.Valid, ip.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null IP.
+func (ip *IP) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, ip.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this IP is null.
+func (ip IP) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, ip.Valid, ip.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null IP.
+func (ip *IP) UnmarshalXMLAttr(attr xml.Attr) error {
+	return ip.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this IP is null.
+func (ip IP) MarshalBSONValue() (byte, []byte, error) {
+	if !ip.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(ip.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (ip *IP) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.IP", ip.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this IP is null.
+func (ip IP) MarshalMsgpack() ([]byte, error) {
+	if !ip.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, ip.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (ip *IP) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.IP", ip.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this IP is null.
+func (ip IP) MarshalCBOR() ([]byte, error) {
+	if !ip.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, ip.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (ip *IP) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.IP", ip.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (ip IP) MarshalGQL(w io.Writer) {
+	marshalGQL(w, ip.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null IP.
+func (ip *IP) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, ip.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this IP is
+// null.
+func (ip IP) MarshalCSV() (string, error) {
+	return marshalCSV(ip.Valid, ip.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null IP.
+func (ip *IP) UnmarshalCSV(cell string) error {
+	return ip.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this IP is null.
+func (ip IP) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(ip.Valid, ip.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null IP.
+func (ip *IP) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, ip.UnmarshalText, ip.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random address in 10.0.0.0/8, or an explicit null if shouldBeNull is true.
+func (ip *IP) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*ip = NewIP(netip.Addr{}, false)
+		return
+	}
+	n := randomN(nextInt, 1<<24)
+	ip.SetValid(netip.AddrFrom4([4]byte{10, byte(n >> 16), byte(n >> 8), byte(n)}))
+}
+
+// Generate implements testing/quick's Generator.
+func (ip IP) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewIP(netip.Addr{}, false), func() IP {
+		if rand.Intn(2) == 0 {
+			var a [4]byte
+			rand.Read(a[:])
+			return IPFrom(netip.AddrFrom4(a))
+		}
+		var a [16]byte
+		rand.Read(a[:])
+		return IPFrom(netip.AddrFrom16(a))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (ip IP) MarshalBinary() ([]byte, error) {
+	return marshalBinary(ip.Set, ip.Valid, func() ([]byte, error) {
+		return ip.IP.MarshalBinary()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ip *IP) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.IP")
+	if err != nil {
+		return err
+	}
+	var v netip.Addr
+	if valid {
+		if err = v.UnmarshalBinary(p); err != nil {
+			return err
+		}
+	}
+	ip.IP, ip.Valid, ip.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (ip *IP) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.IP", value)
+	switch x := value.(type) {
+	case nil:
+		ip.IP, ip.Valid, ip.Set = netip.Addr{}, false, true
+		return nil
+	case string:
+		err = ip.UnmarshalText([]byte(x))
+	case []byte:
+		err = ip.UnmarshalText(x)
+	default:
+		ip.IP, ip.Valid, ip.Set = netip.Addr{}, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.IP: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (ip IP) Value() (driver.Value, error) {
+	if !ip.Valid {
+		return nil, nil
+	}
+	return ip.IP.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (ip IP) String() string {
+	return fmt.Sprint(ip)
+}
+
+// GoString implements the GoStringer interface, formatting ip as the Go
+// expression that builds it.
+func (ip IP) GoString() string {
+	return goString("IP", "", "IP", goAddr(ip.IP), ip.IP == netip.Addr{}, ip.Valid, ip.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// ip with the verb if it is valid.
+func (ip IP) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, ip, ip.Valid, ip.Set, ip.IP)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value, or to nil if this IP is null.
+func (ip IP) LogValue() slog.Value {
+	if !ip.Valid {
+		return logNull
+	}
+	return slog.StringValue(ip.IP.String())
+}
+
+// parseIP parses an address, allowing a full-length host prefix.
+func parseIP(s string) (netip.Addr, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("null: cannot parse %q into null.IP: %v", s, err)
+		}
+		return addr, nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("null: cannot parse %q into null.IP: %v", s, err)
+	}
+	if prefix.Bits() != prefix.Addr().BitLen() {
+		return netip.Addr{}, fmt.Errorf("null: cannot parse %q into null.IP: not a host address", s)
+	}
+	return prefix.Addr(), nil
+}
@@ -0,0 +1,178 @@
+package null
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+var (
+	ipValue = netip.MustParseAddr("192.168.0.1")
+	ipJSON  = []byte(`"192.168.0.1"`)
+)
+
+func TestIPFrom(t *testing.T) {
+	ip := IPFrom(ipValue)
+	assertIP(t, ip, "IPFrom()")
+
+	null := IPFrom(netip.Addr{})
+	assertNullIP(t, null, "IPFrom(zero)")
+}
+
+func TestIPFromPtr(t *testing.T) {
+	v := ipValue
+	ip := IPFromPtr(&v)
+	assertIP(t, ip, "IPFromPtr()")
+
+	null := IPFromPtr(nil)
+	assertNullIP(t, null, "IPFromPtr(nil)")
+}
+
+func TestIPFromString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"192.168.0.1", "192.168.0.1"},
+		{"192.168.0.1/32", "192.168.0.1"},
+		{"2001:DB8::1", "2001:db8::1"},
+		{"2001:db8::1/128", "2001:db8::1"},
+		{"::ffff:1.2.3.4", "::ffff:1.2.3.4"},
+		{"fe80::1%eth0", "fe80::1%eth0"},
+	}
+	for _, test := range tests {
+		ip, err := IPFromString(test.in)
+		maybePanic(err)
+		data, err := ip.MarshalText()
+		maybePanic(err)
+		if !ip.Valid || string(data) != test.want {
+			t.Errorf("IPFromString(%q) = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	null, err := IPFromString("")
+	maybePanic(err)
+	assertNullIP(t, null, "IPFromString(\"\")")
+
+	for _, in := range []string{"abc", "256.0.0.1", "10.0.0.0/8", "10.0.0.1/33"} {
+		if _, err := IPFromString(in); err == nil {
+			t.Errorf("IPFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestUnmarshalIP(t *testing.T) {
+	var ip IP
+	err := json.Unmarshal(ipJSON, &ip)
+	maybePanic(err)
+	assertIP(t, ip, "ip json")
+
+	var null IP
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullIP(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty IP
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullIP(t, empty, "empty string json")
+
+	var badType IP
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullIP(t, badType, "wrong type json")
+}
+
+func TestMarshalIP(t *testing.T) {
+	ip := IPFrom(ipValue)
+	data, err := json.Marshal(ip)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(ipJSON), "non-empty json marshal")
+
+	null := NewIP(netip.Addr{}, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestIPScanValue(t *testing.T) {
+	for _, v := range []interface{}{"192.168.0.1", []byte("192.168.0.1/32")} {
+		var ip IP
+		err := ip.Scan(v)
+		maybePanic(err)
+		assertIP(t, ip, "scanned ip")
+
+		value, err := ip.Value()
+		maybePanic(err)
+		if value != "192.168.0.1" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var zone IP
+	err := zone.Scan("fe80::1%eth0")
+	maybePanic(err)
+	if value, _ := zone.Value(); value != "fe80::1%eth0" {
+		t.Errorf("bad zone value: %v", value)
+	}
+
+	var null IP
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullIP(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"abc", int64(1)} {
+		var wrong IP
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullIP(t, wrong, "scanned garbage")
+	}
+}
+
+func TestIPPointer(t *testing.T) {
+	ip := IPFrom(ipValue)
+	ptr := ip.Ptr()
+	if *ptr != ipValue {
+		t.Errorf("bad %s ip: %#v ≠ %v\n", "pointer", ptr, ipValue)
+	}
+
+	null := NewIP(netip.Addr{}, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s ip: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestIPSetValid(t *testing.T) {
+	change := NewIP(netip.Addr{}, false)
+	assertNullIP(t, change, "SetValid()")
+	change.SetValid(ipValue)
+	assertIP(t, change, "SetValid()")
+}
+
+func assertIP(t *testing.T, ip IP, from string) {
+	if ip.IP != ipValue {
+		t.Errorf("bad %s ip: %v ≠ %v\n", from, ip.IP, ipValue)
+	}
+	if !ip.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullIP(t *testing.T, ip IP, from string) {
+	if ip.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -4,12 +4,29 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"math/rand"
+	"reflect"
 
 	"github.com/sisoftrg/null/convert"
 )
 
+var (
+	// JSONScanValidate makes JSON.Scan reject data that is not valid JSON,
+	// so that a corrupted column fails when it is read rather than when the
+	// value is decoded later.
+	JSONScanValidate = false
+
+	// JSONValueAsString makes JSON.Value return a string instead of a
+	// []byte, for drivers that refuse []byte parameters for JSON columns.
+	JSONValueAsString = false
+)
+
 // JSON is a nullable []byte that contains JSON.
 //
 // You might want to use this in the case where you have say a nullable
@@ -23,7 +40,8 @@ type JSON struct {
 	Set   bool
 }
 
-// NewJSON creates a new JSON
+// NewJSON creates a new JSON. It shares b with the caller instead of
+// copying it; see JSONFromCopy and Clone.
 func NewJSON(b []byte, valid bool) JSON {
 	return JSON{
 		JSON:  b,
@@ -32,7 +50,8 @@ func NewJSON(b []byte, valid bool) JSON {
 	}
 }
 
-// JSONFrom creates a new JSON that will be invalid if nil.
+// JSONFrom creates a new JSON that will be invalid if nil. A non-nil empty
+// b, such as []byte{}, gives a valid JSON that MarshalJSON writes as null.
 func JSONFrom(b []byte) JSON {
 	return NewJSON(b, b != nil)
 }
@@ -46,6 +65,28 @@ func JSONFromPtr(b *[]byte) JSON {
 	return n
 }
 
+// JSONFromNonEmpty creates a new JSON that will be null if b is empty.
+func JSONFromNonEmpty(b []byte) JSON {
+	return NewJSON(b, len(b) > 0)
+}
+
+// JSONFromCopy creates a new JSON holding a copy of b, so that later
+// changes to b do not show through. It will be invalid if b is nil.
+func JSONFromCopy(b []byte) JSON {
+	return JSONFrom(bytes.Clone(b))
+}
+
+// JSONFromValue creates a new JSON holding the encoding of v.
+// A nil v, or one that encodes to JSON null such as a nil map or pointer,
+// produces a null JSON.
+func JSONFromValue(v interface{}) (JSON, error) {
+	var j JSON
+	if err := j.Marshal(v); err != nil {
+		return JSON{}, err
+	}
+	return j, nil
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (j JSON) IsValid() bool {
@@ -57,23 +98,115 @@ func (j JSON) IsSet() bool {
 	return j.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (j JSON) IsNull() bool {
+	return j.Set && !j.Valid
+}
+
 // Unmarshal will unmarshal your JSON stored in
 // your JSON object and store the result in the
 // value pointed to by dest.
+//
+// If the JSON is null, or holds an empty payload, dest is left untouched.
 func (j JSON) Unmarshal(dest interface{}) error {
 	if dest == nil {
 		return errors.New("destination is nil, not a valid pointer to an object")
 	}
 
-	// Call our implementation of
-	// JSON MarshalJSON through json.Marshal
-	// to get the value of the JSON object
-	res, err := json.Marshal(j)
-	if err != nil {
-		return err
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil
 	}
 
-	return json.Unmarshal(res, dest)
+	return json.Unmarshal(j.JSON, dest)
+}
+
+// Equal reports whether j and other hold the same JSON value. Two nulls are
+// equal and a null never equals a non-null. Otherwise both payloads are
+// decoded and compared structurally: object keys in any order, arrays in
+// order and numbers by numeric value, so 1 and 1.0 are equal. Payloads that
+// are not valid JSON are compared as raw bytes. Set is ignored; see
+// ExactEqual.
+func (j JSON) Equal(other JSON) bool {
+	jNull, otherNull := !j.Valid || len(j.JSON) == 0, !other.Valid || len(other.JSON) == 0
+	if jNull || otherNull {
+		return jNull == otherNull
+	}
+
+	a, errA := decodeJSONValue(j.JSON)
+	b, errB := decodeJSONValue(other.JSON)
+	if errA != nil || errB != nil {
+		return bytes.Equal(j.JSON, other.JSON)
+	}
+	return jsonValuesEqual(a, b)
+}
+
+// ExactEqual is like Equal, but also requires j and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (j JSON) ExactEqual(other JSON) bool {
+	return j.Set == other.Set && j.Equal(other)
+}
+
+// Clone returns a copy of j with its own copy of the data, so that
+// changes to either do not show in the other.
+func (j JSON) Clone() JSON {
+	j.JSON = bytes.Clone(j.JSON)
+	return j
+}
+
+func decodeJSONValue(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("null: trailing data after JSON value")
+	}
+	return v, nil
+}
+
+func jsonValuesEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !jsonValuesEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonValuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := new(big.Rat).SetString(x.String())
+		ry, oky := new(big.Rat).SetString(y.String())
+		if !okx || !oky {
+			return x == y
+		}
+		return rx.Cmp(ry) == 0
+	default:
+		return a == b
+	}
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -87,7 +220,8 @@ func (j JSON) Unmarshal(dest interface{}) error {
 // That's to say if 'null' is passed in at the json level we do not capture that
 // value - instead we set the value-level null flag so that an sql value will
 // turn out null.
-func (j *JSON) UnmarshalJSON(data []byte) error {
+func (j *JSON) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.JSON", j, data)
 	if data == nil {
 		return fmt.Errorf("null: cannot unmarshal nil into Go value of type null.JSON")
 	}
@@ -100,6 +234,11 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	if err := checkLen("null.JSON", MaxJSONLen, len(data)); err != nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
+
 	j.Valid = true
 	j.JSON = make([]byte, len(data))
 	copy(j.JSON, data)
@@ -108,8 +247,13 @@ func (j *JSON) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (j *JSON) UnmarshalText(text []byte) error {
+func (j *JSON) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.JSON", text)
 	j.Set = true
+	if err := checkLen("null.JSON", MaxJSONLen, len(text)); err != nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
 	if len(text) == 0 {
 		j.JSON = nil
 		j.Valid = false
@@ -123,6 +267,8 @@ func (j *JSON) UnmarshalText(text []byte) error {
 
 // Marshal will marshal the passed in object,
 // and store it in the JSON member on the JSON object.
+// Objects that encode to JSON null, such as nil, a nil map or a nil
+// pointer, leave the JSON set but null.
 func (j *JSON) Marshal(obj interface{}) error {
 	res, err := json.Marshal(obj)
 	if err != nil {
@@ -136,13 +282,25 @@ func (j *JSON) Marshal(obj interface{}) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// A null JSON, and a valid one whose payload is empty or only whitespace,
+// marshal to null, the same way Scan reads such data, so the output is
+// never empty. Other payloads are written as they are.
 func (j JSON) MarshalJSON() ([]byte, error) {
-	if len(j.JSON) == 0 || j.JSON == nil {
+	if !j.Valid || len(bytes.TrimSpace(j.JSON)) == 0 {
 		return NullBytes, nil
 	}
 	return j.JSON, nil
 }
 
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns,
+// the payload without copying it first, to b.
+func (j JSON) AppendJSON(b []byte) ([]byte, error) {
+	if !j.Valid || len(bytes.TrimSpace(j.JSON)) == 0 {
+		return append(b, "null"...), nil
+	}
+	return append(b, j.JSON...), nil
+}
+
 // MarshalText implements encoding.TextMarshaler.
 func (j JSON) MarshalText() ([]byte, error) {
 	if !j.Valid {
@@ -151,6 +309,16 @@ func (j JSON) MarshalText() ([]byte, error) {
 	return j.JSON, nil
 }
 
+// AppendText implements encoding.TextAppender, appending the payload that
+// MarshalText returns, without copying, to b. Nothing is appended if j is
+// null.
+func (j JSON) AppendText(b []byte) ([]byte, error) {
+	if !j.Valid {
+		return b, nil
+	}
+	return append(b, j.JSON...), nil
+}
+
 // SetValid changes this JSON's value and also sets it to be non-null.
 func (j *JSON) SetValid(n []byte) {
 	j.JSON = n
@@ -158,6 +326,22 @@ func (j *JSON) SetValid(n []byte) {
 	j.Set = true
 }
 
+// SetNull sets this JSON to an explicit null, zeroing its value.
+func (j *JSON) SetNull() {
+	*j = JSON{Set: true}
+}
+
+// Unset resets this JSON to the zero value, as if it was never set.
+func (j *JSON) Unset() {
+	*j = JSON{}
+}
+
+// SetPtr changes this JSON to the value p points to, or to an explicit
+// null if p is nil.
+func (j *JSON) SetPtr(p *[]byte) {
+	*j = JSONFromPtr(p)
+}
+
 // Ptr returns a pointer to this JSON's value, or a nil pointer if this JSON is null.
 func (j JSON) Ptr() *[]byte {
 	if !j.Valid {
@@ -166,19 +350,332 @@ func (j JSON) Ptr() *[]byte {
 	return &j.JSON
 }
 
-// IsZero returns true for null or zero JSON's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (j JSON) ValueOrZero() []byte {
+	if !j.Valid {
+		return nil
+	}
+	return j.JSON
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (j JSON) Or(def []byte) []byte {
+	if !j.Valid {
+		return def
+	}
+	return j.JSON
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (j JSON) Val() []byte {
+	return j.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (j JSON) Get() ([]byte, bool) {
+	return j.ValueOrZero(), j.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if j is
+// null.
+func (j JSON) MustGet() []byte {
+	if !j.Valid {
+		panic(&NullError{Type: "null.JSON"})
+	}
+	return j.JSON
+}
+
+// IsZero returns true if this JSON was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (j JSON) IsZero() bool {
-	return !j.Valid
+	return !j.Set && !j.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The JSON is decoded and emitted as the equivalent YAML structure.
+func (j JSON) MarshalYAML() (interface{}, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(j.JSON, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// Any YAML structure with string keys is accepted and stored as JSON.
+// It will unmarshal to a null JSON if the input is null or ~.
+func (j *JSON) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	j.Set = true
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
+	if v == nil {
+		j.JSON, j.Valid = nil, false
+		return nil
+	}
+
+	v, err := yamlToJSONValue(v)
+	if err == nil {
+		j.JSON, err = json.Marshal(v)
+	}
+	j.Valid = err == nil
+	return err
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this JSON is null.
+func (j JSON) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, j.Valid, j.MarshalText)
 }
 
-// Scan implements the Scanner interface.
-func (j *JSON) Scan(value interface{}) error {
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null JSON.
+func (j *JSON) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, j.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this JSON is null.
+func (j JSON) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, j.Valid, j.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null JSON.
+func (j *JSON) UnmarshalXMLAttr(attr xml.Attr) error {
+	return j.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It converts the JSON to the matching BSON value: objects become
+// documents, and integers int32 or int64 when they fit.
+func (j JSON) MarshalBSONValue() (byte, []byte, error) {
+	if !j.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonFromJSON(j.JSON)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It converts any BSON value with a JSON counterpart to JSON text.
+func (j *JSON) UnmarshalBSONValue(typ byte, data []byte) error {
+	j.Set = true
+	if bsonIsNull(typ) {
+		j.JSON, j.Valid = nil, false
+		return nil
+	}
+	v, err := bsonToJSON(typ, data)
+	j.JSON, j.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It converts the JSON to the matching msgpack value, keeping object keys
+// in order.
+func (j JSON) MarshalMsgpack() ([]byte, error) {
+	if !j.Valid {
+		return msgpackNull, nil
+	}
+	return msgpackFromJSON(j.JSON)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It converts any msgpack value with a JSON counterpart to JSON text.
+func (j *JSON) UnmarshalMsgpack(data []byte) error {
+	j.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
+	res, err := msgpackToJSON(v)
+	j.JSON, j.Valid = res, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It converts the JSON to the matching CBOR value, keeping object keys in
+// order.
+func (j JSON) MarshalCBOR() ([]byte, error) {
+	if !j.Valid {
+		return cborNull, nil
+	}
+	return cborFromJSON(j.JSON)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It converts any CBOR value with a JSON counterpart to JSON text.
+func (j *JSON) UnmarshalCBOR(data []byte) error {
+	j.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
+	res, err := cborToJSON(v)
+	j.JSON, j.Valid = res, err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (j JSON) MarshalGQL(w io.Writer) {
+	marshalGQL(w, j.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null JSON.
+func (j *JSON) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, j.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this JSON is
+// null.
+func (j JSON) MarshalCSV() (string, error) {
+	return marshalCSV(j.Valid, j.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null JSON.
+func (j *JSON) UnmarshalCSV(cell string) error {
+	return j.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this JSON is null.
+func (j JSON) MarshalTOML() ([]byte, error) {
+	return marshalTOML(j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null JSON.
+func (j *JSON) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, j.UnmarshalText, j.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes the JSON text as a STRING, or a typed NULL if this JSON is
+// null. Mutations store it in JSON columns as is; in a query, wrap the
+// parameter in PARSE_JSON to compare it with a JSON column.
+func (j JSON) EncodeSpanner() (interface{}, error) {
+	if !j.Valid {
+		return (*string)(nil), nil
+	}
+	return string(j.JSON), nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts a JSON column, which Spanner sends as JSON text, or a STRING
+// holding JSON, checked like Scan when JSONScanValidate is set. NULL
+// decodes to a null JSON; a JSON null value is valid.
+func (j *JSON) DecodeSpanner(input interface{}) error {
+	j.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		j.JSON, j.Valid = nil, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.JSON")
+	}
+	if JSONScanValidate && !json.Valid([]byte(str)) {
+		j.JSON, j.Valid = nil, false
+		return fmt.Errorf("null: cannot decode invalid Spanner JSON into null.JSON")
+	}
+	j.JSON, j.Valid = []byte(str), true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a one-letter JSON string, or an explicit null if shouldBeNull is true.
+func (j *JSON) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*j = NewJSON(nil, false)
+		return
+	}
+	j.SetValid([]byte(`"` + randomString(nextInt, 1) + `"`))
+}
+
+// Generate implements testing/quick's Generator.
+func (j JSON) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewJSON(nil, false), func() JSON {
+		return JSONFrom(generateJSON(rand, size))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (j JSON) MarshalBinary() ([]byte, error) {
+	return marshalBinary(j.Set, j.Valid, func() ([]byte, error) {
+		return j.JSON, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSON) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.JSON")
+	if err != nil {
+		return err
+	}
+	var v []byte
+	if valid {
+		v = append([]byte{}, p...)
+	}
+	j.JSON, j.Valid, j.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface. It accepts a string or []byte,
+// which it copies. Empty input and a JSON null scan to a null JSON, as NULL
+// does; other data is checked with json.Valid if JSONScanValidate is set.
+func (j *JSON) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.JSON", value)
 	if value == nil {
-		j.JSON, j.Valid, j.Set = nil, false, false
+		j.JSON, j.Valid, j.Set = nil, false, true
 		return nil
 	}
 	j.Valid, j.Set = true, true
-	return convert.ConvertAssign(&j.JSON, value)
+	switch x := value.(type) {
+	case []byte:
+		// The driver may reuse x once Scan returns.
+		j.JSON = bytes.Clone(x)
+	case string:
+		j.JSON = []byte(x)
+	default:
+		if err := convert.ConvertAssign(&j.JSON, value); err != nil {
+			j.JSON, j.Valid = nil, false
+			return err
+		}
+	}
+	if v := bytes.TrimSpace(j.JSON); len(v) == 0 || bytes.Equal(v, NullBytes) {
+		j.JSON, j.Valid = nil, false
+		return nil
+	}
+	if err := checkLen("null.JSON", MaxJSONLen, len(j.JSON)); err != nil {
+		j.JSON, j.Valid = nil, false
+		return err
+	}
+	if JSONScanValidate && !json.Valid(j.JSON) {
+		j.JSON, j.Valid = nil, false
+		return fmt.Errorf("null: cannot scan invalid JSON into null.JSON")
+	}
+	return nil
 }
 
 // Value implements the driver Valuer interface.
@@ -186,13 +683,36 @@ func (j JSON) Value() (driver.Value, error) {
 	if !j.Valid {
 		return nil, nil
 	}
+	if JSONValueAsString {
+		return string(j.JSON), nil
+	}
 	return j.JSON, nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (j JSON) String() string {
-	if j.Valid {
-		return fmt.Sprintf("JSON(%s)", string(j.JSON))
+	return fmt.Sprint(j)
+}
+
+// GoString implements the GoStringer interface, formatting j as the Go
+// expression that builds it.
+func (j JSON) GoString() string {
+	return goString("JSON", "", "JSON", goJSON(j.JSON), j.JSON == nil, j.Valid, j.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// j with the verb if it is valid.
+func (j JSON) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, j, j.Valid, j.Set, string(j.JSON))
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the JSON text, cut off after
+// MaxLogValueLen bytes, or to nil if this JSON is null.
+func (j JSON) LogValue() slog.Value {
+	if !j.Valid {
+		return logNull
 	}
-	return "JSON(invalid)"
+	return logBytes(j.JSON, true, rawText)
 }
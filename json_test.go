@@ -108,6 +108,49 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestJSONFromValue(t *testing.T) {
+	t.Parallel()
+
+	j, err := JSONFromValue(&Test{Name: "hello", Age: 15})
+	maybePanic(err)
+	if !j.Valid || !j.Set || !bytes.Equal(j.JSON, []byte(`{"Name":"hello","Age":15}`)) {
+		t.Errorf("bad JSONFromValue(): %v", j)
+	}
+
+	var nilMap map[string]int
+	var nilPtr *Test
+	for _, v := range []interface{}{nil, nilMap, nilPtr} {
+		j, err = JSONFromValue(v)
+		maybePanic(err)
+		if j.Valid || !j.Set {
+			t.Errorf("JSONFromValue(%#v) should be set and null: %v", v, j)
+		}
+	}
+
+	j, err = JSONFromValue(map[string]int{})
+	maybePanic(err)
+	if !j.Valid || string(j.JSON) != "{}" {
+		t.Errorf("empty map should be valid: %v", j)
+	}
+
+	if _, err = JSONFromValue(make(chan int)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestUnmarshalNullLeavesDest(t *testing.T) {
+	t.Parallel()
+
+	for _, j := range []JSON{{}, NewJSON(nil, false), JSONFrom([]byte{})} {
+		dest := map[string]int{"a": 1}
+		err := j.Unmarshal(&dest)
+		maybePanic(err)
+		if dest == nil || dest["a"] != 1 {
+			t.Errorf("%v: dest should be untouched, got %v", j, dest)
+		}
+	}
+}
+
 func TestUnmarshalJSON(t *testing.T) {
 	t.Parallel()
 
@@ -203,6 +246,50 @@ func TestMarshalJSON(t *testing.T) {
 	assertJSONEquals(t, data, "null", "null json marshal")
 }
 
+func TestMarshalJSONEmptyPayload(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		j    JSON
+		want string
+	}{
+		{JSONFrom([]byte{}), "null"},
+		{JSONFrom([]byte(" \n\t")), "null"},
+		{JSONFrom([]byte("null")), "null"},
+		{JSONFrom([]byte(`{"a":1}`)), `{"a":1}`},
+		{NewJSON([]byte(`{"a":1}`), false), "null"},
+	} {
+		data, err := test.j.MarshalJSON()
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("MarshalJSON(%q) = %s, want %s", test.j.JSON, data, test.want)
+		}
+
+		// Embedded in a document, the output stays valid and reads back
+		// the way Scan reads the same payload.
+		doc, err := json.Marshal(map[string]JSON{"v": test.j})
+		maybePanic(err)
+		var back map[string]JSON
+		err = json.Unmarshal(doc, &back)
+		maybePanic(err)
+		var scanned JSON
+		if test.j.Valid {
+			err = scanned.Scan(test.j.JSON)
+			maybePanic(err)
+		}
+		if back["v"].Valid != scanned.Valid {
+			t.Errorf("%q: %s reads back with Valid %v, Scan gives %v", test.j.JSON, doc, back["v"].Valid, scanned.Valid)
+		}
+	}
+
+	if j := JSONFrom(nil); j.Valid {
+		t.Error("JSONFrom(nil) should be null")
+	}
+	if j := JSONFrom([]byte{}); !j.Valid {
+		t.Error("JSONFrom([]byte{}) should be valid")
+	}
+}
+
 func TestMarshalJSONText(t *testing.T) {
 	t.Parallel()
 
@@ -243,7 +330,12 @@ func TestJSONIsZero(t *testing.T) {
 	}
 
 	null := NewJSON(nil, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset JSON
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -274,6 +366,21 @@ func TestJSONScan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullJSON(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func TestJSONScanCopies(t *testing.T) {
+	t.Parallel()
+
+	buf := []byte(`"hello"`)
+	var j JSON
+	err := j.Scan(buf)
+	maybePanic(err)
+	// A driver reuses its buffer for the next row.
+	copy(buf, `"jello"`)
+	assertJSON(t, j, "scanned reused buffer")
 }
 
 func assertJSON(t *testing.T, i JSON, from string) {
@@ -292,3 +399,142 @@ func assertNullJSON(t *testing.T, i JSON, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestJSONEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b  JSON
+		equal bool
+	}{
+		{JSON{}, JSON{}, true},
+		{NewJSON(nil, false), JSON{}, true},
+		{JSON{}, JSONFrom([]byte(`{}`)), false},
+		{JSONFrom([]byte(`{}`)), NewJSON(nil, false), false},
+		{JSONFrom([]byte(`{"a":1,"b":2}`)), JSONFrom([]byte(`{"b":2,"a":1}`)), true},
+		{JSONFrom([]byte(`{"a": 1}`)), JSONFrom([]byte("{\n\t\"a\":1\n}")), true},
+		{JSONFrom([]byte(`1`)), JSONFrom([]byte(`1.0`)), true},
+		{JSONFrom([]byte(`1`)), JSONFrom([]byte(`1e0`)), true},
+		{JSONFrom([]byte(`12345678901234567890`)), JSONFrom([]byte(`12345678901234567891`)), false},
+		{JSONFrom([]byte(`[1,2]`)), JSONFrom([]byte(`[2,1]`)), false},
+		{JSONFrom([]byte(`{"a":{"b":[1,{"c":null}]}}`)), JSONFrom([]byte(`{"a":{"b":[1.0,{"c":null}]}}`)), true},
+		{JSONFrom([]byte(`{"a":{"b":[1,{"c":null}]}}`)), JSONFrom([]byte(`{"a":{"b":[1,{"c":false}]}}`)), false},
+		{JSONFrom([]byte(`{"a":1}`)), JSONFrom([]byte(`{"a":1,"b":2}`)), false},
+		{JSONFrom([]byte(`"1"`)), JSONFrom([]byte(`1`)), false},
+		{JSONFrom([]byte(`{bad`)), JSONFrom([]byte(`{bad`)), true},
+		{JSONFrom([]byte(`{bad`)), JSONFrom([]byte(`{bad }`)), false},
+		{JSONFrom([]byte(`1 2`)), JSONFrom([]byte(`1`)), false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Equal(test.b); got != test.equal {
+			t.Errorf("%v.Equal(%v) = %t, want %t", test.a, test.b, got, test.equal)
+		}
+		if got := test.b.Equal(test.a); got != test.equal {
+			t.Errorf("%v.Equal(%v) = %t, want %t", test.b, test.a, got, test.equal)
+		}
+	}
+}
+
+func TestJSONScanValidate(t *testing.T) {
+	defer func(v bool) { JSONScanValidate = v }(JSONScanValidate)
+
+	var lax JSON
+	err := lax.Scan([]byte(`{bad`))
+	maybePanic(err)
+	if !lax.Valid || string(lax.JSON) != `{bad` {
+		t.Errorf("invalid data should be kept without validation: %v", lax)
+	}
+
+	JSONScanValidate = true
+
+	var ok JSON
+	err = ok.Scan([]byte(`{"a":1}`))
+	maybePanic(err)
+	if !ok.Valid || string(ok.JSON) != `{"a":1}` {
+		t.Errorf("bad scanned JSON: %v", ok)
+	}
+
+	var bad JSON
+	if err = bad.Scan([]byte(`{bad`)); err == nil {
+		t.Error("expected error")
+	}
+	assertNullJSON(t, bad, "scanned invalid JSON")
+
+	var null JSON
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullJSON(t, null, "scanned null")
+}
+
+func TestJSONScanShapes(t *testing.T) {
+	defer func(v bool) { JSONScanValidate = v }(JSONScanValidate)
+	JSONScanValidate = true
+
+	tests := []struct {
+		in    interface{}
+		want  string
+		valid bool
+		err   bool
+	}{
+		{in: []byte(`{"a":[1,2]}`), want: `{"a":[1,2]}`, valid: true},
+		{in: `[1,"two",null]`, want: `[1,"two",null]`, valid: true},
+		{in: `"text"`, want: `"text"`, valid: true},
+		{in: []byte(`-1.5e3`), want: `-1.5e3`, valid: true},
+		{in: `false`, want: `false`, valid: true},
+		{in: `null`},
+		{in: []byte(" null\n")},
+		{in: []byte{}},
+		{in: ""},
+		{in: `{"a":`, err: true},
+		{in: []byte("\xff"), err: true},
+		{in: `nul`, err: true},
+	}
+	for _, test := range tests {
+		j := JSONFrom([]byte(`"stale"`))
+		err := j.Scan(test.in)
+		if (err != nil) != test.err {
+			t.Errorf("Scan(%q) error = %v, want error %t", test.in, err, test.err)
+		}
+		if string(j.JSON) != test.want || j.Valid != test.valid || !j.Set {
+			t.Errorf("Scan(%q) = %#v, want %q, valid %t", test.in, j, test.want, test.valid)
+		}
+	}
+}
+
+func TestJSONValueAsString(t *testing.T) {
+	defer func(v bool) { JSONValueAsString = v }(JSONValueAsString)
+
+	j := JSONFrom([]byte(`{"a":1}`))
+	v, err := j.Value()
+	maybePanic(err)
+	if b, ok := v.([]byte); !ok || string(b) != `{"a":1}` {
+		t.Errorf("expected []byte value, got %T %v", v, v)
+	}
+
+	JSONValueAsString = true
+
+	v, err = j.Value()
+	maybePanic(err)
+	if s, ok := v.(string); !ok || s != `{"a":1}` {
+		t.Errorf("expected string value, got %T %v", v, v)
+	}
+
+	v, err = NewJSON(nil, false).Value()
+	if v != nil || err != nil {
+		t.Errorf("null should have a nil value, got %v %v", v, err)
+	}
+}
+
+func TestJSONClone(t *testing.T) {
+	t.Parallel()
+
+	buf := []byte(`"hello"`)
+	copied, clone := JSONFromCopy(buf), JSONFrom(buf).Clone()
+	copy(buf, `"jello"`)
+	assertJSON(t, copied, "JSONFromCopy() after mutating argument")
+	assertJSON(t, clone, "Clone() after mutating original")
+	if c := (JSON{}).Clone(); c.JSON != nil || c.Valid || c.Set {
+		t.Errorf("Clone() of unset = %#v", c)
+	}
+}
@@ -0,0 +1,45 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// The field path is added by UnmarshalJSONFrom, which encoding/json calls
+// when it is built on encoding/json/v2. jsonfield_v1_test.go checks the
+// original decoder, which adds it to the bare *json.UnmarshalTypeError.
+
+func TestJSONTypeErrorField(t *testing.T) {
+	type limits struct {
+		Limit Int `json:"limit"`
+		Count int `json:"count"`
+	}
+	var user struct {
+		Age      Int            `json:"age"`
+		Settings Val[limits]    `json:"settings"`
+		Nested   JSONOf[limits] `json:"nested"`
+		Scores   []Int          `json:"scores"`
+	}
+	tests := []struct {
+		input, field, msg string
+	}{
+		{`{"age": "abc"}`, "age", `json: cannot unmarshal string "abc" into Go struct field .age of type null.Int: null: invalid input`},
+		{`{"settings": {"limit": true}}`, "settings.limit", `json: cannot unmarshal bool true into Go struct field .settings.limit of type null.Int: null: invalid input`},
+		{`{"settings": {"count": "x"}}`, "settings.count", `json: cannot unmarshal string into Go struct field .settings.count of type int: null: invalid input`},
+		{`{"nested": {"limit": [1]}}`, "nested.limit", `json: cannot unmarshal array into Go struct field .nested.limit of type null.Int: null: invalid input`},
+		{`{"scores": [1, {}]}`, "scores.1", `json: cannot unmarshal object into .scores.1 of type null.Int: null: invalid input`},
+	}
+	for _, test := range tests {
+		err := json.Unmarshal([]byte(test.input), &user)
+		var te *json.UnmarshalTypeError
+		if !errors.As(err, &te) || te.Field != test.field || err.Error() != test.msg {
+			t.Errorf("Unmarshal(%s) = %v, want Field %q and %s", test.input, err, test.field, test.msg)
+		}
+		if !errors.Is(err, ErrInvalidInput) || !errors.As(err, new(*ConvertError)) {
+			t.Errorf("Unmarshal(%s) = %v, want ErrInvalidInput and a *ConvertError", test.input, err)
+		}
+	}
+}
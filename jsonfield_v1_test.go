@@ -0,0 +1,36 @@
+//go:build !go1.27 || !goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// The original decoder adds the field path to a bare
+// *json.UnmarshalTypeError only, so these errors do not match
+// ErrInvalidInput. jsonfield_test.go checks encoding/json/v2.
+
+func TestJSONTypeErrorField(t *testing.T) {
+	type limits struct {
+		Limit Int `json:"limit"`
+	}
+	var user struct {
+		Age      Int         `json:"age"`
+		Settings Val[limits] `json:"settings"`
+	}
+	tests := []struct {
+		input, field, msg string
+	}{
+		{`{"age": "abc"}`, "age", `json: cannot unmarshal string "abc" into Go struct field .age of type null.Int`},
+		{`{"settings": {"limit": true}}`, "settings.limit", `json: cannot unmarshal bool true into Go struct field .settings.limit of type null.Int`},
+	}
+	for _, test := range tests {
+		err := json.Unmarshal([]byte(test.input), &user)
+		var te *json.UnmarshalTypeError
+		if !errors.As(err, &te) || te.Field != test.field || err.Error() != test.msg {
+			t.Errorf("Unmarshal(%s) = %v, want Field %q and %s", test.input, err, test.field, test.msg)
+		}
+	}
+}
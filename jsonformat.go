@@ -0,0 +1,73 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Pretty returns j indented with json.Indent, each element on its own line
+// and indented by one more copy of indent per level of nesting, as in
+// j.Pretty("  ") for a debug endpoint. A null j, or one with an empty
+// payload, is returned as it is. A payload that is not valid JSON gives a
+// null JSON and an error that wraps the *json.SyntaxError and names its
+// offset.
+//
+// The result never shares its bytes with j.
+func (j JSON) Pretty(indent string) (JSON, error) {
+	return j.reformat(func(dst *bytes.Buffer, src []byte) error {
+		return json.Indent(dst, src, "", indent)
+	})
+}
+
+// Compact returns j with insignificant space removed by json.Compact, for
+// storage. It treats null, empty and invalid payloads as Pretty does, and
+// the result never shares its bytes with j.
+func (j JSON) Compact() (JSON, error) {
+	return j.reformat(json.Compact)
+}
+
+// Canonical returns j compacted, with the keys of every object sorted, so
+// that documents equal but for key order and spacing get the same bytes,
+// to hash for instance. Numbers are kept as written, strings are reescaped
+// as by json.Marshal but without HTML escaping, and of repeated keys in an
+// object the last one wins. It treats null, empty and invalid payloads as
+// Pretty does, and the result never shares its bytes with j.
+func (j JSON) Canonical() (JSON, error) {
+	return j.reformat(func(dst *bytes.Buffer, src []byte) error {
+		if err := json.Compact(dst, src); err != nil {
+			return err
+		}
+		v, err := decodeJSONValue(dst.Bytes())
+		if err != nil {
+			return err
+		}
+		dst.Reset()
+		enc := json.NewEncoder(dst)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		dst.Truncate(dst.Len() - 1) // the newline Encode adds
+		return nil
+	})
+}
+
+// reformat writes the payload of j through format into a new JSON.
+func (j JSON) reformat(format func(dst *bytes.Buffer, src []byte) error) (JSON, error) {
+	if !j.Valid || len(j.JSON) == 0 {
+		return j.Clone(), nil
+	}
+	var buf bytes.Buffer
+	if err := format(&buf, j.JSON); err != nil {
+		// The v1 json.Compact reports every syntax error at offset 0, so
+		// the error is taken from Unmarshal, which gets it right.
+		var syntaxErr *json.SyntaxError
+		if errors.As(json.Unmarshal(j.JSON, new(json.RawMessage)), &syntaxErr) {
+			err = fmt.Errorf("null: invalid JSON at offset %d: %w", syntaxErr.Offset, syntaxErr)
+		}
+		return NewJSON(nil, false), err
+	}
+	return NewJSON(buf.Bytes(), true), nil
+}
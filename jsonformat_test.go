@@ -0,0 +1,78 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONPretty(t *testing.T) {
+	j := JSONFrom([]byte(`{"b": [1, 2], "a": {}}`))
+	pretty, err := j.Pretty("  ")
+	maybePanic(err)
+	want := "{\n  \"b\": [\n    1,\n    2\n  ],\n  \"a\": {}\n}"
+	if !pretty.Valid || string(pretty.JSON) != want {
+		t.Errorf("Pretty() = %q, want %q", pretty.JSON, want)
+	}
+
+	compact, err := pretty.Compact()
+	maybePanic(err)
+	if !compact.Valid || string(compact.JSON) != `{"b":[1,2],"a":{}}` {
+		t.Errorf("Compact() = %s", compact.JSON)
+	}
+
+	compact.JSON[0] = '['
+	if j.JSON[0] != '{' || pretty.JSON[0] != '{' {
+		t.Error("Compact() should not share its bytes")
+	}
+}
+
+func TestJSONCanonical(t *testing.T) {
+	a := JSONFrom([]byte(` {"z": 1.50, "a": {"y": "<b>", "x": [true, null]}, "m": "é"} `))
+	b := JSONFrom([]byte(`{"m":"é","a":{"x":[true,null],"y":"<b>"},"z":1.50}`))
+	ca, err := a.Canonical()
+	maybePanic(err)
+	cb, err := b.Canonical()
+	maybePanic(err)
+	want := `{"a":{"x":[true,null],"y":"<b>"},"m":"é","z":1.50}`
+	if string(ca.JSON) != want || string(cb.JSON) != want {
+		t.Errorf("Canonical() = %s and %s, want %s", ca.JSON, cb.JSON, want)
+	}
+
+	dup, err := JSONFrom([]byte(`{"a":1,"a":2}`)).Canonical()
+	if err != nil || string(dup.JSON) != `{"a":2}` {
+		t.Errorf("Canonical() of repeated keys = %s, %v", dup.JSON, err)
+	}
+}
+
+func TestJSONReformatNullAndErrors(t *testing.T) {
+	for _, j := range []JSON{{}, NewJSON(nil, false), NewJSON([]byte("stale"), false), JSONFrom([]byte{})} {
+		for name, reformat := range map[string]func() (JSON, error){
+			"Pretty":    func() (JSON, error) { return j.Pretty("\t") },
+			"Compact":   j.Compact,
+			"Canonical": j.Canonical,
+		} {
+			got, err := reformat()
+			if err != nil || !got.ExactEqual(j) || got.Valid != j.Valid {
+				t.Errorf("%s() of %#v = %#v, %v, want it unchanged", name, j, got, err)
+			}
+			if len(got.JSON) > 0 && &got.JSON[0] == &j.JSON[0] {
+				t.Errorf("%s() of %#v shares its bytes", name, j)
+			}
+		}
+	}
+
+	bad := JSONFrom([]byte(`{"a": 1,}`))
+	for name, reformat := range map[string]func() (JSON, error){
+		"Pretty":    func() (JSON, error) { return bad.Pretty("  ") },
+		"Compact":   bad.Compact,
+		"Canonical": bad.Canonical,
+	} {
+		got, err := reformat()
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) || !strings.Contains(err.Error(), "offset 9") || got.Valid {
+			t.Errorf("%s() of invalid JSON = %#v, %v", name, got, err)
+		}
+	}
+}
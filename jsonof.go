@@ -0,0 +1,502 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+)
+
+// JSONOf is a nullable T stored as JSON, such as a jsonb column holding an
+// app-defined struct. Unlike JSON, which keeps the raw text, it holds the
+// decoded T, so read sites need not declare the target type and handle
+// decode errors again: UnmarshalJSON and Scan decode right away and report
+// invalid input there. T is encoded and decoded by encoding/json, so a T
+// implementing json.Marshaler or json.Unmarshaler is honored. A null JSONOf
+// marshals to null and leaves T at its zero value. A valid T that encodes
+// as null itself, such as a nil map, reads back as null.
+type JSONOf[T any] struct {
+	JSONOf T
+	Valid  bool
+	Set    bool
+}
+
+// NewJSONOf creates a new JSONOf.
+func NewJSONOf[T any](v T, valid bool) JSONOf[T] {
+	return JSONOf[T]{
+		JSONOf: v,
+		Valid:  valid,
+		Set:    true,
+	}
+}
+
+// JSONOfFrom creates a new JSONOf that will always be valid.
+func JSONOfFrom[T any](v T) JSONOf[T] {
+	return NewJSONOf(v, true)
+}
+
+// JSONOfFromPtr creates a new JSONOf that will be null if v is nil.
+func JSONOfFromPtr[T any](v *T) JSONOf[T] {
+	if v == nil {
+		var zero T
+		return NewJSONOf(zero, false)
+	}
+	return NewJSONOf(*v, true)
+}
+
+// JSONOfFromNonZero creates a new JSONOf that will be null if v is T's zero value.
+func JSONOfFromNonZero[T any](v T) JSONOf[T] {
+	return NewJSONOf(v, !reflect.ValueOf(&v).Elem().IsZero())
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (j JSONOf[T]) IsValid() bool {
+	return j.Set && j.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (j JSONOf[T]) IsSet() bool {
+	return j.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (j JSONOf[T]) IsNull() bool {
+	return j.Set && !j.Valid
+}
+
+// Equal reports whether j and other are both null, or both valid with
+// equal values, compared with T's Equal method if it has one and with
+// reflect.DeepEqual otherwise. Set is ignored; see ExactEqual.
+func (j JSONOf[T]) Equal(other JSONOf[T]) bool {
+	if !j.Valid || !other.Valid {
+		return j.Valid == other.Valid
+	}
+	return valEqual(j.JSONOf, other.JSONOf)
+}
+
+// ExactEqual is like Equal, but also requires j and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (j JSONOf[T]) ExactEqual(other JSONOf[T]) bool {
+	return j.Set == other.Set && j.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes data into a new T; null input gives a null JSONOf.
+func (j *JSONOf[T]) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.JSONOf", j, data)
+	var zero T
+	j.Set = true
+	if bytes.Equal(data, NullBytes) {
+		j.JSONOf, j.Valid = zero, false
+		return nil
+	}
+
+	var res T
+	if err := json.Unmarshal(data, &res); err != nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	j.JSONOf, j.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this JSONOf is null.
+func (j JSONOf[T]) MarshalJSON() ([]byte, error) {
+	if !j.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(j.JSONOf)
+}
+
+// SetValid changes this JSONOf's value and also sets it to be non-null.
+func (j *JSONOf[T]) SetValid(v T) {
+	j.JSONOf = v
+	j.Valid = true
+	j.Set = true
+}
+
+// SetNull sets this JSONOf to an explicit null, zeroing its value.
+func (j *JSONOf[T]) SetNull() {
+	*j = JSONOf[T]{Set: true}
+}
+
+// Unset resets this JSONOf to the zero value, as if it was never set.
+func (j *JSONOf[T]) Unset() {
+	*j = JSONOf[T]{}
+}
+
+// SetPtr changes this JSONOf to the value p points to, or to an explicit
+// null if p is nil.
+func (j *JSONOf[T]) SetPtr(p *T) {
+	*j = JSONOfFromPtr(p)
+}
+
+// Ptr returns a pointer to this JSONOf's value, or a nil pointer if this JSONOf is null.
+func (j JSONOf[T]) Ptr() *T {
+	if !j.Valid {
+		return nil
+	}
+	return &j.JSONOf
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (j JSONOf[T]) ValueOrZero() T {
+	if !j.Valid {
+		var zero T
+		return zero
+	}
+	return j.JSONOf
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (j JSONOf[T]) Or(def T) T {
+	if !j.Valid {
+		return def
+	}
+	return j.JSONOf
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (j JSONOf[T]) Val() T {
+	return j.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (j JSONOf[T]) Get() (T, bool) {
+	return j.ValueOrZero(), j.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if j is
+// null.
+func (j JSONOf[T]) MustGet() T {
+	if !j.Valid {
+		panic(&NullError{Type: "null.JSONOf" + goTypeArgs[T]()})
+	}
+	return j.JSONOf
+}
+
+// IsZero returns true if this JSONOf was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (j JSONOf[T]) IsZero() bool {
+	return !j.Set && !j.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The value is encoded by the YAML package as T. It will encode null if
+// this JSONOf is null.
+func (j JSONOf[T]) MarshalYAML() (interface{}, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	return j.JSONOf, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// The value is decoded by the YAML package as T. It will unmarshal to a
+// null JSONOf if the input is null or ~.
+func (j *JSONOf[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var zero T
+	j.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+
+	var res T
+	if err := unmarshal(&res); err != nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	j.JSONOf, j.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this JSONOf is null.
+func (j JSONOf[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null JSONOf.
+func (j *JSONOf[T]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(j.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this JSONOf is null.
+func (j JSONOf[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null JSONOf.
+func (j *JSONOf[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(j.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It converts the JSON of MarshalJSON to the matching BSON value, or BSON
+// null if this JSONOf is null.
+func (j JSONOf[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !j.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := j.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any BSON value with a JSON counterpart, decoded as
+// UnmarshalJSON decodes JSON.
+func (j *JSONOf[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	var zero T
+	j.Set = true
+	if bsonIsNull(typ) {
+		j.JSONOf, j.Valid = zero, false
+		return nil
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	return j.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It converts the JSON of MarshalJSON to the matching msgpack value, or nil
+// if this JSONOf is null.
+func (j JSONOf[T]) MarshalMsgpack() ([]byte, error) {
+	if !j.Valid {
+		return msgpackNull, nil
+	}
+	data, err := j.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any msgpack value with a JSON counterpart, decoded as
+// UnmarshalJSON decodes JSON.
+func (j *JSONOf[T]) UnmarshalMsgpack(data []byte) error {
+	var zero T
+	j.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	return j.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It converts the JSON of MarshalJSON to the matching CBOR value, or null
+// if this JSONOf is null.
+func (j JSONOf[T]) MarshalCBOR() ([]byte, error) {
+	if !j.Valid {
+		return cborNull, nil
+	}
+	data, err := j.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any CBOR value with a JSON counterpart, decoded as
+// UnmarshalJSON decodes JSON.
+func (j *JSONOf[T]) UnmarshalCBOR(data []byte) error {
+	var zero T
+	j.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		j.JSONOf, j.Valid = zero, false
+		return err
+	}
+	return j.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (j JSONOf[T]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, j.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null JSONOf.
+func (j *JSONOf[T]) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, j.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this JSONOf
+// is null.
+func (j JSONOf[T]) MarshalCSV() (string, error) {
+	return marshalCSV(j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null JSONOf.
+func (j *JSONOf[T]) UnmarshalCSV(cell string) error {
+	return jsonText(j.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this JSONOf is null.
+func (j JSONOf[T]) MarshalTOML() ([]byte, error) {
+	return marshalTOML(j.Valid, j.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null JSONOf.
+func (j *JSONOf[T]) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, j.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a value randomized as Val randomizes T, or an explicit null if
+// shouldBeNull is true. It panics if T cannot be randomized.
+func (j *JSONOf[T]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*j = JSONOf[T]{Set: true}
+		return
+	}
+	var v Val[T]
+	v.Randomize(nextInt, fieldType, false)
+	j.SetValid(v.Val)
+}
+
+// Generate implements testing/quick's Generator.
+func (j JSONOf[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, JSONOf[T]{Set: true}, func() JSONOf[T] {
+		var res T
+		if !generateValue(rand, size, reflect.ValueOf(&res).Elem()) {
+			panic("null: cannot generate null.JSONOf" + goTypeArgs[T]())
+		}
+		return JSONOfFrom(res)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The value is
+// encoded as JSON.
+func (j JSONOf[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(j.Set, j.Valid, func() ([]byte, error) {
+		return j.MarshalJSON()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSONOf[T]) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.JSONOf")
+	if err != nil {
+		return err
+	}
+	var v T
+	if valid {
+		if err = json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+	}
+	j.JSONOf, j.Valid, j.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It decodes JSON from string or []byte into a new T; a JSON null is null.
+func (j *JSONOf[T]) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.JSONOf", value)
+	var zero T
+	switch x := value.(type) {
+	case nil:
+		j.JSONOf, j.Valid, j.Set = zero, false, true
+		return nil
+	case string:
+		err = j.UnmarshalJSON([]byte(x))
+	case []byte:
+		err = j.UnmarshalJSON(x)
+	default:
+		j.JSONOf, j.Valid, j.Set = zero, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.JSONOf: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The value is encoded as JSON, returned as a string if JSONValueAsString
+// is set.
+func (j JSONOf[T]) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	data, err := j.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if JSONValueAsString {
+		return string(data), nil
+	}
+	return data, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (j JSONOf[T]) String() string {
+	return fmt.Sprint(j)
+}
+
+// GoString implements the GoStringer interface, formatting j as the Go
+// expression that builds it.
+func (j JSONOf[T]) GoString() string {
+	return goString("JSONOf", goTypeArgs[T](), "JSONOf", fmt.Sprintf("%#v", j.JSONOf), reflect.ValueOf(&j.JSONOf).Elem().IsZero(), j.Valid, j.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// j with the verb if it is valid.
+func (j JSONOf[T]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, j, j.Valid, j.Set, j.JSONOf)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to slog.AnyValue of the value, or to nil if this JSONOf is
+// null.
+func (j JSONOf[T]) LogValue() slog.Value {
+	if !j.Valid {
+		return logNull
+	}
+	return slog.AnyValue(j.JSONOf)
+}
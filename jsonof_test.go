@@ -0,0 +1,136 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testSettings struct {
+	Theme  string    `json:"theme"`
+	Limits []int     `json:"limits,omitempty"`
+	Title  testShout `json:"title"`
+}
+
+func TestJSONOfJSON(t *testing.T) {
+	tests := []struct {
+		in   JSONOf[testSettings]
+		want string
+	}{
+		{NewJSONOf(testSettings{Theme: "stale"}, false), `null`},
+		{JSONOfFrom(testSettings{}), `{"theme":"","title":""}`},
+		{JSONOfFrom(testSettings{"dark", []int{3}, "hi"}), `{"theme":"dark","limits":[3],"title":"HI"}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	var got JSONOf[testSettings]
+	err := json.Unmarshal([]byte(`{"theme":"dark","title":"HI"}`), &got)
+	maybePanic(err)
+	if !got.Valid || !got.Set || got.JSONOf.Theme != "dark" || got.JSONOf.Title != "HI" {
+		t.Errorf("unmarshal = %#v", got)
+	}
+
+	var null JSONOf[testSettings]
+	err = json.Unmarshal([]byte(`null`), &null)
+	maybePanic(err)
+	if null.Valid || !null.Set || null.JSONOf.Theme != "" {
+		t.Errorf("unmarshal null = %#v, want a set null", null)
+	}
+
+	for _, bad := range []string{`[]`, `{"theme":1}`, `{"title":"quiet"}`} {
+		j := JSONOfFrom(testSettings{Theme: "old"})
+		err := json.Unmarshal([]byte(bad), &j)
+		if err == nil {
+			t.Errorf("unmarshal %s should fail", bad)
+		}
+		if j.Valid || j.JSONOf.Theme != "" {
+			t.Errorf("unmarshal %s left %#v", bad, j)
+		}
+	}
+
+	// A valid nil map encodes as null, so it reads back as null.
+	data, err := json.Marshal(JSONOfFrom(map[string]int(nil)))
+	maybePanic(err)
+	var m JSONOf[map[string]int]
+	maybePanic(json.Unmarshal(data, &m))
+	if m.Valid {
+		t.Errorf("a nil map read back as %#v", m)
+	}
+}
+
+func TestJSONOfAccessors(t *testing.T) {
+	j := JSONOfFrom(testSettings{Theme: "dark"})
+	if p := j.Ptr(); p == nil || p.Theme != "dark" {
+		t.Errorf("Ptr() = %v", p)
+	}
+	if v, ok := j.Get(); !ok || v.Theme != "dark" || j.MustGet().Theme != "dark" {
+		t.Errorf("Get() = %v, %t", v, ok)
+	}
+
+	null := NewJSONOf(testSettings{Theme: "stale"}, false)
+	if p := null.Ptr(); p != nil {
+		t.Errorf("null Ptr() = %v", p)
+	}
+	if v, ok := null.Get(); ok || v.Theme != "" || null.Or(testSettings{Theme: "def"}).Theme != "def" {
+		t.Errorf("null Get() = %v, %t", v, ok)
+	}
+	func() {
+		defer func() {
+			var nerr *NullError
+			if err, _ := recover().(error); !errors.As(err, &nerr) || nerr.Type != "null.JSONOf[null.testSettings]" {
+				t.Errorf("MustGet on null panicked with %v", err)
+			}
+		}()
+		null.MustGet()
+	}()
+
+	if p := JSONOfFromPtr[testSettings](nil); p.Valid || !p.Set {
+		t.Errorf("JSONOfFromPtr(nil) = %#v", p)
+	}
+	if !JSONOfFrom(testSettings{Limits: []int{1}}).Equal(JSONOfFrom(testSettings{Limits: []int{1}})) || j.Equal(null) {
+		t.Error("bad Equal")
+	}
+}
+
+func TestJSONOfScanValue(t *testing.T) {
+	j := JSONOfFrom(testSettings{Theme: "dark"})
+	v, err := j.Value()
+	maybePanic(err)
+	if string(v.([]byte)) != `{"theme":"dark","title":""}` {
+		t.Errorf("Value() = %s", v)
+	}
+
+	var back JSONOf[testSettings]
+	maybePanic(back.Scan(v))
+	if !back.ExactEqual(j) {
+		t.Errorf("Scan(%s) = %#v", v, back)
+	}
+	maybePanic(back.Scan(`{"theme":"light"}`))
+	if back.JSONOf.Theme != "light" {
+		t.Errorf("Scan(string) = %#v", back)
+	}
+
+	maybePanic(back.Scan(nil))
+	if back.Valid || !back.Set || back.JSONOf.Theme != "" {
+		t.Errorf("Scan(nil) = %#v, want a set null", back)
+	}
+	if v, err := back.Value(); v != nil || err != nil {
+		t.Errorf("null Value() = %v, %v", v, err)
+	}
+
+	// Decode errors come from Scan, not from later reads.
+	err = back.Scan([]byte(`{"theme":true}`))
+	var cerr *ConvertError
+	if !errors.As(err, &cerr) || cerr.Type != "null.JSONOf" || back.Valid {
+		t.Errorf("Scan of a bad document = %#v, %v", back, err)
+	}
+	if err := back.Scan(int64(1)); err == nil || back.Valid {
+		t.Errorf("Scan(int64) = %#v, %v, want an error", back, err)
+	}
+}
@@ -0,0 +1,187 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrPathNotFound is returned by JSON.Lookup and the typed lookups when the
+// document has no value at the path.
+var ErrPathNotFound = errors.New("null: JSON path not found")
+
+// Lookup returns the value at path in j, walking object keys and, for
+// arrays, decimal indexes, as in j.Lookup("payload", "items", "0", "id").
+// It is not named Get, which returns the payload as on the other types.
+//
+// A JSON null at the path gives a set null JSON. A path that does not exist,
+// including one going through a null or a scalar, gives an error matching
+// ErrPathNotFound, and a j that is not valid JSON a *json.SyntaxError. A
+// null j is returned as is for an empty path and has no other path.
+//
+// The document is scanned once, without decoding the values along the way.
+// The result shares its bytes with j; see Clone.
+func (j JSON) Lookup(path ...string) (JSON, error) {
+	if !j.Valid {
+		if len(path) == 0 {
+			return j, nil
+		}
+		return NewJSON(nil, false), fmt.Errorf("%w: %s", ErrPathNotFound, strings.Join(path, "."))
+	}
+	data := j.JSON
+	if !json.Valid(data) {
+		return NewJSON(nil, false), json.Unmarshal(data, new(interface{}))
+	}
+	start := skipJSONSpace(data, 0)
+	end := skipJSONValue(data, start)
+	for n, key := range path {
+		var ok bool
+		start, end, ok = lookupJSON(data, start, key)
+		if !ok {
+			return NewJSON(nil, false), fmt.Errorf("%w: %s", ErrPathNotFound, strings.Join(path[:n+1], "."))
+		}
+	}
+	if v := data[start:end]; !bytes.Equal(v, NullBytes) {
+		return JSONFrom(v), nil
+	}
+	return NewJSON(nil, false), nil
+}
+
+// LookupString is Lookup for a JSON string, returned as a String. A JSON
+// null gives a null String, and any other value an error.
+func (j JSON) LookupString(path ...string) (String, error) {
+	var s String
+	err := j.lookupInto(&s, path)
+	return s, err
+}
+
+// LookupInt64 is Lookup for a JSON number, returned as an Int64, like
+// LookupString.
+func (j JSON) LookupInt64(path ...string) (Int64, error) {
+	var i Int64
+	err := j.lookupInto(&i, path)
+	return i, err
+}
+
+// LookupFloat64 is Lookup for a JSON number, returned as a Float64, like
+// LookupString.
+func (j JSON) LookupFloat64(path ...string) (Float64, error) {
+	var f Float64
+	err := j.lookupInto(&f, path)
+	return f, err
+}
+
+// LookupBool is Lookup for a JSON boolean, returned as a Bool, like
+// LookupString.
+func (j JSON) LookupBool(path ...string) (Bool, error) {
+	var b Bool
+	err := j.lookupInto(&b, path)
+	return b, err
+}
+
+func (j JSON) lookupInto(dst json.Unmarshaler, path []string) error {
+	v, err := j.Lookup(path...)
+	if err != nil {
+		return err
+	}
+	if !v.Valid {
+		return dst.UnmarshalJSON(NullBytes)
+	}
+	return dst.UnmarshalJSON(v.JSON)
+}
+
+// lookupJSON finds key in the object or array starting at data[start],
+// returning the bounds of its value. data must be valid JSON.
+func lookupJSON(data []byte, start int, key string) (int, int, bool) {
+	switch data[start] {
+	case '{':
+		i := skipJSONSpace(data, start+1)
+		for data[i] != '}' {
+			end := skipJSONString(data, i)
+			name := data[i+1 : end-1]
+			i = skipJSONSpace(data, skipJSONSpace(data, end)+1) // past the colon
+			vend := skipJSONValue(data, i)
+			if jsonKeyEqual(name, key) {
+				return i, vend, true
+			}
+			i = skipJSONSpace(data, vend)
+			if data[i] == ',' {
+				i = skipJSONSpace(data, i+1)
+			}
+		}
+	case '[':
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			return 0, 0, false
+		}
+		i := skipJSONSpace(data, start+1)
+		for n := 0; data[i] != ']'; n++ {
+			vend := skipJSONValue(data, i)
+			if n == index {
+				return i, vend, true
+			}
+			i = skipJSONSpace(data, vend)
+			if data[i] == ',' {
+				i = skipJSONSpace(data, i+1)
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// jsonKeyEqual reports whether the raw object key name, without its
+// quotes, is key. Only keys with escapes are decoded.
+func jsonKeyEqual(name []byte, key string) bool {
+	if bytes.IndexByte(name, '\\') < 0 {
+		return string(name) == key
+	}
+	var s string
+	err := json.Unmarshal(append(append([]byte{'"'}, name...), '"'), &s)
+	return err == nil && s == key
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// skipJSONString returns the end of the string starting at data[i].
+func skipJSONString(data []byte, i int) int {
+	for i++; data[i] != '"'; i++ {
+		if data[i] == '\\' {
+			i++
+		}
+	}
+	return i + 1
+}
+
+// skipJSONValue returns the end of the value starting at data[i].
+func skipJSONValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		return skipJSONString(data, i)
+	case '{', '[':
+		depth := 0
+		for ; ; i++ {
+			switch data[i] {
+			case '"':
+				i = skipJSONString(data, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+		}
+	}
+	for i < len(data) && !strings.ContainsRune(",]} \t\n\r", rune(data[i])) {
+		i++
+	}
+	return i
+}
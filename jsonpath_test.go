@@ -0,0 +1,134 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+var lookupDoc = []byte(` {
+	"payload": {
+		"customer": {"id": 42, "name": "Alice \"A\"", "vip": true, "score": 1.5, "note": null},
+		"items": [{"sku": "a-1"}, {"sku": "b-2", "tags": ["x", "y"]}],
+		"esc\u0061ped": "yes",
+		"0": "key"
+	},
+	"empty": {}
+} `)
+
+func TestJSONLookup(t *testing.T) {
+	j := JSONFrom(lookupDoc)
+	for _, test := range []struct {
+		path []string
+		want string
+	}{
+		{[]string{"payload", "customer", "id"}, `42`},
+		{[]string{"payload", "customer", "name"}, `"Alice \"A\""`},
+		{[]string{"payload", "items", "1", "tags", "0"}, `"x"`},
+		{[]string{"payload", "items", "0"}, `{"sku": "a-1"}`},
+		{[]string{"payload", "escaped"}, `"yes"`},
+		{[]string{"payload", "0"}, `"key"`},
+		{[]string{"empty"}, `{}`},
+	} {
+		got, err := j.Lookup(test.path...)
+		maybePanic(err)
+		if !got.Valid || string(got.JSON) != test.want {
+			t.Errorf("Lookup(%q) = %s, want %s", test.path, got.JSON, test.want)
+		}
+	}
+
+	whole, err := j.Lookup()
+	maybePanic(err)
+	if !whole.Valid || whole.JSON[0] != '{' {
+		t.Errorf("Lookup() = %s, want the document", whole.JSON)
+	}
+
+	null, err := j.Lookup("payload", "customer", "note")
+	maybePanic(err)
+	if !null.ExactEqual(NewJSON(nil, false)) {
+		t.Errorf("Lookup() of a null = %#v, want a set null", null)
+	}
+
+	for _, path := range [][]string{
+		{"missing"},
+		{"payload", "items", "2"},
+		{"payload", "items", "-1"},
+		{"payload", "items", "sku"},
+		{"payload", "customer", "id", "x"},
+		{"payload", "customer", "note", "x"},
+		{"empty", "x"},
+	} {
+		if _, err := j.Lookup(path...); !errors.Is(err, ErrPathNotFound) {
+			t.Errorf("Lookup(%q) error = %v, want ErrPathNotFound", path, err)
+		}
+	}
+
+	var syntax *json.SyntaxError
+	if _, err := JSONFrom([]byte(`{"a": [1,}`)).Lookup("a"); !errors.As(err, &syntax) || errors.Is(err, ErrPathNotFound) {
+		t.Errorf("Lookup() on bad JSON error = %v, want a *json.SyntaxError", err)
+	}
+
+	if got, err := NewJSON(nil, false).Lookup(); err != nil || !got.ExactEqual(NewJSON(nil, false)) {
+		t.Errorf("null Lookup() = %#v, %v", got, err)
+	}
+	if _, err := (JSON{}).Lookup("a"); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("unset Lookup(a) error = %v, want ErrPathNotFound", err)
+	}
+}
+
+func TestJSONLookupTyped(t *testing.T) {
+	j := JSONFrom(lookupDoc)
+	s, err := j.LookupString("payload", "customer", "name")
+	maybePanic(err)
+	if !s.ExactEqual(StringFrom(`Alice "A"`)) {
+		t.Errorf("LookupString() = %#v", s)
+	}
+	i, err := j.LookupInt64("payload", "customer", "id")
+	maybePanic(err)
+	if !i.ExactEqual(Int64From(42)) {
+		t.Errorf("LookupInt64() = %#v", i)
+	}
+	f, err := j.LookupFloat64("payload", "customer", "score")
+	maybePanic(err)
+	if !f.ExactEqual(Float64From(1.5)) {
+		t.Errorf("LookupFloat64() = %#v", f)
+	}
+	b, err := j.LookupBool("payload", "customer", "vip")
+	maybePanic(err)
+	if !b.ExactEqual(BoolFrom(true)) {
+		t.Errorf("LookupBool() = %#v", b)
+	}
+	s, err = j.LookupString("payload", "customer", "note")
+	maybePanic(err)
+	if !s.ExactEqual(NewString("", false)) {
+		t.Errorf("LookupString() of null = %#v", s)
+	}
+
+	if _, err := j.LookupInt64("payload", "customer", "name"); err == nil || errors.Is(err, ErrPathNotFound) {
+		t.Errorf("LookupInt64() of a string error = %v", err)
+	}
+	if _, err := j.LookupBool("payload", "nope"); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("LookupBool() of a missing path error = %v", err)
+	}
+}
+
+func BenchmarkJSONLookup(b *testing.B) {
+	j := JSONFrom(lookupDoc)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := j.LookupInt64("payload", "customer", "id"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONDecodeLookup(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v map[string]interface{}
+		if err := json.Unmarshal(lookupDoc, &v); err != nil {
+			b.Fatal(err)
+		}
+		_ = v["payload"].(map[string]interface{})["customer"].(map[string]interface{})["id"]
+	}
+}
@@ -0,0 +1,472 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding/json"
+	"encoding/json/jsontext"
+	"errors"
+	"strings"
+)
+
+// The JSON v2 methods implement json.MarshalerTo and json.UnmarshalerFrom
+// from encoding/json/v2, so its Marshal and Unmarshal, and encoding/json
+// when built on them, write and read the values straight from the token
+// stream. They are only built with Go 1.27 or later and the jsonv2
+// experiment, which provides those packages, so older toolchains and
+// GOEXPERIMENT=nojsonv2 builds are not affected.
+//
+// The output is that of MarshalJSON byte for byte, and input is decoded by
+// UnmarshalJSON, with the same errors. Unmarshal calls UnmarshalJSONFrom
+// only for a field present in the input, null included, so an absent field
+// stays unset and an explicit null sets Set, as with encoding/json.
+
+// unmarshalJSONFrom passes the next value of dec to unmarshalJSON. A
+// *json.UnmarshalTypeError it returns gets the path of the value in Field,
+// in front of any path it already has, as the original encoding/json
+// decoder adds it, since encoding/json returns errors from these methods as
+// they are. Struct is left empty, as the root type is not known here.
+func unmarshalJSONFrom(dec *jsontext.Decoder, unmarshalJSON func([]byte) error) error {
+	data, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	err = unmarshalJSON(data)
+	var te *json.UnmarshalTypeError
+	if path := strings.ReplaceAll(strings.TrimPrefix(string(dec.StackPointer()), "/"), "/", "."); path != "" && errors.As(err, &te) {
+		if te.Field != "" {
+			path += "." + te.Field
+		}
+		te.Struct, te.Field = "", path
+	}
+	return err
+}
+
+// marshalJSONTo writes what marshalJSON returns to enc.
+func marshalJSONTo(enc *jsontext.Encoder, marshalJSON func() ([]byte, error)) error {
+	data, err := marshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(data)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (b Bool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, b.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (b *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (b Byte) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, b.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (b *Byte) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (r Rune) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, r.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (r *Rune) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, r.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (b Bytes) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, b.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (b *Bytes) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (b RawBytes) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, b.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (b *RawBytes) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (h HexBytes) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, h.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (h *HexBytes) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, h.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (j JSON) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, j.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (j *JSON) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, j.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (s String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, s.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (s *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (i Int) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, i.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (i *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (i Int8) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, i.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (i *Int8) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (i Int16) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, i.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (i *Int16) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (i Int32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, i.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (i *Int32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (i Int64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, i.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (i *Int64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u Uint) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *Uint) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u Uint8) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *Uint8) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u Uint16) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *Uint16) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u Uint32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *Uint32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u Uint64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *Uint64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (f Float32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, f.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (f *Float32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, f.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (f Float64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, f.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (f *Float64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, f.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, t.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, t.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (d Date) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, d.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (d *Date) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, d.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (t TimeOfDay) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, t.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (t *TimeOfDay) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, t.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (d Duration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, d.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (d *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, d.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (d Decimal) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, d.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (d *Decimal) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, d.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (m Money) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, m.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (m *Money) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, m.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (s Secret) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, s.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (s *Secret) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u UUID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *UUID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (u URL) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, u.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (u *URL) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (ip IP) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, ip.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (ip *IP) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, ip.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (c CIDR) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, c.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (c *CIDR) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, c.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (b BigInt) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, b.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (b *BigInt) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (m Map) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, m.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (m *Map) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, m.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (m StringMap) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, m.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (m *StringMap) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, m.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (s StringSlice) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, s.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (s *StringSlice) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (s Int64Slice) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, s.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (s *Int64Slice) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (e Enum[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, e.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (e *Enum[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, e.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (v Val[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, v.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (v *Val[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, v.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (v Validated[T, V]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, v.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (v *Validated[T, V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, v.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (s Slice[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, s.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (s *Slice[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (m MapOf[K, V]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, m.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (m *MapOf[K, V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, m.UnmarshalJSON)
+}
+
+// MarshalJSONTo implements json.MarshalerTo.
+func (j JSONOf[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalJSONTo(enc, j.MarshalJSON)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom.
+func (j *JSONOf[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, j.UnmarshalJSON)
+}
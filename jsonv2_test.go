@@ -0,0 +1,91 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSONv2MatchesMarshalJSON(t *testing.T) {
+	for _, states := range binaryStates() {
+		for i, v := range states {
+			want, err := v.(json.Marshaler).MarshalJSON()
+			maybePanic(err)
+			var buf bytes.Buffer
+			enc := jsontext.NewEncoder(&buf)
+			maybePanic(v.(jsonv2.MarshalerTo).MarshalJSONTo(enc))
+			if got := bytes.TrimSuffix(buf.Bytes(), []byte("\n")); !bytes.Equal(got, want) {
+				t.Errorf("%T state %d: MarshalJSONTo = %s, MarshalJSON = %s", v, i, got, want)
+			}
+			v2, err := jsonv2.Marshal(v)
+			maybePanic(err)
+			if !bytes.Equal(v2, want) {
+				t.Errorf("%T state %d: v2 Marshal = %s, MarshalJSON = %s", v, i, v2, want)
+			}
+			v1, err := json.Marshal(v)
+			maybePanic(err)
+			if !bytes.Equal(v1, v2) {
+				t.Errorf("%T state %d: v1 Marshal = %s, v2 Marshal = %s", v, i, v1, v2)
+			}
+
+			if i == 0 {
+				continue
+			}
+			back := reflect.New(reflect.TypeOf(v))
+			maybePanic(jsonv2.Unmarshal(want, back.Interface()))
+			if !back.Elem().MethodByName("ExactEqual").Call([]reflect.Value{reflect.ValueOf(v)})[0].Bool() {
+				t.Errorf("%T state %d: v2 Unmarshal(%s) = %#v", v, i, want, back.Elem())
+			}
+		}
+	}
+}
+
+func TestJSONv2Presence(t *testing.T) {
+	type patch struct {
+		Name  String `json:"name"`
+		Count Int    `json:"count"`
+		Tags  Slice[string]
+	}
+	var p patch
+	maybePanic(jsonv2.Unmarshal([]byte(`{"name":null,"count":3}`), &p))
+	if !p.Name.IsSet() || p.Name.Valid {
+		t.Errorf("explicit null = %#v, want a set null", p.Name)
+	}
+	if p.Count != IntFrom(3) {
+		t.Errorf("count = %#v", p.Count)
+	}
+	if p.Tags.IsSet() {
+		t.Errorf("absent field = %#v, want unset", p.Tags)
+	}
+
+	type omit struct {
+		Name String `json:"name,omitzero"`
+		Note String `json:"note,omitzero"`
+	}
+	data, err := jsonv2.Marshal(omit{Name: NewString("", false)})
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"name":null}`, "v2 omitzero")
+}
+
+func TestJSONv2Errors(t *testing.T) {
+	var tm Time
+	err := jsonv2.Unmarshal([]byte(`"yesterday"`), &tm)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("v2 Unmarshal(\"yesterday\") = %v, want ErrInvalidInput", err)
+	}
+	var s struct{ I Int64 }
+	err = json.Unmarshal([]byte(`{"I":"abc"}`), &s)
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Type != reflect.TypeOf(s.I) {
+		t.Errorf("Unmarshal(\"abc\") = %v, want a type error for null.Int64", err)
+	}
+	if _, err := jsonv2.Marshal(MapOfFrom(map[testMapKey]int{{1, 2}: 3})); err == nil {
+		t.Error("v2 Marshal should report the MarshalJSON error")
+	}
+}
@@ -0,0 +1,128 @@
+package null
+
+import "time"
+
+// Lift returns a slice of vals converted by from, such as StringFrom, so a
+// []string from a request becomes a []String in one call:
+//
+//	tags := null.Lift(req.Tags, null.StringFrom)
+//
+// LiftStrings and the other Lift functions do this for the common types.
+// A nil vals gives a nil slice, and an empty one an empty slice.
+func Lift[T, N any](vals []T, from func(T) N) []N {
+	if vals == nil {
+		return nil
+	}
+	out := make([]N, len(vals))
+	for i, v := range vals {
+		out[i] = from(v)
+	}
+	return out
+}
+
+// LiftPtrs is Lift for a slice of pointers and a constructor such as
+// StringFromPtr, which makes the nil elements nulls.
+func LiftPtrs[T, N any](ptrs []*T, fromPtr func(*T) N) []N {
+	return Lift(ptrs, fromPtr)
+}
+
+// CollectValid returns the payloads of the valid values in vals, in order,
+// dropping nulls and unset values. A nil vals gives a nil slice, and one
+// without valid values an empty slice.
+func CollectValid[N Getter[T], T any](vals []N) []T {
+	if vals == nil {
+		return nil
+	}
+	out := make([]T, 0, len(vals))
+	for _, v := range vals {
+		if x, ok := v.Get(); ok {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// CollectOrZero returns the payloads of vals, with the zero value of T in
+// place of each null or unset value, so the result has the length of vals.
+// A nil vals gives a nil slice.
+func CollectOrZero[N Getter[T], T any](vals []N) []T {
+	if vals == nil {
+		return nil
+	}
+	out := make([]T, len(vals))
+	for i, v := range vals {
+		if x, ok := v.Get(); ok {
+			out[i] = x
+		}
+	}
+	return out
+}
+
+// CollectPtrs returns pointers to the payloads of vals, with nil in place
+// of each null or unset value, the inverse of LiftPtrs. The pointers are to
+// copies, which share one backing array. A nil vals gives a nil slice.
+func CollectPtrs[N Getter[T], T any](vals []N) []*T {
+	if vals == nil {
+		return nil
+	}
+	out := make([]*T, len(vals))
+	payloads := make([]T, len(vals))
+	for i, v := range vals {
+		if x, ok := v.Get(); ok {
+			payloads[i] = x
+			out[i] = &payloads[i]
+		}
+	}
+	return out
+}
+
+// LiftStrings converts a []string to a []String of valid values.
+func LiftStrings(vals []string) []String {
+	return Lift(vals, StringFrom)
+}
+
+// LiftStringPtrs converts a []*string to a []String, nil elements to null.
+func LiftStringPtrs(ptrs []*string) []String {
+	return LiftPtrs(ptrs, StringFromPtr)
+}
+
+// LiftInt64s converts a []int64 to a []Int64 of valid values.
+func LiftInt64s(vals []int64) []Int64 {
+	return Lift(vals, Int64From)
+}
+
+// LiftInt64Ptrs converts a []*int64 to a []Int64, nil elements to null.
+func LiftInt64Ptrs(ptrs []*int64) []Int64 {
+	return LiftPtrs(ptrs, Int64FromPtr)
+}
+
+// LiftFloat64s converts a []float64 to a []Float64 of valid values.
+func LiftFloat64s(vals []float64) []Float64 {
+	return Lift(vals, Float64From)
+}
+
+// LiftFloat64Ptrs converts a []*float64 to a []Float64, nil elements to
+// null.
+func LiftFloat64Ptrs(ptrs []*float64) []Float64 {
+	return LiftPtrs(ptrs, Float64FromPtr)
+}
+
+// LiftBools converts a []bool to a []Bool of valid values.
+func LiftBools(vals []bool) []Bool {
+	return Lift(vals, BoolFrom)
+}
+
+// LiftBoolPtrs converts a []*bool to a []Bool, nil elements to null.
+func LiftBoolPtrs(ptrs []*bool) []Bool {
+	return LiftPtrs(ptrs, BoolFromPtr)
+}
+
+// LiftTimes converts a []time.Time to a []Time of valid values.
+func LiftTimes(vals []time.Time) []Time {
+	return Lift(vals, TimeFrom)
+}
+
+// LiftTimePtrs converts a []*time.Time to a []Time, nil elements to null.
+func LiftTimePtrs(ptrs []*time.Time) []Time {
+	return LiftPtrs(ptrs, TimeFromPtr)
+}
@@ -0,0 +1,89 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLift(t *testing.T) {
+	if got := LiftStrings([]string{"a", ""}); !reflect.DeepEqual(got, []String{StringFrom("a"), StringFrom("")}) {
+		t.Errorf("LiftStrings() = %#v", got)
+	}
+	if got := LiftInt64s([]int64{1, 0, -1}); !reflect.DeepEqual(got, []Int64{Int64From(1), Int64From(0), Int64From(-1)}) {
+		t.Errorf("LiftInt64s() = %#v", got)
+	}
+	if got := LiftFloat64s([]float64{0.5}); !reflect.DeepEqual(got, []Float64{Float64From(0.5)}) {
+		t.Errorf("LiftFloat64s() = %#v", got)
+	}
+	if got := LiftBools([]bool{false}); !reflect.DeepEqual(got, []Bool{BoolFrom(false)}) {
+		t.Errorf("LiftBools() = %#v", got)
+	}
+	now := time.Now()
+	if got := LiftTimes([]time.Time{now}); !reflect.DeepEqual(got, []Time{TimeFrom(now)}) {
+		t.Errorf("LiftTimes() = %#v", got)
+	}
+	if got := Lift([]testColor{"red"}, EnumFrom[testColor]); !reflect.DeepEqual(got, []Enum[testColor]{EnumFrom[testColor]("red")}) {
+		t.Errorf("Lift() of Enum = %#v", got)
+	}
+
+	s, i, f, b := "a", int64(2), 1.5, true
+	if got := LiftStringPtrs([]*string{&s, nil}); !reflect.DeepEqual(got, []String{StringFrom("a"), NewString("", false)}) {
+		t.Errorf("LiftStringPtrs() = %#v", got)
+	}
+	if got := LiftInt64Ptrs([]*int64{nil, &i}); !reflect.DeepEqual(got, []Int64{NewInt64(0, false), Int64From(2)}) {
+		t.Errorf("LiftInt64Ptrs() = %#v", got)
+	}
+	if got := LiftFloat64Ptrs([]*float64{&f}); !reflect.DeepEqual(got, []Float64{Float64From(1.5)}) {
+		t.Errorf("LiftFloat64Ptrs() = %#v", got)
+	}
+	if got := LiftBoolPtrs([]*bool{nil, &b}); !reflect.DeepEqual(got, []Bool{NewBool(false, false), BoolFrom(true)}) {
+		t.Errorf("LiftBoolPtrs() = %#v", got)
+	}
+	if got := LiftTimePtrs([]*time.Time{&now, nil}); !reflect.DeepEqual(got, []Time{TimeFrom(now), NewTime(time.Time{}, false)}) {
+		t.Errorf("LiftTimePtrs() = %#v", got)
+	}
+
+	if LiftStrings(nil) != nil || LiftTimePtrs(nil) != nil || Lift[int](nil, IntFrom) != nil {
+		t.Error("Lift of nil is not nil")
+	}
+	if got := LiftInt64s([]int64{}); got == nil || len(got) != 0 {
+		t.Errorf("LiftInt64s() of an empty slice = %#v", got)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	vals := []String{StringFrom("a"), NewString("stale", false), {}, StringFrom("")}
+	if got := CollectValid(vals); !reflect.DeepEqual(got, []string{"a", ""}) {
+		t.Errorf("CollectValid() = %#v", got)
+	}
+	if got := CollectOrZero(vals); !reflect.DeepEqual(got, []string{"a", "", "", ""}) {
+		t.Errorf("CollectOrZero() = %#v", got)
+	}
+	ptrs := CollectPtrs(vals)
+	if len(ptrs) != 4 || *ptrs[0] != "a" || ptrs[1] != nil || ptrs[2] != nil || *ptrs[3] != "" {
+		t.Errorf("CollectPtrs() = %#v", ptrs)
+	}
+	*ptrs[0] = "changed"
+	if vals[0].String != "a" {
+		t.Error("CollectPtrs() shares the payloads")
+	}
+	if got := LiftStringPtrs(ptrs); !reflect.DeepEqual(got, []String{StringFrom("changed"), NewString("", false), NewString("", false), StringFrom("")}) {
+		t.Errorf("LiftStringPtrs(CollectPtrs()) = %#v", got)
+	}
+
+	times := []Time{NewTime(time.Unix(1, 0), false), TimeFrom(time.Unix(2, 0))}
+	if got := CollectOrZero(times); !reflect.DeepEqual(got, []time.Time{{}, time.Unix(2, 0)}) {
+		t.Errorf("CollectOrZero() of Time = %#v", got)
+	}
+	if got := CollectValid([]Int64{NewInt64(1, false), {}}); got == nil || len(got) != 0 {
+		t.Errorf("CollectValid() of nulls = %#v, want an empty slice", got)
+	}
+	if got := CollectValid([]Val[int]{ValFrom(3)}); !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("CollectValid() of Val = %#v", got)
+	}
+
+	if CollectValid([]Bool(nil)) != nil || CollectOrZero([]Float64(nil)) != nil || CollectPtrs([]Int64(nil)) != nil {
+		t.Error("Collect of nil is not nil")
+	}
+}
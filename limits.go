@@ -0,0 +1,23 @@
+package null
+
+// Size limits for values decoded by UnmarshalJSON, UnmarshalText and Scan,
+// for types exposed to untrusted input. Zero, the default, means no limit.
+// A longer value is an error matching ErrTooLong, and leaves the value
+// null.
+var (
+	// MaxStringLen limits the length of a String in bytes.
+	MaxStringLen = 0
+	// MaxBytesLen limits the length of a Bytes, HexBytes or RawBytes after
+	// decoding.
+	MaxBytesLen = 0
+	// MaxJSONLen limits the length of the raw JSON held by a JSON.
+	MaxJSONLen = 0
+)
+
+// checkLen returns a *TooLongError if n exceeds a limit that is set.
+func checkLen(typ string, limit, n int) error {
+	if limit > 0 && n > limit {
+		return &TooLongError{Type: typ, Limit: limit, Len: n}
+	}
+	return nil
+}
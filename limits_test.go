@@ -0,0 +1,88 @@
+package null
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSizeLimits(t *testing.T) {
+	defer func(s, b, j int) { MaxStringLen, MaxBytesLen, MaxJSONLen = s, b, j }(MaxStringLen, MaxBytesLen, MaxJSONLen)
+	MaxStringLen, MaxBytesLen, MaxJSONLen = 4, 4, 6
+
+	quote := func(s string) []byte { b, _ := json.Marshal(s); return b }
+	b64 := func(s string) []byte { return quote(base64.StdEncoding.EncodeToString([]byte(s))) }
+	tests := []struct {
+		name       string
+		new        func() interface{}
+		json, text func(n int) []byte
+		scan       func(n int) interface{}
+		limit      int
+	}{
+		{"String", func() interface{} { return &String{} },
+			func(n int) []byte { return quote(strings.Repeat("é", n/2)) },
+			func(n int) []byte { return []byte(strings.Repeat("a", n)) },
+			func(n int) interface{} { return strings.Repeat("a", n) }, 4},
+		{"Bytes", func() interface{} { return &Bytes{} },
+			func(n int) []byte { return b64(strings.Repeat("a", n)) },
+			func(n int) []byte { return []byte(strings.Repeat("a", n)) },
+			func(n int) interface{} { return []byte(strings.Repeat("a", n)) }, 4},
+		{"RawBytes", func() interface{} { return &RawBytes{} },
+			func(n int) []byte { return quote(strings.Repeat("a", n)) },
+			func(n int) []byte { return []byte(strings.Repeat("a", n)) },
+			func(n int) interface{} { return strings.Repeat("a", n) }, 4},
+		{"JSON", func() interface{} { return &JSON{} },
+			func(n int) []byte { return []byte(`"` + strings.Repeat("a", n-2) + `"`) },
+			func(n int) []byte { return []byte(`"` + strings.Repeat("a", n-2) + `"`) },
+			func(n int) interface{} { return `"` + strings.Repeat("a", n-2) + `"` }, 6},
+	}
+	for _, test := range tests {
+		entries := []struct {
+			name string
+			call func(v interface{}, n int) error
+		}{
+			{"UnmarshalJSON", func(v interface{}, n int) error { return v.(json.Unmarshaler).UnmarshalJSON(test.json(n)) }},
+			{"UnmarshalText", func(v interface{}, n int) error {
+				return v.(interface{ UnmarshalText([]byte) error }).UnmarshalText(test.text(n))
+			}},
+			{"Scan", func(v interface{}, n int) error {
+				return v.(interface{ Scan(interface{}) error }).Scan(test.scan(n))
+			}},
+		}
+		for _, entry := range entries {
+			v := test.new()
+			if err := entry.call(v, test.limit); err != nil {
+				t.Errorf("%s.%s at the limit: %v", test.name, entry.name, err)
+			}
+			if !v.(Value).IsValid() {
+				t.Errorf("%s.%s at the limit = %#v, want valid", test.name, entry.name, v)
+			}
+
+			err := entry.call(v, test.limit+2)
+			var tooLong *TooLongError
+			if !errors.Is(err, ErrTooLong) || !errors.As(err, &tooLong) {
+				t.Errorf("%s.%s over the limit = %v, want ErrTooLong", test.name, entry.name, err)
+				continue
+			}
+			if tooLong.Type != "null."+test.name || tooLong.Limit != test.limit || tooLong.Len != test.limit+2 {
+				t.Errorf("%s.%s over the limit = %#v", test.name, entry.name, tooLong)
+			}
+			back := reflect.ValueOf(v).Elem()
+			if back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() || !back.Field(0).IsZero() {
+				t.Errorf("%s.%s over the limit left %#v, want a set null", test.name, entry.name, v)
+			}
+		}
+	}
+
+	if want := "null: null.String of 6 bytes exceeds the limit of 4"; new(String).UnmarshalText([]byte("abcdef")).Error() != want {
+		t.Errorf("TooLongError message, want %q", want)
+	}
+
+	MaxStringLen = 0
+	if err := new(String).UnmarshalText([]byte(strings.Repeat("a", 1<<16))); err != nil {
+		t.Errorf("no limit: %v", err)
+	}
+}
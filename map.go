@@ -0,0 +1,547 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+)
+
+// Map is a nullable JSON object, such as a flat jsonb column.
+// Constructors and SetValid deep-copy nested maps and slices, so the
+// caller's map can be changed afterwards without affecting the Map.
+type Map struct {
+	Map   map[string]interface{}
+	Valid bool
+	Set   bool
+}
+
+// NewMap creates a new Map holding a copy of m, nested maps and slices
+// included.
+func NewMap(m map[string]interface{}, valid bool) Map {
+	return Map{
+		Map:   copyMap(m),
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// MapFrom creates a new Map that will always be valid.
+// A nil map is valid and marshals to {}.
+func MapFrom(m map[string]interface{}) Map {
+	return NewMap(m, true)
+}
+
+// MapFromPtr creates a new Map that will be null if m is nil.
+func MapFromPtr(m *map[string]interface{}) Map {
+	if m == nil {
+		return NewMap(nil, false)
+	}
+	return NewMap(*m, true)
+}
+
+// MapFromNonEmpty creates a new Map that will be null if m is empty.
+func MapFromNonEmpty(m map[string]interface{}) Map {
+	return NewMap(m, len(m) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (m Map) IsValid() bool {
+	return m.Set && m.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (m Map) IsSet() bool {
+	return m.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (m Map) IsNull() bool {
+	return m.Set && !m.Valid
+}
+
+// Equal reports whether m and other are both null, or both valid with
+// the same value, a nil map being equal to an empty one. Set is ignored;
+// see ExactEqual.
+func (m Map) Equal(other Map) bool {
+	if !m.Valid || !other.Valid {
+		return m.Valid == other.Valid
+	}
+	if len(m.Map) == 0 && len(other.Map) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(m.Map, other.Map)
+}
+
+// ExactEqual is like Equal, but also requires m and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (m Map) ExactEqual(other Map) bool {
+	return m.Set == other.Set && m.Equal(other)
+}
+
+// Clone returns a copy of m with its own copy of the map, so that
+// changes to either do not show in the other. Nested maps and slices, as
+// decoded from JSON, are copied too; other values are shared.
+func (m Map) Clone() Map {
+	m.Map = copyMap(m.Map)
+	return m
+}
+
+// Get returns the value stored under key, and whether it was present.
+// A null Map has no keys.
+func (m Map) Get(key string) (interface{}, bool) {
+	if !m.Valid {
+		return nil, false
+	}
+	v, ok := m.Map[key]
+	return v, ok
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports object and null input.
+func (m *Map) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Map", m, data)
+	m.Set = true
+	if bytes.Equal(data, NullBytes) {
+		m.Map, m.Valid = nil, false
+		return nil
+	}
+
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	m.Map, m.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Map is null.
+func (m Map) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return NullBytes, nil
+	}
+	if m.Map == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.Map)
+}
+
+// SetValid changes this Map's value to a copy of v and also sets it to be
+// non-null.
+func (m *Map) SetValid(v map[string]interface{}) {
+	m.Map = copyMap(v)
+	m.Valid = true
+	m.Set = true
+}
+
+// SetNull sets this Map to an explicit null, zeroing its value.
+func (m *Map) SetNull() {
+	*m = Map{Set: true}
+}
+
+// Unset resets this Map to the zero value, as if it was never set.
+func (m *Map) Unset() {
+	*m = Map{}
+}
+
+// SetPtr changes this Map to the value p points to, or to an explicit
+// null if p is nil.
+func (m *Map) SetPtr(p *map[string]interface{}) {
+	*m = MapFromPtr(p)
+}
+
+// Ptr returns a pointer to this Map's value, or a nil pointer if this Map is null.
+func (m Map) Ptr() *map[string]interface{} {
+	if !m.Valid {
+		return nil
+	}
+	return &m.Map
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (m Map) ValueOrZero() map[string]interface{} {
+	if !m.Valid {
+		return nil
+	}
+	return m.Map
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (m Map) Or(def map[string]interface{}) map[string]interface{} {
+	if !m.Valid {
+		return def
+	}
+	return m.Map
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (m Map) Val() map[string]interface{} {
+	return m.ValueOrZero()
+}
+
+// MustGet returns the inner value, or panics with a *NullError if m is
+// null.
+func (m Map) MustGet() map[string]interface{} {
+	if !m.Valid {
+		panic(&NullError{Type: "null.Map"})
+	}
+	return m.Map
+}
+
+// IsZero returns true if this Map was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (m Map) IsZero() bool {
+	return !m.Set && !m.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Map is null.
+func (m Map) MarshalYAML() (interface{}, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	if m.Map == nil {
+		return map[string]interface{}{}, nil
+	}
+	return m.Map, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Map if the input is null or ~.
+func (m *Map) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	m.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+
+	res := map[string]interface{}{}
+	if err := unmarshal(&res); err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	if _, err := yamlToJSONValue(res); err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	m.Map, m.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Map is null.
+func (m Map) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null Map.
+func (m *Map) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(m.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Map is null.
+func (m Map) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Map.
+func (m *Map) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(m.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON document, or BSON null if this Map is null.
+func (m Map) MarshalBSONValue() (byte, []byte, error) {
+	if !m.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON document, decoded as UnmarshalJSON decodes JSON.
+func (m *Map) UnmarshalBSONValue(typ byte, data []byte) error {
+	m.Set = true
+	if bsonIsNull(typ) {
+		m.Map, m.Valid = nil, false
+		return nil
+	}
+	if typ != bsonDocument {
+		m.Map, m.Valid = nil, false
+		return bsonTypeError(typ, "null.Map")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack map, or nil if this Map is null.
+func (m Map) MarshalMsgpack() ([]byte, error) {
+	if !m.Valid {
+		return msgpackNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack map, decoded as UnmarshalJSON decodes JSON.
+func (m *Map) UnmarshalMsgpack(data []byte) error {
+	m.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.Map, m.Valid = nil, false
+		return msgpackTypeError(v, "null.Map")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR map, or null if this Map is null.
+func (m Map) MarshalCBOR() ([]byte, error) {
+	if !m.Valid {
+		return cborNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR map, decoded as UnmarshalJSON decodes JSON.
+func (m *Map) UnmarshalCBOR(data []byte) error {
+	m.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.Map, m.Valid = nil, false
+		return cborTypeError(v, "null.Map")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		m.Map, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (m Map) MarshalGQL(w io.Writer) {
+	marshalGQL(w, m.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Map.
+func (m *Map) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, m.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this Map is
+// null.
+func (m Map) MarshalCSV() (string, error) {
+	return marshalCSV(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null Map.
+func (m *Map) UnmarshalCSV(cell string) error {
+	return jsonText(m.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Map is null.
+func (m Map) MarshalTOML() ([]byte, error) {
+	return marshalTOML(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null Map.
+func (m *Map) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, m.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets an object with one random string, or an explicit null if shouldBeNull is true.
+func (m *Map) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*m = NewMap(nil, false)
+		return
+	}
+	m.SetValid(map[string]interface{}{"key": randomString(nextInt, 8)})
+}
+
+// Generate implements testing/quick's Generator.
+func (m Map) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewMap(nil, false), func() Map {
+		m := make(map[string]interface{})
+		for i := generateLen(rand, size); i > 0; i-- {
+			m[generateWord(rand)] = generateText(rand, size)
+		}
+		return MapFrom(m)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (m Map) MarshalBinary() ([]byte, error) {
+	return marshalBinary(m.Set, m.Valid, func() ([]byte, error) {
+		return m.MarshalJSON()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *Map) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Map")
+	if err != nil {
+		return err
+	}
+	var v map[string]interface{}
+	if valid {
+		v = map[string]interface{}{}
+		if err = json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+	}
+	m.Map, m.Valid, m.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It decodes a JSON object from string or []byte; a JSON null is null.
+func (m *Map) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Map", value)
+	switch x := value.(type) {
+	case nil:
+		m.Map, m.Valid, m.Set = nil, false, true
+		return nil
+	case string:
+		err = m.UnmarshalJSON([]byte(x))
+	case []byte:
+		err = m.UnmarshalJSON(x)
+	default:
+		m.Map, m.Valid, m.Set = nil, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.Map: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The Map is encoded as JSON, returned as a string if JSONValueAsString
+// is set.
+func (m Map) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if JSONValueAsString {
+		return string(data), nil
+	}
+	return data, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (m Map) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString implements the GoStringer interface, formatting m as the Go
+// expression that builds it.
+func (m Map) GoString() string {
+	return goString("Map", "", "Map", fmt.Sprintf("%#v", m.Map), m.Map == nil, m.Valid, m.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// m with the verb if it is valid.
+func (m Map) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, m, m.Valid, m.Set, m.Map)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the map, or to nil if this Map is null.
+func (m Map) LogValue() slog.Value {
+	if !m.Valid {
+		return logNull
+	}
+	return slog.AnyValue(m.Map)
+}
+
+// copyMap deep-copies the maps and slices decoded JSON is made of.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = copyJSONTree(v)
+	}
+	return res
+}
+
+func copyJSONTree(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return copyMap(x)
+	case []interface{}:
+		if x == nil {
+			return x
+		}
+		res := make([]interface{}, len(x))
+		for i, e := range x {
+			res[i] = copyJSONTree(e)
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,199 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var (
+	mapJSON = []byte(`{"a":1,"b":{"c":[true,"d"]}}`)
+)
+
+func mapValue() map[string]interface{} {
+	return map[string]interface{}{
+		"a": float64(1),
+		"b": map[string]interface{}{"c": []interface{}{true, "d"}},
+	}
+}
+
+func TestMapFrom(t *testing.T) {
+	m := MapFrom(mapValue())
+	assertMap(t, m, "MapFrom()")
+
+	empty := MapFrom(nil)
+	if !empty.Valid {
+		t.Error("MapFrom(nil)", "is invalid, but should be valid")
+	}
+}
+
+func TestMapFromPtr(t *testing.T) {
+	v := mapValue()
+	m := MapFromPtr(&v)
+	assertMap(t, m, "MapFromPtr()")
+
+	null := MapFromPtr(nil)
+	assertNullMap(t, null, "MapFromPtr(nil)")
+}
+
+func TestMapCopy(t *testing.T) {
+	v := mapValue()
+	m := MapFrom(v)
+	v["a"] = "changed"
+	v["b"].(map[string]interface{})["c"].([]interface{})[0] = false
+	assertMap(t, m, "MapFrom() after mutating argument")
+
+	var set Map
+	v = mapValue()
+	set.SetValid(v)
+	v["b"].(map[string]interface{})["c"] = nil
+	assertMap(t, set, "SetValid() after mutating argument")
+
+	clone := m.Clone()
+	m.Map["b"].(map[string]interface{})["c"].([]interface{})[0] = false
+	assertMap(t, clone, "Clone() after mutating original")
+}
+
+func TestMapGet(t *testing.T) {
+	m := MapFrom(mapValue())
+	if v, ok := m.Get("a"); !ok || v != float64(1) {
+		t.Errorf("Get(a) = %v, %v", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Get(missing) should not be found")
+	}
+
+	null := NewMap(mapValue(), false)
+	if _, ok := null.Get("a"); ok {
+		t.Error("Get on null Map should not be found")
+	}
+}
+
+func TestUnmarshalMap(t *testing.T) {
+	var m Map
+	err := json.Unmarshal(mapJSON, &m)
+	maybePanic(err)
+	assertMap(t, m, "map json")
+
+	var empty Map
+	err = json.Unmarshal([]byte(`{}`), &empty)
+	maybePanic(err)
+	if !empty.Valid || empty.Map == nil || len(empty.Map) != 0 {
+		t.Errorf("bad empty map: %v", empty)
+	}
+
+	var null Map
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullMap(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType Map
+	err = json.Unmarshal([]byte(`[1,2]`), &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullMap(t, badType, "wrong type json")
+}
+
+func TestMarshalMap(t *testing.T) {
+	m := MapFrom(mapValue())
+	data, err := json.Marshal(m)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(mapJSON), "non-empty json marshal")
+
+	for _, empty := range []Map{MapFrom(nil), MapFrom(map[string]interface{}{})} {
+		data, err = json.Marshal(empty)
+		maybePanic(err)
+		assertJSONEquals(t, data, "{}", "empty json marshal")
+	}
+
+	null := NewMap(nil, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestMapScanValue(t *testing.T) {
+	for _, v := range []interface{}{string(mapJSON), mapJSON} {
+		var m Map
+		err := m.Scan(v)
+		maybePanic(err)
+		assertMap(t, m, "scanned map")
+
+		value, err := m.Value()
+		maybePanic(err)
+		if string(value.([]byte)) != string(mapJSON) {
+			t.Errorf("bad value: %s", value)
+		}
+	}
+
+	var empty Map
+	err := empty.Scan("{}")
+	maybePanic(err)
+	value, err := empty.Value()
+	maybePanic(err)
+	if string(value.([]byte)) != "{}" {
+		t.Errorf("bad empty value: %s", value)
+	}
+
+	for _, v := range []interface{}{nil, "null"} {
+		var null Map
+		err := null.Scan(v)
+		maybePanic(err)
+		assertNullMap(t, null, "scanned null")
+		if !null.Set {
+			t.Error("should be Set")
+		}
+		if v, err := null.Value(); v != nil || err != nil {
+			t.Error("bad value or err:", v, err)
+		}
+	}
+
+	for _, v := range []interface{}{"[]", "{", int64(1)} {
+		var wrong Map
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullMap(t, wrong, "scanned garbage")
+	}
+}
+
+func TestMapPointer(t *testing.T) {
+	m := MapFrom(mapValue())
+	ptr := m.Ptr()
+	if ptr == nil || len(*ptr) != 2 {
+		t.Errorf("bad %s map: %#v\n", "pointer", ptr)
+	}
+
+	null := NewMap(nil, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s map: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestMapSetValid(t *testing.T) {
+	change := NewMap(nil, false)
+	assertNullMap(t, change, "SetValid()")
+	change.SetValid(mapValue())
+	assertMap(t, change, "SetValid()")
+}
+
+func assertMap(t *testing.T, m Map, from string) {
+	data, err := json.Marshal(m.Map)
+	maybePanic(err)
+	if string(data) != string(mapJSON) {
+		t.Errorf("bad %s map: %s ≠ %s\n", from, data, mapJSON)
+	}
+	if !m.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullMap(t *testing.T, m Map, from string) {
+	if m.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,574 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"reflect"
+)
+
+// MapOf is a nullable map[K]V stored as a JSON object, such as a jsonb
+// column of typed metadata like map[string]int64. Values are encoded and
+// decoded by encoding/json, and so are keys, which must follow its rules
+// for map keys: K must be a string or integer type, or implement both
+// encoding.TextMarshaler and encoding.TextUnmarshaler. Other key types make
+// every encoding and decoding fail with an error naming the type.
+//
+// Constructors and SetValid copy the map, so the caller's map can be
+// changed afterwards without affecting the MapOf. The values are copied
+// as by assignment, so maps, slices and pointers inside them are shared.
+type MapOf[K comparable, V any] struct {
+	MapOf map[K]V
+	Valid bool
+	Set   bool
+}
+
+// NewMapOf creates a new MapOf holding a copy of m.
+func NewMapOf[K comparable, V any](m map[K]V, valid bool) MapOf[K, V] {
+	return MapOf[K, V]{
+		MapOf: maps.Clone(m),
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// MapOfFrom creates a new MapOf that will always be valid.
+// A nil map is valid and marshals to {}.
+func MapOfFrom[K comparable, V any](m map[K]V) MapOf[K, V] {
+	return NewMapOf(m, true)
+}
+
+// MapOfFromPtr creates a new MapOf that will be null if m is nil.
+func MapOfFromPtr[K comparable, V any](m *map[K]V) MapOf[K, V] {
+	if m == nil {
+		return NewMapOf[K, V](nil, false)
+	}
+	return NewMapOf(*m, true)
+}
+
+// MapOfFromNonEmpty creates a new MapOf that will be null if m is empty.
+func MapOfFromNonEmpty[K comparable, V any](m map[K]V) MapOf[K, V] {
+	return NewMapOf(m, len(m) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (m MapOf[K, V]) IsValid() bool {
+	return m.Set && m.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (m MapOf[K, V]) IsSet() bool {
+	return m.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (m MapOf[K, V]) IsNull() bool {
+	return m.Set && !m.Valid
+}
+
+// Equal reports whether m and other are both null, or both valid with the
+// same keys holding deeply equal values. A nil map equals an empty one.
+// Set is ignored; see ExactEqual.
+func (m MapOf[K, V]) Equal(other MapOf[K, V]) bool {
+	if !m.Valid || !other.Valid {
+		return m.Valid == other.Valid
+	}
+	return maps.EqualFunc(m.MapOf, other.MapOf, func(a, b V) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// ExactEqual is like Equal, but also requires m and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (m MapOf[K, V]) ExactEqual(other MapOf[K, V]) bool {
+	return m.Set == other.Set && m.Equal(other)
+}
+
+// Clone returns a copy of m with its own copy of the map, so that
+// changes to either do not show in the other. The values are copied as by
+// assignment.
+func (m MapOf[K, V]) Clone() MapOf[K, V] {
+	m.MapOf = maps.Clone(m.MapOf)
+	return m
+}
+
+// Len returns the number of keys, which is 0 if this MapOf is null.
+func (m MapOf[K, V]) Len() int {
+	if !m.Valid {
+		return 0
+	}
+	return len(m.MapOf)
+}
+
+// Get returns the value stored under key, and whether it was present.
+// A null MapOf has no keys.
+func (m MapOf[K, V]) Get(key K) (V, bool) {
+	if !m.Valid {
+		var zero V
+		return zero, false
+	}
+	v, ok := m.MapOf[key]
+	return v, ok
+}
+
+// SetKey stores value under key, making a null or unset MapOf a valid one
+// holding only that key. It is not named Set, which is taken by the field.
+func (m *MapOf[K, V]) SetKey(key K, value V) {
+	if !m.Valid || m.MapOf == nil {
+		m.MapOf = map[K]V{}
+	}
+	m.MapOf[key] = value
+	m.Valid, m.Set = true, true
+}
+
+// checkMapOfKey reports an error if encoding/json cannot use K as a map
+// key.
+func checkMapOfKey[K comparable]() error {
+	t := reflect.TypeOf((*K)(nil)).Elem()
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return nil
+	}
+	if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+	return fmt.Errorf("null: cannot use %s as a null.MapOf key, want a string or integer type or an encoding.TextMarshaler and TextUnmarshaler", t)
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports object and null input.
+func (m *MapOf[K, V]) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.MapOf", m, data)
+	m.Set = true
+	m.MapOf, m.Valid = nil, false
+	if err := checkMapOfKey[K](); err != nil {
+		return err
+	}
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+
+	res := map[K]V{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	m.MapOf, m.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this MapOf is null.
+func (m MapOf[K, V]) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return NullBytes, nil
+	}
+	if err := checkMapOfKey[K](); err != nil {
+		return nil, err
+	}
+	if m.MapOf == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.MapOf)
+}
+
+// SetValid changes this MapOf's value to a copy of v and also sets it to be
+// non-null.
+func (m *MapOf[K, V]) SetValid(v map[K]V) {
+	m.MapOf = maps.Clone(v)
+	m.Valid = true
+	m.Set = true
+}
+
+// SetNull sets this MapOf to an explicit null, zeroing its value.
+func (m *MapOf[K, V]) SetNull() {
+	*m = MapOf[K, V]{Set: true}
+}
+
+// Unset resets this MapOf to the zero value, as if it was never set.
+func (m *MapOf[K, V]) Unset() {
+	*m = MapOf[K, V]{}
+}
+
+// SetPtr changes this MapOf to a copy of the value p points to, or to an
+// explicit null if p is nil.
+func (m *MapOf[K, V]) SetPtr(p *map[K]V) {
+	*m = MapOfFromPtr(p)
+}
+
+// Ptr returns a pointer to this MapOf's value, or a nil pointer if this MapOf is null.
+func (m MapOf[K, V]) Ptr() *map[K]V {
+	if !m.Valid {
+		return nil
+	}
+	return &m.MapOf
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (m MapOf[K, V]) ValueOrZero() map[K]V {
+	if !m.Valid {
+		return nil
+	}
+	return m.MapOf
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (m MapOf[K, V]) Or(def map[K]V) map[K]V {
+	if !m.Valid {
+		return def
+	}
+	return m.MapOf
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (m MapOf[K, V]) Val() map[K]V {
+	return m.ValueOrZero()
+}
+
+// MustGet returns the inner value, or panics with a *NullError if m is
+// null.
+func (m MapOf[K, V]) MustGet() map[K]V {
+	if !m.Valid {
+		panic(&NullError{Type: "null.MapOf" + goTypeArgs2[K, V]()})
+	}
+	return m.MapOf
+}
+
+// IsZero returns true if this MapOf was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (m MapOf[K, V]) IsZero() bool {
+	return !m.Set && !m.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The map is encoded by the YAML package as map[K]V. It will encode null
+// if this MapOf is null.
+func (m MapOf[K, V]) MarshalYAML() (interface{}, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	if m.MapOf == nil {
+		return map[K]V{}, nil
+	}
+	return m.MapOf, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// The map is decoded by the YAML package as map[K]V. It will unmarshal to
+// a null MapOf if the input is null or ~.
+func (m *MapOf[K, V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	m.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+
+	res := map[K]V{}
+	if err := unmarshal(&res); err != nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	m.MapOf, m.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this MapOf is null.
+func (m MapOf[K, V]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null MapOf.
+func (m *MapOf[K, V]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(m.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this MapOf is null.
+func (m MapOf[K, V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null MapOf.
+func (m *MapOf[K, V]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(m.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes the JSON of MarshalJSON as a BSON document, or BSON null if
+// this MapOf is null.
+func (m MapOf[K, V]) MarshalBSONValue() (byte, []byte, error) {
+	if !m.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON document, decoded as UnmarshalJSON decodes JSON.
+func (m *MapOf[K, V]) UnmarshalBSONValue(typ byte, data []byte) error {
+	m.Set = true
+	if bsonIsNull(typ) {
+		m.MapOf, m.Valid = nil, false
+		return nil
+	}
+	if typ != bsonDocument {
+		m.MapOf, m.Valid = nil, false
+		return bsonTypeError(typ, "null.MapOf")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the JSON of MarshalJSON as a msgpack map, or nil if this
+// MapOf is null.
+func (m MapOf[K, V]) MarshalMsgpack() ([]byte, error) {
+	if !m.Valid {
+		return msgpackNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack map, decoded as UnmarshalJSON decodes JSON.
+func (m *MapOf[K, V]) UnmarshalMsgpack(data []byte) error {
+	m.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.MapOf, m.Valid = nil, false
+		return msgpackTypeError(v, "null.MapOf")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the JSON of MarshalJSON as a CBOR map, or null if this MapOf
+// is null.
+func (m MapOf[K, V]) MarshalCBOR() ([]byte, error) {
+	if !m.Valid {
+		return cborNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR map, decoded as UnmarshalJSON decodes JSON.
+func (m *MapOf[K, V]) UnmarshalCBOR(data []byte) error {
+	m.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.MapOf, m.Valid = nil, false
+		return cborTypeError(v, "null.MapOf")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		m.MapOf, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (m MapOf[K, V]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, m.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null MapOf.
+func (m *MapOf[K, V]) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, m.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this MapOf is
+// null.
+func (m MapOf[K, V]) MarshalCSV() (string, error) {
+	return marshalCSV(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null MapOf.
+func (m *MapOf[K, V]) UnmarshalCSV(cell string) error {
+	return jsonText(m.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this MapOf is null.
+func (m MapOf[K, V]) MarshalTOML() ([]byte, error) {
+	return marshalTOML(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null MapOf.
+func (m *MapOf[K, V]) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, m.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a map of one random key and value, randomized as Val randomizes
+// K and V, or an explicit null if shouldBeNull is true. It panics if K or
+// V cannot be randomized.
+func (m *MapOf[K, V]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*m = NewMapOf[K, V](nil, false)
+		return
+	}
+	var k Val[K]
+	var v Val[V]
+	k.Randomize(nextInt, fieldType, false)
+	v.Randomize(nextInt, fieldType, false)
+	m.MapOf, m.Valid, m.Set = map[K]V{k.Val: v.Val}, true, true
+}
+
+// Generate implements testing/quick's Generator.
+func (m MapOf[K, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewMapOf[K, V](nil, false), func() MapOf[K, V] {
+		var res map[K]V
+		if !generateValue(rand, size, reflect.ValueOf(&res).Elem()) {
+			panic("null: cannot generate null.MapOf" + goTypeArgs2[K, V]())
+		}
+		return MapOf[K, V]{MapOf: res, Valid: true, Set: true}
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The map is
+// encoded as JSON.
+func (m MapOf[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(m.Set, m.Valid, func() ([]byte, error) {
+		return m.MarshalJSON()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *MapOf[K, V]) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.MapOf")
+	if err != nil {
+		return err
+	}
+	var v map[K]V
+	if valid {
+		v = map[K]V{}
+		if err = json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+	}
+	m.MapOf, m.Valid, m.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It decodes a JSON object from string or []byte; a JSON null is null.
+func (m *MapOf[K, V]) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.MapOf", value)
+	switch x := value.(type) {
+	case nil:
+		m.MapOf, m.Valid, m.Set = nil, false, true
+		return nil
+	case string:
+		err = m.UnmarshalJSON([]byte(x))
+	case []byte:
+		err = m.UnmarshalJSON(x)
+	default:
+		m.MapOf, m.Valid, m.Set = nil, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.MapOf: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The MapOf is encoded as JSON, returned as a string if JSONValueAsString
+// is set.
+func (m MapOf[K, V]) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if JSONValueAsString {
+		return string(data), nil
+	}
+	return data, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (m MapOf[K, V]) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString implements the GoStringer interface, formatting m as the Go
+// expression that builds it.
+func (m MapOf[K, V]) GoString() string {
+	return goString("MapOf", goTypeArgs2[K, V](), "MapOf", fmt.Sprintf("%#v", m.MapOf), m.MapOf == nil, m.Valid, m.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// m with the verb if it is valid.
+func (m MapOf[K, V]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, m, m.Valid, m.Set, m.MapOf)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the map, or to nil if this MapOf is null.
+func (m MapOf[K, V]) LogValue() slog.Value {
+	if !m.Valid {
+		return logNull
+	}
+	return slog.AnyValue(m.MapOf)
+}
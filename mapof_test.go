@@ -0,0 +1,165 @@
+package null
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+type testMapKey struct{ A, B int }
+
+func TestMapOfJSON(t *testing.T) {
+	tests := []struct {
+		in   json.Marshaler
+		want string
+	}{
+		{NewMapOf[string, int64](nil, false), `null`},
+		{MapOfFrom[string, int64](nil), `{}`},
+		{MapOfFrom(map[string]int64{"b": 2, "a": -9007199254740993}), `{"a":-9007199254740993,"b":2}`},
+		{MapOfFrom(map[int]string{10: "x", 2: "y"}), `{"10":"x","2":"y"}`},
+		{MapOfFrom(map[netip.Addr]bool{netip.MustParseAddr("10.0.0.1"): true}), `{"10.0.0.1":true}`},
+		{MapOfFrom(map[string]testShout{"greeting": "hi"}), `{"greeting":"HI"}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	var m MapOf[int, string]
+	err := json.Unmarshal([]byte(`{"10":"x","-2":"y"}`), &m)
+	maybePanic(err)
+	if v, ok := m.Get(-2); !m.Valid || m.Len() != 2 || v != "y" || !ok {
+		t.Errorf("unmarshal = %#v", m)
+	}
+
+	var null MapOf[string, int64]
+	err = json.Unmarshal([]byte(`null`), &null)
+	maybePanic(err)
+	if null.Valid || !null.Set || null.MapOf != nil {
+		t.Errorf("unmarshal null = %#v, want a set null", null)
+	}
+
+	for _, bad := range []string{`[]`, `{"a":"b"}`, `{"a":1.5}`} {
+		var m MapOf[string, int64]
+		if err := json.Unmarshal([]byte(bad), &m); err == nil {
+			t.Errorf("unmarshal %s should fail", bad)
+		}
+		if m.Valid || m.MapOf != nil {
+			t.Errorf("unmarshal %s left %#v", bad, m)
+		}
+	}
+	var shout MapOf[string, testShout]
+	if err := json.Unmarshal([]byte(`{"a":"quiet"}`), &shout); err == nil {
+		t.Error("the values' UnmarshalJSON should be used")
+	}
+}
+
+func TestMapOfKeyType(t *testing.T) {
+	m := MapOfFrom(map[testMapKey]int{{1, 2}: 3})
+	want := "null.testMapKey as a null.MapOf key"
+	if _, err := m.MarshalJSON(); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("MarshalJSON error = %v, want one containing %q", err, want)
+	}
+	if _, err := m.Value(); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Value error = %v, want one containing %q", err, want)
+	}
+
+	var back MapOf[testMapKey, int]
+	if err := back.UnmarshalJSON([]byte(`{}`)); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("UnmarshalJSON error = %v, want one containing %q", err, want)
+	}
+	if err := back.Scan(`{}`); err == nil || back.Valid {
+		t.Errorf("Scan = %#v, %v, want an error", back, err)
+	}
+
+	// A null needs no keys, so it still marshals.
+	if data, err := NewMapOf[testMapKey, int](nil, false).MarshalJSON(); err != nil || string(data) != "null" {
+		t.Errorf("null MarshalJSON = %s, %v", data, err)
+	}
+}
+
+func TestMapOfAccessors(t *testing.T) {
+	var m MapOf[string, int]
+	if v, ok := m.Get("a"); v != 0 || ok || m.Len() != 0 {
+		t.Errorf("unset Get = %d, %t, Len = %d", v, ok, m.Len())
+	}
+	m.SetKey("a", 1)
+	if v, ok := m.Get("a"); v != 1 || !ok || !m.Valid || !m.Set || m.Len() != 1 {
+		t.Errorf("SetKey on an unset MapOf = %#v", m)
+	}
+
+	null := NewMapOf(map[string]int{"stale": 1}, false)
+	if v, ok := null.Get("stale"); v != 0 || ok || null.Len() != 0 {
+		t.Errorf("null Get = %d, %t, Len = %d", v, ok, null.Len())
+	}
+	null.SetKey("b", 2)
+	if _, ok := null.Get("stale"); ok || null.Len() != 1 || !null.Valid {
+		t.Errorf("SetKey on a null MapOf = %#v, want only the new key", null)
+	}
+
+	empty := MapOfFrom[string, int](nil)
+	empty.SetKey("c", 3)
+	if v, _ := empty.Get("c"); v != 3 {
+		t.Errorf("SetKey on a nil map = %#v", empty)
+	}
+}
+
+func TestMapOfCopies(t *testing.T) {
+	src := map[string][]int{"a": {1, 2}}
+	m := MapOfFrom(src)
+	src["b"] = []int{3}
+	delete(src, "a")
+	if m.Len() != 1 {
+		t.Errorf("MapOfFrom shares its map: %#v", m)
+	}
+	// The values are copied as by assignment, so slice elements are shared.
+	m.MapOf["a"][0] = 9
+	if v, _ := m.Get("a"); v[0] != 9 {
+		t.Error("expected the slice value to be shared")
+	}
+
+	var set MapOf[string, []int]
+	set.SetValid(src)
+	src["c"] = nil
+	if set.Len() != 1 {
+		t.Errorf("SetValid shares its map: %#v", set)
+	}
+
+	clone := set.Clone()
+	clone.SetKey("d", nil)
+	if set.Len() != 1 || clone.Len() != 2 {
+		t.Errorf("Clone shares its map: %#v and %#v", set, clone)
+	}
+	if !MapOfFrom[string, int](nil).Equal(MapOfFrom(map[string]int{})) || clone.Equal(set) {
+		t.Error("bad Equal")
+	}
+}
+
+func TestMapOfScanValue(t *testing.T) {
+	m := MapOfFrom(map[string]string{"env": "prod"})
+	v, err := m.Value()
+	maybePanic(err)
+	if string(v.([]byte)) != `{"env":"prod"}` {
+		t.Errorf("Value() = %s", v)
+	}
+
+	var back MapOf[string, string]
+	err = back.Scan(v)
+	maybePanic(err)
+	if !back.Equal(m) {
+		t.Errorf("Scan(%s) = %#v", v, back)
+	}
+
+	err = back.Scan(nil)
+	maybePanic(err)
+	if back.Valid || !back.Set {
+		t.Errorf("Scan(nil) = %#v, want a set null", back)
+	}
+	if err := back.Scan(1.5); err == nil || back.Valid {
+		t.Errorf("Scan(float64) = %#v, %v, want an error", back, err)
+	}
+}
@@ -0,0 +1,383 @@
+package null
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MarshalJSON is like json.Marshal, but leaves out the struct fields
+// holding a Value that was never set, while a set null is still encoded as
+// null. This tells an absent field from an explicit null on the wire, which
+// a PATCH response echoing a partial resource needs, without an omitzero
+// tag on every field:
+//
+//	b, err := null.MarshalJSON(patch) // {"name":"Alice","email":null}
+//
+// Field names, embedded structs and the tag options of encoding/json are
+// honored. Structs are handled at any depth, including behind pointers,
+// interfaces, slices and maps. Map entries and slice elements holding an
+// unset Value are encoded as null, since only fields can be left out, and
+// so are nil pointers to a Value.
+// Values of types implementing json.Marshaler or encoding.TextMarshaler
+// are encoded by them, and so are not looked into.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, reflect.ValueOf(v), 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// maxJSONDepth bounds the nesting of pointers, slices and maps, so a
+// cycle is an error instead of a stack overflow.
+const maxJSONDepth = 1000
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func encodeJSON(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if depth > maxJSONDepth {
+		return fmt.Errorf("null: cannot marshal %s, it is nested too deeply or has a cycle", v.Type())
+	}
+	if !needsWalk(v.Type()) {
+		if v.CanAddr() && v.Kind() != reflect.Ptr && hasMarshaler(reflect.PointerTo(v.Type())) {
+			v = v.Addr()
+		}
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return encodeJSON(buf, v.Elem(), depth+1)
+	case reflect.Struct:
+		return encodeJSONStruct(buf, v, depth)
+	case reflect.Map:
+		return encodeJSONMap(buf, v, depth)
+	case reflect.Slice:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	panic("null: unexpected kind " + v.Kind().String())
+}
+
+func encodeJSONStruct(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	buf.WriteByte('{')
+	first := true
+	for _, f := range jsonFieldsFor(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if f.value && !(fv.Kind() == reflect.Ptr && fv.IsNil()) && !fv.Interface().(Value).IsSet() {
+			continue
+		}
+		if (f.omitEmpty && isEmptyJSONValue(fv)) || (f.omitZero && isZeroJSONValue(fv)) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(f.key)
+		if f.quoted {
+			b, err := json.Marshal(fv.Interface())
+			if err != nil {
+				return err
+			}
+			b, _ = json.Marshal(string(b))
+			buf.Write(b)
+			continue
+		}
+		if err := encodeJSON(buf, fv, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func encodeJSONMap(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if v.IsNil() {
+		buf.WriteString("null")
+		return nil
+	}
+	type entry struct {
+		key string
+		v   reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := jsonMapKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key, iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	buf.WriteByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		if err := encodeJSON(buf, e.v, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// jsonMapKey returns the object key for k the way encoding/json does.
+func jsonMapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if m, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", nil
+		}
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("null: cannot marshal a map with keys of type %s", k.Type())
+}
+
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+	return false
+}
+
+func isZeroJSONValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	name      string
+	key       []byte // the quoted name and a colon
+	index     []int
+	tagged    bool
+	value     bool // the field's type implements Value
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+}
+
+var (
+	jsonFields sync.Map // map[reflect.Type][]jsonField
+	jsonWalks  sync.Map // map[reflect.Type]bool
+)
+
+func jsonFieldsFor(t reflect.Type) []jsonField {
+	if f, ok := jsonFields.Load(t); ok {
+		return f.([]jsonField)
+	}
+	f, _ := jsonFields.LoadOrStore(t, buildJSONFields(t))
+	return f.([]jsonField)
+}
+
+// buildJSONFields lists the fields of t, including those promoted from
+// embedded structs, with the dominance rules of encoding/json: a field
+// hides deeper fields of the same name, and of several at the same depth
+// only a single tagged one survives.
+func buildJSONFields(t reflect.Type) []jsonField {
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+	var fields []jsonField
+	visited := map[reflect.Type]bool{}
+	for next := []level{{t: t}}; len(next) > 0; {
+		current := next
+		next = nil
+		for _, l := range current {
+			if visited[l.t] {
+				continue
+			}
+			visited[l.t] = true
+			for i := 0; i < l.t.NumField(); i++ {
+				sf := l.t.Field(i)
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), l.index...), i)
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, level{ft, index})
+					continue
+				}
+				f := jsonField{name: name, index: index, tagged: name != "", value: sf.Type.Implements(valueType)}
+				if f.name == "" {
+					f.name = sf.Name
+				}
+				for _, opt := range strings.Split(opts, ",") {
+					switch opt {
+					case "omitempty":
+						f.omitEmpty = true
+					case "omitzero":
+						f.omitZero = true
+					case "string":
+						switch ft.Kind() {
+						case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+							reflect.Float32, reflect.Float64, reflect.String:
+							f.quoted = sf.Type.Kind() != reflect.Ptr
+						}
+					}
+				}
+				key, _ := json.Marshal(f.name)
+				f.key = append(key, ':')
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i], fields[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if len(a.index) != len(b.index) {
+			return len(a.index) < len(b.index)
+		}
+		return a.tagged && !b.tagged
+	})
+	out := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		group := fields[i:j]
+		i = j
+		if len(group) > 1 && len(group[1].index) == len(group[0].index) && group[1].tagged == group[0].tagged {
+			continue // ambiguous, so encoding/json drops them all
+		}
+		out = append(out, group[0])
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].index, out[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return out
+}
+
+// needsWalk reports whether values of type t may hold a struct field of a
+// Value type, so MarshalJSON must walk them instead of handing them to
+// encoding/json whole. Types with their own marshalers are handed over.
+func needsWalk(t reflect.Type) bool {
+	if w, ok := jsonWalks.Load(t); ok {
+		return w.(bool)
+	}
+	w := walks(t, map[reflect.Type]bool{})
+	jsonWalks.Store(t, w)
+	return w
+}
+
+func walks(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	if hasMarshaler(t) || (t.Kind() != reflect.Ptr && hasMarshaler(reflect.PointerTo(t))) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return walks(t.Elem(), seen)
+	case reflect.Map:
+		return walks(t.Elem(), seen)
+	case reflect.Struct:
+		for _, f := range jsonFieldsFor(t) {
+			ft := t.FieldByIndex(f.index).Type
+			if f.value || walks(ft, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasMarshaler(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType)
+}
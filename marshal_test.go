@@ -0,0 +1,141 @@
+package null
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MarshalBase struct {
+	ID      Int64 `json:"id"`
+	Updated Time  `json:"updated,omitzero"`
+}
+
+type marshalAddress struct {
+	City String `json:"city"`
+	Zip  String `json:"zip"`
+}
+
+type marshalResource struct {
+	MarshalBase
+	Name     String                    `json:"name"`
+	Email    String                    `json:"email"`
+	Age      *Int                      `json:"age"`
+	Count    int                       `json:"count,string"`
+	Note     string                    `json:"note,omitempty"`
+	Hidden   String                    `json:"-"`
+	Address  *marshalAddress           `json:"address"`
+	Previous []marshalAddress          `json:"previous"`
+	ByKind   map[string]marshalAddress `json:"by_kind,omitempty"`
+	Extra    interface{}               `json:"extra,omitempty"`
+	Tags     StringSlice               `json:"tags"`
+	When     time.Time                 `json:"-"`
+	secret   String
+}
+
+func TestMarshalJSONOmitsUnset(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{nil, `null`},
+		{StringFrom("a"), `"a"`},
+		{String{}, `null`},
+		{marshalResource{}, `{"age":null,"count":"0","address":null,"previous":null}`},
+		{
+			marshalResource{
+				MarshalBase: MarshalBase{ID: Int64From(7)},
+				Name:        StringFrom("Alice"),
+				Email:       NewString("", false),
+				Count:       2,
+				Hidden:      StringFrom("x"),
+				Address:     &marshalAddress{City: StringFrom("Paris")},
+				Previous:    []marshalAddress{{Zip: NewString("", false)}, {}},
+				ByKind:      map[string]marshalAddress{"b": {City: StringFrom("Lyon")}, "a": {}},
+				Extra:       []interface{}{marshalAddress{City: StringFrom("Nice")}, String{}},
+				Tags:        StringSliceFrom([]string{"x"}),
+				secret:      StringFrom("x"),
+			},
+			`{"id":7,"name":"Alice","email":null,"age":null,"count":"2","address":{"city":"Paris"},` +
+				`"previous":[{"zip":null},{}],"by_kind":{"a":{},"b":{"city":"Lyon"}},` +
+				`"extra":[{"city":"Nice"},null],"tags":["x"]}`,
+		},
+		{&marshalAddress{City: StringFrom("<b>")}, `{"city":"\u003cb\u003e"}`},
+		{map[int]*marshalAddress{2: nil, 1: {}}, `{"1":{},"2":null}`},
+		{[2]marshalAddress{}, `[{},{}]`},
+	}
+	for _, test := range tests {
+		b, err := MarshalJSON(test.in)
+		maybePanic(err)
+		if string(b) != test.want {
+			t.Errorf("MarshalJSON(%#v) = %s, want %s", test.in, b, test.want)
+		}
+	}
+}
+
+// TestMarshalJSONLikeEncodingJSON checks that values with every field set
+// are encoded exactly as encoding/json encodes them.
+func TestMarshalJSONOmitsUnsetLikeEncodingJSON(t *testing.T) {
+	type Inner struct {
+		A String `json:"a"`
+		B int
+	}
+	type Other struct {
+		A String  `json:"a"`
+		C float64 `json:"c,omitempty"`
+	}
+	type outer struct {
+		*Inner
+		Other
+		B      string `json:"b"`
+		C      bool   `json:",omitempty"`
+		Uint   uint8  `json:",string"`
+		Raw    []byte
+		Ptr    *String          `json:",omitempty"`
+		Time   time.Time        `json:"time"`
+		Values map[Date]Float64 `json:"values"`
+	}
+	in := outer{
+		Inner:  &Inner{A: StringFrom("inner"), B: 1},
+		Other:  Other{A: StringFrom("other")},
+		B:      "b",
+		Uint:   3,
+		Raw:    []byte{1, 2},
+		Time:   time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC),
+		Values: map[Date]Float64{DateOf(2012, 12, 21): Float64From(1.5), DateOf(2000, 1, 1): NewFloat64(0, false)},
+	}
+	for _, v := range []interface{}{in, &in, outer{Other: in.Other}} {
+		want, err := json.Marshal(v)
+		maybePanic(err)
+		got, err := MarshalJSON(v)
+		maybePanic(err)
+		if string(got) != string(want) {
+			t.Errorf("MarshalJSON(%#v) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+type marshalNode struct {
+	Name String       `json:"name"`
+	Next *marshalNode `json:"next,omitempty"`
+}
+
+func TestMarshalJSONOmitsUnsetErrors(t *testing.T) {
+	node := &marshalNode{Name: StringFrom("a")}
+	node.Next = node
+	if _, err := MarshalJSON(node); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("MarshalJSON of a cycle = %v, want an error", err)
+	}
+	if _, err := MarshalJSON(map[float64]marshalNode{1: {}}); err == nil {
+		t.Error("MarshalJSON with float keys should fail")
+	}
+	if _, err := MarshalJSON([]interface{}{Float64From(0), func() {}}); err == nil {
+		t.Error("MarshalJSON of a func should fail")
+	}
+	b, err := MarshalJSON(&marshalNode{Next: &marshalNode{Name: NewString("", false)}})
+	maybePanic(err)
+	if string(b) != `{"next":{"name":null}}` {
+		t.Errorf("MarshalJSON of a list = %s", b)
+	}
+}
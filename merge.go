@@ -0,0 +1,101 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Merge overlays one patch struct onto another: the fields of overlay that
+// are set replace those of base, which keeps the rest, so a newer PATCH
+// request stacks on top of a pending draft:
+//
+//	err := null.Merge(&draft, patch)
+//
+// Set, not Valid, marks a field as present, so an explicit null in overlay
+// replaces a value in base. base is a pointer to a struct and overlay a
+// struct or a pointer to one, usually of the same type. Fields are matched
+// and walked as by ApplyPatch, nested structs included, but each field must
+// have the same type in both structs; a mismatch is an error naming the
+// path of the field, and base is left untouched. Copy base first to keep it.
+func Merge(base, overlay interface{}) error {
+	bv := reflect.ValueOf(base)
+	if bv.Kind() != reflect.Ptr || bv.IsNil() || bv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("null: Merge needs a non-nil pointer to a struct, not %T", base)
+	}
+	ot := reflect.TypeOf(overlay)
+	if ot != nil && ot.Kind() == reflect.Ptr {
+		ot = ot.Elem()
+	}
+	if ot == nil || ot.Kind() != reflect.Struct {
+		return fmt.Errorf("null: Merge needs a struct overlay, not %T", overlay)
+	}
+	if err := mergeCheckFor(ot, bv.Elem().Type()); err != nil {
+		return err
+	}
+	return ApplyPatch(base, overlay)
+}
+
+var mergeChecks sync.Map // map[[2]reflect.Type]error
+
+// mergeCheckFor returns the error of merging structs of type overlay into
+// base, or nil if their fields match, caching the answer.
+func mergeCheckFor(overlay, base reflect.Type) error {
+	if overlay == base {
+		return nil
+	}
+	key := [2]reflect.Type{overlay, base}
+	if err, ok := mergeChecks.Load(key); ok {
+		err, _ := err.(error)
+		return err
+	}
+	err := checkMerge(overlay, base, nil, map[[2]reflect.Type]bool{})
+	mergeChecks.Store(key, err)
+	return err
+}
+
+// checkMerge checks the fields of overlay found at path against base.
+// Pairs of types already in seen are skipped, so recursive types end.
+func checkMerge(overlay, base reflect.Type, path []string, seen map[[2]reflect.Type]bool) error {
+	key := [2]reflect.Type{overlay, base}
+	if overlay == base || seen[key] {
+		return nil
+	}
+	seen[key] = true
+	for i := 0; i < overlay.NumField(); i++ {
+		f := overlay.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("patch")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && isNestedStruct(f.Type) {
+			if err := checkMerge(derefType(f.Type), base, append(path, f.Name), seen); err != nil {
+				return err
+			}
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		bf, ok := base.FieldByName(name)
+		if !ok {
+			// ApplyPatch reports the missing field.
+			continue
+		}
+		fieldPath := strings.Join(append(append([]string(nil), path...), f.Name), ".")
+		switch {
+		case f.Type.Implements(valueType) && bf.Type != f.Type:
+			return fmt.Errorf("null: cannot merge %s of type %s into %s.%s of type %s", fieldPath, f.Type, base, name, bf.Type)
+		case !f.Type.Implements(valueType) && isNestedStruct(f.Type) && isNestedStruct(bf.Type):
+			if err := checkMerge(derefType(f.Type), derefType(bf.Type), append(path, f.Name), seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mergeDraft struct {
+	Name  String
+	Email String
+	Age   Int
+	Home  diffAddress
+	Work  *diffAddress
+	Note  string
+}
+
+func TestMerge(t *testing.T) {
+	draft := mergeDraft{
+		Name:  StringFrom("alice"),
+		Email: StringFrom("alice@example.com"),
+		Age:   IntFrom(30),
+		Home:  diffAddress{City: StringFrom("Paris")},
+		Note:  "draft",
+	}
+	patch := mergeDraft{
+		Email: NewString("", false),
+		Age:   IntFrom(31),
+		Home:  diffAddress{Zip: StringFrom("75001")},
+		Work:  &diffAddress{City: StringFrom("Lyon")},
+		Note:  "ignored",
+	}
+	maybePanic(Merge(&draft, &patch))
+	want := mergeDraft{
+		Name:  StringFrom("alice"),
+		Email: NewString("", false),
+		Age:   IntFrom(31),
+		Home:  diffAddress{City: StringFrom("Paris"), Zip: StringFrom("75001")},
+		Work:  &diffAddress{City: StringFrom("Lyon")},
+		Note:  "draft",
+	}
+	if !reflect.DeepEqual(draft, want) {
+		t.Errorf("Merge = %#v, want %#v", draft, want)
+	}
+
+	maybePanic(Merge(&draft, mergeDraft{Work: &diffAddress{Zip: StringFrom("69001")}}))
+	if draft.Work.City.String != "Lyon" || draft.Work.Zip.String != "69001" {
+		t.Errorf("Merge into a pointer = %#v", draft.Work)
+	}
+	maybePanic(Merge(&draft, (*mergeDraft)(nil)))
+	if draft.Age.Int != 31 {
+		t.Errorf("Merge of nil changed %#v", draft)
+	}
+}
+
+type mergeOther struct {
+	Name String
+	Home struct {
+		City Int
+	}
+}
+
+func TestMergeMismatch(t *testing.T) {
+	draft := mergeDraft{Name: StringFrom("alice")}
+	err := Merge(&draft, mergeOther{Name: StringFrom("bob")})
+	if err == nil || !strings.Contains(err.Error(), "Home.City of type null.Int") {
+		t.Errorf("Merge of mismatched types = %v, want an error naming Home.City", err)
+	}
+	if draft.Name.String != "alice" {
+		t.Errorf("a failed Merge changed %#v", draft)
+	}
+
+	type sameFields struct{ Name, Email String }
+	maybePanic(Merge(&draft, sameFields{Email: StringFrom("a@b.c")}))
+	if draft.Email.String != "a@b.c" {
+		t.Errorf("Merge of matching types = %#v", draft)
+	}
+
+	for _, bad := range []struct{ base, overlay interface{} }{
+		{draft, draft},
+		{&draft, 42},
+		{(*mergeDraft)(nil), draft},
+	} {
+		if err := Merge(bad.base, bad.overlay); err == nil {
+			t.Errorf("Merge(%T, %T) should fail", bad.base, bad.overlay)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergePatch applies patch to j as a JSON Merge Patch, as RFC 7386 defines
+// it. A null member of the patch deletes the key, an object member is
+// merged into the object under its key, and any other member replaces it.
+// A patch that is not an object replaces the whole document, so a null
+// patch returns a null JSON, and an object patch applied to a document that
+// is not an object, or is null, is applied to an empty object. A patch that
+// was never set returns j as it is.
+//
+// The result has its own data. Its object keys are sorted, and numbers and
+// strings keep their text. An error is returned if j or patch is not valid
+// JSON, and j is not changed either way.
+func (j JSON) MergePatch(patch JSON) (JSON, error) {
+	if !patch.Set && !patch.Valid {
+		return j, nil
+	}
+	if !patch.Valid || len(patch.JSON) == 0 {
+		return NewJSON(nil, false), nil
+	}
+	p, err := decodeJSONValue(patch.JSON)
+	if err != nil {
+		return JSON{}, fmt.Errorf("null: invalid JSON merge patch: %w", err)
+	}
+	var target interface{}
+	if j.Valid && len(j.JSON) > 0 {
+		if target, err = decodeJSONValue(j.JSON); err != nil {
+			return JSON{}, fmt.Errorf("null: invalid JSON merge patch target: %w", err)
+		}
+	}
+	merged := mergeJSONPatch(target, p)
+	if merged == nil {
+		return NewJSON(nil, false), nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(merged); err != nil {
+		return JSON{}, err
+	}
+	return JSONFrom(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// mergeJSONPatch applies the decoded patch to the decoded target, which it
+// may modify.
+func mergeJSONPatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergeJSONPatch(t[k], v)
+	}
+	return t
+}
+
+// MergePatchStruct applies patch, a JSON Merge Patch document, to dst, a
+// pointer to a struct of null types, and returns the JSON names of the
+// fields the patch touched, in field order:
+//
+//	touched, err := null.MergePatchStruct(&user, body)
+//	// touched is []string{"name", "address.city"}
+//
+// The patch is unmarshaled into a new value of the type of dst, and the
+// fields it set are copied over as ApplyPatch copies them, so a member of
+// the patch overwrites its field, a null member makes the field null, and
+// the fields the patch leaves out keep their values. An object member for a
+// nested struct is merged into it field by field, and one for a JSON field
+// is merged into its document with MergePatch. Fields of other types are
+// not patched. Nested fields are named by their path, with a dot between
+// the names.
+//
+// If the patch cannot be unmarshaled or merged, dst is left untouched.
+func MergePatchStruct(dst interface{}, patch []byte) ([]string, error) {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("null: MergePatchStruct needs a non-nil pointer to a struct, not %T", dst)
+	}
+	fresh := reflect.New(dv.Elem().Type())
+	if err := json.Unmarshal(patch, fresh.Interface()); err != nil {
+		return nil, err
+	}
+	touched, err := mergePatchFields(dv.Elem(), fresh.Elem(), "")
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyPatch(dst, fresh.Interface()); err != nil {
+		return nil, err
+	}
+	return touched, nil
+}
+
+var jsonType = reflect.TypeOf(JSON{})
+
+// mergePatchFields lists the set fields of patch, a struct of the same type
+// as dst, under prefix, and replaces each valid JSON field of patch by the
+// merge of it into the matching field of dst.
+func mergePatchFields(dst, patch reflect.Value, prefix string) ([]string, error) {
+	var touched []string
+	t := patch.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = f.Name
+		}
+		pf, df := patch.Field(i), dst.Field(i)
+
+		if f.Type.Implements(valueType) {
+			if f.Type.Kind() == reflect.Ptr && pf.IsNil() {
+				continue
+			}
+			if !pf.Interface().(Value).IsSet() {
+				continue
+			}
+			if f.Type == jsonType {
+				merged, err := df.Interface().(JSON).MergePatch(pf.Interface().(JSON))
+				if err != nil {
+					return nil, fmt.Errorf("null: cannot merge patch into %s: %w", prefix+name, err)
+				}
+				pf.Set(reflect.ValueOf(merged))
+			}
+			touched = append(touched, prefix+name)
+			continue
+		}
+		if !isNestedStruct(f.Type) {
+			continue
+		}
+		if f.Type.Kind() == reflect.Ptr {
+			if pf.IsNil() {
+				continue
+			}
+			pf = pf.Elem()
+			if df.IsNil() {
+				df = reflect.New(f.Type.Elem())
+			}
+			df = df.Elem()
+		}
+		sub := prefix + name + "."
+		if f.Anonymous && !tagged {
+			sub = prefix
+		}
+		names, err := mergePatchFields(df, pf, sub)
+		if err != nil {
+			return nil, err
+		}
+		touched = append(touched, names...)
+	}
+	return touched, nil
+}
@@ -0,0 +1,152 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONMergePatch(t *testing.T) {
+	// The examples of RFC 7386, Appendix A, and a few more.
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		// Nested deletes, empty patches and numbers kept as written.
+		{`{"a":{"b":{"c":1,"d":2}},"e":3}`, `{"a":{"b":{"c":null}},"e":null}`, `{"a":{"b":{"d":2}}}`},
+		{`{"a":1.50,"b":"<x>"}`, `{}`, `{"a":1.50,"b":"<x>"}`},
+		{`"scalar"`, `{}`, `{}`},
+		{`{"a":1}`, `"b"`, `"b"`},
+	}
+	for _, test := range tests {
+		got, err := JSONFrom([]byte(test.target)).MergePatch(JSONFrom([]byte(test.patch)))
+		maybePanic(err)
+		if !got.Valid || !got.Set || string(got.JSON) != test.want {
+			t.Errorf("MergePatch(%s, %s) = %s, want %s", test.target, test.patch, got.JSON, test.want)
+		}
+	}
+
+	doc := JSONFrom([]byte(`{"a":1}`))
+	for _, null := range []JSON{NewJSON(nil, false), JSONFrom([]byte("null"))} {
+		if got, err := doc.MergePatch(null); err != nil || got.Valid || !got.Set {
+			t.Errorf("MergePatch with a null patch = %#v, %v, want a set null", got, err)
+		}
+	}
+	if got, err := doc.MergePatch(JSON{}); err != nil || !got.ExactEqual(doc) {
+		t.Errorf("MergePatch with an unset patch = %#v, %v, want the document", got, err)
+	}
+	if got, err := NewJSON(nil, false).MergePatch(JSONFrom([]byte(`{"a":null,"b":2}`))); err != nil || string(got.JSON) != `{"b":2}` {
+		t.Errorf("MergePatch onto null = %s, %v", got.JSON, err)
+	}
+
+	if _, err := doc.MergePatch(JSONFrom([]byte(`{"a":`))); err == nil {
+		t.Error("MergePatch with an invalid patch should fail")
+	}
+	if _, err := JSONFrom([]byte(`{"a"`)).MergePatch(JSONFrom([]byte(`{}`))); err == nil {
+		t.Error("MergePatch onto an invalid document should fail")
+	}
+	if string(doc.JSON) != `{"a":1}` {
+		t.Errorf("MergePatch changed its target to %s", doc.JSON)
+	}
+}
+
+type mergeAddress struct {
+	City String `json:"city"`
+	Zip  String `json:"zip"`
+}
+
+type MergeAudit struct {
+	UpdatedBy String `json:"updated_by"`
+}
+
+type mergeUser struct {
+	MergeAudit
+	Name    String        `json:"name"`
+	Email   String        `json:"email"`
+	Age     Int           `json:"age,omitempty"`
+	Prefs   JSON          `json:"prefs"`
+	Address mergeAddress  `json:"address"`
+	Billing *mergeAddress `json:"billing"`
+	Secret  String        `json:"-"`
+	Note    string
+}
+
+func TestMergePatchStruct(t *testing.T) {
+	user := mergeUser{
+		Name:    StringFrom("alice"),
+		Email:   StringFrom("alice@example.com"),
+		Age:     IntFrom(30),
+		Prefs:   JSONFrom([]byte(`{"theme":"dark","lang":"en"}`)),
+		Address: mergeAddress{City: StringFrom("Paris"), Zip: StringFrom("75001")},
+		Secret:  StringFrom("s3cret"),
+		Note:    "kept",
+	}
+	patch := `{
+		"name": "bob",
+		"email": null,
+		"prefs": {"theme": null, "font": "mono"},
+		"address": {"city": "Lyon"},
+		"billing": {"zip": "69001"},
+		"updated_by": "admin",
+		"Secret": "ignored",
+		"Note": "ignored"
+	}`
+	touched, err := MergePatchStruct(&user, []byte(patch))
+	maybePanic(err)
+
+	wantTouched := []string{"updated_by", "name", "email", "prefs", "address.city", "billing.zip"}
+	if !reflect.DeepEqual(touched, wantTouched) {
+		t.Errorf("touched = %q, want %q", touched, wantTouched)
+	}
+	want := mergeUser{
+		MergeAudit: MergeAudit{UpdatedBy: StringFrom("admin")},
+		Name:       StringFrom("bob"),
+		Email:      NewString("", false),
+		Age:        IntFrom(30),
+		Prefs:      JSONFrom([]byte(`{"font":"mono","lang":"en"}`)),
+		Address:    mergeAddress{City: StringFrom("Lyon"), Zip: StringFrom("75001")},
+		Billing:    &mergeAddress{Zip: StringFrom("69001")},
+		Secret:     StringFrom("s3cret"),
+		Note:       "kept",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("patched %#v, want %#v", user, want)
+	}
+
+	touched, err = MergePatchStruct(&user, []byte(`{}`))
+	maybePanic(err)
+	if len(touched) != 0 || !reflect.DeepEqual(user, want) {
+		t.Errorf("empty patch touched %q and left %#v", touched, user)
+	}
+
+	for _, bad := range []string{`{"age":"old"}`, `{"name":`, `[1]`} {
+		if _, err := MergePatchStruct(&user, []byte(bad)); err == nil {
+			t.Errorf("MergePatchStruct(%s) should fail", bad)
+		}
+	}
+	broken := mergeUser{Name: StringFrom("carol"), Prefs: JSONFrom([]byte(`{"theme"`))}
+	if _, err := MergePatchStruct(&broken, []byte(`{"name":"dave","prefs":{"a":1}}`)); err == nil {
+		t.Error("MergePatchStruct into an invalid JSON field should fail")
+	}
+	if broken.Name.String != "carol" {
+		t.Errorf("a failed MergePatchStruct changed dst to %#v", broken)
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("a failed MergePatchStruct changed dst to %#v", user)
+	}
+
+	if _, err := MergePatchStruct(user, []byte(`{}`)); err == nil {
+		t.Error("MergePatchStruct should need a pointer")
+	}
+}
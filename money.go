@@ -0,0 +1,792 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Amount is an exact amount of money: a whole number of the minor units
+// of an ISO 4217 currency, such as cents of "EUR".
+type Amount struct {
+	// Minor is the amount in minor units, so 1234 is 12.34 EUR but
+	// 1234 JPY, which has none.
+	Minor int64
+	// Currency is the upper-case ISO 4217 alphabetic code.
+	Currency string
+}
+
+// Decimal returns the amount in major units with as many decimals as its
+// currency has, such as "12.34" or "-0.05". It fails for an unknown
+// currency, whose scale is unknown.
+func (a Amount) Decimal() (string, error) {
+	b, err := a.appendDecimal(make([]byte, 0, 24))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// appendDecimal appends what Decimal returns to b.
+func (a Amount) appendDecimal(b []byte) ([]byte, error) {
+	scale, ok := currencyScales[a.Currency]
+	if !ok {
+		return b, fmt.Errorf("null: unknown currency %q", a.Currency)
+	}
+	abs := uint64(a.Minor)
+	if a.Minor < 0 {
+		b = append(b, '-')
+		abs = -abs
+	}
+	var buf [20]byte
+	digits := strconv.AppendUint(buf[:0], abs, 10)
+	if len(digits) <= scale {
+		b = append(b, "0."...)
+		for i := len(digits); i < scale; i++ {
+			b = append(b, '0')
+		}
+		return append(b, digits...), nil
+	}
+	b = append(b, digits[:len(digits)-scale]...)
+	if scale > 0 {
+		b = append(b, '.')
+		b = append(b, digits[len(digits)-scale:]...)
+	}
+	return b, nil
+}
+
+// String returns the text form of Money, such as "12.34 EUR", or for an
+// unknown currency the minor units and the code as they are.
+func (a Amount) String() string {
+	d, err := a.Decimal()
+	if err != nil {
+		return strconv.FormatInt(a.Minor, 10) + " minor units of " + strconv.Quote(a.Currency)
+	}
+	return d + " " + a.Currency
+}
+
+// ParseAmount parses amount, in major units such as "12.34", as an amount
+// of currency. The currency must be a known ISO 4217 code, and amount a
+// decimal number with at most that currency's number of decimals, so
+// "12.345 EUR" is an error rather than rounded. Fewer decimals, as in
+// "12.3", are padded.
+func ParseAmount(amount, currency string) (Amount, error) {
+	scale, ok := currencyScales[currency]
+	if !ok {
+		return Amount{}, fmt.Errorf("null: unknown currency %q", currency)
+	}
+	bad := fmt.Errorf("null: cannot parse %q as an amount of money", amount)
+	s := strings.TrimPrefix(amount, "-")
+	intPart, fracPart, dot := strings.Cut(s, ".")
+	if intPart == "" || (dot && fracPart == "") {
+		return Amount{}, bad
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return Amount{}, bad
+			}
+		}
+	}
+	if len(fracPart) > scale {
+		return Amount{}, fmt.Errorf("null: %q has more decimals than the %d of %s", amount, scale, currency)
+	}
+	digits := intPart + fracPart + strings.Repeat("0", scale-len(fracPart))
+	if len(s) < len(amount) {
+		digits = "-" + digits
+	}
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("null: %q is out of range for an amount of %s: %w", amount, currency, ErrOverflow)
+	}
+	return Amount{Minor: minor, Currency: currency}, nil
+}
+
+// Money is a nullable amount of money, pairing an exact amount with its
+// currency so that neither can be set without the other. It marshals to
+// JSON as {"amount":"12.34","currency":"EUR"}, with the amount a string so
+// that JavaScript doesn't round it, and to text, and SQL, as "12.34 EUR";
+// for separate amount and currency columns see Columns and
+// MoneyFromColumns.
+//
+// Decoding rejects currencies that are not ISO 4217 codes and amounts with
+// more decimals than the currency has. Encoding a Money holding an unknown
+// currency fails, since its scale is unknown.
+type Money struct {
+	Money Amount
+	Valid bool
+	Set   bool
+}
+
+// NewMoney creates a new Money
+func NewMoney(a Amount, valid bool) Money {
+	return Money{
+		Money: a,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// MoneyFrom creates a new Money that will always be valid.
+// a is stored as is; use MoneyFromString or ParseAmount to parse untrusted
+// input.
+func MoneyFrom(a Amount) Money {
+	return NewMoney(a, true)
+}
+
+// MoneyFromPtr creates a new Money that will be null if a is nil.
+func MoneyFromPtr(a *Amount) Money {
+	if a == nil {
+		return NewMoney(Amount{}, false)
+	}
+	return NewMoney(*a, true)
+}
+
+// MoneyFromString parses the text form, such as "12.34 EUR", into a new
+// Money. An empty string produces a null Money.
+func MoneyFromString(s string) (Money, error) {
+	var m Money
+	err := m.UnmarshalText([]byte(s))
+	return m, err
+}
+
+// MoneyFromColumns joins the values of separate amount and currency
+// columns into a Money, validated as ParseAmount does. Both null gives a
+// null Money and both unset an unset one; a null or unset column next to a
+// valid one is an error, as it would make half a Money.
+func MoneyFromColumns(amount Decimal, currency String) (Money, error) {
+	if !amount.Valid || !currency.Valid {
+		if amount.Valid || currency.Valid {
+			return Money{}, fmt.Errorf("null: money amount %v and currency %v must be both valid or both null", amount, currency)
+		}
+		return Money{Set: amount.Set || currency.Set}, nil
+	}
+	a, err := ParseAmount(amount.Decimal, currency.String)
+	if err != nil {
+		return Money{}, err
+	}
+	return MoneyFrom(a), nil
+}
+
+// Columns splits m into the values of separate amount and currency
+// columns, such as NUMERIC(19, 2) and CHAR(3), which are both null if m is
+// null and both unset if m is unset.
+func (m Money) Columns() (Decimal, String, error) {
+	if !m.Valid {
+		return Decimal{Set: m.Set}, String{Set: m.Set}, nil
+	}
+	d, err := m.Money.Decimal()
+	if err != nil {
+		return Decimal{}, String{}, err
+	}
+	return DecimalFrom(d), StringFrom(m.Money.Currency), nil
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (m Money) IsValid() bool {
+	return m.Set && m.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (m Money) IsSet() bool {
+	return m.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (m Money) IsNull() bool {
+	return m.Set && !m.Valid
+}
+
+// Equal reports whether m and other are both null, or both valid with the
+// same amount in the same currency. Set is ignored; see ExactEqual.
+func (m Money) Equal(other Money) bool {
+	if !m.Valid || !other.Valid {
+		return m.Valid == other.Valid
+	}
+	return m.Money == other.Money
+}
+
+// ExactEqual is like Equal, but also requires m and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (m Money) ExactEqual(other Money) bool {
+	return m.Set == other.Set && m.Equal(other)
+}
+
+// moneyJSON is the JSON form of a valid Money.
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency *string         `json:"currency"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts null, or an object with an amount, as a string or a number in
+// decimal notation, and a currency, and no other keys.
+func (m *Money) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Money", m, data)
+	m.Set = true
+	m.Money, m.Valid = Amount{}, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+
+	var v moneyJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	if v.Amount == nil || v.Currency == nil {
+		return fmt.Errorf("null: money needs both an amount and a currency")
+	}
+	var amount string
+	if bytes.HasPrefix(v.Amount, []byte(`"`)) {
+		if err := json.Unmarshal(v.Amount, &amount); err != nil {
+			return err
+		}
+	} else {
+		amount = string(v.Amount)
+	}
+	a, err := ParseAmount(amount, *v.Currency)
+	if err != nil {
+		return err
+	}
+	m.Money, m.Valid = a, true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts an amount and a currency code separated by a space, such as
+// "12.34 EUR". Empty text unmarshals to a null Money.
+func (m *Money) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Money", text)
+	m.Set = true
+	m.Money, m.Valid = Amount{}, false
+	if len(text) == 0 {
+		return nil
+	}
+	a, err := parseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	m.Money, m.Valid = a, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Money is null.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return NullBytes, nil
+	}
+	return m.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (m Money) AppendJSON(b []byte) ([]byte, error) {
+	if !m.Valid {
+		return append(b, "null"...), nil
+	}
+	n := len(b)
+	b, err := m.Money.appendDecimal(append(b, `{"amount":"`...))
+	if err != nil {
+		return b[:n], err
+	}
+	b = append(b, `","currency":"`...)
+	b = append(b, m.Money.Currency...)
+	return append(b, `"}`...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns the amount and the currency code, or empty text if this Money
+// is null.
+func (m Money) MarshalText() ([]byte, error) {
+	if !m.Valid {
+		return []byte{}, nil
+	}
+	return m.AppendText(make([]byte, 0, 32))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if m is null.
+func (m Money) AppendText(b []byte) ([]byte, error) {
+	if !m.Valid {
+		return b, nil
+	}
+	b, err := m.Money.appendDecimal(b)
+	if err != nil {
+		return b, err
+	}
+	b = append(b, ' ')
+	return append(b, m.Money.Currency...), nil
+}
+
+// SetValid changes this Money's value and also sets it to be non-null.
+func (m *Money) SetValid(v Amount) {
+	m.Money = v
+	m.Valid = true
+	m.Set = true
+}
+
+// SetNull sets this Money to an explicit null, zeroing its value.
+func (m *Money) SetNull() {
+	*m = Money{Set: true}
+}
+
+// Unset resets this Money to the zero value, as if it was never set.
+func (m *Money) Unset() {
+	*m = Money{}
+}
+
+// SetPtr changes this Money to the value p points to, or to an explicit
+// null if p is nil.
+func (m *Money) SetPtr(p *Amount) {
+	*m = MoneyFromPtr(p)
+}
+
+// Ptr returns a pointer to this Money's value, or a nil pointer if this
+// Money is null.
+func (m Money) Ptr() *Amount {
+	if !m.Valid {
+		return nil
+	}
+	return &m.Money
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (m Money) ValueOrZero() Amount {
+	if !m.Valid {
+		return Amount{}
+	}
+	return m.Money
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (m Money) Or(def Amount) Amount {
+	if !m.Valid {
+		return def
+	}
+	return m.Money
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (m Money) Val() Amount {
+	return m.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (m Money) Get() (Amount, bool) {
+	return m.ValueOrZero(), m.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if m is
+// null.
+func (m Money) MustGet() Amount {
+	if !m.Valid {
+		panic(&NullError{Type: "null.Money"})
+	}
+	return m.Money
+}
+
+// IsZero returns true if this Money was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (m Money) IsZero() bool {
+	return !m.Set && !m.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It encodes a mapping like the JSON object, or null if this Money is null.
+func (m Money) MarshalYAML() (interface{}, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	d, err := m.Money.Decimal()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"amount": d, "currency": m.Money.Currency}, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts a mapping as UnmarshalJSON accepts an object. It will
+// unmarshal to a null Money if the input is null or ~.
+func (m *Money) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	m.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	if v, err = yamlToJSONValue(v); err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	return m.UnmarshalJSON(data)
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes the text form, or an empty element if this Money is null.
+func (m Money) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, m.Valid, m.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Money.
+func (m *Money) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, m.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Money is null.
+func (m Money) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, m.Valid, m.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Money.
+func (m *Money) UnmarshalXMLAttr(attr xml.Attr) error {
+	return m.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON document like the JSON object, or BSON null if this
+// Money is null.
+func (m Money) MarshalBSONValue() (byte, []byte, error) {
+	if !m.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON document, decoded as UnmarshalJSON decodes JSON, or a
+// string in the same form as UnmarshalText.
+func (m *Money) UnmarshalBSONValue(typ byte, data []byte) error {
+	m.Set = true
+	if bsonIsNull(typ) {
+		m.Money, m.Valid = Amount{}, false
+		return nil
+	}
+	switch typ {
+	case bsonString, bsonSymbol:
+		return unmarshalBSONText(typ, data, "null.Money", m.UnmarshalText)
+	case bsonDocument:
+	default:
+		m.Money, m.Valid = Amount{}, false
+		return bsonTypeError(typ, "null.Money")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	return m.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack map like the JSON object, or nil if this Money is
+// null.
+func (m Money) MarshalMsgpack() ([]byte, error) {
+	if !m.Valid {
+		return msgpackNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack map, decoded as UnmarshalJSON decodes JSON, or a
+// string in the same form as UnmarshalText.
+func (m *Money) UnmarshalMsgpack(data []byte) error {
+	m.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return m.UnmarshalText([]byte(x))
+	case decodedMap:
+	default:
+		m.Money, m.Valid = Amount{}, false
+		return msgpackTypeError(x, "null.Money")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR map like the JSON object, or null if this Money is
+// null.
+func (m Money) MarshalCBOR() ([]byte, error) {
+	if !m.Valid {
+		return cborNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR map, decoded as UnmarshalJSON decodes JSON, or a text
+// string in the same form as UnmarshalText.
+func (m *Money) UnmarshalCBOR(data []byte) error {
+	m.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	switch x := v.(type) {
+	case string:
+		return m.UnmarshalText([]byte(x))
+	case decodedMap:
+	default:
+		m.Money, m.Valid = Amount{}, false
+		return cborTypeError(x, "null.Money")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		m.Money, m.Valid = Amount{}, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (m Money) MarshalGQL(w io.Writer) {
+	marshalGQL(w, m.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Money.
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, m.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Money
+// is null.
+func (m Money) MarshalCSV() (string, error) {
+	return marshalCSV(m.Valid, m.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Money.
+func (m *Money) UnmarshalCSV(cell string) error {
+	return m.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Money is null.
+func (m Money) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(m.Valid, m.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and a table
+// as UnmarshalJSON accepts an object. An empty string unmarshals to a null
+// Money.
+func (m *Money) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, m.UnmarshalText, m.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random amount of EUR, or an explicit null if shouldBeNull is
+// true.
+func (m *Money) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*m = NewMoney(Amount{}, false)
+		return
+	}
+	m.SetValid(Amount{Minor: randomN(nextInt, 10000000), Currency: "EUR"})
+}
+
+// Generate implements testing/quick's Generator.
+func (m Money) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewMoney(Amount{}, false), func() Money {
+		codes := slices.Sorted(maps.Keys(currencyScales))
+		return MoneyFrom(Amount{Minor: generateInt64(rand), Currency: codes[rand.Intn(len(codes))]})
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The amount is
+// kept in minor units, so that any currency code round trips.
+func (m Money) MarshalBinary() ([]byte, error) {
+	return marshalBinary(m.Set, m.Valid, func() ([]byte, error) {
+		return []byte(strconv.FormatInt(m.Money.Minor, 10) + " " + m.Money.Currency), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *Money) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Money")
+	if err != nil {
+		return err
+	}
+	var v Amount
+	if valid {
+		minor, currency, _ := strings.Cut(string(p), " ")
+		if v.Minor, err = strconv.ParseInt(minor, 10, 64); err != nil {
+			return fmt.Errorf("null: cannot decode %q as binary null.Money: %w", p, err)
+		}
+		v.Currency = currency
+	}
+	m.Money, m.Valid, m.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It accepts a string or []byte in the same form as UnmarshalText, such as
+// "12.34 EUR". To scan separate amount and currency columns, scan them
+// into a Decimal and a String and join them with MoneyFromColumns.
+func (m *Money) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Money", value)
+	var a Amount
+	switch x := value.(type) {
+	case nil:
+		m.Money, m.Valid, m.Set = Amount{}, false, true
+		return nil
+	case string:
+		a, err = parseMoney(x)
+	case []byte:
+		a, err = parseMoney(string(x))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Money: %v", value, value)
+	}
+	m.Set = true
+	m.Valid = err == nil
+	m.Money = a
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// It returns the text form, such as "12.34 EUR"; see Columns for separate
+// columns.
+func (m Money) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (m Money) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString implements the GoStringer interface, formatting m as the Go
+// expression that builds it.
+func (m Money) GoString() string {
+	payload := fmt.Sprintf("null.Amount{Minor: %d, Currency: %q}", m.Money.Minor, m.Money.Currency)
+	return goString("Money", "", "Money", payload, m.Money == Amount{}, m.Valid, m.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// m with the verb if it is valid.
+func (m Money) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, m, m.Valid, m.Set, m.Money)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the text form, or to nil if this Money
+// is null.
+func (m Money) LogValue() slog.Value {
+	if !m.Valid {
+		return logNull
+	}
+	return slog.StringValue(m.Money.String())
+}
+
+// parseMoney parses the text form of Money, such as "12.34 EUR".
+func parseMoney(s string) (Amount, error) {
+	amount, currency, ok := strings.Cut(s, " ")
+	if !ok {
+		return Amount{}, fmt.Errorf("null: cannot parse %q as money, want an amount and a currency such as \"12.34 EUR\"", s)
+	}
+	return ParseAmount(amount, currency)
+}
+
+// currencyScales maps the active ISO 4217 currency codes to their number of
+// decimals, leaving out the codes that have none defined, such as XAU.
+var currencyScales = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2,
+	"AUD": 2, "AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2,
+	"BMD": 2, "BND": 2, "BOB": 2, "BOV": 2, "BRL": 2, "BSD": 2, "BTN": 2,
+	"BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHE": 2, "CHF": 2,
+	"CHW": 2, "CNY": 2, "COP": 2, "COU": 2, "CRC": 2, "CUP": 2, "CVE": 2,
+	"CZK": 2, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2,
+	"EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2,
+	"GMD": 2, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "IRR": 2, "JMD": 2, "KES": 2, "KGS": 2,
+	"KHR": 2, "KPW": 2, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2,
+	"LRD": 2, "LSL": 2, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2,
+	"MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2,
+	"MXV": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2,
+	"NPR": 2, "NZD": 2, "PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2, "PKR": 2,
+	"PLN": 2, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2, "SBD": 2,
+	"SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2, "SLE": 2, "SOS": 2,
+	"SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2, "SZL": 2, "THB": 2,
+	"TJS": 2, "TMT": 2, "TOP": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2,
+	"UAH": 2, "USD": 2, "USN": 2, "UYU": 2, "UZS": 2, "VED": 2, "VES": 2,
+	"WST": 2, "XCD": 2, "XCG": 2, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2,
+
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+
+	"CLF": 4, "UYW": 4,
+}
@@ -0,0 +1,167 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		minor            int64
+		text             string
+	}{
+		{"12.34", "EUR", 1234, "12.34 EUR"},
+		{"12.3", "EUR", 1230, "12.30 EUR"},
+		{"12", "EUR", 1200, "12.00 EUR"},
+		{"-0.05", "USD", -5, "-0.05 USD"},
+		{"-0", "USD", 0, "0.00 USD"},
+		{"007", "USD", 700, "7.00 USD"},
+		{"1234", "JPY", 1234, "1234 JPY"},
+		{"1.234", "KWD", 1234, "1.234 KWD"},
+		{"0.0001", "CLF", 1, "0.0001 CLF"},
+		{"-92233720368547758.08", "EUR", -9223372036854775808, "-92233720368547758.08 EUR"},
+	}
+	for _, test := range tests {
+		a, err := ParseAmount(test.amount, test.currency)
+		if err != nil || a != (Amount{test.minor, test.currency}) {
+			t.Errorf("ParseAmount(%q, %q) = %#v, %v", test.amount, test.currency, a, err)
+			continue
+		}
+		if s := a.String(); s != test.text {
+			t.Errorf("%#v.String() = %q, want %q", a, s, test.text)
+		}
+	}
+
+	for _, bad := range [][2]string{
+		{"12.34", "XYZ"}, {"12.34", "eur"}, {"12.34", ""}, {"12.34", "XAU"},
+		{"12.345", "EUR"}, {"12.340", "EUR"}, {"1.5", "JPY"},
+		{"", "EUR"}, {"-", "EUR"}, {".5", "EUR"}, {"5.", "EUR"}, {"+5", "EUR"},
+		{"1e3", "EUR"}, {"1,5", "EUR"}, {" 5", "EUR"},
+	} {
+		if a, err := ParseAmount(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseAmount(%q, %q) = %#v, want an error", bad[0], bad[1], a)
+		}
+	}
+	if _, err := ParseAmount("92233720368547758.08", "EUR"); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ParseAmount() out of range: %v", err)
+	}
+
+	if s := (Amount{Minor: 5, Currency: "XYZ"}).String(); s != `5 minor units of "XYZ"` {
+		t.Errorf("String() of an unknown currency = %q", s)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	m := MoneyFrom(Amount{Minor: 1234, Currency: "EUR"})
+	data, err := json.Marshal(m)
+	maybePanic(err)
+	if string(data) != `{"amount":"12.34","currency":"EUR"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+	data, err = json.Marshal(NewMoney(Amount{}, false))
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null Money")
+
+	for _, in := range []string{
+		`{"amount":"12.34","currency":"EUR"}`,
+		`{"currency":"EUR","amount":12.34}`,
+		`{ "amount" : "12.34", "currency" : "EUR" }`,
+	} {
+		var back Money
+		maybePanic(json.Unmarshal([]byte(in), &back))
+		if !back.ExactEqual(m) {
+			t.Errorf("unmarshal %s = %#v", in, back)
+		}
+	}
+
+	for _, bad := range []string{
+		`{}`, `{"amount":"12.34"}`, `{"currency":"EUR"}`, `{"amount":"12.34","currency":"EUR","note":""}`,
+		`{"amount":"12.345","currency":"EUR"}`, `{"amount":"12.34","currency":"ABC"}`,
+		`{"amount":null,"currency":"EUR"}`, `{"amount":"12.34","currency":null}`,
+		`{"amount":1.2e1,"currency":"EUR"}`, `"12.34 EUR"`, `12.34`,
+	} {
+		var back Money
+		if err := json.Unmarshal([]byte(bad), &back); err == nil || back.Valid {
+			t.Errorf("unmarshal %s = %#v, %v, want an error", bad, back, err)
+		}
+	}
+
+	if _, err := MoneyFrom(Amount{Minor: 1, Currency: "ABC"}).MarshalJSON(); err == nil {
+		t.Error("marshaling an unknown currency should fail")
+	}
+}
+
+func TestMoneyTextScanValue(t *testing.T) {
+	m, err := MoneyFromString("-0.50 USD")
+	if err != nil || m != MoneyFrom(Amount{Minor: -50, Currency: "USD"}) {
+		t.Errorf("MoneyFromString() = %#v, %v", m, err)
+	}
+	if m, err := MoneyFromString(""); err != nil || !m.IsNull() {
+		t.Errorf("MoneyFromString(\"\") = %#v, %v", m, err)
+	}
+	for _, bad := range []string{"12.34", "12.34EUR", "12.34  EUR", "EUR 12.34", "12.345 EUR"} {
+		if m, err := MoneyFromString(bad); err == nil || m.Valid || !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("MoneyFromString(%q) = %#v, %v, want an error", bad, m, err)
+		}
+	}
+
+	v, err := m.Value()
+	if err != nil || v != "-0.50 USD" {
+		t.Errorf("Value() = %#v, %v", v, err)
+	}
+	var back Money
+	maybePanic(back.Scan([]byte("-0.50 USD")))
+	if !back.ExactEqual(m) {
+		t.Errorf("Scan() = %#v", back)
+	}
+	maybePanic(back.Scan(nil))
+	if !back.IsNull() {
+		t.Errorf("Scan(nil) = %#v", back)
+	}
+	if err := back.Scan(int64(5)); err == nil || back.Valid {
+		t.Errorf("Scan(5) = %#v, %v, want an error", back, err)
+	}
+}
+
+func TestMoneyColumns(t *testing.T) {
+	m := MoneyFrom(Amount{Minor: 1200, Currency: "JPY"})
+	amount, currency, err := m.Columns()
+	if err != nil || amount != DecimalFrom("1200") || currency != StringFrom("JPY") {
+		t.Errorf("Columns() = %#v, %#v, %v", amount, currency, err)
+	}
+	back, err := MoneyFromColumns(amount, currency)
+	if err != nil || !back.ExactEqual(m) {
+		t.Errorf("MoneyFromColumns() = %#v, %v", back, err)
+	}
+
+	for _, m := range []Money{{}, NewMoney(Amount{}, false)} {
+		amount, currency, err := m.Columns()
+		if err != nil || amount.Valid || currency.Valid || amount.Set != m.Set || currency.Set != m.Set {
+			t.Errorf("%#v.Columns() = %#v, %#v, %v", m, amount, currency, err)
+		}
+		back, err := MoneyFromColumns(amount, currency)
+		if err != nil || !back.ExactEqual(m) {
+			t.Errorf("MoneyFromColumns() = %#v, %v, want %#v", back, err, m)
+		}
+	}
+
+	for _, pair := range []struct {
+		amount   Decimal
+		currency String
+	}{
+		{DecimalFrom("1.00"), NewString("", false)},
+		{NewDecimal("", false), StringFrom("EUR")},
+		{Decimal{}, StringFrom("EUR")},
+		{DecimalFrom("1.001"), StringFrom("EUR")},
+	} {
+		if m, err := MoneyFromColumns(pair.amount, pair.currency); err == nil || m.IsSet() {
+			t.Errorf("MoneyFromColumns(%#v, %#v) = %#v, want an error", pair.amount, pair.currency, m)
+		}
+	}
+
+	if _, _, err := MoneyFrom(Amount{Currency: "ABC"}).Columns(); err == nil {
+		t.Error("Columns() of an unknown currency should fail")
+	}
+}
@@ -0,0 +1,490 @@
+package null
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// The msgpack methods implement the msgpack.Marshaler and
+// msgpack.Unmarshaler interfaces of github.com/vmihailenco/msgpack, which
+// exchange encoded bytes rather than the package's own Encoder and Decoder
+// types, so the package needs no msgpack dependency. The msgpack package
+// prefers them over MarshalBinary. Valid values encode as the plain msgpack
+// scalar and null values as nil; Time uses the msgpack timestamp
+// extension.
+//
+// The msgpack package zeroes a value itself when it reads nil, without
+// calling UnmarshalMsgpack, so Set is only true for non-nil values that
+// were present in the input.
+
+// msgpackMarshaler and msgpackUnmarshaler mirror the msgpack package's
+// interfaces, for Val to delegate to T.
+type msgpackMarshaler interface {
+	MarshalMsgpack() ([]byte, error)
+}
+
+type msgpackUnmarshaler interface {
+	UnmarshalMsgpack(data []byte) error
+}
+
+// msgpackNull is the msgpack encoding of nil.
+var msgpackNull = []byte{0xc0}
+
+// msgpackTimeExt is the extension type of the msgpack timestamp.
+const msgpackTimeExt = -1
+
+func appendMsgpackInt(dst []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return appendMsgpackUint(dst, uint64(v))
+	case v >= -32:
+		return append(dst, byte(v))
+	case v >= math.MinInt8:
+		return append(dst, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return append(dst, 0xd1, byte(v>>8), byte(v))
+	case v >= math.MinInt32:
+		return appendMsgpackUint32(append(dst, 0xd2), uint32(v))
+	}
+	return appendMsgpackUint64(append(dst, 0xd3), uint64(v))
+}
+
+func appendMsgpackUint(dst []byte, v uint64) []byte {
+	switch {
+	case v <= 0x7f:
+		return append(dst, byte(v))
+	case v <= math.MaxUint8:
+		return append(dst, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return append(dst, 0xcd, byte(v>>8), byte(v))
+	case v <= math.MaxUint32:
+		return appendMsgpackUint32(append(dst, 0xce), uint32(v))
+	}
+	return appendMsgpackUint64(append(dst, 0xcf), v)
+}
+
+func appendMsgpackUint32(dst []byte, v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return append(dst, b...)
+}
+
+func appendMsgpackUint64(dst []byte, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return append(dst, b...)
+}
+
+func appendMsgpackFloat32(dst []byte, v float32) []byte {
+	return appendMsgpackUint32(append(dst, 0xca), math.Float32bits(v))
+}
+
+func appendMsgpackFloat64(dst []byte, v float64) []byte {
+	return appendMsgpackUint64(append(dst, 0xcb), math.Float64bits(v))
+}
+
+func appendMsgpackBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 0xc3)
+	}
+	return append(dst, 0xc2)
+}
+
+func appendMsgpackString(dst []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n <= 31:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		dst = append(dst, 0xda, byte(n>>8), byte(n))
+	default:
+		dst = appendMsgpackUint32(append(dst, 0xdb), uint32(n))
+	}
+	return append(dst, s...)
+}
+
+func appendMsgpackBin(dst []byte, b []byte) []byte {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		dst = append(dst, 0xc5, byte(n>>8), byte(n))
+	default:
+		dst = appendMsgpackUint32(append(dst, 0xc6), uint32(n))
+	}
+	return append(dst, b...)
+}
+
+// appendMsgpackHeader appends an array header, or a map header if isMap
+// is true, for n elements.
+func appendMsgpackHeader(dst []byte, isMap bool, n int) []byte {
+	fix, code := byte(0x90), byte(0xdc)
+	if isMap {
+		fix, code = 0x80, 0xde
+	}
+	switch {
+	case n <= 15:
+		return append(dst, fix|byte(n))
+	case n <= math.MaxUint16:
+		return append(dst, code, byte(n>>8), byte(n))
+	}
+	return appendMsgpackUint32(append(dst, code+1), uint32(n))
+}
+
+// appendMsgpackText appends the output of a MarshalText method as a
+// msgpack string.
+func appendMsgpackText(dst []byte, text func() ([]byte, error)) ([]byte, error) {
+	data, err := text()
+	if err != nil {
+		return nil, err
+	}
+	return appendMsgpackString(dst, string(data)), nil
+}
+
+// appendMsgpackTime appends t using the smallest form of the msgpack
+// timestamp extension.
+func appendMsgpackTime(dst []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		return appendMsgpackUint32(append(dst, 0xd6, 0xff), uint32(sec))
+	case sec >= 0 && sec>>34 == 0:
+		return appendMsgpackUint64(append(dst, 0xd7, 0xff), nsec<<34|uint64(sec))
+	}
+	dst = appendMsgpackUint32(append(dst, 0xc7, 12, 0xff), uint32(nsec))
+	return appendMsgpackUint64(dst, uint64(sec))
+}
+
+// msgpackFromJSON encodes JSON text as msgpack, keeping object keys in
+// order. Integers become msgpack integers and other numbers float64.
+func msgpackFromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	res, err := appendMsgpackJSON(nil, dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("null: cannot encode JSON %q as msgpack: trailing data", data)
+	}
+	return res, nil
+}
+
+func appendMsgpackJSON(dst []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch x := tok.(type) {
+	case nil:
+		return append(dst, msgpackNull...), nil
+	case bool:
+		return appendMsgpackBool(dst, x), nil
+	case string:
+		return appendMsgpackString(dst, x), nil
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return appendMsgpackInt(dst, i), nil
+		}
+		if u, err := strconv.ParseUint(x.String(), 10, 64); err == nil {
+			return appendMsgpackUint(dst, u), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendMsgpackFloat64(dst, f), nil
+	case json.Delim:
+		// The element count isn't known until the end, so encode the
+		// elements first and add the header after.
+		var elems []byte
+		n := 0
+		for ; dec.More(); n++ {
+			if x == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				elems = appendMsgpackString(elems, key.(string))
+			}
+			if elems, err = appendMsgpackJSON(elems, dec); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return append(appendMsgpackHeader(dst, x == '{', n), elems...), nil
+	}
+	return nil, fmt.Errorf("null: unexpected JSON token %v", tok)
+}
+
+// msgpackExt is a decoded msgpack extension value.
+type msgpackExt struct {
+	typ  int8
+	data []byte
+}
+
+// decodeMsgpack decodes a single msgpack value into the Go values listed
+// in decoded.go. Timestamps decode as UTC times and other extensions as
+// msgpackExt.
+func decodeMsgpack(data []byte) (interface{}, error) {
+	v, rest, err := readMsgpack(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("null: %d bytes of trailing msgpack data", len(rest))
+	}
+	return v, nil
+}
+
+var errMsgpackShort = errors.New("null: unexpected end of msgpack data")
+
+func readMsgpack(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errMsgpackShort
+	}
+	c, data := data[0], data[1:]
+	take := func(n int) ([]byte, error) {
+		if n < 0 || n > len(data) {
+			return nil, errMsgpackShort
+		}
+		b := data[:n]
+		data = data[n:]
+		return b, nil
+	}
+	size := func(n int) (int, error) {
+		b, err := take(n)
+		if err != nil {
+			return 0, err
+		}
+		switch n {
+		case 1:
+			return int(b[0]), nil
+		case 2:
+			return int(binary.BigEndian.Uint16(b)), nil
+		}
+		return int(binary.BigEndian.Uint32(b)), nil
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), data, nil
+	case c >= 0xe0:
+		return int64(int8(c)), data, nil
+	case c >= 0xa0 && c <= 0xbf:
+		b, err := take(int(c & 0x1f))
+		return string(b), data, err
+	case c >= 0x90 && c <= 0x9f:
+		return readMsgpackArray(data, int(c&0x0f))
+	case c >= 0x80 && c <= 0x8f:
+		return readMsgpackMap(data, int(c&0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, data, nil
+	case 0xc2, 0xc3:
+		return c == 0xc3, data, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		b, err := take(1 << (c - 0xcc))
+		if err != nil {
+			return nil, nil, err
+		}
+		var u uint64
+		for _, x := range b {
+			u = u<<8 | uint64(x)
+		}
+		if u > math.MaxInt64 {
+			return u, data, nil
+		}
+		return int64(u), data, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		n := 1 << (c - 0xd0)
+		b, err := take(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		var u uint64
+		for _, x := range b {
+			u = u<<8 | uint64(x)
+		}
+		shift := 64 - 8*n
+		return int64(u<<shift) >> shift, data, nil
+	case 0xca:
+		b, err := take(4)
+		if err != nil {
+			return nil, nil, err
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), data, nil
+	case 0xcb:
+		b, err := take(8)
+		if err != nil {
+			return nil, nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), data, nil
+	case 0xd9, 0xda, 0xdb, 0xc4, 0xc5, 0xc6:
+		width := 1
+		switch c {
+		case 0xda, 0xc5:
+			width = 2
+		case 0xdb, 0xc6:
+			width = 4
+		}
+		n, err := size(width)
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := take(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c >= 0xd9 {
+			return string(b), data, nil
+		}
+		return append([]byte{}, b...), data, nil
+	case 0xdc, 0xdd:
+		n, err := size(2 << (c - 0xdc))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackArray(data, n)
+	case 0xde, 0xdf:
+		n, err := size(2 << (c - 0xde))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackMap(data, n)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8, 0xc7, 0xc8, 0xc9:
+		var n int
+		var err error
+		switch c {
+		case 0xc7, 0xc8, 0xc9:
+			n, err = size(1 << (c - 0xc7))
+		default:
+			n = 1 << (c - 0xd4)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		typ, err := take(1)
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := take(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		ext := msgpackExt{typ: int8(typ[0]), data: append([]byte{}, b...)}
+		if ext.typ != msgpackTimeExt {
+			return ext, data, nil
+		}
+		t, err := msgpackExtTime(ext.data)
+		return t, data, err
+	}
+	return nil, nil, fmt.Errorf("null: invalid msgpack code 0x%02x", c)
+}
+
+func readMsgpackArray(data []byte, n int) (interface{}, []byte, error) {
+	if n > len(data) {
+		return nil, nil, errMsgpackShort
+	}
+	res := make([]interface{}, n)
+	for i := range res {
+		var err error
+		if res[i], data, err = readMsgpack(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return res, data, nil
+}
+
+func readMsgpackMap(data []byte, n int) (interface{}, []byte, error) {
+	if 2*n > len(data) {
+		return nil, nil, errMsgpackShort
+	}
+	res := make(decodedMap, n)
+	for i := range res {
+		var err error
+		if res[i].key, data, err = readMsgpack(data); err != nil {
+			return nil, nil, err
+		}
+		if res[i].value, data, err = readMsgpack(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return res, data, nil
+}
+
+// msgpackExtTime decodes the payload of a 32, 64 or 96-bit msgpack
+// timestamp.
+func msgpackExtTime(b []byte) (time.Time, error) {
+	switch len(b) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(b)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(b)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(b)
+		sec := int64(binary.BigEndian.Uint64(b[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("null: invalid msgpack timestamp of %d bytes", len(b))
+}
+
+func msgpackTypeError(v interface{}, to string) error {
+	return decodedTypeError("msgpack", v, to)
+}
+
+func msgpackInt(v interface{}, to string, min, max int64) (int64, error) {
+	return decodedInt("msgpack", v, to, min, max)
+}
+
+func msgpackUint(v interface{}, to string, max uint64) (uint64, error) {
+	return decodedUint("msgpack", v, to, max)
+}
+
+func msgpackFloat(v interface{}, to string) (float64, error) {
+	return decodedFloat("msgpack", v, to)
+}
+
+func msgpackText(v interface{}, to string) (string, error) {
+	return decodedText("msgpack", v, to)
+}
+
+func msgpackTime(v interface{}, to string) (time.Time, error) {
+	return decodedTime("msgpack", v, to)
+}
+
+func msgpackToJSON(v interface{}) ([]byte, error) {
+	return decodedToJSON("msgpack", v)
+}
+
+// unmarshalMsgpackText decodes a msgpack string and passes it to fromText.
+// Nil passes empty text, and so does any other msgpack type before its
+// error is returned, leaving the value set but null.
+func unmarshalMsgpackText(data []byte, to string, fromText func([]byte) error) error {
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		fromText(nil)
+		return err
+	}
+	s, err := msgpackText(v, to)
+	if err != nil {
+		fromText(nil)
+		return err
+	}
+	return fromText([]byte(s))
+}
@@ -0,0 +1,150 @@
+package null
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type msgpackCodec interface {
+	MarshalMsgpack() ([]byte, error)
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := states[2]
+		data, err := valid.(msgpackCodec).MarshalMsgpack()
+		maybePanic(err)
+		ptr := reflect.New(reflect.TypeOf(valid))
+		err = ptr.Interface().(msgpackUnmarshaler).UnmarshalMsgpack(data)
+		maybePanic(err)
+		got := ptr.Elem().Interface()
+		if tm, ok := valid.(Time); ok {
+			// Decoded times are in UTC.
+			valid = TimeFrom(tm.Time.UTC())
+		}
+		if !reflect.DeepEqual(got, valid) {
+			t.Errorf("msgpack round trip of %T: %#v ≠ %#v", valid, got, valid)
+		}
+
+		for _, null := range states[:2] {
+			data, err := null.(msgpackCodec).MarshalMsgpack()
+			maybePanic(err)
+			if !bytes.Equal(data, msgpackNull) {
+				t.Errorf("null %T.MarshalMsgpack() = %x, want c0", null, data)
+			}
+		}
+		ptr = reflect.New(reflect.TypeOf(valid))
+		err = ptr.Interface().(msgpackUnmarshaler).UnmarshalMsgpack(msgpackNull)
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalMsgpack(nil) = %#v, want set null", valid, back.Interface())
+		}
+	}
+}
+
+func TestMsgpackEncoding(t *testing.T) {
+	tests := []struct {
+		in   msgpackCodec
+		want string
+	}{
+		{Int64From(1), "01"},
+		{Int64From(-1), "ff"},
+		{Int64From(-33), "d0df"},
+		{Int64From(300), "cd012c"},
+		{Int64From(-1 << 40), "d3ffffff0000000000"},
+		{Uint64From(math.MaxUint64), "cfffffffffffffffff"},
+		{Float32From(1.5), "ca3fc00000"},
+		{Float64From(1.5), "cb3ff8000000000000"},
+		{BoolFrom(true), "c3"},
+		{StringFrom("hi"), "a26869"},
+		{BytesFrom([]byte{0xab}), "c401ab"},
+		{TimeFrom(time.Unix(1, 0)), "d6ff00000001"},
+		{TimeFrom(time.Unix(1, 1)), "d7ff0000000400000001"},
+		{TimeFrom(time.Unix(-1, 0)), "c70cff00000000ffffffffffffffff"},
+		{JSONFrom([]byte(`{"b":1,"a":[true,null]}`)), "82a16201a16192c3c0"},
+		{StringSliceFrom(nil), "90"},
+		{Int64SliceFrom([]int64{1, -1}), "9201ff"},
+		{DecimalFrom("1.10"), "a4312e3130"},
+	}
+	for _, test := range tests {
+		data, err := test.in.MarshalMsgpack()
+		maybePanic(err)
+		if hex.EncodeToString(data) != test.want {
+			t.Errorf("%T.MarshalMsgpack() = %x, want %s", test.in, data, test.want)
+		}
+	}
+}
+
+func TestMsgpackRelatedTypes(t *testing.T) {
+	tests := []struct {
+		out  msgpackUnmarshaler
+		data string
+		want interface{}
+	}{
+		{&Int64{}, "d0f6", Int64From(-10)},
+		{&Int64{}, "cc80", Int64From(128)},
+		{&Int64{}, "cb4008000000000000", Int64From(3)},
+		{&Uint8{}, "d000", Uint8From(0)},
+		{&Float64{}, "05", Float64From(5)},
+		{&Float32{}, "cb3ff8000000000000", Float32From(1.5)},
+		{&Time{}, "c70cff0000000100000000000000ff", TimeFrom(time.Unix(255, 1).UTC())},
+		{&Time{}, "b4323031322d31322d32315432313a32313a32315a", TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC))},
+		{&Date{}, "aa323031322d31322d3231", DateOf(2012, 12, 21)},
+		{&Duration{}, "cd03e8", DurationFrom(time.Microsecond)},
+		{&Decimal{}, "cb3fd0000000000000", DecimalFrom("0.25")},
+		{&Decimal{}, "fc", DecimalFrom("-4")},
+		{&BigInt{}, "cfffffffffffffffff", BigIntFrom(new(big.Int).SetUint64(math.MaxUint64))},
+		{&String{}, "c4026869", StringFrom("hi")},
+		{&Bytes{}, "a26869", BytesFrom([]byte("hi"))},
+		{&Val[int]{}, "09", ValFrom(9)},
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test.data)
+		maybePanic(err)
+		err = test.out.UnmarshalMsgpack(data)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalMsgpack(%s) = %#v, want %#v", test.out, test.data, got, test.want)
+		}
+	}
+}
+
+func TestMsgpackErrors(t *testing.T) {
+	tests := []struct {
+		out  msgpackUnmarshaler
+		data string
+	}{
+		{&Int64{}, "a3616263"},
+		{&Int8{}, "cd01f4"},
+		{&Int64{}, "cb3ff8000000000000"},
+		{&Int64{}, "cfffffffffffffffff"},
+		{&Uint64{}, "ff"},
+		{&Bool{}, "01"},
+		{&Int32{}, "cd01"},
+		{&Int32{}, "0101"},
+		{&String{}, "c1"},
+		{&UUID{}, "a3616263"},
+		{&Enum[testStatus]{}, "a3616263"},
+		{&Map{}, "90"},
+		{&Map{}, "810101"},
+		{&StringSlice{}, "80"},
+		{&Time{}, "d4ff00"},
+		{&JSON{}, "d40100"},
+		{&Val[int]{}, "a3616263"},
+	}
+	for _, test := range tests {
+		data, err := hex.DecodeString(test.data)
+		maybePanic(err)
+		if err := test.out.UnmarshalMsgpack(data); err == nil {
+			t.Errorf("%T.UnmarshalMsgpack(%s) should fail", test.out, test.data)
+		}
+		if reflect.ValueOf(test.out).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalMsgpack", test.out)
+		}
+	}
+}
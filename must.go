@@ -0,0 +1,17 @@
+package null
+
+// NullError is the value MustGet panics with when it is called on a null
+// value, so recover handlers can tell these panics apart.
+type NullError struct {
+	// Type is the name of the null type, such as "null.Int64".
+	Type string
+}
+
+func (e *NullError) Error() string {
+	return "null: MustGet called on a null " + e.Type
+}
+
+// Is makes a *NullError match ErrNullValue.
+func (e *NullError) Is(target error) bool {
+	return target == ErrNullValue
+}
@@ -0,0 +1,73 @@
+package null
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMustGet(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := reflect.ValueOf(states[2])
+		payload := valid.Field(0).Interface()
+		if got := valid.MethodByName("MustGet").Call(nil)[0].Interface(); !reflect.DeepEqual(got, payload) {
+			t.Errorf("%#v.MustGet() = %#v, want %#v", valid, got, payload)
+		}
+
+		for _, null := range states[:2] {
+			err := mustGetPanic(reflect.ValueOf(null))
+			var nullErr *NullError
+			if !errors.As(err, &nullErr) {
+				t.Errorf("%#v.MustGet() panicked with %v, want a *NullError", null, err)
+				continue
+			}
+			if want := "null." + strings.SplitN(reflect.TypeOf(null).Name(), "[", 2)[0]; !strings.HasPrefix(nullErr.Type, want) {
+				t.Errorf("%#v.MustGet() NullError.Type = %s, want %s", null, nullErr.Type, want)
+			}
+		}
+	}
+
+	err := mustGetPanic(reflect.ValueOf(Int64{}))
+	if want := "null: MustGet called on a null null.Int64"; err == nil || err.Error() != want {
+		t.Errorf("Int64.MustGet() error = %v, want %s", err, want)
+	}
+	err = mustGetPanic(reflect.ValueOf(Enum[testStatus]{}))
+	if want := "null: MustGet called on a null null.Enum[null.testStatus]"; err == nil || err.Error() != want {
+		t.Errorf("Enum.MustGet() error = %v, want %s", err, want)
+	}
+}
+
+func mustGetPanic(v reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+	v.MethodByName("MustGet").Call(nil)
+	return nil
+}
+
+func TestGet(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := reflect.ValueOf(states[2])
+		// Map, StringMap and MapOf have Get(key) instead.
+		if m, ok := valid.Type().MethodByName("Get"); !ok || m.Type.NumIn() != 1 {
+			continue
+		}
+		out := valid.MethodByName("Get").Call(nil)
+		if !reflect.DeepEqual(out[0].Interface(), valid.Field(0).Interface()) || !out[1].Bool() {
+			t.Errorf("%#v.Get() = %#v, %t", valid, out[0], out[1].Bool())
+		}
+		for _, null := range states[:2] {
+			out := reflect.ValueOf(null).MethodByName("Get").Call(nil)
+			if !out[0].IsZero() || out[1].Bool() {
+				t.Errorf("%#v.Get() = %#v, %t, want zero, false", null, out[0], out[1].Bool())
+			}
+		}
+	}
+
+	if v, ok := IntFrom(42).Get(); v != 42 || !ok {
+		t.Errorf("IntFrom(42).Get() = %d, %t", v, ok)
+	}
+}
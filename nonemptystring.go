@@ -0,0 +1,76 @@
+package null
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+)
+
+// NonEmptyString is a String that writes an empty string to the database as
+// NULL, for columns where "" and NULL must not both appear, such as a
+// nullable column under a unique index. Only Value differs: it returns nil
+// when the String is null, unset or valid but empty. Every other method,
+// Scan and the JSON and text encoders included, is String's, so an empty
+// JSON string still decodes as a valid "" and marshals back as "".
+//
+// A valid "" written and read back therefore scans as a set null. Only the
+// empty string is affected; whitespace such as " " is written as it is.
+// Use it per field, in place of String:
+//
+//	type User struct {
+//		Email null.NonEmptyString `db:"email"`
+//	}
+type NonEmptyString struct {
+	String
+}
+
+// NewNonEmptyString creates a new NonEmptyString.
+func NewNonEmptyString(s string, valid bool) NonEmptyString {
+	return NonEmptyString{NewString(s, valid)}
+}
+
+// NonEmptyStringFrom creates a new NonEmptyString that will always be valid,
+// even if s is empty.
+func NonEmptyStringFrom(s string) NonEmptyString {
+	return NonEmptyString{StringFrom(s)}
+}
+
+// NonEmptyStringFromPtr creates a new NonEmptyString that will be null if s
+// is nil.
+func NonEmptyStringFromPtr(s *string) NonEmptyString {
+	return NonEmptyString{StringFromPtr(s)}
+}
+
+// Value implements the driver Valuer interface.
+// It returns nil for an empty string, as for a null or unset one.
+func (s NonEmptyString) Value() (driver.Value, error) {
+	if !s.Valid || s.String.String == "" {
+		return nil, nil
+	}
+	return s.String.String, nil
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it.
+func (s NonEmptyString) GoString() string {
+	if !s.Set {
+		if s.String == (String{}) {
+			return "null.NonEmptyString{}"
+		}
+		return "null.NonEmptyString{String: " + s.String.GoString() + "}"
+	}
+	return goString("NonEmptyString", "", "String", strconv.Quote(s.String.String), s.String.String == "", s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// s with the verb if it is valid.
+func (s NonEmptyString) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, s.String.String)
+}
+
+// Generate implements quick.Generator, generating values like String's.
+func (s NonEmptyString) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(NonEmptyString{s.String.Generate(rand, size).Interface().(String)})
+}
@@ -0,0 +1,165 @@
+package null
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+type testProfile struct {
+	Email NonEmptyString `json:"email"`
+}
+
+func TestNonEmptyStringValue(t *testing.T) {
+	tests := []struct {
+		s    NonEmptyString
+		want interface{}
+	}{
+		{NonEmptyStringFrom(""), nil},
+		{NonEmptyStringFrom(" "), " "},
+		{NonEmptyStringFrom("a"), "a"},
+		{NewNonEmptyString("a", false), nil},
+		{NonEmptyStringFromPtr(nil), nil},
+		{NonEmptyString{String{String: "a"}}, nil},
+		{NonEmptyString{String{String: "a", Valid: true}}, "a"},
+		{NonEmptyString{}, nil},
+	}
+	db := &rowsDB{cols: []string{"email"}}
+	conn := sql.OpenDB(db)
+	defer conn.Close()
+	for _, test := range tests {
+		_, err := conn.Exec("INSERT", test.s)
+		maybePanic(err)
+	}
+	for i, test := range tests {
+		if got := db.data[i][0]; got != test.want {
+			t.Errorf("%#v wrote %#v, want %#v", test.s, got, test.want)
+		}
+	}
+
+	// An explicitly set empty string comes back as a set null.
+	rows, err := conn.Query("SELECT")
+	maybePanic(err)
+	rows.Next()
+	var got NonEmptyString
+	maybePanic(rows.Scan(&got))
+	maybePanic(rows.Close())
+	if !got.IsSet() || got.Valid {
+		t.Errorf("Scan() of a written \"\" = %#v, want a set null", got)
+	}
+}
+
+func TestNonEmptyStringLikeString(t *testing.T) {
+	var s NonEmptyString
+	maybePanic(s.Scan(""))
+	if !s.Valid || !s.IsSet() || s.String.String != "" {
+		t.Errorf("Scan(\"\") = %#v, want a valid empty string", s)
+	}
+
+	var p testProfile
+	maybePanic(json.Unmarshal([]byte(`{"email":""}`), &p))
+	if !p.Email.Valid || !p.Email.IsSet() || p.Email.String.String != "" {
+		t.Errorf("UnmarshalJSON(\"\") = %#v", p.Email)
+	}
+	data, err := json.Marshal(p)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"email":""}`, "json.Marshal")
+
+	for _, s := range []NonEmptyString{NonEmptyStringFrom(""), NonEmptyStringFrom("a"), NewNonEmptyString("", false), {}} {
+		want, err := json.Marshal(s.String)
+		maybePanic(err)
+		got, err := json.Marshal(s)
+		maybePanic(err)
+		if string(got) != string(want) {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", s, got, want)
+		}
+		wantText, wantErr := s.String.MarshalText()
+		gotText, err := s.MarshalText()
+		if string(gotText) != string(wantText) || (err == nil) != (wantErr == nil) {
+			t.Errorf("MarshalText(%#v) = %q, %v", s, gotText, err)
+		}
+		if got, want := fmt.Sprintf("%v|%5s|%q", s, s, s), fmt.Sprintf("%v|%5s|%q", s.String, s.String, s.String); got != want {
+			t.Errorf("Sprintf() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNonEmptyStringGoString(t *testing.T) {
+	tests := []struct {
+		s    NonEmptyString
+		want string
+	}{
+		{NonEmptyStringFrom("a"), `null.NonEmptyStringFrom("a")`},
+		{NewNonEmptyString("", false), `null.NewNonEmptyString("", false)`},
+		{NonEmptyString{}, `null.NonEmptyString{}`},
+		{NonEmptyString{String{String: "a"}}, `null.NonEmptyString{String: null.String{String: "a"}}`},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf("%#v", test.s); got != test.want {
+			t.Errorf("%%#v = %s, want %s", got, test.want)
+		}
+	}
+
+	f := func(s NonEmptyString) bool {
+		_, err := s.Value()
+		return err == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNonEmptyStringStructHelpers(t *testing.T) {
+	type row struct {
+		Email NonEmptyString `db:"email"`
+		Name  NonEmptyString `db:"name"`
+		Nick  NonEmptyString `db:"nick"`
+	}
+	r := row{Email: NonEmptyStringFrom("a@example.com"), Name: NewNonEmptyString("", false)}
+
+	if cols, err := SetColumns(r, "db"); err != nil || !reflect.DeepEqual(cols, []string{"email", "name"}) {
+		t.Errorf("SetColumns() = %v, %v", cols, err)
+	}
+	if cols, err := ValidColumns(r, "db"); err != nil || !reflect.DeepEqual(cols, []string{"email"}) {
+		t.Errorf("ValidColumns() = %v, %v", cols, err)
+	}
+	if vals, err := Values(r, "db"); err != nil || !reflect.DeepEqual(vals, map[string]interface{}{"email": "a@example.com", "name": nil}) {
+		t.Errorf("Values() = %#v, %v", vals, err)
+	}
+
+	var plain struct {
+		Email string
+		Name  *string
+		Nick  NonEmptyString
+	}
+	plain.Name = new(string)
+	plain.Nick = NonEmptyStringFrom("kept")
+	maybePanic(ApplyPatch(&plain, r))
+	if plain.Email != "a@example.com" || plain.Name != nil || plain.Nick != NonEmptyStringFrom("kept") {
+		t.Errorf("ApplyPatch() = %#v", plain)
+	}
+
+	base := row{Name: NonEmptyStringFrom("old"), Nick: NonEmptyStringFrom("nick")}
+	maybePanic(Merge(&base, r))
+	if base != (row{Email: r.Email, Name: r.Name, Nick: NonEmptyStringFrom("nick")}) {
+		t.Errorf("Merge() = %#v", base)
+	}
+
+	var ptrs struct {
+		Email *string
+		Name  *string
+		Nick  *string
+	}
+	maybePanic(ToPtrStruct(r, &ptrs))
+	if ptrs.Email == nil || *ptrs.Email != "a@example.com" || ptrs.Name != nil || ptrs.Nick != nil {
+		t.Errorf("ToPtrStruct() = %#v", ptrs)
+	}
+	var back row
+	maybePanic(FromPtrStruct(ptrs, &back))
+	if back.Email != r.Email || !back.Name.IsNull() || !back.Nick.IsNull() {
+		t.Errorf("FromPtrStruct() = %#v", back)
+	}
+}
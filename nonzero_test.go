@@ -0,0 +1,74 @@
+package null
+
+import (
+	"math"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type nullable interface {
+	IsValid() bool
+	IsSet() bool
+}
+
+func TestFromNonZero(t *testing.T) {
+	now := time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		zero, some nullable
+	}{
+		{"Bool", BoolFromNonZero(false), BoolFromNonZero(true)},
+		{"Byte", ByteFromNonZero(0), ByteFromNonZero('a')},
+		{"Rune", RuneFromNonZero(0), RuneFromNonZero('é')},
+		{"Bytes", BytesFromNonEmpty([]byte{}), BytesFromNonEmpty([]byte{0})},
+		{"RawBytes", RawBytesFromNonEmpty(nil), RawBytesFromNonEmpty([]byte("x"))},
+		{"HexBytes", HexBytesFromNonEmpty(nil), HexBytesFromNonEmpty([]byte{0xff})},
+		{"JSON", JSONFromNonEmpty(nil), JSONFromNonEmpty([]byte(`0`))},
+		{"String", StringFromNonEmpty(""), StringFromNonEmpty(" ")},
+		{"Int", IntFromNonZero(0), IntFromNonZero(-1)},
+		{"Int8", Int8FromNonZero(0), Int8FromNonZero(math.MinInt8)},
+		{"Int16", Int16FromNonZero(0), Int16FromNonZero(1)},
+		{"Int32", Int32FromNonZero(0), Int32FromNonZero(1)},
+		{"Int64", Int64FromNonZero(0), Int64FromNonZero(math.MaxInt64)},
+		{"Uint", UintFromNonZero(0), UintFromNonZero(1)},
+		{"Uint8", Uint8FromNonZero(0), Uint8FromNonZero(1)},
+		{"Uint16", Uint16FromNonZero(0), Uint16FromNonZero(1)},
+		{"Uint32", Uint32FromNonZero(0), Uint32FromNonZero(1)},
+		{"Uint64", Uint64FromNonZero(0), Uint64FromNonZero(math.MaxUint64)},
+		{"Float32", Float32FromNonZero(float32(math.Copysign(0, -1))), Float32FromNonZero(float32(math.NaN()))},
+		{"Float64", Float64FromNonZero(math.Copysign(0, -1)), Float64FromNonZero(math.SmallestNonzeroFloat64)},
+		{"Time", TimeFromNonZero(time.Time{}), TimeFromNonZero(time.Unix(0, 0))},
+		{"Date", DateFromNonZero(time.Time{}), DateFromNonZero(now)},
+		{"TimeOfDay", TimeOfDayFromNonZero(0), TimeOfDayFromNonZero(time.Second)},
+		{"Duration", DurationFromNonZero(0), DurationFromNonZero(-time.Nanosecond)},
+		{"Decimal", DecimalFromNonEmpty(""), DecimalFromNonEmpty("0")},
+		{"UUID", UUIDFromNonZero([16]byte{}), UUIDFromNonZero([16]byte{15: 1})},
+		{"URL", URLFromNonEmpty(&url.URL{}), URLFromNonEmpty(&url.URL{Path: "/"})},
+		{"URL nil", URLFromNonEmpty(nil), URLFromNonEmpty(&url.URL{Scheme: "https", Host: "example.com"})},
+		{"IP", IPFromNonZero(netip.Addr{}), IPFromNonZero(netip.IPv4Unspecified())},
+		{"CIDR", CIDRFromNonZero(netip.Prefix{}), CIDRFromNonZero(netip.MustParsePrefix("0.0.0.0/0"))},
+		{"BigInt", BigIntFromNonZero(new(big.Int)), BigIntFromNonZero(big.NewInt(-1))},
+		{"BigInt nil", BigIntFromNonZero(nil), BigIntFromNonZero(big.NewInt(1))},
+		{"Map", MapFromNonEmpty(map[string]interface{}{}), MapFromNonEmpty(map[string]interface{}{"": nil})},
+		{"StringSlice", StringSliceFromNonEmpty(nil), StringSliceFromNonEmpty([]string{""})},
+		{"Int64Slice", Int64SliceFromNonEmpty([]int64{}), Int64SliceFromNonEmpty([]int64{0})},
+		{"Enum", EnumFromNonEmpty(testStatus("")), EnumFromNonEmpty(statusActive)},
+		{"Val", ValFromNonZero(testPoint{}), ValFromNonZero(testPoint{X: 1})},
+		{"Val pointer", ValFromNonZero[*int](nil), ValFromNonZero(new(int))},
+		{"Val interface", ValFromNonZero[interface{}](nil), ValFromNonZero[interface{}](0)},
+		{"Slice", SliceFromNonEmpty[int](nil), SliceFromNonEmpty([]int{0})},
+		{"MapOf", MapOfFromNonEmpty(map[string]int{}), MapOfFromNonEmpty(map[string]int{"": 0})},
+		{"JSONOf", JSONOfFromNonZero(testPoint{}), JSONOfFromNonZero(testPoint{Y: -1})},
+	}
+	for _, test := range tests {
+		if test.zero.IsValid() || !test.zero.IsSet() {
+			t.Errorf("%s: zero value gives %#v, want a set null", test.name, test.zero)
+		}
+		if !test.some.IsValid() {
+			t.Errorf("%s: non-zero value gives %#v, want it valid", test.name, test.some)
+		}
+	}
+}
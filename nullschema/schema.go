@@ -0,0 +1,56 @@
+// Package nullschema registers the null types with github.com/gorilla/schema,
+// so URL-encoded forms decode into structs that hold them.
+//
+// Each value is parsed with the type's UnmarshalText, so an empty form
+// value, such as "age=", decodes to a null that is Set, and the rules for
+// what parses are those of the text form everywhere else. A key missing
+// from the form leaves its field as it was, which is unset in a new struct.
+package nullschema
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/gorilla/schema"
+	"github.com/sisoftrg/null"
+)
+
+// Register registers converters with d for String, Bool, Byte, Time, and
+// the Int, Uint and Float types. Recent versions of gorilla/schema fall back
+// to UnmarshalText without it, with the same results for single fields, but
+// older ones fail with "converter not found".
+//
+// Converters cannot return errors, so a value that does not parse fails
+// Decode with a schema.ConversionError whose Err is nil.
+func Register(d *schema.Decoder) {
+	register[null.String](d)
+	register[null.Bool](d)
+	register[null.Byte](d)
+	register[null.Time](d)
+	register[null.Int](d)
+	register[null.Int8](d)
+	register[null.Int16](d)
+	register[null.Int32](d)
+	register[null.Int64](d)
+	register[null.Uint](d)
+	register[null.Uint8](d)
+	register[null.Uint16](d)
+	register[null.Uint32](d)
+	register[null.Uint64](d)
+	register[null.Float32](d)
+	register[null.Float64](d)
+}
+
+// register registers a converter for T that parses with UnmarshalText.
+func register[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](d *schema.Decoder) {
+	d.RegisterConverter(*new(T), func(s string) reflect.Value {
+		var v T
+		if err := P(&v).UnmarshalText([]byte(s)); err != nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(v)
+	})
+}
@@ -0,0 +1,92 @@
+package nullschema
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/sisoftrg/null"
+)
+
+type form struct {
+	Name    null.String  `schema:"name"`
+	Note    null.String  `schema:"note"`
+	Count   null.Int     `schema:"count"`
+	Size    null.Int64   `schema:"size"`
+	Port    null.Uint16  `schema:"port"`
+	Ratio   null.Float64 `schema:"ratio"`
+	Verbose null.Bool    `schema:"verbose"`
+	Grade   null.Byte    `schema:"grade"`
+	Since   null.Time    `schema:"since"`
+	Until   null.Time    `schema:"until"`
+	Limit   *null.Int    `schema:"limit"`
+}
+
+func TestRegister(t *testing.T) {
+	d := schema.NewDecoder()
+	Register(d)
+
+	var f form
+	err := d.Decode(&f, url.Values{
+		"name":    {"alice"},
+		"note":    {""},
+		"count":   {"0"},
+		"size":    {""},
+		"port":    {"8080"},
+		"ratio":   {"0.5"},
+		"verbose": {"true"},
+		"grade":   {"A"},
+		"since":   {"2012-12-21T21:21:21Z"},
+		"limit":   {"10"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := form{
+		Name:    null.StringFrom("alice"),
+		Note:    null.NewString("", false),
+		Count:   null.IntFrom(0),
+		Size:    null.NewInt64(0, false),
+		Port:    null.Uint16From(8080),
+		Ratio:   null.Float64From(0.5),
+		Verbose: null.BoolFrom(true),
+		Grade:   null.ByteFrom('A'),
+		Since:   null.TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)),
+	}
+	limit := null.IntFrom(10)
+	want.Limit = &limit
+	if f.Limit == nil || *f.Limit != *want.Limit {
+		t.Errorf("limit = %#v, want %#v", f.Limit, want.Limit)
+	}
+	f.Limit, want.Limit = nil, nil
+	if !f.Since.ExactEqual(want.Since) {
+		t.Errorf("since = %#v, want %#v", f.Since, want.Since)
+	}
+	f.Since, want.Since = null.Time{}, null.Time{}
+	if f != want {
+		t.Errorf("Decode() = %#v\nwant %#v", f, want)
+	}
+	if f.Until.IsSet() {
+		t.Errorf("missing key = %#v, want unset", f.Until)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	d := schema.NewDecoder()
+	Register(d)
+
+	for key, value := range map[string]string{
+		"count":   "many",
+		"port":    "70000",
+		"verbose": "perhaps",
+		"since":   "yesterday",
+	} {
+		var f form
+		err := d.Decode(&f, url.Values{key: {value}})
+		if _, ok := err.(schema.MultiError)[key].(schema.ConversionError); !ok {
+			t.Errorf("%s=%s: %v, want a ConversionError", key, value, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package nulltest helps test code that uses the null types, with go-cmp
+// options to compare them and random values for property-based tests.
+//
+// Without options, cmp.Diff prints the payload, Valid and Set fields of a
+// mismatched value. With them each value is compared with its own Equal
+// method and printed as its payload, "<null>" or "<unset>", the way the
+// null package's Format methods print it:
+//
+//	if diff := cmp.Diff(want, got, nulltest.Options()); diff != "" {
+//		t.Errorf("user mismatch (-want +got):\n%s", diff)
+//	}
+//
+// reports
+//
+//	  User{
+//	- 	Name: null.String(Inverse(Null, s"alice")),
+//	+ 	Name: null.String(Inverse(Null, s"<null>")),
+//	  }
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sisoftrg/null"
+)
+
+// Options returns a cmp.Option that compares the null types with their
+// Equal methods, so an unset value equals a set null, and Time compares
+// instants rather than locations.
+func Options() cmp.Option {
+	return options("Equal")
+}
+
+// ExactOptions is like Options, but compares with the ExactEqual methods,
+// so Set is significant and an unset value differs from a set null.
+func ExactOptions() cmp.Option {
+	return options("ExactEqual")
+}
+
+func options(method string) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return isNullType(p.Last().Type(), method)
+	}, cmp.Transformer("Null", func(v interface{}) view {
+		return view{reflect.ValueOf(v), method}
+	}))
+}
+
+var pkgPath = reflect.TypeOf(null.String{}).PkgPath()
+
+// isNullType reports whether t is one of the null types, a struct of the
+// null package with Valid and Set fields and a method(t) bool.
+func isNullType(t reflect.Type, method string) bool {
+	if t == nil || t.Kind() != reflect.Struct || t.PkgPath() != pkgPath {
+		return false
+	}
+	for _, name := range []string{"Valid", "Set"} {
+		f, ok := t.FieldByName(name)
+		if !ok || f.Type.Kind() != reflect.Bool {
+			return false
+		}
+	}
+	m, ok := t.MethodByName(method)
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+// view is what the transformer turns a null value into. cmp compares views
+// with their Equal method and prints them with their String method.
+type view struct {
+	v      reflect.Value
+	method string
+}
+
+func (x view) Equal(y view) bool {
+	return x.v.MethodByName(x.method).Call([]reflect.Value{y.v})[0].Bool()
+}
+
+func (x view) String() string {
+	return fmt.Sprint(x.v.Interface())
+}
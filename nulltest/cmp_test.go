@@ -0,0 +1,92 @@
+package nulltest
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sisoftrg/null"
+)
+
+// fields collapses the runs of spaces in a diff, which cmp varies on
+// purpose, to one space.
+func fields(diff string) string {
+	return strings.Join(strings.Fields(diff), " ")
+}
+
+type user struct {
+	Name    null.String
+	Age     *null.Int
+	Born    null.Time
+	Balance null.BigInt
+	Tags    null.Slice[string]
+	Score   null.Val[int]
+	Note    string
+}
+
+func TestOptionsEqual(t *testing.T) {
+	born := time.Date(1990, 5, 17, 12, 0, 0, 0, time.UTC)
+	paris := time.FixedZone("CEST", 2*60*60)
+	a := user{
+		Name:    null.StringFrom("alice"),
+		Age:     &null.Int{},
+		Born:    null.TimeFrom(born),
+		Balance: null.BigIntFrom(big.NewInt(1e18)),
+		Tags:    null.SliceFrom([]string{"admin"}),
+		Note:    "same",
+	}
+	b := user{
+		Name:    null.StringFrom("alice"),
+		Age:     &null.Int{Set: true},
+		Born:    null.TimeFrom(born.In(paris)),
+		Balance: null.BigIntFrom(new(big.Int).SetInt64(1e18)),
+		Tags:    null.SliceFrom([]string{"admin"}),
+		Score:   null.NewVal(0, false),
+		Note:    "same",
+	}
+	if diff := cmp.Diff(a, b, Options()); diff != "" {
+		t.Errorf("Options diff:\n%s", diff)
+	}
+
+	diff := fields(cmp.Diff(a, b, ExactOptions()))
+	for _, want := range []string{
+		`- Age: &null.Int(Inverse(Null, s"<unset>")),`,
+		`+ Age: &null.Int(Inverse(Null, s"<null>")),`,
+		`- Score: null.Val[int](Inverse(Null, s"<unset>")),`,
+		`+ Score: null.Val[int](Inverse(Null, s"<null>")),`,
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("ExactOptions diff lacks %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, "- Born") || strings.Contains(diff, "- Name") {
+		t.Errorf("ExactOptions diff reports equal fields:\n%s", diff)
+	}
+}
+
+func TestOptionsDiff(t *testing.T) {
+	want := user{Name: null.StringFrom("alice"), Tags: null.SliceFrom([]string{"a", "b"}), Note: "x"}
+	got := user{Name: null.NewString("", false), Tags: null.SliceFrom([]string{"a"}), Note: "y"}
+	diff := fields(cmp.Diff(want, got, Options()))
+	for _, line := range []string{
+		`- Name: null.String(Inverse(Null, s"alice")),`,
+		`+ Name: null.String(Inverse(Null, s"<null>")),`,
+		`- Tags: null.Slice[string](Inverse(Null, s"[a b]")),`,
+		`+ Tags: null.Slice[string](Inverse(Null, s"[a]")),`,
+		`- Note: "x",`,
+		`+ Note: "y",`,
+	} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("diff lacks %q:\n%s", line, diff)
+		}
+	}
+	if strings.Contains(diff, "Valid") {
+		t.Errorf("diff prints the raw fields:\n%s", diff)
+	}
+
+	if !cmp.Equal(null.Float64From(1), null.Float64From(1), Options()) || cmp.Equal(null.Float64From(1), null.Float64From(2), Options()) {
+		t.Error("bad top-level comparison")
+	}
+}
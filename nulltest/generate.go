@@ -0,0 +1,34 @@
+package nulltest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+)
+
+// Arbitrary returns a random T drawn from rand. For the null types it calls
+// their Generate methods, so about a quarter of the values are unset, a
+// quarter set nulls and the rest valid, with strings and slices at most size
+// long. Other types, such as structs of null types, are filled in by
+// quick.Value, which picks its own size. Libraries with their own source of
+// randomness can seed rand from it, as with rapid:
+//
+//	name := rapid.Custom(func(t *rapid.T) null.String {
+//		rand := rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed")))
+//		return nulltest.Arbitrary[null.String](rand, 20)
+//	})
+//
+// Arbitrary panics if quick.Value cannot generate a T.
+func Arbitrary[T any](rand *rand.Rand, size int) T {
+	var v T
+	t := reflect.TypeOf(&v).Elem()
+	if g, ok := any(v).(quick.Generator); ok && t.Kind() != reflect.Pointer {
+		return g.Generate(rand, size).Interface().(T)
+	}
+	rv, ok := quick.Value(t, rand)
+	if !ok {
+		panic(fmt.Sprintf("nulltest: cannot generate a %s", t))
+	}
+	return rv.Interface().(T)
+}
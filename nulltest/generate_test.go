@@ -0,0 +1,49 @@
+package nulltest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/sisoftrg/null"
+)
+
+func TestArbitrary(t *testing.T) {
+	rand := rand.New(rand.NewSource(1))
+	var unset, nulls, valid int
+	for i := 0; i < 200; i++ {
+		s := Arbitrary[null.String](rand, 5)
+		switch {
+		case s.Valid:
+			valid++
+			if len([]rune(s.String)) > 5 {
+				t.Errorf("Arbitrary generated %q, longer than the size", s.String)
+			}
+		case s.Set:
+			nulls++
+		default:
+			unset++
+		}
+	}
+	if unset == 0 || nulls == 0 || valid == 0 {
+		t.Errorf("generated %d unset, %d null and %d valid values", unset, nulls, valid)
+	}
+
+	type row struct {
+		ID   null.Int64
+		Tags null.StringSlice
+	}
+	ids := make(map[null.Int64]bool)
+	for i := 0; i < 20; i++ {
+		ids[Arbitrary[row](rand, 5).ID] = true
+	}
+	if len(ids) < 2 {
+		t.Errorf("Arbitrary generated %d distinct structs", len(ids))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Arbitrary of a channel should panic")
+		}
+	}()
+	Arbitrary[chan int](rand, 5)
+}
@@ -0,0 +1,86 @@
+// Package nullunsafe scans text and binary columns into null.String and
+// null.Bytes without copying the driver's buffer, for read-heavy paths such
+// as exports where those copies dominate allocations.
+//
+// THE RESULTS ARE ONLY VALID UNTIL THE NEXT CALL TO rows.Next, rows.Scan OR
+// rows.Close. A driver may reuse the buffer a []byte column is returned in,
+// and then a string or []byte scanned through this package changes under
+// the caller, though Go strings are meant to be immutable. Use each value
+// before moving to the next row, and never store it, send it to another
+// goroutine or keep it in a map; copy it with strings.Clone or Bytes.Clone
+// if it must outlive the row:
+//
+//	var name null.String
+//	for rows.Next() {
+//		if err := rows.Scan(nullunsafe.String(&name)); err != nil {
+//			return err
+//		}
+//		w.Write(name.String) // fine: used before the next row
+//	}
+//
+// Drivers that return a string, rather than a []byte, for a column already
+// hand over memory of their own, and then nothing is different from
+// scanning into the null type directly. The null package itself always
+// copies.
+package nullunsafe
+
+import (
+	"bytes"
+	"database/sql"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/sisoftrg/null"
+)
+
+// String returns a scanner that scans into dst as null.String.Scan does,
+// with the same UTF-8 and length checks, except that a []byte from the
+// driver is turned into the string without a copy. Bytes that are
+// sanitized or rejected are copied as usual.
+//
+// See the package documentation: dst.String is only valid until the next
+// row.
+func String(dst *null.String) sql.Scanner {
+	return (*stringScanner)(dst)
+}
+
+// Bytes returns a scanner that scans into dst as null.Bytes.Scan does,
+// except that a []byte from the driver is kept as it is instead of copied.
+// Postgres bytea hex text is still decoded into new memory.
+//
+// See the package documentation: dst.Bytes is only valid until the next
+// row.
+func Bytes(dst *null.Bytes) sql.Scanner {
+	return (*bytesScanner)(dst)
+}
+
+// stringScanner is a null.String scanned without a copy; converting the
+// pointer needs no allocation, so neither does passing it to rows.Scan.
+type stringScanner null.String
+
+// Scan implements the Scanner interface.
+// Bytes that null.String.Scan would sanitize or reject are left to it.
+func (s *stringScanner) Scan(value interface{}) error {
+	dst := (*null.String)(s)
+	x, ok := value.([]byte)
+	if !ok || (null.StringUTF8 != null.UTF8Keep && !utf8.Valid(x)) ||
+		(null.MaxStringLen > 0 && len(x) > null.MaxStringLen) {
+		return dst.Scan(value)
+	}
+	*dst = null.StringFrom(unsafe.String(unsafe.SliceData(x), len(x)))
+	return nil
+}
+
+// bytesScanner is a null.Bytes scanned without a copy.
+type bytesScanner null.Bytes
+
+// Scan implements the Scanner interface.
+func (b *bytesScanner) Scan(value interface{}) error {
+	dst := (*null.Bytes)(b)
+	x, ok := value.([]byte)
+	if !ok || bytes.HasPrefix(x, []byte(`\x`)) || (null.MaxBytesLen > 0 && len(x) > null.MaxBytesLen) {
+		return dst.Scan(value)
+	}
+	*dst = null.NewBytes(x, true)
+	return nil
+}
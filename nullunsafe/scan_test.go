@@ -0,0 +1,167 @@
+package nullunsafe
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sisoftrg/null"
+)
+
+func TestScanValues(t *testing.T) {
+	var s null.String
+	if err := String(&s).Scan([]byte("alice")); err != nil || s != null.StringFrom("alice") {
+		t.Errorf("String().Scan() = %#v, %v", s, err)
+	}
+	if err := String(&s).Scan([]byte{}); err != nil || s != null.StringFrom("") {
+		t.Errorf("String().Scan() of empty bytes = %#v, %v", s, err)
+	}
+	if err := String(&s).Scan(nil); err != nil || !s.IsNull() {
+		t.Errorf("String().Scan(nil) = %#v, %v", s, err)
+	}
+	if err := String(&s).Scan(int64(7)); err != nil || s != null.StringFrom("7") {
+		t.Errorf("String().Scan(7) = %#v, %v", s, err)
+	}
+
+	defer func(p null.UTF8Policy) { null.StringUTF8 = p }(null.StringUTF8)
+	null.StringUTF8 = null.UTF8Reject
+	buf := []byte("a\xffb")
+	err := String(&s).Scan(buf)
+	var utf8Err *null.UTF8Error
+	if !errors.As(err, &utf8Err) || s.Valid {
+		t.Errorf("String().Scan() of invalid UTF-8 = %#v, %v", s, err)
+	}
+	buf[0] = 'z'
+	var ce *null.ConvertError
+	if errors.As(err, &ce) && ce.Input != "" && ce.Input[0] == 'z' {
+		t.Errorf("the error shares the driver's buffer: %v", err)
+	}
+
+	var b null.Bytes
+	if err := Bytes(&b).Scan([]byte("raw")); err != nil || !b.Valid || string(b.Bytes) != "raw" {
+		t.Errorf("Bytes().Scan() = %#v, %v", b, err)
+	}
+	if err := Bytes(&b).Scan([]byte(`\x6869`)); err != nil || string(b.Bytes) != "hi" {
+		t.Errorf("Bytes().Scan() of bytea hex = %#v, %v", b, err)
+	}
+	if err := Bytes(&b).Scan(nil); err != nil || !b.IsNull() {
+		t.Errorf("Bytes().Scan(nil) = %#v, %v", b, err)
+	}
+
+	defer func(n int) { null.MaxBytesLen = n }(null.MaxBytesLen)
+	null.MaxBytesLen = 2
+	var tooLong *null.TooLongError
+	if err := Bytes(&b).Scan([]byte("raw")); !errors.As(err, &tooLong) || b.Valid {
+		t.Errorf("Bytes().Scan() over MaxBytesLen = %#v, %v", b, err)
+	}
+}
+
+// TestScanInvalidation shows what the package documentation warns of: a
+// driver that reuses its buffer changes what was scanned by the next row.
+func TestScanInvalidation(t *testing.T) {
+	db := sql.OpenDB(&reuseDB{rows: []string{"alice", "bobby"}})
+	defer db.Close()
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var safe, fast null.String
+	var fastBytes null.Bytes
+	rows.Next()
+	if err := rows.Scan(&safe, String(&fast), Bytes(&fastBytes)); err != nil {
+		t.Fatal(err)
+	}
+	if safe.String != "alice" || fast.String != "alice" || string(fastBytes.Bytes) != "alice" {
+		t.Fatalf("scanned %q, %q and %q", safe.String, fast.String, fastBytes.Bytes)
+	}
+
+	rows.Next()
+	if safe.String != "alice" {
+		t.Errorf("null.String changed to %q after Next", safe.String)
+	}
+	if fast.String != "bobby" || string(fastBytes.Bytes) != "bobby" {
+		t.Errorf("with the buffer reused, the unsafe values were %q and %q, want %q",
+			fast.String, fastBytes.Bytes, "bobby")
+	}
+}
+
+func BenchmarkScanString(b *testing.B) {
+	var buf interface{} = bytes.Repeat([]byte("x"), 256)
+	var s null.String
+	b.Run("null", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = s.Scan(buf)
+		}
+	})
+	b.Run("nullunsafe", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = String(&s).Scan(buf)
+		}
+	})
+}
+
+func BenchmarkScanBytes(b *testing.B) {
+	var buf interface{} = bytes.Repeat([]byte("x"), 256)
+	var v null.Bytes
+	b.Run("null", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = v.Scan(buf)
+		}
+	})
+	b.Run("nullunsafe", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = Bytes(&v).Scan(buf)
+		}
+	})
+}
+
+// reuseDB is a database/sql driver whose every query yields rows, each
+// returned three times, as a []byte in one buffer shared by every row.
+type reuseDB struct {
+	rows []string
+}
+
+func (db *reuseDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *reuseDB) Driver() driver.Driver                        { return nil }
+func (db *reuseDB) Prepare(string) (driver.Stmt, error)          { return db, nil }
+func (db *reuseDB) Close() error                                 { return nil }
+func (db *reuseDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (db *reuseDB) NumInput() int                                { return -1 }
+
+func (db *reuseDB) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (db *reuseDB) Query([]driver.Value) (driver.Rows, error) {
+	return &reuseRows{rows: db.rows}, nil
+}
+
+type reuseRows struct {
+	rows []string
+	buf  []byte
+}
+
+func (r *reuseRows) Columns() []string { return []string{"a", "b", "c"} }
+func (r *reuseRows) Close() error      { return nil }
+
+func (r *reuseRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	r.buf = append(r.buf[:0], r.rows[0]...)
+	r.rows = r.rows[1:]
+	for i := range dest {
+		dest[i] = r.buf
+	}
+	return nil
+}
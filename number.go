@@ -0,0 +1,249 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sisoftrg/null/convert"
+)
+
+// unquoteNumber allows numeric types to accept numbers encoded as JSON
+// strings, the same way encoding/json's ",string" option does. A quoted
+// number is returned without its quotes and an empty string is returned as
+// JSON null. Anything that isn't a JSON string is returned unchanged, and a
+// string holding no number is a *json.UnmarshalTypeError, as a string is
+// for a plain number field.
+func unquoteNumber(data []byte, typ string) ([]byte, error) {
+	if len(data) == 0 || data[0] != '"' {
+		return data, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return nil, err
+	}
+	if len(str) == 0 {
+		return NullBytes, nil
+	}
+
+	num := []byte(str)
+	if (num[0] != '-' && (num[0] < '0' || num[0] > '9')) || !json.Valid(num) {
+		return nil, &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(json.Number(""))}
+	}
+	return num, nil
+}
+
+// nonFiniteFloat recognizes the "NaN", "Infinity" and "-Infinity" strings
+// some drivers and feeds use for values JSON numbers cannot express.
+func nonFiniteFloat(data []byte) (float64, bool) {
+	switch {
+	case bytes.Equal(data, []byte(`"NaN"`)):
+		return math.NaN(), true
+	case bytes.Equal(data, []byte(`"Infinity"`)):
+		return math.Inf(1), true
+	case bytes.Equal(data, []byte(`"-Infinity"`)):
+		return math.Inf(-1), true
+	}
+	return 0, false
+}
+
+// appendJSONFloat appends f the way encoding/json formats floats: without
+// an exponent for magnitudes from 1e-6 to below 1e21, and with the shortest
+// exponent otherwise, such as 1e-7 and 1e+21. f must be finite.
+func appendJSONFloat(b []byte, f float64, bits int) []byte {
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// Shorten e-09 to e-9.
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// scanInt converts a driver value for the Scan method of typ, a signed
+// integer type of the given bit size. Besides integers it accepts
+// whole-valued floats and decimal text, as string or []byte, which MySQL
+// and SQLite deliver for computed columns.
+func scanInt(value interface{}, typ string, bits int) (int64, error) {
+	var n int64
+	switch x := value.(type) {
+	case int64:
+		n = x
+	case uint64:
+		if x > math.MaxInt64 {
+			return 0, overflowf("null: %d does not fit in %s", x, typ)
+		}
+		n = int64(x)
+	case float64:
+		if x != math.Trunc(x) {
+			return 0, fmt.Errorf("null: cannot scan %v into %s: not a whole number", x, typ)
+		}
+		if x < math.MinInt64 || x >= math.MaxInt64 {
+			return 0, overflowf("null: %v does not fit in %s", x, typ)
+		}
+		n = int64(x)
+	case string:
+		return parseScanInt(x, typ, bits)
+	case []byte:
+		return parseScanInt(string(x), typ, bits)
+	default:
+		if err := convert.ConvertAssign(&n, value); err != nil {
+			return 0, fmt.Errorf("null: cannot scan type %T into %s: %w", value, typ, err)
+		}
+	}
+	if bits < 64 && (n < -1<<(bits-1) || n > 1<<(bits-1)-1) {
+		return 0, overflowf("null: %d does not fit in %s", n, typ)
+	}
+	return n, nil
+}
+
+// scanUint is like scanInt for unsigned integer types.
+func scanUint(value interface{}, typ string, bits int) (uint64, error) {
+	var n uint64
+	switch x := value.(type) {
+	case int64:
+		if x < 0 {
+			return 0, negativeError(x, typ)
+		}
+		n = uint64(x)
+	case uint64:
+		n = x
+	case float64:
+		if x != math.Trunc(x) {
+			return 0, fmt.Errorf("null: cannot scan %v into %s: not a whole number", x, typ)
+		}
+		if x < 0 {
+			return 0, negativeError(x, typ)
+		}
+		if x >= math.MaxUint64 {
+			return 0, overflowf("null: %v does not fit in %s", x, typ)
+		}
+		n = uint64(x)
+	case string:
+		return parseScanUint(x, typ, bits)
+	case []byte:
+		return parseScanUint(string(x), typ, bits)
+	default:
+		if err := convert.ConvertAssign(&n, value); err != nil {
+			return 0, fmt.Errorf("null: cannot scan type %T into %s: %w", value, typ, err)
+		}
+	}
+	if bits < 64 && n > 1<<bits-1 {
+		return 0, overflowf("null: %d does not fit in %s", n, typ)
+	}
+	return n, nil
+}
+
+// scanFloat converts a driver value for the Scan method of typ, a float
+// type of the given bit size. Besides float64 it accepts integers, which
+// SQLite returns for whole numbers, and text, as string or []byte, which
+// MySQL returns for DECIMAL columns. A finite value too large for the type
+// is an overflow error rather than an infinity.
+func scanFloat(value interface{}, typ string, bits int) (float64, error) {
+	var f float64
+	switch x := value.(type) {
+	case float64:
+		f = x
+	case int64:
+		if bits == 32 {
+			return float64(float32(x)), nil
+		}
+		return float64(x), nil
+	case uint64:
+		if bits == 32 {
+			return float64(float32(x)), nil
+		}
+		return float64(x), nil
+	case string:
+		return parseScanFloat(x, typ, bits)
+	case []byte:
+		return parseScanFloat(string(x), typ, bits)
+	default:
+		if err := convert.ConvertAssign(&f, value); err != nil {
+			return 0, fmt.Errorf("null: cannot scan type %T into %s: %w", value, typ, err)
+		}
+	}
+	if bits == 32 && !math.IsInf(f, 0) && math.IsInf(float64(float32(f)), 0) {
+		return 0, overflowf("null: %v does not fit in %s", f, typ)
+	}
+	return f, nil
+}
+
+func parseScanFloat(s, typ string, bits int) (float64, error) {
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, scanNumError(s, typ, err)
+	}
+	return f, nil
+}
+
+func parseScanInt(s, typ string, bits int) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, bits)
+	if err != nil {
+		return 0, scanNumError(s, typ, err)
+	}
+	return n, nil
+}
+
+func parseScanUint(s, typ string, bits int) (uint64, error) {
+	n, err := parseUint(s, bits, typ)
+	if err != nil {
+		return 0, scanNumError(s, typ, err)
+	}
+	return n, nil
+}
+
+func scanNumError(s, typ string, err error) error {
+	if errors.Is(err, ErrOverflow) {
+		return err
+	}
+	if ne, ok := err.(*strconv.NumError); ok {
+		err = ne.Err
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return overflowf("null: %s does not fit in %s", s, typ)
+	}
+	return fmt.Errorf("null: cannot scan %q into %s: %w", s, typ, err)
+}
+
+// parseUint is strconv.ParseUint for typ, except that "-0" is zero and
+// that other negative integers are reported by negativeError.
+func parseUint(s string, bits int, typ string) (uint64, error) {
+	if len(s) > 1 && s[0] == '-' && strings.Trim(s[1:], "0123456789") == "" {
+		if strings.Trim(s[1:], "0") == "" {
+			return 0, nil
+		}
+		return 0, negativeError(s, typ)
+	}
+	return strconv.ParseUint(s, 10, bits)
+}
+
+// unmarshalUint decodes the JSON number data for typ like parseUint.
+func unmarshalUint(data []byte, typ string) (uint64, error) {
+	if len(data) > 0 && data[0] == '-' {
+		return parseUint(string(data), 64, typ)
+	}
+	var x uint64
+	err := json.Unmarshal(data, &x)
+	return x, err
+}
+
+// negativeError reports a negative value v given to typ, an unsigned type.
+func negativeError(v interface{}, typ string) error {
+	return overflowf("null: cannot store negative value %v in %s", v, strings.TrimPrefix(typ, "null."))
+}
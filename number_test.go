@@ -0,0 +1,452 @@
+package null
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type numberUnmarshaler interface {
+	json.Unmarshaler
+	json.Marshaler
+	Value
+}
+
+func numberTypes() map[string]func() numberUnmarshaler {
+	return map[string]func() numberUnmarshaler{
+		"Int":     func() numberUnmarshaler { return new(Int) },
+		"Int8":    func() numberUnmarshaler { return new(Int8) },
+		"Int16":   func() numberUnmarshaler { return new(Int16) },
+		"Int32":   func() numberUnmarshaler { return new(Int32) },
+		"Int64":   func() numberUnmarshaler { return new(Int64) },
+		"Uint":    func() numberUnmarshaler { return new(Uint) },
+		"Uint8":   func() numberUnmarshaler { return new(Uint8) },
+		"Uint16":  func() numberUnmarshaler { return new(Uint16) },
+		"Uint32":  func() numberUnmarshaler { return new(Uint32) },
+		"Uint64":  func() numberUnmarshaler { return new(Uint64) },
+		"Float32": func() numberUnmarshaler { return new(Float32) },
+		"Float64": func() numberUnmarshaler { return new(Float64) },
+	}
+}
+
+func TestUnmarshalQuotedNumber(t *testing.T) {
+	tests := []struct {
+		in    string
+		out   string
+		valid bool
+		err   bool
+	}{
+		{in: `42`, out: `42`, valid: true},
+		{in: `"42"`, out: `42`, valid: true},
+		{in: `""`, out: `null`},
+		{in: `null`, out: `null`},
+		{in: `"abc"`, err: true},
+		{in: `"null"`, err: true},
+		{in: `"4 2"`, err: true},
+	}
+
+	for name, mk := range numberTypes() {
+		for _, test := range tests {
+			v := mk()
+			err := json.Unmarshal([]byte(test.in), v)
+			if test.err {
+				if err == nil {
+					t.Errorf("%s %s: expected error", name, test.in)
+				} else if !strings.Contains(err.Error(), test.in) {
+					t.Errorf("%s %s: error should name the input: %v", name, test.in, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s %s: unexpected error: %v", name, test.in, err)
+				continue
+			}
+			if v.IsValid() != test.valid || !v.IsSet() {
+				t.Errorf("%s %s: got valid=%t set=%t", name, test.in, v.IsValid(), v.IsSet())
+			}
+			data, err := v.MarshalJSON()
+			maybePanic(err)
+			assertJSONEquals(t, data, test.out, name+" "+test.in)
+		}
+	}
+}
+
+func TestUnmarshalQuotedNumberInStruct(t *testing.T) {
+	var v struct {
+		Count Int64   `json:"count"`
+		Ratio Float64 `json:"ratio"`
+	}
+	err := json.Unmarshal([]byte(`{"count": "-42", "ratio": "1.5e3"}`), &v)
+	maybePanic(err)
+	if !v.Count.Valid || v.Count.Int64 != -42 {
+		t.Errorf("bad count: %v", v.Count)
+	}
+	if !v.Ratio.Valid || v.Ratio.Float64 != 1500 {
+		t.Errorf("bad ratio: %v", v.Ratio)
+	}
+}
+
+// numberSamples pairs valid numbers with the payload encoding/json is
+// given for comparison.
+func numberSamples() []struct {
+	v       interface{}
+	payload interface{}
+} {
+	return []struct {
+		v       interface{}
+		payload interface{}
+	}{
+		{IntFrom(math.MinInt64), int64(math.MinInt64)},
+		{IntFrom(-1), -1},
+		{Int8From(math.MinInt8), int8(math.MinInt8)},
+		{Int8From(math.MaxInt8), int8(math.MaxInt8)},
+		{Int16From(math.MinInt16), int16(math.MinInt16)},
+		{Int32From(math.MinInt32), int32(math.MinInt32)},
+		{Int64From(math.MaxInt64), int64(math.MaxInt64)},
+		{Int64From(math.MinInt64), int64(math.MinInt64)},
+		{UintFrom(math.MaxUint64), uint64(math.MaxUint64)},
+		{Uint8From(math.MaxUint8), uint8(math.MaxUint8)},
+		{Uint16From(math.MaxUint16), uint16(math.MaxUint16)},
+		{Uint32From(math.MaxUint32), uint32(math.MaxUint32)},
+		{Uint64From(math.MaxUint64), uint64(math.MaxUint64)},
+		{Uint64From(0), uint64(0)},
+		{Float64From(0), 0.0},
+		{Float64From(math.Copysign(0, -1)), math.Copysign(0, -1)},
+		{Float64From(0.1), 0.1},
+		{Float64From(-1.5), -1.5},
+		{Float64From(1e-6), 1e-6},
+		{Float64From(1e-7), 1e-7},
+		{Float64From(123456789e-15), 123456789e-15},
+		{Float64From(1e20), 1e20},
+		{Float64From(1e21), 1e21},
+		{Float64From(math.MaxFloat64), math.MaxFloat64},
+		{Float64From(-math.SmallestNonzeroFloat64), -math.SmallestNonzeroFloat64},
+		{Float32From(0.1), float32(0.1)},
+		{Float32From(1e-7), float32(1e-7)},
+		{Float32From(1e21), float32(1e21)},
+		{Float32From(math.MaxFloat32), float32(math.MaxFloat32)},
+		{Float32From(-math.SmallestNonzeroFloat32), float32(-math.SmallestNonzeroFloat32)},
+	}
+}
+
+func TestMarshalNumberLikeEncodingJSON(t *testing.T) {
+	for _, s := range numberSamples() {
+		got, err := json.Marshal(s.v)
+		maybePanic(err)
+		want, err := json.Marshal(s.payload)
+		maybePanic(err)
+		if string(got) != string(want) {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", s.v, got, want)
+		}
+
+		text, err := s.v.(encoding.TextMarshaler).MarshalText()
+		maybePanic(err)
+		appended, err := s.v.(encoding.TextAppender).AppendText([]byte("x="))
+		maybePanic(err)
+		if string(appended) != "x="+string(text) {
+			t.Errorf("%#v.AppendText() = %q, want %q", s.v, appended, "x="+string(text))
+		}
+	}
+
+	for _, null := range []encoding.TextAppender{Int8{}, NewUint64(7, false), NewFloat32(1, false)} {
+		if got, err := null.AppendText([]byte("x")); err != nil || string(got) != "x" {
+			t.Errorf("%#v.AppendText() = %q, %v, want nothing appended", null, got, err)
+		}
+	}
+}
+
+func BenchmarkMarshalNumber(b *testing.B) {
+	values := []json.Marshaler{
+		Int64From(math.MinInt64), Int32From(-123456), Int8From(-100), UintFrom(42),
+		Uint64From(math.MaxUint64), Float64From(-1234.5678), Float32From(0.1), Float64From(1e-7),
+	}
+	for _, v := range values {
+		b.Run(fmt.Sprintf("%T", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := v.MarshalJSON(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAppendNumberText(b *testing.B) {
+	values := []encoding.TextAppender{Int64From(math.MinInt64), Uint64From(math.MaxUint64), Float64From(-1234.5678)}
+	buf := make([]byte, 0, 64)
+	for _, v := range values {
+		b.Run(fmt.Sprintf("%T", v), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var err error
+				if buf, err = v.AppendText(buf[:0]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestScanIntegerDriverValues(t *testing.T) {
+	// One past the largest value of each type.
+	tooBig := map[string]string{
+		"Int": fmt.Sprint(uint64(math.MaxInt) + 1), "Int8": "128", "Int16": "32768", "Int32": "2147483648", "Int64": "9223372036854775808",
+		"Uint": "18446744073709551616", "Uint8": "256", "Uint16": "65536", "Uint32": "4294967296", "Uint64": "18446744073709551616",
+	}
+	for name, limit := range tooBig {
+		newValue := numberTypes()[name]
+		for _, in := range []interface{}{"42", []byte("42"), int64(42), uint64(42), float64(42), 42} {
+			v := newValue()
+			if err := v.(sql.Scanner).Scan(in); err != nil {
+				t.Errorf("%s.Scan(%#v): %v", name, in, err)
+				continue
+			}
+			if data, _ := v.MarshalJSON(); !v.IsValid() || string(data) != "42" {
+				t.Errorf("%s.Scan(%#v) = %s, valid %v, want 42", name, in, data, v.IsValid())
+			}
+		}
+
+		for _, in := range []interface{}{"4.2", []byte(" 42"), "abc", "", float64(4.5), true} {
+			v := newValue()
+			err := v.(sql.Scanner).Scan(in)
+			if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "null."+name) {
+				t.Errorf("%s.Scan(%#v) = %v, want an error naming the type", name, in, err)
+			}
+			if v.IsValid() || !v.IsSet() {
+				t.Errorf("%s should be set but invalid after a failed Scan(%#v)", name, in)
+			}
+		}
+
+		for _, in := range []interface{}{limit, []byte(limit), float64(1e20)} {
+			v := newValue()
+			err := v.(sql.Scanner).Scan(in)
+			if !errors.Is(err, ErrOverflow) || !strings.Contains(err.Error(), "null."+name) {
+				t.Errorf("%s.Scan(%#v) = %v, want an overflow error", name, in, err)
+			}
+		}
+	}
+}
+
+func TestScanFloatDriverValues(t *testing.T) {
+	tests := []struct {
+		in     interface{}
+		want64 float64
+		want32 float32
+	}{
+		{1.5, 1.5, 1.5},
+		{"1.5", 1.5, 1.5},
+		{[]byte("-0.25"), -0.25, -0.25},
+		{[]byte("123.4500"), 123.45, 123.45},
+		{"1.5e3", 1500, 1500},
+		{[]byte("-2E-3"), -0.002, -0.002},
+		{"6.02214076e+23", 6.02214076e23, 6.02214076e23},
+		{[]byte("1e39"), 1e39, 0},
+		{int64(-42), -42, -42},
+		{int64(1<<53 + 1), 1 << 53, 1 << 53},
+		{uint64(math.MaxUint64), math.MaxUint64, math.MaxUint64},
+		{uint64(1<<24 + 1), 1<<24 + 1, 1 << 24},
+		{42, 42, 42},
+	}
+	for _, test := range tests {
+		var f Float64
+		if err := f.Scan(test.in); err != nil || !f.Valid || !f.Set || f.Float64 != test.want64 {
+			t.Errorf("Float64.Scan(%#v) = %#v, %v, want %v", test.in, f, err, test.want64)
+		}
+		if test.want32 == 0 {
+			continue
+		}
+		var g Float32
+		if err := g.Scan(test.in); err != nil || !g.Valid || !g.Set || g.Float32 != test.want32 {
+			t.Errorf("Float32.Scan(%#v) = %#v, %v, want %v", test.in, g, err, test.want32)
+		}
+	}
+
+	for _, in := range []interface{}{"abc", []byte(" 1.5"), "", "1,5", true} {
+		for name, v := range map[string]sql.Scanner{"Float64": new(Float64), "Float32": new(Float32)} {
+			err := v.Scan(in)
+			if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "null."+name) {
+				t.Errorf("%s.Scan(%#v) = %v, want an error naming the type", name, in, err)
+			}
+			if n := v.(interface{ IsValid() bool }); n.IsValid() {
+				t.Errorf("%s should be invalid after a failed Scan(%#v)", name, in)
+			}
+		}
+	}
+
+	for _, in := range []interface{}{"1e39", []byte("-3.5e38"), 1e300} {
+		var f Float32
+		err := f.Scan(in)
+		if !errors.Is(err, ErrOverflow) || !strings.Contains(err.Error(), "null.Float32") || f.Valid || !f.Set {
+			t.Errorf("Float32.Scan(%#v) = %#v, %v, want an overflow error", in, f, err)
+		}
+	}
+	var f Float64
+	if err := f.Scan("1e309"); !errors.Is(err, ErrOverflow) || f.Valid {
+		t.Errorf("Float64.Scan(1e309) = %#v, %v, want an overflow error", f, err)
+	}
+	// Infinities spelled out stay infinities, as strconv.ParseFloat reads them.
+	var g Float32
+	if err := g.Scan("-Inf"); err != nil || !math.IsInf(float64(g.Float32), -1) {
+		t.Errorf("Float32.Scan(-Inf) = %#v, %v", g, err)
+	}
+}
+
+func TestIntegerRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int64
+	}{
+		{"Int8", math.MinInt8, math.MaxInt8},
+		{"Int16", math.MinInt16, math.MaxInt16},
+		{"Int32", math.MinInt32, math.MaxInt32},
+		{"Uint8", 0, math.MaxUint8},
+		{"Uint16", 0, math.MaxUint16},
+		{"Uint32", 0, math.MaxUint32},
+	}
+	for _, test := range tests {
+		newValue := numberTypes()[test.name]
+		for _, n := range []int64{test.lo, test.hi} {
+			v := newValue()
+			err := v.(sql.Scanner).Scan(n)
+			maybePanic(err)
+			if data, _ := v.MarshalJSON(); string(data) != fmt.Sprint(n) {
+				t.Errorf("%s.Scan(%d) = %s", test.name, n, data)
+			}
+			v = newValue()
+			err = v.UnmarshalJSON([]byte(fmt.Sprint(n)))
+			maybePanic(err)
+			if data, _ := v.MarshalJSON(); string(data) != fmt.Sprint(n) {
+				t.Errorf("%s.UnmarshalJSON(%d) = %s", test.name, n, data)
+			}
+		}
+
+		outside := []int64{test.hi + 1}
+		if test.lo < 0 {
+			outside = append(outside, test.lo-1)
+		}
+		for _, n := range outside {
+			v := newValue()
+			err := v.(sql.Scanner).Scan(n)
+			if !errors.Is(err, ErrOverflow) || !strings.Contains(err.Error(), fmt.Sprintf("%d does not fit in null.%s", n, test.name)) {
+				t.Errorf("%s.Scan(%d) = %v, want an overflow error", test.name, n, err)
+			}
+			if v.IsValid() {
+				t.Errorf("%s should be invalid after Scan(%d)", test.name, n)
+			}
+			v = newValue()
+			err = v.UnmarshalJSON([]byte(fmt.Sprint(n)))
+			if !errors.Is(err, ErrOverflow) || !strings.Contains(err.Error(), fmt.Sprintf("%d does not fit in null.%s", n, test.name)) {
+				t.Errorf("%s.UnmarshalJSON(%d) = %v, want an overflow error", test.name, n, err)
+			}
+			if v.IsValid() {
+				t.Errorf("%s should be invalid after UnmarshalJSON(%d)", test.name, n)
+			}
+		}
+	}
+
+	var b Byte
+	if err := b.Scan(int64(math.MaxUint8)); err != nil || b.Byte != math.MaxUint8 {
+		t.Errorf("Byte.Scan(255) = %v, %v", b, err)
+	}
+	for _, n := range []int64{-1, math.MaxUint8 + 1} {
+		var b Byte
+		if err := b.Scan(n); !errors.Is(err, ErrOverflow) || b.Valid {
+			t.Errorf("Byte.Scan(%d) = %v, %v, want an overflow error", n, b, err)
+		}
+	}
+}
+
+func TestUnsignedNegative(t *testing.T) {
+	for _, name := range []string{"Uint", "Uint8", "Uint16", "Uint32", "Uint64"} {
+		newValue := numberTypes()[name]
+		for _, n := range []string{"-1", fmt.Sprint(math.MinInt64), "-18446744073709551616"} {
+			want := fmt.Sprintf("null: cannot store negative value %s in %s", n, name)
+			check := func(from string, v numberUnmarshaler, err error) {
+				t.Helper()
+				if err == nil || err.Error() != want || !errors.Is(err, ErrOverflow) {
+					t.Errorf("%s.%s(%s) = %v, want %q", name, from, n, err, want)
+				}
+				if v.IsValid() {
+					t.Errorf("%s should be invalid after %s(%s)", name, from, n)
+				}
+			}
+
+			v := newValue()
+			check("UnmarshalJSON", v, v.UnmarshalJSON([]byte(n)))
+			v = newValue()
+			check("UnmarshalJSON", v, v.UnmarshalJSON([]byte(`"`+n+`"`)))
+			v = newValue()
+			check("UnmarshalText", v, v.(encoding.TextUnmarshaler).UnmarshalText([]byte(n)))
+			v = newValue()
+			check("Scan", v, v.(sql.Scanner).Scan(n))
+			if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+				v = newValue()
+				check("Scan", v, v.(sql.Scanner).Scan(i))
+			}
+		}
+
+		// Negative zero is zero.
+		for _, decode := range []func(numberUnmarshaler) error{
+			func(v numberUnmarshaler) error { return v.UnmarshalJSON([]byte("-0")) },
+			func(v numberUnmarshaler) error { return v.(encoding.TextUnmarshaler).UnmarshalText([]byte("-0")) },
+			func(v numberUnmarshaler) error { return v.(sql.Scanner).Scan("-0") },
+			func(v numberUnmarshaler) error { return v.(sql.Scanner).Scan(math.Copysign(0, -1)) },
+		} {
+			v := newValue()
+			if err := decode(v); err != nil || !v.IsValid() {
+				t.Errorf("%s from -0 = %v, %v, want a valid 0", name, v, err)
+			}
+		}
+	}
+}
+
+func TestNumberFromString(t *testing.T) {
+	parsers := map[string]func(string) (interface{}, error){
+		"Int":     func(s string) (interface{}, error) { return IntFromString(s) },
+		"Int8":    func(s string) (interface{}, error) { return Int8FromString(s) },
+		"Int16":   func(s string) (interface{}, error) { return Int16FromString(s) },
+		"Int32":   func(s string) (interface{}, error) { return Int32FromString(s) },
+		"Int64":   func(s string) (interface{}, error) { return Int64FromString(s) },
+		"Uint":    func(s string) (interface{}, error) { return UintFromString(s) },
+		"Uint8":   func(s string) (interface{}, error) { return Uint8FromString(s) },
+		"Uint16":  func(s string) (interface{}, error) { return Uint16FromString(s) },
+		"Uint32":  func(s string) (interface{}, error) { return Uint32FromString(s) },
+		"Uint64":  func(s string) (interface{}, error) { return Uint64FromString(s) },
+		"Float32": func(s string) (interface{}, error) { return Float32FromString(s) },
+		"Float64": func(s string) (interface{}, error) { return Float64FromString(s) },
+	}
+	for name, parse := range parsers {
+		for _, in := range []string{"", "42", "-7", "1.5", "300", "99999999999999999999", "abc", " 1"} {
+			got, err := parse(in)
+			want := numberTypes()[name]()
+			wantErr := want.(encoding.TextUnmarshaler).UnmarshalText([]byte(in))
+			if (err == nil) != (wantErr == nil) {
+				t.Errorf("%sFromString(%q) error = %v, UnmarshalText gives %v", name, in, err, wantErr)
+			}
+			if w := reflect.ValueOf(want).Elem().Interface(); got != w {
+				t.Errorf("%sFromString(%q) = %#v, want %#v", name, in, got, w)
+			}
+		}
+	}
+
+	if i := MustInt64FromString("12"); !i.ExactEqual(Int64From(12)) {
+		t.Errorf("MustInt64FromString(\"12\") = %#v", i)
+	}
+	if f := MustFloat64FromString(""); !f.ExactEqual(NewFloat64(0, false)) {
+		t.Errorf("MustFloat64FromString(\"\") = %#v", f)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustUint8FromString(\"256\") should panic")
+		}
+	}()
+	MustUint8FromString("256")
+}
@@ -0,0 +1,1476 @@
+package null
+
+import (
+	"fmt"
+	"math"
+)
+
+// intSize is the size of int and uint in bits.
+const intSize = 32 << (^uint(0) >> 63)
+
+// The numeric conversion methods convert between Int, Int8, Int16, Int32,
+// Int64, Uint, Uint8, Uint16, Uint32, Uint64, Float32 and Float64, keeping
+// Valid and Set. Null and unset values always convert, to a zero payload
+// whatever theirs was. A valid value that does not fit in the target type
+// is an error wrapping ErrOverflow, and the result is the target's zero
+// value: negative values do not fit in unsigned types, and uint64 values
+// above math.MaxInt64 do not fit in Int64.
+//
+// Floats are truncated toward zero when converted to integers, so 2.9
+// becomes 2 and -2.9 becomes -2, and NaN and the infinities are errors.
+// Integers converted to floats are rounded to the nearest representable
+// value, as are Float64 values converted to Float32; a finite Float64
+// beyond the range of float32 is an error, while NaN and the infinities
+// are kept.
+
+func checkSigned(v, lo, hi int64, to string) error {
+	if v < lo || v > hi {
+		return fmt.Errorf("%w: %d does not fit in null.%s", ErrOverflow, v, to)
+	}
+	return nil
+}
+
+func checkUnsigned(v, hi uint64, to string) error {
+	if v > hi {
+		return fmt.Errorf("%w: %d does not fit in null.%s", ErrOverflow, v, to)
+	}
+	return nil
+}
+
+func checkSignedToUnsigned(v int64, hi uint64, to string) error {
+	if v < 0 || uint64(v) > hi {
+		return fmt.Errorf("%w: %d does not fit in null.%s", ErrOverflow, v, to)
+	}
+	return nil
+}
+
+func checkUnsignedToSigned(v uint64, hi int64, to string) error {
+	if v > uint64(hi) {
+		return fmt.Errorf("%w: %d does not fit in null.%s", ErrOverflow, v, to)
+	}
+	return nil
+}
+
+// checkFloat checks that v truncated toward zero lies in [lo, limit).
+// Both bounds are powers of two, so they are exact as float64.
+func checkFloat(v, lo, limit float64, to string) error {
+	if t := math.Trunc(v); math.IsNaN(t) || t < lo || t >= limit {
+		return fmt.Errorf("%w: %v does not fit in null.%s", ErrOverflow, v, to)
+	}
+	return nil
+}
+
+func checkFloat32(v float64) error {
+	if !math.IsInf(v, 0) && math.IsInf(float64(float32(v)), 0) {
+		return fmt.Errorf("%w: %v does not fit in null.Float32", ErrOverflow, v)
+	}
+	return nil
+}
+
+// Int8 converts i to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Int8() (Int8, error) {
+	if !i.Valid {
+		return Int8{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int), math.MinInt8, math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Int16 converts i to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Int16() (Int16, error) {
+	if !i.Valid {
+		return Int16{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int), math.MinInt16, math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Int32 converts i to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Int32() (Int32, error) {
+	if !i.Valid {
+		return Int32{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int), math.MinInt32, math.MaxInt32, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Int64 converts i to an Int64, which always holds the value.
+func (i Int) Int64() (Int64, error) {
+	if !i.Valid {
+		return Int64{Set: i.Set}, nil
+	}
+	return Int64{Int64: int64(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Uint converts i to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Uint() (Uint, error) {
+	if !i.Valid {
+		return Uint{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int), math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Uint8 converts i to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Uint8() (Uint8, error) {
+	if !i.Valid {
+		return Uint8{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Uint16 converts i to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Uint16() (Uint16, error) {
+	if !i.Valid {
+		return Uint16{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Uint32 converts i to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Uint32() (Uint32, error) {
+	if !i.Valid {
+		return Uint32{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int), math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Uint64 converts i to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int) Uint64() (Uint64, error) {
+	if !i.Valid {
+		return Uint64{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int), math.MaxUint64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Float32 converts i to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (i Int) Float32() (Float32, error) {
+	if !i.Valid {
+		return Float32{Set: i.Set}, nil
+	}
+	return Float32{Float32: float32(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Float64 converts i to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (i Int) Float64() (Float64, error) {
+	if !i.Valid {
+		return Float64{Set: i.Set}, nil
+	}
+	return Float64{Float64: float64(i.Int), Valid: true, Set: i.Set}, nil
+}
+
+// Int converts i to an Int, which always holds the value.
+func (i Int8) Int() (Int, error) {
+	if !i.Valid {
+		return Int{Set: i.Set}, nil
+	}
+	return Int{Int: int(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Int16 converts i to an Int16, which always holds the value.
+func (i Int8) Int16() (Int16, error) {
+	if !i.Valid {
+		return Int16{Set: i.Set}, nil
+	}
+	return Int16{Int16: int16(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Int32 converts i to an Int32, which always holds the value.
+func (i Int8) Int32() (Int32, error) {
+	if !i.Valid {
+		return Int32{Set: i.Set}, nil
+	}
+	return Int32{Int32: int32(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Int64 converts i to an Int64, which always holds the value.
+func (i Int8) Int64() (Int64, error) {
+	if !i.Valid {
+		return Int64{Set: i.Set}, nil
+	}
+	return Int64{Int64: int64(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Uint converts i to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int8) Uint() (Uint, error) {
+	if !i.Valid {
+		return Uint{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int8), math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Uint8 converts i to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int8) Uint8() (Uint8, error) {
+	if !i.Valid {
+		return Uint8{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int8), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Uint16 converts i to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int8) Uint16() (Uint16, error) {
+	if !i.Valid {
+		return Uint16{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int8), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Uint32 converts i to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int8) Uint32() (Uint32, error) {
+	if !i.Valid {
+		return Uint32{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int8), math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Uint64 converts i to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int8) Uint64() (Uint64, error) {
+	if !i.Valid {
+		return Uint64{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int8), math.MaxUint64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Float32 converts i to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (i Int8) Float32() (Float32, error) {
+	if !i.Valid {
+		return Float32{Set: i.Set}, nil
+	}
+	return Float32{Float32: float32(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Float64 converts i to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (i Int8) Float64() (Float64, error) {
+	if !i.Valid {
+		return Float64{Set: i.Set}, nil
+	}
+	return Float64{Float64: float64(i.Int8), Valid: true, Set: i.Set}, nil
+}
+
+// Int converts i to an Int, which always holds the value.
+func (i Int16) Int() (Int, error) {
+	if !i.Valid {
+		return Int{Set: i.Set}, nil
+	}
+	return Int{Int: int(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Int8 converts i to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Int8() (Int8, error) {
+	if !i.Valid {
+		return Int8{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int16), math.MinInt8, math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Int32 converts i to an Int32, which always holds the value.
+func (i Int16) Int32() (Int32, error) {
+	if !i.Valid {
+		return Int32{Set: i.Set}, nil
+	}
+	return Int32{Int32: int32(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Int64 converts i to an Int64, which always holds the value.
+func (i Int16) Int64() (Int64, error) {
+	if !i.Valid {
+		return Int64{Set: i.Set}, nil
+	}
+	return Int64{Int64: int64(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Uint converts i to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Uint() (Uint, error) {
+	if !i.Valid {
+		return Uint{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int16), math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Uint8 converts i to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Uint8() (Uint8, error) {
+	if !i.Valid {
+		return Uint8{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int16), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Uint16 converts i to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Uint16() (Uint16, error) {
+	if !i.Valid {
+		return Uint16{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int16), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Uint32 converts i to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Uint32() (Uint32, error) {
+	if !i.Valid {
+		return Uint32{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int16), math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Uint64 converts i to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int16) Uint64() (Uint64, error) {
+	if !i.Valid {
+		return Uint64{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int16), math.MaxUint64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Float32 converts i to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (i Int16) Float32() (Float32, error) {
+	if !i.Valid {
+		return Float32{Set: i.Set}, nil
+	}
+	return Float32{Float32: float32(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Float64 converts i to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (i Int16) Float64() (Float64, error) {
+	if !i.Valid {
+		return Float64{Set: i.Set}, nil
+	}
+	return Float64{Float64: float64(i.Int16), Valid: true, Set: i.Set}, nil
+}
+
+// Int converts i to an Int, which always holds the value.
+func (i Int32) Int() (Int, error) {
+	if !i.Valid {
+		return Int{Set: i.Set}, nil
+	}
+	return Int{Int: int(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Int8 converts i to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Int8() (Int8, error) {
+	if !i.Valid {
+		return Int8{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int32), math.MinInt8, math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Int16 converts i to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Int16() (Int16, error) {
+	if !i.Valid {
+		return Int16{Set: i.Set}, nil
+	}
+	if err := checkSigned(int64(i.Int32), math.MinInt16, math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Int64 converts i to an Int64, which always holds the value.
+func (i Int32) Int64() (Int64, error) {
+	if !i.Valid {
+		return Int64{Set: i.Set}, nil
+	}
+	return Int64{Int64: int64(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Uint converts i to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Uint() (Uint, error) {
+	if !i.Valid {
+		return Uint{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int32), math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Uint8 converts i to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Uint8() (Uint8, error) {
+	if !i.Valid {
+		return Uint8{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int32), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Uint16 converts i to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Uint16() (Uint16, error) {
+	if !i.Valid {
+		return Uint16{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int32), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Uint32 converts i to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Uint32() (Uint32, error) {
+	if !i.Valid {
+		return Uint32{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int32), math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Uint64 converts i to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int32) Uint64() (Uint64, error) {
+	if !i.Valid {
+		return Uint64{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(int64(i.Int32), math.MaxUint64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Float32 converts i to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (i Int32) Float32() (Float32, error) {
+	if !i.Valid {
+		return Float32{Set: i.Set}, nil
+	}
+	return Float32{Float32: float32(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Float64 converts i to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (i Int32) Float64() (Float64, error) {
+	if !i.Valid {
+		return Float64{Set: i.Set}, nil
+	}
+	return Float64{Float64: float64(i.Int32), Valid: true, Set: i.Set}, nil
+}
+
+// Int converts i to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Int() (Int, error) {
+	if !i.Valid {
+		return Int{Set: i.Set}, nil
+	}
+	if err := checkSigned(i.Int64, math.MinInt, math.MaxInt, "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Int8 converts i to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Int8() (Int8, error) {
+	if !i.Valid {
+		return Int8{Set: i.Set}, nil
+	}
+	if err := checkSigned(i.Int64, math.MinInt8, math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Int16 converts i to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Int16() (Int16, error) {
+	if !i.Valid {
+		return Int16{Set: i.Set}, nil
+	}
+	if err := checkSigned(i.Int64, math.MinInt16, math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Int32 converts i to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Int32() (Int32, error) {
+	if !i.Valid {
+		return Int32{Set: i.Set}, nil
+	}
+	if err := checkSigned(i.Int64, math.MinInt32, math.MaxInt32, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Uint converts i to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Uint() (Uint, error) {
+	if !i.Valid {
+		return Uint{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(i.Int64, math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Uint8 converts i to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Uint8() (Uint8, error) {
+	if !i.Valid {
+		return Uint8{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(i.Int64, math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Uint16 converts i to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Uint16() (Uint16, error) {
+	if !i.Valid {
+		return Uint16{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(i.Int64, math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Uint32 converts i to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Uint32() (Uint32, error) {
+	if !i.Valid {
+		return Uint32{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(i.Int64, math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Uint64 converts i to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (i Int64) Uint64() (Uint64, error) {
+	if !i.Valid {
+		return Uint64{Set: i.Set}, nil
+	}
+	if err := checkSignedToUnsigned(i.Int64, math.MaxUint64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Float32 converts i to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (i Int64) Float32() (Float32, error) {
+	if !i.Valid {
+		return Float32{Set: i.Set}, nil
+	}
+	return Float32{Float32: float32(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Float64 converts i to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (i Int64) Float64() (Float64, error) {
+	if !i.Valid {
+		return Float64{Set: i.Set}, nil
+	}
+	return Float64{Float64: float64(i.Int64), Valid: true, Set: i.Set}, nil
+}
+
+// Int converts u to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Int() (Int, error) {
+	if !u.Valid {
+		return Int{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint), math.MaxInt, "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Int8 converts u to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Int8() (Int8, error) {
+	if !u.Valid {
+		return Int8{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint), math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Int16 converts u to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Int16() (Int16, error) {
+	if !u.Valid {
+		return Int16{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint), math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Int32 converts u to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Int32() (Int32, error) {
+	if !u.Valid {
+		return Int32{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint), math.MaxInt32, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Int64 converts u to an Int64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Int64() (Int64, error) {
+	if !u.Valid {
+		return Int64{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint), math.MaxInt64, "Int64"); err != nil {
+		return Int64{}, err
+	}
+	return Int64{Int64: int64(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Uint8 converts u to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Uint8() (Uint8, error) {
+	if !u.Valid {
+		return Uint8{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Uint16 converts u to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Uint16() (Uint16, error) {
+	if !u.Valid {
+		return Uint16{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Uint32 converts u to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint) Uint32() (Uint32, error) {
+	if !u.Valid {
+		return Uint32{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint), math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Uint64 converts u to a Uint64, which always holds the value.
+func (u Uint) Uint64() (Uint64, error) {
+	if !u.Valid {
+		return Uint64{Set: u.Set}, nil
+	}
+	return Uint64{Uint64: uint64(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Float32 converts u to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint) Float32() (Float32, error) {
+	if !u.Valid {
+		return Float32{Set: u.Set}, nil
+	}
+	return Float32{Float32: float32(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Float64 converts u to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint) Float64() (Float64, error) {
+	if !u.Valid {
+		return Float64{Set: u.Set}, nil
+	}
+	return Float64{Float64: float64(u.Uint), Valid: true, Set: u.Set}, nil
+}
+
+// Int converts u to an Int, which always holds the value.
+func (u Uint8) Int() (Int, error) {
+	if !u.Valid {
+		return Int{Set: u.Set}, nil
+	}
+	return Int{Int: int(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Int8 converts u to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint8) Int8() (Int8, error) {
+	if !u.Valid {
+		return Int8{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint8), math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Int16 converts u to an Int16, which always holds the value.
+func (u Uint8) Int16() (Int16, error) {
+	if !u.Valid {
+		return Int16{Set: u.Set}, nil
+	}
+	return Int16{Int16: int16(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Int32 converts u to an Int32, which always holds the value.
+func (u Uint8) Int32() (Int32, error) {
+	if !u.Valid {
+		return Int32{Set: u.Set}, nil
+	}
+	return Int32{Int32: int32(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Int64 converts u to an Int64, which always holds the value.
+func (u Uint8) Int64() (Int64, error) {
+	if !u.Valid {
+		return Int64{Set: u.Set}, nil
+	}
+	return Int64{Int64: int64(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Uint converts u to a Uint, which always holds the value.
+func (u Uint8) Uint() (Uint, error) {
+	if !u.Valid {
+		return Uint{Set: u.Set}, nil
+	}
+	return Uint{Uint: uint(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Uint16 converts u to a Uint16, which always holds the value.
+func (u Uint8) Uint16() (Uint16, error) {
+	if !u.Valid {
+		return Uint16{Set: u.Set}, nil
+	}
+	return Uint16{Uint16: uint16(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Uint32 converts u to a Uint32, which always holds the value.
+func (u Uint8) Uint32() (Uint32, error) {
+	if !u.Valid {
+		return Uint32{Set: u.Set}, nil
+	}
+	return Uint32{Uint32: uint32(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Uint64 converts u to a Uint64, which always holds the value.
+func (u Uint8) Uint64() (Uint64, error) {
+	if !u.Valid {
+		return Uint64{Set: u.Set}, nil
+	}
+	return Uint64{Uint64: uint64(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Float32 converts u to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint8) Float32() (Float32, error) {
+	if !u.Valid {
+		return Float32{Set: u.Set}, nil
+	}
+	return Float32{Float32: float32(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Float64 converts u to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint8) Float64() (Float64, error) {
+	if !u.Valid {
+		return Float64{Set: u.Set}, nil
+	}
+	return Float64{Float64: float64(u.Uint8), Valid: true, Set: u.Set}, nil
+}
+
+// Int converts u to an Int, which always holds the value.
+func (u Uint16) Int() (Int, error) {
+	if !u.Valid {
+		return Int{Set: u.Set}, nil
+	}
+	return Int{Int: int(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Int8 converts u to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint16) Int8() (Int8, error) {
+	if !u.Valid {
+		return Int8{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint16), math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Int16 converts u to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint16) Int16() (Int16, error) {
+	if !u.Valid {
+		return Int16{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint16), math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Int32 converts u to an Int32, which always holds the value.
+func (u Uint16) Int32() (Int32, error) {
+	if !u.Valid {
+		return Int32{Set: u.Set}, nil
+	}
+	return Int32{Int32: int32(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Int64 converts u to an Int64, which always holds the value.
+func (u Uint16) Int64() (Int64, error) {
+	if !u.Valid {
+		return Int64{Set: u.Set}, nil
+	}
+	return Int64{Int64: int64(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Uint converts u to a Uint, which always holds the value.
+func (u Uint16) Uint() (Uint, error) {
+	if !u.Valid {
+		return Uint{Set: u.Set}, nil
+	}
+	return Uint{Uint: uint(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Uint8 converts u to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint16) Uint8() (Uint8, error) {
+	if !u.Valid {
+		return Uint8{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint16), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Uint32 converts u to a Uint32, which always holds the value.
+func (u Uint16) Uint32() (Uint32, error) {
+	if !u.Valid {
+		return Uint32{Set: u.Set}, nil
+	}
+	return Uint32{Uint32: uint32(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Uint64 converts u to a Uint64, which always holds the value.
+func (u Uint16) Uint64() (Uint64, error) {
+	if !u.Valid {
+		return Uint64{Set: u.Set}, nil
+	}
+	return Uint64{Uint64: uint64(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Float32 converts u to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint16) Float32() (Float32, error) {
+	if !u.Valid {
+		return Float32{Set: u.Set}, nil
+	}
+	return Float32{Float32: float32(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Float64 converts u to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint16) Float64() (Float64, error) {
+	if !u.Valid {
+		return Float64{Set: u.Set}, nil
+	}
+	return Float64{Float64: float64(u.Uint16), Valid: true, Set: u.Set}, nil
+}
+
+// Int converts u to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Int() (Int, error) {
+	if !u.Valid {
+		return Int{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint32), math.MaxInt, "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Int8 converts u to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Int8() (Int8, error) {
+	if !u.Valid {
+		return Int8{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint32), math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Int16 converts u to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Int16() (Int16, error) {
+	if !u.Valid {
+		return Int16{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint32), math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Int32 converts u to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Int32() (Int32, error) {
+	if !u.Valid {
+		return Int32{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(uint64(u.Uint32), math.MaxInt32, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Int64 converts u to an Int64, which always holds the value.
+func (u Uint32) Int64() (Int64, error) {
+	if !u.Valid {
+		return Int64{Set: u.Set}, nil
+	}
+	return Int64{Int64: int64(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Uint converts u to a Uint, which always holds the value.
+func (u Uint32) Uint() (Uint, error) {
+	if !u.Valid {
+		return Uint{Set: u.Set}, nil
+	}
+	return Uint{Uint: uint(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Uint8 converts u to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Uint8() (Uint8, error) {
+	if !u.Valid {
+		return Uint8{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint32), math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Uint16 converts u to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint32) Uint16() (Uint16, error) {
+	if !u.Valid {
+		return Uint16{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(uint64(u.Uint32), math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Uint64 converts u to a Uint64, which always holds the value.
+func (u Uint32) Uint64() (Uint64, error) {
+	if !u.Valid {
+		return Uint64{Set: u.Set}, nil
+	}
+	return Uint64{Uint64: uint64(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Float32 converts u to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint32) Float32() (Float32, error) {
+	if !u.Valid {
+		return Float32{Set: u.Set}, nil
+	}
+	return Float32{Float32: float32(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Float64 converts u to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint32) Float64() (Float64, error) {
+	if !u.Valid {
+		return Float64{Set: u.Set}, nil
+	}
+	return Float64{Float64: float64(u.Uint32), Valid: true, Set: u.Set}, nil
+}
+
+// Int converts u to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Int() (Int, error) {
+	if !u.Valid {
+		return Int{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(u.Uint64, math.MaxInt, "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Int8 converts u to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Int8() (Int8, error) {
+	if !u.Valid {
+		return Int8{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(u.Uint64, math.MaxInt8, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Int16 converts u to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Int16() (Int16, error) {
+	if !u.Valid {
+		return Int16{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(u.Uint64, math.MaxInt16, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Int32 converts u to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Int32() (Int32, error) {
+	if !u.Valid {
+		return Int32{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(u.Uint64, math.MaxInt32, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Int64 converts u to an Int64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Int64() (Int64, error) {
+	if !u.Valid {
+		return Int64{Set: u.Set}, nil
+	}
+	if err := checkUnsignedToSigned(u.Uint64, math.MaxInt64, "Int64"); err != nil {
+		return Int64{}, err
+	}
+	return Int64{Int64: int64(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Uint converts u to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Uint() (Uint, error) {
+	if !u.Valid {
+		return Uint{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(u.Uint64, math.MaxUint, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Uint8 converts u to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Uint8() (Uint8, error) {
+	if !u.Valid {
+		return Uint8{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(u.Uint64, math.MaxUint8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Uint16 converts u to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Uint16() (Uint16, error) {
+	if !u.Valid {
+		return Uint16{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(u.Uint64, math.MaxUint16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Uint32 converts u to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (u Uint64) Uint32() (Uint32, error) {
+	if !u.Valid {
+		return Uint32{Set: u.Set}, nil
+	}
+	if err := checkUnsigned(u.Uint64, math.MaxUint32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Float32 converts u to a Float32, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint64) Float32() (Float32, error) {
+	if !u.Valid {
+		return Float32{Set: u.Set}, nil
+	}
+	return Float32{Float32: float32(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Float64 converts u to a Float64, rounding to the nearest float if needed.
+// It never fails.
+func (u Uint64) Float64() (Float64, error) {
+	if !u.Valid {
+		return Float64{Set: u.Set}, nil
+	}
+	return Float64{Float64: float64(u.Uint64), Valid: true, Set: u.Set}, nil
+}
+
+// Int converts f to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Int() (Int, error) {
+	if !f.Valid {
+		return Int{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), -(1 << (intSize - 1)), 1<<(intSize-1), "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Int8 converts f to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Int8() (Int8, error) {
+	if !f.Valid {
+		return Int8{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), -(1 << 7), 1<<7, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Int16 converts f to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Int16() (Int16, error) {
+	if !f.Valid {
+		return Int16{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), -(1 << 15), 1<<15, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Int32 converts f to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Int32() (Int32, error) {
+	if !f.Valid {
+		return Int32{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), -(1 << 31), 1<<31, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Int64 converts f to an Int64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Int64() (Int64, error) {
+	if !f.Valid {
+		return Int64{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), -(1 << 63), 1<<63, "Int64"); err != nil {
+		return Int64{}, err
+	}
+	return Int64{Int64: int64(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Uint converts f to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Uint() (Uint, error) {
+	if !f.Valid {
+		return Uint{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), 0, 1<<intSize, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Uint8 converts f to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Uint8() (Uint8, error) {
+	if !f.Valid {
+		return Uint8{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), 0, 1<<8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Uint16 converts f to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Uint16() (Uint16, error) {
+	if !f.Valid {
+		return Uint16{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), 0, 1<<16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Uint32 converts f to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Uint32() (Uint32, error) {
+	if !f.Valid {
+		return Uint32{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), 0, 1<<32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Uint64 converts f to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float32) Uint64() (Uint64, error) {
+	if !f.Valid {
+		return Uint64{Set: f.Set}, nil
+	}
+	if err := checkFloat(float64(f.Float32), 0, 1<<64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Float64 converts f to a Float64, which always holds the value.
+func (f Float32) Float64() (Float64, error) {
+	if !f.Valid {
+		return Float64{Set: f.Set}, nil
+	}
+	return Float64{Float64: float64(f.Float32), Valid: true, Set: f.Set}, nil
+}
+
+// Int converts f to an Int, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Int() (Int, error) {
+	if !f.Valid {
+		return Int{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, -(1 << (intSize - 1)), 1<<(intSize-1), "Int"); err != nil {
+		return Int{}, err
+	}
+	return Int{Int: int(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Int8 converts f to an Int8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Int8() (Int8, error) {
+	if !f.Valid {
+		return Int8{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, -(1 << 7), 1<<7, "Int8"); err != nil {
+		return Int8{}, err
+	}
+	return Int8{Int8: int8(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Int16 converts f to an Int16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Int16() (Int16, error) {
+	if !f.Valid {
+		return Int16{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, -(1 << 15), 1<<15, "Int16"); err != nil {
+		return Int16{}, err
+	}
+	return Int16{Int16: int16(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Int32 converts f to an Int32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Int32() (Int32, error) {
+	if !f.Valid {
+		return Int32{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, -(1 << 31), 1<<31, "Int32"); err != nil {
+		return Int32{}, err
+	}
+	return Int32{Int32: int32(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Int64 converts f to an Int64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Int64() (Int64, error) {
+	if !f.Valid {
+		return Int64{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, -(1 << 63), 1<<63, "Int64"); err != nil {
+		return Int64{}, err
+	}
+	return Int64{Int64: int64(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Uint converts f to a Uint, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Uint() (Uint, error) {
+	if !f.Valid {
+		return Uint{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, 0, 1<<intSize, "Uint"); err != nil {
+		return Uint{}, err
+	}
+	return Uint{Uint: uint(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Uint8 converts f to a Uint8, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Uint8() (Uint8, error) {
+	if !f.Valid {
+		return Uint8{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, 0, 1<<8, "Uint8"); err != nil {
+		return Uint8{}, err
+	}
+	return Uint8{Uint8: uint8(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Uint16 converts f to a Uint16, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Uint16() (Uint16, error) {
+	if !f.Valid {
+		return Uint16{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, 0, 1<<16, "Uint16"); err != nil {
+		return Uint16{}, err
+	}
+	return Uint16{Uint16: uint16(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Uint32 converts f to a Uint32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Uint32() (Uint32, error) {
+	if !f.Valid {
+		return Uint32{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, 0, 1<<32, "Uint32"); err != nil {
+		return Uint32{}, err
+	}
+	return Uint32{Uint32: uint32(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Uint64 converts f to a Uint64, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Uint64() (Uint64, error) {
+	if !f.Valid {
+		return Uint64{Set: f.Set}, nil
+	}
+	if err := checkFloat(f.Float64, 0, 1<<64, "Uint64"); err != nil {
+		return Uint64{}, err
+	}
+	return Uint64{Uint64: uint64(f.Float64), Valid: true, Set: f.Set}, nil
+}
+
+// Float32 converts f to a Float32, or returns an error wrapping ErrOverflow if
+// the value does not fit.
+func (f Float64) Float32() (Float32, error) {
+	if !f.Valid {
+		return Float32{Set: f.Set}, nil
+	}
+	if err := checkFloat32(f.Float64); err != nil {
+		return Float32{}, err
+	}
+	return Float32{Float32: float32(f.Float64), Valid: true, Set: f.Set}, nil
+}
@@ -0,0 +1,134 @@
+package null
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var numericTypes = []interface{}{
+	Int{}, Int8{}, Int16{}, Int32{}, Int64{},
+	Uint{}, Uint8{}, Uint16{}, Uint32{}, Uint64{},
+	Float32{}, Float64{},
+}
+
+func TestNumericConversionsKeepNull(t *testing.T) {
+	for _, from := range numericTypes {
+		ft := reflect.TypeOf(from)
+		for _, to := range numericTypes {
+			tt := reflect.TypeOf(to)
+			if ft == tt {
+				continue
+			}
+			for _, states := range [][2]bool{{false, false}, {false, true}} {
+				in := reflect.New(ft).Elem()
+				// A null payload is never checked.
+				in.Field(0).Set(reflect.ValueOf(maxOf(ft)).Convert(ft.Field(0).Type))
+				in.FieldByName("Set").SetBool(states[1])
+				out := in.MethodByName(tt.Name()).Call(nil)
+				if err := out[1].Interface(); err != nil {
+					t.Errorf("%s.%s() of a null = %v", ft.Name(), tt.Name(), err)
+					continue
+				}
+				got := out[0]
+				if got.FieldByName("Valid").Bool() || got.FieldByName("Set").Bool() != states[1] {
+					t.Errorf("%s.%s() = %#v, want Set %v", ft.Name(), tt.Name(), got.Interface(), states[1])
+				}
+			}
+		}
+	}
+}
+
+// maxOf returns the largest value of the payload of t.
+func maxOf(t reflect.Type) interface{} {
+	switch t.Field(0).Type.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.Inf(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint64(math.MaxUint64)
+	}
+	return int64(-1)
+}
+
+func TestNumericConversions(t *testing.T) {
+	type conv = func() (interface{}, error)
+	wrap := func(f interface{}) conv {
+		return func() (interface{}, error) {
+			out := reflect.ValueOf(f).Call(nil)
+			err, _ := out[1].Interface().(error)
+			return out[0].Interface(), err
+		}
+	}
+	tests := []struct {
+		conv conv
+		want interface{}
+	}{
+		{wrap(Int64From(-128).Int8), Int8From(-128)},
+		{wrap(Int64From(math.MaxInt32).Int32), Int32From(math.MaxInt32)},
+		{wrap(Int64From(math.MinInt64).Int), IntFrom(math.MinInt64)},
+		{wrap(Int8From(-1).Int64), Int64From(-1)},
+		{wrap(IntFrom(65535).Uint16), Uint16From(65535)},
+		{wrap(Int64From(math.MaxInt64).Uint64), Uint64From(math.MaxInt64)},
+		{wrap(Uint64From(math.MaxInt64).Int64), Int64From(math.MaxInt64)},
+		{wrap(Uint64From(math.MaxUint64).Uint), UintFrom(math.MaxUint64)},
+		{wrap(Uint8From(255).Int8), nil},
+		{wrap(Uint32From(math.MaxUint32).Int64), Int64From(math.MaxUint32)},
+		{wrap(Int64From(1<<53 + 1).Float64), Float64From(1 << 53)},
+		{wrap(Uint64From(math.MaxUint64).Float32), Float32From(1 << 64)},
+		{wrap(Float64From(2.9).Int), IntFrom(2)},
+		{wrap(Float64From(-2.9).Int8), Int8From(-2)},
+		{wrap(Float64From(-0.5).Uint), UintFrom(0)},
+		{wrap(Float64From(-128.9).Int8), Int8From(-128)},
+		{wrap(Float64From(255.9).Uint8), Uint8From(255)},
+		{wrap(Float64From(math.MaxInt64).Int64), nil},
+		{wrap(Float64From(1 << 62).Int64), Int64From(1 << 62)},
+		{wrap(Float64From(math.Nextafter(1<<64, 0)).Uint64), Uint64From(1<<64 - 2048)},
+		{wrap(Float32From(1.5).Float64), Float64From(1.5)},
+		{wrap(Float64From(0.1).Float32), Float32From(0.1)},
+		{wrap(Float64From(math.Inf(-1)).Float32), Float32From(float32(math.Inf(-1)))},
+		{wrap(NewFloat64(math.NaN(), false).Int), NewInt(0, false)},
+
+		{wrap(Int64From(128).Int8), nil},
+		{wrap(Int64From(-129).Int8), nil},
+		{wrap(Int64From(math.MinInt64).Int32), nil},
+		{wrap(Int8From(-1).Uint64), nil},
+		{wrap(IntFrom(-1).Uint), nil},
+		{wrap(IntFrom(256).Uint8), nil},
+		{wrap(Uint64From(math.MaxInt64 + 1).Int64), nil},
+		{wrap(Uint64From(math.MaxUint64).Int), nil},
+		{wrap(Uint16From(65535).Int16), nil},
+		{wrap(UintFrom(1 << 32).Uint32), nil},
+		{wrap(Float64From(-1).Uint), nil},
+		{wrap(Float64From(256).Uint8), nil},
+		{wrap(Float64From(-129).Int8), nil},
+		{wrap(Float64From(1 << 63).Int64), nil},
+		{wrap(Float64From(1 << 64).Uint64), nil},
+		{wrap(Float64From(math.NaN()).Int32), nil},
+		{wrap(Float32From(float32(math.Inf(1))).Int64), nil},
+		{wrap(Float64From(math.MaxFloat64).Float32), nil},
+	}
+	for i, test := range tests {
+		got, err := test.conv()
+		if test.want == nil {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("%d: conversion = %#v, %v, want ErrOverflow", i, got, err)
+			}
+			if reflect.ValueOf(got).FieldByName("Set").Bool() {
+				t.Errorf("%d: failed conversion = %#v, want the zero value", i, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d: conversion failed: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d: conversion = %#v, want %#v", i, got, test.want)
+		}
+	}
+
+	// NaN is kept by float conversions.
+	if f, err := Float64From(math.NaN()).Float32(); err != nil || !math.IsNaN(float64(f.Float32)) || !f.Valid {
+		t.Errorf("Float64.Float32() of NaN = %#v, %v", f, err)
+	}
+}
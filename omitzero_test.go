@@ -0,0 +1,59 @@
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOmitZero(t *testing.T) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		structType := reflect.StructOf([]reflect.StructField{{
+			Name: "V",
+			Type: typ,
+			Tag:  `json:"v,omitzero"`,
+		}})
+		marshal := func(v interface{}) string {
+			s := reflect.New(structType).Elem()
+			s.Field(0).Set(reflect.ValueOf(v))
+			data, err := json.Marshal(s.Interface())
+			maybePanic(err)
+			return string(data)
+		}
+
+		if got := marshal(states[0]); got != "{}" {
+			t.Errorf("unset %s with omitzero = %s, want {}", typ, got)
+		}
+		if got := marshal(states[1]); got != `{"v":null}` {
+			t.Errorf("explicit null %s with omitzero = %s, want {\"v\":null}", typ, got)
+		}
+		if got := marshal(states[2]); !strings.HasPrefix(got, `{"v":`) || got == `{"v":null}` {
+			t.Errorf("valid %s with omitzero = %s", typ, got)
+		}
+	}
+}
+
+func TestOmitZeroPatch(t *testing.T) {
+	type patch struct {
+		Name  String `json:"name,omitzero"`
+		Email String `json:"email,omitzero"`
+		Age   Int    `json:"age,omitzero"`
+	}
+
+	var p patch
+	err := json.Unmarshal([]byte(`{"name":"Alice","email":null}`), &p)
+	maybePanic(err)
+	data, err := json.Marshal(p)
+	maybePanic(err)
+	if want := `{"name":"Alice","email":null}`; string(data) != want {
+		t.Errorf("patch round trip = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(patch{Age: Int{Int: 3, Valid: true}})
+	maybePanic(err)
+	if want := `{"age":3}`; string(data) != want {
+		t.Errorf("valid but unset field = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,56 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValueOrZero(t *testing.T) {
+	for _, states := range binaryStates() {
+		valid := reflect.ValueOf(states[2])
+		typ := valid.Type()
+		payload := valid.Field(0)
+		zero := reflect.Zero(payload.Type())
+
+		// A value with a leftover payload but Valid false is still null, and
+		// a valid value that was never Set is still valid.
+		stale := reflect.New(typ).Elem()
+		stale.Set(valid)
+		stale.FieldByName("Valid").SetBool(false)
+		unset := reflect.New(typ).Elem()
+		unset.Set(valid)
+		unset.FieldByName("Set").SetBool(false)
+
+		for _, null := range []reflect.Value{reflect.ValueOf(states[0]), reflect.ValueOf(states[1]), stale} {
+			if got := null.MethodByName("ValueOrZero").Call(nil)[0]; !reflect.DeepEqual(got.Interface(), zero.Interface()) {
+				t.Errorf("%#v.ValueOrZero() = %#v, want zero", null, got)
+			}
+			if got := null.MethodByName("Or").Call([]reflect.Value{payload})[0]; !reflect.DeepEqual(got.Interface(), payload.Interface()) {
+				t.Errorf("%#v.Or(%#v) = %#v", null, payload, got)
+			}
+		}
+		for _, v := range []reflect.Value{valid, unset} {
+			if got := v.MethodByName("ValueOrZero").Call(nil)[0]; !reflect.DeepEqual(got.Interface(), payload.Interface()) {
+				t.Errorf("%#v.ValueOrZero() = %#v, want %#v", v, got, payload)
+			}
+			if got := v.MethodByName("Or").Call([]reflect.Value{zero})[0]; !reflect.DeepEqual(got.Interface(), payload.Interface()) {
+				t.Errorf("%#v.Or(zero) = %#v, want %#v", v, got, payload)
+			}
+		}
+	}
+}
+
+func TestOr(t *testing.T) {
+	if got := NewString("", false).Or("n/a"); got != "n/a" {
+		t.Errorf("null String.Or() = %q, want n/a", got)
+	}
+	if got := StringFrom("").Or("n/a"); got != "" {
+		t.Errorf("empty String.Or() = %q, want empty", got)
+	}
+	if got := (Int64{}).Or(-1); got != -1 {
+		t.Errorf("unset Int64.Or(-1) = %d", got)
+	}
+	if got := ValFrom(testPoint{1, 2}).Or(testPoint{}); got != (testPoint{1, 2}) {
+		t.Errorf("Val.Or() = %v", got)
+	}
+}
@@ -0,0 +1,112 @@
+package null
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type paramUnmarshaler interface {
+	UnmarshalParam(param string) error
+}
+
+type listParams struct {
+	Limit  Int     `query:"limit"`
+	Active Bool    `query:"active"`
+	Name   String  `query:"name"`
+	Since  Time    `query:"since"`
+	Min    Float64 `query:"min"`
+}
+
+// bindQuery binds the query parameters into the fields of dst the way echo's
+// and gin's binders do: UnmarshalParam is called with the first value of each
+// parameter present, and the fields of absent parameters are not touched.
+func bindQuery(values url.Values, dst interface{}) error {
+	v := reflect.ValueOf(dst).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		vs, ok := values[v.Type().Field(i).Tag.Get("query")]
+		if !ok {
+			continue
+		}
+		if err := v.Field(i).Addr().Interface().(paramUnmarshaler).UnmarshalParam(vs[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestUnmarshalParamBinding(t *testing.T) {
+	bound := make(chan listParams, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p listParams
+		if err := bindQuery(r.URL.Query(), &p); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bound <- p
+	}))
+	defer srv.Close()
+
+	var got listParams
+	get := func(query string) int {
+		resp, err := http.Get(srv.URL + "/items?" + query)
+		maybePanic(err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			got = <-bound
+		}
+		return resp.StatusCode
+	}
+
+	if code := get("limit=10&active=&name=alice"); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	want := listParams{Limit: IntFrom(10), Active: NewBool(false, false), Name: StringFrom("alice")}
+	if got != want {
+		t.Errorf("bound %#v, want %#v", got, want)
+	}
+	if !got.Active.IsSet() || got.Active.IsValid() {
+		t.Errorf("active=: IsSet %v, IsValid %v, want a set null", got.Active.IsSet(), got.Active.IsValid())
+	}
+	if got.Since.IsSet() || got.Min.IsSet() {
+		t.Errorf("absent parameters should stay unset, got %#v and %#v", got.Since, got.Min)
+	}
+
+	if code := get("since=2012-12-21T21:21:21Z&min=0.5&active=true"); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	since := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	if !got.Since.Time.Equal(since) || !got.Since.Valid || got.Min != Float64From(0.5) || got.Active != BoolFrom(true) {
+		t.Errorf("bound %#v", got)
+	}
+
+	for _, query := range []string{"limit=ten", "active=maybe", "since=yesterday", "min=x"} {
+		if code := get(query); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, code)
+		}
+	}
+}
+
+func TestUnmarshalParamMatchesText(t *testing.T) {
+	for _, v := range []paramUnmarshaler{
+		new(String), new(Bool), new(Time),
+		new(Int), new(Int8), new(Int16), new(Int32), new(Int64),
+		new(Uint), new(Uint8), new(Uint16), new(Uint32), new(Uint64),
+		new(Float32), new(Float64),
+	} {
+		for _, param := range []string{"", "1", "-1", "300", "true", "2012-12-21T21:21:21Z", "x"} {
+			text := reflect.New(reflect.TypeOf(v).Elem()).Interface().(paramUnmarshaler)
+			reflect.ValueOf(text).Elem().Set(reflect.ValueOf(v).Elem())
+			errParam := v.UnmarshalParam(param)
+			errText := text.(interface{ UnmarshalText([]byte) error }).UnmarshalText([]byte(param))
+			if (errParam == nil) != (errText == nil) || !reflect.DeepEqual(v, text) {
+				t.Errorf("%T.UnmarshalParam(%q) = %#v, %v; UnmarshalText gives %#v, %v", v, param, v, errParam, text, errText)
+			}
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ApplyPatch copies the fields of patch that were set onto dst, a pointer
+// to a struct, so a PATCH handler needs no IsSet ladder:
+//
+//	type UserPatch struct {
+//		Name  null.String
+//		Email null.String `patch:"Contact"`
+//	}
+//	err := null.ApplyPatch(&user, patch)
+//
+// Every null-typed field of patch with Set true is copied into the field of
+// dst with the same name, or the name in its patch tag; a tag of "-" skips
+// the field. Fields that were never set are left alone. The target may be
+// the same null type, which is copied as is, the payload type, which is set
+// to the value or cleared to its zero value for an explicit null, or a
+// pointer to the payload type, which is set to a copy of the value or to
+// nil. A Secret, whose payload is hidden, only patches another Secret.
+// Nested structs and pointers to structs are patched recursively, and
+// the fields of exported embedded structs in patch are matched like fields
+// of patch itself. Other fields of patch are ignored.
+//
+// Slices, maps and pointers in the payload are shared with patch, not
+// copied. A field of patch without a matching field in dst, or with a
+// target of another type, is an error, whether or not it was set, and dst
+// is left untouched. The reflection work is cached per pair of types.
+func ApplyPatch(dst, patch interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("null: ApplyPatch needs a non-nil pointer to a struct, not %T", dst)
+	}
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() == reflect.Ptr {
+		if pv.IsNil() {
+			return nil
+		}
+		pv = pv.Elem()
+	}
+	if pv.Kind() != reflect.Struct {
+		return fmt.Errorf("null: ApplyPatch needs a struct patch, not %T", patch)
+	}
+	plan, err := patchPlanFor(pv.Type(), dv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	plan.apply(dv.Elem(), pv)
+	return nil
+}
+
+// nullPkgPath is the import path of this package, which identifies the
+// null types among struct fields.
+var nullPkgPath = reflect.TypeOf(Bool{}).PkgPath()
+
+var valueType = reflect.TypeOf((*Value)(nil)).Elem()
+
+// nullFields returns the index sequences of the payload, Valid and Set
+// fields of t if t is one of this package's types, which all start with
+// their payload, for reflect.Value.FieldByIndex. A type that embeds another,
+// such as NonEmptyString, has them in the embedded type. A type whose
+// payload is unexported, such as Secret, is handled like a Value of another
+// package, through its methods.
+func nullFields(t reflect.Type) (payload, valid, set []int, ok bool) {
+	if t.Kind() != reflect.Struct || t.PkgPath() != nullPkgPath || !t.Implements(valueType) ||
+		t.NumField() == 0 || t.Field(0).PkgPath != "" {
+		return nil, nil, nil, false
+	}
+	payload = []int{0}
+	if first := t.Field(0); first.Anonymous {
+		inner, _, _, ok := nullFields(first.Type)
+		if !ok {
+			return nil, nil, nil, false
+		}
+		payload = append(payload, inner...)
+	}
+	v, okValid := t.FieldByName("Valid")
+	s, okSet := t.FieldByName("Set")
+	if !okValid || !okSet || v.Type.Kind() != reflect.Bool || s.Type.Kind() != reflect.Bool {
+		return nil, nil, nil, false
+	}
+	return payload, v.Index, s.Index, true
+}
+
+// isNestedStruct reports whether a field of type t holds a struct to be
+// walked field by field, as opposed to a value such as a time.Time or a
+// type implementing Value.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) &&
+		!t.Implements(valueType) && !reflect.PointerTo(t).Implements(valueType)
+}
+
+type patchOp int
+
+const (
+	patchCopy    patchOp = iota // dst has the same type as the patch field
+	patchPayload                // dst has the payload type
+	patchPointer                // dst is a pointer to the payload type
+	patchNested                 // both are structs, or pointers to structs
+	patchValue                  // a foreign Value type copied as is
+)
+
+// patchStep copies one field of a patch onto dst.
+type patchStep struct {
+	op       patchOp
+	src, dst []int
+	payload  []int
+	valid    []int
+	set      []int
+	convert  reflect.Type // the payload's target type if it must be converted
+	nested   *patchPlan
+}
+
+type patchPlan struct {
+	steps []patchStep
+	err   error
+}
+
+var (
+	patchMu    sync.Mutex
+	patchPlans sync.Map // map[[2]reflect.Type]*patchPlan
+)
+
+func patchPlanFor(patch, dst reflect.Type) (*patchPlan, error) {
+	if p, ok := patchPlans.Load([2]reflect.Type{patch, dst}); ok {
+		plan := p.(*patchPlan)
+		return plan, plan.err
+	}
+	patchMu.Lock()
+	defer patchMu.Unlock()
+	building := map[[2]reflect.Type]*patchPlan{}
+	plan := buildPatchPlan(patch, dst, building)
+	for key, p := range building {
+		patchPlans.Store(key, p)
+	}
+	return plan, plan.err
+}
+
+// buildPatchPlan returns the plan for patching dst with patch. Plans under
+// construction are kept in building, so recursive types refer to their own
+// plan instead of building it forever.
+func buildPatchPlan(patch, dst reflect.Type, building map[[2]reflect.Type]*patchPlan) *patchPlan {
+	key := [2]reflect.Type{patch, dst}
+	if p, ok := patchPlans.Load(key); ok {
+		return p.(*patchPlan)
+	}
+	if plan, ok := building[key]; ok {
+		return plan
+	}
+	plan := &patchPlan{}
+	building[key] = plan
+	plan.err = plan.build(patch, dst, nil, nil, building)
+	return plan
+}
+
+// build adds the steps for the fields of patch, found at prefix within the
+// top-level patch, and names them after path in errors.
+func (plan *patchPlan) build(patch, dst reflect.Type, prefix []int, path []string, building map[[2]reflect.Type]*patchPlan) error {
+	for i := 0; i < patch.NumField(); i++ {
+		f := patch.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("patch")
+		if tag == "-" {
+			continue
+		}
+		src := append(append([]int(nil), prefix...), i)
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		fieldPath := strings.Join(append(append([]string(nil), path...), f.Name), ".")
+
+		if f.Anonymous && tag == "" && isNestedStruct(f.Type) {
+			if f.Type.Kind() == reflect.Ptr {
+				// Embedded pointers are followed at apply time.
+				sub := buildPatchPlan(f.Type.Elem(), dst, building)
+				if sub.err != nil {
+					return sub.err
+				}
+				plan.steps = append(plan.steps, patchStep{op: patchNested, src: src, nested: sub})
+				continue
+			}
+			if err := plan.build(f.Type, dst, src, append(path, f.Name), building); err != nil {
+				return err
+			}
+			continue
+		}
+
+		isValue := f.Type.Implements(valueType)
+		if !isValue && !isNestedStruct(f.Type) {
+			continue
+		}
+		df, ok := dst.FieldByName(name)
+		if !ok || df.PkgPath != "" {
+			return fmt.Errorf("null: patch field %s has no matching field %s in %s", fieldPath, name, dst)
+		}
+		step := patchStep{src: src, dst: df.Index}
+
+		switch {
+		case !isValue:
+			if !isNestedStruct(df.Type) {
+				return fmt.Errorf("null: cannot patch %s.%s of type %s with struct field %s of type %s", dst, name, df.Type, fieldPath, f.Type)
+			}
+			pt, dt := f.Type, df.Type
+			if pt.Kind() == reflect.Ptr {
+				pt = pt.Elem()
+			}
+			if dt.Kind() == reflect.Ptr {
+				dt = dt.Elem()
+			}
+			sub := buildPatchPlan(pt, dt, building)
+			if sub.err != nil {
+				return sub.err
+			}
+			step.op, step.nested = patchNested, sub
+		case df.Type == f.Type:
+			step.op = patchCopy
+			step.payload, step.valid, step.set, ok = nullFields(f.Type)
+			if !ok {
+				step.op = patchValue
+			}
+		default:
+			var okNull bool
+			step.payload, step.valid, step.set, okNull = nullFields(f.Type)
+			if !okNull {
+				return fmt.Errorf("null: cannot patch %s.%s of type %s with field %s of type %s", dst, name, df.Type, fieldPath, f.Type)
+			}
+			pt := f.Type.FieldByIndex(step.payload).Type
+			step.op = patchPayload
+			target := df.Type
+			if df.Type.Kind() == reflect.Ptr && df.Type != pt {
+				step.op = patchPointer
+				target = df.Type.Elem()
+			}
+			switch {
+			case pt == target:
+			case pt.Kind() == target.Kind() && pt.ConvertibleTo(target):
+				step.convert = target
+			default:
+				return fmt.Errorf("null: cannot patch %s.%s of type %s with field %s of type %s holding %s", dst, name, df.Type, fieldPath, f.Type, pt)
+			}
+		}
+		plan.steps = append(plan.steps, step)
+	}
+	return nil
+}
+
+func (plan *patchPlan) apply(dst, patch reflect.Value) {
+	for _, step := range plan.steps {
+		src := patch.FieldByIndex(step.src)
+		switch step.op {
+		case patchNested:
+			if src.Kind() == reflect.Ptr {
+				if src.IsNil() {
+					continue
+				}
+				src = src.Elem()
+			}
+			target := dst
+			if step.dst != nil {
+				target = settableField(dst, step.dst)
+				if target.Kind() == reflect.Ptr {
+					if target.IsNil() {
+						target.Set(reflect.New(target.Type().Elem()))
+					}
+					target = target.Elem()
+				}
+			}
+			step.nested.apply(target, src)
+			continue
+		case patchValue:
+			if src.Kind() == reflect.Ptr && src.IsNil() {
+				continue
+			}
+			if src.Interface().(Value).IsSet() {
+				settableField(dst, step.dst).Set(src)
+			}
+			continue
+		}
+
+		if !src.FieldByIndex(step.set).Bool() {
+			continue
+		}
+		target := settableField(dst, step.dst)
+		valid := src.FieldByIndex(step.valid).Bool()
+		switch step.op {
+		case patchCopy:
+			target.Set(src)
+		case patchPayload:
+			if !valid {
+				target.Set(reflect.Zero(target.Type()))
+				continue
+			}
+			target.Set(step.convertPayload(src.FieldByIndex(step.payload)))
+		case patchPointer:
+			if !valid {
+				target.Set(reflect.Zero(target.Type()))
+				continue
+			}
+			ptr := reflect.New(target.Type().Elem())
+			ptr.Elem().Set(step.convertPayload(src.FieldByIndex(step.payload)))
+			target.Set(ptr)
+		}
+	}
+}
+
+func (step *patchStep) convertPayload(v reflect.Value) reflect.Value {
+	if step.convert != nil {
+		return v.Convert(step.convert)
+	}
+	return v
+}
+
+// settableField returns the field of v at index, allocating any nil
+// embedded struct pointers on the way.
+func settableField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,205 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type patchAddress struct {
+	City String
+	Zip  string
+}
+
+type PatchAudit struct {
+	UpdatedBy String
+}
+
+type patchUser struct {
+	Name      string
+	Nickname  *string
+	Age       Int
+	Score     int64
+	Contact   string
+	Status    testStatus
+	Address   patchAddress
+	Billing   *patchAddress
+	Born      time.Time
+	Updated   string
+	Untouched string
+}
+
+type userPatch struct {
+	PatchAudit
+	Name     String
+	Nickname String
+	Age      Int
+	Score    Int64
+	Email    String `patch:"Contact"`
+	Status   Enum[testStatus]
+	Address  *struct{ City String }
+	Billing  struct{ City String }
+	Born     Time
+	Internal String `patch:"-"`
+	Comment  string
+	ignored  String
+}
+
+func TestApplyPatch(t *testing.T) {
+	when := time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)
+	nick := "al"
+	user := patchUser{
+		Name:      "Alice",
+		Nickname:  &nick,
+		Age:       IntFrom(30),
+		Score:     10,
+		Contact:   "alice@example.com",
+		Status:    statusActive,
+		Address:   patchAddress{City: StringFrom("Paris"), Zip: "75001"},
+		Born:      when,
+		Untouched: "keep",
+	}
+	patch := userPatch{
+		PatchAudit: PatchAudit{UpdatedBy: StringFrom("admin")},
+		Name:       StringFrom("Alicia"),
+		Nickname:   NewString("", false),
+		Age:        NewInt(0, false),
+		Email:      StringFrom("alicia@example.com"),
+		Status:     EnumFrom(statusBlocked),
+		Address:    &struct{ City String }{City: NewString("", false)},
+		Billing:    struct{ City String }{City: StringFrom("Lyon")},
+		Internal:   StringFrom("ignored"),
+		Comment:    "ignored",
+		ignored:    StringFrom("ignored"),
+	}
+	if err := ApplyPatch(&user, patch); err == nil || !strings.Contains(err.Error(), "UpdatedBy") {
+		t.Fatalf("ApplyPatch() = %v, want an error for the missing UpdatedBy field", err)
+	}
+
+	type auditedUser struct {
+		patchUser
+		UpdatedBy *string
+	}
+	audited := auditedUser{patchUser: user}
+	if err := ApplyPatch(&audited, &patch); err != nil {
+		t.Fatal(err)
+	}
+	admin := "admin"
+	want := auditedUser{
+		patchUser: patchUser{
+			Name:      "Alicia",
+			Nickname:  nil,
+			Age:       NewInt(0, false),
+			Score:     10,
+			Contact:   "alicia@example.com",
+			Status:    statusBlocked,
+			Address:   patchAddress{City: NewString("", false), Zip: "75001"},
+			Billing:   &patchAddress{City: StringFrom("Lyon")},
+			Born:      when,
+			Untouched: "keep",
+		},
+		UpdatedBy: &admin,
+	}
+	if !reflect.DeepEqual(audited, want) {
+		t.Errorf("ApplyPatch() = %#v, want %#v", audited, want)
+	}
+
+	// Unset fields leave everything alone, and so does a nil patch.
+	before := audited
+	if err := ApplyPatch(&audited, userPatch{Address: &struct{ City String }{}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyPatch(&audited, (*userPatch)(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(audited, before) {
+		t.Errorf("ApplyPatch() with an empty patch changed %#v to %#v", before, audited)
+	}
+
+	// A time clears to the zero time.
+	if err := ApplyPatch(&audited, userPatch{Born: NewTime(time.Time{}, false)}); err != nil {
+		t.Fatal(err)
+	}
+	if !audited.Born.IsZero() {
+		t.Errorf("ApplyPatch() left Born = %v, want the zero time", audited.Born)
+	}
+}
+
+func TestApplyPatchConvert(t *testing.T) {
+	type name string
+	var dst struct {
+		Name  name
+		Alias *name
+	}
+	patch := struct{ Name, Alias String }{StringFrom("a"), StringFrom("b")}
+	if err := ApplyPatch(&dst, patch); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "a" || dst.Alias == nil || *dst.Alias != "b" {
+		t.Errorf("ApplyPatch() = %#v", dst)
+	}
+}
+
+type patchNode struct {
+	Name String
+	Next *patchNode
+}
+
+func TestApplyPatchRecursive(t *testing.T) {
+	dst := patchNode{Name: StringFrom("a")}
+	patch := patchNode{Next: &patchNode{Name: StringFrom("b")}}
+	if err := ApplyPatch(&dst, patch); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != StringFrom("a") || dst.Next == nil || dst.Next.Name != StringFrom("b") {
+		t.Errorf("ApplyPatch() = %#v", dst)
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	var dst struct {
+		Count   int32
+		Name    int
+		Nested  string
+		private String
+	}
+	tests := []struct {
+		dst, patch interface{}
+		msg        string
+	}{
+		{dst, struct{}{}, "pointer to a struct"},
+		{(*struct{})(nil), struct{}{}, "pointer to a struct"},
+		{&dst, 42, "struct patch"},
+		{&dst, struct{ Count Int64 }{}, "Count of type int32 with field Count of type null.Int64 holding int64"},
+		{&dst, struct{ Name String }{}, "Name of type int"},
+		{&dst, struct{ Nested struct{ A String } }{}, "struct field Nested"},
+		{&dst, struct{ Missing String }{}, "no matching field Missing"},
+		{&dst, struct {
+			Private String `patch:"private"`
+		}{}, "no matching field private"},
+	}
+	for _, test := range tests {
+		err := ApplyPatch(test.dst, test.patch)
+		if err == nil || !strings.Contains(err.Error(), test.msg) {
+			t.Errorf("ApplyPatch(%T, %T) = %v, want an error containing %q", test.dst, test.patch, err, test.msg)
+		}
+	}
+}
+
+func BenchmarkApplyPatch(b *testing.B) {
+	user := patchUser{Name: "Alice", Address: patchAddress{City: StringFrom("Paris")}}
+	patch := struct {
+		Name    String
+		Age     Int
+		Score   Int64
+		Email   String `patch:"Contact"`
+		Address struct{ City String }
+	}{Name: StringFrom("Alicia"), Score: Int64From(11), Address: struct{ City String }{StringFrom("Lyon")}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ApplyPatch(&user, &patch); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package pb converts between the null types and the protobuf well-known
+// types: the wrappers such as google.protobuf.StringValue, and
+// google.protobuf.Timestamp.
+//
+// A nil message converts to a null value that is Set, like the null
+// package's FromPtr constructors, and a null value converts to a nil
+// message, which leaves the field unset on the wire. Proto3 optional
+// scalars are plain pointers, so FromPtr and Ptr already cover them.
+package pb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sisoftrg/null"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// StringFromWrapper creates a null.String that is null if w is nil.
+func StringFromWrapper(w *wrapperspb.StringValue) null.String {
+	if w == nil {
+		return null.NewString("", false)
+	}
+	return null.StringFrom(w.GetValue())
+}
+
+// StringToWrapper returns s as a StringValue, or nil if s is null.
+func StringToWrapper(s null.String) *wrapperspb.StringValue {
+	if !s.Valid {
+		return nil
+	}
+	return wrapperspb.String(s.String)
+}
+
+// Int32FromWrapper creates a null.Int32 that is null if w is nil.
+func Int32FromWrapper(w *wrapperspb.Int32Value) null.Int32 {
+	if w == nil {
+		return null.NewInt32(0, false)
+	}
+	return null.Int32From(w.GetValue())
+}
+
+// Int32ToWrapper returns i as an Int32Value, or nil if i is null.
+func Int32ToWrapper(i null.Int32) *wrapperspb.Int32Value {
+	if !i.Valid {
+		return nil
+	}
+	return wrapperspb.Int32(i.Int32)
+}
+
+// Int64FromWrapper creates a null.Int64 that is null if w is nil.
+func Int64FromWrapper(w *wrapperspb.Int64Value) null.Int64 {
+	if w == nil {
+		return null.NewInt64(0, false)
+	}
+	return null.Int64From(w.GetValue())
+}
+
+// Int64ToWrapper returns i as an Int64Value, or nil if i is null.
+func Int64ToWrapper(i null.Int64) *wrapperspb.Int64Value {
+	if !i.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(i.Int64)
+}
+
+// Uint32FromWrapper creates a null.Uint32 that is null if w is nil.
+func Uint32FromWrapper(w *wrapperspb.UInt32Value) null.Uint32 {
+	if w == nil {
+		return null.NewUint32(0, false)
+	}
+	return null.Uint32From(w.GetValue())
+}
+
+// Uint32ToWrapper returns u as a UInt32Value, or nil if u is null.
+func Uint32ToWrapper(u null.Uint32) *wrapperspb.UInt32Value {
+	if !u.Valid {
+		return nil
+	}
+	return wrapperspb.UInt32(u.Uint32)
+}
+
+// Uint64FromWrapper creates a null.Uint64 that is null if w is nil.
+func Uint64FromWrapper(w *wrapperspb.UInt64Value) null.Uint64 {
+	if w == nil {
+		return null.NewUint64(0, false)
+	}
+	return null.Uint64From(w.GetValue())
+}
+
+// Uint64ToWrapper returns u as a UInt64Value, or nil if u is null.
+func Uint64ToWrapper(u null.Uint64) *wrapperspb.UInt64Value {
+	if !u.Valid {
+		return nil
+	}
+	return wrapperspb.UInt64(u.Uint64)
+}
+
+// Float32FromWrapper creates a null.Float32 from a FloatValue that is null
+// if w is nil.
+func Float32FromWrapper(w *wrapperspb.FloatValue) null.Float32 {
+	if w == nil {
+		return null.NewFloat32(0, false)
+	}
+	return null.Float32From(w.GetValue())
+}
+
+// Float32ToWrapper returns f as a FloatValue, or nil if f is null.
+func Float32ToWrapper(f null.Float32) *wrapperspb.FloatValue {
+	if !f.Valid {
+		return nil
+	}
+	return wrapperspb.Float(f.Float32)
+}
+
+// Float64FromWrapper creates a null.Float64 from a DoubleValue that is null
+// if w is nil.
+func Float64FromWrapper(w *wrapperspb.DoubleValue) null.Float64 {
+	if w == nil {
+		return null.NewFloat64(0, false)
+	}
+	return null.Float64From(w.GetValue())
+}
+
+// Float64ToWrapper returns f as a DoubleValue, or nil if f is null.
+func Float64ToWrapper(f null.Float64) *wrapperspb.DoubleValue {
+	if !f.Valid {
+		return nil
+	}
+	return wrapperspb.Double(f.Float64)
+}
+
+// BoolFromWrapper creates a null.Bool that is null if w is nil.
+func BoolFromWrapper(w *wrapperspb.BoolValue) null.Bool {
+	if w == nil {
+		return null.NewBool(false, false)
+	}
+	return null.BoolFrom(w.GetValue())
+}
+
+// BoolToWrapper returns b as a BoolValue, or nil if b is null.
+func BoolToWrapper(b null.Bool) *wrapperspb.BoolValue {
+	if !b.Valid {
+		return nil
+	}
+	return wrapperspb.Bool(b.Bool)
+}
+
+// BytesFromWrapper creates a null.Bytes that is null if w is nil. An empty
+// BytesValue is valid.
+func BytesFromWrapper(w *wrapperspb.BytesValue) null.Bytes {
+	if w == nil {
+		return null.NewBytes(nil, false)
+	}
+	v := w.GetValue()
+	if v == nil {
+		v = []byte{}
+	}
+	return null.NewBytes(v, true)
+}
+
+// BytesToWrapper returns b as a BytesValue, or nil if b is null.
+func BytesToWrapper(b null.Bytes) *wrapperspb.BytesValue {
+	if !b.Valid {
+		return nil
+	}
+	return wrapperspb.Bytes(b.Bytes)
+}
+
+// TimeFromTimestamp creates a null.Time that is null if ts is nil. It
+// returns an error if ts is outside the range protobuf allows, from year 1
+// to year 9999, or has invalid nanoseconds. The time is in UTC.
+func TimeFromTimestamp(ts *timestamppb.Timestamp) (null.Time, error) {
+	if ts == nil {
+		return null.NewTime(time.Time{}, false), nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return null.NewTime(time.Time{}, false), fmt.Errorf("null: cannot convert Timestamp into null.Time: %w", err)
+	}
+	return null.TimeFrom(ts.AsTime()), nil
+}
+
+// TimeToTimestamp returns t as a Timestamp, or nil if t is null. It returns
+// an error if t is outside the range a Timestamp can hold.
+func TimeToTimestamp(t null.Time) (*timestamppb.Timestamp, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	ts := timestamppb.New(t.Time)
+	if err := ts.CheckValid(); err != nil {
+		return nil, fmt.Errorf("null: cannot convert null.Time into Timestamp: %w", err)
+	}
+	return ts, nil
+}
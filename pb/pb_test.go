@@ -0,0 +1,125 @@
+package pb
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sisoftrg/null"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFromWrapper(t *testing.T) {
+	tests := []struct {
+		got, want interface{}
+	}{
+		{StringFromWrapper(wrapperspb.String("")), null.StringFrom("")},
+		{StringFromWrapper(nil), null.NewString("", false)},
+		{Int32FromWrapper(wrapperspb.Int32(math.MinInt32)), null.Int32From(math.MinInt32)},
+		{Int32FromWrapper(nil), null.NewInt32(0, false)},
+		{Int64FromWrapper(wrapperspb.Int64(math.MaxInt64)), null.Int64From(math.MaxInt64)},
+		{Int64FromWrapper(nil), null.NewInt64(0, false)},
+		{Uint32FromWrapper(wrapperspb.UInt32(math.MaxUint32)), null.Uint32From(math.MaxUint32)},
+		{Uint32FromWrapper(nil), null.NewUint32(0, false)},
+		{Uint64FromWrapper(wrapperspb.UInt64(math.MaxUint64)), null.Uint64From(math.MaxUint64)},
+		{Uint64FromWrapper(nil), null.NewUint64(0, false)},
+		{Float32FromWrapper(wrapperspb.Float(1.5)), null.Float32From(1.5)},
+		{Float32FromWrapper(nil), null.NewFloat32(0, false)},
+		{Float64FromWrapper(wrapperspb.Double(-2.5)), null.Float64From(-2.5)},
+		{Float64FromWrapper(nil), null.NewFloat64(0, false)},
+		{BoolFromWrapper(wrapperspb.Bool(false)), null.BoolFrom(false)},
+		{BoolFromWrapper(nil), null.NewBool(false, false)},
+		{BytesFromWrapper(wrapperspb.Bytes([]byte{0xab})), null.BytesFrom([]byte{0xab})},
+		{BytesFromWrapper(&wrapperspb.BytesValue{}), null.NewBytes([]byte{}, true)},
+		{BytesFromWrapper(nil), null.NewBytes(nil, false)},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("got %#v, want %#v", test.got, test.want)
+		}
+	}
+}
+
+func TestToWrapper(t *testing.T) {
+	tests := []struct {
+		got, want proto.Message
+	}{
+		{StringToWrapper(null.StringFrom("")), wrapperspb.String("")},
+		{Int32ToWrapper(null.Int32From(-32)), wrapperspb.Int32(-32)},
+		{Int64ToWrapper(null.Int64From(-64)), wrapperspb.Int64(-64)},
+		{Uint32ToWrapper(null.Uint32From(32)), wrapperspb.UInt32(32)},
+		{Uint64ToWrapper(null.Uint64From(64)), wrapperspb.UInt64(64)},
+		{Float32ToWrapper(null.Float32From(1.5)), wrapperspb.Float(1.5)},
+		{Float64ToWrapper(null.Float64From(-2.5)), wrapperspb.Double(-2.5)},
+		{BoolToWrapper(null.BoolFrom(false)), wrapperspb.Bool(false)},
+		{BytesToWrapper(null.BytesFrom([]byte{0xab})), wrapperspb.Bytes([]byte{0xab})},
+	}
+	for _, test := range tests {
+		if !proto.Equal(test.got, test.want) {
+			t.Errorf("got %v, want %v", test.got, test.want)
+		}
+	}
+
+	nulls := []interface{}{
+		StringToWrapper(null.String{}),
+		Int32ToWrapper(null.NewInt32(0, false)),
+		Int64ToWrapper(null.Int64{}),
+		Uint32ToWrapper(null.Uint32{}),
+		Uint64ToWrapper(null.Uint64{}),
+		Float32ToWrapper(null.Float32{}),
+		Float64ToWrapper(null.Float64{}),
+		BoolToWrapper(null.NewBool(true, false)),
+		BytesToWrapper(null.Bytes{}),
+	}
+	for _, w := range nulls {
+		if !reflect.ValueOf(w).IsNil() {
+			t.Errorf("null converted to %v, want nil", w)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC)
+	ts, err := TimeToTimestamp(null.TimeFrom(when.In(time.FixedZone("UTC+3", 3*60*60))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(ts, timestamppb.New(when)) {
+		t.Errorf("TimeToTimestamp() = %v, want %v", ts, timestamppb.New(when))
+	}
+	back, err := TimeFromTimestamp(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back != null.TimeFrom(when) {
+		t.Errorf("TimeFromTimestamp() = %#v, want %#v", back, null.TimeFrom(when))
+	}
+
+	if ts, err := TimeToTimestamp(null.Time{}); ts != nil || err != nil {
+		t.Errorf("TimeToTimestamp(null) = %v, %v, want nil", ts, err)
+	}
+	if got, err := TimeFromTimestamp(nil); err != nil || got.Valid || !got.Set {
+		t.Errorf("TimeFromTimestamp(nil) = %#v, %v, want a set null", got, err)
+	}
+
+	for _, bad := range []*timestamppb.Timestamp{
+		{Seconds: 253402300800},
+		{Seconds: -62135596801},
+		{Seconds: 0, Nanos: 1e9},
+	} {
+		if got, err := TimeFromTimestamp(bad); err == nil || got.Valid {
+			t.Errorf("TimeFromTimestamp(%v) = %#v, %v, want an error", bad, got, err)
+		}
+	}
+	for _, bad := range []time.Time{
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC),
+	} {
+		if ts, err := TimeToTimestamp(null.TimeFrom(bad)); err == nil || ts != nil {
+			t.Errorf("TimeToTimestamp(%v) = %v, %v, want an error", bad, ts, err)
+		}
+	}
+}
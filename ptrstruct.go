@@ -0,0 +1,284 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// FromPtrStruct fills dst, a pointer to a struct of null types, from src,
+// a struct or a pointer to a struct with pointer fields, such as the
+// optional fields of generated protobuf code:
+//
+//	type User struct {
+//		Name  null.String
+//		Email null.String `null:"ContactEmail"`
+//	}
+//	err := null.FromPtrStruct(msg, &user)
+//
+// Every null-typed field of dst is matched with the field of src with the
+// same name, or the name in its null tag; a tag of "-" skips the field. A
+// nil pointer becomes a set null and any other pointer a valid value. A
+// field of src holding the payload type directly, rather than a pointer to
+// it, always gives a valid value. Payloads of another type of the same
+// kind, such as a named string type, are converted. Nested structs and
+// pointers to structs are mapped recursively, a nil pointer leaving its
+// target at the zero value. Other fields of dst, and fields of src without
+// a match, are ignored. For a single pointer, ValFromPtr and the FromPtr
+// constructor of each type do the same.
+//
+// A field of dst without a matching field in src, or with a match of an
+// unsuitable type, is an error, and dst is left untouched. The reflection
+// work is cached per pair of types.
+func FromPtrStruct(src, dst interface{}) error {
+	dv, sv, err := ptrStructArgs(dst, src, "FromPtrStruct")
+	if err != nil || !sv.IsValid() {
+		return err
+	}
+	plan, err := ptrPlanFor(dv.Type(), sv.Type())
+	if err != nil {
+		return err
+	}
+	plan.fromPtr(dv, sv)
+	return nil
+}
+
+// ToPtrStruct is the inverse of FromPtrStruct: it fills dst, a pointer to
+// a struct with pointer fields, from src, a struct of null types or a
+// pointer to one. Fields are matched by the names and tags of src the same
+// way. A null becomes a nil pointer, whether set or not, and a valid value
+// a pointer to a copy of it; a field holding the payload type directly is
+// set to the value, or to its zero value for a null. Nested structs are
+// allocated as needed. Fields of dst without a match are left alone.
+func ToPtrStruct(src, dst interface{}) error {
+	dv, sv, err := ptrStructArgs(dst, src, "ToPtrStruct")
+	if err != nil || !sv.IsValid() {
+		return err
+	}
+	plan, err := ptrPlanFor(sv.Type(), dv.Type())
+	if err != nil {
+		return err
+	}
+	plan.toPtr(dv, sv)
+	return nil
+}
+
+// ptrStructArgs checks the arguments of FromPtrStruct and ToPtrStruct and
+// returns the structs they point to. The source is invalid if it is a nil
+// pointer, which maps nothing.
+func ptrStructArgs(dst, src interface{}, fn string) (reflect.Value, reflect.Value, error) {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("null: %s needs a non-nil pointer to a struct, not %T", fn, dst)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Ptr {
+		if sv.IsNil() {
+			return reflect.Value{}, reflect.Value{}, nil
+		}
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("null: %s needs a struct source, not %T", fn, src)
+	}
+	return dv.Elem(), sv, nil
+}
+
+type ptrOp int
+
+const (
+	ptrPointer ptrOp = iota // the pointer struct has a pointer to the payload
+	ptrPlain                // the pointer struct has the payload itself
+	ptrNested               // both are structs, or pointers to structs
+)
+
+// ptrField maps one field of a null struct to one of a pointer struct.
+type ptrField struct {
+	op                  ptrOp
+	null, ptr           int
+	payload, valid, set []int
+	nullType, ptrType   reflect.Type // the payload types on either side
+	nested              *ptrPlan
+}
+
+type ptrPlan struct {
+	fields []ptrField
+	err    error
+}
+
+var (
+	ptrMu    sync.Mutex
+	ptrPlans sync.Map // map[[2]reflect.Type]*ptrPlan
+)
+
+func ptrPlanFor(null, ptr reflect.Type) (*ptrPlan, error) {
+	if p, ok := ptrPlans.Load([2]reflect.Type{null, ptr}); ok {
+		plan := p.(*ptrPlan)
+		return plan, plan.err
+	}
+	ptrMu.Lock()
+	defer ptrMu.Unlock()
+	building := map[[2]reflect.Type]*ptrPlan{}
+	plan := buildPtrPlan(null, ptr, building)
+	for key, p := range building {
+		ptrPlans.Store(key, p)
+	}
+	return plan, plan.err
+}
+
+// buildPtrPlan returns the plan mapping null to ptr, keeping plans under
+// construction in building like buildPatchPlan.
+func buildPtrPlan(null, ptr reflect.Type, building map[[2]reflect.Type]*ptrPlan) *ptrPlan {
+	key := [2]reflect.Type{null, ptr}
+	if p, ok := ptrPlans.Load(key); ok {
+		return p.(*ptrPlan)
+	}
+	if plan, ok := building[key]; ok {
+		return plan
+	}
+	plan := &ptrPlan{}
+	building[key] = plan
+	plan.err = plan.build(null, ptr, building)
+	return plan
+}
+
+func (plan *ptrPlan) build(null, ptr reflect.Type, building map[[2]reflect.Type]*ptrPlan) error {
+	for i := 0; i < null.NumField(); i++ {
+		f := null.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := f.Tag.Get("null")
+		if tag == "-" {
+			continue
+		}
+		payload, valid, set, isNull := nullFields(f.Type)
+		if !isNull && !isNestedStruct(f.Type) {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		pf, ok := ptr.FieldByName(name)
+		if !ok || pf.PkgPath != "" || len(pf.Index) != 1 {
+			return fmt.Errorf("null: field %s.%s has no matching field %s in %s", null, f.Name, name, ptr)
+		}
+		field := ptrField{null: i, ptr: pf.Index[0]}
+
+		if !isNull {
+			if !isNestedStruct(pf.Type) {
+				return fmt.Errorf("null: cannot map %s.%s of type %s to struct field %s.%s of type %s", ptr, name, pf.Type, null, f.Name, f.Type)
+			}
+			sub := buildPtrPlan(derefType(f.Type), derefType(pf.Type), building)
+			if sub.err != nil {
+				return sub.err
+			}
+			field.op, field.nested = ptrNested, sub
+			plan.fields = append(plan.fields, field)
+			continue
+		}
+
+		field.payload, field.valid, field.set = payload, valid, set
+		field.nullType = f.Type.FieldByIndex(payload).Type
+		field.op, field.ptrType = ptrPlain, pf.Type
+		if pf.Type.Kind() == reflect.Ptr && pf.Type != field.nullType {
+			field.op, field.ptrType = ptrPointer, pf.Type.Elem()
+		}
+		if field.ptrType != field.nullType &&
+			(field.ptrType.Kind() != field.nullType.Kind() || !field.ptrType.ConvertibleTo(field.nullType) || !field.nullType.ConvertibleTo(field.ptrType)) {
+			return fmt.Errorf("null: cannot map %s.%s of type %s to %s.%s of type %s", ptr, name, pf.Type, null, f.Name, f.Type)
+		}
+		plan.fields = append(plan.fields, field)
+	}
+	return nil
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// fromPtr sets the fields of null, a struct of null types, from ptr.
+func (plan *ptrPlan) fromPtr(null, ptr reflect.Value) {
+	for _, f := range plan.fields {
+		src, dst := ptr.Field(f.ptr), null.Field(f.null)
+		switch f.op {
+		case ptrNested:
+			if src.Kind() == reflect.Ptr {
+				if src.IsNil() {
+					dst.Set(reflect.Zero(dst.Type()))
+					continue
+				}
+				src = src.Elem()
+			}
+			f.nested.fromPtr(allocStruct(dst), src)
+			continue
+		case ptrPointer:
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+				dst.FieldByIndex(f.set).SetBool(true)
+				continue
+			}
+			src = src.Elem()
+		}
+		v := reflect.New(dst.Type()).Elem()
+		v.FieldByIndex(f.payload).Set(convertTo(src, f.nullType))
+		v.FieldByIndex(f.valid).SetBool(true)
+		v.FieldByIndex(f.set).SetBool(true)
+		dst.Set(v)
+	}
+}
+
+// toPtr sets the fields of ptr from null, a struct of null types.
+func (plan *ptrPlan) toPtr(ptr, null reflect.Value) {
+	for _, f := range plan.fields {
+		src, dst := null.Field(f.null), ptr.Field(f.ptr)
+		switch f.op {
+		case ptrNested:
+			if src.Kind() == reflect.Ptr {
+				if src.IsNil() {
+					dst.Set(reflect.Zero(dst.Type()))
+					continue
+				}
+				src = src.Elem()
+			}
+			f.nested.toPtr(allocStruct(dst), src)
+		case ptrPointer:
+			if !src.FieldByIndex(f.valid).Bool() {
+				dst.Set(reflect.Zero(dst.Type()))
+				continue
+			}
+			p := reflect.New(f.ptrType)
+			p.Elem().Set(convertTo(src.FieldByIndex(f.payload), f.ptrType))
+			dst.Set(p)
+		case ptrPlain:
+			if !src.FieldByIndex(f.valid).Bool() {
+				dst.Set(reflect.Zero(dst.Type()))
+				continue
+			}
+			dst.Set(convertTo(src.FieldByIndex(f.payload), f.ptrType))
+		}
+	}
+}
+
+// allocStruct returns the struct v holds, or points to, allocating it if v
+// is a nil pointer.
+func allocStruct(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr {
+		return v
+	}
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	return v.Elem()
+}
+
+func convertTo(v reflect.Value, t reflect.Type) reflect.Value {
+	if v.Type() != t {
+		return v.Convert(t)
+	}
+	return v
+}
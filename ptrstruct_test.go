@@ -0,0 +1,167 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+)
+
+type ptrAddress struct {
+	City *string
+	Zip  *string
+}
+
+type ptrUser struct {
+	Name         *string
+	Age          *int64
+	Admin        bool
+	ContactEmail *string
+	Status       *testStatus
+	Home         *ptrAddress
+	Work         ptrAddress
+	Ignored      *string
+	state        int
+}
+
+type nullAddress struct {
+	City String
+	Zip  String
+}
+
+type nullUser struct {
+	Name   String
+	Age    Int64
+	Admin  Bool
+	Email  String `null:"ContactEmail"`
+	Status String
+	Home   *nullAddress
+	Work   nullAddress
+	Local  String `null:"-"`
+	Note   string
+}
+
+func TestFromPtrStruct(t *testing.T) {
+	name, city, status := "Alice", "Paris", statusActive
+	src := ptrUser{
+		Name:   &name,
+		Admin:  true,
+		Status: &status,
+		Home:   &ptrAddress{City: &city},
+	}
+	user := nullUser{Age: Int64From(7), Local: StringFrom("kept"), Note: "kept"}
+	err := FromPtrStruct(&src, &user)
+	maybePanic(err)
+	want := nullUser{
+		Name:   StringFrom("Alice"),
+		Age:    NewInt64(0, false),
+		Admin:  BoolFrom(true),
+		Email:  NewString("", false),
+		Status: StringFrom("active"),
+		Home:   &nullAddress{City: StringFrom("Paris"), Zip: NewString("", false)},
+		Work:   nullAddress{City: NewString("", false), Zip: NewString("", false)},
+		Local:  StringFrom("kept"),
+		Note:   "kept",
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("FromPtrStruct() = %#v, want %#v", user, want)
+	}
+
+	// The values are copies.
+	name = "Bob"
+	if user.Name.String != "Alice" {
+		t.Errorf("FromPtrStruct() shares its payload with the source: %v", user.Name)
+	}
+
+	src.Home = nil
+	err = FromPtrStruct(src, &user)
+	maybePanic(err)
+	if user.Home != nil {
+		t.Errorf("a nil nested pointer should give nil, got %#v", user.Home)
+	}
+
+	if err := FromPtrStruct((*ptrUser)(nil), &user); err != nil {
+		t.Errorf("FromPtrStruct(nil) = %v", err)
+	}
+}
+
+func TestToPtrStruct(t *testing.T) {
+	user := nullUser{
+		Name:   StringFrom("Alice"),
+		Age:    NewInt64(0, false),
+		Admin:  NewBool(true, false),
+		Email:  StringFrom("a@example.com"),
+		Status: StringFrom("blocked"),
+		Work:   nullAddress{City: StringFrom("Paris")},
+	}
+	ignored := "kept"
+	out := ptrUser{Age: new(int64), Ignored: &ignored}
+	err := ToPtrStruct(user, &out)
+	maybePanic(err)
+	if out.Name == nil || *out.Name != "Alice" || out.Age != nil || out.Admin ||
+		out.ContactEmail == nil || *out.ContactEmail != "a@example.com" ||
+		out.Status == nil || *out.Status != statusBlocked || out.Home != nil ||
+		out.Work.City == nil || *out.Work.City != "Paris" || out.Work.Zip != nil || out.Ignored != &ignored {
+		t.Errorf("ToPtrStruct() = %+v", out)
+	}
+
+	// A round trip gives the valid values back and nulls as set nulls,
+	// except through a plain field, which has no null.
+	var back nullUser
+	err = FromPtrStruct(&out, &back)
+	maybePanic(err)
+	if !back.Name.ExactEqual(user.Name) || !back.Age.ExactEqual(user.Age) || back.Admin != BoolFrom(false) || back.Home != nil {
+		t.Errorf("round trip = %#v", back)
+	}
+
+	user.Home = &nullAddress{Zip: StringFrom("75001")}
+	err = ToPtrStruct(&user, &out)
+	maybePanic(err)
+	if out.Home == nil || out.Home.Zip == nil || *out.Home.Zip != "75001" || out.Home.City != nil {
+		t.Errorf("ToPtrStruct() nested = %+v", out.Home)
+	}
+}
+
+func TestPtrStructErrors(t *testing.T) {
+	type missing struct {
+		Nickname String
+	}
+	type mismatch struct {
+		Name Int64
+	}
+	type nested struct {
+		Name nullAddress
+	}
+	var user nullUser
+	for _, test := range []struct {
+		src, dst interface{}
+	}{
+		{ptrUser{}, user},
+		{ptrUser{}, (*nullUser)(nil)},
+		{3, &user},
+		{ptrUser{}, &missing{}},
+		{ptrUser{}, &mismatch{}},
+		{ptrUser{}, &nested{}},
+	} {
+		if err := FromPtrStruct(test.src, test.dst); err == nil {
+			t.Errorf("FromPtrStruct(%T, %T) should fail", test.src, test.dst)
+		}
+	}
+	if err := ToPtrStruct(&mismatch{Name: Int64From(1)}, &ptrUser{}); err == nil {
+		t.Error("ToPtrStruct() of mismatched types should fail")
+	}
+
+	m := missing{Nickname: StringFrom("x")}
+	if err := FromPtrStruct(ptrUser{}, &m); err == nil || m.Nickname.String != "x" {
+		t.Errorf("a failed FromPtrStruct() should leave dst alone: %v, %v", m, err)
+	}
+}
+
+func BenchmarkFromPtrStruct(b *testing.B) {
+	name, age := "Alice", int64(42)
+	src := &ptrUser{Name: &name, Age: &age, Home: &ptrAddress{City: &name}}
+	var user nullUser
+	for i := 0; i < b.N; i++ {
+		if err := FromPtrStruct(src, &user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
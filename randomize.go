@@ -0,0 +1,173 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The Randomize methods implement the Randomizer interface of sqlboiler's
+// randomize package, which fills the fixtures of generated model tests, so
+// the package needs no sqlboiler dependency. When shouldBeNull is true the
+// value becomes an explicit null, with Set true. Otherwise it is valid and
+// derived from nextInt, which sqlboiler increments on every call so that
+// unique columns don't collide. fieldType is the database column type, such
+// as "uuid" or "enum('a','b')", and picks a matching format where the Go
+// type alone can't.
+
+// randomizer matches sqlboiler's randomize.Randomizer, which Val uses for T
+// when T implements it.
+type randomizer interface {
+	Randomize(nextInt func() int64, fieldType string, shouldBeNull bool)
+}
+
+const randomAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// randomN returns a value in [0, n) taken from nextInt.
+func randomN(nextInt func() int64, n int64) int64 {
+	v := nextInt() % n
+	if v < 0 {
+		v += n
+	}
+	return v
+}
+
+// randomString returns n letters and digits.
+func randomString(nextInt func() int64, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randomAlphabet[randomN(nextInt, int64(len(randomAlphabet)))]
+	}
+	return string(b)
+}
+
+// randomBytes returns n arbitrary bytes, printable or not.
+func randomBytes(nextInt func() int64, n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(randomN(nextInt, 256))
+	}
+	return b
+}
+
+// randomFloat returns a number with one decimal below 10, like sqlboiler
+// does for plain float fields.
+func randomFloat(nextInt func() int64) float64 {
+	return float64(randomN(nextInt, 10))/10 + float64(randomN(nextInt, 10))
+}
+
+// randomDate returns midnight UTC on a day between 1972 and 2031, which
+// every database stores without loss, so fixtures compare equal after a
+// round trip.
+func randomDate(nextInt func() int64) time.Time {
+	return time.Date(int(1972+randomN(nextInt, 60)), time.Month(1+randomN(nextInt, 12)), int(1+randomN(nextInt, 28)), 0, 0, 0, 0, time.UTC)
+}
+
+// randomUUID returns a version 4 UUID whose last bytes hold a value from
+// nextInt, so sequential calls never repeat.
+func randomUUID(nextInt func() int64) [16]byte {
+	var u [16]byte
+	copy(u[:8], randomBytes(nextInt, 8))
+	n := uint64(nextInt())
+	for i := 15; i >= 8; i-- {
+		u[i] = byte(n)
+		n >>= 8
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// randomFormatted returns a random value in the text format of column
+// types that a string holds but that the database parses, and reports
+// whether fieldType is one of them.
+func randomFormatted(nextInt func() int64, fieldType string) (string, bool) {
+	if strings.HasPrefix(fieldType, "enum") {
+		values := enumFieldValues(fieldType)
+		if len(values) == 0 {
+			panic(fmt.Sprintf("null: cannot parse the values of enum column type %q", fieldType))
+		}
+		return values[randomN(nextInt, int64(len(values)))], true
+	}
+
+	switch fieldType {
+	case "json", "jsonb":
+		return `"` + randomString(nextInt, 1) + `"`, true
+	case "interval":
+		return fmt.Sprintf("%d days", 2+randomN(nextInt, 26)), true
+	case "uuid":
+		return formatUUID(randomUUID(nextInt)), true
+	case "cidr", "inet":
+		return fmt.Sprintf("%d.%d.%d.%d", 1+randomN(nextInt, 254), 1+randomN(nextInt, 254), 1+randomN(nextInt, 254), 1+randomN(nextInt, 254)), true
+	case "macaddr":
+		b := randomBytes(nextInt, 6)
+		b[0] = b[0]&^1 | 2 // a locally administered unicast address
+		return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4], b[5]), true
+	case "money":
+		return fmt.Sprintf("%d.00", randomN(nextInt, 100000)), true
+	case "time":
+		return fmt.Sprintf("%02d:%02d:%02d", randomN(nextInt, 24), randomN(nextInt, 60), randomN(nextInt, 60)), true
+	}
+	return "", false
+}
+
+// enumFieldValues returns the values of an enum column type in sqlboiler's
+// form, such as enum('a','b') or enum.status('a','b').
+func enumFieldValues(fieldType string) []string {
+	open, end := strings.IndexByte(fieldType, '('), strings.LastIndexByte(fieldType, ')')
+	if open < 0 || end < open {
+		return nil
+	}
+	var values []string
+	for _, v := range strings.Split(fieldType[open+1:end], ",") {
+		v = strings.TrimSpace(v)
+		if len(v) < 2 || v[0] != '\'' || v[len(v)-1] != '\'' {
+			return nil
+		}
+		values = append(values, v[1:len(v)-1])
+	}
+	return values
+}
+
+// randomizeValue sets rv, which must be settable, to a random value if it
+// has a basic kind, is a time.Time or is a struct of such fields, and
+// reports whether it did.
+func randomizeValue(rv reflect.Value, nextInt func() int64, fieldType string) bool {
+	if rv.Type() == reflect.TypeOf(time.Time{}) {
+		rv.Set(reflect.ValueOf(randomDate(nextInt)))
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(randomN(nextInt, math.MaxInt64>>(64-rv.Type().Bits())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		rv.SetUint(uint64(randomN(nextInt, math.MaxInt64>>(64-rv.Type().Bits()))))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(randomFloat(nextInt))
+	case reflect.String:
+		str, ok := randomFormatted(nextInt, fieldType)
+		if !ok {
+			str = randomString(nextInt, 1)
+		}
+		rv.SetString(str)
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if r, ok := field.Addr().Interface().(randomizer); ok {
+				r.Randomize(nextInt, "", false)
+			} else if !randomizeValue(field, nextInt, "") {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
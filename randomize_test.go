@@ -0,0 +1,122 @@
+package null
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// counter returns a nextInt function like sqlboiler's Seed.NextInt.
+func counter(start int64) func() int64 {
+	return func() int64 {
+		start++
+		return start
+	}
+}
+
+func TestRandomize(t *testing.T) {
+	nextInt := counter(time.Now().Unix())
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[2])
+		for i := 0; i < 10; i++ {
+			ptr := reflect.New(typ)
+			ptr.Interface().(randomizer).Randomize(nextInt, "", false)
+			v := ptr.Elem()
+			if !v.FieldByName("Valid").Bool() || !v.FieldByName("Set").Bool() {
+				t.Fatalf("%v.Randomize() = %#v, want a valid value", typ, v.Interface())
+			}
+			// The value must survive the trip through the database.
+			dv, err := v.Interface().(driver.Valuer).Value()
+			maybePanic(err)
+			back := reflect.New(typ)
+			if err := back.Interface().(interface{ Scan(interface{}) error }).Scan(dv); err != nil {
+				t.Errorf("%v.Randomize() = %#v, which doesn't scan back: %v", typ, v.Interface(), err)
+			}
+
+			ptr = reflect.New(typ)
+			ptr.Interface().(randomizer).Randomize(nextInt, "", true)
+			if v := ptr.Elem(); v.FieldByName("Valid").Bool() || !v.FieldByName("Set").Bool() {
+				t.Errorf("%v.Randomize(shouldBeNull) = %#v, want a set null", typ, v.Interface())
+			}
+		}
+	}
+}
+
+func TestRandomizeValues(t *testing.T) {
+	nextInt := counter(1 << 40)
+	for i := 0; i < 100; i++ {
+		var b Byte
+		b.Randomize(nextInt, "", false)
+		if !strings.ContainsRune(randomAlphabet, rune(b.Byte)) {
+			t.Errorf("Byte.Randomize() = %q, want a letter or digit", b.Byte)
+		}
+
+		var tm Time
+		tm.Randomize(nextInt, "", false)
+		if y := tm.Time.Year(); y < 1972 || y > 2031 || tm.Time.Location() != time.UTC || !tm.Time.Equal(tm.Time.Truncate(24*time.Hour)) {
+			t.Errorf("Time.Randomize() = %v, want midnight UTC between 1972 and 2031", tm.Time)
+		}
+
+		var medium Int32
+		medium.Randomize(nextInt, "mediumint", false)
+		if medium.Int32 < 0 || medium.Int32 >= 1<<23 {
+			t.Errorf("Int32.Randomize(mediumint) = %d, out of range", medium.Int32)
+		}
+
+		var id String
+		id.Randomize(nextInt, "uuid", false)
+		if _, err := UUIDFromString(id.String); err != nil {
+			t.Errorf("String.Randomize(uuid) = %q: %v", id.String, err)
+		}
+
+		var mood String
+		mood.Randomize(nextInt, "enum.mood('happy','sad')", false)
+		if mood.String != "happy" && mood.String != "sad" {
+			t.Errorf("String.Randomize(enum) = %q, want happy or sad", mood.String)
+		}
+
+		var e Enum[testStatus]
+		e.Randomize(nextInt, "", false)
+		if e.Enum != statusActive && e.Enum != statusBlocked {
+			t.Errorf("Enum.Randomize() = %q, want a registered value", e.Enum)
+		}
+	}
+
+	var u1, u2 UUID
+	u1.Randomize(nextInt, "", false)
+	u2.Randomize(nextInt, "", false)
+	if u1 == u2 {
+		t.Errorf("UUID.Randomize() repeated %v", u1)
+	}
+
+	var p Val[time.Time]
+	p.Randomize(nextInt, "", false)
+	if !p.Valid || p.Val.IsZero() {
+		t.Errorf("Val[time.Time].Randomize() = %#v, want a valid time", p)
+	}
+}
+
+func TestRandomizePanics(t *testing.T) {
+	type unregistered string
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Enum without values", func() { new(Enum[unregistered]).Randomize(counter(0), "", false) }},
+		{"Val of a slice", func() { new(Val[[]int]).Randomize(counter(0), "", false) }},
+		{"Val of a struct with a map", func() { new(Val[struct{ M map[string]int }]).Randomize(counter(0), "", false) }},
+		{"unparsable enum column", func() { new(String).Randomize(counter(0), "enum", false) }},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Randomize should panic", test.name)
+				}
+			}()
+			test.fn()
+		}()
+	}
+}
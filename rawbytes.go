@@ -0,0 +1,489 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/sisoftrg/null/convert"
+)
+
+// RawBytes is a nullable []byte that is encoded in JSON as an ordinary
+// string holding the bytes verbatim, rather than base64 like Bytes.
+// It is meant for short textual payloads stored in binary columns.
+type RawBytes struct {
+	RawBytes []byte
+	Valid    bool
+	Set      bool
+}
+
+// NewRawBytes creates a new RawBytes. It shares b with the caller
+// instead of copying it; see Clone.
+func NewRawBytes(b []byte, valid bool) RawBytes {
+	return RawBytes{
+		RawBytes: b,
+		Valid:    valid,
+		Set:      true,
+	}
+}
+
+// RawBytesFrom creates a new RawBytes that will be invalid if nil.
+func RawBytesFrom(b []byte) RawBytes {
+	return NewRawBytes(b, b != nil)
+}
+
+// RawBytesFromPtr creates a new RawBytes that will be invalid if nil.
+func RawBytesFromPtr(b *[]byte) RawBytes {
+	if b == nil {
+		return NewRawBytes(nil, false)
+	}
+	return NewRawBytes(*b, true)
+}
+
+// RawBytesFromNonEmpty creates a new RawBytes that will be null if b is empty.
+func RawBytesFromNonEmpty(b []byte) RawBytes {
+	return NewRawBytes(b, len(b) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (b RawBytes) IsValid() bool {
+	return b.Set && b.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (b RawBytes) IsSet() bool {
+	return b.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (b RawBytes) IsNull() bool {
+	return b.Set && !b.Valid
+}
+
+// Equal reports whether b and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (b RawBytes) Equal(other RawBytes) bool {
+	if !b.Valid || !other.Valid {
+		return b.Valid == other.Valid
+	}
+	return bytes.Equal(b.RawBytes, other.RawBytes)
+}
+
+// ExactEqual is like Equal, but also requires b and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (b RawBytes) ExactEqual(other RawBytes) bool {
+	return b.Set == other.Set && b.Equal(other)
+}
+
+// Clone returns a copy of b with its own copy of the bytes, so that
+// changes to either do not show in the other.
+func (b RawBytes) Clone() RawBytes {
+	b.RawBytes = bytes.Clone(b.RawBytes)
+	return b
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// The JSON string is stored as is, without base64 decoding.
+func (b *RawBytes) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.RawBytes", b, data)
+	b.Set = true
+
+	if bytes.Equal(data, NullBytes) {
+		b.Valid = false
+		b.RawBytes = nil
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if err := checkLen("null.RawBytes", MaxBytesLen, len(s)); err != nil {
+		b.RawBytes, b.Valid = nil, false
+		return err
+	}
+
+	b.RawBytes = []byte(s)
+	b.Valid = true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *RawBytes) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.RawBytes", text)
+	b.Set = true
+	if err := checkLen("null.RawBytes", MaxBytesLen, len(text)); err != nil {
+		b.RawBytes, b.Valid = nil, false
+		return err
+	}
+	if len(text) == 0 {
+		b.RawBytes = nil
+		b.Valid = false
+	} else {
+		b.RawBytes = append(b.RawBytes[0:0], text...)
+		b.Valid = true
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It returns an error if the bytes are not valid UTF-8, since they could not
+// be represented faithfully in a JSON string.
+func (b RawBytes) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return NullBytes, nil
+	}
+	return b.AppendJSON(make([]byte, 0, len(b.RawBytes)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to buf.
+func (b RawBytes) AppendJSON(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return append(buf, "null"...), nil
+	}
+	if !utf8.Valid(b.RawBytes) {
+		return buf, fmt.Errorf("null: cannot marshal invalid UTF-8 %q in null.RawBytes", b.RawBytes)
+	}
+	return appendJSONString(buf, b.RawBytes), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b RawBytes) MarshalText() ([]byte, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.RawBytes, nil
+}
+
+// AppendText implements encoding.TextAppender, appending the bytes that
+// MarshalText returns, without copying, to buf. Nothing is appended if b
+// is null.
+func (b RawBytes) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return buf, nil
+	}
+	return append(buf, b.RawBytes...), nil
+}
+
+// SetValid changes this RawBytes's value and also sets it to be non-null.
+func (b *RawBytes) SetValid(n []byte) {
+	b.RawBytes = n
+	b.Valid = true
+	b.Set = true
+}
+
+// SetNull sets this RawBytes to an explicit null, zeroing its value.
+func (b *RawBytes) SetNull() {
+	*b = RawBytes{Set: true}
+}
+
+// Unset resets this RawBytes to the zero value, as if it was never set.
+func (b *RawBytes) Unset() {
+	*b = RawBytes{}
+}
+
+// SetPtr changes this RawBytes to the value p points to, or to an explicit
+// null if p is nil.
+func (b *RawBytes) SetPtr(p *[]byte) {
+	*b = RawBytesFromPtr(p)
+}
+
+// Ptr returns a pointer to this RawBytes's value, or a nil pointer if this RawBytes is null.
+func (b RawBytes) Ptr() *[]byte {
+	if !b.Valid {
+		return nil
+	}
+	return &b.RawBytes
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (b RawBytes) ValueOrZero() []byte {
+	if !b.Valid {
+		return nil
+	}
+	return b.RawBytes
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (b RawBytes) Or(def []byte) []byte {
+	if !b.Valid {
+		return def
+	}
+	return b.RawBytes
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (b RawBytes) Val() []byte {
+	return b.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (b RawBytes) Get() ([]byte, bool) {
+	return b.ValueOrZero(), b.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if b is
+// null.
+func (b RawBytes) MustGet() []byte {
+	if !b.Valid {
+		panic(&NullError{Type: "null.RawBytes"})
+	}
+	return b.RawBytes
+}
+
+// IsZero returns true if this RawBytes was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (b RawBytes) IsZero() bool {
+	return !b.Set && !b.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this RawBytes is null, and the text form otherwise.
+func (b RawBytes) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	data, err := b.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// RawBytes if the input is null or ~.
+func (b *RawBytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	b.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		b.RawBytes, b.Valid = nil, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		b.RawBytes, b.Valid = nil, false
+		return err
+	}
+	return b.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this RawBytes is null.
+func (b RawBytes) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null RawBytes.
+func (b *RawBytes) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, b.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this RawBytes is null.
+func (b RawBytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, b.Valid, b.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null RawBytes.
+func (b *RawBytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this RawBytes is null.
+func (b RawBytes) MarshalBSONValue() (byte, []byte, error) {
+	if !b.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(b.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (b *RawBytes) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.RawBytes", b.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this RawBytes is null.
+func (b RawBytes) MarshalMsgpack() ([]byte, error) {
+	if !b.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, b.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (b *RawBytes) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.RawBytes", b.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this RawBytes is null.
+func (b RawBytes) MarshalCBOR() ([]byte, error) {
+	if !b.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, b.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (b *RawBytes) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.RawBytes", b.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (b RawBytes) MarshalGQL(w io.Writer) {
+	marshalGQL(w, b.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null RawBytes.
+func (b *RawBytes) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, b.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this RawBytes is
+// null.
+func (b RawBytes) MarshalCSV() (string, error) {
+	return marshalCSV(b.Valid, b.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null RawBytes.
+func (b *RawBytes) UnmarshalCSV(cell string) error {
+	return b.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this RawBytes is null.
+func (b RawBytes) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(b.Valid, b.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null RawBytes.
+func (b *RawBytes) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, b.UnmarshalText, b.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random letter or digit, or an explicit null if shouldBeNull is true.
+func (b *RawBytes) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*b = NewRawBytes(nil, false)
+		return
+	}
+	b.SetValid([]byte(randomString(nextInt, 1)))
+}
+
+// Generate implements testing/quick's Generator.
+func (b RawBytes) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewRawBytes(nil, false), func() RawBytes {
+		return RawBytesFrom([]byte(generateText(rand, size)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (b RawBytes) MarshalBinary() ([]byte, error) {
+	return marshalBinary(b.Set, b.Valid, func() ([]byte, error) {
+		return b.RawBytes, nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *RawBytes) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.RawBytes")
+	if err != nil {
+		return err
+	}
+	var v []byte
+	if valid {
+		v = append([]byte{}, p...)
+	}
+	b.RawBytes, b.Valid, b.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (b *RawBytes) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.RawBytes", value)
+	if value == nil {
+		b.RawBytes, b.Valid, b.Set = nil, false, true
+		return nil
+	}
+	b.Valid, b.Set = true, true
+	if err := convert.ConvertAssign(&b.RawBytes, value); err != nil {
+		return err
+	}
+	if err := checkLen("null.RawBytes", MaxBytesLen, len(b.RawBytes)); err != nil {
+		b.RawBytes, b.Valid = nil, false
+		return err
+	}
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (b RawBytes) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.RawBytes, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (b RawBytes) String() string {
+	return fmt.Sprint(b)
+}
+
+// GoString implements the GoStringer interface, formatting b as the Go
+// expression that builds it.
+func (b RawBytes) GoString() string {
+	return goString("RawBytes", "", "RawBytes", goBytes(b.RawBytes), b.RawBytes == nil, b.Valid, b.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// b with the verb if it is valid.
+func (b RawBytes) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, b, b.Valid, b.Set, b.RawBytes)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the bytes, cut off after MaxLogValueLen
+// bytes, or to nil if this RawBytes is null.
+func (b RawBytes) LogValue() slog.Value {
+	if !b.Valid {
+		return logNull
+	}
+	return logBytes(b.RawBytes, true, rawText)
+}
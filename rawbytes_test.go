@@ -0,0 +1,135 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestRawBytesFrom(t *testing.T) {
+	i := RawBytesFrom(hello)
+	assertRawBytes(t, i, "RawBytesFrom()")
+
+	zero := RawBytesFrom(nil)
+	if zero.Valid {
+		t.Error("RawBytesFrom(nil)", "is valid, but should be invalid")
+	}
+
+	zero = RawBytesFrom([]byte{})
+	if !zero.Valid {
+		t.Error("RawBytesFrom([]byte{})", "is invalid, but should be valid")
+	}
+}
+
+func TestRawBytesFromPtr(t *testing.T) {
+	n := hello
+	i := RawBytesFromPtr(&n)
+	assertRawBytes(t, i, "RawBytesFromPtr()")
+
+	null := RawBytesFromPtr(nil)
+	assertNullRawBytes(t, null, "RawBytesFromPtr(nil)")
+}
+
+func TestUnmarshalRawBytes(t *testing.T) {
+	var i RawBytes
+	err := json.Unmarshal(bytesJSON, &i)
+	maybePanic(err)
+	assertRawBytes(t, i, "raw json")
+
+	var b64 RawBytes
+	err = json.Unmarshal(b64BytesJSON, &b64)
+	maybePanic(err)
+	if string(b64.RawBytes) != "aGVsbG8=" {
+		t.Errorf("base64 should not be decoded: %s", b64.RawBytes)
+	}
+
+	var null RawBytes
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullRawBytes(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType RawBytes
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullRawBytes(t, badType, "wrong type json")
+}
+
+func TestMarshalRawBytes(t *testing.T) {
+	i := RawBytesFrom(hello)
+	data, err := json.Marshal(i)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"hello"`, "non-empty json marshal")
+
+	data, err = json.Marshal(RawBytesFrom([]byte(`a "quoted" €`)))
+	maybePanic(err)
+	assertJSONEquals(t, data, `"a \"quoted\" €"`, "escaped json marshal")
+
+	null := NewRawBytes(nil, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	_, err = json.Marshal(RawBytesFrom([]byte{0xff, 0xfe}))
+	if err == nil {
+		t.Error("expected error for invalid UTF-8")
+	}
+}
+
+func TestRawBytesRoundTrip(t *testing.T) {
+	for _, in := range []RawBytes{RawBytesFrom(hello), RawBytesFrom([]byte{}), NewRawBytes(nil, false)} {
+		data, err := json.Marshal(in)
+		maybePanic(err)
+
+		var out RawBytes
+		err = json.Unmarshal(data, &out)
+		maybePanic(err)
+		if out.Valid != in.Valid || !bytes.Equal(out.RawBytes, in.RawBytes) {
+			t.Errorf("bad round trip of %v: got %v", in, out)
+		}
+	}
+}
+
+func TestRawBytesScan(t *testing.T) {
+	var i RawBytes
+	err := i.Scan(hello)
+	maybePanic(err)
+	assertRawBytes(t, i, "Scan() []byte")
+
+	var null RawBytes
+	err = null.Scan(nil)
+	maybePanic(err)
+	assertNullRawBytes(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+}
+
+func assertRawBytes(t *testing.T, i RawBytes, from string) {
+	if !bytes.Equal(i.RawBytes, hello) {
+		t.Errorf("bad %s []byte: %v ≠ %v\n", from, string(i.RawBytes), "hello")
+	}
+	if !i.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullRawBytes(t *testing.T, i RawBytes, from string) {
+	if i.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestRawBytesClone(t *testing.T) {
+	buf := []byte("hello")
+	b := RawBytesFrom(buf)
+	clone := b.Clone()
+	buf[0] = 'j'
+	if string(clone.RawBytes) != "hello" || !clone.Valid || !clone.Set {
+		t.Errorf("Clone() after mutating original = %#v", clone)
+	}
+}
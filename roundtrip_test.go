@@ -0,0 +1,202 @@
+package null
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// The canonical encodings: JSON null and empty text are null, and every
+// other encoding is a valid value. A valid value whose encoding is that of
+// null, such as a NaN in JSON or an empty String in text, comes back null,
+// and an unset value, which marshals as null, comes back as a set null.
+
+// roundTrip is a form that a value can be encoded to and decoded from.
+type roundTrip struct {
+	name   string
+	null   string
+	encode func(v interface{}) ([]byte, bool, error)
+	decode func(data []byte, ptr interface{}) error
+}
+
+var roundTrips = []roundTrip{
+	{
+		name: "JSON",
+		null: "null",
+		encode: func(v interface{}) ([]byte, bool, error) {
+			data, err := json.Marshal(v)
+			return data, true, err
+		},
+		decode: json.Unmarshal,
+	},
+	{
+		name: "text",
+		encode: func(v interface{}) ([]byte, bool, error) {
+			m, ok := v.(encoding.TextMarshaler)
+			if !ok {
+				return nil, false, nil
+			}
+			text, err := m.MarshalText()
+			return text, true, err
+		},
+		decode: func(text []byte, ptr interface{}) error {
+			return ptr.(encoding.TextUnmarshaler).UnmarshalText(text)
+		},
+	},
+}
+
+// exactEqual calls the ExactEqual method of a with b, taking two values
+// that print the same Go expression, such as two NaNs, as equal too.
+func exactEqual(a, b reflect.Value) bool {
+	return a.MethodByName("ExactEqual").Call([]reflect.Value{b})[0].Bool() ||
+		fmt.Sprintf("%#v", a.Interface()) == fmt.Sprintf("%#v", b.Interface())
+}
+
+// checkRoundTrip decodes what v encodes to in form and checks the result
+// against the canonical encodings.
+func checkRoundTrip(t *testing.T, form roundTrip, v interface{}) {
+	t.Helper()
+	data, ok, err := form.encode(v)
+	if !ok {
+		return
+	}
+	if err != nil {
+		t.Errorf("%s of %#v: %v", form.name, v, err)
+		return
+	}
+	back := reflect.New(reflect.TypeOf(v))
+	if err := form.decode(data, back.Interface()); err != nil {
+		t.Errorf("%s of %#v: cannot decode %q: %v", form.name, v, data, err)
+		return
+	}
+	want := reflect.ValueOf(v)
+	if !want.Interface().(Value).IsValid() || string(data) == form.null {
+		want = reflect.New(want.Type())
+		want.Interface().(interface{ SetNull() }).SetNull()
+		want = want.Elem()
+	}
+	if !exactEqual(back.Elem(), want) {
+		t.Errorf("%s of %#v: %q decodes to %#v", form.name, v, data, back.Elem().Interface())
+	}
+}
+
+func TestRoundTripStates(t *testing.T) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		t.Run(typ.Name(), func(t *testing.T) {
+			rand := rand.New(rand.NewSource(1))
+			values := states[:]
+			for i := 0; i < 200; i++ {
+				values = append(values, states[0].(generator).Generate(rand, 10).Interface())
+			}
+			for _, form := range roundTrips {
+				for _, v := range values {
+					checkRoundTrip(t, form, v)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTripEdges(t *testing.T) {
+	for _, v := range []interface{}{
+		BytesFrom([]byte{}),
+		NewBytes([]byte("stale"), false),
+		HexBytesFrom([]byte{}),
+		NewHexBytes([]byte("stale"), false),
+		RawBytesFrom([]byte{}),
+		JSONFrom([]byte(`null`)),
+		StringFrom(""),
+		ByteFrom(0),
+		ByteFrom('"'),
+		RuneFrom(0),
+		Float64From(math.NaN()),
+		Float32From(float32(math.Inf(-1))),
+		Float64From(math.Copysign(0, -1)),
+		MapFrom(nil),
+		MapFrom(map[string]interface{}{}),
+		StringSliceFrom(nil),
+		StringSliceFrom([]string{""}),
+		Int64SliceFrom([]int64{}),
+		URLFrom(&url.URL{}),
+		EnumFrom(testStatus("")),
+		SliceFrom[int](nil),
+		MapOfFrom(map[string]int{}),
+		ValFrom[*int](nil),
+		ValFrom(""),
+		JSONOfFrom[*int](nil),
+		DurationFrom(0),
+		TimeOfDayFrom(0),
+	} {
+		for _, form := range roundTrips {
+			checkRoundTrip(t, form, v)
+		}
+	}
+}
+
+// fuzzRoundTrip decodes data in form into each type, and checks that what
+// decodes encodes to a fixed point: encoding it, decoding that and
+// encoding again gives the same bytes and an equal value, unless the value
+// encoded as null.
+func fuzzRoundTrip(t *testing.T, form roundTrip, data []byte) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		first := reflect.New(typ)
+		if _, ok := first.Interface().(encoding.TextUnmarshaler); !ok && form.name == "text" {
+			continue
+		}
+		if form.decode(data, first.Interface()) != nil {
+			continue
+		}
+		enc1, _, err := form.encode(first.Elem().Interface())
+		if err != nil {
+			// Decoding accepts some values that cannot be encoded, such as
+			// a Rune for an unpaired surrogate escape.
+			continue
+		}
+		second := reflect.New(typ)
+		if err := form.decode(enc1, second.Interface()); err != nil {
+			t.Fatalf("%s: %q decodes to %#v, which encodes to %q that does not decode: %v",
+				typ, data, first.Elem().Interface(), enc1, err)
+		}
+		enc2, _, err := form.encode(second.Elem().Interface())
+		if err != nil || !bytes.Equal(enc1, enc2) {
+			t.Fatalf("%s: %q encodes to %q, then to %q, %v", typ, data, enc1, enc2, err)
+		}
+		if string(enc1) != form.null && !exactEqual(first.Elem(), second.Elem()) {
+			t.Fatalf("%s: %q decodes to %#v, but its encoding %q to %#v",
+				typ, data, first.Elem().Interface(), enc1, second.Elem().Interface())
+		}
+	}
+}
+
+func FuzzJSONRoundTrip(f *testing.F) {
+	for _, seed := range []string{
+		`null`, `""`, `"x"`, `0`, `-0`, `1e400`, `"NaN"`, `"42"`, `true`, `"2012-12-21T21:21:21.000000001Z"`,
+		`"15:04:05.000006"`, `"deadbeef"`, `"3q2+7w=="`, `{"a":1}`, `[1,-2]`, `{"X":1,"Y":2}`, `"ÿ"`,
+		`"https://example.com/a?b=c"`, `"10.0.0.0/8"`, `"00000000-0000-0000-0000-000000000000"`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, roundTrips[0], data)
+	})
+}
+
+func FuzzTextRoundTrip(f *testing.F) {
+	for _, seed := range []string{
+		``, `x`, `0`, `-0`, `NaN`, `true`, `2012-12-21T21:21:21.000000001Z`, `15:04:05`, `1h30m`,
+		`deadbeef`, `3q2+7w==`, `1.10`, `fe80::1%eth0`, `https://example.com/`, "\x00", `"`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzRoundTrip(t, roundTrips[1], data)
+	})
+}
@@ -0,0 +1,526 @@
+package null
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Rune is a nullable rune, for single-character text that may be outside
+// ASCII, such as a CHAR(1) column holding '€'.
+type Rune struct {
+	Rune  rune
+	Valid bool
+	Set   bool
+}
+
+// NewRune creates a new Rune
+func NewRune(r rune, valid bool) Rune {
+	return Rune{
+		Rune:  r,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// RuneFrom creates a new Rune that will always be valid.
+func RuneFrom(r rune) Rune {
+	return NewRune(r, true)
+}
+
+// RuneFromPtr creates a new Rune that be null if r is nil.
+func RuneFromPtr(r *rune) Rune {
+	if r == nil {
+		return NewRune(0, false)
+	}
+	return NewRune(*r, true)
+}
+
+// RuneFromNonZero creates a new Rune that will be null if r is 0.
+func RuneFromNonZero(r rune) Rune {
+	return NewRune(r, r != 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (r Rune) IsValid() bool {
+	return r.Set && r.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (r Rune) IsSet() bool {
+	return r.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (r Rune) IsNull() bool {
+	return r.Set && !r.Valid
+}
+
+// Equal reports whether r and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (r Rune) Equal(other Rune) bool {
+	if !r.Valid || !other.Valid {
+		return r.Valid == other.Valid
+	}
+	return r.Rune == other.Rune
+}
+
+// ExactEqual is like Equal, but also requires r and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (r Rune) ExactEqual(other Rune) bool {
+	return r.Set == other.Set && r.Equal(other)
+}
+
+// Compare returns -1 if r is less than other, 0 if they are equal and +1
+// if r is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (r Rune) Compare(other Rune) int {
+	if !r.Valid || !other.Valid {
+		return compareNull(r.Valid, other.Valid)
+	}
+	return cmp.Compare(r.Rune, other.Rune)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports one-character strings and null. An empty string is null.
+// Lone surrogate escapes such as "\ud800" are rejected.
+func (r *Rune) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Rune", r, data)
+	r.Set = true
+	if len(data) == 0 || bytes.Equal(data, NullBytes) {
+		r.Rune, r.Valid = 0, false
+		return nil
+	}
+
+	var x string
+	if err := json.Unmarshal(data, &x); err != nil {
+		r.Rune, r.Valid = 0, false
+		return err
+	}
+	// encoding/json replaces invalid UTF-8 and lone surrogates with
+	// U+FFFD, so that is only accepted when the input really spelled it.
+	if inner := data[1 : len(data)-1]; x == string(utf8.RuneError) &&
+		!bytes.Equal(inner, []byte(x)) && !bytes.EqualFold(inner, []byte(`\ufffd`)) {
+		r.Rune, r.Valid = 0, false
+		return fmt.Errorf("json: cannot unmarshal %s into null.Rune: invalid UTF-8 or lone surrogate", data)
+	}
+	return r.UnmarshalText([]byte(x))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null Rune if the input is a blank string.
+func (r *Rune) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Rune", text)
+	r.Set = true
+	if len(text) == 0 {
+		r.Rune, r.Valid = 0, false
+		return nil
+	}
+	res, err := decodeSingleRune(text)
+	r.Rune, r.Valid = res, err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It is an error to marshal a rune that is not valid Unicode.
+func (r Rune) MarshalJSON() ([]byte, error) {
+	if !r.Valid {
+		return NullBytes, nil
+	}
+	return r.AppendJSON(make([]byte, 0, 8))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (r Rune) AppendJSON(b []byte) ([]byte, error) {
+	if !r.Valid {
+		return append(b, "null"...), nil
+	}
+	if !utf8.ValidRune(r.Rune) {
+		return b, fmt.Errorf("null: cannot marshal invalid rune %U", r.Rune)
+	}
+	var buf [utf8.UTFMax]byte
+	return appendJSONString(b, buf[:utf8.EncodeRune(buf[:], r.Rune)]), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It is an error to marshal a rune that is not valid Unicode.
+func (r Rune) MarshalText() ([]byte, error) {
+	if !r.Valid {
+		return []byte{}, nil
+	}
+	return r.AppendText(make([]byte, 0, utf8.UTFMax))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if r is null.
+func (r Rune) AppendText(b []byte) ([]byte, error) {
+	if !r.Valid {
+		return b, nil
+	}
+	if !utf8.ValidRune(r.Rune) {
+		return b, fmt.Errorf("null: cannot marshal invalid rune %U", r.Rune)
+	}
+	return utf8.AppendRune(b, r.Rune), nil
+}
+
+// SetValid changes this Rune's value and also sets it to be non-null.
+func (r *Rune) SetValid(v rune) {
+	r.Rune = v
+	r.Valid = true
+	r.Set = true
+}
+
+// SetNull sets this Rune to an explicit null, zeroing its value.
+func (r *Rune) SetNull() {
+	*r = Rune{Set: true}
+}
+
+// Unset resets this Rune to the zero value, as if it was never set.
+func (r *Rune) Unset() {
+	*r = Rune{}
+}
+
+// SetPtr changes this Rune to the value p points to, or to an explicit
+// null if p is nil.
+func (r *Rune) SetPtr(p *rune) {
+	*r = RuneFromPtr(p)
+}
+
+// Ptr returns a pointer to this Rune's value, or a nil pointer if this Rune is null.
+func (r Rune) Ptr() *rune {
+	if !r.Valid {
+		return nil
+	}
+	return &r.Rune
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (r Rune) ValueOrZero() rune {
+	if !r.Valid {
+		return 0
+	}
+	return r.Rune
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (r Rune) Or(def rune) rune {
+	if !r.Valid {
+		return def
+	}
+	return r.Rune
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (r Rune) Val() rune {
+	return r.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (r Rune) Get() (rune, bool) {
+	return r.ValueOrZero(), r.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if r is
+// null.
+func (r Rune) MustGet() rune {
+	if !r.Valid {
+		panic(&NullError{Type: "null.Rune"})
+	}
+	return r.Rune
+}
+
+// IsZero returns true if this Rune was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (r Rune) IsZero() bool {
+	return !r.Set && !r.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Rune is null, and the text form otherwise.
+func (r Rune) MarshalYAML() (interface{}, error) {
+	if !r.Valid {
+		return nil, nil
+	}
+	data, err := r.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// Rune if the input is null or ~.
+func (r *Rune) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	r.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		r.Rune, r.Valid = 0, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		r.Rune, r.Valid = 0, false
+		return err
+	}
+	return r.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Rune is null.
+func (r Rune) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, r.Valid, r.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Rune.
+func (r *Rune) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, r.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Rune is null.
+func (r Rune) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, r.Valid, r.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Rune.
+func (r *Rune) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this Rune is null.
+func (r Rune) MarshalBSONValue() (byte, []byte, error) {
+	if !r.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(r.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (r *Rune) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.Rune", r.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this Rune is null.
+func (r Rune) MarshalMsgpack() ([]byte, error) {
+	if !r.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, r.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (r *Rune) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.Rune", r.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this Rune is null.
+func (r Rune) MarshalCBOR() ([]byte, error) {
+	if !r.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, r.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (r *Rune) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.Rune", r.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (r Rune) MarshalGQL(w io.Writer) {
+	marshalGQL(w, r.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Rune.
+func (r *Rune) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, r.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Rune is
+// null.
+func (r Rune) MarshalCSV() (string, error) {
+	return marshalCSV(r.Valid, r.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Rune.
+func (r *Rune) UnmarshalCSV(cell string) error {
+	return r.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this Rune is null.
+func (r Rune) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(r.Valid, r.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Rune.
+func (r *Rune) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, r.UnmarshalText, r.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random letter or digit, or an explicit null if shouldBeNull is true.
+func (r *Rune) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*r = NewRune(0, false)
+		return
+	}
+	r.SetValid(rune(randomString(nextInt, 1)[0]))
+}
+
+// Generate implements testing/quick's Generator.
+func (r Rune) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewRune(0, false), func() Rune {
+		return RuneFrom(generateRune(rand))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (r Rune) MarshalBinary() ([]byte, error) {
+	return marshalBinary(r.Set, r.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(r.Rune)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Rune) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Rune")
+	if err != nil {
+		return err
+	}
+	var v rune
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.Rune", math.MinInt32, math.MaxInt32); err != nil {
+			return err
+		}
+		v = rune(n)
+	}
+	r.Rune, r.Valid, r.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// Text must hold exactly one UTF-8 encoded character; an empty string is
+// null. An int64 is read as a code point.
+func (r *Rune) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Rune", value)
+	var res rune
+	switch x := value.(type) {
+	case nil:
+		r.Rune, r.Valid, r.Set = 0, false, true
+		return nil
+	case string:
+		return r.UnmarshalText([]byte(x))
+	case []byte:
+		return r.UnmarshalText(x)
+	case int64:
+		res = rune(x)
+		if int64(res) != x || !utf8.ValidRune(res) {
+			err = fmt.Errorf("null: cannot scan %d into null.Rune: not a valid code point", x)
+		}
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.Rune: %v", value, value)
+	}
+	r.Set = true
+	r.Valid = err == nil
+	if r.Valid {
+		r.Rune = res
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The rune is stored as a UTF-8 string.
+func (r Rune) Value() (driver.Value, error) {
+	if !r.Valid {
+		return nil, nil
+	}
+	if !utf8.ValidRune(r.Rune) {
+		return nil, fmt.Errorf("null: cannot store invalid rune %U", r.Rune)
+	}
+	return string(r.Rune), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (r Rune) String() string {
+	return fmt.Sprint(r)
+}
+
+// GoString implements the GoStringer interface, formatting r as the Go
+// expression that builds it.
+func (r Rune) GoString() string {
+	return goString("Rune", "", "Rune", strconv.QuoteRune(r.Rune), r.Rune == 0, r.Valid, r.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// r with the verb if it is valid.
+func (r Rune) Format(state fmt.State, verb rune) {
+	payload := interface{}(r.Rune)
+	if verb == 'v' || verb == 's' {
+		payload = string(r.Rune)
+	}
+	formatValue(state, verb, r, r.Valid, r.Set, payload)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the character, or to nil if this Rune is
+// null.
+func (r Rune) LogValue() slog.Value {
+	if !r.Valid {
+		return logNull
+	}
+	return slog.StringValue(string(r.Rune))
+}
+
+// decodeSingleRune decodes text that must hold exactly one valid UTF-8
+// encoded rune.
+func decodeSingleRune(text []byte) (rune, error) {
+	res, size := utf8.DecodeRune(text)
+	if res == utf8.RuneError && size <= 1 {
+		return 0, fmt.Errorf("null: cannot convert %q to null.Rune: invalid UTF-8", text)
+	}
+	if size != len(text) {
+		return 0, fmt.Errorf("null: cannot convert %q to null.Rune: text has more than one character", text)
+	}
+	return res, nil
+}
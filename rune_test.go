@@ -0,0 +1,197 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var (
+	runeJSON = []byte(`"€"`)
+)
+
+func TestRuneFrom(t *testing.T) {
+	r := RuneFrom('€')
+	assertRune(t, r, "RuneFrom()")
+
+	zero := RuneFrom(0)
+	if !zero.Valid {
+		t.Error("RuneFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestRuneFromPtr(t *testing.T) {
+	v := '€'
+	r := RuneFromPtr(&v)
+	assertRune(t, r, "RuneFromPtr()")
+
+	null := RuneFromPtr(nil)
+	assertNullRune(t, null, "RuneFromPtr(nil)")
+}
+
+func TestUnmarshalRune(t *testing.T) {
+	var r Rune
+	err := json.Unmarshal(runeJSON, &r)
+	maybePanic(err)
+	assertRune(t, r, "rune json")
+
+	tests := []struct {
+		in   string
+		want rune
+	}{
+		{`"a"`, 'a'},
+		{`"€"`, '€'},
+		{`"😀"`, '😀'},
+		{`"\ud83d\ude00"`, '😀'},
+		{`"\ufffd"`, '\uFFFD'},
+		{"\"\uFFFD\"", '\uFFFD'},
+	}
+	for _, test := range tests {
+		var r Rune
+		err := json.Unmarshal([]byte(test.in), &r)
+		maybePanic(err)
+		if !r.Valid || r.Rune != test.want {
+			t.Errorf("Unmarshal(%s) = %v, want %q", test.in, r, test.want)
+		}
+	}
+
+	var null Rune
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullRune(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty Rune
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullRune(t, empty, "empty string json")
+
+	for _, in := range []string{`"ab"`, `"€€"`, `"\ud800"`, "\"\xff\"", `1`} {
+		var bad Rune
+		if err := json.Unmarshal([]byte(in), &bad); err == nil {
+			t.Errorf("Unmarshal(%s) should fail", in)
+		}
+		assertNullRune(t, bad, "bad json")
+	}
+}
+
+func TestUnmarshalRuneText(t *testing.T) {
+	var r Rune
+	err := r.UnmarshalText([]byte("€"))
+	maybePanic(err)
+	assertRune(t, r, "UnmarshalText()")
+
+	var null Rune
+	err = null.UnmarshalText([]byte{})
+	maybePanic(err)
+	assertNullRune(t, null, "empty text")
+
+	for _, in := range [][]byte{[]byte("€a"), {0xe2, 0x82}, {0xed, 0xa0, 0x80}} {
+		var bad Rune
+		if err := bad.UnmarshalText(in); err == nil {
+			t.Errorf("UnmarshalText(%q) should fail", in)
+		}
+		assertNullRune(t, bad, "bad text")
+	}
+}
+
+func TestMarshalRune(t *testing.T) {
+	r := RuneFrom('€')
+	data, err := json.Marshal(r)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(runeJSON), "non-empty json marshal")
+
+	data, err = r.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "€", "non-empty text marshal")
+
+	null := NewRune(0, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	surrogate := RuneFrom(0xD800)
+	if _, err = json.Marshal(surrogate); err == nil {
+		t.Error("expected error marshaling a surrogate")
+	}
+	if _, err = surrogate.Value(); err == nil {
+		t.Error("expected error storing a surrogate")
+	}
+}
+
+func TestRuneScanValue(t *testing.T) {
+	for _, v := range []interface{}{"€", []byte("€"), int64('€')} {
+		var r Rune
+		err := r.Scan(v)
+		maybePanic(err)
+		assertRune(t, r, "scanned rune")
+		if !r.Set {
+			t.Error("should be Set")
+		}
+
+		value, err := r.Value()
+		maybePanic(err)
+		if value != "€" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	for _, v := range []interface{}{nil, ""} {
+		var null Rune
+		err := null.Scan(v)
+		maybePanic(err)
+		assertNullRune(t, null, "scanned null")
+		if !null.Set {
+			t.Error("should be Set")
+		}
+	}
+	null := NewRune(0, false)
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"€5", []byte{0xff}, int64(0xD800), int64(0x110000), float64(1)} {
+		var wrong Rune
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullRune(t, wrong, "scanned garbage")
+	}
+}
+
+func TestRunePointer(t *testing.T) {
+	r := RuneFrom('€')
+	ptr := r.Ptr()
+	if *ptr != '€' {
+		t.Errorf("bad %s rune: %#v ≠ %q\n", "pointer", ptr, '€')
+	}
+
+	null := NewRune(0, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s rune: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestRuneSetValid(t *testing.T) {
+	change := NewRune(0, false)
+	assertNullRune(t, change, "SetValid()")
+	change.SetValid('€')
+	assertRune(t, change, "SetValid()")
+}
+
+func assertRune(t *testing.T, r Rune, from string) {
+	if r.Rune != '€' {
+		t.Errorf("bad %s rune: %q ≠ %q\n", from, r.Rune, '€')
+	}
+	if !r.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullRune(t *testing.T, r Rune, from string) {
+	if r.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
@@ -0,0 +1,216 @@
+package null
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ScanStruct scans the current row of rows into dst, a pointer to a
+// struct, matching each result column to a field by its db tag or, if it
+// has none, its Go name:
+//
+//	for rows.Next() {
+//		var u User
+//		if err := null.ScanStruct(rows, &u); err != nil {
+//			return err
+//		}
+//	}
+//
+// A column matches the field with exactly its name, or failing that the
+// one whose name differs only in case, so an untagged Name field reads a
+// name column. Fields are walked as by SetColumns, embedded structs
+// included, except that a field of any type is scanned, with the
+// conversions of rows.Scan. Where an embedded struct and the outer one
+// have a field of the same name, the outer field wins, and between
+// embedded structs at the same depth the first one does.
+//
+// *dst is reset first, so the null types of fields whose column is not in
+// the result are unset, telling them apart from columns that were NULL.
+// Embedded nil pointers are allocated when one of their fields is scanned.
+// Columns that match no field are read and discarded, and a column name
+// appearing twice in the result is an error, as it is unclear which to
+// keep; alias one of them in the query. The matching is cached per struct
+// type and column list.
+func ScanStruct(rows *sql.Rows, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("null: cannot scan into %T, it is not a pointer to a struct", dst)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	plan, err := scanPlanFor(v.Elem().Type(), cols)
+	if err != nil {
+		return err
+	}
+	return plan.scan(rows, v.Elem())
+}
+
+// ScanAll scans every remaining row of rows into dst, a pointer to a slice
+// of structs or of pointers to structs, appending one element per row, as
+// ScanStruct does for one. It returns the error of rows.Err, if any, once
+// the rows are done; the caller still closes rows.
+func ScanAll(rows *sql.Rows, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("null: cannot scan into %T, it is not a pointer to a slice", dst)
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("null: cannot scan into %T, its elements are not structs", dst)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	plan, err := scanPlanFor(elem, cols)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		row := reflect.New(elem)
+		if err := plan.scan(rows, row.Elem()); err != nil {
+			return err
+		}
+		if !isPtr {
+			row = row.Elem()
+		}
+		slice.Set(reflect.Append(slice, row))
+	}
+	return rows.Err()
+}
+
+// scanPlan holds, for each result column, the index of the field it scans
+// into, or nil to discard it.
+type scanPlan struct {
+	fields [][]int
+}
+
+func (p *scanPlan) scan(rows *sql.Rows, v reflect.Value) error {
+	v.SetZero()
+	targets := make([]interface{}, len(p.fields))
+	for i, index := range p.fields {
+		if index == nil {
+			targets[i] = new(interface{})
+			continue
+		}
+		targets[i] = fieldAlloc(v, index).Addr().Interface()
+	}
+	return rows.Scan(targets...)
+}
+
+var scanPlans sync.Map // map[scanKey]scanPlanResult
+
+type scanKey struct {
+	t    reflect.Type
+	cols string
+}
+
+type scanPlanResult struct {
+	plan *scanPlan
+	err  error
+}
+
+func scanPlanFor(t reflect.Type, cols []string) (*scanPlan, error) {
+	key := scanKey{t, strings.Join(cols, "\x00")}
+	if r, ok := scanPlans.Load(key); ok {
+		return r.(scanPlanResult).plan, r.(scanPlanResult).err
+	}
+	plan, err := buildScanPlan(t, cols)
+	r, _ := scanPlans.LoadOrStore(key, scanPlanResult{plan, err})
+	return r.(scanPlanResult).plan, r.(scanPlanResult).err
+}
+
+func buildScanPlan(t reflect.Type, cols []string) (*scanPlan, error) {
+	fields := buildScanFields(t, nil, map[reflect.Type]bool{})
+	exact := make(map[string][]int)
+	folded := make(map[string][]int)
+	for _, f := range fields {
+		if prev, ok := exact[f.name]; !ok || len(f.index) < len(prev) {
+			exact[f.name] = f.index
+		}
+		lower := strings.ToLower(f.name)
+		if prev, ok := folded[lower]; !ok || len(f.index) < len(prev) {
+			folded[lower] = f.index
+		}
+	}
+	plan := &scanPlan{fields: make([][]int, len(cols))}
+	seen := make(map[string]bool, len(cols))
+	for i, col := range cols {
+		if seen[col] {
+			return nil, fmt.Errorf("null: cannot scan into %v, column %q appears more than once", t, col)
+		}
+		seen[col] = true
+		if index, ok := exact[col]; ok {
+			plan.fields[i] = index
+		} else {
+			plan.fields[i] = folded[strings.ToLower(col)]
+		}
+	}
+	return plan, nil
+}
+
+// scanField is a field that ScanStruct can scan into.
+type scanField struct {
+	name  string
+	index []int
+}
+
+// buildScanFields lists the fields of t found at prefix, named as by
+// buildColumns with the db tag, but of any type. Embedded structs already
+// in seen are skipped, so embedded pointer cycles end.
+func buildScanFields(t reflect.Type, prefix []int, seen map[reflect.Type]bool) []scanField {
+	seen[t] = true
+	defer delete(seen, t)
+	var fields []scanField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		if name == "-" {
+			continue
+		}
+		index := append(append([]int(nil), prefix...), i)
+		if f.Anonymous && isNestedStruct(f.Type) {
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if !seen[et] {
+				fields = append(fields, buildScanFields(et, index, seen)...)
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, scanField{name, index})
+	}
+	return fields
+}
+
+// fieldAlloc is like reflect.Value.FieldByIndex, but allocates nil embedded
+// pointers on the way.
+func fieldAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package null
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ScanBase struct {
+	ID      Int64 `db:"id"`
+	Name    String
+	Skipped String `db:"-"`
+}
+
+type scanUser struct {
+	*ScanBase
+	Name    String `db:"name"`
+	Email   String
+	Age     *Int   `db:"age"`
+	Plain   string `db:"plain"`
+	private String
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := queryRows(t, []string{"id", "name", "EMAIL", "age", "plain", "extra"},
+		[]driver.Value{int64(1), "alice", nil, int64(30), "x", "ignored"},
+		[]driver.Value{int64(2), nil, "bob@example.com", nil, "y", nil},
+	)
+	defer rows.Close()
+
+	u := scanUser{Email: StringFrom("stale"), private: StringFrom("stale")}
+	if !rows.Next() {
+		t.Fatal("no rows")
+	}
+	if err := ScanStruct(rows, &u); err != nil {
+		t.Fatal(err)
+	}
+	want := scanUser{
+		ScanBase: &ScanBase{ID: Int64From(1)},
+		Name:     StringFrom("alice"),
+		Email:    NewString("", false),
+		Age:      new(Int),
+		Plain:    "x",
+	}
+	*want.Age = IntFrom(30)
+	if !reflect.DeepEqual(u, want) {
+		t.Errorf("ScanStruct() = %#v, want %#v", u, want)
+	}
+	if u.ScanBase.Name.Set || u.Skipped.Set {
+		t.Error("shadowed and skipped fields should be unset")
+	}
+
+	if !rows.Next() {
+		t.Fatal("no second row")
+	}
+	maybePanic(ScanStruct(rows, &u))
+	if !u.Name.IsNull() || u.Email != StringFrom("bob@example.com") || u.Age != nil {
+		t.Errorf("ScanStruct() of nulls = %#v", u)
+	}
+}
+
+func TestScanStructMissingColumns(t *testing.T) {
+	rows := queryRows(t, []string{"name"}, []driver.Value{nil})
+	defer rows.Close()
+	rows.Next()
+	var u scanUser
+	maybePanic(ScanStruct(rows, &u))
+	if u.ScanBase != nil || u.Email.Set || !u.Name.IsNull() {
+		t.Errorf("only name should be set, to null: %#v", u)
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	cols := []string{"id", "name"}
+	data := [][]driver.Value{{int64(1), "alice"}, {int64(2), nil}}
+
+	var users []scanUser
+	rows := queryRows(t, cols, data...)
+	maybePanic(ScanAll(rows, &users))
+	if len(users) != 2 || users[0].ID != Int64From(1) || users[0].Name != StringFrom("alice") ||
+		users[1].ID != Int64From(2) || !users[1].Name.IsNull() {
+		t.Errorf("ScanAll() = %#v", users)
+	}
+
+	var ptrs []*scanUser
+	rows = queryRows(t, cols, data...)
+	maybePanic(ScanAll(rows, &ptrs))
+	if len(ptrs) != 2 || ptrs[1].ID != Int64From(2) {
+		t.Errorf("ScanAll() of pointers = %#v", ptrs)
+	}
+	if ptrs[0].ScanBase == ptrs[1].ScanBase {
+		t.Error("rows should not share embedded structs")
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	rows := queryRows(t, []string{"id", "name", "id"}, []driver.Value{int64(1), "a", int64(2)})
+	defer rows.Close()
+	rows.Next()
+	var u scanUser
+	if err := ScanStruct(rows, &u); err == nil || !strings.Contains(err.Error(), `column "id" appears more than once`) {
+		t.Errorf("duplicate column: %v", err)
+	}
+
+	for _, dst := range []interface{}{nil, u, new(int), (*scanUser)(nil)} {
+		if err := ScanStruct(rows, dst); err == nil {
+			t.Errorf("ScanStruct(%T) should fail", dst)
+		}
+	}
+	for _, dst := range []interface{}{nil, []scanUser{}, new([]int), &u} {
+		if err := ScanAll(rows, dst); err == nil {
+			t.Errorf("ScanAll(%T) should fail", dst)
+		}
+	}
+
+	rows = queryRows(t, []string{"age"}, []driver.Value{"old"})
+	var users []scanUser
+	if err := ScanAll(rows, &users); err == nil {
+		t.Error("scanning text into an Int should fail")
+	}
+}
+
+// queryRows returns rows of a query that yields cols and data.
+func queryRows(t *testing.T, cols []string, data ...[]driver.Value) *sql.Rows {
+	db := sql.OpenDB(&rowsDB{cols: cols, data: data})
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT")
+	maybePanic(err)
+	return rows
+}
+
+// rowsDB is a database/sql driver whose every query yields cols and data,
+// to which every statement executed adds a row.
+type rowsDB struct {
+	cols []string
+	data [][]driver.Value
+}
+
+func (db *rowsDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *rowsDB) Driver() driver.Driver                        { return nil }
+func (db *rowsDB) Prepare(string) (driver.Stmt, error)          { return db, nil }
+func (db *rowsDB) Close() error                                 { return nil }
+func (db *rowsDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (db *rowsDB) NumInput() int                                { return -1 }
+
+// Exec adds its arguments as a row.
+func (db *rowsDB) Exec(args []driver.Value) (driver.Result, error) {
+	db.data = append(db.data, append([]driver.Value(nil), args...))
+	return driver.RowsAffected(1), nil
+}
+
+func (db *rowsDB) Query([]driver.Value) (driver.Rows, error) {
+	return &dataRows{cols: db.cols, data: db.data}, nil
+}
+
+type dataRows struct {
+	cols []string
+	data [][]driver.Value
+}
+
+func (r *dataRows) Columns() []string { return r.cols }
+func (r *dataRows) Close() error      { return nil }
+
+func (r *dataRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.data[0])
+	r.data = r.data[1:]
+	return nil
+}
@@ -0,0 +1,277 @@
+package null
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SecretJSONMode is what Secret.MarshalJSON writes for a valid Secret; see
+// SecretJSONAs.
+type SecretJSONMode int
+
+const (
+	// SecretJSONPlaceholder writes SecretPlaceholder as a JSON string, so
+	// that a client can tell a secret that is set from one that is not.
+	// It is the default.
+	SecretJSONPlaceholder SecretJSONMode = iota
+	// SecretJSONNull writes null, as for a null Secret.
+	SecretJSONNull
+)
+
+// SecretJSONAs sets what Secret.MarshalJSON writes for a valid Secret. A
+// null Secret marshals to null in every mode.
+var SecretJSONAs = SecretJSONPlaceholder
+
+// SecretPlaceholder is what a valid Secret prints, logs and marshals to in
+// place of its value, whatever that value is, the empty string included.
+var SecretPlaceholder = "***"
+
+// Secret is a nullable string for credentials such as API keys and webhook
+// secrets. It scans and writes to the database like String, and decodes
+// the real value from JSON and text, but it prints, logs and encodes to
+// JSON and text as SecretPlaceholder, so that a struct holding one can be
+// logged or returned as it is. Reveal returns the value.
+//
+// The value is held behind an unexported pointer, so that printing a
+// struct with an unexported Secret field shows only an address. Compare
+// Secrets with Equal, which takes constant time; == compares the
+// pointers.
+type Secret struct {
+	secret *string
+	Valid  bool
+	Set    bool
+}
+
+// NewSecret creates a new Secret.
+func NewSecret(s string, valid bool) Secret {
+	return Secret{
+		secret: &s,
+		Valid:  valid,
+		Set:    true,
+	}
+}
+
+// SecretFrom creates a new Secret that will always be valid.
+func SecretFrom(s string) Secret {
+	return NewSecret(s, true)
+}
+
+// SecretFromPtr creates a new Secret that will be null if s is nil.
+func SecretFromPtr(s *string) Secret {
+	if s == nil {
+		return NewSecret("", false)
+	}
+	return NewSecret(*s, true)
+}
+
+// SecretFromString creates a new Secret with the value and state of s.
+func SecretFromString(s String) Secret {
+	return Secret{secret: &s.String, Valid: s.Valid, Set: s.Set}
+}
+
+// IsValid returns true if this carries an explicit value and is not null.
+func (s Secret) IsValid() bool {
+	return s.Set && s.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive).
+func (s Secret) IsSet() bool {
+	return s.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (s Secret) IsNull() bool {
+	return s.Set && !s.Valid
+}
+
+// Reveal returns the secret, or the empty string if this Secret is null.
+func (s Secret) Reveal() string {
+	if !s.Valid || s.secret == nil {
+		return ""
+	}
+	return *s.secret
+}
+
+// ToString returns s as a String, which no longer hides the value.
+func (s Secret) ToString() String {
+	return String{String: s.Reveal(), Valid: s.Valid, Set: s.Set}
+}
+
+// Equal returns true if both secrets are valid and hold the same value,
+// or if both are null. Values are compared with subtle.ConstantTimeCompare,
+// so the time taken does not depend on where they differ; as for
+// Bytes.EqualConstantTime, only that the lengths differ and whether either
+// side is null are not hidden.
+func (s Secret) Equal(other Secret) bool {
+	if !s.Valid || !other.Valid {
+		return s.Valid == other.Valid
+	}
+	return subtle.ConstantTimeCompare([]byte(s.Reveal()), []byte(other.Reveal())) == 1
+}
+
+// ExactEqual is like Equal, but also requires s and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (s Secret) ExactEqual(other Secret) bool {
+	return s.Set == other.Set && s.Equal(other)
+}
+
+// SetValid changes this Secret's value and also sets it to be non-null.
+func (s *Secret) SetValid(v string) {
+	*s = SecretFrom(v)
+}
+
+// SetNull sets this Secret to null, keeping Set.
+func (s *Secret) SetNull() {
+	*s = NewSecret("", false)
+}
+
+// Unset resets this Secret to the zero value, as if it was never set.
+func (s *Secret) Unset() {
+	*s = Secret{}
+}
+
+// IsZero returns true if this Secret was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (s Secret) IsZero() bool {
+	return !s.Set && !s.Valid
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes the real value as String does, so a placeholder that a client
+// sends back is taken as the secret itself. Errors do not quote the input.
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	return s.decode(func(str *String) error { return str.UnmarshalJSON(data) })
+}
+
+// MarshalJSON implements json.Marshaler.
+// It writes SecretPlaceholder, or null as SecretJSONAs sets, for a valid
+// Secret, and null for a null one.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	if !s.Valid || SecretJSONAs == SecretJSONNull {
+		return NullBytes, nil
+	}
+	return s.AppendJSON(make([]byte, 0, len(SecretPlaceholder)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (s Secret) AppendJSON(b []byte) ([]byte, error) {
+	if !s.Valid || SecretJSONAs == SecretJSONNull {
+		return append(b, "null"...), nil
+	}
+	return appendJSONString(b, SecretPlaceholder), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes the real value as String does; errors do not quote the input.
+func (s *Secret) UnmarshalText(text []byte) error {
+	return s.decode(func(str *String) error { return str.UnmarshalText(text) })
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns SecretPlaceholder for a valid Secret and a blank for a null
+// one. The encoders that use it, such as those of XML, YAML and TOML,
+// therefore do not see the value either.
+func (s Secret) MarshalText() ([]byte, error) {
+	if !s.Valid {
+		return []byte{}, nil
+	}
+	return s.AppendText(make([]byte, 0, len(SecretPlaceholder)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if s is null.
+func (s Secret) AppendText(b []byte) ([]byte, error) {
+	if !s.Valid {
+		return b, nil
+	}
+	return append(b, SecretPlaceholder...), nil
+}
+
+// Scan implements the Scanner interface.
+// It accepts what String.Scan does; errors do not quote the input.
+func (s *Secret) Scan(value interface{}) error {
+	return s.decode(func(str *String) error { return str.Scan(value) })
+}
+
+// Value implements the driver Valuer interface.
+// Unlike the other encodings it returns the real value, for storage.
+func (s Secret) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.Reveal(), nil
+}
+
+// decode sets s from a String that decode fills in, replacing an error
+// with one that keeps its kind and no longer quotes the input.
+func (s *Secret) decode(decode func(*String) error) error {
+	var str String
+	err := decode(&str)
+	if err != nil {
+		str = NewString("", false)
+	}
+	*s = SecretFromString(str)
+	return secretError(err)
+}
+
+// secretError returns an error like err, which comes from one of the String
+// decoders, without the input that err and its *ConvertError may quote.
+func secretError(err error) error {
+	var tooLong *TooLongError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &tooLong):
+		err = &TooLongError{Type: "null.Secret", Limit: tooLong.Limit, Len: tooLong.Len}
+	case errors.As(err, &typeErr):
+		kind, _, _ := strings.Cut(typeErr.Value, " ")
+		return newTypeError(&json.UnmarshalTypeError{Value: kind, Type: reflect.TypeOf(Secret{})},
+			&ConvertError{Type: "null.Secret", Input: SecretPlaceholder, Err: ErrInvalidInput})
+	default:
+		err = errors.New("null: invalid null.Secret value")
+	}
+	return &ConvertError{Type: "null.Secret", Input: SecretPlaceholder, Err: err}
+}
+
+// String implements the Stringer interface, returning what %v prints:
+// SecretPlaceholder, "<null>" or "<unset>".
+func (s Secret) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it, with SecretPlaceholder for the value.
+func (s Secret) GoString() string {
+	payload := `""`
+	if s.Valid {
+		payload = strconv.Quote(SecretPlaceholder)
+	}
+	return goString("Secret", "", "secret", payload, !s.Valid, s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting
+// SecretPlaceholder with the verb if s is valid.
+func (s Secret) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, SecretPlaceholder)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to SecretPlaceholder as a String value, or to nil if this
+// Secret is null.
+func (s Secret) LogValue() slog.Value {
+	if !s.Valid {
+		return logNull
+	}
+	return slog.StringValue(SecretPlaceholder)
+}
@@ -0,0 +1,545 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"slices"
+)
+
+// Slice is a nullable []T stored as JSON, such as a jsonb column holding an
+// array of app-defined records. Elements are encoded and decoded by
+// encoding/json, so a T implementing json.Marshaler or json.Unmarshaler is
+// honored. A null Slice marshals to null and a valid one to an array, even
+// if its slice is nil, so null, [] and a list of elements all survive a
+// round trip.
+type Slice[T any] struct {
+	Slice []T
+	Valid bool
+	Set   bool
+}
+
+// NewSlice creates a new Slice. It shares s with the caller instead of
+// copying it; see Clone.
+func NewSlice[T any](s []T, valid bool) Slice[T] {
+	return Slice[T]{
+		Slice: s,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// SliceFrom creates a new Slice that will always be valid.
+// A nil slice is valid and marshals to an empty array.
+func SliceFrom[T any](s []T) Slice[T] {
+	return NewSlice(s, true)
+}
+
+// SliceFromPtr creates a new Slice that will be null if s is nil.
+func SliceFromPtr[T any](s *[]T) Slice[T] {
+	if s == nil {
+		return NewSlice[T](nil, false)
+	}
+	return NewSlice(*s, true)
+}
+
+// SliceFromNonEmpty creates a new Slice that will be null if s is empty.
+func SliceFromNonEmpty[T any](s []T) Slice[T] {
+	return NewSlice(s, len(s) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (s Slice[T]) IsValid() bool {
+	return s.Set && s.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (s Slice[T]) IsSet() bool {
+	return s.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (s Slice[T]) IsNull() bool {
+	return s.Set && !s.Valid
+}
+
+// Equal reports whether s and other are both null, or both valid with
+// deeply equal elements. A nil slice equals an empty one. Set is ignored;
+// see ExactEqual.
+func (s Slice[T]) Equal(other Slice[T]) bool {
+	if !s.Valid || !other.Valid {
+		return s.Valid == other.Valid
+	}
+	if len(s.Slice) != len(other.Slice) {
+		return false
+	}
+	for i := range s.Slice {
+		if !reflect.DeepEqual(s.Slice[i], other.Slice[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ExactEqual is like Equal, but also requires s and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (s Slice[T]) ExactEqual(other Slice[T]) bool {
+	return s.Set == other.Set && s.Equal(other)
+}
+
+// Clone returns a copy of s with its own copy of the slice, so that
+// changes to either do not show in the other. The elements themselves are
+// copied as values, so maps and pointers inside them are shared.
+func (s Slice[T]) Clone() Slice[T] {
+	s.Slice = slices.Clone(s.Slice)
+	return s
+}
+
+// Len returns the number of elements, which is 0 if this Slice is null.
+func (s Slice[T]) Len() int {
+	if !s.Valid {
+		return 0
+	}
+	return len(s.Slice)
+}
+
+// At returns the element at index i. Like indexing a slice, it panics if i
+// is out of range, which it always is if this Slice is null.
+func (s Slice[T]) At(i int) T {
+	if !s.Valid {
+		panic(fmt.Sprintf("null: index %d out of range of a null Slice", i))
+	}
+	return s.Slice[i]
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports array and null input.
+func (s *Slice[T]) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Slice", s, data)
+	s.Set = true
+	if bytes.Equal(data, NullBytes) {
+		s.Slice, s.Valid = nil, false
+		return nil
+	}
+
+	res := []T{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	s.Slice, s.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Slice is null.
+func (s Slice[T]) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return NullBytes, nil
+	}
+	if s.Slice == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.Slice)
+}
+
+// SetValid changes this Slice's value and also sets it to be non-null.
+func (s *Slice[T]) SetValid(v []T) {
+	s.Slice = v
+	s.Valid = true
+	s.Set = true
+}
+
+// SetNull sets this Slice to an explicit null, zeroing its value.
+func (s *Slice[T]) SetNull() {
+	*s = Slice[T]{Set: true}
+}
+
+// Unset resets this Slice to the zero value, as if it was never set.
+func (s *Slice[T]) Unset() {
+	*s = Slice[T]{}
+}
+
+// SetPtr changes this Slice to the value p points to, or to an explicit
+// null if p is nil.
+func (s *Slice[T]) SetPtr(p *[]T) {
+	*s = SliceFromPtr(p)
+}
+
+// Ptr returns a pointer to this Slice's value, or a nil pointer if this Slice is null.
+func (s Slice[T]) Ptr() *[]T {
+	if !s.Valid {
+		return nil
+	}
+	return &s.Slice
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (s Slice[T]) ValueOrZero() []T {
+	if !s.Valid {
+		return nil
+	}
+	return s.Slice
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (s Slice[T]) Or(def []T) []T {
+	if !s.Valid {
+		return def
+	}
+	return s.Slice
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (s Slice[T]) Val() []T {
+	return s.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (s Slice[T]) Get() ([]T, bool) {
+	return s.ValueOrZero(), s.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if s is
+// null.
+func (s Slice[T]) MustGet() []T {
+	if !s.Valid {
+		panic(&NullError{Type: "null.Slice" + goTypeArgs[T]()})
+	}
+	return s.Slice
+}
+
+// IsZero returns true if this Slice was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (s Slice[T]) IsZero() bool {
+	return !s.Set && !s.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The elements are encoded by the YAML package as T. It will encode null
+// if this Slice is null.
+func (s Slice[T]) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	if s.Slice == nil {
+		return []T{}, nil
+	}
+	return s.Slice, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// The elements are decoded by the YAML package as T. It will unmarshal to
+// a null Slice if the input is null or ~.
+func (s *Slice[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	s.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+
+	res := []T{}
+	if err := unmarshal(&res); err != nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	s.Slice, s.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Slice is null.
+func (s Slice[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null Slice.
+func (s *Slice[T]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(s.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Slice is null.
+func (s Slice[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Slice.
+func (s *Slice[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(s.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes the JSON of MarshalJSON as a BSON array, or BSON null if this
+// Slice is null.
+func (s Slice[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !s.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON array, decoded as UnmarshalJSON decodes JSON.
+func (s *Slice[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	s.Set = true
+	if bsonIsNull(typ) {
+		s.Slice, s.Valid = nil, false
+		return nil
+	}
+	if typ != bsonArray {
+		s.Slice, s.Valid = nil, false
+		return bsonTypeError(typ, "null.Slice")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the JSON of MarshalJSON as a msgpack array, or nil if this
+// Slice is null.
+func (s Slice[T]) MarshalMsgpack() ([]byte, error) {
+	if !s.Valid {
+		return msgpackNull, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack array, decoded as UnmarshalJSON decodes JSON.
+func (s *Slice[T]) UnmarshalMsgpack(data []byte) error {
+	s.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.Slice, s.Valid = nil, false
+		return msgpackTypeError(v, "null.Slice")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the JSON of MarshalJSON as a CBOR array, or null if this
+// Slice is null.
+func (s Slice[T]) MarshalCBOR() ([]byte, error) {
+	if !s.Valid {
+		return cborNull, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR array, decoded as UnmarshalJSON decodes JSON.
+func (s *Slice[T]) UnmarshalCBOR(data []byte) error {
+	s.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.Slice, s.Valid = nil, false
+		return cborTypeError(v, "null.Slice")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		s.Slice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (s Slice[T]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, s.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Slice.
+func (s *Slice[T]) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, s.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this Slice is
+// null.
+func (s Slice[T]) MarshalCSV() (string, error) {
+	return marshalCSV(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null Slice.
+func (s *Slice[T]) UnmarshalCSV(cell string) error {
+	return jsonText(s.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Slice is null.
+func (s Slice[T]) MarshalTOML() ([]byte, error) {
+	return marshalTOML(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null Slice.
+func (s *Slice[T]) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, s.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a list of one random element, randomized as Val randomizes T, or
+// an explicit null if shouldBeNull is true. It panics if T cannot be
+// randomized.
+func (s *Slice[T]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*s = NewSlice[T](nil, false)
+		return
+	}
+	var e Val[T]
+	e.Randomize(nextInt, fieldType, false)
+	s.SetValid([]T{e.Val})
+}
+
+// Generate implements testing/quick's Generator.
+func (s Slice[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewSlice[T](nil, false), func() Slice[T] {
+		var res []T
+		if !generateValue(rand, size, reflect.ValueOf(&res).Elem()) {
+			panic("null: cannot generate null.Slice" + goTypeArgs[T]())
+		}
+		return SliceFrom(res)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The elements
+// are encoded as JSON.
+func (s Slice[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.Set, s.Valid, func() ([]byte, error) {
+		return s.MarshalJSON()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Slice[T]) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Slice")
+	if err != nil {
+		return err
+	}
+	var v []T
+	if valid {
+		v = []T{}
+		if err = json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+	}
+	s.Slice, s.Valid, s.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It decodes a JSON array from string or []byte; a JSON null is null.
+func (s *Slice[T]) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Slice", value)
+	switch x := value.(type) {
+	case nil:
+		s.Slice, s.Valid, s.Set = nil, false, true
+		return nil
+	case string:
+		err = s.UnmarshalJSON([]byte(x))
+	case []byte:
+		err = s.UnmarshalJSON(x)
+	default:
+		s.Slice, s.Valid, s.Set = nil, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.Slice: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The Slice is encoded as JSON, returned as a string if JSONValueAsString
+// is set.
+func (s Slice[T]) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if JSONValueAsString {
+		return string(data), nil
+	}
+	return data, nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (s Slice[T]) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it.
+func (s Slice[T]) GoString() string {
+	return goString("Slice", goTypeArgs[T](), "Slice", fmt.Sprintf("%#v", s.Slice), s.Slice == nil, s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// s with the verb if it is valid.
+func (s Slice[T]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, s.Slice)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the slice, or to nil if this Slice is
+// null.
+func (s Slice[T]) LogValue() slog.Value {
+	if !s.Valid {
+		return logNull
+	}
+	return slog.AnyValue(s.Slice)
+}
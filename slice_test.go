@@ -0,0 +1,141 @@
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testOrderLine struct {
+	SKU   string `json:"sku"`
+	Qty   int    `json:"qty"`
+	Label testShout
+}
+
+func TestSliceJSON(t *testing.T) {
+	lines := []testOrderLine{{"A-1", 2, "box"}, {"B-2", 1, ""}}
+	tests := []struct {
+		in   Slice[testOrderLine]
+		want string
+	}{
+		{NewSlice[testOrderLine](nil, false), `null`},
+		{SliceFrom[testOrderLine](nil), `[]`},
+		{SliceFrom([]testOrderLine{}), `[]`},
+		{SliceFrom(lines), `[{"sku":"A-1","qty":2,"Label":"BOX"},{"sku":"B-2","qty":1,"Label":""}]`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	var null Slice[int]
+	err := json.Unmarshal([]byte(`null`), &null)
+	maybePanic(err)
+	if null.Valid || !null.Set || null.Slice != nil {
+		t.Errorf("unmarshal null = %#v, want a set null", null)
+	}
+
+	var empty Slice[int]
+	err = json.Unmarshal([]byte(`[]`), &empty)
+	maybePanic(err)
+	if !empty.Valid || empty.Slice == nil || empty.Len() != 0 {
+		t.Errorf("unmarshal [] = %#v, want a valid empty slice", empty)
+	}
+
+	var got Slice[testOrderLine]
+	err = json.Unmarshal([]byte(`[{"sku":"A-1","qty":2,"Label":"BOX"}]`), &got)
+	maybePanic(err)
+	if !got.Valid || got.Len() != 1 || got.At(0) != (testOrderLine{"A-1", 2, "BOX"}) {
+		t.Errorf("unmarshal elements = %#v", got)
+	}
+
+	for _, bad := range []string{`{}`, `[1,"a"]`, `[{"Label":"quiet"}]`} {
+		var s Slice[testOrderLine]
+		if err := json.Unmarshal([]byte(bad), &s); err == nil {
+			t.Errorf("unmarshal %s should fail", bad)
+		}
+		if s.Valid || s.Slice != nil {
+			t.Errorf("unmarshal %s left %#v", bad, s)
+		}
+	}
+}
+
+func TestSliceFromPtr(t *testing.T) {
+	if s := SliceFromPtr[int](nil); s.Valid || !s.Set {
+		t.Errorf("SliceFromPtr(nil) = %#v, want a set null", s)
+	}
+	v := []int{1, 2}
+	s := SliceFromPtr(&v)
+	if !s.Valid || !reflect.DeepEqual(s.Slice, v) {
+		t.Errorf("SliceFromPtr(&%v) = %#v", v, s)
+	}
+	if p := s.Ptr(); p == nil || !reflect.DeepEqual(*p, v) {
+		t.Errorf("Ptr() = %v", p)
+	}
+}
+
+func TestSliceAccessors(t *testing.T) {
+	null := NewSlice[int](nil, false)
+	if null.Len() != 0 {
+		t.Errorf("null Len() = %d", null.Len())
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("At on a null Slice should panic")
+			}
+		}()
+		null.At(0)
+	}()
+
+	s := SliceFrom([]int{1, 2, 3})
+	if s.Len() != 3 || s.At(2) != 3 {
+		t.Errorf("Len() = %d, At(2) = %d", s.Len(), s.At(2))
+	}
+	clone := s.Clone()
+	clone.Slice[0] = 9
+	if s.At(0) != 1 {
+		t.Error("Clone shares its elements")
+	}
+	if !SliceFrom[int](nil).Equal(SliceFrom([]int{})) || s.Equal(clone) || null.Equal(SliceFrom[int](nil)) {
+		t.Error("bad Equal")
+	}
+}
+
+func TestSliceScanValue(t *testing.T) {
+	s := SliceFrom([]testOrderLine{{"A-1", 2, "box"}})
+	v, err := s.Value()
+	maybePanic(err)
+	if string(v.([]byte)) != `[{"sku":"A-1","qty":2,"Label":"BOX"}]` {
+		t.Errorf("Value() = %s", v)
+	}
+
+	var back Slice[testOrderLine]
+	err = back.Scan(v)
+	maybePanic(err)
+	if !back.Valid || back.At(0) != (testOrderLine{"A-1", 2, "BOX"}) {
+		t.Errorf("Scan(%s) = %#v", v, back)
+	}
+
+	err = back.Scan(`[]`)
+	maybePanic(err)
+	if !back.Valid || back.Slice == nil || back.Len() != 0 {
+		t.Errorf("Scan([]) = %#v, want a valid empty slice", back)
+	}
+
+	err = back.Scan(nil)
+	maybePanic(err)
+	if back.Valid || !back.Set {
+		t.Errorf("Scan(nil) = %#v, want a set null", back)
+	}
+	if v, err := back.Value(); v != nil || err != nil {
+		t.Errorf("null Value() = %v, %v", v, err)
+	}
+
+	if err := back.Scan(int64(1)); err == nil || back.Valid {
+		t.Errorf("Scan(int64) = %#v, %v, want an error", back, err)
+	}
+}
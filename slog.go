@@ -0,0 +1,51 @@
+package null
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"unicode/utf8"
+)
+
+// All types implement slog.LogValuer, so a logged value shows its payload
+// rather than a group of its fields. A valid value resolves to the natural
+// slog kind: String for text, Int64 or Uint64 for integers, Float64, Bool,
+// Time and Duration, Any for maps and slices, and the kind of T for Val. A
+// null or unset value resolves to slog.AnyValue(nil), which slog.JSONHandler writes as
+// null and slog.TextHandler as <nil>.
+
+// MaxLogValueLen limits the payload bytes of Bytes, RawBytes, HexBytes and
+// JSON values that LogValue formats, so a large blob cannot flood a log
+// line. The rest is summarized by its size, as in "aGVsbG8...(1024 bytes)".
+// Zero means no limit.
+var MaxLogValueLen = 64
+
+// logNull is the value a null resolves to.
+var logNull = slog.AnyValue(nil)
+
+// logBytes formats the first MaxLogValueLen bytes of b with format, noting
+// the full size if the rest is cut off. For text, the cut is moved back to
+// the start of a UTF-8 sequence.
+func logBytes(b []byte, text bool, format func([]byte) string) slog.Value {
+	n := len(b)
+	if MaxLogValueLen <= 0 || n <= MaxLogValueLen {
+		return slog.StringValue(format(b))
+	}
+	cut := MaxLogValueLen
+	for text && cut > 0 && !utf8.RuneStart(b[cut]) {
+		cut--
+	}
+	return slog.StringValue(fmt.Sprintf("%s...(%d bytes)", format(b[:cut]), n))
+}
+
+// logFloat32 returns f as a Float64 value with the shortest decimal that
+// round-trips f, so 0.1 logs as 0.1 rather than 0.10000000149011612.
+func logFloat32(f float32) slog.Value {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return slog.Float64Value(v)
+}
+
+// rawText formats the bytes of RawBytes and JSON as they are.
+func rawText(b []byte) string {
+	return string(b)
+}
@@ -0,0 +1,45 @@
+package null
+
+import "fmt"
+
+// The Spanner methods implement the Encoder and Decoder interfaces of
+// cloud.google.com/go/spanner on String, Int64, Float64, Bool, Time, Bytes
+// and JSON, so the package needs no Spanner dependency. Spanner uses them
+// for struct fields, mutations, query parameters and Row.Column.
+//
+// A null value is encoded as a typed NULL. DecodeSpanner receives the
+// column as Spanner sends it: a string for STRING, INT64, TIMESTAMP, BYTES
+// and JSON, a float64 for FLOAT64 and a bool for BOOL. NULL arrives as a
+// typed nil pointer and decodes to null with Set true. A column that is not
+// read is never decoded, so Set stays false.
+
+// spannerValue dereferences the pointers Spanner passes to DecodeSpanner
+// and reports whether v is NULL.
+func spannerValue(v interface{}) (interface{}, bool) {
+	switch x := v.(type) {
+	case nil:
+		return nil, true
+	case *string:
+		if x == nil {
+			return nil, true
+		}
+		return *x, false
+	case *float64:
+		if x == nil {
+			return nil, true
+		}
+		return *x, false
+	case *bool:
+		if x == nil {
+			return nil, true
+		}
+		return *x, false
+	}
+	return v, false
+}
+
+// spannerError reports a Spanner value that cannot be decoded into the null
+// type called name.
+func spannerError(v interface{}, name string) error {
+	return fmt.Errorf("null: cannot decode Spanner value of type %T into %s", v, name)
+}
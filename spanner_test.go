@@ -0,0 +1,112 @@
+package null
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type spannerEncoder interface {
+	EncodeSpanner() (interface{}, error)
+}
+
+type spannerDecoder interface {
+	DecodeSpanner(interface{}) error
+}
+
+func TestSpannerEncode(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC)
+	tests := []struct {
+		in         spannerEncoder
+		want, null interface{}
+	}{
+		{StringFrom(""), "", (*string)(nil)},
+		{Int64From(math.MaxInt64), int64(math.MaxInt64), (*int64)(nil)},
+		{Float64From(1.5), 1.5, (*float64)(nil)},
+		{BoolFrom(false), false, (*bool)(nil)},
+		{TimeFrom(when), when, (*time.Time)(nil)},
+		{BytesFrom([]byte{0xab}), []byte{0xab}, []byte(nil)},
+		{NewBytes(nil, true), []byte{}, []byte(nil)},
+		{JSONFrom([]byte(`{"a":1}`)), `{"a":1}`, (*string)(nil)},
+	}
+	for _, test := range tests {
+		got, err := test.in.EncodeSpanner()
+		maybePanic(err)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.EncodeSpanner() = %#v, want %#v", test.in, got, test.want)
+		}
+
+		null := reflect.Zero(reflect.TypeOf(test.in)).Interface().(spannerEncoder)
+		got, err = null.EncodeSpanner()
+		maybePanic(err)
+		if !reflect.DeepEqual(got, test.null) {
+			t.Errorf("null %T.EncodeSpanner() = %#v, want %#v", null, got, test.null)
+		}
+	}
+}
+
+func TestSpannerDecode(t *testing.T) {
+	str := "hello"
+	tests := []struct {
+		out   spannerDecoder
+		input interface{}
+		want  interface{}
+	}{
+		{&String{}, "hello", StringFrom("hello")},
+		{&String{}, &str, StringFrom("hello")},
+		{&String{}, (*string)(nil), NewString("", false)},
+		{&Int64{}, "9007199254740993", Int64From(9007199254740993)},
+		{&Int64{}, (*string)(nil), NewInt64(0, false)},
+		{&Float64{}, 1.5, Float64From(1.5)},
+		{&Float64{}, "-Infinity", Float64From(math.Inf(-1))},
+		{&Float64{}, (*float64)(nil), NewFloat64(0, false)},
+		{&Bool{}, true, BoolFrom(true)},
+		{&Bool{}, (*bool)(nil), NewBool(false, false)},
+		{&Time{}, "2012-12-21T21:21:21.000000005Z", TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC))},
+		{&Time{}, (*string)(nil), NewTime(time.Time{}, false)},
+		{&Bytes{}, "qw==", BytesFrom([]byte{0xab})},
+		{&Bytes{}, "", NewBytes([]byte{}, true)},
+		{&Bytes{}, (*string)(nil), NewBytes(nil, false)},
+		{&JSON{}, `{"a":1}`, JSONFrom([]byte(`{"a":1}`))},
+		{&JSON{}, "null", JSONFrom([]byte("null"))},
+		{&JSON{}, (*string)(nil), NewJSON(nil, false)},
+	}
+	for _, test := range tests {
+		err := test.out.DecodeSpanner(test.input)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.DecodeSpanner(%#v) = %#v, want %#v", test.out, test.input, got, test.want)
+		}
+	}
+
+	var f Float64
+	maybePanic(f.DecodeSpanner("NaN"))
+	if !f.Valid || !math.IsNaN(f.Float64) {
+		t.Errorf("Float64.DecodeSpanner(\"NaN\") = %#v, want NaN", f)
+	}
+}
+
+func TestSpannerDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		out   spannerDecoder
+		input interface{}
+	}{
+		{&String{}, 1.5},
+		{&Int64{}, "1.5"},
+		{&Int64{}, "9223372036854775808"},
+		{&Int64{}, 42.0},
+		{&Float64{}, "1.5"},
+		{&Bool{}, "true"},
+		{&Time{}, "2012-12-21"},
+		{&Bytes{}, "not base64!"},
+		{&JSON{}, true},
+	} {
+		if err := test.out.DecodeSpanner(test.input); err == nil {
+			t.Errorf("%T.DecodeSpanner(%#v) should fail", test.out, test.input)
+		}
+		if v := reflect.ValueOf(test.out).Elem(); v.FieldByName("Valid").Bool() || !v.FieldByName("Set").Bool() {
+			t.Errorf("%T after a failed DecodeSpanner = %#v, want a set null", test.out, v.Interface())
+		}
+	}
+}
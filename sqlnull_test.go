@@ -0,0 +1,85 @@
+package null
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromSQLNull(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		got, want interface{}
+	}{
+		{StringFromNullString(sql.NullString{String: "a", Valid: true}), StringFrom("a")},
+		{StringFromNullString(sql.NullString{}), NewString("", false)},
+		{IntFromNullInt64(sql.NullInt64{Int64: -1, Valid: true}), IntFrom(-1)},
+		{IntFromNullInt64(sql.NullInt64{}), NewInt(0, false)},
+		{Int16FromNullInt16(sql.NullInt16{Int16: -16, Valid: true}), Int16From(-16)},
+		{Int16FromNullInt16(sql.NullInt16{}), NewInt16(0, false)},
+		{Int32FromNullInt32(sql.NullInt32{Int32: -32, Valid: true}), Int32From(-32)},
+		{Int32FromNullInt32(sql.NullInt32{}), NewInt32(0, false)},
+		{Int64FromNullInt64(sql.NullInt64{Int64: -64, Valid: true}), Int64From(-64)},
+		{Int64FromNullInt64(sql.NullInt64{}), NewInt64(0, false)},
+		{ByteFromNullByte(sql.NullByte{Byte: 'x', Valid: true}), ByteFrom('x')},
+		{ByteFromNullByte(sql.NullByte{}), NewByte(0, false)},
+		{Float64FromNullFloat64(sql.NullFloat64{Float64: 1.5, Valid: true}), Float64From(1.5)},
+		{Float64FromNullFloat64(sql.NullFloat64{}), NewFloat64(0, false)},
+		{BoolFromNullBool(sql.NullBool{Bool: true, Valid: true}), BoolFrom(true)},
+		{BoolFromNullBool(sql.NullBool{Bool: false, Valid: true}), BoolFrom(false)},
+		{BoolFromNullBool(sql.NullBool{}), NewBool(false, false)},
+		{TimeFromNullTime(sql.NullTime{Time: when, Valid: true}), TimeFrom(when)},
+		{TimeFromNullTime(sql.NullTime{}), NewTime(time.Time{}, false)},
+		{DateFromNullTime(sql.NullTime{Time: when, Valid: true}), DateOf(2012, 12, 21)},
+		{DateFromNullTime(sql.NullTime{}), NewDate(time.Time{}, false)},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("got %#v, want %#v", test.got, test.want)
+		}
+	}
+}
+
+func TestToSQLNull(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		got, want interface{}
+	}{
+		{StringFrom("a").ToNullString(), sql.NullString{String: "a", Valid: true}},
+		{NewString("", false).ToNullString(), sql.NullString{}},
+		{String{}.ToNullString(), sql.NullString{}},
+		{IntFrom(-1).ToNullInt64(), sql.NullInt64{Int64: -1, Valid: true}},
+		{Int{}.ToNullInt64(), sql.NullInt64{}},
+		{Int16From(-16).ToNullInt16(), sql.NullInt16{Int16: -16, Valid: true}},
+		{Int16{}.ToNullInt16(), sql.NullInt16{}},
+		{Int32From(-32).ToNullInt32(), sql.NullInt32{Int32: -32, Valid: true}},
+		{Int32{}.ToNullInt32(), sql.NullInt32{}},
+		{Int64From(-64).ToNullInt64(), sql.NullInt64{Int64: -64, Valid: true}},
+		{NewInt64(0, false).ToNullInt64(), sql.NullInt64{}},
+		{ByteFrom('x').ToNullByte(), sql.NullByte{Byte: 'x', Valid: true}},
+		{Byte{}.ToNullByte(), sql.NullByte{}},
+		{Float64From(1.5).ToNullFloat64(), sql.NullFloat64{Float64: 1.5, Valid: true}},
+		{Float64{}.ToNullFloat64(), sql.NullFloat64{}},
+		{BoolFrom(false).ToNullBool(), sql.NullBool{Valid: true}},
+		{Bool{}.ToNullBool(), sql.NullBool{}},
+		{TimeFrom(when).ToNullTime(), sql.NullTime{Time: when, Valid: true}},
+		{Time{}.ToNullTime(), sql.NullTime{}},
+		{DateOf(2012, 12, 21).ToNullTime(), sql.NullTime{Time: time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC), Valid: true}},
+		{Date{}.ToNullTime(), sql.NullTime{}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("got %#v, want %#v", test.got, test.want)
+		}
+	}
+}
+
+func TestSQLNullRoundTrip(t *testing.T) {
+	if got := Int64FromNullInt64(Int64From(7).ToNullInt64()); got != Int64From(7) {
+		t.Errorf("Int64 round trip = %#v", got)
+	}
+	if got := StringFromNullString(String{}.ToNullString()); got != NewString("", false) {
+		t.Errorf("unset String round trip = %#v, want a set null", got)
+	}
+}
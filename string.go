@@ -2,13 +2,53 @@ package null
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/sisoftrg/null/convert"
 )
 
+// StringJSONEmptyNull makes String marshal a null to the JSON string "" and
+// unmarshal "" to a null, for clients that cannot handle JSON null strings.
+// It conflates null with the empty string, so a valid "" comes back as a
+// null, and leaves JSON null, text and SQL untouched.
+var StringJSONEmptyNull = false
+
+// UTF8Policy is what String does with invalid UTF-8 in Scan, UnmarshalText
+// and UnmarshalJSON; see StringUTF8.
+type UTF8Policy int
+
+const (
+	// UTF8Keep stores the input as it is, the default. UnmarshalJSON still
+	// replaces invalid bytes with U+FFFD, as encoding/json does.
+	UTF8Keep UTF8Policy = iota
+	// UTF8Reject fails with a *UTF8Error, leaving the String null.
+	UTF8Reject
+	// UTF8Sanitize replaces each run of invalid bytes with
+	// StringUTF8Replacement.
+	UTF8Sanitize
+)
+
+// StringUTF8 sets what String does with invalid UTF-8 input, which
+// encoding/json would otherwise mangle on output and Postgres rejects, for
+// data from sources that cannot be trusted to produce valid text.
+var StringUTF8 = UTF8Keep
+
+// StringUTF8Replacement replaces invalid UTF-8 when StringUTF8 is
+// UTF8Sanitize. It may be empty, to drop the invalid bytes.
+var StringUTF8Replacement = "\uFFFD"
+
 // String is a nullable string. It supports SQL and JSON serialization.
 type String struct {
 	String string
@@ -29,6 +69,16 @@ func StringFromPtr(s *string) String {
 	return NewString(*s, true)
 }
 
+// StringFromNonEmpty creates a new String that will be null if s is empty.
+func StringFromNonEmpty(s string) String {
+	return NewString(s, s != "")
+}
+
+// StringFromNullString creates a new String from a sql.NullString. It is always Set.
+func StringFromNullString(n sql.NullString) String {
+	return NewString(n.String, n.Valid)
+}
+
 // NewString creates a new String
 func NewString(s string, valid bool) String {
 	return String{
@@ -49,8 +99,49 @@ func (s String) IsSet() bool {
 	return s.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (s String) IsNull() bool {
+	return s.Set && !s.Valid
+}
+
+// Equal reports whether s and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (s String) Equal(other String) bool {
+	if !s.Valid || !other.Valid {
+		return s.Valid == other.Valid
+	}
+	return s.String == other.String
+}
+
+// ExactEqual is like Equal, but also requires s and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (s String) ExactEqual(other String) bool {
+	return s.Set == other.Set && s.Equal(other)
+}
+
+// Compare returns -1 if s is less than other, 0 if they are equal and +1
+// if s is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (s String) Compare(other String) int {
+	if !s.Valid || !other.Valid {
+		return compareNull(s.Valid, other.Valid)
+	}
+	return cmp.Compare(s.String, other.String)
+}
+
+// ToBytes converts s to a Bytes holding a copy of the string, keeping
+// Valid and Set.
+func (s String) ToBytes() Bytes {
+	if !s.Valid {
+		return Bytes{Set: s.Set}
+	}
+	return Bytes{Bytes: []byte(s.String), Valid: true, Set: s.Set}
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (s *String) UnmarshalJSON(data []byte) error {
+func (s *String) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.String", s, data)
 	s.Set = true
 	if bytes.Equal(data, NullBytes) {
 		s.String = ""
@@ -58,20 +149,44 @@ func (s *String) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	data, err = checkJSONUTF8(data)
+	if err != nil {
+		s.String, s.Valid = "", false
+		return err
+	}
 	if err := json.Unmarshal(data, &s.String); err != nil {
 		return err
 	}
+	if err := checkLen("null.String", MaxStringLen, len(s.String)); err != nil {
+		s.String, s.Valid = "", false
+		return err
+	}
 
-	s.Valid = true
+	s.Valid = !StringJSONEmptyNull || s.String != ""
 	return nil
 }
 
 // MarshalJSON implements json.Marshaler.
 func (s String) MarshalJSON() ([]byte, error) {
 	if !s.Valid {
+		if StringJSONEmptyNull {
+			return []byte(`""`), nil
+		}
 		return NullBytes, nil
 	}
-	return json.Marshal(s.String)
+	return s.AppendJSON(make([]byte, 0, len(s.String)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (s String) AppendJSON(b []byte) ([]byte, error) {
+	if !s.Valid {
+		if StringJSONEmptyNull {
+			return append(b, `""`...), nil
+		}
+		return append(b, "null"...), nil
+	}
+	return appendJSONString(b, s.String), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -79,22 +194,81 @@ func (s String) MarshalText() ([]byte, error) {
 	if !s.Valid {
 		return []byte{}, nil
 	}
-	return []byte(s.String), nil
+	return s.AppendText(make([]byte, 0, len(s.String)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if s is null.
+func (s String) AppendText(b []byte) ([]byte, error) {
+	if !s.Valid {
+		return b, nil
+	}
+	return append(b, s.String...), nil
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *String) UnmarshalText(text []byte) error {
+func (s *String) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.String", text)
 	s.Set = true
 	if len(text) == 0 {
 		s.Valid = false
 		return nil
 	}
+	str, err := checkUTF8(string(text))
+	if err == nil {
+		err = checkLen("null.String", MaxStringLen, len(str))
+	}
+	if err != nil {
+		s.String, s.Valid = "", false
+		return err
+	}
 
-	s.String = string(text)
+	s.String = str
 	s.Valid = true
 	return nil
 }
 
+// checkUTF8 applies StringUTF8 to s, returning the string to store.
+func checkUTF8(s string) (string, error) {
+	if StringUTF8 == UTF8Keep || utf8.ValidString(s) {
+		return s, nil
+	}
+	if StringUTF8 == UTF8Sanitize {
+		return strings.ToValidUTF8(s, StringUTF8Replacement), nil
+	}
+	return "", &UTF8Error{Type: "null.String", Offset: invalidUTF8(s)}
+}
+
+// checkJSONUTF8 is checkUTF8 for the JSON document data, before
+// encoding/json replaces the invalid bytes in its strings.
+func checkJSONUTF8(data []byte) ([]byte, error) {
+	if StringUTF8 == UTF8Keep || utf8.Valid(data) {
+		return data, nil
+	}
+	if StringUTF8 == UTF8Sanitize {
+		// Invalid bytes can only be within strings, where the escaped
+		// replacement decodes to itself.
+		repl, err := json.Marshal(StringUTF8Replacement)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.ToValidUTF8(data, repl[1:len(repl)-1]), nil
+	}
+	return nil, &UTF8Error{Type: "null.String", Offset: invalidUTF8(string(data))}
+}
+
+// invalidUTF8 returns the offset of the first invalid byte in s.
+func invalidUTF8(s string) int {
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
 // SetValid changes this String's value and also sets it to be non-null.
 func (s *String) SetValid(v string) {
 	s.String = v
@@ -102,6 +276,22 @@ func (s *String) SetValid(v string) {
 	s.Set = true
 }
 
+// SetNull sets this String to an explicit null, zeroing its value.
+func (s *String) SetNull() {
+	*s = String{Set: true}
+}
+
+// Unset resets this String to the zero value, as if it was never set.
+func (s *String) Unset() {
+	*s = String{}
+}
+
+// SetPtr changes this String to the value p points to, or to an explicit
+// null if p is nil.
+func (s *String) SetPtr(p *string) {
+	*s = StringFromPtr(p)
+}
+
 // Ptr returns a pointer to this String's value, or a nil pointer if this String is null.
 func (s String) Ptr() *string {
 	if !s.Valid {
@@ -110,19 +300,330 @@ func (s String) Ptr() *string {
 	return &s.String
 }
 
-// IsZero returns true for null strings, for potential future omitempty support.
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (s String) ValueOrZero() string {
+	if !s.Valid {
+		return ""
+	}
+	return s.String
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (s String) Or(def string) string {
+	if !s.Valid {
+		return def
+	}
+	return s.String
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (s String) Val() string {
+	return s.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (s String) Get() (string, bool) {
+	return s.ValueOrZero(), s.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if s is
+// null.
+func (s String) MustGet() string {
+	if !s.Valid {
+		panic(&NullError{Type: "null.String"})
+	}
+	return s.String
+}
+
+// IsValidUTF8 reports whether s is null or holds valid UTF-8.
+func (s String) IsValidUTF8() bool {
+	return !s.Valid || utf8.ValidString(s.String)
+}
+
+// Sanitized returns s with each run of invalid UTF-8 in its value replaced
+// by repl.
+func (s String) Sanitized(repl string) String {
+	if s.Valid {
+		s.String = strings.ToValidUTF8(s.String, repl)
+	}
+	return s
+}
+
+// IsZero returns true if this String was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (s String) IsZero() bool {
-	return !s.Valid
+	return !s.Set && !s.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this String is null.
+func (s String) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.String, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null String if the input is null or ~.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	s.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		s.String, s.Valid = "", false
+		return err
+	}
+
+	var v string
+	if err := unmarshal(&v); err != nil {
+		s.String, s.Valid = "", false
+		return err
+	}
+	s.String, s.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this String is null.
+func (s String) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, s.Valid, s.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null String.
+func (s *String) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, s.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this String is null.
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, s.Valid, s.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null String.
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string, or BSON null if this String is null.
+func (s String) MarshalBSONValue() (byte, []byte, error) {
+	if !s.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonStringValue(s.String)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string or symbol. An empty string is valid.
+func (s *String) UnmarshalBSONValue(typ byte, data []byte) error {
+	s.Set = true
+	if bsonIsNull(typ) {
+		s.String, s.Valid = "", false
+		return nil
+	}
+	v, err := bsonText(typ, data, "null.String")
+	s.String, s.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string, or nil if this String is null.
+func (s String) MarshalMsgpack() ([]byte, error) {
+	if !s.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackString(nil, s.String), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string, or binary holding text. An empty string is
+// valid.
+func (s *String) UnmarshalMsgpack(data []byte) error {
+	s.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		s.String, s.Valid = "", false
+		return err
+	}
+	res, err := msgpackText(v, "null.String")
+	s.String, s.Valid = res, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string, or null if this String is null.
+func (s String) MarshalCBOR() ([]byte, error) {
+	if !s.Valid {
+		return cborNull, nil
+	}
+	return appendCBORString(nil, s.String), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string, or binary holding text. An empty string is
+// valid.
+func (s *String) UnmarshalCBOR(data []byte) error {
+	s.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		s.String, s.Valid = "", false
+		return err
+	}
+	res, err := cborText(v, "null.String")
+	s.String, s.Valid = res, err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (s String) MarshalGQL(w io.Writer) {
+	marshalGQL(w, s.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null String.
+func (s *String) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, s.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this String is
+// null.
+func (s String) MarshalCSV() (string, error) {
+	return marshalCSV(s.Valid, s.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null String.
+func (s *String) UnmarshalCSV(cell string) error {
+	return s.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null String, while an absent one is never passed, leaving
+// the field unset.
+func (s *String) UnmarshalParam(param string) error {
+	return s.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this String is null.
+func (s String) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(s.Valid, s.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null String.
+func (s *String) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, s.UnmarshalText, s.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes a STRING, or a typed NULL if this String is null.
+func (s String) EncodeSpanner() (interface{}, error) {
+	if !s.Valid {
+		return (*string)(nil), nil
+	}
+	return s.String, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts a STRING. NULL decodes to a null String.
+func (s *String) DecodeSpanner(input interface{}) error {
+	s.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		s.String, s.Valid = "", false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.String")
+	}
+	s.String, s.Valid = str, true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a value in the format of fieldType, such as a UUID for "uuid"
+// columns, or a random letter or digit, or an explicit null if shouldBeNull is true.
+func (s *String) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*s = NewString("", false)
+		return
+	}
+	str, ok := randomFormatted(nextInt, fieldType)
+	if !ok {
+		str = randomString(nextInt, 1)
+	}
+	s.SetValid(str)
+}
+
+// Generate implements testing/quick's Generator.
+func (s String) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewString("", false), func() String {
+		return StringFrom(generateText(rand, size))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (s String) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.Set, s.Valid, func() ([]byte, error) {
+		return []byte(s.String), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *String) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.String")
+	if err != nil {
+		return err
+	}
+	var v string
+	if valid {
+		v = string(p)
+	}
+	s.String, s.Valid, s.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (s *String) Scan(value interface{}) error {
+// Like database/sql does for plain string targets, it converts []byte,
+// integer, float, bool and time.Time driver values to their natural string
+// representation. Times are formatted as RFC 3339 with nanoseconds.
+func (s *String) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.String", value)
 	if value == nil {
-		s.String, s.Valid, s.Set = "", false, false
+		s.String, s.Valid, s.Set = "", false, true
 		return nil
 	}
-	s.Valid, s.Set = true, true
-	return convert.ConvertAssign(&s.String, value)
+	s.Set = true
+	err = convert.ConvertAssign(&s.String, value)
+	if err == nil {
+		s.String, err = checkUTF8(s.String)
+	}
+	if err == nil {
+		err = checkLen("null.String", MaxStringLen, len(s.String))
+	}
+	if err != nil {
+		s.String = ""
+	}
+	s.Valid = err == nil
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -133,10 +634,28 @@ func (s String) Value() (driver.Value, error) {
 	return s.String, nil
 }
 
-// String implements the Stringer interface
+// ToNullString converts s to a sql.NullString, dropping Set.
+func (s String) ToNullString() sql.NullString {
+	return sql.NullString{String: s.String, Valid: s.Valid}
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it.
 func (s String) GoString() string {
-	if s.Valid {
-		return fmt.Sprintf("Str(\"%s\")", s.String)
+	return goString("String", "", "String", strconv.Quote(s.String), s.String == "", s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// s with the verb if it is valid.
+func (s String) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, s.String)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value, or to nil if this String is null.
+func (s String) LogValue() slog.Value {
+	if !s.Valid {
+		return logNull
 	}
-	return "Str(invalid)"
+	return slog.StringValue(s.String)
 }
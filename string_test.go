@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -63,7 +64,8 @@ func TestUnmarshalString(t *testing.T) {
 
 	var invalid String
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullStr(t, invalid, "invalid json")
@@ -108,6 +110,118 @@ func TestMarshalString(t *testing.T) {
 	assertJSONEquals(t, data, "", "string marshal text")
 }
 
+func TestStringUTF8(t *testing.T) {
+	defer func(p UTF8Policy, repl string) {
+		StringUTF8, StringUTF8Replacement = p, repl
+	}(StringUTF8, StringUTF8Replacement)
+	bad := "ab\xffc\xc3"
+	decoders := map[string]func(*String) error{
+		"Scan string":   func(s *String) error { return s.Scan(bad) },
+		"Scan []byte":   func(s *String) error { return s.Scan([]byte(bad)) },
+		"UnmarshalText": func(s *String) error { return s.UnmarshalText([]byte(bad)) },
+		"UnmarshalJSON": func(s *String) error { return s.UnmarshalJSON([]byte(`"` + bad + `"`)) },
+	}
+
+	tests := []struct {
+		policy UTF8Policy
+		repl   string
+		want   string
+	}{
+		{UTF8Sanitize, "\uFFFD", "ab\uFFFDc\uFFFD"},
+		{UTF8Sanitize, "?", "ab?c?"},
+		{UTF8Sanitize, "", "abc"},
+		{UTF8Sanitize, `"<&>\`, `ab"<&>\c"<&>\`},
+	}
+	for _, test := range tests {
+		StringUTF8, StringUTF8Replacement = test.policy, test.repl
+		for name, decode := range decoders {
+			var s String
+			if err := decode(&s); err != nil || !s.Valid || s.String != test.want {
+				t.Errorf("%s with replacement %q = %q, %v, want %q", name, test.repl, s.String, err, test.want)
+			}
+		}
+	}
+
+	StringUTF8 = UTF8Reject
+	for name, decode := range decoders {
+		s := StringFrom("stale")
+		err := decode(&s)
+		var ue *UTF8Error
+		if !errors.As(err, &ue) || ue.Type != "null.String" || !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s = %v, want a *UTF8Error", name, err)
+		}
+		want := 2
+		if name == "UnmarshalJSON" {
+			want++ // past the opening quote
+		}
+		if ue != nil && ue.Offset != want {
+			t.Errorf("%s error offset = %d, want %d", name, ue.Offset, want)
+		}
+		if s.Valid || !s.Set || s.String != "" {
+			t.Errorf("%s after rejection = %#v, want a set null", name, s)
+		}
+	}
+	var ok String
+	if err := ok.UnmarshalJSON([]byte(`"h\u00e9llo \u2028"`)); err != nil || ok.String != "héllo \u2028" {
+		t.Errorf("valid UTF-8 rejected: %#v, %v", ok, err)
+	}
+
+	StringUTF8 = UTF8Keep
+	for name, decode := range decoders {
+		var s String
+		if err := decode(&s); err != nil || !s.Valid {
+			t.Errorf("%s by default = %#v, %v", name, s, err)
+		}
+		if want := bad; name != "UnmarshalJSON" && s.String != want {
+			t.Errorf("%s by default = %q, want %q unchanged", name, s.String, want)
+		}
+	}
+
+	s := StringFrom(bad)
+	if s.IsValidUTF8() || !StringFrom("héllo").IsValidUTF8() || !NewString(bad, false).IsValidUTF8() {
+		t.Error("bad IsValidUTF8")
+	}
+	if clean := s.Sanitized("?"); clean.String != "ab?c?" || !clean.Valid || !clean.Set || !clean.IsValidUTF8() {
+		t.Errorf("Sanitized = %#v", clean)
+	}
+	if null := NewString(bad, false).Sanitized("?"); null.String != bad || null.Valid {
+		t.Errorf("Sanitized null = %#v, want it unchanged", null)
+	}
+}
+
+func TestStringJSONEmptyNull(t *testing.T) {
+	defer func(v bool) { StringJSONEmptyNull = v }(StringJSONEmptyNull)
+	StringJSONEmptyNull = true
+
+	for _, null := range []String{{}, NewString("stale", false)} {
+		data, err := json.Marshal(null)
+		maybePanic(err)
+		assertJSONEquals(t, data, `""`, "null json marshal with StringJSONEmptyNull")
+	}
+	data, err := json.Marshal(StringFrom("test"))
+	maybePanic(err)
+	assertJSONEquals(t, data, `"test"`, "json marshal with StringJSONEmptyNull")
+
+	var str String
+	err = json.Unmarshal([]byte(`""`), &str)
+	maybePanic(err)
+	assertNullStr(t, str, `"" json with StringJSONEmptyNull`)
+	err = json.Unmarshal([]byte(`null`), &str)
+	maybePanic(err)
+	assertNullStr(t, str, "null json with StringJSONEmptyNull")
+	err = json.Unmarshal([]byte(`"test"`), &str)
+	maybePanic(err)
+	assertStr(t, str, "json with StringJSONEmptyNull")
+
+	// Text and SQL are unaffected.
+	if v, _ := NewString("", false).Value(); v != nil {
+		t.Errorf("Value() = %#v, want nil", v)
+	}
+	if err := str.Scan(""); err != nil || !str.Valid {
+		t.Errorf("Scan(\"\") = %#v, %v, want a valid empty string", str, err)
+	}
+}
+
 // Tests omitempty... broken until Go 1.4
 // type stringInStruct struct {
 // 	Test String `json:"test,omitempty"`
@@ -150,7 +264,12 @@ func TestStringIsZero(t *testing.T) {
 	}
 
 	null := StringFromPtr(nil)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset String
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 }
@@ -168,10 +287,47 @@ func TestStringScan(t *testing.T) {
 	maybePanic(err)
 	assertStr(t, str, "scanned string")
 
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{[]byte("test"), "test"},
+		{int64(-42), "-42"},
+		{uint64(42), "42"},
+		{float64(1.5), "1.5"},
+		{true, "true"},
+		{timeValue, timeString},
+	}
+	for _, test := range tests {
+		var conv String
+		err = conv.Scan(test.in)
+		maybePanic(err)
+		if !conv.Valid || conv.String != test.want {
+			t.Errorf("bad scanned %T: %q ≠ %q", test.in, conv.String, test.want)
+		}
+	}
+
+	buf := []byte("test")
+	var copied String
+	err = copied.Scan(buf)
+	maybePanic(err)
+	buf[0] = 'b'
+	assertStr(t, copied, "scanned []byte after reuse")
+
+	var wrong String
+	err = wrong.Scan(struct{}{})
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullStr(t, wrong, "scanned wrong type")
+
 	var null String
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullStr(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func maybePanic(err error) {
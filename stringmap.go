@@ -0,0 +1,694 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StringMapValueMode is the driver value StringMap.Value returns; see
+// StringMapValueAs.
+type StringMapValueMode int
+
+const (
+	// StringMapValueHstore returns a Postgres hstore literal such as
+	// "a"=>"1", "b"=>"2" as a string, the default.
+	StringMapValueHstore StringMapValueMode = iota
+	// StringMapValueJSON returns a JSON object, as Map.Value does, for json
+	// and jsonb columns.
+	StringMapValueJSON
+)
+
+// StringMapValueAs sets what StringMap.Value returns for a valid StringMap.
+// A null StringMap is nil in every mode. Scan accepts both forms whatever
+// the mode.
+var StringMapValueAs = StringMapValueHstore
+
+// StringMap is a nullable map[string]string, such as a Postgres hstore
+// column or a json column that maps strings to strings. It encodes as a
+// JSON object of strings, and scans and values the hstore literal format,
+// so no driver-specific hstore type is needed. JSON null values decode as
+// empty strings, as encoding/json decodes them into a map[string]string, but
+// Scan rejects NULL hstore values, which Value would otherwise write back as
+// empty strings.
+//
+// Constructors and SetValid copy the map, so the caller's map can be
+// changed afterwards without affecting the StringMap.
+type StringMap struct {
+	StringMap map[string]string
+	Valid     bool
+	Set       bool
+}
+
+// NewStringMap creates a new StringMap holding a copy of m.
+func NewStringMap(m map[string]string, valid bool) StringMap {
+	return StringMap{
+		StringMap: maps.Clone(m),
+		Valid:     valid,
+		Set:       true,
+	}
+}
+
+// StringMapFrom creates a new StringMap that will always be valid.
+// A nil map is valid and marshals to {}.
+func StringMapFrom(m map[string]string) StringMap {
+	return NewStringMap(m, true)
+}
+
+// StringMapFromPtr creates a new StringMap that will be null if m is nil.
+func StringMapFromPtr(m *map[string]string) StringMap {
+	if m == nil {
+		return NewStringMap(nil, false)
+	}
+	return NewStringMap(*m, true)
+}
+
+// StringMapFromNonEmpty creates a new StringMap that will be null if m is
+// empty.
+func StringMapFromNonEmpty(m map[string]string) StringMap {
+	return NewStringMap(m, len(m) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (m StringMap) IsValid() bool {
+	return m.Set && m.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (m StringMap) IsSet() bool {
+	return m.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (m StringMap) IsNull() bool {
+	return m.Set && !m.Valid
+}
+
+// Equal reports whether m and other are both null, or both valid with the
+// same keys and values. A nil map equals an empty one. Set is ignored; see
+// ExactEqual.
+func (m StringMap) Equal(other StringMap) bool {
+	if !m.Valid || !other.Valid {
+		return m.Valid == other.Valid
+	}
+	return maps.Equal(m.StringMap, other.StringMap)
+}
+
+// ExactEqual is like Equal, but also requires m and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (m StringMap) ExactEqual(other StringMap) bool {
+	return m.Set == other.Set && m.Equal(other)
+}
+
+// Clone returns a copy of m with its own copy of the map, so that
+// changes to either do not show in the other.
+func (m StringMap) Clone() StringMap {
+	m.StringMap = maps.Clone(m.StringMap)
+	return m
+}
+
+// Len returns the number of keys, which is 0 if this StringMap is null.
+func (m StringMap) Len() int {
+	if !m.Valid {
+		return 0
+	}
+	return len(m.StringMap)
+}
+
+// Get returns the value stored under key, and whether it was present.
+// A null StringMap has no keys.
+func (m StringMap) Get(key string) (string, bool) {
+	if !m.Valid {
+		return "", false
+	}
+	v, ok := m.StringMap[key]
+	return v, ok
+}
+
+// SetKey stores value under key, making a null or unset StringMap a valid
+// one holding only that key. It is not named Set, which is taken by the
+// field. Like any map write it shows in copies of m that share its map;
+// see Clone.
+func (m *StringMap) SetKey(key, value string) {
+	if !m.Valid || m.StringMap == nil {
+		m.StringMap = map[string]string{}
+	}
+	m.StringMap[key] = value
+	m.Valid, m.Set = true, true
+}
+
+// Delete removes key, if present. A null or unset StringMap is left as it
+// is, and removing the last key leaves a valid empty map rather than null.
+func (m *StringMap) Delete(key string) {
+	if m.Valid {
+		delete(m.StringMap, key)
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null, and objects whose values are strings or null.
+func (m *StringMap) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.StringMap", m, data)
+	m.Set = true
+	m.StringMap, m.Valid = nil, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+
+	res := map[string]string{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	m.StringMap, m.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this StringMap is null.
+func (m StringMap) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return NullBytes, nil
+	}
+	if m.StringMap == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.StringMap)
+}
+
+// SetValid changes this StringMap's value to a copy of v and also sets it
+// to be non-null.
+func (m *StringMap) SetValid(v map[string]string) {
+	m.StringMap = maps.Clone(v)
+	m.Valid = true
+	m.Set = true
+}
+
+// SetNull sets this StringMap to an explicit null, zeroing its value.
+func (m *StringMap) SetNull() {
+	*m = StringMap{Set: true}
+}
+
+// Unset resets this StringMap to the zero value, as if it was never set.
+func (m *StringMap) Unset() {
+	*m = StringMap{}
+}
+
+// SetPtr changes this StringMap to a copy of the value p points to, or to
+// an explicit null if p is nil.
+func (m *StringMap) SetPtr(p *map[string]string) {
+	*m = StringMapFromPtr(p)
+}
+
+// Ptr returns a pointer to this StringMap's value, or a nil pointer if this
+// StringMap is null.
+func (m StringMap) Ptr() *map[string]string {
+	if !m.Valid {
+		return nil
+	}
+	return &m.StringMap
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (m StringMap) ValueOrZero() map[string]string {
+	if !m.Valid {
+		return nil
+	}
+	return m.StringMap
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (m StringMap) Or(def map[string]string) map[string]string {
+	if !m.Valid {
+		return def
+	}
+	return m.StringMap
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (m StringMap) Val() map[string]string {
+	return m.ValueOrZero()
+}
+
+// MustGet returns the inner value, or panics with a *NullError if m is
+// null.
+func (m StringMap) MustGet() map[string]string {
+	if !m.Valid {
+		panic(&NullError{Type: "null.StringMap"})
+	}
+	return m.StringMap
+}
+
+// IsZero returns true if this StringMap was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (m StringMap) IsZero() bool {
+	return !m.Set && !m.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this StringMap is null.
+func (m StringMap) MarshalYAML() (interface{}, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	if m.StringMap == nil {
+		return map[string]string{}, nil
+	}
+	return m.StringMap, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null StringMap if the input is null or ~.
+func (m *StringMap) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	m.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+
+	res := map[string]string{}
+	if err := unmarshal(&res); err != nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	m.StringMap, m.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this StringMap is null.
+func (m StringMap) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null StringMap.
+func (m *StringMap) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(m.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this StringMap is null.
+func (m StringMap) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null StringMap.
+func (m *StringMap) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(m.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON document of strings, or BSON null if this StringMap is
+// null.
+func (m StringMap) MarshalBSONValue() (byte, []byte, error) {
+	if !m.Valid {
+		return bsonNull, nil, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON document, decoded as UnmarshalJSON decodes JSON.
+func (m *StringMap) UnmarshalBSONValue(typ byte, data []byte) error {
+	m.Set = true
+	if bsonIsNull(typ) {
+		m.StringMap, m.Valid = nil, false
+		return nil
+	}
+	if typ != bsonDocument {
+		m.StringMap, m.Valid = nil, false
+		return bsonTypeError(typ, "null.StringMap")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack map of strings, or nil if this StringMap is null.
+func (m StringMap) MarshalMsgpack() ([]byte, error) {
+	if !m.Valid {
+		return msgpackNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack map, decoded as UnmarshalJSON decodes JSON.
+func (m *StringMap) UnmarshalMsgpack(data []byte) error {
+	m.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.StringMap, m.Valid = nil, false
+		return msgpackTypeError(v, "null.StringMap")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR map of strings, or null if this StringMap is null.
+func (m StringMap) MarshalCBOR() ([]byte, error) {
+	if !m.Valid {
+		return cborNull, nil
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR map, decoded as UnmarshalJSON decodes JSON.
+func (m *StringMap) UnmarshalCBOR(data []byte) error {
+	m.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	if _, ok := v.(decodedMap); !ok {
+		m.StringMap, m.Valid = nil, false
+		return cborTypeError(v, "null.StringMap")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		m.StringMap, m.Valid = nil, false
+		return err
+	}
+	return m.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (m StringMap) MarshalGQL(w io.Writer) {
+	marshalGQL(w, m.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null StringMap.
+func (m *StringMap) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, m.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this
+// StringMap is null.
+func (m StringMap) MarshalCSV() (string, error) {
+	return marshalCSV(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null StringMap.
+func (m *StringMap) UnmarshalCSV(cell string) error {
+	return jsonText(m.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this StringMap is null.
+func (m StringMap) MarshalTOML() ([]byte, error) {
+	return marshalTOML(m.Valid, m.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null StringMap.
+func (m *StringMap) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, m.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a map of one random string, or an explicit null if shouldBeNull
+// is true.
+func (m *StringMap) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*m = NewStringMap(nil, false)
+		return
+	}
+	m.StringMap, m.Valid, m.Set = map[string]string{"key": randomString(nextInt, 8)}, true, true
+}
+
+// Generate implements testing/quick's Generator.
+func (m StringMap) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewStringMap(nil, false), func() StringMap {
+		m := make(map[string]string)
+		for i := generateLen(rand, size); i > 0; i-- {
+			m[generateText(rand, size)] = generateText(rand, size)
+		}
+		return StringMap{StringMap: m, Valid: true, Set: true}
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The map is
+// encoded as JSON.
+func (m StringMap) MarshalBinary() ([]byte, error) {
+	return marshalBinary(m.Set, m.Valid, func() ([]byte, error) {
+		return m.MarshalJSON()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *StringMap) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.StringMap")
+	if err != nil {
+		return err
+	}
+	var v map[string]string
+	if valid {
+		v = map[string]string{}
+		if err = json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+	}
+	m.StringMap, m.Valid, m.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It parses a string or []byte holding a JSON object, or else a Postgres
+// hstore literal such as "a"=>"1", b=>"2", in which of repeated keys the
+// first is kept, as in Postgres. A NULL value is an error matching
+// ErrNullValue, as a map[string]string cannot hold it.
+func (m *StringMap) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.StringMap", value)
+	var s string
+	switch x := value.(type) {
+	case nil:
+		m.StringMap, m.Valid, m.Set = nil, false, true
+		return nil
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	default:
+		m.StringMap, m.Valid, m.Set = nil, false, true
+		return fmt.Errorf("null: cannot scan type %T into null.StringMap: %v", value, value)
+	}
+	if strings.HasPrefix(strings.TrimLeft(s, " \t\r\n"), "{") {
+		return m.UnmarshalJSON([]byte(s))
+	}
+	res, err := parseHstore(s)
+	m.Set = true
+	m.Valid = err == nil
+	m.StringMap = res
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The map is encoded as an hstore literal with every key and value quoted
+// and the keys sorted, or as JSON with StringMapValueJSON, returned as a
+// string if JSONValueAsString is set.
+func (m StringMap) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	if StringMapValueAs == StringMapValueJSON {
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if JSONValueAsString {
+			return string(data), nil
+		}
+		return data, nil
+	}
+	var b strings.Builder
+	for i, k := range slices.Sorted(maps.Keys(m.StringMap)) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeHstoreString(&b, k)
+		b.WriteString("=>")
+		writeHstoreString(&b, m.StringMap[k])
+	}
+	return b.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (m StringMap) String() string {
+	return fmt.Sprint(m)
+}
+
+// GoString implements the GoStringer interface, formatting m as the Go
+// expression that builds it.
+func (m StringMap) GoString() string {
+	return goString("StringMap", "", "StringMap", fmt.Sprintf("%#v", m.StringMap), m.StringMap == nil, m.Valid, m.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// m with the verb if it is valid.
+func (m StringMap) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, m, m.Valid, m.Set, m.StringMap)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the map, or to nil if this StringMap is
+// null.
+func (m StringMap) LogValue() slog.Value {
+	if !m.Valid {
+		return logNull
+	}
+	return slog.AnyValue(m.StringMap)
+}
+
+// writeHstoreString writes s to b as a quoted hstore key or value.
+func writeHstoreString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+// parseHstore parses a Postgres hstore literal. Keys and values may be
+// quoted, with backslash escapes, or bare; a bare NULL value is an error.
+func parseHstore(src string) (map[string]string, error) {
+	bad := func(why string) error {
+		return fmt.Errorf("null: cannot parse %q into null.StringMap: %s", src, why)
+	}
+	isSpace := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	}
+	s := src
+	i := 0
+	skipSpace := func() {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+	}
+	// next reads a key or value, reporting whether it was quoted.
+	next := func(what string) (string, bool, error) {
+		var elem strings.Builder
+		if i < len(s) && s[i] == '"' {
+			i++
+			for i < len(s) {
+				c := s[i]
+				i++
+				if c == '\\' && i < len(s) {
+					c = s[i]
+					i++
+				} else if c == '"' {
+					return elem.String(), true, nil
+				}
+				elem.WriteByte(c)
+			}
+			return "", false, bad("unterminated quoted " + what)
+		}
+		for i < len(s) && !isSpace(s[i]) && s[i] != ',' && s[i] != '=' && s[i] != '"' {
+			c := s[i]
+			i++
+			if c == '\\' && i < len(s) {
+				c = s[i]
+				i++
+			}
+			elem.WriteByte(c)
+		}
+		if elem.Len() == 0 {
+			return "", false, bad("missing " + what)
+		}
+		return elem.String(), false, nil
+	}
+
+	res := map[string]string{}
+	skipSpace()
+	for i < len(s) {
+		key, _, err := next("key")
+		if err != nil {
+			return nil, err
+		}
+		skipSpace()
+		if !strings.HasPrefix(s[i:], "=>") {
+			return nil, bad("expected =>")
+		}
+		i += 2
+		skipSpace()
+		start := i
+		value, quoted, err := next("value")
+		if err != nil {
+			return nil, err
+		}
+		if !quoted && strings.EqualFold(s[start:i], "NULL") {
+			return nil, nullValuef("null: cannot parse %q into null.StringMap: the value of %q is NULL", src, key)
+		}
+		if _, ok := res[key]; !ok {
+			res[key] = value
+		}
+		skipSpace()
+		if i == len(s) {
+			break
+		}
+		if s[i] != ',' {
+			return nil, bad("expected ,")
+		}
+		i++
+		skipSpace()
+		if i == len(s) {
+			return nil, bad("trailing ,")
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,192 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStringMapJSON(t *testing.T) {
+	tests := []struct {
+		in   StringMap
+		want string
+	}{
+		{NewStringMap(nil, false), `null`},
+		{StringMapFrom(nil), `{}`},
+		{StringMapFrom(map[string]string{"b": "2", "a": `"q"`}), `{"a":"\"q\"","b":"2"}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	var m StringMap
+	maybePanic(json.Unmarshal([]byte(`{"a":"x","b":null}`), &m))
+	if !reflect.DeepEqual(m, StringMapFrom(map[string]string{"a": "x", "b": ""})) {
+		t.Errorf("unmarshal = %#v", m)
+	}
+	maybePanic(json.Unmarshal([]byte(`null`), &m))
+	if !m.IsNull() || m.StringMap != nil {
+		t.Errorf("unmarshal null = %#v, want a set null", m)
+	}
+	for _, bad := range []string{`[]`, `"a"`, `{"a":1}`, `{"a":["b"]}`} {
+		var m StringMap
+		if err := json.Unmarshal([]byte(bad), &m); err == nil || m.Valid || m.StringMap != nil {
+			t.Errorf("unmarshal %s = %#v, %v, want an error", bad, m, err)
+		}
+	}
+}
+
+func TestStringMapScan(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		want map[string]string
+	}{
+		{``, map[string]string{}},
+		{`"a"=>"1"`, map[string]string{"a": "1"}},
+		{[]byte(`"a"=>"1", "b"=>"2"`), map[string]string{"a": "1", "b": "2"}},
+		{`"b"=>"NULL", "c"=>""`, map[string]string{"b": "NULL", "c": ""}},
+		{` a => 1 ,b=>nullable `, map[string]string{"a": "1", "b": "nullable"}},
+		{`"k\"ey"=>"va\\l", "x y"=>"=>,"`, map[string]string{`k"ey`: `va\l`, "x y": "=>,"}},
+		{`"a"=>"1", "a"=>"2"`, map[string]string{"a": "1"}},
+		{`"é"=>"ü"`, map[string]string{"é": "ü"}},
+		{` {"a":"1","b":null}`, map[string]string{"a": "1", "b": ""}},
+	}
+	for _, test := range tests {
+		var m StringMap
+		if err := m.Scan(test.in); err != nil {
+			t.Errorf("Scan(%q): %v", test.in, err)
+			continue
+		}
+		if !m.Valid || !m.Set || !reflect.DeepEqual(m.StringMap, test.want) {
+			t.Errorf("Scan(%q) = %#v, want %#v", test.in, m, test.want)
+		}
+	}
+
+	var null StringMap
+	maybePanic(null.Scan(nil))
+	if !null.IsNull() {
+		t.Errorf("Scan(nil) = %#v, want a set null", null)
+	}
+
+	for _, bad := range []interface{}{
+		`"a"`, `"a"=>`, `"a"=>"1",`, `"a"=>"1" "b"=>"2"`, `"a=>"1"`, `"a"=>"1`, `a=1`, `=>"1"`, `{"a":1}`, 42,
+	} {
+		m := StringMapFrom(map[string]string{"old": ""})
+		if err := m.Scan(bad); err == nil || m.Valid || m.StringMap != nil {
+			t.Errorf("Scan(%#v) = %#v, %v, want an error", bad, m, err)
+		}
+	}
+
+	// NULL values are rejected rather than written back as empty strings.
+	for _, in := range []interface{}{[]byte(`"a"=>"1", "b"=>NULL`), ` a => 1 ,b=>null `} {
+		m := StringMapFrom(map[string]string{"old": ""})
+		err := m.Scan(in)
+		if !errors.Is(err, ErrNullValue) || !m.IsNull() {
+			t.Errorf("Scan(%q) = %#v, %v, want a set null and ErrNullValue", in, m, err)
+		}
+	}
+}
+
+func TestStringMapValue(t *testing.T) {
+	defer func(mode StringMapValueMode) { StringMapValueAs = mode }(StringMapValueAs)
+
+	m := StringMapFrom(map[string]string{"b": `"2"`, "a": `c:\`, "": ""})
+	v, err := m.Value()
+	maybePanic(err)
+	want := `""=>"", "a"=>"c:\\", "b"=>"\"2\""`
+	if v != want {
+		t.Errorf("Value() = %#v, want %#v", v, want)
+	}
+	var back StringMap
+	maybePanic(back.Scan(v))
+	if !back.Equal(m) {
+		t.Errorf("Scan(Value()) = %#v, want %#v", back, m)
+	}
+	if v, err := StringMapFrom(nil).Value(); err != nil || v != "" {
+		t.Errorf("Value() of an empty map = %#v, %v", v, err)
+	}
+
+	StringMapValueAs = StringMapValueJSON
+	v, err = StringMapFrom(map[string]string{"a": "1"}).Value()
+	maybePanic(err)
+	if b, ok := v.([]byte); !ok || string(b) != `{"a":"1"}` {
+		t.Errorf("Value() in JSON mode = %#v", v)
+	}
+
+	for _, mode := range []StringMapValueMode{StringMapValueHstore, StringMapValueJSON} {
+		StringMapValueAs = mode
+		if v, err := NewStringMap(map[string]string{"a": "1"}, false).Value(); v != nil || err != nil {
+			t.Errorf("Value() of null in mode %d = %#v, %v", mode, v, err)
+		}
+	}
+}
+
+func TestStringMapKeys(t *testing.T) {
+	var m StringMap
+	if v, ok := m.Get("a"); v != "" || ok || m.Len() != 0 {
+		t.Errorf("Get() of unset = %q, %t", v, ok)
+	}
+	m.Delete("a")
+	if m.IsSet() {
+		t.Errorf("Delete() on unset = %#v, want it left unset", m)
+	}
+
+	m.SetNull()
+	m.Delete("a")
+	if !m.IsNull() {
+		t.Errorf("Delete() on null = %#v, want it left null", m)
+	}
+	m.SetKey("a", "1")
+	if v, ok := m.Get("a"); !m.Valid || v != "1" || !ok || m.Len() != 1 {
+		t.Errorf("SetKey() on null = %#v", m)
+	}
+	m.SetKey("b", "")
+	if v, ok := m.Get("b"); v != "" || !ok || m.Len() != 2 {
+		t.Errorf("Get() of an empty value = %q, %t", v, ok)
+	}
+	m.Delete("a")
+	m.Delete("b")
+	if !m.Valid || m.Len() != 0 || m.StringMap == nil {
+		t.Errorf("Delete() of every key = %#v, want a valid empty map", m)
+	}
+
+	// A null with a stale map must not bring its keys back.
+	stale := StringMap{StringMap: map[string]string{"old": "x"}, Set: true}
+	if _, ok := stale.Get("old"); ok {
+		t.Error("Get() of null should find nothing")
+	}
+	stale.SetKey("new", "y")
+	if !reflect.DeepEqual(stale.StringMap, map[string]string{"new": "y"}) {
+		t.Errorf("SetKey() on null = %#v", stale)
+	}
+}
+
+func TestStringMapCopies(t *testing.T) {
+	src := map[string]string{"a": "1"}
+	m := StringMapFrom(src)
+	p := StringMapFromPtr(&src)
+	var set StringMap
+	set.SetValid(src)
+	src["a"] = "changed"
+	for _, m := range []StringMap{m, p, set} {
+		if m.StringMap["a"] != "1" {
+			t.Errorf("%#v shares its map with the caller", m)
+		}
+	}
+
+	clone := m.Clone()
+	clone.SetKey("b", "2")
+	if m.Len() != 1 {
+		t.Errorf("Clone() shares its map: %#v", m)
+	}
+
+	if StringMapFromPtr(nil).Valid || StringMapFromNonEmpty(map[string]string{}).Valid ||
+		!StringMapFromNonEmpty(map[string]string{"a": ""}).Valid {
+		t.Error("bad validity from StringMapFromPtr or StringMapFromNonEmpty")
+	}
+}
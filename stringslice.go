@@ -0,0 +1,621 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StringSlice is a nullable []string, such as a Postgres text[] column.
+// It scans and values the Postgres array literal format, so no
+// driver-specific array type is needed. NULL array elements scan as
+// empty strings.
+type StringSlice struct {
+	StringSlice []string
+	Valid       bool
+	Set         bool
+}
+
+// NewStringSlice creates a new StringSlice. It shares s with the caller
+// instead of copying it; see Clone.
+func NewStringSlice(s []string, valid bool) StringSlice {
+	return StringSlice{
+		StringSlice: s,
+		Valid:       valid,
+		Set:         true,
+	}
+}
+
+// StringSliceFrom creates a new StringSlice that will always be valid.
+// A nil slice is valid and marshals to an empty array.
+func StringSliceFrom(s []string) StringSlice {
+	return NewStringSlice(s, true)
+}
+
+// StringSliceFromPtr creates a new StringSlice that will be null if s is nil.
+func StringSliceFromPtr(s *[]string) StringSlice {
+	if s == nil {
+		return NewStringSlice(nil, false)
+	}
+	return NewStringSlice(*s, true)
+}
+
+// StringSliceFromNonEmpty creates a new StringSlice that will be null if s is empty.
+func StringSliceFromNonEmpty(s []string) StringSlice {
+	return NewStringSlice(s, len(s) > 0)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (s StringSlice) IsValid() bool {
+	return s.Set && s.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (s StringSlice) IsSet() bool {
+	return s.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (s StringSlice) IsNull() bool {
+	return s.Set && !s.Valid
+}
+
+// Equal reports whether s and other are both null, or both valid with
+// the same elements. Set is ignored; see ExactEqual.
+func (s StringSlice) Equal(other StringSlice) bool {
+	if !s.Valid || !other.Valid {
+		return s.Valid == other.Valid
+	}
+	return slicesEqual(s.StringSlice, other.StringSlice)
+}
+
+// ExactEqual is like Equal, but also requires s and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (s StringSlice) ExactEqual(other StringSlice) bool {
+	return s.Set == other.Set && s.Equal(other)
+}
+
+// Clone returns a copy of s with its own copy of the slice, so that
+// changes to either do not show in the other.
+func (s StringSlice) Clone() StringSlice {
+	s.StringSlice = slices.Clone(s.StringSlice)
+	return s
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports array and null input.
+func (s *StringSlice) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.StringSlice", s, data)
+	s.Set = true
+	if bytes.Equal(data, NullBytes) {
+		s.StringSlice, s.Valid = nil, false
+		return nil
+	}
+
+	res := []string{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	s.StringSlice, s.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this StringSlice is null.
+func (s StringSlice) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return NullBytes, nil
+	}
+	if s.StringSlice == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.StringSlice)
+}
+
+// SetValid changes this StringSlice's value and also sets it to be non-null.
+func (s *StringSlice) SetValid(v []string) {
+	s.StringSlice = v
+	s.Valid = true
+	s.Set = true
+}
+
+// SetNull sets this StringSlice to an explicit null, zeroing its value.
+func (s *StringSlice) SetNull() {
+	*s = StringSlice{Set: true}
+}
+
+// Unset resets this StringSlice to the zero value, as if it was never set.
+func (s *StringSlice) Unset() {
+	*s = StringSlice{}
+}
+
+// SetPtr changes this StringSlice to the value p points to, or to an explicit
+// null if p is nil.
+func (s *StringSlice) SetPtr(p *[]string) {
+	*s = StringSliceFromPtr(p)
+}
+
+// Ptr returns a pointer to this StringSlice's value, or a nil pointer if this StringSlice is null.
+func (s StringSlice) Ptr() *[]string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.StringSlice
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (s StringSlice) ValueOrZero() []string {
+	if !s.Valid {
+		return nil
+	}
+	return s.StringSlice
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (s StringSlice) Or(def []string) []string {
+	if !s.Valid {
+		return def
+	}
+	return s.StringSlice
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (s StringSlice) Val() []string {
+	return s.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (s StringSlice) Get() ([]string, bool) {
+	return s.ValueOrZero(), s.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if s is
+// null.
+func (s StringSlice) MustGet() []string {
+	if !s.Valid {
+		panic(&NullError{Type: "null.StringSlice"})
+	}
+	return s.StringSlice
+}
+
+// IsZero returns true if this StringSlice was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (s StringSlice) IsZero() bool {
+	return !s.Set && !s.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this StringSlice is null.
+func (s StringSlice) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	if s.StringSlice == nil {
+		return []string{}, nil
+	}
+	return s.StringSlice, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null StringSlice if the input is null or ~.
+func (s *StringSlice) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	s.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+
+	res := []string{}
+	if err := unmarshal(&res); err != nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	s.StringSlice, s.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this StringSlice is null.
+func (s StringSlice) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The content must be JSON, as for UnmarshalJSON. An empty element
+// unmarshals to a null StringSlice.
+func (s *StringSlice) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, jsonText(s.UnmarshalJSON))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this StringSlice is null.
+func (s StringSlice) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null StringSlice.
+func (s *StringSlice) UnmarshalXMLAttr(attr xml.Attr) error {
+	return jsonText(s.UnmarshalJSON)([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON array of strings, or BSON null if this StringSlice
+// is null.
+func (s StringSlice) MarshalBSONValue() (byte, []byte, error) {
+	if !s.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonArrayValue(len(s.StringSlice), func(i int) (byte, []byte, error) {
+		return bsonStringValue(s.StringSlice[i])
+	})
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON array, decoded as UnmarshalJSON decodes JSON.
+func (s *StringSlice) UnmarshalBSONValue(typ byte, data []byte) error {
+	s.Set = true
+	if bsonIsNull(typ) {
+		s.StringSlice, s.Valid = nil, false
+		return nil
+	}
+	if typ != bsonArray {
+		s.StringSlice, s.Valid = nil, false
+		return bsonTypeError(typ, "null.StringSlice")
+	}
+	v, err := bsonToJSON(typ, data)
+	if err != nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(v)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack array of strings, or nil if this StringSlice is
+// null.
+func (s StringSlice) MarshalMsgpack() ([]byte, error) {
+	if !s.Valid {
+		return msgpackNull, nil
+	}
+	res := appendMsgpackHeader(nil, false, len(s.StringSlice))
+	for _, e := range s.StringSlice {
+		res = appendMsgpackString(res, e)
+	}
+	return res, nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack array, decoded as UnmarshalJSON decodes JSON.
+func (s *StringSlice) UnmarshalMsgpack(data []byte) error {
+	s.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.StringSlice, s.Valid = nil, false
+		return msgpackTypeError(v, "null.StringSlice")
+	}
+	res, err := msgpackToJSON(v)
+	if err != nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR array of strings, or null if this StringSlice is null.
+func (s StringSlice) MarshalCBOR() ([]byte, error) {
+	if !s.Valid {
+		return cborNull, nil
+	}
+	res := appendCBORHead(nil, cborMajorArray, uint64(len(s.StringSlice)))
+	for _, e := range s.StringSlice {
+		res = appendCBORString(res, e)
+	}
+	return res, nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR array, decoded as UnmarshalJSON decodes JSON.
+func (s *StringSlice) UnmarshalCBOR(data []byte) error {
+	s.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	if _, ok := v.([]interface{}); !ok {
+		s.StringSlice, s.Valid = nil, false
+		return cborTypeError(v, "null.StringSlice")
+	}
+	res, err := cborToJSON(v)
+	if err != nil {
+		s.StringSlice, s.Valid = nil, false
+		return err
+	}
+	return s.UnmarshalJSON(res)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (s StringSlice) MarshalGQL(w io.Writer) {
+	marshalGQL(w, s.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null StringSlice.
+func (s *StringSlice) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, s.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It writes the same JSON as MarshalJSON, or an empty cell if this StringSlice is
+// null.
+func (s StringSlice) MarshalCSV() (string, error) {
+	return marshalCSV(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same JSON as UnmarshalJSON. An empty cell unmarshals to a
+// null StringSlice.
+func (s *StringSlice) UnmarshalCSV(cell string) error {
+	return jsonText(s.UnmarshalJSON)([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this StringSlice is null.
+func (s StringSlice) MarshalTOML() ([]byte, error) {
+	return marshalTOML(s.Valid, s.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null StringSlice.
+func (s *StringSlice) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, nil, s.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a list of one random string, or an explicit null if shouldBeNull is true.
+func (s *StringSlice) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*s = NewStringSlice(nil, false)
+		return
+	}
+	s.SetValid([]string{randomString(nextInt, 8)})
+}
+
+// Generate implements testing/quick's Generator.
+func (s StringSlice) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewStringSlice(nil, false), func() StringSlice {
+		s := make([]string, generateLen(rand, size))
+		for i := range s {
+			s[i] = generateText(rand, size)
+		}
+		return StringSliceFrom(s)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (s StringSlice) MarshalBinary() ([]byte, error) {
+	return marshalBinary(s.Set, s.Valid, func() ([]byte, error) {
+		return appendBinaryStrings(nil, s.StringSlice), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *StringSlice) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.StringSlice")
+	if err != nil {
+		return err
+	}
+	var v []string
+	if valid {
+		if v, err = binaryStrings(p, "null.StringSlice"); err != nil {
+			return err
+		}
+	}
+	s.StringSlice, s.Valid, s.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It parses a one-dimensional Postgres array literal such as {a,"b,c",NULL}.
+func (s *StringSlice) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.StringSlice", value)
+	var res []string
+	switch x := value.(type) {
+	case nil:
+		s.StringSlice, s.Valid, s.Set = nil, false, true
+		return nil
+	case string:
+		res, err = parseStringArray(x)
+	case []byte:
+		res, err = parseStringArray(string(x))
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.StringSlice: %v", value, value)
+	}
+	s.Set = true
+	s.Valid = err == nil
+	s.StringSlice = res
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The slice is encoded as a Postgres array literal with every element quoted.
+func (s StringSlice) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range s.StringSlice {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		for j := 0; j < len(e); j++ {
+			if e[j] == '"' || e[j] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(e[j])
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (s StringSlice) String() string {
+	return fmt.Sprint(s)
+}
+
+// GoString implements the GoStringer interface, formatting s as the Go
+// expression that builds it.
+func (s StringSlice) GoString() string {
+	return goString("StringSlice", "", "StringSlice", fmt.Sprintf("%#v", s.StringSlice), s.StringSlice == nil, s.Valid, s.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// s with the verb if it is valid.
+func (s StringSlice) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, s, s.Valid, s.Set, s.StringSlice)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to an Any value of the slice, or to nil if this StringSlice is
+// null.
+func (s StringSlice) LogValue() slog.Value {
+	if !s.Valid {
+		return logNull
+	}
+	return slog.AnyValue(s.StringSlice)
+}
+
+// parseStringArray parses a one-dimensional Postgres array literal.
+// Unquoted NULL elements become empty strings.
+func parseStringArray(src string) ([]string, error) {
+	bad := func(why string) error {
+		return fmt.Errorf("null: cannot parse %q into null.StringSlice: %s", src, why)
+	}
+
+	s := src
+	if strings.HasPrefix(s, "[") {
+		// Skip explicit bounds, as in "[0:1]={a,b}".
+		i := strings.Index(s, "=")
+		if i < 0 {
+			return nil, bad("bad dimension decoration")
+		}
+		s = s[i+1:]
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, bad("not an array literal")
+	}
+	s = s[1 : len(s)-1]
+
+	res := []string{}
+	if strings.TrimSpace(s) == "" {
+		return res, nil
+	}
+
+	for i := 0; ; {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		var elem strings.Builder
+		if i < len(s) && s[i] == '"' {
+			i++
+			closed := false
+			for i < len(s) {
+				c := s[i]
+				i++
+				if c == '\\' && i < len(s) {
+					c = s[i]
+					i++
+				} else if c == '"' {
+					closed = true
+					break
+				}
+				elem.WriteByte(c)
+			}
+			if !closed {
+				return nil, bad("unterminated quoted element")
+			}
+			for i < len(s) && s[i] == ' ' {
+				i++
+			}
+			res = append(res, elem.String())
+		} else {
+			escaped := false
+			for i < len(s) && s[i] != ',' {
+				c := s[i]
+				i++
+				switch c {
+				case '{', '}', '"':
+					return nil, bad("unexpected " + string(c))
+				case '\\':
+					if i < len(s) {
+						c = s[i]
+						i++
+						escaped = true
+					}
+				}
+				elem.WriteByte(c)
+			}
+			str := strings.TrimRight(elem.String(), " ")
+			if str == "" {
+				return nil, bad("empty element")
+			}
+			if !escaped && strings.EqualFold(str, "NULL") {
+				str = ""
+			}
+			res = append(res, str)
+		}
+
+		if i == len(s) {
+			return res, nil
+		}
+		if s[i] != ',' {
+			return nil, bad("expected ,")
+		}
+		i++
+	}
+}
+
+// slicesEqual reports whether a and b have the same elements. A nil slice
+// equals an empty one.
+func slicesEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
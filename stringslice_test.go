@@ -0,0 +1,194 @@
+package null
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	stringSliceValue = []string{"a", "b", "c,d"}
+	stringSliceJSON  = []byte(`["a","b","c,d"]`)
+)
+
+func TestStringSliceFrom(t *testing.T) {
+	s := StringSliceFrom(stringSliceValue)
+	assertStringSlice(t, s, "StringSliceFrom()")
+
+	nilSlice := StringSliceFrom(nil)
+	if !nilSlice.Valid {
+		t.Error("StringSliceFrom(nil)", "is invalid, but should be valid")
+	}
+}
+
+func TestStringSliceFromPtr(t *testing.T) {
+	v := stringSliceValue
+	s := StringSliceFromPtr(&v)
+	assertStringSlice(t, s, "StringSliceFromPtr()")
+
+	null := StringSliceFromPtr(nil)
+	assertNullStringSlice(t, null, "StringSliceFromPtr(nil)")
+}
+
+func TestUnmarshalStringSlice(t *testing.T) {
+	var s StringSlice
+	err := json.Unmarshal(stringSliceJSON, &s)
+	maybePanic(err)
+	assertStringSlice(t, s, "string slice json")
+
+	var empty StringSlice
+	err = json.Unmarshal([]byte(`[]`), &empty)
+	maybePanic(err)
+	if !empty.Valid || empty.StringSlice == nil || len(empty.StringSlice) != 0 {
+		t.Errorf("bad empty slice: %#v", empty)
+	}
+
+	var null StringSlice
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullStringSlice(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var badType StringSlice
+	err = json.Unmarshal([]byte(`[1,2]`), &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullStringSlice(t, badType, "wrong type json")
+}
+
+func TestMarshalStringSlice(t *testing.T) {
+	tests := []struct {
+		in   StringSlice
+		want string
+	}{
+		{StringSliceFrom(stringSliceValue), string(stringSliceJSON)},
+		{StringSliceFrom(nil), "[]"},
+		{StringSliceFrom([]string{}), "[]"},
+		{NewStringSlice(nil, false), "null"},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.in)
+		maybePanic(err)
+		assertJSONEquals(t, data, test.want, test.in.String())
+	}
+}
+
+func TestStringSliceScan(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{`{a,b,"c,d"}`, stringSliceValue},
+		{`{}`, []string{}},
+		{`{""}`, []string{""}},
+		{`{"say \"hi\"","back\\slash"}`, []string{`say "hi"`, `back\slash`}},
+		{`{a,NULL,"NULL",null}`, []string{"a", "", "NULL", ""}},
+		{`{ a , b }`, []string{"a", "b"}},
+		{`{a\,b}`, []string{"a,b"}},
+		{`[1:2]={x,y}`, []string{"x", "y"}},
+	}
+	for _, test := range tests {
+		for _, v := range []interface{}{test.in, []byte(test.in)} {
+			var s StringSlice
+			err := s.Scan(v)
+			maybePanic(err)
+			if !s.Valid || !reflect.DeepEqual(s.StringSlice, test.want) {
+				t.Errorf("Scan(%#v) = %#v, want %#v", v, s.StringSlice, test.want)
+			}
+		}
+	}
+
+	var null StringSlice
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullStringSlice(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	for _, v := range []interface{}{"a,b", "{a,b", `{"a}`, "{a,,b}", "{{a},{b}}", `{a"b}`, int64(1)} {
+		var wrong StringSlice
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullStringSlice(t, wrong, "scanned garbage")
+	}
+}
+
+func TestStringSliceValue(t *testing.T) {
+	tests := []struct {
+		in   StringSlice
+		want interface{}
+	}{
+		{StringSliceFrom(stringSliceValue), `{"a","b","c,d"}`},
+		{StringSliceFrom(nil), `{}`},
+		{StringSliceFrom([]string{}), `{}`},
+		{StringSliceFrom([]string{`say "hi"`, `back\slash`, "NULL", ""}), `{"say \"hi\"","back\\slash","NULL",""}`},
+		{NewStringSlice(nil, false), nil},
+	}
+	for _, test := range tests {
+		value, err := test.in.Value()
+		maybePanic(err)
+		if value != test.want {
+			t.Errorf("%v.Value() = %#v, want %#v", test.in, value, test.want)
+		}
+		if value == nil {
+			continue
+		}
+
+		var back StringSlice
+		err = back.Scan(value)
+		maybePanic(err)
+		if len(back.StringSlice) != len(test.in.StringSlice) {
+			t.Errorf("bad round trip of %v: %v", test.in, back)
+		}
+	}
+}
+
+func TestStringSlicePointer(t *testing.T) {
+	s := StringSliceFrom(stringSliceValue)
+	ptr := s.Ptr()
+	if !reflect.DeepEqual(*ptr, stringSliceValue) {
+		t.Errorf("bad %s string slice: %#v ≠ %v\n", "pointer", ptr, stringSliceValue)
+	}
+
+	null := NewStringSlice(nil, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s string slice: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestStringSliceSetValid(t *testing.T) {
+	change := NewStringSlice(nil, false)
+	assertNullStringSlice(t, change, "SetValid()")
+	change.SetValid(stringSliceValue)
+	assertStringSlice(t, change, "SetValid()")
+}
+
+func assertStringSlice(t *testing.T, s StringSlice, from string) {
+	if !reflect.DeepEqual(s.StringSlice, stringSliceValue) {
+		t.Errorf("bad %s string slice: %#v ≠ %#v\n", from, s.StringSlice, stringSliceValue)
+	}
+	if !s.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullStringSlice(t *testing.T, s StringSlice, from string) {
+	if s.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestStringSliceClone(t *testing.T) {
+	s := StringSliceFrom([]string{"a", "b"})
+	clone := s.Clone()
+	s.StringSlice[0] = "changed"
+	if !clone.Equal(StringSliceFrom([]string{"a", "b"})) || !clone.Set {
+		t.Errorf("Clone() after mutating original = %#v", clone)
+	}
+}
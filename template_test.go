@@ -0,0 +1,59 @@
+package null
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+type templateUser struct {
+	Nickname String
+	Age      Int64
+	Joined   Time
+	Admin    Bool
+}
+
+const userTemplate = `{{ .Nickname.Or "anonymous" }}|{{ .Nickname.Val }}|{{ .Age.Or 18 }}|{{ .Age.Val }}|` +
+	`{{ if .Joined.IsValid }}{{ .Joined.Val.Format "2006-01-02" }}{{ else }}never{{ end }}|` +
+	`{{ if .Admin.Val }}admin{{ else }}user{{ end }}|{{ .Admin.Or true }}|{{ .Nickname.IsSet }}`
+
+func TestTemplates(t *testing.T) {
+	joined := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	for _, test := range []struct {
+		name string
+		user templateUser
+		want string
+	}{
+		{
+			"valid",
+			templateUser{StringFrom("<bob>"), Int64From(42), TimeFrom(joined), BoolFrom(true)},
+			"<bob>|<bob>|42|42|2012-12-21|admin|true|true",
+		},
+		{
+			"null",
+			templateUser{NewString("", false), NewInt64(0, false), NewTime(time.Time{}, false), NewBool(false, false)},
+			"anonymous||18|0|never|user|true|true",
+		},
+		{
+			"unset",
+			templateUser{},
+			"anonymous||18|0|never|user|true|false",
+		},
+	} {
+		var text strings.Builder
+		err := template.Must(template.New("user").Parse(userTemplate)).Execute(&text, test.user)
+		maybePanic(err)
+		if text.String() != test.want {
+			t.Errorf("%s: text/template = %q, want %q", test.name, text.String(), test.want)
+		}
+
+		var html strings.Builder
+		err = htmltemplate.Must(htmltemplate.New("user").Parse(userTemplate)).Execute(&html, test.user)
+		maybePanic(err)
+		if want := strings.ReplaceAll(strings.ReplaceAll(test.want, "<", "&lt;"), ">", "&gt;"); html.String() != want {
+			t.Errorf("%s: html/template = %q, want %q", test.name, html.String(), want)
+		}
+	}
+}
@@ -2,11 +2,120 @@ package null
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// TimeFormat is the layout used by Time to marshal and unmarshal JSON and
+// text.
+// It defaults to RFC 3339 with nanoseconds, matching time.Time's own
+// JSON encoding.
+var TimeFormat = time.RFC3339Nano
+
+// TimeParseLayouts are further layouts that Time tries, in order, when
+// unmarshaling JSON and text that TimeFormat does not parse, for input from
+// sources that disagree on a format:
+//
+//	null.TimeParseLayouts = []string{"2006-01-02 15:04:05", "2006-01-02"}
+//
+// The first layout that parses wins, and a time without an offset is in
+// UTC. TimeFormat stays the layout that Time marshals with. If no layout
+// parses, the error lists every layout tried.
+var TimeParseLayouts []string
+
+// CSVTimeFormat is the layout used by Time to marshal and unmarshal CSV
+// cells. Spreadsheets rarely emit RFC 3339, so set it to match the export,
+// for example "2006-01-02 15:04:05". Times parsed with a layout that has no
+// time zone are in UTC.
+var CSVTimeFormat = time.RFC3339Nano
+
+// TimeValueUTC makes Time convert to UTC in Value, MarshalJSON and
+// MarshalText, so a timestamp column without a time zone gets the same wall
+// clock whatever Location each writer's times carry. The stored time.Time is
+// left as it is.
+var TimeValueUTC = false
+
+// TimeTruncate makes Time truncate to TimePrecision in Value, MarshalJSON
+// and MarshalText, so a value written to a column of lower precision, such
+// as a Postgres timestamp, matches what is read back. The stored time.Time
+// is left as it is.
+var TimeTruncate = false
+
+// TimePrecision is what TimeTruncate and TimeEqualTruncate truncate to. It
+// defaults to a microsecond, the precision of Postgres and MySQL
+// timestamps.
+var TimePrecision = time.Microsecond
+
+// TimeEqualTruncate makes Time.Equal, Compare, Before, After and Between
+// compare times truncated to TimePrecision, so a value read back from the
+// database equals the one that was written.
+var TimeEqualTruncate = false
+
+// TimeScanUnixMillis makes Time.Scan read every integer as Unix
+// milliseconds, for BIGINT columns of epoch millis. By default an integer
+// is read as Unix seconds if its magnitude is below 1e10, which is up to
+// 2286-11-20 17:46:39 UTC, and as milliseconds otherwise, which is from
+// 1970-04-26 onwards; set it when millis columns can hold earlier times.
+var TimeScanUnixMillis = false
+
+// TimeScanLocation is the Location in which Time.Scan reads string and
+// []byte timestamps that have no offset, such as MySQL DATETIME text, which
+// are otherwise in UTC. Timestamps with an offset and time.Time values from
+// the driver are left as they are. A wall clock time skipped by a daylight
+// saving change is read with the offset in effect before the change, so it
+// moves forward by the length of the gap, and one repeated by a change is
+// read as its first occurrence.
+var TimeScanLocation *time.Location
+
+// TimeInfinity and TimeNegInfinity stand for the Postgres timestamps
+// 'infinity' and '-infinity'. Time.Scan reads those strings as them, and
+// Value writes a time equal to either back as the string, so such rows
+// round-trip instead of failing to scan. They default to the last
+// nanosecond of year 9999 and the first of year 0, the ends of the range
+// that JSON and RFC 3339 can hold. Set one to the zero time.Time to turn
+// its mapping off.
+var (
+	TimeInfinity    = time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
+	TimeNegInfinity = time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// TimeValueMode is the driver value Time.Value returns; see TimeValueAs.
+type TimeValueMode int
+
+const (
+	// TimeValueTime returns a time.Time, the default.
+	TimeValueTime TimeValueMode = iota
+	// TimeValueString returns a string in TimeValueLayout, for drivers that
+	// would store a time.Time as text in a format of their own, such as
+	// SQLite's with TEXT columns.
+	TimeValueString
+)
+
+// TimeValueAs sets what Time.Value returns for a valid Time. A null Time
+// is nil in every mode. TimeValueUTC and TimeTruncate apply before the time
+// is formatted, and infinite times are still "infinity" and "-infinity".
+var TimeValueAs = TimeValueTime
+
+// TimeValueLayout is the layout of the strings Time.Value returns with
+// TimeValueString. Scan tries it before its other layouts, so it reads
+// those strings back as the same instants. The default trims trailing
+// zeros from the fraction, so its strings do not sort in time order; with
+// TimeValueUTC and a fixed-width fraction such as ".000000" they do. A
+// layout without an offset writes the wall clock, which Scan reads in UTC
+// or TimeScanLocation.
+var TimeValueLayout = "2006-01-02 15:04:05.999999999-07:00"
+
 // Time is a nullable time.Time. It supports SQL and JSON serialization.
 type Time struct {
 	Time  time.Time
@@ -36,6 +145,73 @@ func TimeFromPtr(t *time.Time) Time {
 	return NewTime(*t, true)
 }
 
+// TimeFromNonZero creates a new Time that will be null if t is the zero
+// time, for sources such as protobuf messages and legacy structs that
+// encode "no timestamp" as time.Time{}. It is Set either way, so the result
+// is never IsZero: a zero instant becomes an explicit null rather than a
+// 0001-01-01 row or an absent field.
+func TimeFromNonZero(t time.Time) Time {
+	return NewTime(t, !t.IsZero())
+}
+
+// TimeFromString parses s with time.Parse and layout into a new Time. An
+// empty string produces a null Time, and an error is a *ConvertError like
+// those of UnmarshalText.
+func TimeFromString(layout, s string) (t Time, err error) {
+	defer wrapConvertError(&err, "null.Time", s)
+	if s == "" {
+		return NewTime(time.Time{}, false), nil
+	}
+	v, err := time.Parse(layout, s)
+	if err != nil {
+		return NewTime(time.Time{}, false), err
+	}
+	return TimeFrom(v), nil
+}
+
+// MustTimeFromString is like TimeFromString but panics if s cannot be
+// parsed. It is meant for tests and constants.
+func MustTimeFromString(layout, s string) Time {
+	t, err := TimeFromString(layout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TimeFromNullTime creates a new Time from a sql.NullTime. It is always Set.
+func TimeFromNullTime(n sql.NullTime) Time {
+	return NewTime(n.Time, n.Valid)
+}
+
+// TimeFromUnix creates a new valid Time from a Unix time in seconds, in UTC.
+func TimeFromUnix(sec int64) Time {
+	return TimeFrom(time.Unix(sec, 0).UTC())
+}
+
+// TimeFromUnixPtr is like TimeFromUnix, but gives a null Time if sec is nil.
+func TimeFromUnixPtr(sec *int64) Time {
+	if sec == nil {
+		return NewTime(time.Time{}, false)
+	}
+	return TimeFromUnix(*sec)
+}
+
+// TimeFromUnixMilli creates a new valid Time from a Unix time in
+// milliseconds, in UTC.
+func TimeFromUnixMilli(ms int64) Time {
+	return TimeFrom(time.UnixMilli(ms).UTC())
+}
+
+// TimeFromUnixMilliPtr is like TimeFromUnixMilli, but gives a null Time if
+// ms is nil.
+func TimeFromUnixMilliPtr(ms *int64) Time {
+	if ms == nil {
+		return NewTime(time.Time{}, false)
+	}
+	return TimeFromUnixMilli(*ms)
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (t Time) IsValid() bool {
@@ -47,53 +223,237 @@ func (t Time) IsSet() bool {
 	return t.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (t Time) IsNull() bool {
+	return t.Set && !t.Valid
+}
+
+// Equal reports whether t and other are both null, or both valid with
+// the same instant, in any location. Set is ignored; see ExactEqual.
+func (t Time) Equal(other Time) bool {
+	if !t.Valid || !other.Valid {
+		return t.Valid == other.Valid
+	}
+	return t.compared().Equal(other.compared())
+}
+
+// ExactEqual is like Equal, but also requires t and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (t Time) ExactEqual(other Time) bool {
+	return t.Set == other.Set && t.Equal(other)
+}
+
+// Compare returns -1 if t is less than other, 0 if they are equal and +1
+// if t is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+//
+// Times are compared as instants, whatever their locations.
+func (t Time) Compare(other Time) int {
+	if !t.Valid || !other.Valid {
+		return compareNull(t.Valid, other.Valid)
+	}
+	return t.compared().Compare(other.compared())
+}
+
+// compared returns the time to compare, truncated if TimeEqualTruncate is
+// set.
+func (t Time) compared() time.Time {
+	if TimeEqualTruncate {
+		return t.Time.Truncate(TimePrecision)
+	}
+	return t.Time
+}
+
+// Before reports whether t is before other. It is false if either is null.
+func (t Time) Before(other Time) bool {
+	return t.Valid && other.Valid && t.compared().Before(other.compared())
+}
+
+// After reports whether t is after other. It is false if either is null.
+func (t Time) After(other Time) bool {
+	return t.Valid && other.Valid && t.compared().After(other.compared())
+}
+
+// Between reports whether t is within the range from start to end, both
+// included. It is false if t, start or end is null, and if end is before
+// start.
+func (t Time) Between(start, end Time) bool {
+	return t.Valid && start.Valid && end.Valid &&
+		!t.compared().Before(start.compared()) && !t.compared().After(end.compared())
+}
+
+// Unix returns t as a Unix time in seconds, or a null Int64 with the Set of
+// t if t is null.
+func (t Time) Unix() Int64 {
+	if !t.Valid {
+		return Int64{Set: t.Set}
+	}
+	return Int64From(t.Time.Unix())
+}
+
+// UnixMilli returns t as a Unix time in milliseconds, or a null Int64 with
+// the Set of t if t is null.
+func (t Time) UnixMilli() Int64 {
+	if !t.Valid {
+		return Int64{Set: t.Set}
+	}
+	return Int64From(t.Time.UnixMilli())
+}
+
 // MarshalJSON implements json.Marshaler.
 func (t Time) MarshalJSON() ([]byte, error) {
 	if !t.Valid {
 		return NullBytes, nil
 	}
-	return t.Time.MarshalJSON()
+	return t.AppendJSON(make([]byte, 0, len(TimeFormat)+2))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (t Time) AppendJSON(b []byte) ([]byte, error) {
+	if !t.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = t.emitted().AppendFormat(b, TimeFormat)
+	return append(b, '"'), nil
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
-func (t *Time) UnmarshalJSON(data []byte) error {
+// Besides strings in TimeFormat it accepts Unix epoch numbers, which may be
+// fractional. Numbers with a magnitude of at least 1e10 are read as
+// milliseconds, smaller ones as seconds. Epoch times are returned in UTC.
+func (t *Time) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Time", t, data)
 	t.Set = true
+	if len(data) == 0 {
+		t.Valid = false
+		t.Time = time.Time{}
+		return fmt.Errorf("null: cannot unmarshal empty JSON input into null.Time")
+	}
 	if bytes.Equal(data, NullBytes) {
 		t.Valid = false
 		t.Time = time.Time{}
 		return nil
 	}
 
-	if err := t.Time.UnmarshalJSON(data); err != nil {
+	if c := data[0]; c == '-' || (c >= '0' && c <= '9') {
+		v, err := timeFromEpoch(string(data))
+		if err != nil {
+			return err
+		}
+		t.Time = v
+		t.Valid = true
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
 		return err
 	}
+	return t.UnmarshalText([]byte(str))
+}
 
-	t.Valid = true
-	return nil
+// epochMillisThreshold is the magnitude from which an epoch number is read as
+// milliseconds instead of seconds. 1e10 seconds falls in the year 2286 while
+// 1e10 milliseconds falls in April 1970, so neither range is ambiguous in
+// practice.
+const epochMillisThreshold = 1e10
+
+// timeFromEpoch parses a Unix epoch number, in seconds or milliseconds and
+// possibly fractional, into a UTC time.
+func timeFromEpoch(num string) (time.Time, error) {
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if n >= epochMillisThreshold || n <= -epochMillisThreshold {
+			return checkEpochYear(num, time.Unix(n/1e3, n%1e3*1e6).UTC())
+		}
+		return checkEpochYear(num, time.Unix(n, 0).UTC())
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("null: cannot parse %q as a Unix timestamp", num)
+	}
+	if math.Abs(f) >= epochMillisThreshold {
+		f /= 1e3
+	}
+	sec, frac := math.Modf(f)
+	if math.Abs(sec) > maxEpochSeconds {
+		return time.Time{}, fmt.Errorf("null: Unix timestamp %s is outside the years 0 to 9999", num)
+	}
+	return checkEpochYear(num, time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC())
+}
+
+// maxEpochSeconds bounds the Unix seconds that timeFromEpoch converts, well
+// past the years it accepts, so converting them to int64 is exact.
+const maxEpochSeconds = 1 << 40
+
+// checkEpochYear returns t, or an error if its year is outside the range
+// that RFC 3339 and so MarshalJSON can represent.
+func checkEpochYear(num string, t time.Time) (time.Time, error) {
+	if y := t.Year(); y < 0 || y > 9999 {
+		return time.Time{}, fmt.Errorf("null: Unix timestamp %s is outside the years 0 to 9999", num)
+	}
+	return t, nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
+// It formats the time with TimeFormat, like MarshalJSON without the quotes,
+// and returns empty text if this Time is null. This lets Time be a JSON map
+// key and a query parameter.
 func (t Time) MarshalText() ([]byte, error) {
 	if !t.Valid {
-		return NullBytes, nil
+		return []byte{}, nil
+	}
+	return t.AppendText(make([]byte, 0, len(TimeFormat)))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if t is null.
+func (t Time) AppendText(b []byte) ([]byte, error) {
+	if !t.Valid {
+		return b, nil
 	}
-	return t.Time.MarshalText()
+	return t.emitted().AppendFormat(b, TimeFormat), nil
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (t *Time) UnmarshalText(text []byte) error {
+// It parses text with TimeFormat, as UnmarshalJSON parses a JSON string,
+// and empty text unmarshals to a null Time.
+func (t *Time) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Time", text)
 	t.Set = true
 	if len(text) == 0 {
 		t.Valid = false
+		t.Time = time.Time{}
 		return nil
 	}
-	if err := t.Time.UnmarshalText(text); err != nil {
+	v, err := parseTime(string(text))
+	if err != nil {
+		t.Valid = false
 		return err
 	}
+	t.Time = v
 	t.Valid = true
 	return nil
 }
 
+// parseTime parses s with TimeFormat, then with each of TimeParseLayouts.
+func parseTime(s string) (time.Time, error) {
+	v, err := time.Parse(TimeFormat, s)
+	if err == nil || len(TimeParseLayouts) == 0 {
+		return v, err
+	}
+	for _, layout := range TimeParseLayouts {
+		if v, err := time.Parse(layout, s); err == nil {
+			return v, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("null: cannot parse %q with any of the layouts %q: %w",
+		s, append([]string{TimeFormat}, TimeParseLayouts...), err)
+}
+
 // SetValid changes this Time's value and sets it to be non-null.
 func (t *Time) SetValid(v time.Time) {
 	t.Time = v
@@ -101,6 +461,22 @@ func (t *Time) SetValid(v time.Time) {
 	t.Set = true
 }
 
+// SetNull sets this Time to an explicit null, zeroing its value.
+func (t *Time) SetNull() {
+	*t = Time{Set: true}
+}
+
+// Unset resets this Time to the zero value, as if it was never set.
+func (t *Time) Unset() {
+	*t = Time{}
+}
+
+// SetPtr changes this Time to the value p points to, or to an explicit
+// null if p is nil.
+func (t *Time) SetPtr(p *time.Time) {
+	*t = TimeFromPtr(p)
+}
+
 // Ptr returns a pointer to this Time's value, or a nil pointer if this Time is null.
 func (t Time) Ptr() *time.Time {
 	if !t.Valid {
@@ -109,19 +485,352 @@ func (t Time) Ptr() *time.Time {
 	return &t.Time
 }
 
-// IsZero returns true for an invalid Time's value, for potential future omitempty support.
+// ValueOrZero returns the inner value if valid, otherwise the zero value,
+// so a null or unset Time gives time.Time{}, undoing TimeFromNonZero.
+func (t Time) ValueOrZero() time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (t Time) Or(def time.Time) time.Time {
+	if !t.Valid {
+		return def
+	}
+	return t.Time
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (t Time) Val() time.Time {
+	return t.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (t Time) Get() (time.Time, bool) {
+	return t.ValueOrZero(), t.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if t is
+// null.
+func (t Time) MustGet() time.Time {
+	if !t.Valid {
+		panic(&NullError{Type: "null.Time"})
+	}
+	return t.Time
+}
+
+// IsZero returns true if this Time was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero, even one holding the zero instant; test t.Time.IsZero()
+// or ValueOrZero().IsZero() for that, and IsNull for an explicit null.
 func (t Time) IsZero() bool {
-	return !t.Valid
+	return !t.Set && !t.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Time is null.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Time if the input is null or ~.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	t.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+
+	var v time.Time
+	if err := unmarshal(&v); err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes the time as MarshalText does, in TimeFormat, or an empty
+// element if this Time is null.
+func (t Time) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, t.Valid, t.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It parses the text as UnmarshalText does. An empty element unmarshals to
+// a null Time.
+func (t *Time) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, t.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The time is encoded as MarshalText does, and the attribute is omitted if
+// this Time is null.
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, t.Valid, t.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Time.
+func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON UTC datetime, which keeps millisecond precision, or
+// BSON null if this Time is null.
+func (t Time) MarshalBSONValue() (byte, []byte, error) {
+	if !t.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonDateTimeValue(t.Time)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON datetime, a BSON timestamp or an RFC 3339 string, and
+// always produces a UTC time.
+func (t *Time) UnmarshalBSONValue(typ byte, data []byte) error {
+	t.Set = true
+	if bsonIsNull(typ) {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	v, err := bsonTime(typ, data, "null.Time")
+	t.Time, t.Valid = v, err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack timestamp extension, or nil if this Time is null.
+func (t Time) MarshalMsgpack() ([]byte, error) {
+	if !t.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackTime(nil, t.Time), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack timestamp or an RFC 3339 string, and always produces
+// a UTC time.
+func (t *Time) UnmarshalMsgpack(data []byte) error {
+	t.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	res, err := msgpackTime(v, "null.Time")
+	t.Time, t.Valid = res, err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes an RFC 3339 string of tag 0, which keeps the nanoseconds and
+// offset, or null if this Time is null.
+func (t Time) MarshalCBOR() ([]byte, error) {
+	if !t.Valid {
+		return cborNull, nil
+	}
+	return appendCBORTime(nil, t.Time), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a string of tag 0, an epoch time of tag 1, which produces a
+// UTC time, or an untagged RFC 3339 string.
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	t.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	res, err := cborTime(v, "null.Time")
+	t.Time, t.Valid = res, err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (t Time) MarshalGQL(w io.Writer) {
+	marshalGQL(w, t.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Time.
+func (t *Time) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, t.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It formats the time with CSVTimeFormat, or returns an empty cell if this
+// Time is null.
+func (t Time) MarshalCSV() (string, error) {
+	if !t.Valid {
+		return "", nil
+	}
+	return t.Time.Format(CSVTimeFormat), nil
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It parses the cell with CSVTimeFormat. An empty cell unmarshals to a null
+// Time.
+func (t *Time) UnmarshalCSV(cell string) error {
+	t.Set = true
+	if cell == "" {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	v, err := time.Parse(CSVTimeFormat, cell)
+	if err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Time, while an absent one is never passed, leaving
+// the field unset.
+func (t *Time) UnmarshalParam(param string) error {
+	return t.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML offset date-time, or an empty string if this Time is
+// null.
+func (t Time) MarshalTOML() ([]byte, error) {
+	if !t.Valid {
+		return tomlEmpty, nil
+	}
+	return []byte(t.Time.Format(time.RFC3339Nano)), nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It takes a TOML date-time as it is, keeping its offset, or a string in the
+// same form as UnmarshalText. An empty string unmarshals to a null Time.
+func (t *Time) UnmarshalTOML(v interface{}) error {
+	if x, ok := v.(time.Time); ok {
+		t.Time, t.Valid, t.Set = x, true, true
+		return nil
+	}
+	return unmarshalTOML(v, t.UnmarshalText, t.UnmarshalJSON)
+}
+
+// EncodeSpanner implements spanner.Encoder.
+// It encodes a TIMESTAMP, or a typed NULL if this Time is null.
+func (t Time) EncodeSpanner() (interface{}, error) {
+	if !t.Valid {
+		return (*time.Time)(nil), nil
+	}
+	return t.Time, nil
+}
+
+// DecodeSpanner implements spanner.Decoder.
+// It accepts a TIMESTAMP, which Spanner sends as RFC 3339 text in UTC.
+// NULL decodes to a null Time.
+func (t *Time) DecodeSpanner(input interface{}) error {
+	t.Set = true
+	v, null := spannerValue(input)
+	str, ok := v.(string)
+	if null || !ok {
+		t.Time, t.Valid = time.Time{}, false
+		if null {
+			return nil
+		}
+		return spannerError(input, "null.Time")
+	}
+	tv, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return fmt.Errorf("null: cannot decode Spanner TIMESTAMP %q into null.Time: %w", str, err)
+	}
+	t.Time, t.Valid = tv, true
+	return nil
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets midnight UTC on a random day between 1972 and 2031, which every
+// database stores without loss, or an explicit null if shouldBeNull is true.
+func (t *Time) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*t = NewTime(time.Time{}, false)
+		return
+	}
+	t.SetValid(randomDate(nextInt))
+}
+
+// Generate implements testing/quick's Generator.
+func (t Time) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewTime(time.Time{}, false), func() Time {
+		return TimeFrom(generateTime(rand))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (t Time) MarshalBinary() ([]byte, error) {
+	return marshalBinary(t.Set, t.Valid, func() ([]byte, error) {
+		return t.Time.MarshalBinary()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Time")
+	if err != nil {
+		return err
+	}
+	var v time.Time
+	if valid {
+		if err = v.UnmarshalBinary(p); err != nil {
+			return err
+		}
+	}
+	t.Time, t.Valid, t.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (t *Time) Scan(value interface{}) error {
-	var err error
+// Besides time.Time it accepts string and []byte timestamps in RFC 3339,
+// "2006-01-02 15:04:05" (with optional fractional seconds and offset),
+// date-only form or TimeValueLayout. An int64 is a Unix time in seconds or
+// milliseconds, as TimeScanUnixMillis describes, and a float64 is a Unix
+// time in seconds with a fraction, as SQLite stores them; both scan to UTC.
+// The Postgres strings "infinity" and "-infinity" scan to TimeInfinity and
+// TimeNegInfinity.
+func (t *Time) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Time", value)
 	switch x := value.(type) {
 	case time.Time:
 		t.Time = x
+	case int64:
+		t.Time = scanUnixTime(x)
+	case float64:
+		if math.IsNaN(x) || math.Abs(x) >= 1<<62 {
+			err = fmt.Errorf("null: cannot scan %v into null.Time", x)
+			break
+		}
+		whole, frac := math.Modf(x)
+		t.Time = time.Unix(int64(whole), int64(math.Round(frac*1e9))).UTC()
+	case string:
+		t.Time, err = parseScanTime(x)
+	case []byte:
+		t.Time, err = parseScanTime(string(x))
 	case nil:
-		t.Valid, t.Set = false, false
+		t.Time, t.Valid, t.Set = time.Time{}, false, true
 		return nil
 	default:
 		err = fmt.Errorf("null: cannot scan type %T into null.Time: %v", value, value)
@@ -132,10 +841,159 @@ func (t *Time) Scan(value interface{}) error {
 	return err
 }
 
-// Value implements the driver Valuer interface.
+// scanUnixTime reads n as Unix seconds or milliseconds, as
+// TimeScanUnixMillis describes.
+func scanUnixTime(n int64) time.Time {
+	if TimeScanUnixMillis || n >= 1e10 || n <= -1e10 {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+// scanTimeLayouts are the layouts Scan tries, in order, for timestamps
+// drivers return as text.
+var scanTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseScanTime(s string) (time.Time, error) {
+	switch {
+	case s == "infinity" && !TimeInfinity.IsZero():
+		return TimeInfinity, nil
+	case s == "-infinity" && !TimeNegInfinity.IsZero():
+		return TimeNegInfinity, nil
+	}
+	if v, ok := parseScanLayout(TimeValueLayout, s); ok {
+		return v, nil
+	}
+	for _, layout := range scanTimeLayouts {
+		if v, ok := parseScanLayout(layout, s); ok {
+			return v, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("null: cannot parse %q into null.Time", s)
+}
+
+// parseScanLayout parses s with layout for Scan, reading a time without an
+// offset in TimeScanLocation if it is set.
+func parseScanLayout(layout, s string) (time.Time, bool) {
+	v, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if TimeScanLocation != nil && !strings.Contains(layout, "Z07") && !strings.Contains(layout, "-07") &&
+		!strings.Contains(layout, "MST") {
+		v = wallClockIn(v, TimeScanLocation)
+	}
+	return v, true
+}
+
+// wallClockIn returns the time in loc whose wall clock reads as the UTC
+// time wall does. time.Date leaves it unspecified which side of a daylight
+// saving change it picks for a wall clock that is skipped or repeated, so
+// the zones on either side of its pick are tried in order: the first whose
+// offset yields a time within the zone wins, the earlier of two for a
+// repeated wall clock. A skipped one falls within no zone, and the last
+// zone whose offset yields a time past its end, the one before the gap,
+// wins.
+func wallClockIn(wall time.Time, loc *time.Location) time.Time {
+	year, month, day := wall.Date()
+	hour, min, sec := wall.Clock()
+	t := time.Date(year, month, day, hour, min, sec, wall.Nanosecond(), loc)
+
+	start, end := t.ZoneBounds()
+	zones := []time.Time{t}
+	if !start.IsZero() {
+		zones = append([]time.Time{start.Add(-time.Nanosecond)}, zones...)
+	}
+	if !end.IsZero() {
+		zones = append(zones, end)
+	}
+	// candidate returns the time with wall's clock at the offset of the
+	// zone z is in, whether it falls within that zone, and whether it falls
+	// after it, as it does for the zone before a gap.
+	candidate := func(z time.Time) (c time.Time, within, after bool) {
+		_, offset := z.Zone()
+		c = wall.Add(-time.Duration(offset) * time.Second)
+		zs, ze := z.ZoneBounds()
+		after = !ze.IsZero() && !c.Before(ze)
+		return c, !after && (zs.IsZero() || !c.Before(zs)), after
+	}
+	for _, z := range zones {
+		if c, within, _ := candidate(z); within {
+			return c.In(loc)
+		}
+	}
+	for i := len(zones) - 1; i >= 0; i-- {
+		if c, _, after := candidate(zones[i]); after {
+			return c.In(loc)
+		}
+	}
+	return t
+}
+
+// Value implements the driver Valuer interface. A time equal to
+// TimeInfinity or TimeNegInfinity is written as the string "infinity" or
+// "-infinity". Other times are a time.Time, or a string in TimeValueLayout
+// with TimeValueString; see TimeValueAs.
 func (t Time) Value() (driver.Value, error) {
-	if !t.Valid {
+	switch {
+	case !t.Valid:
 		return nil, nil
+	case !TimeInfinity.IsZero() && t.Time.Equal(TimeInfinity):
+		return "infinity", nil
+	case !TimeNegInfinity.IsZero() && t.Time.Equal(TimeNegInfinity):
+		return "-infinity", nil
+	case TimeValueAs == TimeValueString:
+		return t.emitted().Format(TimeValueLayout), nil
 	}
-	return t.Time, nil
+	return t.emitted(), nil
+}
+
+// emitted returns the time to write out, in UTC if TimeValueUTC is set and
+// truncated if TimeTruncate is.
+func (t Time) emitted() time.Time {
+	v := t.Time
+	if TimeValueUTC {
+		v = v.UTC()
+	}
+	if TimeTruncate {
+		v = v.Truncate(TimePrecision)
+	}
+	return v
+}
+
+// ToNullTime converts t to a sql.NullTime, dropping Set.
+func (t Time) ToNullTime() sql.NullTime {
+	return sql.NullTime{Time: t.Time, Valid: t.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (t Time) String() string {
+	return fmt.Sprint(t)
+}
+
+// GoString implements the GoStringer interface, formatting t as the Go
+// expression that builds it.
+func (t Time) GoString() string {
+	return goString("Time", "", "Time", t.Time.GoString(), t.Time == (time.Time{}), t.Valid, t.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// t with the verb if it is valid.
+func (t Time) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, t, t.Valid, t.Set, t.Time)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Time value, or to nil if this Time is null.
+func (t Time) LogValue() slog.Value {
+	if !t.Valid {
+		return logNull
+	}
+	return slog.TimeValue(t.Time)
 }
@@ -1,9 +1,20 @@
 package null
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+	_ "time/tzdata"
 )
 
 var (
@@ -30,8 +41,9 @@ func TestUnmarshalTimeJSON(t *testing.T) {
 
 	var invalid Time
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*time.ParseError); !ok {
-		t.Errorf("expected json.ParseError, not %T", err)
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
+		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullTime(t, invalid, "invalid from object json")
 
@@ -43,13 +55,166 @@ func TestUnmarshalTimeJSON(t *testing.T) {
 	assertNullTime(t, bad, "bad from object json")
 
 	var wrongType Time
-	err = json.Unmarshal(intJSON, &wrongType)
+	err = json.Unmarshal(boolJSON, &wrongType)
 	if err == nil {
 		t.Errorf("expected error: wrong type JSON")
 	}
 	assertNullTime(t, wrongType, "wrong type object json")
 }
 
+func TestUnmarshalTimeJSONEmpty(t *testing.T) {
+	var ti Time
+	err := json.Unmarshal([]byte(`""`), &ti)
+	maybePanic(err)
+	assertNullTime(t, ti, "empty string json")
+	if !ti.Set {
+		t.Error("should be Set")
+	}
+
+	var bad Time
+	err = json.Unmarshal([]byte(`"yesterday"`), &bad)
+	var target *time.ParseError
+	if !errors.As(err, &target) {
+		t.Errorf("expected time.ParseError, not %T", err)
+	}
+	assertNullTime(t, bad, "unparseable string json")
+
+	for _, data := range [][]byte{nil, {}} {
+		var empty Time
+		if err := empty.UnmarshalJSON(data); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("UnmarshalJSON(%#v) error = %v, want ErrInvalidInput", data, err)
+		}
+		assertNullTime(t, empty, "empty input")
+	}
+}
+
+func TestUnmarshalTimeJSONEpoch(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`1712345678`, time.Unix(1712345678, 0)},
+		{`1712345678123`, time.Unix(1712345678, 123e6)},
+		{`1712345678.5`, time.Unix(1712345678, 5e8)},
+		{`0`, time.Unix(0, 0)},
+		{`-86400`, time.Unix(-86400, 0)},
+		{`9999999999`, time.Unix(9999999999, 0)},
+		{`10000000000`, time.Unix(1e7, 0)},
+	}
+
+	for _, test := range tests {
+		var ti Time
+		err := json.Unmarshal([]byte(test.in), &ti)
+		maybePanic(err)
+		if !ti.Valid || !ti.Set {
+			t.Errorf("%s: should be valid and set", test.in)
+		}
+		if !ti.Time.Equal(test.want) {
+			t.Errorf("%s: bad time: %v ≠ %v", test.in, ti.Time, test.want)
+		}
+		if ti.Time.Location() != time.UTC {
+			t.Errorf("%s: location should be UTC, not %v", test.in, ti.Time.Location())
+		}
+	}
+
+	for _, in := range []string{`1e400`, `1e20`, `-1e20`, `9223372036854775807`, `253402300800000`} {
+		var bad Time
+		err := json.Unmarshal([]byte(in), &bad)
+		if err == nil {
+			t.Errorf("%s: expected error", in)
+		}
+		assertNullTime(t, bad, "overflowing epoch json "+in)
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	defer func(layout string) { TimeFormat = layout }(TimeFormat)
+
+	tests := []struct {
+		layout string
+		value  time.Time
+		json   string
+	}{
+		{time.RFC3339Nano, timeValue.Add(500 * time.Millisecond), `"2012-12-21T21:21:21.5Z"`},
+		{"2006-01-02 15:04:05", timeValue, `"2012-12-21 21:21:21"`},
+		{time.RFC1123, timeValue, `"Fri, 21 Dec 2012 21:21:21 UTC"`},
+	}
+
+	for _, test := range tests {
+		TimeFormat = test.layout
+
+		data, err := json.Marshal(TimeFrom(test.value))
+		maybePanic(err)
+		assertJSONEquals(t, data, test.json, test.layout+" marshal")
+
+		var ti Time
+		err = json.Unmarshal(data, &ti)
+		maybePanic(err)
+		if !ti.Valid || !ti.Time.Equal(test.value) {
+			t.Errorf("bad %s round trip: %v ≠ %v", test.layout, ti.Time, test.value)
+		}
+
+		// XML elements and attributes are written in TimeFormat too, so they
+		// read back.
+		type timeEvent struct {
+			At    Time `xml:"at"`
+			Since Time `xml:"since,attr"`
+		}
+		var event timeEvent
+		event.At, event.Since = TimeFrom(test.value), TimeFrom(test.value)
+		data, err = xml.Marshal(event)
+		maybePanic(err)
+		event.At, event.Since = Time{}, Time{}
+		if err := xml.Unmarshal(data, &event); err != nil || !event.At.Time.Equal(test.value) || !event.Since.Time.Equal(test.value) {
+			t.Errorf("bad %s XML round trip of %s: %v, %v", test.layout, data, event, err)
+		}
+
+		data, err = json.Marshal(Time{})
+		maybePanic(err)
+		assertJSONEquals(t, data, "null", test.layout+" null marshal")
+
+		var null Time
+		err = json.Unmarshal(nullTimeJSON, &null)
+		maybePanic(err)
+		assertNullTime(t, null, test.layout+" null unmarshal")
+	}
+}
+
+func TestTimeParseLayouts(t *testing.T) {
+	defer func(layouts []string) { TimeParseLayouts = layouts }(TimeParseLayouts)
+	TimeParseLayouts = []string{"2006-01-02 15:04:05", "2006-01-02"}
+
+	for in, want := range map[string]time.Time{
+		`"2012-12-21T21:21:21Z"`:        timeValue,
+		`"2012-12-21T21:21:21.5+01:00"`: timeValue.Add(-time.Hour + 500*time.Millisecond),
+		`"2012-12-21 21:21:21"`:         timeValue,
+		`"2012-12-21"`:                  time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC),
+	} {
+		var ti Time
+		maybePanic(json.Unmarshal([]byte(in), &ti))
+		if !ti.Valid || !ti.Time.Equal(want) {
+			t.Errorf("%s: got %v, want %v", in, ti.Time, want)
+		}
+		data, err := json.Marshal(ti)
+		maybePanic(err)
+		if want == timeValue {
+			assertJSONEquals(t, data, `"2012-12-21T21:21:21Z"`, in+" marshal")
+		}
+	}
+
+	var ti Time
+	err := ti.UnmarshalText([]byte("21/12/2012"))
+	if err == nil || !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected an invalid input error, got %v", err)
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if !strings.Contains(err.Error(), layout) {
+			t.Errorf("error %q should list the layout %q", err, layout)
+		}
+	}
+	assertNullTime(t, ti, "unparsable with TimeParseLayouts")
+}
+
 func TestUnmarshalTimeText(t *testing.T) {
 	ti := TimeFrom(timeValue)
 	txt, err := ti.MarshalText()
@@ -69,6 +234,47 @@ func TestUnmarshalTimeText(t *testing.T) {
 	assertNullTime(t, invalid, "bad string")
 }
 
+func TestTimeTextMatchesJSON(t *testing.T) {
+	for _, in := range []string{timeString, "2012-12-21T21:21:21.123456789+03:00", "", "2012-12-21", "hello world"} {
+		var text, js Time
+		textErr := text.UnmarshalText([]byte(in))
+		jsonErr := js.UnmarshalJSON([]byte(strconv.Quote(in)))
+		if (textErr == nil) != (jsonErr == nil) || !text.ExactEqual(js) {
+			t.Errorf("UnmarshalText(%q) = %#v, %v but UnmarshalJSON gives %#v, %v", in, text, textErr, js, jsonErr)
+		}
+		if textErr != nil && !strings.Contains(textErr.Error(), strconv.Quote(in)) {
+			t.Errorf("UnmarshalText(%q) error %q should include the input", in, textErr)
+		}
+	}
+
+	null, err := NewTime(timeValue, false).MarshalText()
+	maybePanic(err)
+	if len(null) != 0 {
+		t.Errorf("null MarshalText() = %q, want empty text", null)
+	}
+	data, err := json.Marshal(TimeFrom(timeValue))
+	maybePanic(err)
+	text, err := TimeFrom(timeValue).MarshalText()
+	maybePanic(err)
+	if strconv.Quote(string(text)) != string(data) {
+		t.Errorf("MarshalText() = %s, MarshalJSON() = %s", text, data)
+	}
+}
+
+func TestTimeMapKey(t *testing.T) {
+	in := map[Time]int{TimeFrom(timeValue): 1}
+	data, err := json.Marshal(in)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"`+timeString+`":1}`, "map key marshal")
+
+	var out map[Time]int
+	err = json.Unmarshal(data, &out)
+	maybePanic(err)
+	if len(out) != 1 || out[TimeFrom(timeValue)] != 1 {
+		t.Errorf("map key round trip = %v", out)
+	}
+}
+
 func TestMarshalTime(t *testing.T) {
 	ti := TimeFrom(timeValue)
 	data, err := json.Marshal(ti)
@@ -94,6 +300,68 @@ func TestTimeFromPtr(t *testing.T) {
 	assertNullTime(t, null, "TimeFromPtr(nil)")
 }
 
+func TestTimeFromString(t *testing.T) {
+	ti, err := TimeFromString(time.RFC3339, timeString)
+	maybePanic(err)
+	assertTime(t, ti, "TimeFromString()")
+
+	ti, err = TimeFromString("02/01/2006 15:04", "21/12/2012 21:21")
+	maybePanic(err)
+	if want := time.Date(2012, 12, 21, 21, 21, 0, 0, time.UTC); !ti.Valid || !ti.Time.Equal(want) {
+		t.Errorf("TimeFromString() with a layout = %v, want %v", ti, want)
+	}
+
+	null, err := TimeFromString(time.RFC3339, "")
+	maybePanic(err)
+	assertNullTime(t, null, "TimeFromString(\"\")")
+	if !null.Set {
+		t.Error("TimeFromString(\"\") should be set")
+	}
+
+	_, err = TimeFromString(time.RFC3339, "yesterday")
+	var ce *ConvertError
+	if !errors.As(err, &ce) || ce.Type != "null.Time" || ce.Input != "yesterday" {
+		t.Errorf("TimeFromString(\"yesterday\") error = %#v, want a *ConvertError", err)
+	}
+	assertTime(t, MustTimeFromString(time.RFC3339, timeString), "MustTimeFromString()")
+}
+
+func TestTimeUnix(t *testing.T) {
+	for _, sec := range []int64{0, 1, -1, 1356124881, -14182940} {
+		ti := TimeFromUnix(sec)
+		if !ti.Valid || !ti.Set || ti.Time.Location() != time.UTC || !ti.Time.Equal(time.Unix(sec, 0)) {
+			t.Errorf("TimeFromUnix(%d) = %#v", sec, ti)
+		}
+		if got := ti.Unix(); !got.ExactEqual(Int64From(sec)) {
+			t.Errorf("TimeFromUnix(%d).Unix() = %v", sec, got)
+		}
+		if got := TimeFromUnixPtr(&sec); !got.ExactEqual(ti) {
+			t.Errorf("TimeFromUnixPtr(%d) = %#v, want %#v", sec, got, ti)
+		}
+	}
+	for _, ms := range []int64{0, 1, -1, -1001, 1356124881123} {
+		ti := TimeFromUnixMilli(ms)
+		if !ti.Valid || ti.Time.Location() != time.UTC || !ti.Time.Equal(time.UnixMilli(ms)) {
+			t.Errorf("TimeFromUnixMilli(%d) = %#v", ms, ti)
+		}
+		if got := ti.UnixMilli(); !got.ExactEqual(Int64From(ms)) {
+			t.Errorf("TimeFromUnixMilli(%d).UnixMilli() = %v", ms, got)
+		}
+		if got := TimeFromUnixMilliPtr(&ms); !got.ExactEqual(ti) {
+			t.Errorf("TimeFromUnixMilliPtr(%d) = %#v, want %#v", ms, got, ti)
+		}
+	}
+
+	assertNullTime(t, TimeFromUnixPtr(nil), "TimeFromUnixPtr(nil)")
+	assertNullTime(t, TimeFromUnixMilliPtr(nil), "TimeFromUnixMilliPtr(nil)")
+	if got := NewTime(timeValue, false).Unix(); !got.ExactEqual(NewInt64(0, false)) {
+		t.Errorf("null Unix() = %#v", got)
+	}
+	if got := (Time{}).UnixMilli(); !got.ExactEqual(Int64{}) {
+		t.Errorf("unset UnixMilli() = %#v", got)
+	}
+}
+
 func TestTimeSetValid(t *testing.T) {
 	var ti time.Time
 	change := NewTime(ti, false)
@@ -124,9 +392,23 @@ func TestTimeIsZero(t *testing.T) {
 	}
 
 	null := TimeFromPtr(nil)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Time
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
+
+	fromZero := TimeFromNonZero(time.Time{})
+	if fromZero.IsZero() || !fromZero.IsNull() || !fromZero.ValueOrZero().IsZero() {
+		t.Errorf("TimeFromNonZero(time.Time{}) = %#v, want a set null", fromZero)
+	}
+	zeroInstant := TimeFrom(time.Time{})
+	if zeroInstant.IsZero() || !zeroInstant.Valid || !zeroInstant.Time.IsZero() {
+		t.Errorf("TimeFrom(time.Time{}) = %#v, want a valid zero instant", zeroInstant)
+	}
 }
 
 func TestTimeScanValue(t *testing.T) {
@@ -142,18 +424,489 @@ func TestTimeScanValue(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullTime(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 	if v, err := null.Value(); v != nil || err != nil {
 		t.Error("bad value or err:", v, err)
 	}
 
+	for _, v := range []interface{}{
+		timeString,
+		[]byte(timeString),
+		"2012-12-21 21:21:21",
+		[]byte("2012-12-21 21:21:21"),
+		"2012-12-21 21:21:21.000000",
+	} {
+		var text Time
+		err = text.Scan(v)
+		maybePanic(err)
+		assertTime(t, text, fmt.Sprintf("scanned %q", v))
+	}
+
+	var offset Time
+	err = offset.Scan("2012-12-21 22:21:21+01:00")
+	maybePanic(err)
+	if !offset.Valid || !offset.Time.Equal(timeValue) {
+		t.Errorf("bad scanned offset time: %v ≠ %v", offset.Time, timeValue)
+	}
+
+	var date Time
+	err = date.Scan([]byte("2012-12-21"))
+	maybePanic(err)
+	if want := time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC); !date.Valid || !date.Time.Equal(want) {
+		t.Errorf("bad scanned date: %v ≠ %v", date.Time, want)
+	}
+
+	var malformed Time
+	err = malformed.Scan("21/12/2012")
+	if err == nil || !strings.Contains(err.Error(), "21/12/2012") {
+		t.Errorf("expected error naming the input, got %v", err)
+	}
+	assertNullTime(t, malformed, "scanned malformed")
+
 	var wrong Time
-	err = wrong.Scan(int64(42))
+	err = wrong.Scan(true)
 	if err == nil {
 		t.Error("expected error")
 	}
 	assertNullTime(t, wrong, "scanned wrong")
 }
 
+func TestTimeScanUnix(t *testing.T) {
+	defer func(v bool) { TimeScanUnixMillis = v }(TimeScanUnixMillis)
+	utc := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339Nano, s)
+		maybePanic(err)
+		return v
+	}
+
+	tests := []struct {
+		in     interface{}
+		millis bool
+		want   time.Time
+	}{
+		{int64(1356124881), false, utc("2012-12-21T21:21:21Z")},
+		{int64(1356124881000), false, utc("2012-12-21T21:21:21Z")},
+		{int64(-1356124881), false, utc("1927-01-11T02:38:39Z")},
+		{int64(0), false, utc("1970-01-01T00:00:00Z")},
+		// The last second read as seconds, then the first as millis.
+		{int64(9999999999), false, utc("2286-11-20T17:46:39Z")},
+		{int64(10000000000), false, utc("1970-04-26T17:46:40Z")},
+		{int64(-10000000000), false, utc("1969-09-07T06:13:20Z")},
+		{int64(9999999999), true, utc("1970-04-26T17:46:39.999Z")},
+		{int64(1356124881), true, utc("1970-01-16T16:42:04.881Z")},
+		{1356124881.5, false, utc("2012-12-21T21:21:21.5Z")},
+		{1356124881.5, true, utc("2012-12-21T21:21:21.5Z")},
+		{-0.25, false, utc("1969-12-31T23:59:59.75Z")},
+	}
+	for _, test := range tests {
+		TimeScanUnixMillis = test.millis
+		var ti Time
+		err := ti.Scan(test.in)
+		maybePanic(err)
+		if !ti.Valid || !ti.Set || !ti.Time.Equal(test.want) || ti.Time.Location() != time.UTC {
+			t.Errorf("Scan(%v) with millis %v = %v, want %v", test.in, test.millis, ti.Time, test.want)
+		}
+	}
+
+	for _, in := range []float64{math.NaN(), math.Inf(1), 1e300} {
+		var ti Time
+		if err := ti.Scan(in); err == nil {
+			t.Errorf("Scan(%v) should fail", in)
+		}
+		assertNullTime(t, ti, "scanned float")
+
+	}
+}
+func TestTimeScanLocation(t *testing.T) {
+	defer func(loc *time.Location) { TimeScanLocation = loc }(TimeScanLocation)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	maybePanic(err)
+	newYork, err := time.LoadLocation("America/New_York")
+	maybePanic(err)
+	utc := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339Nano, s)
+		maybePanic(err)
+		return v
+	}
+
+	tests := []struct {
+		loc  *time.Location
+		in   interface{}
+		want time.Time
+	}{
+		{berlin, "2024-07-01 12:00:00", utc("2024-07-01T10:00:00Z")},
+		{berlin, []byte("2024-01-01 12:00:00.25"), utc("2024-01-01T11:00:00.25Z")},
+		{berlin, "2024-07-01", utc("2024-06-30T22:00:00Z")},
+		{newYork, "2024-07-01 12:00:00", utc("2024-07-01T16:00:00Z")},
+		// Either side of the spring transitions.
+		{berlin, "2024-03-31 01:59:59", utc("2024-03-31T00:59:59Z")},
+		{berlin, "2024-03-31 03:00:00", utc("2024-03-31T01:00:00Z")},
+		{newYork, "2024-03-10 01:59:59", utc("2024-03-10T06:59:59Z")},
+		{newYork, "2024-03-10 03:00:00", utc("2024-03-10T07:00:00Z")},
+		// Skipped wall clocks move forward by the gap.
+		{berlin, "2024-03-31 02:30:00", utc("2024-03-31T01:30:00Z")},
+		{berlin, "2024-03-31 02:00:00", utc("2024-03-31T01:00:00Z")},
+		{newYork, "2024-03-10 02:30:00", utc("2024-03-10T07:30:00Z")},
+		// Repeated wall clocks are their first occurrence.
+		{berlin, "2024-10-27 02:30:00", utc("2024-10-27T00:30:00Z")},
+		{berlin, "2024-10-27 02:00:00", utc("2024-10-27T00:00:00Z")},
+		{berlin, "2024-10-27 03:00:00", utc("2024-10-27T02:00:00Z")},
+		{newYork, "2024-11-03 01:30:00", utc("2024-11-03T05:30:00Z")},
+		{newYork, "2024-11-03 02:00:00", utc("2024-11-03T07:00:00Z")},
+		// Offsets and time.Time values are kept.
+		{berlin, "2024-07-01T12:00:00Z", utc("2024-07-01T12:00:00Z")},
+		{berlin, "2024-07-01 12:00:00+05:00", utc("2024-07-01T07:00:00Z")},
+		{berlin, utc("2024-07-01T12:00:00Z"), utc("2024-07-01T12:00:00Z")},
+		{time.UTC, "2024-07-01 12:00:00", utc("2024-07-01T12:00:00Z")},
+		{nil, "2024-07-01 12:00:00", utc("2024-07-01T12:00:00Z")},
+	}
+	for _, test := range tests {
+		TimeScanLocation = test.loc
+		var ti Time
+		err := ti.Scan(test.in)
+		maybePanic(err)
+		if !ti.Valid || !ti.Time.Equal(test.want) {
+			t.Errorf("Scan(%v) in %v = %v, want %v", test.in, test.loc, ti.Time, test.want.In(berlin))
+		}
+		if s, ok := test.in.(string); ok && test.loc != nil && !strings.ContainsAny(s[10:], "Z+") {
+			if loc := ti.Time.Location(); loc != test.loc {
+				t.Errorf("Scan(%v) has Location %v, want %v", test.in, loc, test.loc)
+			}
+		}
+	}
+
+	TimeScanLocation = berlin
+	var ti Time
+	err = ti.Scan(int64(1356124881))
+	maybePanic(err)
+	if ti.Time.Location() != time.UTC {
+		t.Errorf("Unix time scanned to %v, want UTC", ti.Time)
+	}
+}
+
+func TestTimeScanInfinity(t *testing.T) {
+	db := &rowsDB{cols: []string{"at"}, data: [][]driver.Value{
+		{"infinity"}, {[]byte("-infinity")}, {"2012-12-21 21:21:21"},
+	}}
+	conn := sql.OpenDB(db)
+	defer conn.Close()
+	rows, err := conn.Query("SELECT")
+	maybePanic(err)
+	var got []Time
+	for rows.Next() {
+		var ti Time
+		maybePanic(rows.Scan(&ti))
+		got = append(got, ti)
+	}
+	maybePanic(rows.Err())
+	if len(got) != 3 || !got[0].ExactEqual(TimeFrom(TimeInfinity)) ||
+		!got[1].ExactEqual(TimeFrom(TimeNegInfinity)) || !got[2].Time.Equal(timeValue) {
+		t.Fatalf("scanned %v", got)
+	}
+
+	db.data = nil
+	for _, ti := range got {
+		_, err := conn.Exec("INSERT", ti)
+		maybePanic(err)
+	}
+	want := [][]driver.Value{{"infinity"}, {"-infinity"}, {timeValue}}
+	if len(db.data) != 3 || db.data[0][0] != want[0][0] || db.data[1][0] != want[1][0] ||
+		!db.data[2][0].(time.Time).Equal(timeValue) {
+		t.Errorf("stored %v, want %v", db.data, want)
+	}
+	if data, err := json.Marshal(got[0]); err != nil || string(data) != `"9999-12-31T23:59:59.999999999Z"` {
+		t.Errorf("JSON of infinity = %s, %v", data, err)
+	}
+
+	defer func(inf time.Time) { TimeInfinity = inf }(TimeInfinity)
+	TimeInfinity = time.Time{}
+	var ti Time
+	if err := ti.Scan("infinity"); err == nil {
+		t.Error("Scan(infinity) should fail with the mapping off")
+	}
+	if err := ti.Scan("-infinity"); err != nil || !ti.ExactEqual(TimeFrom(TimeNegInfinity)) {
+		t.Errorf("Scan(-infinity) = %v, %v", ti, err)
+	}
+}
+
+func TestTimeValueString(t *testing.T) {
+	defer func(mode TimeValueMode, layout string, utc, trunc bool) {
+		TimeValueAs, TimeValueLayout, TimeValueUTC, TimeTruncate = mode, layout, utc, trunc
+	}(TimeValueAs, TimeValueLayout, TimeValueUTC, TimeTruncate)
+	TimeValueAs = TimeValueString
+
+	zone := time.FixedZone("UTC-5", -5*60*60)
+	times := []time.Time{
+		time.Date(2012, 12, 21, 21, 21, 21, 0, zone),
+		time.Date(2012, 12, 21, 21, 21, 21, 500000000, zone),
+		time.Date(2012, 12, 22, 2, 21, 21, 123456789, time.UTC),
+		time.Date(1999, 1, 2, 3, 4, 5, 6000, time.UTC),
+	}
+	tests := []struct {
+		layout     string
+		utc, trunc bool
+		first      string
+	}{
+		{TimeValueLayout, false, false, "2012-12-21 21:21:21-05:00"},
+		{TimeValueLayout, true, false, "2012-12-22 02:21:21+00:00"},
+		{time.RFC3339Nano, false, false, "2012-12-21T21:21:21-05:00"},
+		{"2006-01-02T15:04:05.000000Z07:00", true, true, "2012-12-22T02:21:21.000000Z"},
+		{"2006-01-02 15:04:05.999999999", true, false, "2012-12-22 02:21:21"},
+		{"02 Jan 2006 15:04:05.999999999 -0700", false, false, "21 Dec 2012 21:21:21 -0500"},
+	}
+	for _, test := range tests {
+		TimeValueLayout, TimeValueUTC, TimeTruncate = test.layout, test.utc, test.trunc
+		db := &rowsDB{cols: []string{"at"}}
+		conn := sql.OpenDB(db)
+		for _, ti := range times {
+			_, err := conn.Exec("INSERT", TimeFrom(ti))
+			maybePanic(err)
+		}
+		if s, ok := db.data[0][0].(string); !ok || s != test.first {
+			t.Errorf("%s: Value() = %#v, want %q", test.layout, db.data[0][0], test.first)
+		}
+
+		rows, err := conn.Query("SELECT")
+		maybePanic(err)
+		for i := 0; rows.Next(); i++ {
+			var back Time
+			maybePanic(rows.Scan(&back))
+			want := times[i]
+			if test.trunc {
+				want = want.Truncate(TimePrecision)
+			}
+			if !back.Valid || !back.Time.Equal(want) {
+				t.Errorf("%s: %q scans to %v, want %v", test.layout, db.data[i][0], back.Time, want)
+			}
+		}
+		maybePanic(rows.Err())
+		conn.Close()
+	}
+
+	// With one offset and a fixed-width fraction the strings sort like
+	// the times they hold.
+	TimeValueLayout, TimeValueUTC, TimeTruncate = "2006-01-02 15:04:05.000000000-07:00", true, false
+	var prev string
+	for i, ti := range []time.Time{times[3], times[0], times[2], times[1]} {
+		v, err := TimeFrom(ti).Value()
+		maybePanic(err)
+		if s := v.(string); i > 0 && s <= prev {
+			t.Errorf("%q sorts before %q", s, prev)
+		} else {
+			prev = s
+		}
+	}
+
+	if v, err := TimeFrom(TimeInfinity).Value(); err != nil || v != "infinity" {
+		t.Errorf("Value() of TimeInfinity = %#v, %v", v, err)
+	}
+	if v, err := NewTime(times[0], false).Value(); err != nil || v != nil {
+		t.Errorf("Value() of null = %#v, %v", v, err)
+	}
+}
+
+func TestTimeEqualBeforeAfter(t *testing.T) {
+	now := TimeFrom(time.Now())
+	stored := TimeFrom(now.Time.Round(0).In(time.FixedZone("UTC+3", 3*60*60)))
+	if now == stored || !now.Equal(stored) || !stored.Equal(now) {
+		t.Errorf("%v should equal %v, which has no monotonic clock reading", now, stored)
+	}
+	null := NewTime(time.Time{}, false)
+	if !null.Equal(Time{}) || null.Equal(now) || now.Equal(null) {
+		t.Error("bad Equal with null")
+	}
+
+	later := TimeFrom(now.Time.Add(time.Nanosecond))
+	if !now.Before(later) || later.Before(now) || now.Before(stored) {
+		t.Error("bad Before")
+	}
+	if !later.After(now) || now.After(later) || now.After(stored) {
+		t.Error("bad After")
+	}
+	if now.Before(null) || null.Before(now) || now.After(null) || null.After(now) || null.Before(null) {
+		t.Error("Before and After should be false with a null")
+	}
+}
+
+func TestTimeBetween(t *testing.T) {
+	start := TimeFrom(time.Now())
+	end := TimeFrom(start.Time.Add(time.Hour))
+	middle := TimeFrom(start.Time.Add(time.Minute))
+	// Without a monotonic reading, in another location.
+	stored := TimeFrom(start.Time.Round(0).In(time.FixedZone("UTC+3", 3*60*60)))
+	null := NewTime(time.Time{}, false)
+
+	for _, in := range []Time{start, middle, end, stored} {
+		if !in.Between(start, end) {
+			t.Errorf("%v should be between %v and %v", in, start, end)
+		}
+	}
+	if end.Between(start, middle) || start.Between(middle, end) || middle.Between(end, start) {
+		t.Error("bad Between outside the range")
+	}
+	if null.Between(start, end) || middle.Between(null, end) || middle.Between(start, null) || (Time{}).Between(null, null) {
+		t.Error("Between should be false with a null")
+	}
+	if start.Compare(null) != 1 || null.Compare(start) != -1 || null.Compare(Time{}) != 0 || start.Compare(stored) != 0 {
+		t.Error("bad Compare")
+	}
+}
+
+func TestTimeValueUTC(t *testing.T) {
+	defer func(v bool) { TimeValueUTC = v }(TimeValueUTC)
+	east := TimeFrom(timeValue.In(time.FixedZone("UTC+3", 3*60*60)))
+	west := TimeFrom(timeValue.In(time.FixedZone("UTC-8", -8*60*60)))
+
+	// Without the option, each writer stores its own wall clock.
+	TimeValueUTC = false
+	if got := roundTripTimestamps(east, west); got[0].Equal(got[1]) {
+		t.Errorf("timestamps %v and %v should differ without TimeValueUTC", got[0], got[1])
+	}
+
+	TimeValueUTC = true
+	for _, got := range roundTripTimestamps(east, west) {
+		if !got.Equal(timeValue) {
+			t.Errorf("timestamp round trip = %v, want %v", got, timeValue)
+		}
+	}
+	for _, ti := range []Time{east, west} {
+		data, err := json.Marshal(ti)
+		maybePanic(err)
+		assertJSONEquals(t, data, `"`+timeString+`"`, "json marshal with TimeValueUTC")
+		text, err := ti.MarshalText()
+		maybePanic(err)
+		if string(text) != timeString {
+			t.Errorf("MarshalText() with TimeValueUTC = %s, want %s", text, timeString)
+		}
+	}
+	if east.Time.Location().String() != "UTC+3" {
+		t.Errorf("TimeValueUTC changed the stored time to %v", east.Time)
+	}
+	if v, err := NewTime(time.Time{}, false).Value(); v != nil || err != nil {
+		t.Error("bad null value or err:", v, err)
+	}
+}
+
+func TestTimeTruncate(t *testing.T) {
+	defer func(v, eq bool, d time.Duration) {
+		TimeTruncate, TimeEqualTruncate, TimePrecision = v, eq, d
+	}(TimeTruncate, TimeEqualTruncate, TimePrecision)
+	ns := time.Date(2012, 12, 21, 21, 21, 21, 123456789, time.UTC)
+	us := time.Date(2012, 12, 21, 21, 21, 21, 123456000, time.UTC)
+	ti := TimeFrom(ns)
+
+	if v, _ := ti.Value(); !v.(time.Time).Equal(ns) {
+		t.Errorf("Value() without TimeTruncate = %v, want %v", v, ns)
+	}
+	if ti.Equal(TimeFrom(us)) {
+		t.Error("times differing in nanoseconds should differ without TimeEqualTruncate")
+	}
+
+	TimeTruncate = true
+	if v, _ := ti.Value(); !v.(time.Time).Equal(us) {
+		t.Errorf("Value() with TimeTruncate = %v, want %v", v, us)
+	}
+	data, err := json.Marshal(ti)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"2012-12-21T21:21:21.123456Z"`, "json marshal with TimeTruncate")
+	text, err := ti.MarshalText()
+	maybePanic(err)
+	if string(text) != "2012-12-21T21:21:21.123456Z" {
+		t.Errorf("MarshalText() with TimeTruncate = %s", text)
+	}
+	if ti.Time != ns {
+		t.Errorf("TimeTruncate changed the stored time to %v", ti.Time)
+	}
+
+	TimePrecision = time.Millisecond
+	if v, _ := ti.Value(); !v.(time.Time).Equal(time.Date(2012, 12, 21, 21, 21, 21, 123e6, time.UTC)) {
+		t.Errorf("Value() with a millisecond TimePrecision = %v", v)
+	}
+	TimePrecision = time.Microsecond
+
+	TimeEqualTruncate = true
+	if !ti.Equal(TimeFrom(us)) || ti.Compare(TimeFrom(us)) != 0 {
+		t.Error("times differing in nanoseconds should be equal with TimeEqualTruncate")
+	}
+	if ti.Equal(TimeFrom(us.Add(time.Microsecond))) || ti.Compare(TimeFrom(us.Add(time.Microsecond))) != -1 {
+		t.Error("times differing in microseconds should differ with TimeEqualTruncate")
+	}
+	if !NewTime(ns, false).Equal(Time{}) {
+		t.Error("nulls should be equal with TimeEqualTruncate")
+	}
+	if written := TimeFrom(us); ti.Before(written) || written.After(ti) || !ti.Between(written, written) {
+		t.Error("Before, After and Between should agree with Compare under TimeEqualTruncate")
+	}
+	if !ti.Before(TimeFrom(us.Add(time.Microsecond))) || !TimeFrom(us.Add(time.Microsecond)).After(ti) {
+		t.Error("times differing in microseconds should be ordered with TimeEqualTruncate")
+	}
+}
+
+// roundTripTimestamps writes times to a timestampDB, and returns what it
+// reads back.
+func roundTripTimestamps(times ...Time) []time.Time {
+	db := sql.OpenDB(&timestampDB{})
+	defer db.Close()
+	for _, ti := range times {
+		_, err := db.Exec("INSERT", ti)
+		maybePanic(err)
+	}
+	rows, err := db.Query("SELECT")
+	maybePanic(err)
+	defer rows.Close()
+	var out []time.Time
+	for rows.Next() {
+		var ti Time
+		maybePanic(rows.Scan(&ti))
+		out = append(out, ti.Time)
+	}
+	maybePanic(rows.Err())
+	return out
+}
+
+// timestampDB is a database/sql driver with a single timestamp without
+// time zone column: it keeps the wall clock of the times written to it,
+// dropping their offset, and reads them back in UTC.
+type timestampDB struct {
+	rows []string
+}
+
+const timestampLayout = "2006-01-02 15:04:05.999999999"
+
+func (db *timestampDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *timestampDB) Driver() driver.Driver                        { return nil }
+func (db *timestampDB) Prepare(string) (driver.Stmt, error)          { return db, nil }
+func (db *timestampDB) Close() error                                 { return nil }
+func (db *timestampDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (db *timestampDB) NumInput() int                                { return -1 }
+
+func (db *timestampDB) Exec(args []driver.Value) (driver.Result, error) {
+	db.rows = append(db.rows, args[0].(time.Time).Format(timestampLayout))
+	return driver.RowsAffected(1), nil
+}
+
+func (db *timestampDB) Query([]driver.Value) (driver.Rows, error) {
+	return &timestampRows{rows: db.rows}, nil
+}
+
+type timestampRows struct {
+	rows []string
+}
+
+func (r *timestampRows) Columns() []string { return []string{"t"} }
+func (r *timestampRows) Close() error      { return nil }
+
+func (r *timestampRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	t, err := time.Parse(timestampLayout, r.rows[0])
+	dest[0], r.rows = t, r.rows[1:]
+	return err
+}
+
 func assertTime(t *testing.T, ti Time, from string) {
 	if ti.Time != timeValue {
 		t.Errorf("bad %v time: %v ≠ %v\n", from, ti.Time, timeValue)
@@ -0,0 +1,630 @@
+package null
+
+import (
+	"bytes"
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a nullable time of day without a date, such as a SQL TIME.
+// It is kept as the time elapsed since midnight, from 00:00:00 up to and
+// including 24:00:00.
+type TimeOfDay struct {
+	TimeOfDay time.Duration
+	Valid     bool
+	Set       bool
+}
+
+// NewTimeOfDay creates a new TimeOfDay from the time elapsed since midnight.
+func NewTimeOfDay(d time.Duration, valid bool) TimeOfDay {
+	return TimeOfDay{
+		TimeOfDay: d,
+		Valid:     valid,
+		Set:       true,
+	}
+}
+
+// TimeOfDayFrom creates a new TimeOfDay that will always be valid.
+func TimeOfDayFrom(d time.Duration) TimeOfDay {
+	return NewTimeOfDay(d, true)
+}
+
+// TimeOfDayFromPtr creates a new TimeOfDay that will be null if d is nil.
+func TimeOfDayFromPtr(d *time.Duration) TimeOfDay {
+	if d == nil {
+		return NewTimeOfDay(0, false)
+	}
+	return NewTimeOfDay(*d, true)
+}
+
+// TimeOfDayFromNonZero creates a new TimeOfDay that will be null if d is 0, which is midnight.
+func TimeOfDayFromNonZero(d time.Duration) TimeOfDay {
+	return NewTimeOfDay(d, d != 0)
+}
+
+// TimeOfDayOf creates a new valid TimeOfDay from its clock components.
+func TimeOfDayOf(hour, min, sec, nsec int) TimeOfDay {
+	d := time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(nsec)
+	return NewTimeOfDay(d, true)
+}
+
+// TimeOfDayFromTime creates a new valid TimeOfDay from the clock of t in
+// t's own location.
+func TimeOfDayFromTime(t time.Time) TimeOfDay {
+	h, m, s := t.Clock()
+	return TimeOfDayOf(h, m, s, t.Nanosecond())
+}
+
+// TimeOfDayFromString parses s in "15:04:05" format, with optional
+// fractional seconds, into a new TimeOfDay. An empty string produces a
+// null TimeOfDay.
+func TimeOfDayFromString(s string) (TimeOfDay, error) {
+	var t TimeOfDay
+	err := t.UnmarshalText([]byte(s))
+	return t, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (t TimeOfDay) IsValid() bool {
+	return t.Set && t.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (t TimeOfDay) IsSet() bool {
+	return t.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (t TimeOfDay) IsNull() bool {
+	return t.Set && !t.Valid
+}
+
+// Equal reports whether t and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (t TimeOfDay) Equal(other TimeOfDay) bool {
+	if !t.Valid || !other.Valid {
+		return t.Valid == other.Valid
+	}
+	return t.TimeOfDay == other.TimeOfDay
+}
+
+// ExactEqual is like Equal, but also requires t and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (t TimeOfDay) ExactEqual(other TimeOfDay) bool {
+	return t.Set == other.Set && t.Equal(other)
+}
+
+// Compare returns -1 if t is less than other, 0 if they are equal and +1
+// if t is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (t TimeOfDay) Compare(other TimeOfDay) int {
+	if !t.Valid || !other.Valid {
+		return compareNull(t.Valid, other.Valid)
+	}
+	return cmp.Compare(t.TimeOfDay, other.TimeOfDay)
+}
+
+// Hour returns the hour, in the range [0, 24].
+func (t TimeOfDay) Hour() int {
+	return int(t.TimeOfDay / time.Hour)
+}
+
+// Minute returns the minute within the hour, in the range [0, 59].
+func (t TimeOfDay) Minute() int {
+	return int(t.TimeOfDay % time.Hour / time.Minute)
+}
+
+// Second returns the second within the minute, in the range [0, 59].
+func (t TimeOfDay) Second() int {
+	return int(t.TimeOfDay % time.Minute / time.Second)
+}
+
+// Nanosecond returns the nanosecond within the second.
+func (t TimeOfDay) Nanosecond() int {
+	return int(t.TimeOfDay % time.Second)
+}
+
+// On returns this time of day on the date of date, in date's location.
+// It returns the zero time.Time if this TimeOfDay is null.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), date.Location())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.TimeOfDay", t, data)
+	t.Set = true
+	if bytes.Equal(data, NullBytes) {
+		t.TimeOfDay, t.Valid = 0, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		t.TimeOfDay, t.Valid = 0, false
+		return err
+	}
+	return t.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null TimeOfDay if the input is a blank string.
+func (t *TimeOfDay) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.TimeOfDay", text)
+	t.Set = true
+	if len(text) == 0 {
+		t.TimeOfDay, t.Valid = 0, false
+		return nil
+	}
+	res, err := parseTimeOfDay(string(text))
+	t.Valid = err == nil
+	t.TimeOfDay = res
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this TimeOfDay is null.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return NullBytes, nil
+	}
+	return t.AppendJSON(make([]byte, 0, 20))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (t TimeOfDay) AppendJSON(b []byte) ([]byte, error) {
+	if !t.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = t.appendFormat(b)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this TimeOfDay is null.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	if !t.Valid {
+		return []byte{}, nil
+	}
+	return t.AppendText(make([]byte, 0, 18))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if t is null.
+func (t TimeOfDay) AppendText(b []byte) ([]byte, error) {
+	if !t.Valid {
+		return b, nil
+	}
+	return t.appendFormat(b), nil
+}
+
+// SetValid changes this TimeOfDay's value and also sets it to be non-null.
+func (t *TimeOfDay) SetValid(v time.Duration) {
+	t.TimeOfDay = v
+	t.Valid = true
+	t.Set = true
+}
+
+// SetNull sets this TimeOfDay to an explicit null, zeroing its value.
+func (t *TimeOfDay) SetNull() {
+	*t = TimeOfDay{Set: true}
+}
+
+// Unset resets this TimeOfDay to the zero value, as if it was never set.
+func (t *TimeOfDay) Unset() {
+	*t = TimeOfDay{}
+}
+
+// SetPtr changes this TimeOfDay to the value p points to, or to an explicit
+// null if p is nil.
+func (t *TimeOfDay) SetPtr(p *time.Duration) {
+	*t = TimeOfDayFromPtr(p)
+}
+
+// Ptr returns a pointer to this TimeOfDay's value, or a nil pointer if this TimeOfDay is null.
+func (t TimeOfDay) Ptr() *time.Duration {
+	if !t.Valid {
+		return nil
+	}
+	return &t.TimeOfDay
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (t TimeOfDay) ValueOrZero() time.Duration {
+	if !t.Valid {
+		return 0
+	}
+	return t.TimeOfDay
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (t TimeOfDay) Or(def time.Duration) time.Duration {
+	if !t.Valid {
+		return def
+	}
+	return t.TimeOfDay
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (t TimeOfDay) Val() time.Duration {
+	return t.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (t TimeOfDay) Get() (time.Duration, bool) {
+	return t.ValueOrZero(), t.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if t is
+// null.
+func (t TimeOfDay) MustGet() time.Duration {
+	if !t.Valid {
+		panic(&NullError{Type: "null.TimeOfDay"})
+	}
+	return t.TimeOfDay
+}
+
+// IsZero returns true if this TimeOfDay was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (t TimeOfDay) IsZero() bool {
+	return !t.Set && !t.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this TimeOfDay is null, and the text form otherwise.
+func (t TimeOfDay) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	data, err := t.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// TimeOfDay if the input is null or ~.
+func (t *TimeOfDay) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	t.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		t.TimeOfDay, t.Valid = 0, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		t.TimeOfDay, t.Valid = 0, false
+		return err
+	}
+	return t.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this TimeOfDay is null.
+func (t TimeOfDay) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, t.Valid, t.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null TimeOfDay.
+func (t *TimeOfDay) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, t.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this TimeOfDay is null.
+func (t TimeOfDay) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, t.Valid, t.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null TimeOfDay.
+func (t *TimeOfDay) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this TimeOfDay is null.
+func (t TimeOfDay) MarshalBSONValue() (byte, []byte, error) {
+	if !t.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(t.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (t *TimeOfDay) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.TimeOfDay", t.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this TimeOfDay is null.
+func (t TimeOfDay) MarshalMsgpack() ([]byte, error) {
+	if !t.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, t.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (t *TimeOfDay) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.TimeOfDay", t.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this TimeOfDay is null.
+func (t TimeOfDay) MarshalCBOR() ([]byte, error) {
+	if !t.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, t.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (t *TimeOfDay) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.TimeOfDay", t.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (t TimeOfDay) MarshalGQL(w io.Writer) {
+	marshalGQL(w, t.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null TimeOfDay.
+func (t *TimeOfDay) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, t.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this TimeOfDay is
+// null.
+func (t TimeOfDay) MarshalCSV() (string, error) {
+	return marshalCSV(t.Valid, t.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null TimeOfDay.
+func (t *TimeOfDay) UnmarshalCSV(cell string) error {
+	return t.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML local time, or an empty string if this TimeOfDay is null.
+func (t TimeOfDay) MarshalTOML() ([]byte, error) {
+	if !t.Valid {
+		return tomlEmpty, nil
+	}
+	return t.MarshalText()
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML local time or a string in the same form as
+// UnmarshalText. An empty string unmarshals to a null TimeOfDay.
+func (t *TimeOfDay) UnmarshalTOML(v interface{}) error {
+	if x, ok := v.(time.Time); ok {
+		t.TimeOfDay, t.Valid, t.Set = tomlClock(x), true, true
+		return nil
+	}
+	return unmarshalTOML(v, t.UnmarshalText, t.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random time of day in whole seconds, or an explicit null if shouldBeNull is true.
+func (t *TimeOfDay) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*t = NewTimeOfDay(0, false)
+		return
+	}
+	t.SetValid(time.Duration(randomN(nextInt, 24*60*60)) * time.Second)
+}
+
+// Generate implements testing/quick's Generator.
+func (t TimeOfDay) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewTimeOfDay(0, false), func() TimeOfDay {
+		return TimeOfDayFrom(time.Duration(rand.Int63n(int64(24*time.Hour) + 1)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (t TimeOfDay) MarshalBinary() ([]byte, error) {
+	return marshalBinary(t.Set, t.Valid, func() ([]byte, error) {
+		return appendVarint(nil, int64(t.TimeOfDay)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *TimeOfDay) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.TimeOfDay")
+	if err != nil {
+		return err
+	}
+	var v time.Duration
+	if valid {
+		var n int64
+		if n, err = binaryVarint(p, "null.TimeOfDay", 0, int64(24*time.Hour)); err != nil {
+			return err
+		}
+		v = time.Duration(n)
+	}
+	t.TimeOfDay, t.Valid, t.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It accepts text and time.Time, of which only the clock is kept.
+func (t *TimeOfDay) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.TimeOfDay", value)
+	switch x := value.(type) {
+	case nil:
+		t.TimeOfDay, t.Valid, t.Set = 0, false, true
+		return nil
+	case time.Time:
+		*t = TimeOfDayFromTime(x)
+	case string:
+		err = t.UnmarshalText([]byte(x))
+	case []byte:
+		err = t.UnmarshalText(x)
+	default:
+		t.TimeOfDay, t.Valid, t.Set = 0, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.TimeOfDay: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The time is stored as "HH:MM:SS" text, with fractional seconds if any.
+func (t TimeOfDay) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.format(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (t TimeOfDay) String() string {
+	return fmt.Sprint(t)
+}
+
+// GoString implements the GoStringer interface, formatting t as the Go
+// expression that builds it.
+func (t TimeOfDay) GoString() string {
+	return goString("TimeOfDay", "", "TimeOfDay", goDuration(t.TimeOfDay), t.TimeOfDay == 0, t.Valid, t.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// t with the verb if it is valid.
+func (t TimeOfDay) Format(state fmt.State, verb rune) {
+	payload := interface{}(t.TimeOfDay)
+	if textVerb(verb) {
+		payload = t.format()
+	}
+	formatValue(state, verb, t, t.Valid, t.Set, payload)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value such as 15:04:05, or to nil if this
+// TimeOfDay is null.
+func (t TimeOfDay) LogValue() slog.Value {
+	if !t.Valid {
+		return logNull
+	}
+	return slog.StringValue(t.format())
+}
+
+// format returns t as "HH:MM:SS" with trailing zeros of the fraction
+// removed.
+func (t TimeOfDay) format() string {
+	return string(t.appendFormat(make([]byte, 0, 18)))
+}
+
+// appendFormat appends what format returns to b.
+func (t TimeOfDay) appendFormat(b []byte) []byte {
+	b = fmt.Appendf(b, "%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
+	switch ns := t.Nanosecond(); {
+	case ns > 0:
+		b = append(b, ".000000000"...)
+		for i := len(b) - 1; ns > 0; i-- {
+			b[i] += byte(ns % 10)
+			ns /= 10
+		}
+		return bytes.TrimRight(b, "0")
+	case ns < 0:
+		return bytes.TrimRight(fmt.Appendf(b, ".%09d", ns), "0")
+	}
+	return b
+}
+
+// parseTimeOfDay parses "HH:MM", "HH:MM:SS" and "HH:MM:SS.fraction".
+func parseTimeOfDay(s string) (time.Duration, error) {
+	bad := fmt.Errorf("null: cannot parse %q into null.TimeOfDay: want HH:MM:SS", s)
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, bad
+	}
+	var frac string
+	if len(parts) == 3 {
+		if i := strings.IndexByte(parts[2], '.'); i >= 0 {
+			parts[2], frac = parts[2][:i], parts[2][i+1:]
+			if frac == "" || len(frac) > 9 {
+				return 0, bad
+			}
+		}
+	} else {
+		parts = append(parts, "00")
+	}
+
+	var nums [4]int
+	for i, p := range parts {
+		if len(p) != 2 || !isDigits(p) {
+			return 0, bad
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, bad
+		}
+		nums[i] = n
+	}
+	if frac != "" {
+		if !isDigits(frac) {
+			return 0, bad
+		}
+		n, err := strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+		if err != nil {
+			return 0, bad
+		}
+		nums[3] = n
+	}
+
+	h, m, sec, ns := nums[0], nums[1], nums[2], nums[3]
+	if h > 24 || m > 59 || sec > 59 || (h == 24 && (m != 0 || sec != 0 || ns != 0)) {
+		return 0, fmt.Errorf("null: cannot parse %q into null.TimeOfDay: out of range", s)
+	}
+	return TimeOfDayOf(h, m, sec, ns).TimeOfDay, nil
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
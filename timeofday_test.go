@@ -0,0 +1,222 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var (
+	timeOfDayValue = 15*time.Hour + 4*time.Minute + 5*time.Second
+	timeOfDayJSON  = []byte(`"15:04:05"`)
+)
+
+func TestTimeOfDayFrom(t *testing.T) {
+	tod := TimeOfDayFrom(timeOfDayValue)
+	assertTimeOfDay(t, tod, "TimeOfDayFrom()")
+
+	assertTimeOfDay(t, TimeOfDayOf(15, 4, 5, 0), "TimeOfDayOf()")
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	assertTimeOfDay(t, TimeOfDayFromTime(time.Date(2012, 12, 21, 15, 4, 5, 0, loc)), "TimeOfDayFromTime()")
+
+	midnight := TimeOfDayFrom(0)
+	if !midnight.Valid {
+		t.Error("TimeOfDayFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestTimeOfDayFromPtr(t *testing.T) {
+	v := timeOfDayValue
+	tod := TimeOfDayFromPtr(&v)
+	assertTimeOfDay(t, tod, "TimeOfDayFromPtr()")
+
+	null := TimeOfDayFromPtr(nil)
+	assertNullTimeOfDay(t, null, "TimeOfDayFromPtr(nil)")
+}
+
+func TestTimeOfDayFromString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		out  string
+	}{
+		{"15:04:05", timeOfDayValue, "15:04:05"},
+		{"15:04", 15*time.Hour + 4*time.Minute, "15:04:00"},
+		{"15:04:05.999", timeOfDayValue + 999*time.Millisecond, "15:04:05.999"},
+		{"15:04:05.000001", timeOfDayValue + time.Microsecond, "15:04:05.000001"},
+		{"15:04:05.123456789", timeOfDayValue + 123456789, "15:04:05.123456789"},
+		{"00:00:00", 0, "00:00:00"},
+		{"24:00:00", 24 * time.Hour, "24:00:00"},
+	}
+	for _, test := range tests {
+		tod, err := TimeOfDayFromString(test.in)
+		maybePanic(err)
+		if !tod.Valid || tod.TimeOfDay != test.want {
+			t.Errorf("TimeOfDayFromString(%q) = %v, want %v", test.in, tod.TimeOfDay, test.want)
+		}
+		data, err := tod.MarshalText()
+		maybePanic(err)
+		if string(data) != test.out {
+			t.Errorf("TimeOfDayFromString(%q) marshals to %s, want %s", test.in, data, test.out)
+		}
+	}
+
+	null, err := TimeOfDayFromString("")
+	maybePanic(err)
+	assertNullTimeOfDay(t, null, "TimeOfDayFromString(\"\")")
+
+	for _, in := range []string{"abc", "15", "5:04:05", "25:00:00", "24:00:01", "15:60:00", "15:04:60", "15:04:05.", "15:04:05.1234567890", "+1:04:05", "15:04:05+03"} {
+		if _, err := TimeOfDayFromString(in); err == nil {
+			t.Errorf("TimeOfDayFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestTimeOfDayComponents(t *testing.T) {
+	tod := TimeOfDayOf(15, 4, 5, 6)
+	if tod.Hour() != 15 || tod.Minute() != 4 || tod.Second() != 5 || tod.Nanosecond() != 6 {
+		t.Errorf("bad components of %v", tod)
+	}
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	date := time.Date(2012, 12, 21, 23, 59, 0, 0, loc)
+	got := TimeOfDayOf(15, 4, 5, 6).On(date)
+	want := time.Date(2012, 12, 21, 15, 4, 5, 6, loc)
+	if !got.Equal(want) || got.Location() != loc {
+		t.Errorf("On() = %v, want %v", got, want)
+	}
+
+	end := TimeOfDayOf(24, 0, 0, 0).On(date)
+	if !end.Equal(time.Date(2012, 12, 22, 0, 0, 0, 0, loc)) {
+		t.Errorf("24:00 On() = %v", end)
+	}
+
+	null := NewTimeOfDay(0, false)
+	if !null.On(date).IsZero() {
+		t.Error("null On() should be the zero time")
+	}
+}
+
+func TestUnmarshalTimeOfDay(t *testing.T) {
+	var tod TimeOfDay
+	err := json.Unmarshal(timeOfDayJSON, &tod)
+	maybePanic(err)
+	assertTimeOfDay(t, tod, "time of day json")
+
+	var null TimeOfDay
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullTimeOfDay(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty TimeOfDay
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullTimeOfDay(t, empty, "empty string json")
+
+	var badType TimeOfDay
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullTimeOfDay(t, badType, "wrong type json")
+}
+
+func TestMarshalTimeOfDay(t *testing.T) {
+	tod := TimeOfDayFrom(timeOfDayValue)
+	data, err := json.Marshal(tod)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(timeOfDayJSON), "non-empty json marshal")
+
+	null := NewTimeOfDay(0, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestTimeOfDayScanValue(t *testing.T) {
+	for _, v := range []interface{}{
+		"15:04:05",
+		[]byte("15:04:05"),
+		time.Date(0, 1, 1, 15, 4, 5, 0, time.UTC),
+	} {
+		var tod TimeOfDay
+		err := tod.Scan(v)
+		maybePanic(err)
+		assertTimeOfDay(t, tod, "scanned time of day")
+		if !tod.Set {
+			t.Error("should be Set")
+		}
+
+		value, err := tod.Value()
+		maybePanic(err)
+		if value != "15:04:05" {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	frac := TimeOfDayOf(8, 30, 0, 250000000)
+	if value, _ := frac.Value(); value != "08:30:00.25" {
+		t.Errorf("bad fractional value: %v", value)
+	}
+
+	var null TimeOfDay
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullTimeOfDay(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"abc", "25:00:00", int64(1)} {
+		var wrong TimeOfDay
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullTimeOfDay(t, wrong, "scanned garbage")
+	}
+}
+
+func TestTimeOfDayPointer(t *testing.T) {
+	tod := TimeOfDayFrom(timeOfDayValue)
+	ptr := tod.Ptr()
+	if *ptr != timeOfDayValue {
+		t.Errorf("bad %s time of day: %#v ≠ %v\n", "pointer", ptr, timeOfDayValue)
+	}
+
+	null := NewTimeOfDay(0, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s time of day: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestTimeOfDaySetValid(t *testing.T) {
+	change := NewTimeOfDay(0, false)
+	assertNullTimeOfDay(t, change, "SetValid()")
+	change.SetValid(timeOfDayValue)
+	assertTimeOfDay(t, change, "SetValid()")
+}
+
+func assertTimeOfDay(t *testing.T, tod TimeOfDay, from string) {
+	if tod.TimeOfDay != timeOfDayValue {
+		t.Errorf("bad %s time of day: %v ≠ %v\n", from, tod.TimeOfDay, timeOfDayValue)
+	}
+	if !tod.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullTimeOfDay(t *testing.T, tod TimeOfDay, from string) {
+	if tod.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
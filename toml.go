@@ -0,0 +1,181 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The TOML methods implement the toml.Marshaler and toml.Unmarshaler
+// interfaces of github.com/BurntSushi/toml, so the types work in config
+// structs without a TOML dependency. The toml package hands UnmarshalTOML
+// the decoded value, such as string, int64, float64, bool, time.Time or a
+// map for a table, and only for keys present in the document, so a missing
+// key leaves Set false. TOML has no null, so an empty string stands for
+// one: MarshalTOML writes a null value as "", and UnmarshalTOML reads "" as
+// null. A value that was never set is a zero struct, which the omitempty
+// tag leaves out.
+//
+// Other strings are read as UnmarshalText reads text, and the remaining
+// values are turned into JSON for UnmarshalJSON, except the TOML datetimes
+// that Time, Date and TimeOfDay take as they are. Integers beyond the
+// 64-bit signed range of TOML are written as strings.
+
+// tomlEmpty is the TOML a null value marshals to.
+var tomlEmpty = []byte(`""`)
+
+// unmarshalTOML passes a TOML string to fromText, if the type has one, and
+// any other value encoded as JSON to fromJSON. An empty string passes
+// JSON null.
+func unmarshalTOML(v interface{}, fromText, fromJSON func([]byte) error) error {
+	if s, ok := v.(string); ok {
+		if s == "" {
+			return fromJSON(NullBytes)
+		}
+		if fromText != nil {
+			return fromText([]byte(s))
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return fromJSON(data)
+}
+
+// marshalTOML converts the output of a MarshalJSON method to TOML, or
+// writes tomlEmpty if the value is null.
+func marshalTOML(valid bool, marshalJSON func() ([]byte, error)) ([]byte, error) {
+	if !valid {
+		return tomlEmpty, nil
+	}
+	data, err := marshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return tomlFromJSON(data)
+}
+
+// marshalTOMLText writes the output of a MarshalText method as a TOML
+// string, or tomlEmpty if the value is null.
+func marshalTOMLText(valid bool, marshalText func() ([]byte, error)) ([]byte, error) {
+	if !valid {
+		return tomlEmpty, nil
+	}
+	data, err := marshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(data))
+}
+
+// appendTOMLFloat appends f as a TOML float, which needs a fraction or an
+// exponent to tell it from an integer.
+func appendTOMLFloat(dst []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, "nan"...)
+	case math.IsInf(f, 1):
+		return append(dst, "inf"...)
+	case math.IsInf(f, -1):
+		return append(dst, "-inf"...)
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'g', -1, bits)
+	if !bytes.ContainsAny(dst[start:], ".e") {
+		dst = append(dst, ".0"...)
+	}
+	return dst
+}
+
+var errTOMLNull = errors.New("null: cannot encode a JSON null in TOML")
+
+// tomlFromJSON converts JSON text to a TOML value. Objects become inline
+// tables with their keys in order, and strings keep their JSON quoting,
+// which TOML basic strings share. TOML has no null, so a null inside an
+// array or object is an error.
+func tomlFromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	res, err := appendTOMLJSON(nil, dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("null: cannot encode JSON %q as TOML: trailing data", data)
+	}
+	return res, nil
+}
+
+func appendTOMLJSON(dst []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch x := tok.(type) {
+	case nil:
+		return nil, errTOMLNull
+	case bool:
+		return strconv.AppendBool(dst, x), nil
+	case string:
+		s, err := json.Marshal(x)
+		return append(dst, s...), err
+	case json.Number:
+		if _, err := x.Int64(); err == nil {
+			return append(dst, x...), nil
+		}
+		if !strings.ContainsAny(x.String(), ".eE") {
+			return strconv.AppendQuote(dst, x.String()), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendTOMLFloat(dst, f, 64), nil
+	case json.Delim:
+		open, sep, end := "[", ", ", "]"
+		if x == '{' {
+			open, end = "{ ", " }"
+		}
+		dst = append(dst, open...)
+		for n := 0; dec.More(); n++ {
+			if n > 0 {
+				dst = append(dst, sep...)
+			}
+			if x == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				k, _ := json.Marshal(key)
+				dst = append(append(dst, k...), " = "...)
+			}
+			if dst, err = appendTOMLJSON(dst, dec); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if x == '{' && bytes.HasSuffix(dst, []byte(open)) {
+			// An empty inline table is written {}.
+			return append(dst[:len(dst)-1], '}'), nil
+		}
+		return append(dst, end...), nil
+	}
+	return nil, fmt.Errorf("null: unexpected JSON token %v", tok)
+}
+
+// tomlClock returns the time of day of a TOML local time, which the toml
+// package decodes as a time.Time on January 1 of year 0.
+func tomlClock(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
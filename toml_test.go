@@ -0,0 +1,187 @@
+package null
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type tomlUnmarshaler interface {
+	UnmarshalTOML(v interface{}) error
+}
+
+type tomlMarshaler interface {
+	MarshalTOML() ([]byte, error)
+}
+
+type tomlConfig struct {
+	Name    String   `toml:"name"`
+	Port    Int64    `toml:"port"`
+	Debug   Bool     `toml:"debug"`
+	Started Time     `toml:"started"`
+	Timeout Duration `toml:"timeout"`
+	Retries Int64    `toml:"retries"`
+	Owner   String   `toml:"owner"`
+	Since   Date     `toml:"since"`
+	Backup  Time     `toml:"backup"`
+	Ratio   Float64  `toml:"ratio"`
+}
+
+// decodeTOML fills dst from decoded TOML keys the way the toml package
+// does: UnmarshalTOML is called for the keys present in the document only.
+func decodeTOML(keys map[string]interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		value, ok := keys[v.Type().Field(i).Tag.Get("toml")]
+		if !ok {
+			continue
+		}
+		if err := v.Field(i).Addr().Interface().(tomlUnmarshaler).UnmarshalTOML(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTOMLConfig(t *testing.T) {
+	started := time.Date(2012, 12, 21, 21, 21, 21, 0, time.FixedZone("", 3600))
+	// The toml package decodes this document to the map below:
+	//
+	//	name = "alice"
+	//	port = 8080
+	//	debug = ""
+	//	started = 2012-12-21T21:21:21+01:00
+	//	timeout = "1m30s"
+	//	owner = ""
+	//	since = 2012-12-21
+	//	ratio = 0.5
+	keys := map[string]interface{}{
+		"name":    "alice",
+		"port":    int64(8080),
+		"debug":   "",
+		"started": started,
+		"timeout": "1m30s",
+		"owner":   "",
+		"since":   time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC),
+		"ratio":   0.5,
+	}
+	var cfg tomlConfig
+	err := decodeTOML(keys, &cfg)
+	maybePanic(err)
+
+	want := tomlConfig{
+		Name:    StringFrom("alice"),
+		Port:    Int64From(8080),
+		Debug:   NewBool(false, false),
+		Started: TimeFrom(started),
+		Timeout: DurationFrom(90 * time.Second),
+		Owner:   NewString("", false),
+		Since:   DateOf(2012, 12, 21),
+		Ratio:   Float64From(0.5),
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("decoded %#v, want %#v", cfg, want)
+	}
+	if cfg.Retries.IsSet() || cfg.Backup.IsSet() {
+		t.Errorf("absent keys should stay unset, got %#v and %#v", cfg.Retries, cfg.Backup)
+	}
+	if !cfg.Debug.IsNull() || !cfg.Owner.IsNull() {
+		t.Errorf("empty keys should be set nulls, got %#v and %#v", cfg.Debug, cfg.Owner)
+	}
+
+	for key, value := range map[string]interface{}{"port": "eighty", "debug": int64(2), "since": "someday"} {
+		var bad tomlConfig
+		if err := decodeTOML(map[string]interface{}{key: value}, &bad); err == nil {
+			t.Errorf("%s = %#v should fail to decode", key, value)
+		}
+	}
+}
+
+func TestTOMLNatives(t *testing.T) {
+	tests := []struct {
+		out  tomlUnmarshaler
+		in   interface{}
+		want interface{}
+	}{
+		{&Int64{}, "42", Int64From(42)},
+		{&Uint64{}, "18446744073709551615", Uint64From(math.MaxUint64)},
+		{&Float64{}, math.Inf(-1), Float64From(math.Inf(-1))},
+		{&Float32{}, int64(3), Float32From(3)},
+		{&Bool{}, true, BoolFrom(true)},
+		{&Date{}, time.Date(2012, 12, 21, 10, 0, 0, 0, time.UTC), DateOf(2012, 12, 21)},
+		{&TimeOfDay{}, time.Date(0, 1, 1, 7, 32, 0, 5, time.UTC), TimeOfDayOf(7, 32, 0, 5)},
+		{&TimeOfDay{}, "07:32:00", TimeOfDayOf(7, 32, 0, 0)},
+		{&Duration{}, int64(1000), DurationFrom(time.Microsecond)},
+		{&Decimal{}, 1.5, DecimalFrom("1.5")},
+		{&Decimal{}, "1.10", DecimalFrom("1.10")},
+		{&StringSlice{}, []interface{}{"a", "b"}, StringSliceFrom([]string{"a", "b"})},
+		{&Map{}, map[string]interface{}{"a": int64(1)}, MapFrom(map[string]interface{}{"a": 1.0})},
+		{&Val[int]{}, int64(9), ValFrom(9)},
+	}
+	for _, test := range tests {
+		err := test.out.UnmarshalTOML(test.in)
+		maybePanic(err)
+		if got := reflect.ValueOf(test.out).Elem().Interface(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.UnmarshalTOML(%#v) = %#v, want %#v", test.out, test.in, got, test.want)
+		}
+	}
+}
+
+func TestMarshalTOML(t *testing.T) {
+	tests := []struct {
+		in   tomlMarshaler
+		want string
+	}{
+		{StringFrom(`say "hi"`), `"say \"hi\""`},
+		{Int64From(-42), "-42"},
+		{Uint64From(math.MaxUint64), `"18446744073709551615"`},
+		{BigIntFrom(new(big.Int).Lsh(big.NewInt(1), 70)), `"1180591620717411303424"`},
+		{Float64From(3), "3.0"},
+		{Float64From(1e21), "1e+21"},
+		{Float32From(1.5), "1.5"},
+		{Float64From(math.NaN()), "nan"},
+		{Float64From(math.Inf(-1)), "-inf"},
+		{BoolFrom(true), "true"},
+		{TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 5, time.UTC)), "2012-12-21T21:21:21.000000005Z"},
+		{DateOf(2012, 12, 21), "2012-12-21"},
+		{TimeOfDayOf(7, 32, 0, 0), "07:32:00"},
+		{DurationFrom(90 * time.Second), `"1m30s"`},
+		{DecimalFrom("1.10"), `"1.10"`},
+		{BytesFrom([]byte("hi")), `"aGk="`},
+		{StringSliceFrom([]string{"a", "b"}), `["a", "b"]`},
+		{Int64SliceFrom([]int64{}), "[]"},
+		{JSONFrom([]byte(`{"a":[1,2.5],"b":{}}`)), `{ "a" = [1, 2.5], "b" = {} }`},
+		{EnumFrom(statusActive), `"active"`},
+	}
+	for _, test := range tests {
+		data, err := test.in.MarshalTOML()
+		maybePanic(err)
+		if string(data) != test.want {
+			t.Errorf("%T.MarshalTOML() = %s, want %s", test.in, data, test.want)
+		}
+	}
+
+	if _, err := JSONFrom([]byte(`[1,null]`)).MarshalTOML(); err == nil {
+		t.Error("MarshalTOML of a JSON null element should fail")
+	}
+}
+
+func TestTOMLNull(t *testing.T) {
+	for _, states := range binaryStates() {
+		for _, null := range states[:2] {
+			data, err := null.(tomlMarshaler).MarshalTOML()
+			maybePanic(err)
+			if string(data) != `""` {
+				t.Errorf("null %T.MarshalTOML() = %s, want \"\"", null, data)
+			}
+		}
+		ptr := reflect.New(reflect.TypeOf(states[0]))
+		err := ptr.Interface().(tomlUnmarshaler).UnmarshalTOML("")
+		maybePanic(err)
+		if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, states[1]) {
+			t.Errorf("%T.UnmarshalTOML(\"\") = %#v, want %#v", states[0], got, states[1])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package null
+
+// Getter is implemented by the types with a Get method, all but Map and
+// MapOf, whose Get looks up a key.
+type Getter[T any] interface {
+	Value
+	Get() (T, bool)
+}
+
+// MapVal returns f applied to the payload of v if v is valid. A null or
+// unset v gives a null or unset Val without calling f, so a mapping layer
+// needs no branch per state:
+//
+//	email := null.StringFromVal(null.MapVal(in.Email, strings.ToLower))
+func MapVal[T, U any](v Getter[T], f func(T) U) Val[U] {
+	x, ok := v.Get()
+	if !ok {
+		return Val[U]{Set: v.IsSet()}
+	}
+	return ValFrom(f(x))
+}
+
+// ThenVal is like MapVal for an f that can fail, such as a parser. If f
+// returns an error, ThenVal returns it with an unset Val.
+//
+//	id, err := null.ThenVal(in.ID, uuid.Parse)
+func ThenVal[T, U any](v Getter[T], f func(T) (U, error)) (Val[U], error) {
+	x, ok := v.Get()
+	if !ok {
+		return Val[U]{Set: v.IsSet()}, nil
+	}
+	y, err := f(x)
+	if err != nil {
+		return Val[U]{}, err
+	}
+	return ValFrom(y), nil
+}
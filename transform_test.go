@@ -0,0 +1,59 @@
+package null
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapVal(t *testing.T) {
+	calls := 0
+	lower := func(s string) string {
+		calls++
+		return strings.ToLower(s)
+	}
+	if got := MapVal(StringFrom("Alice@Example.COM"), lower); !got.ExactEqual(ValFrom("alice@example.com")) {
+		t.Errorf("MapVal(valid) = %#v", got)
+	}
+	if got := MapVal(NewString("", false), lower); got.Valid || !got.Set {
+		t.Errorf("MapVal(null) = %#v, want a set null", got)
+	}
+	if got := MapVal(String{}, lower); got.Valid || got.Set {
+		t.Errorf("MapVal(unset) = %#v, want unset", got)
+	}
+	if calls != 1 {
+		t.Errorf("f called %d times, want once", calls)
+	}
+
+	year := MapVal(TimeFrom(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)), time.Time.Year)
+	if year.Val != 2024 || !year.Valid {
+		t.Errorf("MapVal(Time) = %#v", year)
+	}
+	if got := Int64FromVal(MapVal(ValFrom("abc"), func(s string) int64 { return int64(len(s)) })); !got.ExactEqual(Int64From(3)) {
+		t.Errorf("MapVal(Val) = %#v", got)
+	}
+}
+
+func TestThenVal(t *testing.T) {
+	got, err := ThenVal(StringFrom("42"), strconv.Atoi)
+	if err != nil || !got.ExactEqual(ValFrom(42)) {
+		t.Errorf("ThenVal(valid) = %#v, %v", got, err)
+	}
+	got, err = ThenVal(StringFrom("x"), strconv.Atoi)
+	if err == nil || got.Valid || got.Set {
+		t.Errorf("ThenVal(bad) = %#v, %v, want an error and unset", got, err)
+	}
+	failing := func(string) (int, error) {
+		t.Error("f called on a null")
+		return 0, nil
+	}
+	got, err = ThenVal(NewString("", false), failing)
+	if err != nil || got.Valid || !got.Set {
+		t.Errorf("ThenVal(null) = %#v, %v, want a set null", got, err)
+	}
+	got, err = ThenVal(String{}, failing)
+	if err != nil || got.Valid || got.Set {
+		t.Errorf("ThenVal(unset) = %#v, %v, want unset", got, err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package null
+
+import "encoding/json"
+
+// typeErrorsMatchKinds reports whether the errors of newTypeError match
+// ErrInvalidInput and hold a *ConvertError.
+const typeErrorsMatchKinds = true
+
+// newTypeError returns te with ce as its Err, so that errors.Is and
+// errors.As find ce, and ErrInvalidInput through it, behind the
+// *json.UnmarshalTypeError that encoding/json fills the field path in.
+func newTypeError(te *json.UnmarshalTypeError, ce *ConvertError) error {
+	te.Err = ce
+	return te
+}
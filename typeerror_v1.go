@@ -0,0 +1,17 @@
+//go:build !go1.27 || !goexperiment.jsonv2
+
+package null
+
+import "encoding/json"
+
+// typeErrorsMatchKinds reports whether the errors of newTypeError match
+// ErrInvalidInput and hold a *ConvertError.
+const typeErrorsMatchKinds = false
+
+// newTypeError returns te as it is. Before Go 1.27, or without the jsonv2
+// experiment, json.UnmarshalTypeError has no Err field to hold ce, and
+// encoding/json only fills the field path in a bare *json.UnmarshalTypeError,
+// so the path wins over matching ErrInvalidInput.
+func newTypeError(te *json.UnmarshalTypeError, ce *ConvertError) error {
+	return te
+}
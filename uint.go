@@ -2,12 +2,16 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Uint is an nullable uint.
@@ -39,6 +43,29 @@ func UintFromPtr(i *uint) Uint {
 	return NewUint(*i, true)
 }
 
+// UintFromNonZero creates a new Uint that will be null if i is 0.
+func UintFromNonZero(i uint) Uint {
+	return NewUint(i, i != 0)
+}
+
+// UintFromString parses s with the rules of UnmarshalText into a new Uint.
+// An empty string produces a null Uint.
+func UintFromString(s string) (Uint, error) {
+	var u Uint
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// MustUintFromString is like UintFromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustUintFromString(s string) Uint {
+	u, err := UintFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (u Uint) IsValid() bool {
@@ -50,17 +77,54 @@ func (u Uint) IsSet() bool {
 	return u.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u Uint) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (u Uint) Equal(other Uint) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.Uint == other.Uint
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u Uint) ExactEqual(other Uint) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// Compare returns -1 if u is less than other, 0 if they are equal and +1
+// if u is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (u Uint) Compare(other Uint) int {
+	if !u.Valid || !other.Valid {
+		return compareNull(u.Valid, other.Valid)
+	}
+	return cmp.Compare(u.Uint, other.Uint)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (u *Uint) UnmarshalJSON(data []byte) error {
+func (u *Uint) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Uint", u, data)
 	u.Set = true
+	data, err = unquoteNumber(data, "null.Uint")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		u.Valid = false
 		u.Uint = 0
 		return nil
 	}
 
-	var x uint64
-	if err := json.Unmarshal(data, &x); err != nil {
+	x, err := unmarshalUint(data, "null.Uint")
+	if err != nil {
 		return err
 	}
 
@@ -70,14 +134,14 @@ func (u *Uint) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (u *Uint) UnmarshalText(text []byte) error {
+func (u *Uint) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Uint", text)
 	u.Set = true
 	if len(text) == 0 {
 		u.Valid = false
 		return nil
 	}
-	var err error
-	res, err := strconv.ParseUint(string(text), 10, 0)
+	res, err := parseUint(string(text), 0, "null.Uint")
 	u.Valid = err == nil
 	if u.Valid {
 		u.Uint = uint(res)
@@ -90,7 +154,16 @@ func (u Uint) MarshalJSON() ([]byte, error) {
 	if !u.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint), 10)), nil
+	return u.AppendJSON(make([]byte, 0, 20))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u Uint) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -98,7 +171,16 @@ func (u Uint) MarshalText() ([]byte, error) {
 	if !u.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint), 10)), nil
+	return u.AppendText(make([]byte, 0, 20))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u Uint) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint), 10), nil
 }
 
 // SetValid changes this Uint's value and also sets it to be non-null.
@@ -108,6 +190,22 @@ func (u *Uint) SetValid(n uint) {
 	u.Set = true
 }
 
+// SetNull sets this Uint to an explicit null, zeroing its value.
+func (u *Uint) SetNull() {
+	*u = Uint{Set: true}
+}
+
+// Unset resets this Uint to the zero value, as if it was never set.
+func (u *Uint) Unset() {
+	*u = Uint{}
+}
+
+// SetPtr changes this Uint to the value p points to, or to an explicit
+// null if p is nil.
+func (u *Uint) SetPtr(p *uint) {
+	*u = UintFromPtr(p)
+}
+
 // Ptr returns a pointer to this Uint's value, or a nil pointer if this Uint is null.
 func (u Uint) Ptr() *uint {
 	if !u.Valid {
@@ -116,19 +214,280 @@ func (u Uint) Ptr() *uint {
 	return &u.Uint
 }
 
-// IsZero returns true for invalid Uints, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u Uint) ValueOrZero() uint {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u Uint) Or(def uint) uint {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u Uint) Val() uint {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u Uint) Get() (uint, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u Uint) MustGet() uint {
+	if !u.Valid {
+		panic(&NullError{Type: "null.Uint"})
+	}
+	return u.Uint
+}
+
+// IsZero returns true if this Uint was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (u Uint) IsZero() bool {
-	return !u.Valid
+	return !u.Set && !u.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint is null.
+func (u Uint) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Uint if the input is null or ~.
+func (u *Uint) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.Uint, u.Valid = 0, false
+		return err
+	}
+
+	var v uint
+	if err := unmarshal(&v); err != nil {
+		u.Uint, u.Valid = 0, false
+		return err
+	}
+	u.Uint, u.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Uint is null.
+func (u Uint) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Uint.
+func (u *Uint) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Uint is null.
+func (u Uint) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Uint.
+func (u *Uint) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 64-bit integer, or BSON null if this Uint is null.
+// Values above math.MaxInt64 cannot be encoded.
+func (u Uint) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonUintValue(uint64(u.Uint), "null.Uint")
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any non-negative BSON integer, or a double with no
+// fractional part, that fits in a uint.
+func (u *Uint) UnmarshalBSONValue(typ byte, data []byte) error {
+	u.Set = true
+	if bsonIsNull(typ) {
+		u.Uint, u.Valid = 0, false
+		return nil
+	}
+	v, err := bsonUint(typ, data, "null.Uint", math.MaxUint)
+	u.Uint, u.Valid = uint(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Uint is null.
+func (u Uint) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackUint(nil, uint64(u.Uint)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any non-negative msgpack integer, or a float with no
+// fractional part, that fits in a uint.
+func (u *Uint) UnmarshalMsgpack(data []byte) error {
+	u.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		u.Uint, u.Valid = 0, false
+		return err
+	}
+	n, err := msgpackUint(v, "null.Uint", math.MaxUint)
+	u.Uint, u.Valid = uint(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Uint is null.
+func (u Uint) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORUint(nil, uint64(u.Uint)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any non-negative CBOR integer, or a float with no
+// fractional part, that fits in a uint.
+func (u *Uint) UnmarshalCBOR(data []byte) error {
+	u.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		u.Uint, u.Valid = 0, false
+		return err
+	}
+	n, err := cborUint(v, "null.Uint", math.MaxUint)
+	u.Uint, u.Valid = uint(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u Uint) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Uint.
+func (u *Uint) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Uint is
+// null.
+func (u Uint) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Uint.
+func (u *Uint) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Uint, while an absent one is never passed, leaving
+// the field unset.
+func (u *Uint) UnmarshalParam(param string) error {
+	return u.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Uint is null.
+func (u Uint) MarshalTOML() ([]byte, error) {
+	return marshalTOML(u.Valid, u.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Uint.
+func (u *Uint) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random 32-bit number, or an explicit null if shouldBeNull is true.
+func (u *Uint) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewUint(0, false)
+		return
+	}
+	u.SetValid(uint(randomN(nextInt, math.MaxUint32)))
+}
+
+// Generate implements testing/quick's Generator.
+func (u Uint) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewUint(0, false), func() Uint {
+		return UintFrom(uint(generateUint64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u Uint) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		return appendUvarint(nil, uint64(u.Uint)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Uint")
+	if err != nil {
+		return err
+	}
+	var v uint
+	if valid {
+		var n uint64
+		if n, err = binaryUvarint(p, "null.Uint", math.MaxUint); err != nil {
+			return err
+		}
+		v = uint(n)
+	}
+	u.Uint, u.Valid, u.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (u *Uint) Scan(value interface{}) error {
+func (u *Uint) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Uint", value)
 	if value == nil {
-		u.Uint, u.Valid, u.Set = 0, false, false
+		u.Uint, u.Valid, u.Set = 0, false, true
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint, value)
+	n, err := scanUint(value, "null.Uint", strconv.IntSize)
+	u.Uint, u.Valid, u.Set = uint(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -136,13 +495,38 @@ func (u Uint) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
 	}
+
+	// If u.Uint overflows the range of int64, convert it to string
+	if uint64(u.Uint) >= 1<<63 {
+		return strconv.FormatUint(uint64(u.Uint), 10), nil
+	}
+
 	return int64(u.Uint), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Uint) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Uint(%d)", i.Uint)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u Uint) GoString() string {
+	return goString("Uint", "", "Uint", strconv.FormatUint(uint64(u.Uint), 10), u.Uint == 0, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u Uint) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, u, u.Valid, u.Set, u.Uint)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Uint64 value, or to nil if this Uint is null.
+func (u Uint) LogValue() slog.Value {
+	if !u.Valid {
+		return logNull
 	}
-	return "Uint(invalid)"
+	return slog.Uint64Value(uint64(u.Uint))
 }
@@ -2,13 +2,16 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Uint16 is an nullable uint16.
@@ -40,6 +43,29 @@ func Uint16FromPtr(i *uint16) Uint16 {
 	return NewUint16(*i, true)
 }
 
+// Uint16FromNonZero creates a new Uint16 that will be null if i is 0.
+func Uint16FromNonZero(i uint16) Uint16 {
+	return NewUint16(i, i != 0)
+}
+
+// Uint16FromString parses s with the rules of UnmarshalText into a new Uint16.
+// An empty string produces a null Uint16.
+func Uint16FromString(s string) (Uint16, error) {
+	var u Uint16
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// MustUint16FromString is like Uint16FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustUint16FromString(s string) Uint16 {
+	u, err := Uint16FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (u Uint16) IsValid() bool {
@@ -51,22 +77,59 @@ func (u Uint16) IsSet() bool {
 	return u.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u Uint16) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (u Uint16) Equal(other Uint16) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.Uint16 == other.Uint16
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u Uint16) ExactEqual(other Uint16) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// Compare returns -1 if u is less than other, 0 if they are equal and +1
+// if u is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (u Uint16) Compare(other Uint16) int {
+	if !u.Valid || !other.Valid {
+		return compareNull(u.Valid, other.Valid)
+	}
+	return cmp.Compare(u.Uint16, other.Uint16)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (u *Uint16) UnmarshalJSON(data []byte) error {
+func (u *Uint16) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Uint16", u, data)
 	u.Set = true
+	data, err = unquoteNumber(data, "null.Uint16")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		u.Valid = false
 		u.Uint16 = 0
 		return nil
 	}
 
-	var x uint64
-	if err := json.Unmarshal(data, &x); err != nil {
+	x, err := unmarshalUint(data, "null.Uint16")
+	if err != nil {
 		return err
 	}
 
 	if x > math.MaxUint16 {
-		return fmt.Errorf("json: %d overflows max uint8 value", x)
+		return overflowf("null: %d does not fit in null.Uint16", x)
 	}
 
 	u.Uint16 = uint16(x)
@@ -75,14 +138,14 @@ func (u *Uint16) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (u *Uint16) UnmarshalText(text []byte) error {
+func (u *Uint16) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Uint16", text)
 	u.Set = true
 	if len(text) == 0 {
 		u.Valid = false
 		return nil
 	}
-	var err error
-	res, err := strconv.ParseUint(string(text), 10, 16)
+	res, err := parseUint(string(text), 16, "null.Uint16")
 	u.Valid = err == nil
 	if u.Valid {
 		u.Uint16 = uint16(res)
@@ -95,7 +158,16 @@ func (u Uint16) MarshalJSON() ([]byte, error) {
 	if !u.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint16), 10)), nil
+	return u.AppendJSON(make([]byte, 0, 5))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u Uint16) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint16), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -103,7 +175,16 @@ func (u Uint16) MarshalText() ([]byte, error) {
 	if !u.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint16), 10)), nil
+	return u.AppendText(make([]byte, 0, 5))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u Uint16) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint16), 10), nil
 }
 
 // SetValid changes this Uint16's value and also sets it to be non-null.
@@ -113,6 +194,22 @@ func (u *Uint16) SetValid(n uint16) {
 	u.Set = true
 }
 
+// SetNull sets this Uint16 to an explicit null, zeroing its value.
+func (u *Uint16) SetNull() {
+	*u = Uint16{Set: true}
+}
+
+// Unset resets this Uint16 to the zero value, as if it was never set.
+func (u *Uint16) Unset() {
+	*u = Uint16{}
+}
+
+// SetPtr changes this Uint16 to the value p points to, or to an explicit
+// null if p is nil.
+func (u *Uint16) SetPtr(p *uint16) {
+	*u = Uint16FromPtr(p)
+}
+
 // Ptr returns a pointer to this Uint16's value, or a nil pointer if this Uint16 is null.
 func (u Uint16) Ptr() *uint16 {
 	if !u.Valid {
@@ -121,19 +218,279 @@ func (u Uint16) Ptr() *uint16 {
 	return &u.Uint16
 }
 
-// IsZero returns true for invalid Uint16's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u Uint16) ValueOrZero() uint16 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint16
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u Uint16) Or(def uint16) uint16 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint16
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u Uint16) Val() uint16 {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u Uint16) Get() (uint16, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u Uint16) MustGet() uint16 {
+	if !u.Valid {
+		panic(&NullError{Type: "null.Uint16"})
+	}
+	return u.Uint16
+}
+
+// IsZero returns true if this Uint16 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (u Uint16) IsZero() bool {
-	return !u.Valid
+	return !u.Set && !u.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint16 is null.
+func (u Uint16) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint16, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Uint16 if the input is null or ~.
+func (u *Uint16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.Uint16, u.Valid = 0, false
+		return err
+	}
+
+	var v uint16
+	if err := unmarshal(&v); err != nil {
+		u.Uint16, u.Valid = 0, false
+		return err
+	}
+	u.Uint16, u.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Uint16 is null.
+func (u Uint16) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Uint16.
+func (u *Uint16) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Uint16 is null.
+func (u Uint16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Uint16.
+func (u *Uint16) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 32-bit integer, or BSON null if this Uint16 is null.
+func (u Uint16) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt32Value(int32(u.Uint16))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any non-negative BSON integer, or a double with no
+// fractional part, that fits in a uint16.
+func (u *Uint16) UnmarshalBSONValue(typ byte, data []byte) error {
+	u.Set = true
+	if bsonIsNull(typ) {
+		u.Uint16, u.Valid = 0, false
+		return nil
+	}
+	v, err := bsonUint(typ, data, "null.Uint16", math.MaxUint16)
+	u.Uint16, u.Valid = uint16(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Uint16 is null.
+func (u Uint16) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackUint(nil, uint64(u.Uint16)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any non-negative msgpack integer, or a float with no
+// fractional part, that fits in a uint16.
+func (u *Uint16) UnmarshalMsgpack(data []byte) error {
+	u.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		u.Uint16, u.Valid = 0, false
+		return err
+	}
+	n, err := msgpackUint(v, "null.Uint16", math.MaxUint16)
+	u.Uint16, u.Valid = uint16(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Uint16 is null.
+func (u Uint16) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORUint(nil, uint64(u.Uint16)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any non-negative CBOR integer, or a float with no
+// fractional part, that fits in a uint16.
+func (u *Uint16) UnmarshalCBOR(data []byte) error {
+	u.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		u.Uint16, u.Valid = 0, false
+		return err
+	}
+	n, err := cborUint(v, "null.Uint16", math.MaxUint16)
+	u.Uint16, u.Valid = uint16(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u Uint16) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Uint16.
+func (u *Uint16) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Uint16 is
+// null.
+func (u Uint16) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Uint16.
+func (u *Uint16) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Uint16, while an absent one is never passed, leaving
+// the field unset.
+func (u *Uint16) UnmarshalParam(param string) error {
+	return u.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Uint16 is null.
+func (u Uint16) MarshalTOML() ([]byte, error) {
+	return marshalTOML(u.Valid, u.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Uint16.
+func (u *Uint16) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random number, or an explicit null if shouldBeNull is true.
+func (u *Uint16) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewUint16(0, false)
+		return
+	}
+	u.SetValid(uint16(randomN(nextInt, math.MaxUint16)))
+}
+
+// Generate implements testing/quick's Generator.
+func (u Uint16) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewUint16(0, false), func() Uint16 {
+		return Uint16From(uint16(generateUint64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u Uint16) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		return appendUvarint(nil, uint64(u.Uint16)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint16) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Uint16")
+	if err != nil {
+		return err
+	}
+	var v uint16
+	if valid {
+		var n uint64
+		if n, err = binaryUvarint(p, "null.Uint16", math.MaxUint16); err != nil {
+			return err
+		}
+		v = uint16(n)
+	}
+	u.Uint16, u.Valid, u.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (u *Uint16) Scan(value interface{}) error {
+func (u *Uint16) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Uint16", value)
 	if value == nil {
-		u.Uint16, u.Valid, u.Set = 0, false, false
+		u.Uint16, u.Valid, u.Set = 0, false, true
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint16, value)
+	n, err := scanUint(value, "null.Uint16", 16)
+	u.Uint16, u.Valid, u.Set = uint16(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -144,10 +501,29 @@ func (u Uint16) Value() (driver.Value, error) {
 	return int64(u.Uint16), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Uint16) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Uint16(%d)", i.Uint16)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u Uint16) GoString() string {
+	return goString("Uint16", "", "Uint16", strconv.FormatUint(uint64(u.Uint16), 10), u.Uint16 == 0, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u Uint16) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, u, u.Valid, u.Set, u.Uint16)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Uint64 value, or to nil if this Uint16 is null.
+func (u Uint16) LogValue() slog.Value {
+	if !u.Valid {
+		return logNull
 	}
-	return "Uint16(invalid)"
+	return slog.Uint64Value(uint64(u.Uint16))
 }
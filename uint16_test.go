@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 	"testing"
@@ -54,7 +55,8 @@ func TestUnmarshalUint16(t *testing.T) {
 
 	var invalid Uint16
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullUint16(t, invalid, "invalid json")
@@ -143,7 +145,12 @@ func TestUint16IsZero(t *testing.T) {
 	}
 
 	null := NewUint16(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Uint16
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -170,6 +177,9 @@ func TestUint16Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullUint16(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertUint16(t *testing.T, i Uint16, from string) {
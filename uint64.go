@@ -2,12 +2,16 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Uint64 is an nullable uint64.
@@ -39,6 +43,29 @@ func Uint64FromPtr(i *uint64) Uint64 {
 	return NewUint64(*i, true)
 }
 
+// Uint64FromNonZero creates a new Uint64 that will be null if i is 0.
+func Uint64FromNonZero(i uint64) Uint64 {
+	return NewUint64(i, i != 0)
+}
+
+// Uint64FromString parses s with the rules of UnmarshalText into a new Uint64.
+// An empty string produces a null Uint64.
+func Uint64FromString(s string) (Uint64, error) {
+	var u Uint64
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// MustUint64FromString is like Uint64FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustUint64FromString(s string) Uint64 {
+	u, err := Uint64FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (u Uint64) IsValid() bool {
@@ -50,16 +77,53 @@ func (u Uint64) IsSet() bool {
 	return u.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u Uint64) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (u Uint64) Equal(other Uint64) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.Uint64 == other.Uint64
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u Uint64) ExactEqual(other Uint64) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// Compare returns -1 if u is less than other, 0 if they are equal and +1
+// if u is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (u Uint64) Compare(other Uint64) int {
+	if !u.Valid || !other.Valid {
+		return compareNull(u.Valid, other.Valid)
+	}
+	return cmp.Compare(u.Uint64, other.Uint64)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (u *Uint64) UnmarshalJSON(data []byte) error {
+func (u *Uint64) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Uint64", u, data)
 	u.Set = true
+	data, err = unquoteNumber(data, "null.Uint64")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		u.Uint64 = 0
 		u.Valid = false
 		return nil
 	}
 
-	if err := json.Unmarshal(data, &u.Uint64); err != nil {
+	if u.Uint64, err = unmarshalUint(data, "null.Uint64"); err != nil {
 		return err
 	}
 
@@ -68,14 +132,14 @@ func (u *Uint64) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (u *Uint64) UnmarshalText(text []byte) error {
+func (u *Uint64) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Uint64", text)
 	u.Set = true
 	if len(text) == 0 {
 		u.Valid = false
 		return nil
 	}
-	var err error
-	res, err := strconv.ParseUint(string(text), 10, 64)
+	res, err := parseUint(string(text), 64, "null.Uint64")
 	u.Valid = err == nil
 	if u.Valid {
 		u.Uint64 = uint64(res)
@@ -84,11 +148,25 @@ func (u *Uint64) UnmarshalText(text []byte) error {
 }
 
 // MarshalJSON implements json.Marshaler.
+// It encodes a JSON number, or a string with Int64JSONString.
 func (u Uint64) MarshalJSON() ([]byte, error) {
 	if !u.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatUint(u.Uint64, 10)), nil
+	return u.AppendJSON(make([]byte, 0, 22))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u Uint64) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, "null"...), nil
+	}
+	if Int64JSONString {
+		b = append(b, '"')
+		return append(strconv.AppendUint(b, u.Uint64, 10), '"'), nil
+	}
+	return strconv.AppendUint(b, u.Uint64, 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -96,7 +174,16 @@ func (u Uint64) MarshalText() ([]byte, error) {
 	if !u.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatUint(u.Uint64, 10)), nil
+	return u.AppendText(make([]byte, 0, 20))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u Uint64) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, u.Uint64, 10), nil
 }
 
 // SetValid changes this Uint64's value and also sets it to be non-null.
@@ -106,6 +193,22 @@ func (u *Uint64) SetValid(n uint64) {
 	u.Set = true
 }
 
+// SetNull sets this Uint64 to an explicit null, zeroing its value.
+func (u *Uint64) SetNull() {
+	*u = Uint64{Set: true}
+}
+
+// Unset resets this Uint64 to the zero value, as if it was never set.
+func (u *Uint64) Unset() {
+	*u = Uint64{}
+}
+
+// SetPtr changes this Uint64 to the value p points to, or to an explicit
+// null if p is nil.
+func (u *Uint64) SetPtr(p *uint64) {
+	*u = Uint64FromPtr(p)
+}
+
 // Ptr returns a pointer to this Uint64's value, or a nil pointer if this Uint64 is null.
 func (u Uint64) Ptr() *uint64 {
 	if !u.Valid {
@@ -114,25 +217,278 @@ func (u Uint64) Ptr() *uint64 {
 	return &u.Uint64
 }
 
-// IsZero returns true for invalid Uint64's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u Uint64) ValueOrZero() uint64 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint64
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u Uint64) Or(def uint64) uint64 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint64
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u Uint64) Val() uint64 {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u Uint64) Get() (uint64, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u Uint64) MustGet() uint64 {
+	if !u.Valid {
+		panic(&NullError{Type: "null.Uint64"})
+	}
+	return u.Uint64
+}
+
+// IsZero returns true if this Uint64 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (u Uint64) IsZero() bool {
-	return !u.Valid
+	return !u.Set && !u.Valid
 }
 
-// Scan implements the Scanner interface.
-func (u *Uint64) Scan(value interface{}) error {
-	if value == nil {
-		u.Uint64, u.Valid, u.Set = 0, false, false
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint64 is null.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint64, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Uint64 if the input is null or ~.
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+
+	var v uint64
+	if err := unmarshal(&v); err != nil {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+	u.Uint64, u.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Uint64 is null.
+func (u Uint64) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Uint64.
+func (u *Uint64) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Uint64 is null.
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Uint64.
+func (u *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 64-bit integer, or BSON null if this Uint64 is null.
+// Values above math.MaxInt64 cannot be encoded.
+func (u Uint64) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonUintValue(u.Uint64, "null.Uint64")
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any non-negative BSON integer, or a double with no
+// fractional part, that fits in a uint64.
+func (u *Uint64) UnmarshalBSONValue(typ byte, data []byte) error {
+	u.Set = true
+	if bsonIsNull(typ) {
+		u.Uint64, u.Valid = 0, false
 		return nil
 	}
-	u.Valid, u.Set = true, true
+	v, err := bsonUint(typ, data, "null.Uint64", math.MaxUint64)
+	u.Uint64, u.Valid = uint64(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Uint64 is null.
+func (u Uint64) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackUint(nil, u.Uint64), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any non-negative msgpack integer, or a float with no
+// fractional part, that fits in a uint64.
+func (u *Uint64) UnmarshalMsgpack(data []byte) error {
+	u.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+	n, err := msgpackUint(v, "null.Uint64", math.MaxUint64)
+	u.Uint64, u.Valid = uint64(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Uint64 is null.
+func (u Uint64) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORUint(nil, u.Uint64), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any non-negative CBOR integer, or a float with no
+// fractional part, that fits in a uint64.
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	u.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+	n, err := cborUint(v, "null.Uint64", math.MaxUint64)
+	u.Uint64, u.Valid = uint64(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u Uint64) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Uint64.
+func (u *Uint64) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Uint64 is
+// null.
+func (u Uint64) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Uint64.
+func (u *Uint64) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Uint64, while an absent one is never passed, leaving
+// the field unset.
+func (u *Uint64) UnmarshalParam(param string) error {
+	return u.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Uint64 is null.
+func (u Uint64) MarshalTOML() ([]byte, error) {
+	return marshalTOML(u.Valid, u.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Uint64.
+func (u *Uint64) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random number below 2^63, or an explicit null if shouldBeNull is true.
+func (u *Uint64) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewUint64(0, false)
+		return
+	}
+	u.SetValid(uint64(randomN(nextInt, math.MaxInt64)))
+}
+
+// Generate implements testing/quick's Generator.
+func (u Uint64) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewUint64(0, false), func() Uint64 {
+		return Uint64From(generateUint64(rand))
+	})
+}
 
-	// If value is negative int64, convert it to uint64
-	if i, ok := value.(int64); ok && i < 0 {
-		return convert.ConvertAssign(&u.Uint64, uint64(i))
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u Uint64) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		return appendUvarint(nil, u.Uint64), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Uint64")
+	if err != nil {
+		return err
+	}
+	var v uint64
+	if valid {
+		if v, err = binaryUvarint(p, "null.Uint64", math.MaxUint64); err != nil {
+			return err
+		}
 	}
+	u.Uint64, u.Valid, u.Set = v, valid, set
+	return nil
+}
 
-	return convert.ConvertAssign(&u.Uint64, value)
+// Scan implements the Scanner interface.
+func (u *Uint64) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Uint64", value)
+	if value == nil {
+		u.Uint64, u.Valid, u.Set = 0, false, true
+		return nil
+	}
+	n, err := scanUint(value, "null.Uint64", 64)
+	u.Uint64, u.Valid, u.Set = n, err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -149,10 +505,29 @@ func (u Uint64) Value() (driver.Value, error) {
 	return int64(u.Uint64), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Uint64) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Uint64(%d)", i.Uint64)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u Uint64) GoString() string {
+	return goString("Uint64", "", "Uint64", strconv.FormatUint(u.Uint64, 10), u.Uint64 == 0, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u Uint64) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, u, u.Valid, u.Set, u.Uint64)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Uint64 value, or to nil if this Uint64 is null.
+func (u Uint64) LogValue() slog.Value {
+	if !u.Valid {
+		return logNull
 	}
-	return "Uint64(invalid)"
+	return slog.Uint64Value(u.Uint64)
 }
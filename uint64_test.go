@@ -2,6 +2,9 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
 	"testing"
 )
 
@@ -52,7 +55,8 @@ func TestUnmarshalUint64(t *testing.T) {
 
 	var invalid Uint64
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullUint64(t, invalid, "invalid json")
@@ -125,7 +129,12 @@ func TestUint64IsZero(t *testing.T) {
 	}
 
 	null := NewUint64(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Uint64
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -148,13 +157,18 @@ func TestUint64Scan(t *testing.T) {
 	maybePanic(err)
 	assertUint64(t, i, "scanned uint64")
 
-	err = i.Scan(int64(-2))
+	err = i.Scan(int64(18446744073709551614 >> 1))
 	maybePanic(err)
-	assertUint64(t, i, "scanned int64")
+	if i.Uint64 != 18446744073709551614>>1 || !i.Valid {
+		t.Errorf("bad scanned int64: %v", i)
+	}
 
 	err = i.Scan(nil)
 	maybePanic(err)
 	assertNullUint64(t, i, "scanned null")
+	if !i.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertUint64(t *testing.T, i Uint64, from string) {
@@ -171,3 +185,52 @@ func assertNullUint64(t *testing.T, i Uint64, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestUint64ValueScanRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64} {
+		value, err := Uint64From(v).Value()
+		maybePanic(err)
+
+		var fromValue Uint64
+		err = fromValue.Scan(value)
+		maybePanic(err)
+		if !fromValue.Valid || uint64(fromValue.Uint64) != v {
+			t.Errorf("bad round trip of %d through %T: %d", v, value, fromValue.Uint64)
+		}
+
+		for _, text := range []interface{}{strconv.FormatUint(v, 10), []byte(strconv.FormatUint(v, 10))} {
+			var scanned Uint64
+			err = scanned.Scan(text)
+			maybePanic(err)
+			if !scanned.Valid || uint64(scanned.Uint64) != v {
+				t.Errorf("bad scan of %q: %d", text, scanned.Uint64)
+			}
+		}
+	}
+
+	var big Uint64
+	err := big.Scan("18446744073709551616")
+	if err == nil {
+		t.Error("expected error")
+	}
+	if big.Valid {
+		t.Error("overflowing scan should be invalid")
+	}
+}
+
+func TestUnmarshalUint64Precision(t *testing.T) {
+	for _, v := range []uint64{1<<53 + 1, math.MaxInt64 + 1, math.MaxUint64} {
+		var u Uint64
+		err := json.Unmarshal([]byte(strconv.FormatUint(v, 10)), &u)
+		maybePanic(err)
+		if !u.Valid || u.Uint64 != v {
+			t.Errorf("bad unmarshal of %d: %d", v, u.Uint64)
+		}
+	}
+
+	var u Uint64
+	if err := json.Unmarshal([]byte("18446744073709551616"), &u); err == nil {
+		t.Error("expected overflow error")
+	}
+	assertNullUint64(t, u, "overflowing json")
+}
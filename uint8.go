@@ -2,13 +2,16 @@ package null
 
 import (
 	"bytes"
+	"cmp"
 	"database/sql/driver"
-	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"reflect"
 	"strconv"
-
-	"github.com/sisoftrg/null/convert"
 )
 
 // Uint8 is an nullable uint8.
@@ -40,6 +43,29 @@ func Uint8FromPtr(i *uint8) Uint8 {
 	return NewUint8(*i, true)
 }
 
+// Uint8FromNonZero creates a new Uint8 that will be null if i is 0.
+func Uint8FromNonZero(i uint8) Uint8 {
+	return NewUint8(i, i != 0)
+}
+
+// Uint8FromString parses s with the rules of UnmarshalText into a new Uint8.
+// An empty string produces a null Uint8.
+func Uint8FromString(s string) (Uint8, error) {
+	var u Uint8
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// MustUint8FromString is like Uint8FromString but panics if s cannot be parsed.
+// It is meant for tests and constants.
+func MustUint8FromString(s string) Uint8 {
+	u, err := Uint8FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (u Uint8) IsValid() bool {
@@ -51,22 +77,59 @@ func (u Uint8) IsSet() bool {
 	return u.Set
 }
 
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u Uint8) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (u Uint8) Equal(other Uint8) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.Uint8 == other.Uint8
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u Uint8) ExactEqual(other Uint8) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// Compare returns -1 if u is less than other, 0 if they are equal and +1
+// if u is greater. A null is less than any valid value and equal to another
+// null; Set is ignored. See NullsFirst and NullsLast.
+func (u Uint8) Compare(other Uint8) int {
+	if !u.Valid || !other.Valid {
+		return compareNull(u.Valid, other.Valid)
+	}
+	return cmp.Compare(u.Uint8, other.Uint8)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
-func (u *Uint8) UnmarshalJSON(data []byte) error {
+func (u *Uint8) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Uint8", u, data)
 	u.Set = true
+	data, err = unquoteNumber(data, "null.Uint8")
+	if err != nil {
+		return err
+	}
+
 	if bytes.Equal(data, NullBytes) {
 		u.Valid = false
 		u.Uint8 = 0
 		return nil
 	}
 
-	var x uint64
-	if err := json.Unmarshal(data, &x); err != nil {
+	x, err := unmarshalUint(data, "null.Uint8")
+	if err != nil {
 		return err
 	}
 
 	if x > math.MaxUint8 {
-		return fmt.Errorf("json: %d overflows max uint8 value", x)
+		return overflowf("null: %d does not fit in null.Uint8", x)
 	}
 
 	u.Uint8 = uint8(x)
@@ -75,14 +138,14 @@ func (u *Uint8) UnmarshalJSON(data []byte) error {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (u *Uint8) UnmarshalText(text []byte) error {
+func (u *Uint8) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Uint8", text)
 	u.Set = true
 	if len(text) == 0 {
 		u.Valid = false
 		return nil
 	}
-	var err error
-	res, err := strconv.ParseUint(string(text), 10, 8)
+	res, err := parseUint(string(text), 8, "null.Uint8")
 	u.Valid = err == nil
 	if u.Valid {
 		u.Uint8 = uint8(res)
@@ -95,7 +158,16 @@ func (u Uint8) MarshalJSON() ([]byte, error) {
 	if !u.Valid {
 		return NullBytes, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint8), 10)), nil
+	return u.AppendJSON(make([]byte, 0, 3))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u Uint8) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, "null"...), nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint8), 10), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -103,7 +175,16 @@ func (u Uint8) MarshalText() ([]byte, error) {
 	if !u.Valid {
 		return []byte{}, nil
 	}
-	return []byte(strconv.FormatUint(uint64(u.Uint8), 10)), nil
+	return u.AppendText(make([]byte, 0, 3))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u Uint8) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(u.Uint8), 10), nil
 }
 
 // SetValid changes this Uint8's value and also sets it to be non-null.
@@ -113,6 +194,22 @@ func (u *Uint8) SetValid(n uint8) {
 	u.Set = true
 }
 
+// SetNull sets this Uint8 to an explicit null, zeroing its value.
+func (u *Uint8) SetNull() {
+	*u = Uint8{Set: true}
+}
+
+// Unset resets this Uint8 to the zero value, as if it was never set.
+func (u *Uint8) Unset() {
+	*u = Uint8{}
+}
+
+// SetPtr changes this Uint8 to the value p points to, or to an explicit
+// null if p is nil.
+func (u *Uint8) SetPtr(p *uint8) {
+	*u = Uint8FromPtr(p)
+}
+
 // Ptr returns a pointer to this Uint8's value, or a nil pointer if this Uint8 is null.
 func (u Uint8) Ptr() *uint8 {
 	if !u.Valid {
@@ -121,19 +218,279 @@ func (u Uint8) Ptr() *uint8 {
 	return &u.Uint8
 }
 
-// IsZero returns true for invalid Uint8's, for future omitempty support (Go 1.4?)
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u Uint8) ValueOrZero() uint8 {
+	if !u.Valid {
+		return 0
+	}
+	return u.Uint8
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u Uint8) Or(def uint8) uint8 {
+	if !u.Valid {
+		return def
+	}
+	return u.Uint8
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u Uint8) Val() uint8 {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u Uint8) Get() (uint8, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u Uint8) MustGet() uint8 {
+	if !u.Valid {
+		panic(&NullError{Type: "null.Uint8"})
+	}
+	return u.Uint8
+}
+
+// IsZero returns true if this Uint8 was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
 func (u Uint8) IsZero() bool {
-	return !u.Valid
+	return !u.Set && !u.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this Uint8 is null.
+func (u Uint8) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint8, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It will unmarshal to a null Uint8 if the input is null or ~.
+func (u *Uint8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.Uint8, u.Valid = 0, false
+		return err
+	}
+
+	var v uint8
+	if err := unmarshal(&v); err != nil {
+		u.Uint8, u.Valid = 0, false
+		return err
+	}
+	u.Uint8, u.Valid = v, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this Uint8 is null.
+func (u Uint8) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null Uint8.
+func (u *Uint8) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this Uint8 is null.
+func (u Uint8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null Uint8.
+func (u *Uint8) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON 32-bit integer, or BSON null if this Uint8 is null.
+func (u Uint8) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonInt32Value(int32(u.Uint8))
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts any non-negative BSON integer, or a double with no
+// fractional part, that fits in a uint8.
+func (u *Uint8) UnmarshalBSONValue(typ byte, data []byte) error {
+	u.Set = true
+	if bsonIsNull(typ) {
+		u.Uint8, u.Valid = 0, false
+		return nil
+	}
+	v, err := bsonUint(typ, data, "null.Uint8", math.MaxUint8)
+	u.Uint8, u.Valid = uint8(v), err == nil
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes the smallest msgpack integer that holds the value, or nil if
+// this Uint8 is null.
+func (u Uint8) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackUint(nil, uint64(u.Uint8)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts any non-negative msgpack integer, or a float with no
+// fractional part, that fits in a uint8.
+func (u *Uint8) UnmarshalMsgpack(data []byte) error {
+	u.Set = true
+	v, err := decodeMsgpack(data)
+	if err != nil || v == nil {
+		u.Uint8, u.Valid = 0, false
+		return err
+	}
+	n, err := msgpackUint(v, "null.Uint8", math.MaxUint8)
+	u.Uint8, u.Valid = uint8(n), err == nil
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes the smallest CBOR integer that holds the value, or null if this
+// Uint8 is null.
+func (u Uint8) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORUint(nil, uint64(u.Uint8)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts any non-negative CBOR integer, or a float with no
+// fractional part, that fits in a uint8.
+func (u *Uint8) UnmarshalCBOR(data []byte) error {
+	u.Set = true
+	v, err := decodeCBOR(data)
+	if err != nil || v == nil {
+		u.Uint8, u.Valid = 0, false
+		return err
+	}
+	n, err := cborUint(v, "null.Uint8", math.MaxUint8)
+	u.Uint8, u.Valid = uint8(n), err == nil
+	return err
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u Uint8) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Uint8.
+func (u *Uint8) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this Uint8 is
+// null.
+func (u Uint8) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null Uint8.
+func (u *Uint8) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// UnmarshalParam implements echo's BindUnmarshaler, which gin's form binding
+// also uses. It accepts the same text as UnmarshalText. An empty parameter
+// unmarshals to a null Uint8, while an absent one is never passed, leaving
+// the field unset.
+func (u *Uint8) UnmarshalParam(param string) error {
+	return u.UnmarshalText([]byte(param))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Uint8 is null.
+func (u Uint8) MarshalTOML() ([]byte, error) {
+	return marshalTOML(u.Valid, u.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null Uint8.
+func (u *Uint8) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a random number, or an explicit null if shouldBeNull is true.
+func (u *Uint8) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewUint8(0, false)
+		return
+	}
+	u.SetValid(uint8(randomN(nextInt, math.MaxUint8)))
+}
+
+// Generate implements testing/quick's Generator.
+func (u Uint8) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewUint8(0, false), func() Uint8 {
+		return Uint8From(uint8(generateUint64(rand)))
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u Uint8) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		return appendUvarint(nil, uint64(u.Uint8)), nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint8) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Uint8")
+	if err != nil {
+		return err
+	}
+	var v uint8
+	if valid {
+		var n uint64
+		if n, err = binaryUvarint(p, "null.Uint8", math.MaxUint8); err != nil {
+			return err
+		}
+		v = uint8(n)
+	}
+	u.Uint8, u.Valid, u.Set = v, valid, set
+	return nil
 }
 
 // Scan implements the Scanner interface.
-func (u *Uint8) Scan(value interface{}) error {
+func (u *Uint8) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Uint8", value)
 	if value == nil {
-		u.Uint8, u.Valid, u.Set = 0, false, false
+		u.Uint8, u.Valid, u.Set = 0, false, true
 		return nil
 	}
-	u.Valid, u.Set = true, true
-	return convert.ConvertAssign(&u.Uint8, value)
+	n, err := scanUint(value, "null.Uint8", 8)
+	u.Uint8, u.Valid, u.Set = uint8(n), err == nil, true
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -144,10 +501,29 @@ func (u Uint8) Value() (driver.Value, error) {
 	return int64(u.Uint8), nil
 }
 
-// String implements the Stringer interface
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
 func (i Uint8) String() string {
-	if i.Valid {
-		return fmt.Sprintf("Uint8(%d)", i.Uint8)
+	return fmt.Sprint(i)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u Uint8) GoString() string {
+	return goString("Uint8", "", "Uint8", strconv.FormatUint(uint64(u.Uint8), 10), u.Uint8 == 0, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u Uint8) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, u, u.Valid, u.Set, u.Uint8)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a Uint64 value, or to nil if this Uint8 is null.
+func (u Uint8) LogValue() slog.Value {
+	if !u.Valid {
+		return logNull
 	}
-	return "Uint8(invalid)"
+	return slog.Uint64Value(uint64(u.Uint8))
 }
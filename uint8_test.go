@@ -2,6 +2,7 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 	"testing"
@@ -54,7 +55,8 @@ func TestUnmarshalUint8(t *testing.T) {
 
 	var invalid Uint8
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullUint8(t, invalid, "invalid json")
@@ -143,7 +145,12 @@ func TestUint8IsZero(t *testing.T) {
 	}
 
 	null := NewUint8(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Uint8
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -170,6 +177,9 @@ func TestUint8Scan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullUint8(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertUint8(t *testing.T, i Uint8, from string) {
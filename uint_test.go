@@ -2,6 +2,9 @@ package null
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
 	"testing"
 )
 
@@ -52,7 +55,8 @@ func TestUnmarshalUint(t *testing.T) {
 
 	var invalid Uint
 	err = invalid.UnmarshalJSON(invalidJSON)
-	if _, ok := err.(*json.SyntaxError); !ok {
+	var target *json.SyntaxError
+	if !errors.As(err, &target) {
 		t.Errorf("expected json.SyntaxError, not %T", err)
 	}
 	assertNullUint(t, invalid, "invalid json")
@@ -125,7 +129,12 @@ func TestUintIsZero(t *testing.T) {
 	}
 
 	null := NewUint(0, false)
-	if !null.IsZero() {
+	if null.IsZero() {
+		t.Errorf("IsZero() should be false for an explicit null")
+	}
+
+	var unset Uint
+	if !unset.IsZero() {
 		t.Errorf("IsZero() should be true")
 	}
 
@@ -152,6 +161,9 @@ func TestUintScan(t *testing.T) {
 	err = null.Scan(nil)
 	maybePanic(err)
 	assertNullUint(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
 }
 
 func assertUint(t *testing.T, i Uint, from string) {
@@ -168,3 +180,39 @@ func assertNullUint(t *testing.T, i Uint, from string) {
 		t.Error(from, "is valid, but should be invalid")
 	}
 }
+
+func TestUintValueScanRoundTrip(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("uint is narrower than 64 bits")
+	}
+
+	for _, v := range []uint64{0, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64} {
+		value, err := UintFrom(uint(v)).Value()
+		maybePanic(err)
+
+		var fromValue Uint
+		err = fromValue.Scan(value)
+		maybePanic(err)
+		if !fromValue.Valid || uint64(fromValue.Uint) != v {
+			t.Errorf("bad round trip of %d through %T: %d", v, value, fromValue.Uint)
+		}
+
+		for _, text := range []interface{}{strconv.FormatUint(v, 10), []byte(strconv.FormatUint(v, 10))} {
+			var scanned Uint
+			err = scanned.Scan(text)
+			maybePanic(err)
+			if !scanned.Valid || uint64(scanned.Uint) != v {
+				t.Errorf("bad scan of %q: %d", text, scanned.Uint)
+			}
+		}
+	}
+
+	var big Uint
+	err := big.Scan("18446744073709551616")
+	if err == nil {
+		t.Error("expected error")
+	}
+	if big.Valid {
+		t.Error("overflowing scan should be invalid")
+	}
+}
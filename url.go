@@ -0,0 +1,486 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// URL is a nullable *url.URL.
+// An empty string is treated as null when unmarshaling or scanning.
+type URL struct {
+	URL   *url.URL
+	Valid bool
+	Set   bool
+}
+
+// NewURL creates a new URL. It shares u with the caller instead of
+// copying it; see Clone.
+func NewURL(u *url.URL, valid bool) URL {
+	return URL{
+		URL:   u,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// URLFrom creates a new URL that will be null if u is nil.
+func URLFrom(u *url.URL) URL {
+	return NewURL(u, u != nil)
+}
+
+// URLFromNonEmpty creates a new URL that will be null if u is nil or empty.
+func URLFromNonEmpty(u *url.URL) URL {
+	return NewURL(u, u != nil && u.String() != "")
+}
+
+// URLFromString parses s into a new URL. An empty string produces
+// a null URL.
+func URLFromString(s string) (URL, error) {
+	var u URL
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (u URL) IsValid() bool {
+	return u.Set && u.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (u URL) IsSet() bool {
+	return u.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u URL) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same URL string. Set is ignored; see ExactEqual.
+func (u URL) Equal(other URL) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	if u.URL == nil || other.URL == nil {
+		return u.URL == other.URL
+	}
+	return u.URL.String() == other.URL.String()
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u URL) ExactEqual(other URL) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// Clone returns a copy of u with its own url.URL, so that changing the
+// fields of either does not change the other.
+func (u URL) Clone() URL {
+	if u.URL != nil {
+		c := *u.URL
+		u.URL = &c
+	}
+	return u
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (u *URL) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.URL", u, data)
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.URL, u.Valid = nil, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		u.URL, u.Valid = nil, false
+		return err
+	}
+	return u.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It will unmarshal to a null URL if the input is a blank string.
+func (u *URL) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.URL", text)
+	u.Set = true
+	if len(text) == 0 {
+		u.URL, u.Valid = nil, false
+		return nil
+	}
+	res, err := url.Parse(string(text))
+	u.URL, u.Valid = res, err == nil
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this URL is null or empty, as the empty string
+// unmarshals to null.
+func (u URL) MarshalJSON() ([]byte, error) {
+	if !u.Valid || u.URL == nil {
+		return NullBytes, nil
+	}
+	return u.AppendJSON(nil)
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u URL) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid || u.URL == nil {
+		return append(b, "null"...), nil
+	}
+	s := u.URL.String()
+	if s == "" {
+		return append(b, "null"...), nil
+	}
+	return appendJSONString(b, s), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this URL is null.
+func (u URL) MarshalText() ([]byte, error) {
+	if !u.Valid || u.URL == nil {
+		return []byte{}, nil
+	}
+	return u.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u URL) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid || u.URL == nil {
+		return b, nil
+	}
+	return append(b, u.URL.String()...), nil
+}
+
+// SetValid changes this URL's value and also sets it to be non-null.
+func (u *URL) SetValid(v *url.URL) {
+	u.URL = v
+	u.Valid = true
+	u.Set = true
+}
+
+// SetNull sets this URL to an explicit null, zeroing its value.
+func (u *URL) SetNull() {
+	*u = URL{Set: true}
+}
+
+// Unset resets this URL to the zero value, as if it was never set.
+func (u *URL) Unset() {
+	*u = URL{}
+}
+
+// SetPtr changes this URL to p, or to an explicit null if p is nil.
+func (u *URL) SetPtr(p *url.URL) {
+	*u = URLFrom(p)
+}
+
+// Ptr returns this URL's value, or a nil pointer if this URL is null.
+func (u URL) Ptr() *url.URL {
+	if !u.Valid {
+		return nil
+	}
+	return u.URL
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u URL) ValueOrZero() *url.URL {
+	if !u.Valid {
+		return nil
+	}
+	return u.URL
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u URL) Or(def *url.URL) *url.URL {
+	if !u.Valid {
+		return def
+	}
+	return u.URL
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u URL) Val() *url.URL {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u URL) Get() (*url.URL, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u URL) MustGet() *url.URL {
+	if !u.Valid {
+		panic(&NullError{Type: "null.URL"})
+	}
+	return u.URL
+}
+
+// IsZero returns true if this URL was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (u URL) IsZero() bool {
+	return !u.Set && !u.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this URL is null, and the text form otherwise.
+func (u URL) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	data, err := u.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// URL if the input is null or ~.
+func (u *URL) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.URL, u.Valid = nil, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		u.URL, u.Valid = nil, false
+		return err
+	}
+	return u.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this URL is null.
+func (u URL) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null URL.
+func (u *URL) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this URL is null.
+func (u URL) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null URL.
+func (u *URL) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes a BSON string in the same form as MarshalText, or BSON
+// null if this URL is null.
+func (u URL) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonTextValue(u.MarshalText)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts a BSON string in the same form as UnmarshalText.
+func (u *URL) UnmarshalBSONValue(typ byte, data []byte) error {
+	return unmarshalBSONText(typ, data, "null.URL", u.UnmarshalText)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this URL is null.
+func (u URL) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, u.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (u *URL) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.URL", u.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this URL is null.
+func (u URL) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, u.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (u *URL) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.URL", u.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u URL) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null URL.
+func (u *URL) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this URL is
+// null.
+func (u URL) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null URL.
+func (u *URL) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this URL is null.
+func (u URL) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(u.Valid, u.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null URL.
+func (u *URL) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets an https URL with a random path on example.com, or an explicit null if shouldBeNull is true.
+func (u *URL) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewURL(nil, false)
+		return
+	}
+	u.SetValid(&url.URL{Scheme: "https", Host: "example.com", Path: "/" + randomString(nextInt, 8)})
+}
+
+// Generate implements testing/quick's Generator.
+func (u URL) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewURL(nil, false), func() URL {
+		return URLFrom(&url.URL{Scheme: "https", Host: strings.ToLower(generateWord(rand)) + ".example", Path: "/" + generateWord(rand)})
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u URL) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		if u.URL == nil {
+			return nil, nil
+		}
+		return u.URL.MarshalBinary()
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *URL) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.URL")
+	if err != nil {
+		return err
+	}
+	var v *url.URL
+	if valid {
+		v = new(url.URL)
+		if err = v.UnmarshalBinary(p); err != nil {
+			return err
+		}
+	}
+	u.URL, u.Valid, u.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (u *URL) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.URL", value)
+	switch x := value.(type) {
+	case nil:
+		u.URL, u.Valid, u.Set = nil, false, true
+		return nil
+	case string:
+		err = u.UnmarshalText([]byte(x))
+	case []byte:
+		err = u.UnmarshalText(x)
+	default:
+		u.URL, u.Valid, u.Set = nil, false, true
+		err = fmt.Errorf("null: cannot scan type %T into null.URL: %v", value, value)
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (u URL) Value() (driver.Value, error) {
+	if !u.Valid || u.URL == nil {
+		return nil, nil
+	}
+	return u.URL.String(), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (u URL) String() string {
+	return fmt.Sprint(u)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u URL) GoString() string {
+	return goString("URL", "", "URL", goURL(u.URL), u.URL == nil, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u URL) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, u, u.Valid && u.URL != nil, u.Set, u.URL)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value with any password redacted, or to nil if
+// this URL is null.
+func (u URL) LogValue() slog.Value {
+	if !u.Valid || u.URL == nil {
+		return logNull
+	}
+	return slog.StringValue(u.URL.Redacted())
+}
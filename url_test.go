@@ -0,0 +1,180 @@
+package null
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+var (
+	urlString = "https://example.com/callback?id=1#top"
+	urlJSON   = []byte(`"https://example.com/callback?id=1#top"`)
+)
+
+func TestURLFrom(t *testing.T) {
+	parsed, err := url.Parse(urlString)
+	maybePanic(err)
+	u := URLFrom(parsed)
+	assertURL(t, u, "URLFrom()")
+
+	null := URLFrom(nil)
+	assertNullURL(t, null, "URLFrom(nil)")
+}
+
+func TestURLFromString(t *testing.T) {
+	u, err := URLFromString(urlString)
+	maybePanic(err)
+	assertURL(t, u, "URLFromString()")
+
+	null, err := URLFromString("")
+	maybePanic(err)
+	assertNullURL(t, null, "URLFromString(\"\")")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	for _, in := range []string{"://missing-scheme", "http://host/%zz", "http://[::1"} {
+		if _, err := URLFromString(in); err == nil {
+			t.Errorf("URLFromString(%q) should fail", in)
+		}
+	}
+}
+
+func TestUnmarshalURL(t *testing.T) {
+	var u URL
+	err := json.Unmarshal(urlJSON, &u)
+	maybePanic(err)
+	assertURL(t, u, "url json")
+
+	var null URL
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullURL(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty URL
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullURL(t, empty, "empty string json")
+
+	var bad URL
+	err = json.Unmarshal([]byte(`"http://host/%zz"`), &bad)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullURL(t, bad, "bad url json")
+
+	var badType URL
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullURL(t, badType, "wrong type json")
+}
+
+func TestMarshalURL(t *testing.T) {
+	u, err := URLFromString(urlString)
+	maybePanic(err)
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(urlJSON), "non-empty json marshal")
+
+	data, err = u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, urlString, "non-empty text marshal")
+
+	null := NewURL(nil, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	data, err = null.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, "", "null text marshal")
+}
+
+func TestURLScanValue(t *testing.T) {
+	for _, v := range []interface{}{urlString, []byte(urlString)} {
+		var u URL
+		err := u.Scan(v)
+		maybePanic(err)
+		assertURL(t, u, "scanned url")
+
+		value, err := u.Value()
+		maybePanic(err)
+		if value != urlString {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var null URL
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullURL(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"http://host/%zz", int64(1)} {
+		var wrong URL
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullURL(t, wrong, "scanned garbage")
+	}
+}
+
+func TestURLPointer(t *testing.T) {
+	u, err := URLFromString(urlString)
+	maybePanic(err)
+	if ptr := u.Ptr(); ptr == nil || ptr.String() != urlString {
+		t.Errorf("bad %s url: %#v ≠ %s\n", "pointer", ptr, urlString)
+	}
+
+	null := NewURL(nil, false)
+	if ptr := null.Ptr(); ptr != nil {
+		t.Errorf("bad %s url: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestURLSetValid(t *testing.T) {
+	change := NewURL(nil, false)
+	assertNullURL(t, change, "SetValid()")
+	parsed, err := url.Parse(urlString)
+	maybePanic(err)
+	change.SetValid(parsed)
+	assertURL(t, change, "SetValid()")
+}
+
+func assertURL(t *testing.T, u URL, from string) {
+	if u.URL == nil || u.URL.String() != urlString {
+		t.Errorf("bad %s url: %v ≠ %s\n", from, u.URL, urlString)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullURL(t *testing.T, u URL, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
+
+func TestURLClone(t *testing.T) {
+	u, err := URLFromString(urlString)
+	maybePanic(err)
+	clone := u.Clone()
+	u.URL.Path = "/changed"
+	if clone.URL.String() != urlString || !clone.Valid || !clone.Set {
+		t.Errorf("Clone() after mutating original = %v", clone.URL)
+	}
+	if null := NewURL(nil, false).Clone(); null.URL != nil || null.Valid {
+		t.Errorf("Clone() of null = %#v", null)
+	}
+}
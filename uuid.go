@@ -0,0 +1,558 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+// UUID is a nullable RFC 4122 UUID. The zero UUID is a valid value,
+// distinct from null.
+type UUID struct {
+	UUID  [16]byte
+	Valid bool
+	Set   bool
+}
+
+// NewUUID creates a new UUID
+func NewUUID(u [16]byte, valid bool) UUID {
+	return UUID{
+		UUID:  u,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// UUIDFrom creates a new UUID that will always be valid.
+func UUIDFrom(u [16]byte) UUID {
+	return NewUUID(u, true)
+}
+
+// UUIDFromPtr creates a new UUID that will be null if u is nil.
+func UUIDFromPtr(u *[16]byte) UUID {
+	if u == nil {
+		return NewUUID([16]byte{}, false)
+	}
+	return NewUUID(*u, true)
+}
+
+// UUIDFromNonZero creates a new UUID that will be null if u is the nil UUID.
+func UUIDFromNonZero(u [16]byte) UUID {
+	return NewUUID(u, u != [16]byte{})
+}
+
+// UUIDFromString parses s into a new UUID. An empty string produces
+// a null UUID.
+func UUIDFromString(s string) (UUID, error) {
+	var u UUID
+	err := u.UnmarshalText([]byte(s))
+	return u, err
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (u UUID) IsValid() bool {
+	return u.Set && u.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (u UUID) IsSet() bool {
+	return u.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (u UUID) IsNull() bool {
+	return u.Set && !u.Valid
+}
+
+// Equal reports whether u and other are both null, or both valid with
+// the same value. Set is ignored; see ExactEqual.
+func (u UUID) Equal(other UUID) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.UUID == other.UUID
+}
+
+// ExactEqual is like Equal, but also requires u and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (u UUID) ExactEqual(other UUID) bool {
+	return u.Set == other.Set && u.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports string and null input. An empty string is null.
+func (u *UUID) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.UUID", u, data)
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.UUID, u.Valid = [16]byte{}, false
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		u.UUID, u.Valid = [16]byte{}, false
+		return err
+	}
+	return u.UnmarshalText([]byte(str))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the hyphenated form, optionally in braces or with a
+// "urn:uuid:" prefix, and 32 bare hex digits.
+func (u *UUID) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.UUID", text)
+	u.Set = true
+	if len(text) == 0 {
+		u.UUID, u.Valid = [16]byte{}, false
+		return nil
+	}
+	res, err := parseUUID(string(text))
+	u.Valid = err == nil
+	u.UUID = res
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this UUID is null.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return NullBytes, nil
+	}
+	return u.AppendJSON(make([]byte, 0, 38))
+}
+
+// AppendJSON implements JSONAppender, appending what MarshalJSON returns
+// to b.
+func (u UUID) AppendJSON(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '"')
+	b = appendUUID(b, u.UUID)
+	return append(b, '"'), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode a blank string if this UUID is null.
+func (u UUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return u.AppendText(make([]byte, 0, 36))
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. Nothing is appended if u is null.
+func (u UUID) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return b, nil
+	}
+	return appendUUID(b, u.UUID), nil
+}
+
+// SetValid changes this UUID's value and also sets it to be non-null.
+func (u *UUID) SetValid(v [16]byte) {
+	u.UUID = v
+	u.Valid = true
+	u.Set = true
+}
+
+// SetNull sets this UUID to an explicit null, zeroing its value.
+func (u *UUID) SetNull() {
+	*u = UUID{Set: true}
+}
+
+// Unset resets this UUID to the zero value, as if it was never set.
+func (u *UUID) Unset() {
+	*u = UUID{}
+}
+
+// SetPtr changes this UUID to the value p points to, or to an explicit
+// null if p is nil.
+func (u *UUID) SetPtr(p *[16]byte) {
+	*u = UUIDFromPtr(p)
+}
+
+// Ptr returns a pointer to this UUID's value, or a nil pointer if this UUID is null.
+func (u UUID) Ptr() *[16]byte {
+	if !u.Valid {
+		return nil
+	}
+	return &u.UUID
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (u UUID) ValueOrZero() [16]byte {
+	if !u.Valid {
+		return [16]byte{}
+	}
+	return u.UUID
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (u UUID) Or(def [16]byte) [16]byte {
+	if !u.Valid {
+		return def
+	}
+	return u.UUID
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (u UUID) Val() [16]byte {
+	return u.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (u UUID) Get() ([16]byte, bool) {
+	return u.ValueOrZero(), u.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if u is
+// null.
+func (u UUID) MustGet() [16]byte {
+	if !u.Valid {
+		panic(&NullError{Type: "null.UUID"})
+	}
+	return u.UUID
+}
+
+// IsZero returns true if this UUID was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (u UUID) IsZero() bool {
+	return !u.Set && !u.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this UUID is null, and the text form otherwise.
+func (u UUID) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	data, err := u.MarshalText()
+	return string(data), err
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It accepts the same text as UnmarshalText. It will unmarshal to a null
+// UUID if the input is null or ~.
+func (u *UUID) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	u.Set = true
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		u.UUID, u.Valid = [16]byte{}, false
+		return err
+	}
+
+	var str string
+	if err := unmarshal(&str); err != nil {
+		u.UUID, u.Valid = [16]byte{}, false
+		return err
+	}
+	return u.UnmarshalText([]byte(str))
+}
+
+// MarshalXML implements xml.Marshaler.
+// It encodes an empty element if this UUID is null.
+func (u UUID) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(enc, start, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the same text as UnmarshalText. An empty element unmarshals
+// to a null UUID.
+func (u *UUID) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(dec, start, u.UnmarshalText)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// The attribute is omitted if this UUID is null.
+func (u UUID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, u.Valid, u.MarshalText)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// An empty attribute unmarshals to a null UUID.
+func (u *UUID) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes BSON binary of the UUID subtype, or BSON null if this UUID is
+// null.
+func (u UUID) MarshalBSONValue() (byte, []byte, error) {
+	if !u.Valid {
+		return bsonNull, nil, nil
+	}
+	return bsonBinaryValue(bsonBinaryUUID, u.UUID[:])
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It accepts 16 bytes of BSON binary of either UUID subtype, or a string in
+// the same form as UnmarshalText.
+func (u *UUID) UnmarshalBSONValue(typ byte, data []byte) error {
+	u.Set = true
+	if bsonIsNull(typ) {
+		u.UUID, u.Valid = [16]byte{}, false
+		return nil
+	}
+	if typ == bsonString || typ == bsonSymbol {
+		return unmarshalBSONText(typ, data, "null.UUID", u.UnmarshalText)
+	}
+	sub, v, err := bsonReadBinary(typ, data, "null.UUID")
+	if err == nil && (len(v) != 16 || (sub != bsonBinaryUUID && sub != bsonBinaryUUIDOld)) {
+		err = fmt.Errorf("null: cannot decode BSON binary subtype 0x%02x of length %d into null.UUID", sub, len(v))
+	}
+	if err != nil {
+		u.UUID, u.Valid = [16]byte{}, false
+		return err
+	}
+	copy(u.UUID[:], v)
+	u.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes a msgpack string in the same form as MarshalText, or nil if
+// this UUID is null.
+func (u UUID) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return msgpackNull, nil
+	}
+	return appendMsgpackText(nil, u.MarshalText)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It accepts a msgpack string in the same form as UnmarshalText.
+func (u *UUID) UnmarshalMsgpack(data []byte) error {
+	return unmarshalMsgpackText(data, "null.UUID", u.UnmarshalText)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes a CBOR text string in the same form as MarshalText, or null if
+// this UUID is null.
+func (u UUID) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return cborNull, nil
+	}
+	return appendCBORText(nil, u.MarshalText)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It accepts a CBOR text string in the same form as UnmarshalText.
+func (u *UUID) UnmarshalCBOR(data []byte) error {
+	return unmarshalCBORText(data, "null.UUID", u.UnmarshalText)
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (u UUID) MarshalGQL(w io.Writer) {
+	marshalGQL(w, u.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null UUID.
+func (u *UUID) UnmarshalGQL(v interface{}) error {
+	return unmarshalGQL(v, u.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It returns the same text as MarshalText, or an empty cell if this UUID is
+// null.
+func (u UUID) MarshalCSV() (string, error) {
+	return marshalCSV(u.Valid, u.MarshalText)
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It accepts the same text as UnmarshalText. An empty cell unmarshals to a
+// null UUID.
+func (u *UUID) UnmarshalCSV(cell string) error {
+	return u.UnmarshalText([]byte(cell))
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes a TOML string of the same text as MarshalText, or an empty
+// string if this UUID is null.
+func (u UUID) MarshalTOML() ([]byte, error) {
+	return marshalTOMLText(u.Valid, u.MarshalText)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts a TOML string in the same form as UnmarshalText, and other
+// values as UnmarshalJSON accepts their JSON form. An empty string
+// unmarshals to a null UUID.
+func (u *UUID) UnmarshalTOML(v interface{}) error {
+	return unmarshalTOML(v, u.UnmarshalText, u.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It sets a version 4 UUID that is unique for each nextInt value, or an explicit null if shouldBeNull is true.
+func (u *UUID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*u = NewUUID([16]byte{}, false)
+		return
+	}
+	u.SetValid(randomUUID(nextInt))
+}
+
+// Generate implements testing/quick's Generator.
+func (u UUID) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, NewUUID([16]byte{}, false), func() UUID {
+		var u [16]byte
+		rand.Read(u[:])
+		u[6] = u[6]&0x0f | 0x40
+		u[8] = u[8]&0x3f | 0x80
+		return UUIDFrom(u)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Set, u.Valid, func() ([]byte, error) {
+		return u.UUID[:], nil
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.UUID")
+	if err != nil {
+		return err
+	}
+	var v [16]byte
+	if valid {
+		if err = binaryFixed(p, "null.UUID", 16); err != nil {
+			return err
+		}
+		copy(v[:], p)
+	}
+	u.UUID, u.Valid, u.Set = v, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It accepts text and 16 raw bytes.
+func (u *UUID) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.UUID", value)
+	var res [16]byte
+	switch x := value.(type) {
+	case nil:
+		u.UUID, u.Valid, u.Set = [16]byte{}, false, true
+		return nil
+	case string:
+		res, err = parseUUID(x)
+	case []byte:
+		if len(x) == 16 {
+			copy(res[:], x)
+		} else {
+			res, err = parseUUID(string(x))
+		}
+	default:
+		err = fmt.Errorf("null: cannot scan type %T into null.UUID: %v", value, value)
+	}
+	u.Set = true
+	u.Valid = err == nil
+	u.UUID = res
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// The UUID is stored in its canonical text form.
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return formatUUID(u.UUID), nil
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (u UUID) String() string {
+	return fmt.Sprint(u)
+}
+
+// GoString implements the GoStringer interface, formatting u as the Go
+// expression that builds it.
+func (u UUID) GoString() string {
+	return goString("UUID", "", "UUID", goUUID(u.UUID), u.UUID == [16]byte{}, u.Valid, u.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// u with the verb if it is valid.
+func (u UUID) Format(state fmt.State, verb rune) {
+	payload := interface{}(u.UUID)
+	if textVerb(verb) {
+		payload = formatUUID(u.UUID)
+	}
+	formatValue(state, verb, u, u.Valid, u.Set, payload)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to a String value of the canonical text, or to nil if this
+// UUID is null.
+func (u UUID) LogValue() slog.Value {
+	if !u.Valid {
+		return logNull
+	}
+	return slog.StringValue(formatUUID(u.UUID))
+}
+
+// formatUUID returns the canonical lowercase hyphenated form of u.
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	return string(appendUUID(buf[:0], u))
+}
+
+// appendUUID appends what formatUUID returns to b.
+func appendUUID(b []byte, u [16]byte) []byte {
+	b = hex.AppendEncode(b, u[0:4])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, u[4:6])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, u[6:8])
+	b = append(b, '-')
+	b = hex.AppendEncode(b, u[8:10])
+	b = append(b, '-')
+	return hex.AppendEncode(b, u[10:])
+}
+
+// parseUUID parses the textual forms of a UUID accepted by UnmarshalText.
+func parseUUID(s string) ([16]byte, error) {
+	var u [16]byte
+	str := s
+	if len(str) == 45 && strings.EqualFold(str[:9], "urn:uuid:") {
+		str = str[9:]
+	} else if len(str) == 38 && str[0] == '{' && str[37] == '}' {
+		str = str[1:37]
+	}
+
+	switch len(str) {
+	case 32:
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return u, fmt.Errorf("null: invalid UUID %q", s)
+		}
+		str = str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	default:
+		return u, fmt.Errorf("null: invalid UUID %q: bad length", s)
+	}
+	if _, err := hex.Decode(u[:], []byte(str)); err != nil {
+		return [16]byte{}, fmt.Errorf("null: invalid UUID %q", s)
+	}
+	return u, nil
+}
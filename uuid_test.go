@@ -0,0 +1,177 @@
+package null
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var (
+	uuidString = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	uuidValue  = [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	uuidJSON   = []byte(`"` + uuidString + `"`)
+)
+
+func TestUUIDFrom(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	assertUUID(t, u, "UUIDFrom()")
+
+	zero := UUIDFrom([16]byte{})
+	if !zero.Valid {
+		t.Error("UUIDFrom(zero)", "is invalid, but should be valid")
+	}
+}
+
+func TestUUIDFromPtr(t *testing.T) {
+	v := uuidValue
+	u := UUIDFromPtr(&v)
+	assertUUID(t, u, "UUIDFromPtr()")
+
+	null := UUIDFromPtr(nil)
+	assertNullUUID(t, null, "UUIDFromPtr(nil)")
+}
+
+func TestUUIDFromString(t *testing.T) {
+	for _, in := range []string{
+		uuidString,
+		strings.ToUpper(uuidString),
+		"{" + uuidString + "}",
+		"urn:uuid:" + uuidString,
+		strings.Replace(uuidString, "-", "", -1),
+	} {
+		u, err := UUIDFromString(in)
+		maybePanic(err)
+		assertUUID(t, u, "UUIDFromString("+in+")")
+	}
+
+	null, err := UUIDFromString("")
+	maybePanic(err)
+	assertNullUUID(t, null, "UUIDFromString(\"\")")
+
+	for _, in := range []string{"abc", "6ba7b810-9dad-11d1-80b4-00c04fd430cz", "6ba7b8109-dad-11d1-80b4-00c04fd430c8"} {
+		_, err := UUIDFromString(in)
+		if err == nil {
+			t.Errorf("UUIDFromString(%q) should fail", in)
+		} else if !strings.Contains(err.Error(), in) {
+			t.Errorf("error %q should name the input", err)
+		}
+	}
+}
+
+func TestUnmarshalUUID(t *testing.T) {
+	var u UUID
+	err := json.Unmarshal(uuidJSON, &u)
+	maybePanic(err)
+	assertUUID(t, u, "uuid json")
+
+	var null UUID
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	assertNullUUID(t, null, "null json")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+
+	var empty UUID
+	err = json.Unmarshal([]byte(`""`), &empty)
+	maybePanic(err)
+	assertNullUUID(t, empty, "empty string json")
+
+	var badType UUID
+	err = json.Unmarshal(intJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	assertNullUUID(t, badType, "wrong type json")
+}
+
+func TestMarshalUUID(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	data, err := json.Marshal(u)
+	maybePanic(err)
+	assertJSONEquals(t, data, string(uuidJSON), "non-empty json marshal")
+
+	data, err = u.MarshalText()
+	maybePanic(err)
+	assertJSONEquals(t, data, uuidString, "non-empty text marshal")
+
+	zero := UUIDFrom([16]byte{})
+	data, err = json.Marshal(zero)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"00000000-0000-0000-0000-000000000000"`, "zero json marshal")
+
+	null := NewUUID([16]byte{}, false)
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+}
+
+func TestUUIDScanValue(t *testing.T) {
+	for _, v := range []interface{}{uuidString, []byte(uuidString), uuidValue[:]} {
+		var u UUID
+		err := u.Scan(v)
+		maybePanic(err)
+		assertUUID(t, u, "scanned uuid")
+
+		value, err := u.Value()
+		maybePanic(err)
+		if value != uuidString {
+			t.Errorf("bad value: %v", value)
+		}
+	}
+
+	var null UUID
+	err := null.Scan(nil)
+	maybePanic(err)
+	assertNullUUID(t, null, "scanned null")
+	if !null.Set {
+		t.Error("should be Set")
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	for _, v := range []interface{}{"abc", []byte{1, 2, 3}, int64(1)} {
+		var wrong UUID
+		if err := wrong.Scan(v); err == nil {
+			t.Errorf("Scan(%#v) should fail", v)
+		}
+		assertNullUUID(t, wrong, "scanned garbage")
+	}
+}
+
+func TestUUIDPointer(t *testing.T) {
+	u := UUIDFrom(uuidValue)
+	ptr := u.Ptr()
+	if *ptr != uuidValue {
+		t.Errorf("bad %s uuid: %#v ≠ %v\n", "pointer", ptr, uuidValue)
+	}
+
+	null := NewUUID([16]byte{}, false)
+	ptr = null.Ptr()
+	if ptr != nil {
+		t.Errorf("bad %s uuid: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestUUIDSetValid(t *testing.T) {
+	change := NewUUID([16]byte{}, false)
+	assertNullUUID(t, change, "SetValid()")
+	change.SetValid(uuidValue)
+	assertUUID(t, change, "SetValid()")
+}
+
+func assertUUID(t *testing.T, u UUID, from string) {
+	if u.UUID != uuidValue {
+		t.Errorf("bad %s uuid: %v ≠ %v\n", from, u.UUID, uuidValue)
+	}
+	if !u.Valid {
+		t.Error(from, "is invalid, but should be valid")
+	}
+}
+
+func assertNullUUID(t *testing.T, u UUID, from string) {
+	if u.Valid {
+		t.Error(from, "is valid, but should be invalid")
+	}
+}
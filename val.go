@@ -0,0 +1,783 @@
+package null
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/sisoftrg/null/convert"
+)
+
+// Val is a nullable wrapper around any type the package doesn't ship.
+// JSON encoding is delegated to T, except that JSON null always makes the
+// Val null without calling T's own UnmarshalJSON. If T is a pointer type,
+// a valid Val holding a nil pointer marshals to null.
+type Val[T any] struct {
+	Val   T
+	Valid bool
+	Set   bool
+}
+
+// NewVal creates a new Val
+func NewVal[T any](v T, valid bool) Val[T] {
+	return Val[T]{
+		Val:   v,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// ValFrom creates a new Val that will always be valid.
+func ValFrom[T any](v T) Val[T] {
+	return NewVal(v, true)
+}
+
+// ValFromPtr creates a new Val that will be null if v is nil.
+func ValFromPtr[T any](v *T) Val[T] {
+	if v == nil {
+		var zero T
+		return NewVal(zero, false)
+	}
+	return NewVal(*v, true)
+}
+
+// ValFromNonZero creates a new Val that will be null if v is T's zero value.
+func ValFromNonZero[T any](v T) Val[T] {
+	return NewVal(v, !reflect.ValueOf(&v).Elem().IsZero())
+}
+
+// ValFromSQLNull creates a new Val from a sql.Null. The result is always
+// Set, since database/sql cannot tell an absent value from a null one.
+func ValFromSQLNull[T any](n sql.Null[T]) Val[T] {
+	return NewVal(n.V, n.Valid)
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (v Val[T]) IsValid() bool {
+	return v.Set && v.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (v Val[T]) IsSet() bool {
+	return v.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (v Val[T]) IsNull() bool {
+	return v.Set && !v.Valid
+}
+
+// Equal reports whether v and other are both null, or both valid with
+// the same value. Values are compared with T's Equal method if it has one,
+// as time.Time does, and with reflect.DeepEqual otherwise. Set is ignored;
+// see ExactEqual.
+func (v Val[T]) Equal(other Val[T]) bool {
+	if !v.Valid || !other.Valid {
+		return v.Valid == other.Valid
+	}
+	return valEqual(v.Val, other.Val)
+}
+
+// ExactEqual is like Equal, but also requires v and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (v Val[T]) ExactEqual(other Val[T]) bool {
+	return v.Set == other.Set && v.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Val[T]) UnmarshalJSON(data []byte) (err error) {
+	defer wrapJSONError(&err, "null.Val", v, data)
+	v.Set = true
+	var zero T
+	if bytes.Equal(data, NullBytes) {
+		v.Val, v.Valid = zero, false
+		return nil
+	}
+
+	res := zero
+	if err := json.Unmarshal(data, &res); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Val is null.
+func (v Val[T]) MarshalJSON() ([]byte, error) {
+	if !v.Valid {
+		return NullBytes, nil
+	}
+	return json.Marshal(v.Val)
+}
+
+// SetValid changes this Val's value and also sets it to be non-null.
+func (v *Val[T]) SetValid(val T) {
+	v.Val = val
+	v.Valid = true
+	v.Set = true
+}
+
+// SetNull sets this Val to an explicit null, zeroing its value.
+func (v *Val[T]) SetNull() {
+	*v = Val[T]{Set: true}
+}
+
+// Unset resets this Val to the zero value, as if it was never set.
+func (v *Val[T]) Unset() {
+	*v = Val[T]{}
+}
+
+// SetPtr changes this Val to the value p points to, or to an explicit
+// null if p is nil.
+func (v *Val[T]) SetPtr(p *T) {
+	*v = ValFromPtr(p)
+}
+
+// Ptr returns a pointer to this Val's value, or a nil pointer if this Val is null.
+func (v Val[T]) Ptr() *T {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Val
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (v Val[T]) ValueOrZero() T {
+	if !v.Valid {
+		var zero T
+		return zero
+	}
+	return v.Val
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (v Val[T]) Or(def T) T {
+	if !v.Valid {
+		return def
+	}
+	return v.Val
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (v Val[T]) Get() (T, bool) {
+	return v.ValueOrZero(), v.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if v is
+// null.
+func (v Val[T]) MustGet() T {
+	if !v.Valid {
+		panic(&NullError{Type: "null.Val" + goTypeArgs[T]()})
+	}
+	return v.Val
+}
+
+// IsZero returns true if this Val was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (v Val[T]) IsZero() bool {
+	return !v.Set && !v.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The value is encoded by the YAML package as T.
+func (v Val[T]) MarshalYAML() (interface{}, error) {
+	if !v.Valid {
+		return nil, nil
+	}
+	return v.Val, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// The value is decoded by the YAML package as T. It will unmarshal to a
+// null Val if the input is null or ~.
+func (v *Val[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	v.Set = true
+	var zero T
+	null, err := yamlIsNull(unmarshal)
+	if err != nil || null {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+
+	res := zero
+	if err := unmarshal(&res); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler.
+// The value is encoded by encoding/xml as T, or as an empty element if
+// this Val is null.
+func (v Val[T]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if !v.Valid {
+		return enc.EncodeElement("", start)
+	}
+	return enc.EncodeElement(v.Val, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// The element is decoded by encoding/xml as T. An empty element without
+// attributes unmarshals to a null Val.
+func (v *Val[T]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	v.Set = true
+	var zero T
+	var inner struct {
+		XML []byte `xml:",innerxml"`
+	}
+	if err := dec.DecodeElement(&inner, &start); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	if len(inner.XML) == 0 && len(start.Attr) == 0 {
+		v.Val, v.Valid = zero, false
+		return nil
+	}
+
+	// Rebuild the element so T sees its attributes as well as its content.
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if err := enc.EncodeToken(start); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	buf.Write(inner.XML)
+	if err := enc.EncodeToken(start.End()); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+
+	res := zero
+	if err := xml.Unmarshal(buf.Bytes(), &res); err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// It uses T's MarshalXMLAttr or MarshalText if T has one, and formats the
+// value with fmt otherwise. The attribute is omitted if this Val is null.
+func (v Val[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !v.Valid {
+		return xml.Attr{}, nil
+	}
+	var val interface{} = v.Val
+	if _, ok := val.(xml.MarshalerAttr); !ok {
+		if _, ok := val.(encoding.TextMarshaler); !ok {
+			val = &v.Val
+		}
+	}
+	switch m := val.(type) {
+	case xml.MarshalerAttr:
+		return m.MarshalXMLAttr(name)
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		if err != nil {
+			return xml.Attr{}, err
+		}
+		return xml.Attr{Name: name, Value: string(text)}, nil
+	}
+	return xml.Attr{Name: name, Value: fmt.Sprint(v.Val)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// It uses T's UnmarshalXMLAttr or UnmarshalText if T has one, and Scan
+// otherwise. An empty attribute unmarshals to
+// a null Val.
+func (v *Val[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	v.Set = true
+	var zero T
+	if attr.Value == "" {
+		v.Val, v.Valid = zero, false
+		return nil
+	}
+
+	res := zero
+	var err error
+	switch u := interface{}(&res).(type) {
+	case xml.UnmarshalerAttr:
+		err = u.UnmarshalXMLAttr(attr)
+	case encoding.TextUnmarshaler:
+		err = u.UnmarshalText([]byte(attr.Value))
+	default:
+		return v.Scan(attr.Value)
+	}
+	if err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It uses T's MarshalBSONValue if T has one, encodes a time.Time as a BSON
+// datetime, and converts T's JSON encoding to BSON otherwise. It encodes
+// BSON null if this Val is null.
+func (v Val[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !v.Valid {
+		return bsonNull, nil, nil
+	}
+	switch x := interface{}(v.Val).(type) {
+	case bsonValueMarshaler:
+		return x.MarshalBSONValue()
+	case time.Time:
+		return bsonDateTimeValue(x)
+	}
+	if m, ok := interface{}(&v.Val).(bsonValueMarshaler); ok {
+		return m.MarshalBSONValue()
+	}
+	data, err := json.Marshal(v.Val)
+	if err != nil {
+		return 0, nil, err
+	}
+	return bsonFromJSON(data)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It uses T's UnmarshalBSONValue if T has one, and converts the value to
+// JSON for T to decode otherwise.
+func (v *Val[T]) UnmarshalBSONValue(typ byte, data []byte) error {
+	v.Set = true
+	var zero T
+	if bsonIsNull(typ) {
+		v.Val, v.Valid = zero, false
+		return nil
+	}
+
+	res := zero
+	var err error
+	if u, ok := interface{}(&res).(bsonValueUnmarshaler); ok {
+		err = u.UnmarshalBSONValue(typ, data)
+	} else {
+		var text []byte
+		if text, err = bsonToJSON(typ, data); err == nil {
+			err = json.Unmarshal(text, &res)
+		}
+	}
+	if err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It uses T's MarshalMsgpack if T has one, encodes a time.Time as a msgpack
+// timestamp, and converts T's JSON encoding to msgpack otherwise. It
+// encodes nil if this Val is null.
+func (v Val[T]) MarshalMsgpack() ([]byte, error) {
+	if !v.Valid {
+		return msgpackNull, nil
+	}
+	switch x := interface{}(v.Val).(type) {
+	case msgpackMarshaler:
+		return x.MarshalMsgpack()
+	case time.Time:
+		return appendMsgpackTime(nil, x), nil
+	}
+	if m, ok := interface{}(&v.Val).(msgpackMarshaler); ok {
+		return m.MarshalMsgpack()
+	}
+	data, err := json.Marshal(v.Val)
+	if err != nil {
+		return nil, err
+	}
+	return msgpackFromJSON(data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It uses T's UnmarshalMsgpack if T has one, and converts the value to
+// JSON for T to decode otherwise.
+func (v *Val[T]) UnmarshalMsgpack(data []byte) error {
+	v.Set = true
+	var zero T
+	dv, err := decodeMsgpack(data)
+	if err != nil || dv == nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+
+	res := zero
+	if u, ok := interface{}(&res).(msgpackUnmarshaler); ok {
+		err = u.UnmarshalMsgpack(data)
+	} else {
+		var text []byte
+		if text, err = msgpackToJSON(dv); err == nil {
+			err = json.Unmarshal(text, &res)
+		}
+	}
+	if err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It uses T's MarshalCBOR if T has one, encodes a time.Time as a string of
+// tag 0, and converts T's JSON encoding to CBOR otherwise. It encodes null
+// if this Val is null.
+func (v Val[T]) MarshalCBOR() ([]byte, error) {
+	if !v.Valid {
+		return cborNull, nil
+	}
+	switch x := interface{}(v.Val).(type) {
+	case cborMarshaler:
+		return x.MarshalCBOR()
+	case time.Time:
+		return appendCBORTime(nil, x), nil
+	}
+	if m, ok := interface{}(&v.Val).(cborMarshaler); ok {
+		return m.MarshalCBOR()
+	}
+	data, err := json.Marshal(v.Val)
+	if err != nil {
+		return nil, err
+	}
+	return cborFromJSON(data)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It uses T's UnmarshalCBOR if T has one, and converts the value to
+// JSON for T to decode otherwise.
+func (v *Val[T]) UnmarshalCBOR(data []byte) error {
+	v.Set = true
+	var zero T
+	dv, err := decodeCBOR(data)
+	if err != nil || dv == nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+
+	res := zero
+	if u, ok := interface{}(&res).(cborUnmarshaler); ok {
+		err = u.UnmarshalCBOR(data)
+	} else {
+		var text []byte
+		if text, err = cborToJSON(dv); err == nil {
+			err = json.Unmarshal(text, &res)
+		}
+	}
+	if err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (v Val[T]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, v.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Val.
+func (v *Val[T]) UnmarshalGQL(value interface{}) error {
+	return unmarshalGQL(value, v.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It uses T's MarshalCSV or MarshalText if T has one, and formats the value
+// with fmt otherwise. It returns an empty cell if this Val is null.
+func (v Val[T]) MarshalCSV() (string, error) {
+	if !v.Valid {
+		return "", nil
+	}
+	var val interface{} = v.Val
+	if _, ok := val.(csvMarshaler); !ok {
+		if _, ok := val.(encoding.TextMarshaler); !ok {
+			val = &v.Val
+		}
+	}
+	switch m := val.(type) {
+	case csvMarshaler:
+		return m.MarshalCSV()
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+	return fmt.Sprint(v.Val), nil
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It uses T's UnmarshalCSV or UnmarshalText if T has one, and Scan
+// otherwise. An empty cell unmarshals to a null Val.
+func (v *Val[T]) UnmarshalCSV(cell string) error {
+	v.Set = true
+	var zero T
+	if cell == "" {
+		v.Val, v.Valid = zero, false
+		return nil
+	}
+
+	res := zero
+	var err error
+	switch u := interface{}(&res).(type) {
+	case csvUnmarshaler:
+		err = u.UnmarshalCSV(cell)
+	case encoding.TextUnmarshaler:
+		err = u.UnmarshalText([]byte(cell))
+	default:
+		return v.Scan(cell)
+	}
+	if err != nil {
+		v.Val, v.Valid = zero, false
+		return err
+	}
+	v.Val, v.Valid = res, true
+	return nil
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Val is null.
+func (v Val[T]) MarshalTOML() ([]byte, error) {
+	return marshalTOML(v.Valid, v.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null Val.
+func (v *Val[T]) UnmarshalTOML(value interface{}) error {
+	return unmarshalTOML(value, nil, v.UnmarshalJSON)
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// It uses T's Randomize method if T has one, and otherwise sets a random
+// value for booleans, numbers, strings, time.Time and structs of those, or
+// an explicit null if shouldBeNull is true. It panics for other types of T.
+func (v *Val[T]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	var zero T
+	if shouldBeNull {
+		*v = NewVal(zero, false)
+		return
+	}
+	res := zero
+	if r, ok := interface{}(&res).(randomizer); ok {
+		r.Randomize(nextInt, fieldType, false)
+	} else if !randomizeValue(reflect.ValueOf(&res).Elem(), nextInt, fieldType) {
+		panic("null: cannot randomize null.Val" + goTypeArgs[T]())
+	}
+	v.SetValid(res)
+}
+
+// Generate implements testing/quick's Generator.
+func (v Val[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, Val[T]{Set: true}, func() Val[T] {
+		var res T
+		if !generateValue(rand, size, reflect.ValueOf(&res).Elem()) {
+			panic("null: cannot generate null.Val" + goTypeArgs[T]())
+		}
+		return ValFrom(res)
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches. The value is
+// encoded with T's MarshalBinary if T implements encoding.BinaryMarshaler,
+// and as JSON otherwise.
+func (v Val[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(v.Set, v.Valid, func() ([]byte, error) {
+		if m, ok := interface{}(v.Val).(encoding.BinaryMarshaler); ok {
+			return m.MarshalBinary()
+		}
+		return json.Marshal(v.Val)
+	})
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Val[T]) UnmarshalBinary(data []byte) error {
+	set, valid, p, err := unmarshalBinary(data, "null.Val")
+	if err != nil {
+		return err
+	}
+	var res T
+	if valid {
+		if u, ok := interface{}(&res).(encoding.BinaryUnmarshaler); ok {
+			err = u.UnmarshalBinary(p)
+		} else {
+			err = json.Unmarshal(p, &res)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	v.Val, v.Valid, v.Set = res, valid, set
+	return nil
+}
+
+// Scan implements the Scanner interface.
+// It uses T's Scan method if *T implements sql.Scanner, and the usual
+// database/sql conversions otherwise.
+func (v *Val[T]) Scan(value interface{}) (err error) {
+	defer wrapConvertError(&err, "null.Val", value)
+	var zero T
+	if value == nil {
+		v.Val, v.Valid, v.Set = zero, false, true
+		return nil
+	}
+
+	res := zero
+	if s, ok := interface{}(&res).(sql.Scanner); ok {
+		err = s.Scan(value)
+	} else {
+		err = convert.ConvertAssign(&res, value)
+	}
+	v.Set = true
+	v.Valid = err == nil
+	if v.Valid {
+		v.Val = res
+	} else {
+		v.Val = zero
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+// It uses T's Value method if T implements driver.Valuer, and converts
+// T to a driver value like database/sql does otherwise. A nil pointer is
+// stored as NULL.
+func (v Val[T]) Value() (driver.Value, error) {
+	if !v.Valid {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(&v.Val).Elem(); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil, nil
+	}
+	if vr, ok := interface{}(v.Val).(driver.Valuer); ok {
+		return vr.Value()
+	}
+	if vr, ok := interface{}(&v.Val).(driver.Valuer); ok {
+		return vr.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v.Val)
+}
+
+// ToSQLNull converts v to a sql.Null. Set is discarded.
+func (v Val[T]) ToSQLNull() sql.Null[T] {
+	return sql.Null[T]{V: v.Val, Valid: v.Valid}
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (v Val[T]) String() string {
+	return fmt.Sprint(v)
+}
+
+// GoString implements the GoStringer interface, formatting v as the Go
+// expression that builds it.
+func (v Val[T]) GoString() string {
+	return goString("Val", goTypeArgs[T](), "Val", fmt.Sprintf("%#v", v.Val), reflect.ValueOf(&v.Val).Elem().IsZero(), v.Valid, v.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// v with the verb if it is valid.
+func (v Val[T]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, v, v.Valid, v.Set, v.Val)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves to slog.AnyValue of the payload, so a T such as int or string
+// keeps its kind and a T that is a LogValuer is resolved further, or to nil
+// if this Val is null.
+func (v Val[T]) LogValue() slog.Value {
+	if !v.Valid {
+		return logNull
+	}
+	return slog.AnyValue(v.Val)
+}
+
+// ValFromInt64 converts an Int64 to a Val[int64].
+func ValFromInt64(i Int64) Val[int64] {
+	return Val[int64]{Val: i.Int64, Valid: i.Valid, Set: i.Set}
+}
+
+// Int64FromVal converts a Val[int64] to an Int64.
+func Int64FromVal(v Val[int64]) Int64 {
+	return Int64{Int64: v.Val, Valid: v.Valid, Set: v.Set}
+}
+
+// ValFromFloat64 converts a Float64 to a Val[float64].
+func ValFromFloat64(f Float64) Val[float64] {
+	return Val[float64]{Val: f.Float64, Valid: f.Valid, Set: f.Set}
+}
+
+// Float64FromVal converts a Val[float64] to a Float64.
+func Float64FromVal(v Val[float64]) Float64 {
+	return Float64{Float64: v.Val, Valid: v.Valid, Set: v.Set}
+}
+
+// ValFromString converts a String to a Val[string].
+func ValFromString(s String) Val[string] {
+	return Val[string]{Val: s.String, Valid: s.Valid, Set: s.Set}
+}
+
+// StringFromVal converts a Val[string] to a String.
+func StringFromVal(v Val[string]) String {
+	return String{String: v.Val, Valid: v.Valid, Set: v.Set}
+}
+
+// ValFromBool converts a Bool to a Val[bool].
+func ValFromBool(b Bool) Val[bool] {
+	return Val[bool]{Val: b.Bool, Valid: b.Valid, Set: b.Set}
+}
+
+// BoolFromVal converts a Val[bool] to a Bool.
+func BoolFromVal(v Val[bool]) Bool {
+	return Bool{Bool: v.Val, Valid: v.Valid, Set: v.Set}
+}
+
+// ValFromTime converts a Time to a Val[time.Time].
+func ValFromTime(t Time) Val[time.Time] {
+	return Val[time.Time]{Val: t.Time, Valid: t.Valid, Set: t.Set}
+}
+
+// TimeFromVal converts a Val[time.Time] to a Time.
+func TimeFromVal(v Val[time.Time]) Time {
+	return Time{Time: v.Val, Valid: v.Valid, Set: v.Set}
+}
+
+// valEqual compares a and b with their Equal method if T has one, and with
+// reflect.DeepEqual otherwise.
+func valEqual[T any](a, b T) bool {
+	if eq, ok := any(a).(interface{ Equal(T) bool }); ok {
+		return eq.Equal(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,320 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUserID int64
+
+type testPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Scan parses "(x,y)", like a Postgres point.
+func (p *testPoint) Scan(value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("bad point %v", value)
+	}
+	_, err := fmt.Sscanf(s, "(%d,%d)", &p.X, &p.Y)
+	return err
+}
+
+func (p testPoint) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y), nil
+}
+
+// testShout marshals itself in upper case and refuses lower-case input.
+type testShout string
+
+func (s testShout) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToUpper(string(s)))
+}
+
+func (s *testShout) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if str != strings.ToUpper(str) {
+		return fmt.Errorf("not shouting: %q", str)
+	}
+	*s = testShout(str)
+	return nil
+}
+
+func TestValFrom(t *testing.T) {
+	v := ValFrom(testUserID(42))
+	if !v.Valid || !v.Set || v.Val != 42 {
+		t.Errorf("bad ValFrom(): %v", v)
+	}
+
+	zero := ValFrom(testUserID(0))
+	if !zero.Valid {
+		t.Error("ValFrom(0)", "is invalid, but should be valid")
+	}
+}
+
+func TestValFromPtr(t *testing.T) {
+	id := testUserID(42)
+	v := ValFromPtr(&id)
+	if !v.Valid || v.Val != 42 {
+		t.Errorf("bad ValFromPtr(): %v", v)
+	}
+
+	null := ValFromPtr[testUserID](nil)
+	if null.Valid || !null.Set {
+		t.Errorf("bad ValFromPtr(nil): %v", null)
+	}
+}
+
+func TestValJSON(t *testing.T) {
+	var id Val[testUserID]
+	err := json.Unmarshal([]byte(`42`), &id)
+	maybePanic(err)
+	if !id.Valid || id.Val != 42 {
+		t.Errorf("bad id: %v", id)
+	}
+	data, err := json.Marshal(id)
+	maybePanic(err)
+	assertJSONEquals(t, data, "42", "id json marshal")
+
+	var p Val[testPoint]
+	err = json.Unmarshal([]byte(`{"x":1,"y":2}`), &p)
+	maybePanic(err)
+	if !p.Valid || p.Val != (testPoint{1, 2}) {
+		t.Errorf("bad point: %v", p)
+	}
+	data, err = json.Marshal(p)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"x":1,"y":2}`, "struct json marshal")
+
+	var null Val[testPoint]
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	if null.Valid || !null.Set {
+		t.Errorf("bad null: %v", null)
+	}
+	data, err = json.Marshal(null)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "null json marshal")
+
+	var badType Val[testUserID]
+	err = json.Unmarshal(boolJSON, &badType)
+	if err == nil {
+		t.Error("expected error")
+	}
+	if badType.Valid {
+		t.Error("wrong type json", "is valid, but should be invalid")
+	}
+
+	var missing struct {
+		ID Val[testUserID] `json:"id"`
+	}
+	err = json.Unmarshal([]byte(`{}`), &missing)
+	maybePanic(err)
+	if missing.ID.IsSet() {
+		t.Error("missing field should not be Set")
+	}
+}
+
+func TestValJSONMarshaler(t *testing.T) {
+	v := ValFrom(testShout("hi"))
+	data, err := json.Marshal(v)
+	maybePanic(err)
+	assertJSONEquals(t, data, `"HI"`, "marshaler json marshal")
+
+	var in Val[testShout]
+	err = json.Unmarshal([]byte(`"HEY"`), &in)
+	maybePanic(err)
+	if !in.Valid || in.Val != "HEY" {
+		t.Errorf("bad unmarshaled shout: %v", in)
+	}
+
+	var bad Val[testShout]
+	if err = json.Unmarshal([]byte(`"hey"`), &bad); err == nil {
+		t.Error("T's UnmarshalJSON error should be returned")
+	}
+	if bad.Valid {
+		t.Error("bad shout", "is valid, but should be invalid")
+	}
+
+	// null never reaches T's UnmarshalJSON.
+	var null Val[testShout]
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	if null.Valid || !null.Set {
+		t.Errorf("bad null shout: %v", null)
+	}
+}
+
+func TestValPointerType(t *testing.T) {
+	var v Val[*int]
+	err := json.Unmarshal([]byte(`5`), &v)
+	maybePanic(err)
+	if !v.Valid || v.Val == nil || *v.Val != 5 {
+		t.Errorf("bad pointer val: %v", v)
+	}
+
+	var null Val[*int]
+	err = json.Unmarshal(nullJSON, &null)
+	maybePanic(err)
+	if null.Valid || null.Val != nil {
+		t.Errorf("bad null pointer val: %v", null)
+	}
+
+	nilPtr := ValFrom[*int](nil)
+	data, err := json.Marshal(nilPtr)
+	maybePanic(err)
+	assertJSONEquals(t, data, "null", "valid nil pointer json marshal")
+	if value, err := nilPtr.Value(); value != nil || err != nil {
+		t.Error("bad value or err:", value, err)
+	}
+
+	var scanned Val[*int]
+	err = scanned.Scan(int64(7))
+	maybePanic(err)
+	if !scanned.Valid || scanned.Val == nil || *scanned.Val != 7 {
+		t.Errorf("bad scanned pointer val: %v", scanned)
+	}
+	value, err := scanned.Value()
+	maybePanic(err)
+	if value != int64(7) {
+		t.Errorf("bad pointer value: %#v", value)
+	}
+}
+
+func TestValScanValue(t *testing.T) {
+	var id Val[testUserID]
+	err := id.Scan(int64(42))
+	maybePanic(err)
+	if !id.Valid || id.Val != 42 {
+		t.Errorf("bad scanned id: %v", id)
+	}
+	value, err := id.Value()
+	maybePanic(err)
+	if value != int64(42) {
+		t.Errorf("bad id value: %#v", value)
+	}
+
+	var p Val[testPoint]
+	err = p.Scan("(1,2)")
+	maybePanic(err)
+	if !p.Valid || p.Val != (testPoint{1, 2}) {
+		t.Errorf("bad scanned point: %v", p)
+	}
+	value, err = p.Value()
+	maybePanic(err)
+	if value != "(1,2)" {
+		t.Errorf("bad point value: %#v", value)
+	}
+
+	var null Val[testPoint]
+	err = null.Scan(nil)
+	maybePanic(err)
+	if null.Valid || !null.Set {
+		t.Errorf("bad scanned null: %v", null)
+	}
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Error("bad value or err:", v, err)
+	}
+
+	var wrong Val[testUserID]
+	if err = wrong.Scan("abc"); err == nil {
+		t.Error("expected error")
+	}
+	if wrong.Valid {
+		t.Error("scanned garbage", "is valid, but should be invalid")
+	}
+
+	var wrongPoint Val[testPoint]
+	if err = wrongPoint.Scan(int64(1)); err == nil {
+		t.Error("T's Scan error should be returned")
+	}
+	if wrongPoint.Valid {
+		t.Error("scanned garbage point", "is valid, but should be invalid")
+	}
+}
+
+func TestValPointer(t *testing.T) {
+	v := ValFrom(testUserID(42))
+	if ptr := v.Ptr(); ptr == nil || *ptr != 42 {
+		t.Errorf("bad %s val: %#v ≠ %d\n", "pointer", ptr, 42)
+	}
+
+	null := NewVal(testUserID(0), false)
+	if ptr := null.Ptr(); ptr != nil {
+		t.Errorf("bad %s val: %#v ≠ %s\n", "nil pointer", ptr, "nil")
+	}
+}
+
+func TestValSetValid(t *testing.T) {
+	change := NewVal(testUserID(0), false)
+	if change.Valid || change.IsValid() {
+		t.Error("SetValid()", "is valid, but should be invalid")
+	}
+	change.SetValid(42)
+	if !change.Valid || !change.IsValid() || change.IsZero() || change.Val != 42 {
+		t.Errorf("bad SetValid(): %v", change)
+	}
+}
+
+func TestValConversions(t *testing.T) {
+	for _, i := range []Int64{Int64From(42), NewInt64(0, false), {}} {
+		if back := Int64FromVal(ValFromInt64(i)); back != i {
+			t.Errorf("bad Int64 round trip: %v ≠ %v", back, i)
+		}
+	}
+	for _, f := range []Float64{Float64From(1.5), NewFloat64(0, false), {}} {
+		if back := Float64FromVal(ValFromFloat64(f)); back != f {
+			t.Errorf("bad Float64 round trip: %v ≠ %v", back, f)
+		}
+	}
+	for _, s := range []String{StringFrom("a"), NewString("", false), {}} {
+		if back := StringFromVal(ValFromString(s)); back != s {
+			t.Errorf("bad String round trip: %v ≠ %v", back, s)
+		}
+	}
+	for _, b := range []Bool{BoolFrom(true), NewBool(false, false), {}} {
+		if back := BoolFromVal(ValFromBool(b)); back != b {
+			t.Errorf("bad Bool round trip: %v ≠ %v", back, b)
+		}
+	}
+	now := time.Now()
+	for _, tm := range []Time{TimeFrom(now), NewTime(time.Time{}, false), {}} {
+		if back := TimeFromVal(ValFromTime(tm)); back != tm {
+			t.Errorf("bad Time round trip: %v ≠ %v", back, tm)
+		}
+	}
+
+	v := ValFromInt64(Int64From(42))
+	if !v.Valid || v.Val != 42 {
+		t.Errorf("bad ValFromInt64(): %v", v)
+	}
+}
+
+func TestValSQLNull(t *testing.T) {
+	v := ValFromSQLNull(sql.Null[int64]{V: 42, Valid: true})
+	if v != ValFrom[int64](42) {
+		t.Errorf("ValFromSQLNull(42) = %#v", v)
+	}
+	if v := ValFromSQLNull(sql.Null[string]{}); v != NewVal("", false) {
+		t.Errorf("ValFromSQLNull(null) = %#v, want a set null", v)
+	}
+
+	for _, v := range []Val[time.Time]{ValFrom(time.Date(2012, 12, 21, 0, 0, 0, 0, time.UTC)), NewVal(time.Time{}, false)} {
+		if back := ValFromSQLNull(v.ToSQLNull()); back != v {
+			t.Errorf("bad sql.Null round trip: %v ≠ %v", back, v)
+		}
+	}
+	if n := (Val[int]{}).ToSQLNull(); n != (sql.Null[int]{}) {
+		t.Errorf("unset Val.ToSQLNull() = %#v", n)
+	}
+}
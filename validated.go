@@ -0,0 +1,543 @@
+package null
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"reflect"
+)
+
+// Validator checks the values of a Validated. It is called on the zero
+// value of its type, so it is normally an empty struct type whose name
+// says what it checks:
+//
+//	type Quantity struct{}
+//
+//	func (Quantity) Validate(n int64) error {
+//		if n < 0 {
+//			return errors.New("quantity must not be negative")
+//		}
+//		return nil
+//	}
+//
+//	type Order struct {
+//		Count null.Validated[int64, Quantity] `json:"count"`
+//	}
+//
+// The check is part of the type, rather than a function stored in each
+// value, so it also runs when decoding into a zero Validated, which is how
+// encoding/json and database/sql fill struct fields.
+type Validator[T any] interface {
+	Validate(T) error
+}
+
+// Validated is a nullable T whose values V accepts, for rejecting invalid
+// domain values, such as malformed emails or negative quantities, where they
+// enter: every decoding method, such as UnmarshalJSON, UnmarshalText or
+// Scan, decodes as Val[T] does and then runs V's Validate on a valid
+// result. A value it rejects leaves the Validated null, with an error that
+// matches ErrInvalidInput and wraps that of Validate.
+//
+// Nulls are not validated. The constructors and SetValid store their value
+// as is, like those of the other types; use TrySet to set a value only if
+// it is valid, and Validate to check one built otherwise.
+type Validated[T any, V Validator[T]] struct {
+	Validated T
+	Valid     bool
+	Set       bool
+}
+
+// NewValidated creates a new Validated. v is not validated; see TrySet.
+func NewValidated[T any, V Validator[T]](v T, valid bool) Validated[T, V] {
+	return Validated[T, V]{
+		Validated: v,
+		Valid:     valid,
+		Set:       true,
+	}
+}
+
+// ValidatedFrom creates a new Validated that will always be valid. v is
+// not validated; see TrySet.
+func ValidatedFrom[T any, V Validator[T]](v T) Validated[T, V] {
+	return NewValidated[T, V](v, true)
+}
+
+// ValidatedFromPtr creates a new Validated that will be null if v is nil.
+// *v is not validated; see TrySet.
+func ValidatedFromPtr[T any, V Validator[T]](v *T) Validated[T, V] {
+	if v == nil {
+		var zero T
+		return NewValidated[T, V](zero, false)
+	}
+	return NewValidated[T, V](*v, true)
+}
+
+// val returns v as the Val[T] that does its encoding and decoding.
+func (v Validated[T, V]) val() Val[T] {
+	return Val[T]{Val: v.Validated, Valid: v.Valid, Set: v.Set}
+}
+
+// decode runs decode on v as a Val[T], then validates the result, making v
+// null if either fails.
+func (v *Validated[T, V]) decode(decode func(*Val[T]) error) error {
+	val := v.val()
+	err := decode(&val)
+	var ce *ConvertError
+	if errors.As(err, &ce) && ce.Type == "null.Val" {
+		ce.Type = "null.Validated"
+	}
+	if err == nil && val.Valid {
+		err = v.check(val.Val)
+	}
+	if err != nil {
+		var zero T
+		val.Val, val.Valid = zero, false
+	}
+	v.Validated, v.Valid, v.Set = val.Val, val.Valid, val.Set
+	return err
+}
+
+// check runs V's Validate on x, wrapping its error in a *ConvertError.
+func (v Validated[T, V]) check(x T) error {
+	var validator V
+	if err := validator.Validate(x); err != nil {
+		return &ConvertError{
+			Type:  "null.Validated",
+			Input: fmt.Sprint(x),
+			Err:   fmt.Errorf("null: invalid null.Validated%s value %v: %w", goTypeArgs2[T, V](), x, err),
+		}
+	}
+	return nil
+}
+
+// Validate runs V's Validate on the value of v, returning nil if v is null.
+// The error is the one the decoding methods return.
+func (v Validated[T, V]) Validate() error {
+	if !v.Valid {
+		return nil
+	}
+	return v.check(v.Validated)
+}
+
+// TrySet changes this Validated's value to x and makes it valid if V
+// accepts x. Otherwise it returns the error, leaving v as it was.
+func (v *Validated[T, V]) TrySet(x T) error {
+	if err := v.check(x); err != nil {
+		return err
+	}
+	v.SetValid(x)
+	return nil
+}
+
+// IsValid returns true if this carries and explicit value and
+// is not null.
+func (v Validated[T, V]) IsValid() bool {
+	return v.Set && v.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (v Validated[T, V]) IsSet() bool {
+	return v.Set
+}
+
+// IsNull returns true if this was explicitly set to null, as opposed to
+// carrying a value or never being set.
+func (v Validated[T, V]) IsNull() bool {
+	return v.Set && !v.Valid
+}
+
+// Equal reports whether v and other are both null, or both valid with
+// the same value, compared as Val.Equal does. Set is ignored; see
+// ExactEqual.
+func (v Validated[T, V]) Equal(other Validated[T, V]) bool {
+	return v.val().Equal(other.val())
+}
+
+// ExactEqual is like Equal, but also requires v and other to agree on Set,
+// so an explicit null differs from an absent value.
+func (v Validated[T, V]) ExactEqual(other Validated[T, V]) bool {
+	return v.Set == other.Set && v.Equal(other)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It decodes as Val.UnmarshalJSON does, then validates the value.
+func (v *Validated[T, V]) UnmarshalJSON(data []byte) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalJSON(data)
+	})
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Validated is null.
+func (v Validated[T, V]) MarshalJSON() ([]byte, error) {
+	return v.val().MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It uses T's UnmarshalText if T has one, and Scan otherwise, then
+// validates the value. Empty text unmarshals to a null Validated.
+func (v *Validated[T, V]) UnmarshalText(text []byte) (err error) {
+	defer wrapConvertError(&err, "null.Validated", text)
+	return v.decode(func(val *Val[T]) error {
+		var zero T
+		val.Set = true
+		if len(text) == 0 {
+			val.Val, val.Valid = zero, false
+			return nil
+		}
+		res := zero
+		u, ok := interface{}(&res).(encoding.TextUnmarshaler)
+		if !ok {
+			return val.Scan(string(text))
+		}
+		err := u.UnmarshalText(text)
+		val.Val, val.Valid = res, err == nil
+		return err
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It uses T's MarshalText if T has one, and formats the value with fmt
+// otherwise. It returns empty text if this Validated is null.
+func (v Validated[T, V]) MarshalText() ([]byte, error) {
+	if !v.Valid {
+		return []byte{}, nil
+	}
+	return v.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender, appending the text form
+// MarshalText returns to b. It uses T's AppendText if T has that besides
+// MarshalText. Nothing is appended if v is null.
+func (v Validated[T, V]) AppendText(b []byte) ([]byte, error) {
+	if !v.Valid {
+		return b, nil
+	}
+	var val interface{} = v.Validated
+	if _, ok := val.(encoding.TextMarshaler); !ok {
+		val = &v.Validated
+	}
+	if m, ok := val.(encoding.TextMarshaler); ok {
+		if a, ok := m.(encoding.TextAppender); ok {
+			return a.AppendText(b)
+		}
+		text, err := m.MarshalText()
+		if err != nil {
+			return b, err
+		}
+		return append(b, text...), nil
+	}
+	return fmt.Append(b, v.Validated), nil
+}
+
+// SetValid changes this Validated's value and also sets it to be non-null.
+// The value is not validated; see TrySet.
+func (v *Validated[T, V]) SetValid(val T) {
+	v.Validated = val
+	v.Valid = true
+	v.Set = true
+}
+
+// SetNull sets this Validated to an explicit null, zeroing its value.
+func (v *Validated[T, V]) SetNull() {
+	*v = Validated[T, V]{Set: true}
+}
+
+// Unset resets this Validated to the zero value, as if it was never set.
+func (v *Validated[T, V]) Unset() {
+	*v = Validated[T, V]{}
+}
+
+// SetPtr changes this Validated to the value p points to, or to an
+// explicit null if p is nil. The value is not validated.
+func (v *Validated[T, V]) SetPtr(p *T) {
+	*v = ValidatedFromPtr[T, V](p)
+}
+
+// Ptr returns a pointer to this Validated's value, or a nil pointer if
+// this Validated is null.
+func (v Validated[T, V]) Ptr() *T {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Validated
+}
+
+// ValueOrZero returns the inner value if valid, otherwise the zero value.
+func (v Validated[T, V]) ValueOrZero() T {
+	return v.val().ValueOrZero()
+}
+
+// Or returns the inner value if valid, otherwise def.
+func (v Validated[T, V]) Or(def T) T {
+	return v.val().Or(def)
+}
+
+// Val is ValueOrZero under a name short enough for templates, where
+// {{ .Field.Val }} prints the value or the zero value.
+func (v Validated[T, V]) Val() T {
+	return v.ValueOrZero()
+}
+
+// Get returns the inner value and true if valid, otherwise the zero value
+// and false.
+func (v Validated[T, V]) Get() (T, bool) {
+	return v.ValueOrZero(), v.Valid
+}
+
+// MustGet returns the inner value, or panics with a *NullError if v is
+// null.
+func (v Validated[T, V]) MustGet() T {
+	if !v.Valid {
+		panic(&NullError{Type: "null.Validated" + goTypeArgs2[T, V]()})
+	}
+	return v.Validated
+}
+
+// IsZero returns true if this Validated was never set, so that the omitzero
+// JSON option drops absent fields but keeps explicit nulls. A valid value
+// is never zero.
+func (v Validated[T, V]) IsZero() bool {
+	return !v.Set && !v.Valid
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// The value is encoded by the YAML package as T.
+func (v Validated[T, V]) MarshalYAML() (interface{}, error) {
+	return v.val().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It decodes as Val.UnmarshalYAML does, then validates the value.
+func (v *Validated[T, V]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalYAML(unmarshal)
+	})
+}
+
+// MarshalXML implements xml.Marshaler.
+// The value is encoded by encoding/xml as T, or as an empty element if
+// this Validated is null.
+func (v Validated[T, V]) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return v.val().MarshalXML(enc, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It decodes as Val.UnmarshalXML does, then validates the value.
+func (v *Validated[T, V]) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalXML(dec, start)
+	})
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+// It encodes as Val.MarshalXMLAttr does. The attribute is omitted if this
+// Validated is null.
+func (v Validated[T, V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return v.val().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+// It decodes as Val.UnmarshalXMLAttr does, then validates the value.
+func (v *Validated[T, V]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalXMLAttr(attr)
+	})
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+// It encodes as Val.MarshalBSONValue does.
+func (v Validated[T, V]) MarshalBSONValue() (byte, []byte, error) {
+	return v.val().MarshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler.
+// It decodes as Val.UnmarshalBSONValue does, then validates the value.
+func (v *Validated[T, V]) UnmarshalBSONValue(typ byte, data []byte) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalBSONValue(typ, data)
+	})
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+// It encodes as Val.MarshalMsgpack does.
+func (v Validated[T, V]) MarshalMsgpack() ([]byte, error) {
+	return v.val().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+// It decodes as Val.UnmarshalMsgpack does, then validates the value.
+func (v *Validated[T, V]) UnmarshalMsgpack(data []byte) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalMsgpack(data)
+	})
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It encodes as Val.MarshalCBOR does.
+func (v Validated[T, V]) MarshalCBOR() ([]byte, error) {
+	return v.val().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It decodes as Val.UnmarshalCBOR does, then validates the value.
+func (v *Validated[T, V]) UnmarshalCBOR(data []byte) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalCBOR(data)
+	})
+}
+
+// MarshalGQL implements graphql.Marshaler.
+// It writes the same JSON as MarshalJSON, or null if that fails.
+func (v Validated[T, V]) MarshalGQL(w io.Writer) {
+	marshalGQL(w, v.MarshalJSON)
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler.
+// It accepts the decoded values gqlgen passes the same way UnmarshalJSON
+// accepts their JSON form. A nil value unmarshals to a null Validated.
+func (v *Validated[T, V]) UnmarshalGQL(value interface{}) error {
+	return unmarshalGQL(value, v.UnmarshalJSON)
+}
+
+// MarshalCSV implements gocsv's TypeMarshaller.
+// It encodes as Val.MarshalCSV does, or returns an empty cell if this
+// Validated is null.
+func (v Validated[T, V]) MarshalCSV() (string, error) {
+	return v.val().MarshalCSV()
+}
+
+// UnmarshalCSV implements gocsv's TypeUnmarshaller.
+// It decodes as Val.UnmarshalCSV does, then validates the value.
+func (v *Validated[T, V]) UnmarshalCSV(cell string) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalCSV(cell)
+	})
+}
+
+// MarshalTOML implements toml.Marshaler.
+// It writes the value MarshalJSON encodes in TOML syntax, or an empty string
+// if this Validated is null.
+func (v Validated[T, V]) MarshalTOML() ([]byte, error) {
+	return marshalTOML(v.Valid, v.MarshalJSON)
+}
+
+// UnmarshalTOML implements toml.Unmarshaler.
+// It accepts TOML values as UnmarshalJSON accepts their JSON form. An empty
+// string unmarshals to a null Validated.
+func (v *Validated[T, V]) UnmarshalTOML(value interface{}) error {
+	return unmarshalTOML(value, nil, v.UnmarshalJSON)
+}
+
+// validatorGenerator is implemented by validators that can make valid
+// values for Generate and Randomize, such as one for a range of numbers.
+type validatorGenerator[T any] interface {
+	Generate(rand *rand.Rand, size int) T
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer.
+// If V has a method Generate(*rand.Rand, int) T, it makes the value from a
+// source seeded by nextInt. Otherwise values are drawn as Val.Randomize
+// draws them until V accepts one, and it panics if none is accepted after
+// a hundred tries. It sets an explicit null if shouldBeNull is true.
+func (v *Validated[T, V]) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*v = Validated[T, V]{Set: true}
+		return
+	}
+	var validator V
+	if g, ok := interface{}(validator).(validatorGenerator[T]); ok {
+		v.SetValid(g.Generate(rand.New(rand.NewSource(nextInt())), 10))
+		return
+	}
+	var val Val[T]
+	for i := 0; i < 100; i++ {
+		val.Randomize(nextInt, fieldType, false)
+		if v.check(val.Val) == nil {
+			v.SetValid(val.Val)
+			return
+		}
+	}
+	panic("null: cannot randomize a valid null.Validated" + goTypeArgs2[T, V]())
+}
+
+// Generate implements testing/quick's Generator.
+// Valid values are made by V's Generate(*rand.Rand, int) T method if it has
+// one. Otherwise they are drawn as Val.Generate draws them until V accepts
+// one, and it panics if none is accepted after a hundred tries.
+func (v Validated[T, V]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return generate(rand, Validated[T, V]{Set: true}, func() Validated[T, V] {
+		var validator V
+		if g, ok := interface{}(validator).(validatorGenerator[T]); ok {
+			return ValidatedFrom[T, V](g.Generate(rand, size))
+		}
+		for i := 0; i < 100; i++ {
+			var res T
+			if !generateValue(rand, size, reflect.ValueOf(&res).Elem()) {
+				break
+			}
+			if v.check(res) == nil {
+				return ValidatedFrom[T, V](res)
+			}
+		}
+		panic("null: cannot generate a valid null.Validated" + goTypeArgs2[T, V]())
+	})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding keeps both Valid and Set, for gob and caches, and encodes
+// the value as Val.MarshalBinary does.
+func (v Validated[T, V]) MarshalBinary() ([]byte, error) {
+	return v.val().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It decodes as Val.UnmarshalBinary does, then validates the value.
+func (v *Validated[T, V]) UnmarshalBinary(data []byte) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.UnmarshalBinary(data)
+	})
+}
+
+// Scan implements the Scanner interface.
+// It scans as Val.Scan does, then validates the value.
+func (v *Validated[T, V]) Scan(value interface{}) error {
+	return v.decode(func(val *Val[T]) error {
+		return val.Scan(value)
+	})
+}
+
+// Value implements the driver Valuer interface.
+// It converts the value as Val.Value does, without validating it.
+func (v Validated[T, V]) Value() (driver.Value, error) {
+	return v.val().Value()
+}
+
+// String implements the Stringer interface, returning what %v prints: the
+// payload, "<null>" or "<unset>".
+func (v Validated[T, V]) String() string {
+	return fmt.Sprint(v)
+}
+
+// GoString implements the GoStringer interface, formatting v as the Go
+// expression that builds it.
+func (v Validated[T, V]) GoString() string {
+	return goString("Validated", goTypeArgs2[T, V](), "Validated", fmt.Sprintf("%#v", v.Validated), reflect.ValueOf(&v.Validated).Elem().IsZero(), v.Valid, v.Set)
+}
+
+// Format implements the fmt.Formatter interface, formatting the payload of
+// v with the verb if it is valid.
+func (v Validated[T, V]) Format(state fmt.State, verb rune) {
+	formatValue(state, verb, v, v.Valid, v.Set, v.Validated)
+}
+
+// LogValue implements slog.LogValuer.
+// It resolves as Val.LogValue does.
+func (v Validated[T, V]) LogValue() slog.Value {
+	return v.val().LogValue()
+}
@@ -0,0 +1,144 @@
+package null
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+var errPercentRange = errors.New("percentage out of range")
+
+// testPercent accepts integers from 0 to 100.
+type testPercent struct{}
+
+func (testPercent) Validate(n int64) error {
+	if n < 0 || n > 100 {
+		return errPercentRange
+	}
+	return nil
+}
+
+// Generate lets Validated generate valid percentages.
+func (testPercent) Generate(rand *rand.Rand, size int) int64 {
+	return rand.Int63n(101)
+}
+
+var skuPattern = regexp.MustCompile(`^[A-Z]{3}-[0-9]{4}$`)
+
+// testSKU accepts stock keeping units such as "ABC-1234".
+type testSKU struct{}
+
+func (testSKU) Validate(s string) error {
+	if !skuPattern.MatchString(s) {
+		return fmt.Errorf("%q is not a SKU", s)
+	}
+	return nil
+}
+
+type testPricedLine struct {
+	SKU      Validated[string, testSKU]    `json:"sku"`
+	Discount Validated[int64, testPercent] `json:"discount"`
+}
+
+func TestValidatedJSON(t *testing.T) {
+	var line testPricedLine
+	maybePanic(json.Unmarshal([]byte(`{"sku":"ABC-1234","discount":15}`), &line))
+	if line.SKU != ValidatedFrom[string, testSKU]("ABC-1234") || line.Discount != ValidatedFrom[int64, testPercent](15) {
+		t.Errorf("unmarshal = %#v", line)
+	}
+
+	line = testPricedLine{}
+	err := json.Unmarshal([]byte(`{"sku":"ABC-1234","discount":150}`), &line)
+	if !errors.Is(err, errPercentRange) || !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("unmarshal of an invalid discount: %v", err)
+	}
+	var ce *ConvertError
+	if !errors.As(err, &ce) || ce.Type != "null.Validated" || ce.Input != "150" {
+		t.Errorf("unmarshal error = %#v", err)
+	}
+	if !line.Discount.IsNull() || line.Discount.Validated != 0 {
+		t.Errorf("an invalid discount left %#v, want a set null", line.Discount)
+	}
+
+	for _, bad := range []string{`{"sku":"abc"}`, `{"sku":""}`, `{"sku":1}`} {
+		var line testPricedLine
+		if err := json.Unmarshal([]byte(bad), &line); err == nil || line.SKU.Valid {
+			t.Errorf("unmarshal %s = %#v, %v, want an error", bad, line, err)
+		}
+	}
+
+	// Nulls are not validated.
+	maybePanic(json.Unmarshal([]byte(`{"sku":null}`), &line))
+	if !line.SKU.IsNull() {
+		t.Errorf("unmarshal null = %#v", line.SKU)
+	}
+	data, err := json.Marshal(testPricedLine{SKU: ValidatedFrom[string, testSKU]("XYZ-0001")})
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"sku":"XYZ-0001","discount":null}`, "order line")
+}
+
+func TestValidatedTextScan(t *testing.T) {
+	var p Validated[int64, testPercent]
+	maybePanic(p.UnmarshalText([]byte("100")))
+	if p != ValidatedFrom[int64, testPercent](100) {
+		t.Errorf("UnmarshalText() = %#v", p)
+	}
+	if err := p.UnmarshalText([]byte("101")); !errors.Is(err, errPercentRange) || !p.IsNull() {
+		t.Errorf("UnmarshalText(101) = %#v, %v", p, err)
+	}
+	if err := p.UnmarshalText([]byte("many")); !errors.Is(err, ErrInvalidInput) || errors.Is(err, errPercentRange) || !p.IsNull() {
+		t.Errorf("UnmarshalText(many) = %#v, %v", p, err)
+	}
+	maybePanic(p.UnmarshalText(nil))
+	if !p.IsNull() {
+		t.Errorf("UnmarshalText(nil) = %#v", p)
+	}
+
+	maybePanic(p.Scan(int64(0)))
+	if p != ValidatedFrom[int64, testPercent](0) {
+		t.Errorf("Scan(0) = %#v", p)
+	}
+	err := p.Scan(int64(-1))
+	var ce *ConvertError
+	if !errors.Is(err, errPercentRange) || !errors.As(err, &ce) || ce.Type != "null.Validated" || !p.IsNull() {
+		t.Errorf("Scan(-1) = %#v, %v", p, err)
+	}
+	if err := p.Scan("x"); !errors.As(err, &ce) || ce.Type != "null.Validated" {
+		t.Errorf("Scan(x) = %v, want a null.Validated ConvertError", err)
+	}
+
+	var sku Validated[string, testSKU]
+	maybePanic(sku.Scan([]byte("QRS-0042")))
+	if text, err := sku.MarshalText(); err != nil || string(text) != "QRS-0042" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+	if err := sku.UnmarshalCSV("QRS-42"); err == nil || sku.Valid {
+		t.Errorf("UnmarshalCSV() = %#v, %v, want an error", sku, err)
+	}
+}
+
+func TestValidatedTrySet(t *testing.T) {
+	p := ValidatedFrom[int64, testPercent](10)
+	if err := p.TrySet(200); !errors.Is(err, errPercentRange) || p != ValidatedFrom[int64, testPercent](10) {
+		t.Errorf("TrySet(200) = %v, left %#v", err, p)
+	}
+	maybePanic(p.TrySet(20))
+	if p != ValidatedFrom[int64, testPercent](20) {
+		t.Errorf("TrySet(20) = %#v", p)
+	}
+
+	// SetValid and the constructors don't validate, and Validate tells.
+	p.SetValid(-5)
+	if err := p.Validate(); !errors.Is(err, errPercentRange) {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := NewValidated[int64, testPercent](-5, false).Validate(); err != nil {
+		t.Errorf("Validate() of null = %v", err)
+	}
+	if s := fmt.Sprintf("%#v", ValidatedFrom[int64, testPercent](7)); s != "null.ValidatedFrom[int64, null.testPercent](7)" {
+		t.Errorf("GoString() = %s", s)
+	}
+}
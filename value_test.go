@@ -0,0 +1,71 @@
+package null
+
+import (
+	"reflect"
+	"testing"
+)
+
+type nullState interface {
+	Value
+	IsNull() bool
+}
+
+type nullMutator interface {
+	SetNull()
+	Unset()
+}
+
+func TestNullState(t *testing.T) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		for i, want := range []bool{false, true, false} {
+			if got := states[i].(nullState).IsNull(); got != want {
+				t.Errorf("%s state %d: IsNull() = %v, want %v", typ, i, got, want)
+			}
+		}
+
+		v := reflect.New(typ)
+		v.Elem().Set(reflect.ValueOf(states[2]))
+		v.Interface().(nullMutator).SetNull()
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, states[1]) {
+			t.Errorf("%s: SetNull() = %#v, want %#v", typ, got, states[1])
+		}
+		if x := v.Elem().Interface().(nullState); x.IsValid() || !x.IsSet() || !x.IsNull() {
+			t.Errorf("%s: SetNull() gives IsValid %v, IsSet %v, IsNull %v", typ, x.IsValid(), x.IsSet(), x.IsNull())
+		}
+
+		v.Elem().Set(reflect.ValueOf(states[2]))
+		v.Interface().(nullMutator).Unset()
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, states[0]) {
+			t.Errorf("%s: Unset() = %#v, want %#v", typ, got, states[0])
+		}
+	}
+}
+
+func TestSetPtr(t *testing.T) {
+	for _, states := range binaryStates() {
+		typ := reflect.TypeOf(states[0])
+		v := reflect.New(typ)
+		setPtr := v.MethodByName("SetPtr")
+		ptrType := setPtr.Type().In(0)
+
+		v.Elem().Set(reflect.ValueOf(states[2]))
+		setPtr.Call([]reflect.Value{reflect.Zero(ptrType)})
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, states[1]) {
+			t.Errorf("%s: SetPtr(nil) = %#v, want %#v", typ, got, states[1])
+		}
+
+		// The payload is the first field; pointer payloads are passed as is.
+		payload := reflect.ValueOf(states[2]).Field(0)
+		p := payload
+		if payload.Type() != ptrType {
+			p = reflect.New(payload.Type())
+			p.Elem().Set(payload)
+		}
+		v.Elem().Set(reflect.ValueOf(states[0]))
+		setPtr.Call([]reflect.Value{p})
+		if got := v.Elem().Interface(); !reflect.DeepEqual(got, states[2]) {
+			t.Errorf("%s: SetPtr(%v) = %#v, want %#v", typ, p, got, states[2])
+		}
+	}
+}
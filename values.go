@@ -0,0 +1,97 @@
+package null
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Values returns the fields of v, a struct or a pointer to one, that hold a
+// set Value, keyed by column name. A valid field maps to its payload, such
+// as a string for a String, and an explicit null to nil, while unset fields
+// are left out. This is the argument of a partial INSERT or UPDATE with
+// sqlx's named queries, whose column list SetColumns gives:
+//
+//	args, err := null.Values(patch, "db")
+//	_, err = db.NamedExec(query, args)
+//
+// Fields are named and walked as by SetColumns, embedded structs and
+// pointers to Values included, and the reflection work is shared with it.
+// A Value of another package maps to itself when valid. A nil pointer
+// yields an empty map.
+func Values(v interface{}, tag string) (map[string]interface{}, error) {
+	return ValuesOptions{}.Values(v, tag)
+}
+
+// ValuesOptions changes what Values includes.
+type ValuesOptions struct {
+	// IncludeUnset maps unset fields, nil pointers to a Value among them,
+	// to nil instead of leaving them out, for an INSERT of every column.
+	IncludeUnset bool
+	// Skip lists column names to leave out, such as a primary key.
+	Skip []string
+}
+
+// Values is like the Values function, with the options in o.
+func (o ValuesOptions) Values(v interface{}, tag string) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return map[string]interface{}{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("null: cannot list the values of %T, it is not a struct", v)
+	}
+	values := make(map[string]interface{})
+columns:
+	for _, c := range columnsFor(rv.Type(), tag) {
+		for _, skip := range o.Skip {
+			if c.name == skip {
+				continue columns
+			}
+		}
+		x, set := columnValue(rv, c)
+		if set || o.IncludeUnset {
+			values[c.name] = x
+		}
+	}
+	return values, nil
+}
+
+// columnValue returns the payload of column c of v, or nil if it is null,
+// and whether it is set.
+func columnValue(v reflect.Value, c column) (interface{}, bool) {
+	f, ok := fieldByIndex(v, c.index)
+	if !ok {
+		return nil, false
+	}
+	if c.valid != nil {
+		return nullValue(f, c.payload, c.valid, c.set)
+	}
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return nil, false
+		}
+		if payload, valid, set, ok := nullFields(f.Type().Elem()); ok {
+			return nullValue(f.Elem(), payload, valid, set)
+		}
+	}
+	x := f.Interface().(Value)
+	if !x.IsValid() {
+		return nil, x.IsSet()
+	}
+	return x, true
+}
+
+// nullValue is columnValue for f, one of this package's types with the
+// given fields. As with IsValid, a Valid flag without Set yields nothing.
+func nullValue(f reflect.Value, payload, valid, set []int) (interface{}, bool) {
+	if !f.FieldByIndex(set).Bool() {
+		return nil, false
+	}
+	if !f.FieldByIndex(valid).Bool() {
+		return nil, true
+	}
+	return f.FieldByIndex(payload).Interface(), true
+}
@@ -0,0 +1,66 @@
+package null
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValues(t *testing.T) {
+	age := IntFrom(3)
+	score := NewInt(0, false)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	patch := columnsPatch{
+		ColumnsBase: &ColumnsBase{ID: Int64From(1), Created: TimeFrom(created), Skipped: StringFrom("x")},
+		Name:        StringFrom("Alice"),
+		Email:       NewString("", false),
+		Age:         &age,
+		Score:       &score,
+		Status:      EnumFrom(statusActive),
+		Flag:        columnsFlag(true),
+		Comment:     "not a Value",
+	}
+	got, err := Values(&patch, "db")
+	maybePanic(err)
+	want := map[string]interface{}{
+		"id":         int64(1),
+		"created_at": created,
+		"name":       "Alice",
+		"Email":      nil,
+		"age":        3,
+		"score":      nil,
+		"status":     statusActive,
+		"flag":       columnsFlag(true),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values = %#v, want %#v", got, want)
+	}
+
+	got, err = ValuesOptions{IncludeUnset: true, Skip: []string{"id", "flag"}}.Values(patch, "db")
+	maybePanic(err)
+	want["point"] = nil
+	delete(want, "id")
+	delete(want, "flag")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values including unset = %#v, want %#v", got, want)
+	}
+
+	// The fields of a nil embedded pointer are unset.
+	got, err = ValuesOptions{IncludeUnset: true}.Values(columnsPatch{Age: &age}, "json")
+	maybePanic(err)
+	want = map[string]interface{}{
+		"ID": nil, "Created": nil, "Name": nil, "email": nil, "Age": 3,
+		"Skipped": nil, "Score": nil, "Status": nil, "Point": nil, "Flag": nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values of a nil embedded pointer = %#v, want %#v", got, want)
+	}
+
+	if got, err := Values((*columnsPatch)(nil), "db"); err != nil || len(got) != 0 {
+		t.Errorf("Values(nil) = %#v, %v", got, err)
+	}
+	if _, err := Values(42, "db"); err == nil || !strings.Contains(err.Error(), "not a struct") {
+		t.Errorf("Values(42) = %v, want an error", err)
+	}
+}
@@ -0,0 +1,59 @@
+package null
+
+import "encoding/xml"
+
+// The XML methods encode a valid value as character data, using the same
+// text as MarshalText, and a null value as an empty element. encoding/xml
+// never treats a struct as empty, so omitempty has no effect on these types
+// as elements; use them as attributes, which are left out when null, or as
+// pointers to drop null elements entirely.
+//
+// An empty element or attribute unmarshals to null with Set true. A missing
+// one is never decoded, so Set stays false.
+
+// marshalXML encodes the text of a valid value as the content of start, or
+// an empty element if valid is false.
+func marshalXML(enc *xml.Encoder, start xml.StartElement, valid bool, text func() ([]byte, error)) error {
+	if !valid {
+		return enc.EncodeElement("", start)
+	}
+	data, err := text()
+	if err != nil {
+		return err
+	}
+	return enc.EncodeElement(string(data), start)
+}
+
+// unmarshalXML decodes the character data of start and passes it to
+// fromText. An empty element passes empty text.
+func unmarshalXML(dec *xml.Decoder, start xml.StartElement, fromText func([]byte) error) error {
+	var str string
+	if err := dec.DecodeElement(&str, &start); err != nil {
+		return err
+	}
+	return fromText([]byte(str))
+}
+
+// marshalXMLAttr returns an attribute holding the text of a valid value.
+// A null value returns the zero xml.Attr, which encoding/xml omits.
+func marshalXMLAttr(name xml.Name, valid bool, text func() ([]byte, error)) (xml.Attr, error) {
+	if !valid {
+		return xml.Attr{}, nil
+	}
+	data, err := text()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(data)}, nil
+}
+
+// jsonText adapts an UnmarshalJSON method to text input for the types
+// without a text form. Empty text unmarshals as JSON null.
+func jsonText(unmarshalJSON func([]byte) error) func([]byte) error {
+	return func(text []byte) error {
+		if len(text) == 0 {
+			return unmarshalJSON(NullBytes)
+		}
+		return unmarshalJSON(text)
+	}
+}
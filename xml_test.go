@@ -0,0 +1,187 @@
+package null
+
+import (
+	"encoding/xml"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXML(t *testing.T) {
+	u, _ := url.Parse("https://example.com/a?b=c")
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		valid interface{}
+		text  string
+	}{
+		{BoolFrom(true), "true"},
+		{ByteFrom('x'), "x"},
+		{RuneFrom('€'), "€"},
+		{BytesFrom([]byte("hello")), "aGVsbG8="},
+		{RawBytesFrom([]byte("hello")), "hello"},
+		{JSONFrom([]byte(`{"a":1}`)), `{"a":1}`},
+		{StringFrom("a <b> & c"), "a <b> & c"},
+		{IntFrom(-1), "-1"},
+		{Int8From(-8), "-8"},
+		{Int16From(-16), "-16"},
+		{Int32From(-32), "-32"},
+		{Int64From(-64), "-64"},
+		{UintFrom(1), "1"},
+		{Uint8From(8), "8"},
+		{Uint16From(16), "16"},
+		{Uint32From(32), "32"},
+		{Uint64From(18446744073709551615), "18446744073709551615"},
+		{Float32From(1.5), "1.5"},
+		{Float64From(-2.5), "-2.5"},
+		{TimeFrom(when), "2012-12-21T21:21:21Z"},
+		{DateOf(2012, 12, 21), "2012-12-21"},
+		{TimeOfDayOf(15, 4, 5, 0), "15:04:05"},
+		{DurationFrom(90 * time.Minute), "1h30m0s"},
+		{DecimalFrom("1.10"), "1.10"},
+		{UUIDFrom(uuidValue), uuidString},
+		{URLFrom(u), "https://example.com/a?b=c"},
+		{IPFrom(netip.MustParseAddr("fe80::1%eth0")), "fe80::1%eth0"},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8"},
+		{BigIntFrom(big.NewInt(-42)), "-42"},
+		{MapFrom(map[string]interface{}{"a": 1.0}), `{"a":1}`},
+		{StringSliceFrom([]string{"a", "b"}), `["a","b"]`},
+		{Int64SliceFrom([]int64{1, 2}), "[1,2]"},
+		{EnumFrom(statusActive), "active"},
+		{ValFrom(42), "42"},
+	}
+	start := xml.StartElement{Name: xml.Name{Local: "v"}}
+	for _, test := range tests {
+		typ := reflect.TypeOf(test.valid)
+
+		var sb strings.Builder
+		err := xml.NewEncoder(&sb).EncodeElement(test.valid, start)
+		maybePanic(err)
+		var esc strings.Builder
+		maybePanic(xml.EscapeText(&esc, []byte(test.text)))
+		if want := "<v>" + esc.String() + "</v>"; sb.String() != want {
+			t.Errorf("%T as XML element = %s, want %s", test.valid, sb.String(), want)
+		}
+
+		ptr := reflect.New(typ)
+		err = xml.Unmarshal([]byte(sb.String()), ptr.Interface())
+		maybePanic(err)
+		if back := ptr.Elem().Interface(); !reflect.DeepEqual(back, test.valid) {
+			t.Errorf("%T.UnmarshalXML(%s) = %#v, want %#v", test.valid, sb.String(), back, test.valid)
+		}
+
+		sb.Reset()
+		err = xml.NewEncoder(&sb).EncodeElement(reflect.Zero(typ).Interface(), start)
+		maybePanic(err)
+		if sb.String() != "<v></v>" {
+			t.Errorf("null %T as XML element = %s, want <v></v>", test.valid, sb.String())
+		}
+
+		ptr = reflect.New(typ)
+		err = xml.Unmarshal([]byte("<v/>"), ptr.Interface())
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalXML(<v/>) = %#v, want set null", test.valid, back.Interface())
+		}
+
+		name := xml.Name{Local: "a"}
+		attr, err := test.valid.(xml.MarshalerAttr).MarshalXMLAttr(name)
+		maybePanic(err)
+		if attr.Name != name || attr.Value != test.text {
+			t.Errorf("%T.MarshalXMLAttr() = %#v, want %q", test.valid, attr, test.text)
+		}
+
+		attr, err = reflect.Zero(typ).Interface().(xml.MarshalerAttr).MarshalXMLAttr(name)
+		maybePanic(err)
+		if attr != (xml.Attr{}) {
+			t.Errorf("null %T.MarshalXMLAttr() = %#v, want omitted", test.valid, attr)
+		}
+
+		ptr = reflect.New(typ)
+		err = ptr.Interface().(xml.UnmarshalerAttr).UnmarshalXMLAttr(xml.Attr{Name: name, Value: test.text})
+		maybePanic(err)
+		if back := ptr.Elem().Interface(); !reflect.DeepEqual(back, test.valid) {
+			t.Errorf("%T.UnmarshalXMLAttr(%q) = %#v, want %#v", test.valid, test.text, back, test.valid)
+		}
+
+		ptr = reflect.New(typ)
+		err = ptr.Interface().(xml.UnmarshalerAttr).UnmarshalXMLAttr(xml.Attr{Name: name})
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalXMLAttr(\"\") = %#v, want set null", test.valid, back.Interface())
+		}
+	}
+}
+
+type xmlRecord struct {
+	XMLName xml.Name `xml:"record"`
+	ID      Int64    `xml:"id,attr"`
+	Note    String   `xml:"note,attr"`
+	Name    String   `xml:"name"`
+	Age     Int      `xml:"age"`
+	Born    Time     `xml:"born"`
+	Point   Val[testPoint]
+}
+
+func TestXMLStruct(t *testing.T) {
+	in := xmlRecord{
+		ID:    Int64From(7),
+		Name:  StringFrom("Alice"),
+		Born:  TimeFrom(time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)),
+		Point: ValFrom(testPoint{1, 2}),
+	}
+	data, err := xml.Marshal(in)
+	maybePanic(err)
+	want := `<record id="7"><name>Alice</name><age></age><born>2012-12-21T21:21:21Z</born><Point><X>1</X><Y>2</Y></Point></record>`
+	if string(data) != want {
+		t.Errorf("xml.Marshal = %s, want %s", data, want)
+	}
+
+	var out xmlRecord
+	err = xml.Unmarshal(data, &out)
+	maybePanic(err)
+	in.XMLName = xml.Name{Local: "record"}
+	in.Age = NewInt(0, false)
+	in.Age.Set = true
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("xml round trip = %#v, want %#v", out, in)
+	}
+	if out.Note.Set {
+		t.Error("missing attribute should leave Set false")
+	}
+
+	var missing xmlRecord
+	err = xml.Unmarshal([]byte(`<record></record>`), &missing)
+	maybePanic(err)
+	if missing.Name.Set || missing.Point.Set {
+		t.Errorf("missing elements should leave Set false: %#v", missing)
+	}
+}
+
+func TestXMLErrors(t *testing.T) {
+	tests := []struct {
+		in   interface{}
+		text string
+	}{
+		{&Int64{}, "abc"},
+		{&Bytes{}, "not base64!"},
+		{&UUID{}, "abc"},
+		{&Enum[testStatus]{}, "deleted"},
+		{&Map{}, "[1]"},
+		{&Val[int]{}, "abc"},
+	}
+	for _, test := range tests {
+		if err := xml.Unmarshal([]byte("<v>"+test.text+"</v>"), test.in); err == nil {
+			t.Errorf("%T.UnmarshalXML(%q) should fail", test.in, test.text)
+		}
+		if reflect.ValueOf(test.in).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalXML", test.in)
+		}
+		if err := test.in.(xml.UnmarshalerAttr).UnmarshalXMLAttr(xml.Attr{Value: test.text}); err == nil {
+			t.Errorf("%T.UnmarshalXMLAttr(%q) should fail", test.in, test.text)
+		}
+	}
+}
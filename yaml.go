@@ -0,0 +1,63 @@
+package null
+
+import "fmt"
+
+// The YAML methods implement the yaml.Marshaler interface and the
+// func-style yaml.Unmarshaler interface shared by gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, so the package needs no YAML dependency.
+//
+// Both YAML packages skip unmarshalers for null and ~, leaving the value
+// untouched, so an explicit null cannot be told apart from an absent key:
+// either way Set stays false and Valid false.
+
+// yamlIsNull decodes the node behind unmarshal into an interface{} and
+// reports whether it is null or ~.
+func yamlIsNull(unmarshal func(interface{}) error) (bool, error) {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return false, err
+	}
+	return v == nil, nil
+}
+
+// yamlToJSONValue converts the map[interface{}]interface{} values that
+// gopkg.in/yaml.v2 produces into map[string]interface{}, so the result can
+// be encoded as JSON.
+func yamlToJSONValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("null: cannot convert YAML key %v of type %T to a JSON object key", k, k)
+			}
+			ev, err := yamlToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			res[ks] = ev
+		}
+		return res, nil
+	case map[string]interface{}:
+		for k, e := range x {
+			ev, err := yamlToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			x[k] = ev
+		}
+		return x, nil
+	case []interface{}:
+		for i, e := range x {
+			ev, err := yamlToJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = ev
+		}
+		return x, nil
+	default:
+		return v, nil
+	}
+}
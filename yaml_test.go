@@ -0,0 +1,174 @@
+package null
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type yamlMarshaler interface {
+	MarshalYAML() (interface{}, error)
+}
+
+type yamlUnmarshaler interface {
+	UnmarshalYAML(func(interface{}) error) error
+}
+
+// yamlInput returns an unmarshal func like the YAML packages pass to
+// UnmarshalYAML, decoding v into the target as a YAML decoder would.
+func yamlInput(v interface{}) func(interface{}) error {
+	return func(out interface{}) error {
+		rv := reflect.ValueOf(out).Elem()
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if s, ok := v.(string); ok && rv.Kind() == reflect.String {
+			rv.SetString(s)
+			return nil
+		}
+		if rv.Kind() == reflect.Interface || reflect.TypeOf(v).AssignableTo(rv.Type()) {
+			rv.Set(reflect.ValueOf(v))
+			return nil
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, out)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	when := time.Date(2012, 12, 21, 21, 21, 21, 0, time.UTC)
+	tests := []struct {
+		valid yamlMarshaler
+		yaml  interface{}
+	}{
+		{BoolFrom(true), true},
+		{StringFrom("hello"), "hello"},
+		{IntFrom(-1), -1},
+		{Int8From(-8), int8(-8)},
+		{Int16From(-16), int16(-16)},
+		{Int32From(-32), int32(-32)},
+		{Int64From(-64), int64(-64)},
+		{UintFrom(1), uint(1)},
+		{Uint8From(8), uint8(8)},
+		{Uint16From(16), uint16(16)},
+		{Uint32From(32), uint32(32)},
+		{Uint64From(18446744073709551615), uint64(18446744073709551615)},
+		{Float32From(1.5), float32(1.5)},
+		{Float64From(-2.5), float64(-2.5)},
+		{TimeFrom(when), when},
+		{ByteFrom('x'), "x"},
+		{RuneFrom('€'), "€"},
+		{BytesFrom([]byte("hello")), "aGVsbG8="},
+		{RawBytesFrom([]byte("hello")), "hello"},
+		{JSONFrom([]byte(`{"a":[1,"b"]}`)), map[string]interface{}{"a": []interface{}{1.0, "b"}}},
+		{DecimalFrom("1.10"), "1.10"},
+		{DurationFrom(90 * time.Minute), "1h30m0s"},
+		{UUIDFrom(uuidValue), uuidString},
+		{IPFrom(netip.MustParseAddr("fe80::1%eth0")), "fe80::1%eth0"},
+		{CIDRFrom(netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8"},
+		{BigIntFrom(big.NewInt(-42)), "-42"},
+		{DateOf(2012, 12, 21), "2012-12-21"},
+		{TimeOfDayOf(15, 4, 5, 0), "15:04:05"},
+		{MapFrom(map[string]interface{}{"a": 1.0}), map[string]interface{}{"a": 1.0}},
+		{StringSliceFrom([]string{"a", "b"}), []string{"a", "b"}},
+		{Int64SliceFrom([]int64{1, 2}), []int64{1, 2}},
+		{EnumFrom(statusActive), "active"},
+		{ValFrom(testPoint{1, 2}), testPoint{1, 2}},
+	}
+	for _, test := range tests {
+		got, err := test.valid.MarshalYAML()
+		maybePanic(err)
+		if !reflect.DeepEqual(got, test.yaml) {
+			t.Errorf("%T.MarshalYAML() = %#v, want %#v", test.valid, got, test.yaml)
+		}
+
+		typ := reflect.TypeOf(test.valid)
+		null, err := reflect.Zero(typ).Interface().(yamlMarshaler).MarshalYAML()
+		maybePanic(err)
+		if null != nil {
+			t.Errorf("null %T.MarshalYAML() = %#v, want nil", test.valid, null)
+		}
+
+		ptr := reflect.New(typ)
+		err = ptr.Interface().(yamlUnmarshaler).UnmarshalYAML(yamlInput(test.yaml))
+		maybePanic(err)
+		if back := ptr.Elem().Interface(); !reflect.DeepEqual(back, test.valid) {
+			t.Errorf("%T.UnmarshalYAML(%#v) = %#v, want %#v", test.valid, test.yaml, back, test.valid)
+		}
+
+		ptr = reflect.New(typ)
+		err = ptr.Interface().(yamlUnmarshaler).UnmarshalYAML(yamlInput(nil))
+		maybePanic(err)
+		if back := ptr.Elem(); back.FieldByName("Valid").Bool() || !back.FieldByName("Set").Bool() {
+			t.Errorf("%T.UnmarshalYAML(null) = %#v, want set null", test.valid, back.Interface())
+		}
+	}
+}
+
+func TestYAMLEmpty(t *testing.T) {
+	tests := []struct {
+		in   yamlMarshaler
+		want interface{}
+	}{
+		{MapFrom(nil), map[string]interface{}{}},
+		{StringSliceFrom(nil), []string{}},
+		{Int64SliceFrom(nil), []int64{}},
+		{BytesFrom([]byte{}), nil},
+	}
+	for _, test := range tests {
+		got, err := test.in.MarshalYAML()
+		maybePanic(err)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%T.MarshalYAML() = %#v, want %#v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestYAMLv2Maps(t *testing.T) {
+	in := map[interface{}]interface{}{"a": map[interface{}]interface{}{"b": 1}}
+
+	var j JSON
+	err := j.UnmarshalYAML(yamlInput(in))
+	maybePanic(err)
+	assertJSONEquals(t, j.JSON, `{"a":{"b":1}}`, "yaml.v2 map into JSON")
+
+	var m Map
+	err = m.UnmarshalYAML(yamlInput(map[string]interface{}{"a": map[interface{}]interface{}{"b": 1}}))
+	maybePanic(err)
+	data, err := json.Marshal(m)
+	maybePanic(err)
+	assertJSONEquals(t, data, `{"a":{"b":1}}`, "yaml.v2 map into Map")
+
+	var bad JSON
+	if err := bad.UnmarshalYAML(yamlInput(map[interface{}]interface{}{1: "a"})); err == nil {
+		t.Error("expected error for non-string key")
+	}
+}
+
+func TestYAMLErrors(t *testing.T) {
+	tests := []struct {
+		in   yamlUnmarshaler
+		yaml interface{}
+	}{
+		{&Int64{}, "abc"},
+		{&Bytes{}, "not base64!"},
+		{&UUID{}, "abc"},
+		{&Enum[testStatus]{}, "deleted"},
+		{&Date{}, "2012-12-21T00:00:00Z"},
+	}
+	for _, test := range tests {
+		if err := test.in.UnmarshalYAML(yamlInput(test.yaml)); err == nil {
+			t.Errorf("%T.UnmarshalYAML(%#v) should fail", test.in, test.yaml)
+		}
+		if reflect.ValueOf(test.in).Elem().FieldByName("Valid").Bool() {
+			t.Errorf("%T should be invalid after a failed UnmarshalYAML", test.in)
+		}
+	}
+}